@@ -1,28 +1,64 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
+	"log/slog"
 	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
 
 	_config "macaiki/config"
 	_communityHttpDelivery "macaiki/internal/community/delivery/http"
 	_communityRepo "macaiki/internal/community/repository/mysql"
 	_communityUsecase "macaiki/internal/community/usecase"
 	_driver "macaiki/internal/driver"
+	_export "macaiki/internal/export"
+	_exportHttpDelivery "macaiki/internal/export/delivery/http"
+	_exportRepo "macaiki/internal/export/repository/mysql"
+	_exportUsecase "macaiki/internal/export/usecase"
+	_feedHttpDelivery "macaiki/internal/feed/delivery/http"
+	_feedRepo "macaiki/internal/feed/repository/mysql"
+	_feedUsecase "macaiki/internal/feed/usecase"
+	_health "macaiki/internal/health"
+	_healthHttpDelivery "macaiki/internal/health/delivery/http"
+	_healthUsecase "macaiki/internal/health/usecase"
+	_identity "macaiki/internal/identity"
+	_identityHttpDelivery "macaiki/internal/identity/delivery/http"
+	_identityRepo "macaiki/internal/identity/repository/mysql"
+	_identityUsecase "macaiki/internal/identity/usecase"
+	_jobHttpDelivery "macaiki/internal/job/delivery/http"
+	_jobRepo "macaiki/internal/job/repository/mysql"
+	_jobRunner "macaiki/internal/job/runner"
+	_jobUsecase "macaiki/internal/job/usecase"
+	_notification "macaiki/internal/notification"
 	_notificationHttpDelivery "macaiki/internal/notification/delivery/http"
 	_notificationRepo "macaiki/internal/notification/repository"
 	_notificationUsecase "macaiki/internal/notification/usecase"
 	_reportCategoryHttpDeliver "macaiki/internal/report_category/delivery/http"
 	_reportCategoryRepo "macaiki/internal/report_category/repository/mysql"
 	_reportCategoryUsecase "macaiki/internal/report_category/usecase"
+	_thread "macaiki/internal/thread"
 	_threadHttpDelivery "macaiki/internal/thread/delivery/http"
 	_threadRepo "macaiki/internal/thread/repository/mysql"
 	_threadUsecase "macaiki/internal/thread/usecase"
+	_user "macaiki/internal/user"
 	_userHttpDelivery "macaiki/internal/user/delivery/http"
 	_userRepo "macaiki/internal/user/repository/mysql"
 	_userUsecase "macaiki/internal/user/usecase"
 	_cloudstorage "macaiki/pkg/cloud_storage"
-	_gomail "macaiki/pkg/gomail"
+	_logger "macaiki/pkg/logger"
+	_mailer "macaiki/pkg/mailer"
+	_metrics "macaiki/pkg/metrics"
+	_middL "macaiki/pkg/middleware"
+	_oidc "macaiki/pkg/oidc"
+	_ratelimit "macaiki/pkg/ratelimit"
+	_tracing "macaiki/pkg/tracing"
+	_unfurl "macaiki/pkg/unfurl"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
@@ -34,6 +70,21 @@ func main() {
 	if err != nil {
 		log.Fatal("err", err)
 	}
+
+	appLogger := _logger.New(config.Debug)
+	slog.SetDefault(appLogger)
+
+	shutdownTracing, err := _tracing.Init(context.Background(), _tracing.Config{
+		Exporter:    config.TraceExporter,
+		Endpoint:    config.TraceEndpoint,
+		Insecure:    config.TraceInsecure,
+		ServiceName: config.TraceServiceName,
+		SampleRatio: config.TraceSampleRatio,
+	})
+	if err != nil {
+		fatal(appLogger, "failed to initialize tracing", err)
+	}
+
 	_driver.ConnectDB(
 		config.DBConn,
 		config.DBHost,
@@ -41,49 +92,207 @@ func main() {
 		config.DBUser,
 		config.DBPass,
 		config.DBName,
+		appLogger,
 	)
 
 	e := echo.New()
+	e.HideBanner = true
 	e.GET("/", func(c echo.Context) error {
 		return c.String(http.StatusOK, "Hello World!")
 	})
+	e.GET("/metrics", _metrics.Handler())
 	v := validator.New()
 
 	s3Instance := _cloudstorage.CreateNewS3Instance(config.AWSAccessKeyId, config.AWSSecretKey, config.AWSRegion, config.BucketName)
 
-	goMail := _gomail.NewGomail(config.GomailEmail, config.GomailPassword)
+	smtpMailer := _mailer.NewSMTPMailer(_mailer.SMTPConfig{
+		Host:       config.MailSMTPHost,
+		Port:       config.MailSMTPPort,
+		Username:   config.GomailEmail,
+		Password:   config.GomailPassword,
+		From:       config.MailFrom,
+		TLSMode:    config.MailSMTPTLS,
+		SkipVerify: config.MailSMTPSkipVerify,
+	})
+	mailTemplates, err := _mailer.LoadTemplates()
+	if err != nil {
+		fatal(appLogger, "failed to load mail templates", err)
+	}
+	mailOutbox := _mailer.NewDBOutbox(_driver.DB, mailTemplates)
+	mailWorker := _mailer.NewWorker(_driver.DB, smtpMailer, _mailer.DefaultWorkerConfig(), appLogger.With("component", "mail_worker"))
+
 	// setup Repo
-	userRepo := _userRepo.NewMysqlUserRepository(_driver.DB)
+	userRepo := _userRepo.NewMysqlUserRepository(_driver.DB, appLogger)
 	reportCategoryRepo := _reportCategoryRepo.NewReportCategoryRepository(_driver.DB)
-	threadRepo := _threadRepo.CreateNewThreadRepository(_driver.DB)
-	communityRepo := _communityRepo.NewCommunityRepository(_driver.DB)
+	threadRepo := _threadRepo.CreateNewThreadRepository(_driver.DB, appLogger)
+	communityRepo := _communityRepo.NewCommunityRepository(_driver.DB, appLogger)
 	notificationRepo := _notificationRepo.NewNotificaionRepository(_driver.DB)
+	jobRepo := _jobRepo.NewJobRepository(_driver.DB, appLogger)
+	identityRepo := _identityRepo.NewIdentityRepository(_driver.DB, appLogger)
+	exportRepo := _exportRepo.NewExportRepository(_driver.DB, appLogger)
+	feedRepo := _feedRepo.NewFeedRepository(_driver.DB, appLogger)
+
+	// setup identity providers
+	identityProviders := []_identity.Provider{}
+	if config.OIDCIssuerURL != "" {
+		identityProviders = append(identityProviders, _oidc.NewProvider(_oidc.Config{
+			Name:         config.OIDCProviderName,
+			IssuerURL:    config.OIDCIssuerURL,
+			ClientID:     config.OIDCClientID,
+			ClientSecret: config.OIDCClientSecret,
+			RedirectURL:  config.OIDCRedirectURL,
+			Scopes:       strings.Fields(config.OIDCScopes),
+		}))
+	}
+
+	// setup job runner
+	jobRunnerConfig := _jobRunner.DefaultConfig()
+	jobRunnerConfig.Workers = config.JobWorkers
+	jobRunnerConfig.MaxAttempts = config.JobMaxAttempts
+	if err := jobRunnerConfig.Validate(); err != nil {
+		fatal(appLogger, "invalid job runner config", err)
+	}
+	jobRunner := _jobRunner.NewRunner(jobRepo, jobRunnerConfig, appLogger.With("component", "job_runner"))
+	jobRunner.Register(_notification.JobStoreNotification, _notificationUsecase.NewStoreNotificationHandler(notificationRepo))
+	jobRunner.Register(_cloudstorage.JobDeleteImage, s3Instance.HandleDeleteImage)
+
+	// setup rate limiting
+	rateLimitStore := _ratelimit.NewMemoryStore()
+	lockout := _ratelimit.NewLockout(rateLimitStore, _ratelimit.LockoutPolicy{
+		MaxFailures: config.LockoutMaxFailure,
+		Window:      config.LockoutWindow,
+		Duration:    config.LockoutDuration,
+	})
 
 	// setup usecase
-	userUsecase := _userUsecase.NewUserUsecase(userRepo, reportCategoryRepo, communityRepo, notificationRepo, threadRepo, v, s3Instance, goMail)
+	sessionIssuer := _userUsecase.NewSessionIssuer(userRepo, appLogger)
+	userUsecase := _userUsecase.NewUserUsecase(userRepo, reportCategoryRepo, communityRepo, notificationRepo, threadRepo, v, s3Instance, mailOutbox, jobRunner, lockout, sessionIssuer, config.AppBaseURL, appLogger)
+	jobRunner.Register(_user.JobPurgeUser, _userUsecase.NewPurgeUserHandler(userUsecase))
+	twoFactorUsecase := _userUsecase.NewTwoFactorUsecase(userRepo, v, lockout, config.TOTPIssuer, appLogger)
 	reportCategoryUsecase := _reportCategoryUsecase.NewReportCategoryUsecase(reportCategoryRepo, v)
-	threadUseCase := _threadUsecase.CreateNewThreadUseCase(threadRepo, notificationRepo, s3Instance)
-	communityUsecase := _communityUsecase.NewCommunityUsecase(communityRepo, userRepo, reportCategoryRepo, threadRepo, v, s3Instance)
-	notificationUsecase := _notificationUsecase.NewNotificationUsecase(notificationRepo, userRepo, threadRepo)
+	postingPolicy := _userUsecase.NewPostingPolicy(userRepo, config.RequireVerifiedEmail)
+	rankingConfig := _thread.RankingConfig{
+		Gravity:         config.RankingGravity,
+		DownvotePenalty: config.RankingDownvotePenalty,
+		CommentWeight:   config.RankingCommentWeight,
+		RefreshInterval: config.RankingRefreshInterval,
+	}
+	previews := _unfurl.NewCache(_unfurl.NewFetcher(_unfurl.Config{
+		Timeout:  config.UnfurlTimeout,
+		MaxBytes: config.UnfurlMaxBytes,
+	}), config.UnfurlCacheTTL, 1000)
+	threadUseCase := _threadUsecase.CreateNewThreadUseCase(threadRepo, notificationRepo, s3Instance, jobRunner, postingPolicy, rankingConfig, previews, v, appLogger)
+	jobRunner.Register(_thread.JobPublishThread, _threadUsecase.NewPublishThreadHandler(threadUseCase))
+	jobRunner.Register(_thread.JobUnfurlThread, _threadUsecase.NewUnfurlThreadHandler(threadUseCase))
+	jobRunner.Register(_thread.JobNotifyFollowers, _threadUsecase.NewNotifyFollowersHandler(threadUseCase))
+	communityUsecase := _communityUsecase.NewCommunityUsecase(communityRepo, userRepo, reportCategoryRepo, threadRepo, v, s3Instance, jobRunner, appLogger)
+	notificationUsecase := _notificationUsecase.NewNotificationUsecase(notificationRepo, userRepo, threadRepo, appLogger)
+	jobUsecase := _jobUsecase.NewJobUsecase(jobRepo)
+	identityUsecase := _identityUsecase.NewIdentityUsecase(identityRepo, userRepo, sessionIssuer, identityProviders, v, appLogger)
+	exportUsecase := _exportUsecase.NewExportUsecase(exportRepo, s3Instance, mailOutbox, jobRunner, config.ExportLinkTTL, appLogger)
+	feedUsecase := _feedUsecase.NewFeedUsecase(feedRepo, v, rankingConfig, appLogger)
+	jobRunner.Register(_export.JobBuildExport, _exportUsecase.NewBuildExportHandler(exportUsecase))
+	jobRunner.Register(_export.JobExpireExport, _exportUsecase.NewExpireExportHandler(exportUsecase))
+	healthUsecase := _healthUsecase.NewHealthUsecase(map[string]_health.Checker{
+		"database":   _health.CheckerFunc(_driver.PingDB(_driver.DB)),
+		"migrations": _health.CheckerFunc(_driver.CheckMigrations(_driver.DB)),
+		"storage":    _health.CheckerFunc(s3Instance.CheckWritable),
+	}, config.ReadinessTimeout, appLogger)
 
 	// setup middleware
 	JWTSecret, err := _config.LoadJWTSecret(".")
 	if err != nil {
-		log.Fatal("err", err)
+		fatal(appLogger, "failed to load jwt secret", err)
 	}
 
 	// setup route
 	_userHttpDelivery.NewUserHandler(e, userUsecase, JWTSecret.Secret)
+	_userHttpDelivery.NewTwoFactorHandler(e, twoFactorUsecase, JWTSecret.Secret)
 	_ = _threadHttpDelivery.CreateNewThreadHandler(e, threadUseCase, JWTSecret.Secret)
 	_reportCategoryHttpDeliver.NewReportCategoryHandler(e, reportCategoryUsecase, JWTSecret.Secret)
 	_communityHttpDelivery.NewCommunityHandler(e, communityUsecase, JWTSecret.Secret)
 	_notificationHttpDelivery.NewNotificationHandler(e, notificationUsecase, JWTSecret.Secret)
+	_jobHttpDelivery.NewJobHandler(e, jobUsecase, JWTSecret.Secret)
+	_identityHttpDelivery.NewIdentityHandler(e, identityUsecase, JWTSecret.Secret)
+	_exportHttpDelivery.NewExportHandler(e, exportUsecase, JWTSecret.Secret)
+	_feedHttpDelivery.NewFeedHandler(e, feedUsecase, JWTSecret.Secret)
+	_healthHttpDelivery.NewHealthHandler(e, healthUsecase)
 
 	// setup middleware
-	e.Use(middleware.LoggerWithConfig(middleware.LoggerConfig{
-		Format: "method=${method}, uri=${uri}, status=${status}\n",
-	}))
+	e.Use(_logger.RequestIDMiddleware())
+	e.Use(_tracing.Middleware())
+	e.Use(_metrics.Middleware())
+	e.Use(_logger.RequestLoggerMiddleware(appLogger))
+	if config.RateLimitEnabled {
+		rules, err := rateLimitRules(config, JWTSecret.Secret)
+		if err != nil {
+			fatal(appLogger, "invalid rate limit policy", err)
+		}
+		e.Use(_ratelimit.Middleware(rateLimitStore, rules, appLogger))
+	}
+	e.Use(_middL.ContextTimeout(config.DBTimeout))
 	e.Use(middleware.CORS())
 
-	log.Fatal(e.Start(":" + config.ServerPort))
+	// setup background workers
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	var workers sync.WaitGroup
+	workers.Add(3)
+	go func() {
+		defer workers.Done()
+		mailWorker.Run(workerCtx)
+	}()
+	go func() {
+		defer workers.Done()
+		jobRunner.Run(workerCtx)
+	}()
+	go func() {
+		defer workers.Done()
+		_threadUsecase.RunThreadRanking(workerCtx, threadUseCase, rankingConfig.RefreshInterval, appLogger.With("component", "thread_ranking"))
+	}()
+
+	go func() {
+		if err := e.Start(":" + config.ServerPort); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fatal(appLogger, "http server failed", err)
+		}
+	}()
+
+	// wait for SIGINT/SIGTERM, then drain requests and workers
+	signalCtx, stopSignals := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stopSignals()
+	<-signalCtx.Done()
+
+	appLogger.Info("shutting down", "timeout", config.ShutdownTimeout)
+	healthUsecase.MarkShuttingDown()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), config.ShutdownTimeout)
+	defer cancel()
+
+	if err := e.Shutdown(shutdownCtx); err != nil {
+		appLogger.Error("http server shutdown", "err", err)
+	}
+
+	stopWorkers()
+	drained := make(chan struct{})
+	go func() {
+		workers.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+	case <-shutdownCtx.Done():
+		appLogger.Warn("shutdown timed out, background workers still running")
+	}
+
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		appLogger.Error("tracing shutdown", "err", err)
+	}
+	appLogger.Info("shutdown complete")
+}
+
+func fatal(l *slog.Logger, msg string, err error) {
+	l.Error(msg, "err", err)
+	os.Exit(1)
 }
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"net/http"
+
+	_config "macaiki/config"
+	_middL "macaiki/pkg/middleware"
+	_ratelimit "macaiki/pkg/ratelimit"
+)
+
+// rateLimitRules maps the configured policies onto routes. Unauthenticated
+// endpoints are limited per client address, write endpoints per user.
+func rateLimitRules(config _config.Config, jwtSecret string) ([]_ratelimit.Rule, error) {
+	policies := map[string]string{
+		"auth":    config.RateLimitAuth,
+		"otp":     config.RateLimitOTP,
+		"thread":  config.RateLimitThread,
+		"comment": config.RateLimitComment,
+		"report":  config.RateLimitReport,
+		"export":  config.RateLimitExport,
+	}
+	parsed := map[string]_ratelimit.Policy{}
+	for name, policy := range policies {
+		p, err := _ratelimit.ParsePolicy(policy)
+		if err != nil {
+			return nil, err
+		}
+		parsed[name] = p
+	}
+
+	byUser := _ratelimit.ByUser(_middL.TokenUserID(jwtSecret))
+
+	return []_ratelimit.Rule{
+		{Method: http.MethodPost, Path: "/api/v1/login", Policy: parsed["auth"], Key: _ratelimit.ByIP},
+		{Method: http.MethodPost, Path: "/api/v1/register", Policy: parsed["auth"], Key: _ratelimit.ByIP},
+		{Method: http.MethodPost, Path: "/api/v1/login/two-factor", Policy: parsed["auth"], Key: _ratelimit.ByIP},
+		{Method: http.MethodPost, Path: "/api/v1/login/two-factor/setup", Policy: parsed["auth"], Key: _ratelimit.ByIP},
+		{Method: http.MethodGet, Path: "/api/v1/auth/providers/:provider", Policy: parsed["auth"], Key: _ratelimit.ByIP},
+		{Method: http.MethodPost, Path: "/api/v1/auth/providers/:provider/callback", Policy: parsed["auth"], Key: _ratelimit.ByIP},
+		{Method: http.MethodPost, Path: "/api/v1/curent-user/email-verification", Policy: parsed["otp"], Key: _ratelimit.ByIP},
+		{Method: http.MethodPost, Path: "/api/v1/curent-user/email-verification/verify", Policy: parsed["otp"], Key: _ratelimit.ByIP},
+		// drafts are free, a thread counts when it is posted or published
+		{Method: http.MethodPost, Path: "/api/v1/threads", Policy: parsed["thread"], Key: byUser, Bucket: "thread"},
+		{Method: http.MethodPost, Path: "/api/v1/threads/:threadID/publish", Policy: parsed["thread"], Key: byUser, Bucket: "thread"},
+		{Method: http.MethodPost, Path: "/api/v1/threads/:threadID/comments", Policy: parsed["comment"], Key: byUser},
+		{Method: http.MethodPost, Path: "/api/v1/threads/:threadID/reports", Policy: parsed["report"], Key: byUser},
+		{Method: http.MethodPost, Path: "/api/v1/threads/:threadID/comments/:commentID/reports", Policy: parsed["report"], Key: byUser},
+		{Method: http.MethodPost, Path: "/api/v1/users/:userID/report", Policy: parsed["report"], Key: byUser},
+		{Method: http.MethodPost, Path: "/api/v1/communities/:communityID/reports", Policy: parsed["report"], Key: byUser},
+		{Method: http.MethodPost, Path: "/api/v1/curent-user/exports", Policy: parsed["export"], Key: byUser},
+	}, nil
+}
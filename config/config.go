@@ -1,13 +1,18 @@
 package config
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	Debug      string `mapstructure:"DEBUG"`
+	Debug      string `mapstructure:"APP_DEBUG"`
 	ServerHost string `mapstructure:"APP_SERVER_HOST"`
 	ServerPort string `mapstructure:"APP_SERVER_PORT"`
+	// AppBaseURL is the public URL of the client, used to build emailed links
+	AppBaseURL string `mapstructure:"APP_BASE_URL"`
 
 	DBConn string `mapstructure:"DB_CONNECTION"`
 	DBHost string `mapstructure:"DB_HOST"`
@@ -23,6 +28,64 @@ type Config struct {
 
 	GomailEmail    string `mapstructure:"GOMAIL_EMAIL"`
 	GomailPassword string `mapstructure:"GOMAIL_PASSWORD"`
+
+	MailSMTPHost       string `mapstructure:"MAIL_SMTP_HOST"`
+	MailSMTPPort       int    `mapstructure:"MAIL_SMTP_PORT"`
+	MailSMTPTLS        string `mapstructure:"MAIL_SMTP_TLS"`
+	MailSMTPSkipVerify bool   `mapstructure:"MAIL_SMTP_SKIP_VERIFY"`
+	MailFrom           string `mapstructure:"MAIL_FROM"`
+
+	JobWorkers     int `mapstructure:"JOB_WORKERS"`
+	JobMaxAttempts int `mapstructure:"JOB_MAX_ATTEMPTS"`
+
+	RequireVerifiedEmail bool `mapstructure:"REQUIRE_VERIFIED_EMAIL"`
+
+	// OIDC login is enabled when an issuer URL is set, any OpenID Connect
+	// provider with discovery works including a local mock server
+	OIDCProviderName string `mapstructure:"OIDC_PROVIDER_NAME"`
+	OIDCIssuerURL    string `mapstructure:"OIDC_ISSUER_URL"`
+	OIDCClientID     string `mapstructure:"OIDC_CLIENT_ID"`
+	OIDCClientSecret string `mapstructure:"OIDC_CLIENT_SECRET"`
+	OIDCRedirectURL  string `mapstructure:"OIDC_REDIRECT_URL"`
+	OIDCScopes       string `mapstructure:"OIDC_SCOPES"`
+
+	// TOTPIssuer is the name authenticator apps show for the account
+	TOTPIssuer string `mapstructure:"TOTP_ISSUER"`
+
+	// ExportLinkTTL is how long the emailed data export link stays valid
+	ExportLinkTTL time.Duration `mapstructure:"EXPORT_LINK_TTL"`
+
+	// Ranking tunes the trending scores, see thread.RankingConfig
+	RankingGravity         float64       `mapstructure:"RANKING_GRAVITY"`
+	RankingDownvotePenalty float64       `mapstructure:"RANKING_DOWNVOTE_PENALTY"`
+	RankingCommentWeight   float64       `mapstructure:"RANKING_COMMENT_WEIGHT"`
+	RankingRefreshInterval time.Duration `mapstructure:"RANKING_REFRESH_INTERVAL"`
+
+	// Unfurl bounds the link preview fetcher, see unfurl.Config
+	UnfurlTimeout  time.Duration `mapstructure:"UNFURL_TIMEOUT"`
+	UnfurlMaxBytes int64         `mapstructure:"UNFURL_MAX_BYTES"`
+	UnfurlCacheTTL time.Duration `mapstructure:"UNFURL_CACHE_TTL"`
+
+	RateLimitEnabled  bool          `mapstructure:"RATE_LIMIT_ENABLED"`
+	RateLimitAuth     string        `mapstructure:"RATE_LIMIT_AUTH"`
+	RateLimitOTP      string        `mapstructure:"RATE_LIMIT_OTP"`
+	RateLimitThread   string        `mapstructure:"RATE_LIMIT_THREAD"`
+	RateLimitComment  string        `mapstructure:"RATE_LIMIT_COMMENT"`
+	RateLimitReport   string        `mapstructure:"RATE_LIMIT_REPORT"`
+	RateLimitExport   string        `mapstructure:"RATE_LIMIT_EXPORT"`
+	LockoutMaxFailure int           `mapstructure:"LOCKOUT_MAX_FAILURES"`
+	LockoutWindow     time.Duration `mapstructure:"LOCKOUT_WINDOW"`
+	LockoutDuration   time.Duration `mapstructure:"LOCKOUT_DURATION"`
+
+	TraceExporter    string  `mapstructure:"OTEL_TRACES_EXPORTER"`
+	TraceEndpoint    string  `mapstructure:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+	TraceInsecure    bool    `mapstructure:"OTEL_EXPORTER_OTLP_INSECURE"`
+	TraceServiceName string  `mapstructure:"OTEL_SERVICE_NAME"`
+	TraceSampleRatio float64 `mapstructure:"OTEL_TRACES_SAMPLE_RATIO"`
+
+	ShutdownTimeout  time.Duration `mapstructure:"SHUTDOWN_TIMEOUT"`
+	ReadinessTimeout time.Duration `mapstructure:"READINESS_TIMEOUT"`
+	DBTimeout        time.Duration `mapstructure:"DB_TIMEOUT"`
 }
 
 type JWTSecret struct {
@@ -35,12 +98,57 @@ func LoadConfig(path string) (config Config, err error) {
 
 	viper.AutomaticEnv()
 
+	viper.SetDefault("APP_BASE_URL", "http://localhost:3000")
+	viper.SetDefault("MAIL_SMTP_HOST", "smtp.gmail.com")
+	viper.SetDefault("MAIL_SMTP_PORT", 587)
+	viper.SetDefault("MAIL_SMTP_TLS", "starttls")
+	viper.SetDefault("MAIL_SMTP_SKIP_VERIFY", false)
+	viper.SetDefault("MAIL_FROM", "")
+	viper.SetDefault("JOB_WORKERS", 4)
+	viper.SetDefault("JOB_MAX_ATTEMPTS", 5)
+	viper.SetDefault("REQUIRE_VERIFIED_EMAIL", false)
+	viper.SetDefault("OIDC_PROVIDER_NAME", "oidc")
+	viper.SetDefault("OIDC_SCOPES", "openid email profile")
+	viper.SetDefault("TOTP_ISSUER", "Macaiki")
+	viper.SetDefault("EXPORT_LINK_TTL", "72h")
+	viper.SetDefault("RANKING_GRAVITY", 1.8)
+	viper.SetDefault("RANKING_DOWNVOTE_PENALTY", 1.0)
+	viper.SetDefault("RANKING_COMMENT_WEIGHT", 0.5)
+	viper.SetDefault("RANKING_REFRESH_INTERVAL", "5m")
+	viper.SetDefault("UNFURL_TIMEOUT", "5s")
+	viper.SetDefault("UNFURL_MAX_BYTES", 524288)
+	viper.SetDefault("UNFURL_CACHE_TTL", "1h")
+	viper.SetDefault("RATE_LIMIT_ENABLED", true)
+	viper.SetDefault("RATE_LIMIT_AUTH", "10/1m")
+	viper.SetDefault("RATE_LIMIT_OTP", "5/10m")
+	viper.SetDefault("RATE_LIMIT_THREAD", "10/10m")
+	viper.SetDefault("RATE_LIMIT_COMMENT", "30/10m")
+	viper.SetDefault("RATE_LIMIT_REPORT", "20/1h")
+	viper.SetDefault("RATE_LIMIT_EXPORT", "3/24h")
+	viper.SetDefault("LOCKOUT_MAX_FAILURES", 5)
+	viper.SetDefault("LOCKOUT_WINDOW", "15m")
+	viper.SetDefault("LOCKOUT_DURATION", "15m")
+	viper.SetDefault("OTEL_TRACES_EXPORTER", "none")
+	viper.SetDefault("OTEL_SERVICE_NAME", "macaiki")
+	viper.SetDefault("OTEL_TRACES_SAMPLE_RATIO", 1.0)
+	viper.SetDefault("SHUTDOWN_TIMEOUT", "30s")
+	viper.SetDefault("READINESS_TIMEOUT", "5s")
+	viper.SetDefault("DB_TIMEOUT", "10s")
+
 	if err = viper.ReadInConfig(); err != nil {
 		return Config{}, err
 	}
 
-	err = viper.Unmarshal(&config)
-	return config, err
+	if err = viper.Unmarshal(&config); err != nil {
+		return Config{}, err
+	}
+
+	// the ranking worker refreshes on a ticker, which panics without a period
+	if config.RankingRefreshInterval <= 0 {
+		return Config{}, fmt.Errorf("RANKING_REFRESH_INTERVAL must be positive, got %s", config.RankingRefreshInterval)
+	}
+
+	return config, nil
 }
 
 func LoadJWTSecret(path string) (secret JWTSecret, err error) {
@@ -36,6 +36,10 @@ func NewCommunityHandler(e *echo.Echo, communityUsecase community.CommunityUseca
 	e.POST("api/v1/community-followers/:communityID", communityHandler.FollowCommunity, middleware.JWT([]byte(JWTSecret)))
 	e.DELETE("api/v1/community-followers/:communityID", communityHandler.UnfollowCommunity, middleware.JWT([]byte(JWTSecret)))
 
+	e.GET("api/v1/community-mutes", communityHandler.GetMutedCommunities, middleware.JWT([]byte(JWTSecret)))
+	e.POST("api/v1/community-mutes/:communityID", communityHandler.MuteCommunity, middleware.JWT([]byte(JWTSecret)))
+	e.DELETE("api/v1/community-mutes/:communityID", communityHandler.UnmuteCommunity, middleware.JWT([]byte(JWTSecret)))
+
 	e.PUT("api/v1/communities/:communityID/images", communityHandler.SetCommunityImage, middleware.JWT([]byte(JWTSecret)))
 	e.PUT("api/v1/communities/:communityID/background-images", communityHandler.SetCommunityBackgroundImage, middleware.JWT([]byte(JWTSecret)))
 
@@ -53,7 +57,7 @@ func (communityHandler *CommunityHandler) CreateCommunity(c echo.Context) error
 	c.Bind(&communityReq)
 
 	_, role := _middL.ExtractTokenUser(c)
-	err := communityHandler.communityUsecase.StoreCommunity(communityReq, role)
+	err := communityHandler.communityUsecase.StoreCommunity(c.Request().Context(), communityReq, role)
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -66,7 +70,7 @@ func (communityHandler *CommunityHandler) GetAllCommunityWithDetail(c echo.Conte
 
 	userID, _ := _middL.ExtractTokenUser(c)
 
-	communitiesResp, err := communityHandler.communityUsecase.GetAllCommunities(userID, search)
+	communitiesResp, err := communityHandler.communityUsecase.GetAllCommunities(c.Request().Context(), userID, search)
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -82,7 +86,7 @@ func (communityHandler *CommunityHandler) GetCommunity(c echo.Context) error {
 
 	userID, _ := _middL.ExtractTokenUser(c)
 
-	communityResp, err := communityHandler.communityUsecase.GetCommunity(uint(userID), uint(id))
+	communityResp, err := communityHandler.communityUsecase.GetCommunity(c.Request().Context(), uint(userID), uint(id))
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -98,7 +102,7 @@ func (communityHandler *CommunityHandler) GetCommunityAbout(c echo.Context) erro
 
 	userID, _ := _middL.ExtractTokenUser(c)
 
-	communityResp, err := communityHandler.communityUsecase.GetCommunityAbout(uint(userID), uint(id))
+	communityResp, err := communityHandler.communityUsecase.GetCommunityAbout(c.Request().Context(), uint(userID), uint(id))
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -116,7 +120,7 @@ func (communityHandler *CommunityHandler) UpdateCommunity(c echo.Context) error
 	c.Bind(&communityReq)
 
 	_, role := _middL.ExtractTokenUser(c)
-	communityUpdateResp, err := communityHandler.communityUsecase.UpdateCommunity(uint(id), communityReq, role)
+	communityUpdateResp, err := communityHandler.communityUsecase.UpdateCommunity(c.Request().Context(), uint(id), communityReq, role)
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -131,7 +135,7 @@ func (communityHandler *CommunityHandler) DeleteCommunity(c echo.Context) error
 	}
 
 	_, role := _middL.ExtractTokenUser(c)
-	err = communityHandler.communityUsecase.DeleteCommunity(uint(id), role)
+	err = communityHandler.communityUsecase.DeleteCommunity(c.Request().Context(), uint(id), role)
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -147,7 +151,7 @@ func (communityHandler *CommunityHandler) FollowCommunity(c echo.Context) error
 		return response.ErrorResponse(c, utils.ErrBadParamInput)
 	}
 
-	err = communityHandler.communityUsecase.FollowCommunity(uint(userID), uint(communityID))
+	err = communityHandler.communityUsecase.FollowCommunity(c.Request().Context(), uint(userID), uint(communityID))
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -163,7 +167,7 @@ func (communityHandler *CommunityHandler) UnfollowCommunity(c echo.Context) erro
 		return response.ErrorResponse(c, utils.ErrBadParamInput)
 	}
 
-	err = communityHandler.communityUsecase.UnfollowCommunity(uint(userID), uint(communityID))
+	err = communityHandler.communityUsecase.UnfollowCommunity(c.Request().Context(), uint(userID), uint(communityID))
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -183,7 +187,7 @@ func (communityHandler *CommunityHandler) SetCommunityImage(c echo.Context) erro
 	}
 
 	_, role := _middL.ExtractTokenUser(c)
-	imageUrl, err := communityHandler.communityUsecase.SetImage(uint(id), img, role)
+	imageUrl, err := communityHandler.communityUsecase.SetImage(c.Request().Context(), uint(id), img, role)
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -203,7 +207,7 @@ func (communityHandler *CommunityHandler) SetCommunityBackgroundImage(c echo.Con
 	}
 
 	_, role := _middL.ExtractTokenUser(c)
-	imageUrl, err := communityHandler.communityUsecase.SetBackgroundImage(uint(id), img, role)
+	imageUrl, err := communityHandler.communityUsecase.SetBackgroundImage(c.Request().Context(), uint(id), img, role)
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -218,7 +222,7 @@ func (communityHandler *CommunityHandler) GetThreadByCommunityID(c echo.Context)
 	}
 	userID, _ := _middL.ExtractTokenUser(c)
 
-	threadResp, err := communityHandler.communityUsecase.GetThreadCommunity(uint(userID), uint(communityID))
+	threadResp, err := communityHandler.communityUsecase.GetThreadCommunity(c.Request().Context(), uint(userID), uint(communityID))
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -231,7 +235,7 @@ func (communityHandler *CommunityHandler) AddModerator(c echo.Context) error {
 	c.Bind(&moderatorReq)
 
 	_, role := _middL.ExtractTokenUser(c)
-	err := communityHandler.communityUsecase.AddModerator(moderatorReq, role)
+	err := communityHandler.communityUsecase.AddModerator(c.Request().Context(), moderatorReq, role)
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -244,7 +248,7 @@ func (communityHandler *CommunityHandler) RemoveModerator(c echo.Context) error
 	c.Bind(&moderatorReq)
 
 	_, role := _middL.ExtractTokenUser(c)
-	err := communityHandler.communityUsecase.RemoveModerator(moderatorReq, role)
+	err := communityHandler.communityUsecase.RemoveModerator(c.Request().Context(), moderatorReq, role)
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -261,7 +265,7 @@ func (CommunityHandler *CommunityHandler) ReportCommunity(c echo.Context) error
 	c.Bind(&reportCategoryReq)
 
 	userID, _ := _middL.ExtractTokenUser(c)
-	err = CommunityHandler.communityUsecase.ReportCommunity(uint(userID), uint(communityID), uint(reportCategoryReq.ReportCategoryID))
+	err = CommunityHandler.communityUsecase.ReportCommunity(c.Request().Context(), uint(userID), uint(communityID), uint(reportCategoryReq.ReportCategoryID))
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -275,7 +279,7 @@ func (CommunityHandler *CommunityHandler) DeleteReportCommunity(c echo.Context)
 		return response.ErrorResponse(c, utils.ErrBadParamInput)
 	}
 
-	err = CommunityHandler.communityUsecase.DeleteReportCommunity(uint(reportCommunityID))
+	err = CommunityHandler.communityUsecase.DeleteReportCommunity(c.Request().Context(), uint(reportCommunityID))
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -289,7 +293,7 @@ func (CommunityHandler *CommunityHandler) GetReports(c echo.Context) error {
 		return response.ErrorResponse(c, utils.ErrBadParamInput)
 	}
 	userID, _ := _middL.ExtractTokenUser(c)
-	reports, err := CommunityHandler.communityUsecase.GetReports(uint(userID), uint(communityID))
+	reports, err := CommunityHandler.communityUsecase.GetReports(c.Request().Context(), uint(userID), uint(communityID))
 
 	if err != nil {
 		return response.ErrorResponse(c, err)
@@ -308,7 +312,50 @@ func (CommunityHandler *CommunityHandler) ReportByModerator(c echo.Context) erro
 	dtoReport := dto.ReportRequest{}
 	c.Bind(&dtoReport)
 
-	err = CommunityHandler.communityUsecase.ReportByModerator(uint(userID), uint(communityID), dtoReport)
+	err = CommunityHandler.communityUsecase.ReportByModerator(c.Request().Context(), uint(userID), uint(communityID), dtoReport)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, nil)
+}
+
+func (communityHandler *CommunityHandler) GetMutedCommunities(c echo.Context) error {
+	userID, _ := _middL.ExtractTokenUser(c)
+
+	res, err := communityHandler.communityUsecase.GetMutedCommunities(c.Request().Context(), uint(userID))
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, res)
+}
+
+func (communityHandler *CommunityHandler) MuteCommunity(c echo.Context) error {
+	userID, _ := _middL.ExtractTokenUser(c)
+
+	communityID, err := strconv.Atoi(c.Param("communityID"))
+	if err != nil {
+		return response.ErrorResponse(c, utils.ErrBadParamInput)
+	}
+
+	err = communityHandler.communityUsecase.MuteCommunity(c.Request().Context(), uint(userID), uint(communityID))
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, nil)
+}
+
+func (communityHandler *CommunityHandler) UnmuteCommunity(c echo.Context) error {
+	userID, _ := _middL.ExtractTokenUser(c)
+
+	communityID, err := strconv.Atoi(c.Param("communityID"))
+	if err != nil {
+		return response.ErrorResponse(c, utils.ErrBadParamInput)
+	}
+
+	err = communityHandler.communityUsecase.UnmuteCommunity(c.Request().Context(), uint(userID), uint(communityID))
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
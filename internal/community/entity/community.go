@@ -43,6 +43,15 @@ type BriefReport struct {
 	Type                string
 }
 
+// MutedCommunity keeps the threads of a community out of the feeds of the
+// user who muted it, the community page itself stays reachable
+type MutedCommunity struct {
+	ID          uint `gorm:"primaryKey"`
+	UserID      uint `gorm:"uniqueIndex:idx_muted_communities_user_community"`
+	CommunityID uint `gorm:"uniqueIndex:idx_muted_communities_user_community;index"`
+	CreatedAt   time.Time
+}
+
 type CommunityModerator struct {
 	UserID      uint
 	CommunityID uint
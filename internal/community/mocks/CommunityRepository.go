@@ -3,12 +3,12 @@
 package mocks
 
 import (
+	context "context"
 	communityentity "macaiki/internal/community/entity"
+	threadentity "macaiki/internal/thread/entity"
 	entity "macaiki/internal/user/entity"
 
 	mock "github.com/stretchr/testify/mock"
-
-	threadentity "macaiki/internal/thread/entity"
 )
 
 // CommunityRepository is an autogenerated mock type for the CommunityRepository type
@@ -16,13 +16,13 @@ type CommunityRepository struct {
 	mock.Mock
 }
 
-// AddModerator provides a mock function with given fields: user, _a1
-func (_m *CommunityRepository) AddModerator(user entity.User, _a1 communityentity.Community) error {
-	ret := _m.Called(user, _a1)
+// AddModerator provides a mock function with given fields: ctx, user, _a2
+func (_m *CommunityRepository) AddModerator(ctx context.Context, user entity.User, _a2 communityentity.Community) error {
+	ret := _m.Called(ctx, user, _a2)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(entity.User, communityentity.Community) error); ok {
-		r0 = rf(user, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.User, communityentity.Community) error); ok {
+		r0 = rf(ctx, user, _a2)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -30,13 +30,13 @@ func (_m *CommunityRepository) AddModerator(user entity.User, _a1 communityentit
 	return r0
 }
 
-// DeleteCommunity provides a mock function with given fields: communityID
-func (_m *CommunityRepository) DeleteCommunity(communityID uint) error {
-	ret := _m.Called(communityID)
+// DeleteCommunity provides a mock function with given fields: ctx, communityID
+func (_m *CommunityRepository) DeleteCommunity(ctx context.Context, communityID uint) error {
+	ret := _m.Called(ctx, communityID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint) error); ok {
-		r0 = rf(communityID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, communityID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -44,13 +44,13 @@ func (_m *CommunityRepository) DeleteCommunity(communityID uint) error {
 	return r0
 }
 
-// FollowCommunity provides a mock function with given fields: user, _a1
-func (_m *CommunityRepository) FollowCommunity(user entity.User, _a1 communityentity.Community) error {
-	ret := _m.Called(user, _a1)
+// FollowCommunity provides a mock function with given fields: ctx, user, _a2
+func (_m *CommunityRepository) FollowCommunity(ctx context.Context, user entity.User, _a2 communityentity.Community) error {
+	ret := _m.Called(ctx, user, _a2)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(entity.User, communityentity.Community) error); ok {
-		r0 = rf(user, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.User, communityentity.Community) error); ok {
+		r0 = rf(ctx, user, _a2)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -58,13 +58,13 @@ func (_m *CommunityRepository) FollowCommunity(user entity.User, _a1 communityen
 	return r0
 }
 
-// GetAllCommunities provides a mock function with given fields: userID, search
-func (_m *CommunityRepository) GetAllCommunities(userID uint, search string) ([]communityentity.Community, error) {
-	ret := _m.Called(userID, search)
+// GetAllCommunities provides a mock function with given fields: ctx, userID, search
+func (_m *CommunityRepository) GetAllCommunities(ctx context.Context, userID uint, search string) ([]communityentity.Community, error) {
+	ret := _m.Called(ctx, userID, search)
 
 	var r0 []communityentity.Community
-	if rf, ok := ret.Get(0).(func(uint, string) []communityentity.Community); ok {
-		r0 = rf(userID, search)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) []communityentity.Community); ok {
+		r0 = rf(ctx, userID, search)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]communityentity.Community)
@@ -72,8 +72,8 @@ func (_m *CommunityRepository) GetAllCommunities(userID uint, search string) ([]
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint, string) error); ok {
-		r1 = rf(userID, search)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, string) error); ok {
+		r1 = rf(ctx, userID, search)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -81,20 +81,20 @@ func (_m *CommunityRepository) GetAllCommunities(userID uint, search string) ([]
 	return r0, r1
 }
 
-// GetCommunity provides a mock function with given fields: id
-func (_m *CommunityRepository) GetCommunity(id uint) (communityentity.Community, error) {
-	ret := _m.Called(id)
+// GetCommunity provides a mock function with given fields: ctx, id
+func (_m *CommunityRepository) GetCommunity(ctx context.Context, id uint) (communityentity.Community, error) {
+	ret := _m.Called(ctx, id)
 
 	var r0 communityentity.Community
-	if rf, ok := ret.Get(0).(func(uint) communityentity.Community); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) communityentity.Community); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Get(0).(communityentity.Community)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -102,20 +102,20 @@ func (_m *CommunityRepository) GetCommunity(id uint) (communityentity.Community,
 	return r0, r1
 }
 
-// GetCommunityAbout provides a mock function with given fields: userID, communityID
-func (_m *CommunityRepository) GetCommunityAbout(userID uint, communityID uint) (communityentity.Community, error) {
-	ret := _m.Called(userID, communityID)
+// GetCommunityAbout provides a mock function with given fields: ctx, userID, communityID
+func (_m *CommunityRepository) GetCommunityAbout(ctx context.Context, userID uint, communityID uint) (communityentity.Community, error) {
+	ret := _m.Called(ctx, userID, communityID)
 
 	var r0 communityentity.Community
-	if rf, ok := ret.Get(0).(func(uint, uint) communityentity.Community); ok {
-		r0 = rf(userID, communityID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) communityentity.Community); ok {
+		r0 = rf(ctx, userID, communityID)
 	} else {
 		r0 = ret.Get(0).(communityentity.Community)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint, uint) error); ok {
-		r1 = rf(userID, communityID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, userID, communityID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -123,13 +123,13 @@ func (_m *CommunityRepository) GetCommunityAbout(userID uint, communityID uint)
 	return r0, r1
 }
 
-// GetCommunityThread provides a mock function with given fields: userID, communityID
-func (_m *CommunityRepository) GetCommunityThread(userID uint, communityID uint) ([]threadentity.ThreadWithDetails, error) {
-	ret := _m.Called(userID, communityID)
+// GetCommunityThread provides a mock function with given fields: ctx, userID, communityID
+func (_m *CommunityRepository) GetCommunityThread(ctx context.Context, userID uint, communityID uint) ([]threadentity.ThreadWithDetails, error) {
+	ret := _m.Called(ctx, userID, communityID)
 
 	var r0 []threadentity.ThreadWithDetails
-	if rf, ok := ret.Get(0).(func(uint, uint) []threadentity.ThreadWithDetails); ok {
-		r0 = rf(userID, communityID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) []threadentity.ThreadWithDetails); ok {
+		r0 = rf(ctx, userID, communityID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]threadentity.ThreadWithDetails)
@@ -137,8 +137,8 @@ func (_m *CommunityRepository) GetCommunityThread(userID uint, communityID uint)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint, uint) error); ok {
-		r1 = rf(userID, communityID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, userID, communityID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -146,20 +146,20 @@ func (_m *CommunityRepository) GetCommunityThread(userID uint, communityID uint)
 	return r0, r1
 }
 
-// GetCommunityWithDetail provides a mock function with given fields: userID, communityID
-func (_m *CommunityRepository) GetCommunityWithDetail(userID uint, communityID uint) (communityentity.Community, error) {
-	ret := _m.Called(userID, communityID)
+// GetCommunityWithDetail provides a mock function with given fields: ctx, userID, communityID
+func (_m *CommunityRepository) GetCommunityWithDetail(ctx context.Context, userID uint, communityID uint) (communityentity.Community, error) {
+	ret := _m.Called(ctx, userID, communityID)
 
 	var r0 communityentity.Community
-	if rf, ok := ret.Get(0).(func(uint, uint) communityentity.Community); ok {
-		r0 = rf(userID, communityID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) communityentity.Community); ok {
+		r0 = rf(ctx, userID, communityID)
 	} else {
 		r0 = ret.Get(0).(communityentity.Community)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint, uint) error); ok {
-		r1 = rf(userID, communityID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, userID, communityID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -167,13 +167,13 @@ func (_m *CommunityRepository) GetCommunityWithDetail(userID uint, communityID u
 	return r0, r1
 }
 
-// GetModeratorByCommunityID provides a mock function with given fields: userID, communityID
-func (_m *CommunityRepository) GetModeratorByCommunityID(userID uint, communityID uint) ([]entity.User, error) {
-	ret := _m.Called(userID, communityID)
+// GetModeratorByCommunityID provides a mock function with given fields: ctx, userID, communityID
+func (_m *CommunityRepository) GetModeratorByCommunityID(ctx context.Context, userID uint, communityID uint) ([]entity.User, error) {
+	ret := _m.Called(ctx, userID, communityID)
 
 	var r0 []entity.User
-	if rf, ok := ret.Get(0).(func(uint, uint) []entity.User); ok {
-		r0 = rf(userID, communityID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) []entity.User); ok {
+		r0 = rf(ctx, userID, communityID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entity.User)
@@ -181,8 +181,8 @@ func (_m *CommunityRepository) GetModeratorByCommunityID(userID uint, communityI
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint, uint) error); ok {
-		r1 = rf(userID, communityID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, userID, communityID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -190,20 +190,43 @@ func (_m *CommunityRepository) GetModeratorByCommunityID(userID uint, communityI
 	return r0, r1
 }
 
-// GetModeratorByUserID provides a mock function with given fields: userID, communityID
-func (_m *CommunityRepository) GetModeratorByUserID(userID uint, communityID uint) (communityentity.CommunityModerator, error) {
-	ret := _m.Called(userID, communityID)
+// GetModeratorByUserID provides a mock function with given fields: ctx, userID, communityID
+func (_m *CommunityRepository) GetModeratorByUserID(ctx context.Context, userID uint, communityID uint) (communityentity.CommunityModerator, error) {
+	ret := _m.Called(ctx, userID, communityID)
 
 	var r0 communityentity.CommunityModerator
-	if rf, ok := ret.Get(0).(func(uint, uint) communityentity.CommunityModerator); ok {
-		r0 = rf(userID, communityID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) communityentity.CommunityModerator); ok {
+		r0 = rf(ctx, userID, communityID)
 	} else {
 		r0 = ret.Get(0).(communityentity.CommunityModerator)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint, uint) error); ok {
-		r1 = rf(userID, communityID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, userID, communityID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMutedCommunities provides a mock function with given fields: ctx, userID
+func (_m *CommunityRepository) GetMutedCommunities(ctx context.Context, userID uint) ([]communityentity.Community, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []communityentity.Community
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []communityentity.Community); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]communityentity.Community)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -211,20 +234,20 @@ func (_m *CommunityRepository) GetModeratorByUserID(userID uint, communityID uin
 	return r0, r1
 }
 
-// GetReportCommunity provides a mock function with given fields: id
-func (_m *CommunityRepository) GetReportCommunity(id uint) (communityentity.CommunityReport, error) {
-	ret := _m.Called(id)
+// GetReportCommunity provides a mock function with given fields: ctx, id
+func (_m *CommunityRepository) GetReportCommunity(ctx context.Context, id uint) (communityentity.CommunityReport, error) {
+	ret := _m.Called(ctx, id)
 
 	var r0 communityentity.CommunityReport
-	if rf, ok := ret.Get(0).(func(uint) communityentity.CommunityReport); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) communityentity.CommunityReport); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Get(0).(communityentity.CommunityReport)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -232,13 +255,13 @@ func (_m *CommunityRepository) GetReportCommunity(id uint) (communityentity.Comm
 	return r0, r1
 }
 
-// GetReports provides a mock function with given fields: communityID
-func (_m *CommunityRepository) GetReports(communityID uint) ([]communityentity.BriefReport, error) {
-	ret := _m.Called(communityID)
+// GetReports provides a mock function with given fields: ctx, communityID
+func (_m *CommunityRepository) GetReports(ctx context.Context, communityID uint) ([]communityentity.BriefReport, error) {
+	ret := _m.Called(ctx, communityID)
 
 	var r0 []communityentity.BriefReport
-	if rf, ok := ret.Get(0).(func(uint) []communityentity.BriefReport); ok {
-		r0 = rf(communityID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []communityentity.BriefReport); ok {
+		r0 = rf(ctx, communityID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]communityentity.BriefReport)
@@ -246,8 +269,8 @@ func (_m *CommunityRepository) GetReports(communityID uint) ([]communityentity.B
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint) error); ok {
-		r1 = rf(communityID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, communityID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -255,13 +278,41 @@ func (_m *CommunityRepository) GetReports(communityID uint) ([]communityentity.B
 	return r0, r1
 }
 
-// RemoveModerator provides a mock function with given fields: user, _a1
-func (_m *CommunityRepository) RemoveModerator(user entity.User, _a1 communityentity.Community) error {
-	ret := _m.Called(user, _a1)
+// MuteCommunity provides a mock function with given fields: ctx, userID, communityID
+func (_m *CommunityRepository) MuteCommunity(ctx context.Context, userID uint, communityID uint) error {
+	ret := _m.Called(ctx, userID, communityID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, userID, communityID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveModerator provides a mock function with given fields: ctx, user, _a2
+func (_m *CommunityRepository) RemoveModerator(ctx context.Context, user entity.User, _a2 communityentity.Community) error {
+	ret := _m.Called(ctx, user, _a2)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.User, communityentity.Community) error); ok {
+		r0 = rf(ctx, user, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetCommunityImage provides a mock function with given fields: ctx, id, imageURL, tableName
+func (_m *CommunityRepository) SetCommunityImage(ctx context.Context, id uint, imageURL string, tableName string) error {
+	ret := _m.Called(ctx, id, imageURL, tableName)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(entity.User, communityentity.Community) error); ok {
-		r0 = rf(user, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, string) error); ok {
+		r0 = rf(ctx, id, imageURL, tableName)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -269,13 +320,13 @@ func (_m *CommunityRepository) RemoveModerator(user entity.User, _a1 communityen
 	return r0
 }
 
-// SetCommunityImage provides a mock function with given fields: id, imageURL, tableName
-func (_m *CommunityRepository) SetCommunityImage(id uint, imageURL string, tableName string) error {
-	ret := _m.Called(id, imageURL, tableName)
+// StoreCommunity provides a mock function with given fields: ctx, _a1
+func (_m *CommunityRepository) StoreCommunity(ctx context.Context, _a1 communityentity.Community) error {
+	ret := _m.Called(ctx, _a1)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint, string, string) error); ok {
-		r0 = rf(id, imageURL, tableName)
+	if rf, ok := ret.Get(0).(func(context.Context, communityentity.Community) error); ok {
+		r0 = rf(ctx, _a1)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -283,13 +334,13 @@ func (_m *CommunityRepository) SetCommunityImage(id uint, imageURL string, table
 	return r0
 }
 
-// StoreCommunity provides a mock function with given fields: _a0
-func (_m *CommunityRepository) StoreCommunity(_a0 communityentity.Community) error {
-	ret := _m.Called(_a0)
+// StoreReportCommunity provides a mock function with given fields: ctx, communityReport
+func (_m *CommunityRepository) StoreReportCommunity(ctx context.Context, communityReport communityentity.CommunityReport) error {
+	ret := _m.Called(ctx, communityReport)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(communityentity.Community) error); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, communityentity.CommunityReport) error); ok {
+		r0 = rf(ctx, communityReport)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -297,13 +348,13 @@ func (_m *CommunityRepository) StoreCommunity(_a0 communityentity.Community) err
 	return r0
 }
 
-// StoreReportCommunity provides a mock function with given fields: communityReport
-func (_m *CommunityRepository) StoreReportCommunity(communityReport communityentity.CommunityReport) error {
-	ret := _m.Called(communityReport)
+// UnfollowCommunity provides a mock function with given fields: ctx, user, _a2
+func (_m *CommunityRepository) UnfollowCommunity(ctx context.Context, user entity.User, _a2 communityentity.Community) error {
+	ret := _m.Called(ctx, user, _a2)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(communityentity.CommunityReport) error); ok {
-		r0 = rf(communityReport)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.User, communityentity.Community) error); ok {
+		r0 = rf(ctx, user, _a2)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -311,13 +362,13 @@ func (_m *CommunityRepository) StoreReportCommunity(communityReport communityent
 	return r0
 }
 
-// UnfollowCommunity provides a mock function with given fields: user, _a1
-func (_m *CommunityRepository) UnfollowCommunity(user entity.User, _a1 communityentity.Community) error {
-	ret := _m.Called(user, _a1)
+// UnmuteCommunity provides a mock function with given fields: ctx, userID, communityID
+func (_m *CommunityRepository) UnmuteCommunity(ctx context.Context, userID uint, communityID uint) error {
+	ret := _m.Called(ctx, userID, communityID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(entity.User, communityentity.Community) error); ok {
-		r0 = rf(user, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, userID, communityID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -325,20 +376,20 @@ func (_m *CommunityRepository) UnfollowCommunity(user entity.User, _a1 community
 	return r0
 }
 
-// UpdateCommunity provides a mock function with given fields: _a0, communityReq
-func (_m *CommunityRepository) UpdateCommunity(_a0 communityentity.Community, communityReq communityentity.Community) (communityentity.Community, error) {
-	ret := _m.Called(_a0, communityReq)
+// UpdateCommunity provides a mock function with given fields: ctx, _a1, communityReq
+func (_m *CommunityRepository) UpdateCommunity(ctx context.Context, _a1 communityentity.Community, communityReq communityentity.Community) (communityentity.Community, error) {
+	ret := _m.Called(ctx, _a1, communityReq)
 
 	var r0 communityentity.Community
-	if rf, ok := ret.Get(0).(func(communityentity.Community, communityentity.Community) communityentity.Community); ok {
-		r0 = rf(_a0, communityReq)
+	if rf, ok := ret.Get(0).(func(context.Context, communityentity.Community, communityentity.Community) communityentity.Community); ok {
+		r0 = rf(ctx, _a1, communityReq)
 	} else {
 		r0 = ret.Get(0).(communityentity.Community)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(communityentity.Community, communityentity.Community) error); ok {
-		r1 = rf(_a0, communityReq)
+	if rf, ok := ret.Get(1).(func(context.Context, communityentity.Community, communityentity.Community) error); ok {
+		r1 = rf(ctx, _a1, communityReq)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -346,13 +397,13 @@ func (_m *CommunityRepository) UpdateCommunity(_a0 communityentity.Community, co
 	return r0, r1
 }
 
-// UpdateReportCommunity provides a mock function with given fields: communityReport, userID
-func (_m *CommunityRepository) UpdateReportCommunity(communityReport communityentity.CommunityReport, userID uint) error {
-	ret := _m.Called(communityReport, userID)
+// UpdateReportCommunity provides a mock function with given fields: ctx, communityReport, userID
+func (_m *CommunityRepository) UpdateReportCommunity(ctx context.Context, communityReport communityentity.CommunityReport, userID uint) error {
+	ret := _m.Called(ctx, communityReport, userID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(communityentity.CommunityReport, uint) error); ok {
-		r0 = rf(communityReport, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, communityentity.CommunityReport, uint) error); ok {
+		r0 = rf(ctx, communityReport, userID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -3,13 +3,12 @@
 package mocks
 
 import (
+	context "context"
 	dto "macaiki/internal/community/dto"
-
-	mock "github.com/stretchr/testify/mock"
-
+	threaddto "macaiki/internal/thread/dto"
 	multipart "mime/multipart"
 
-	threaddto "macaiki/internal/thread/dto"
+	mock "github.com/stretchr/testify/mock"
 )
 
 // CommunityUsecase is an autogenerated mock type for the CommunityUsecase type
@@ -17,13 +16,13 @@ type CommunityUsecase struct {
 	mock.Mock
 }
 
-// AddModerator provides a mock function with given fields: moderatorReq, role
-func (_m *CommunityUsecase) AddModerator(moderatorReq dto.CommunityModeratorRequest, role string) error {
-	ret := _m.Called(moderatorReq, role)
+// AddModerator provides a mock function with given fields: ctx, moderatorReq, role
+func (_m *CommunityUsecase) AddModerator(ctx context.Context, moderatorReq dto.CommunityModeratorRequest, role string) error {
+	ret := _m.Called(ctx, moderatorReq, role)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(dto.CommunityModeratorRequest, string) error); ok {
-		r0 = rf(moderatorReq, role)
+	if rf, ok := ret.Get(0).(func(context.Context, dto.CommunityModeratorRequest, string) error); ok {
+		r0 = rf(ctx, moderatorReq, role)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -31,13 +30,13 @@ func (_m *CommunityUsecase) AddModerator(moderatorReq dto.CommunityModeratorRequ
 	return r0
 }
 
-// DeleteCommunity provides a mock function with given fields: id, role
-func (_m *CommunityUsecase) DeleteCommunity(id uint, role string) error {
-	ret := _m.Called(id, role)
+// DeleteCommunity provides a mock function with given fields: ctx, id, role
+func (_m *CommunityUsecase) DeleteCommunity(ctx context.Context, id uint, role string) error {
+	ret := _m.Called(ctx, id, role)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint, string) error); ok {
-		r0 = rf(id, role)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) error); ok {
+		r0 = rf(ctx, id, role)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -45,13 +44,13 @@ func (_m *CommunityUsecase) DeleteCommunity(id uint, role string) error {
 	return r0
 }
 
-// DeleteReportCommunity provides a mock function with given fields: reportCommunityId
-func (_m *CommunityUsecase) DeleteReportCommunity(reportCommunityId uint) error {
-	ret := _m.Called(reportCommunityId)
+// DeleteReportCommunity provides a mock function with given fields: ctx, reportCommunityId
+func (_m *CommunityUsecase) DeleteReportCommunity(ctx context.Context, reportCommunityId uint) error {
+	ret := _m.Called(ctx, reportCommunityId)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint) error); ok {
-		r0 = rf(reportCommunityId)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, reportCommunityId)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -59,13 +58,13 @@ func (_m *CommunityUsecase) DeleteReportCommunity(reportCommunityId uint) error
 	return r0
 }
 
-// FollowCommunity provides a mock function with given fields: userID, communityID
-func (_m *CommunityUsecase) FollowCommunity(userID uint, communityID uint) error {
-	ret := _m.Called(userID, communityID)
+// FollowCommunity provides a mock function with given fields: ctx, userID, communityID
+func (_m *CommunityUsecase) FollowCommunity(ctx context.Context, userID uint, communityID uint) error {
+	ret := _m.Called(ctx, userID, communityID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint, uint) error); ok {
-		r0 = rf(userID, communityID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, userID, communityID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -73,13 +72,13 @@ func (_m *CommunityUsecase) FollowCommunity(userID uint, communityID uint) error
 	return r0
 }
 
-// GetAllCommunities provides a mock function with given fields: userID, search
-func (_m *CommunityUsecase) GetAllCommunities(userID int, search string) ([]dto.CommunityDetailResponse, error) {
-	ret := _m.Called(userID, search)
+// GetAllCommunities provides a mock function with given fields: ctx, userID, search
+func (_m *CommunityUsecase) GetAllCommunities(ctx context.Context, userID int, search string) ([]dto.CommunityDetailResponse, error) {
+	ret := _m.Called(ctx, userID, search)
 
 	var r0 []dto.CommunityDetailResponse
-	if rf, ok := ret.Get(0).(func(int, string) []dto.CommunityDetailResponse); ok {
-		r0 = rf(userID, search)
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) []dto.CommunityDetailResponse); ok {
+		r0 = rf(ctx, userID, search)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]dto.CommunityDetailResponse)
@@ -87,8 +86,8 @@ func (_m *CommunityUsecase) GetAllCommunities(userID int, search string) ([]dto.
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int, string) error); ok {
-		r1 = rf(userID, search)
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = rf(ctx, userID, search)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -96,20 +95,20 @@ func (_m *CommunityUsecase) GetAllCommunities(userID int, search string) ([]dto.
 	return r0, r1
 }
 
-// GetCommunity provides a mock function with given fields: userID, communityID
-func (_m *CommunityUsecase) GetCommunity(userID uint, communityID uint) (dto.CommunityDetailResponse, error) {
-	ret := _m.Called(userID, communityID)
+// GetCommunity provides a mock function with given fields: ctx, userID, communityID
+func (_m *CommunityUsecase) GetCommunity(ctx context.Context, userID uint, communityID uint) (dto.CommunityDetailResponse, error) {
+	ret := _m.Called(ctx, userID, communityID)
 
 	var r0 dto.CommunityDetailResponse
-	if rf, ok := ret.Get(0).(func(uint, uint) dto.CommunityDetailResponse); ok {
-		r0 = rf(userID, communityID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) dto.CommunityDetailResponse); ok {
+		r0 = rf(ctx, userID, communityID)
 	} else {
 		r0 = ret.Get(0).(dto.CommunityDetailResponse)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint, uint) error); ok {
-		r1 = rf(userID, communityID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, userID, communityID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -117,20 +116,43 @@ func (_m *CommunityUsecase) GetCommunity(userID uint, communityID uint) (dto.Com
 	return r0, r1
 }
 
-// GetCommunityAbout provides a mock function with given fields: userID, communityID
-func (_m *CommunityUsecase) GetCommunityAbout(userID uint, communityID uint) (dto.CommunityAboutResponse, error) {
-	ret := _m.Called(userID, communityID)
+// GetCommunityAbout provides a mock function with given fields: ctx, userID, communityID
+func (_m *CommunityUsecase) GetCommunityAbout(ctx context.Context, userID uint, communityID uint) (dto.CommunityAboutResponse, error) {
+	ret := _m.Called(ctx, userID, communityID)
 
 	var r0 dto.CommunityAboutResponse
-	if rf, ok := ret.Get(0).(func(uint, uint) dto.CommunityAboutResponse); ok {
-		r0 = rf(userID, communityID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) dto.CommunityAboutResponse); ok {
+		r0 = rf(ctx, userID, communityID)
 	} else {
 		r0 = ret.Get(0).(dto.CommunityAboutResponse)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint, uint) error); ok {
-		r1 = rf(userID, communityID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, userID, communityID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMutedCommunities provides a mock function with given fields: ctx, userID
+func (_m *CommunityUsecase) GetMutedCommunities(ctx context.Context, userID uint) ([]dto.CommunityDetailResponse, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []dto.CommunityDetailResponse
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []dto.CommunityDetailResponse); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.CommunityDetailResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -138,13 +160,13 @@ func (_m *CommunityUsecase) GetCommunityAbout(userID uint, communityID uint) (dt
 	return r0, r1
 }
 
-// GetReports provides a mock function with given fields: userID, communityID
-func (_m *CommunityUsecase) GetReports(userID uint, communityID uint) ([]dto.BriefReportResponse, error) {
-	ret := _m.Called(userID, communityID)
+// GetReports provides a mock function with given fields: ctx, userID, communityID
+func (_m *CommunityUsecase) GetReports(ctx context.Context, userID uint, communityID uint) ([]dto.BriefReportResponse, error) {
+	ret := _m.Called(ctx, userID, communityID)
 
 	var r0 []dto.BriefReportResponse
-	if rf, ok := ret.Get(0).(func(uint, uint) []dto.BriefReportResponse); ok {
-		r0 = rf(userID, communityID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) []dto.BriefReportResponse); ok {
+		r0 = rf(ctx, userID, communityID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]dto.BriefReportResponse)
@@ -152,8 +174,8 @@ func (_m *CommunityUsecase) GetReports(userID uint, communityID uint) ([]dto.Bri
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint, uint) error); ok {
-		r1 = rf(userID, communityID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, userID, communityID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -161,13 +183,13 @@ func (_m *CommunityUsecase) GetReports(userID uint, communityID uint) ([]dto.Bri
 	return r0, r1
 }
 
-// GetThreadCommunity provides a mock function with given fields: userID, communityID
-func (_m *CommunityUsecase) GetThreadCommunity(userID uint, communityID uint) ([]threaddto.DetailedThreadResponse, error) {
-	ret := _m.Called(userID, communityID)
+// GetThreadCommunity provides a mock function with given fields: ctx, userID, communityID
+func (_m *CommunityUsecase) GetThreadCommunity(ctx context.Context, userID uint, communityID uint) ([]threaddto.DetailedThreadResponse, error) {
+	ret := _m.Called(ctx, userID, communityID)
 
 	var r0 []threaddto.DetailedThreadResponse
-	if rf, ok := ret.Get(0).(func(uint, uint) []threaddto.DetailedThreadResponse); ok {
-		r0 = rf(userID, communityID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) []threaddto.DetailedThreadResponse); ok {
+		r0 = rf(ctx, userID, communityID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]threaddto.DetailedThreadResponse)
@@ -175,8 +197,8 @@ func (_m *CommunityUsecase) GetThreadCommunity(userID uint, communityID uint) ([
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint, uint) error); ok {
-		r1 = rf(userID, communityID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, userID, communityID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -184,13 +206,27 @@ func (_m *CommunityUsecase) GetThreadCommunity(userID uint, communityID uint) ([
 	return r0, r1
 }
 
-// RemoveModerator provides a mock function with given fields: moderatorReq, role
-func (_m *CommunityUsecase) RemoveModerator(moderatorReq dto.CommunityModeratorRequest, role string) error {
-	ret := _m.Called(moderatorReq, role)
+// MuteCommunity provides a mock function with given fields: ctx, userID, communityID
+func (_m *CommunityUsecase) MuteCommunity(ctx context.Context, userID uint, communityID uint) error {
+	ret := _m.Called(ctx, userID, communityID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, userID, communityID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveModerator provides a mock function with given fields: ctx, moderatorReq, role
+func (_m *CommunityUsecase) RemoveModerator(ctx context.Context, moderatorReq dto.CommunityModeratorRequest, role string) error {
+	ret := _m.Called(ctx, moderatorReq, role)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(dto.CommunityModeratorRequest, string) error); ok {
-		r0 = rf(moderatorReq, role)
+	if rf, ok := ret.Get(0).(func(context.Context, dto.CommunityModeratorRequest, string) error); ok {
+		r0 = rf(ctx, moderatorReq, role)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -198,13 +234,13 @@ func (_m *CommunityUsecase) RemoveModerator(moderatorReq dto.CommunityModeratorR
 	return r0
 }
 
-// ReportByModerator provides a mock function with given fields: userID, communityID, reportReq
-func (_m *CommunityUsecase) ReportByModerator(userID uint, communityID uint, reportReq dto.ReportRequest) error {
-	ret := _m.Called(userID, communityID, reportReq)
+// ReportByModerator provides a mock function with given fields: ctx, userID, communityID, reportReq
+func (_m *CommunityUsecase) ReportByModerator(ctx context.Context, userID uint, communityID uint, reportReq dto.ReportRequest) error {
+	ret := _m.Called(ctx, userID, communityID, reportReq)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint, uint, dto.ReportRequest) error); ok {
-		r0 = rf(userID, communityID, reportReq)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, dto.ReportRequest) error); ok {
+		r0 = rf(ctx, userID, communityID, reportReq)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -212,13 +248,13 @@ func (_m *CommunityUsecase) ReportByModerator(userID uint, communityID uint, rep
 	return r0
 }
 
-// ReportCommunity provides a mock function with given fields: userID, communityID, reportCategoryID
-func (_m *CommunityUsecase) ReportCommunity(userID uint, communityID uint, reportCategoryID uint) error {
-	ret := _m.Called(userID, communityID, reportCategoryID)
+// ReportCommunity provides a mock function with given fields: ctx, userID, communityID, reportCategoryID
+func (_m *CommunityUsecase) ReportCommunity(ctx context.Context, userID uint, communityID uint, reportCategoryID uint) error {
+	ret := _m.Called(ctx, userID, communityID, reportCategoryID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint, uint, uint) error); ok {
-		r0 = rf(userID, communityID, reportCategoryID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, uint) error); ok {
+		r0 = rf(ctx, userID, communityID, reportCategoryID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -226,20 +262,20 @@ func (_m *CommunityUsecase) ReportCommunity(userID uint, communityID uint, repor
 	return r0
 }
 
-// SetBackgroundImage provides a mock function with given fields: id, img, role
-func (_m *CommunityUsecase) SetBackgroundImage(id uint, img *multipart.FileHeader, role string) (string, error) {
-	ret := _m.Called(id, img, role)
+// SetBackgroundImage provides a mock function with given fields: ctx, id, img, role
+func (_m *CommunityUsecase) SetBackgroundImage(ctx context.Context, id uint, img *multipart.FileHeader, role string) (string, error) {
+	ret := _m.Called(ctx, id, img, role)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(uint, *multipart.FileHeader, string) string); ok {
-		r0 = rf(id, img, role)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, *multipart.FileHeader, string) string); ok {
+		r0 = rf(ctx, id, img, role)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint, *multipart.FileHeader, string) error); ok {
-		r1 = rf(id, img, role)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, *multipart.FileHeader, string) error); ok {
+		r1 = rf(ctx, id, img, role)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -247,20 +283,20 @@ func (_m *CommunityUsecase) SetBackgroundImage(id uint, img *multipart.FileHeade
 	return r0, r1
 }
 
-// SetImage provides a mock function with given fields: id, img, role
-func (_m *CommunityUsecase) SetImage(id uint, img *multipart.FileHeader, role string) (string, error) {
-	ret := _m.Called(id, img, role)
+// SetImage provides a mock function with given fields: ctx, id, img, role
+func (_m *CommunityUsecase) SetImage(ctx context.Context, id uint, img *multipart.FileHeader, role string) (string, error) {
+	ret := _m.Called(ctx, id, img, role)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(uint, *multipart.FileHeader, string) string); ok {
-		r0 = rf(id, img, role)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, *multipart.FileHeader, string) string); ok {
+		r0 = rf(ctx, id, img, role)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint, *multipart.FileHeader, string) error); ok {
-		r1 = rf(id, img, role)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, *multipart.FileHeader, string) error); ok {
+		r1 = rf(ctx, id, img, role)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -268,13 +304,27 @@ func (_m *CommunityUsecase) SetImage(id uint, img *multipart.FileHeader, role st
 	return r0, r1
 }
 
-// StoreCommunity provides a mock function with given fields: _a0, role
-func (_m *CommunityUsecase) StoreCommunity(_a0 dto.CommunityRequest, role string) error {
-	ret := _m.Called(_a0, role)
+// StoreCommunity provides a mock function with given fields: ctx, _a1, role
+func (_m *CommunityUsecase) StoreCommunity(ctx context.Context, _a1 dto.CommunityRequest, role string) error {
+	ret := _m.Called(ctx, _a1, role)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.CommunityRequest, string) error); ok {
+		r0 = rf(ctx, _a1, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnfollowCommunity provides a mock function with given fields: ctx, userID, communityID
+func (_m *CommunityUsecase) UnfollowCommunity(ctx context.Context, userID uint, communityID uint) error {
+	ret := _m.Called(ctx, userID, communityID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(dto.CommunityRequest, string) error); ok {
-		r0 = rf(_a0, role)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, userID, communityID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -282,13 +332,13 @@ func (_m *CommunityUsecase) StoreCommunity(_a0 dto.CommunityRequest, role string
 	return r0
 }
 
-// UnfollowCommunity provides a mock function with given fields: userID, communityID
-func (_m *CommunityUsecase) UnfollowCommunity(userID uint, communityID uint) error {
-	ret := _m.Called(userID, communityID)
+// UnmuteCommunity provides a mock function with given fields: ctx, userID, communityID
+func (_m *CommunityUsecase) UnmuteCommunity(ctx context.Context, userID uint, communityID uint) error {
+	ret := _m.Called(ctx, userID, communityID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint, uint) error); ok {
-		r0 = rf(userID, communityID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, userID, communityID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -296,20 +346,20 @@ func (_m *CommunityUsecase) UnfollowCommunity(userID uint, communityID uint) err
 	return r0
 }
 
-// UpdateCommunity provides a mock function with given fields: id, _a1, role
-func (_m *CommunityUsecase) UpdateCommunity(id uint, _a1 dto.CommunityRequest, role string) (dto.CommunityUpdateResponse, error) {
-	ret := _m.Called(id, _a1, role)
+// UpdateCommunity provides a mock function with given fields: ctx, id, _a2, role
+func (_m *CommunityUsecase) UpdateCommunity(ctx context.Context, id uint, _a2 dto.CommunityRequest, role string) (dto.CommunityUpdateResponse, error) {
+	ret := _m.Called(ctx, id, _a2, role)
 
 	var r0 dto.CommunityUpdateResponse
-	if rf, ok := ret.Get(0).(func(uint, dto.CommunityRequest, string) dto.CommunityUpdateResponse); ok {
-		r0 = rf(id, _a1, role)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, dto.CommunityRequest, string) dto.CommunityUpdateResponse); ok {
+		r0 = rf(ctx, id, _a2, role)
 	} else {
 		r0 = ret.Get(0).(dto.CommunityUpdateResponse)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint, dto.CommunityRequest, string) error); ok {
-		r1 = rf(id, _a1, role)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, dto.CommunityRequest, string) error); ok {
+		r1 = rf(ctx, id, _a2, role)
 	} else {
 		r1 = ret.Error(1)
 	}
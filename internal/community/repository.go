@@ -1,6 +1,7 @@
 package community
 
 import (
+	"context"
 	"macaiki/internal/community/entity"
 	communityEntity "macaiki/internal/community/entity"
 	threadEntity "macaiki/internal/thread/entity"
@@ -8,26 +9,30 @@ import (
 )
 
 type CommunityRepository interface {
-	GetAllCommunities(userID uint, search string) ([]communityEntity.Community, error)
-	GetCommunityWithDetail(userID, communityID uint) (communityEntity.Community, error)
-	GetCommunity(id uint) (communityEntity.Community, error)
-	GetCommunityThread(userID, communityID uint) ([]threadEntity.ThreadWithDetails, error)
-	GetCommunityAbout(userID, communityID uint) (communityEntity.Community, error)
-	StoreCommunity(community communityEntity.Community) error
-	UpdateCommunity(community communityEntity.Community, communityReq communityEntity.Community) (communityEntity.Community, error)
-	DeleteCommunity(communityID uint) error
+	GetAllCommunities(ctx context.Context, userID uint, search string) ([]communityEntity.Community, error)
+	GetCommunityWithDetail(ctx context.Context, userID, communityID uint) (communityEntity.Community, error)
+	GetCommunity(ctx context.Context, id uint) (communityEntity.Community, error)
+	GetCommunityThread(ctx context.Context, userID, communityID uint) ([]threadEntity.ThreadWithDetails, error)
+	GetCommunityAbout(ctx context.Context, userID, communityID uint) (communityEntity.Community, error)
+	StoreCommunity(ctx context.Context, community communityEntity.Community) error
+	UpdateCommunity(ctx context.Context, community communityEntity.Community, communityReq communityEntity.Community) (communityEntity.Community, error)
+	DeleteCommunity(ctx context.Context, communityID uint) error
 
-	FollowCommunity(user userEntity.User, community communityEntity.Community) error
-	UnfollowCommunity(user userEntity.User, community communityEntity.Community) error
+	FollowCommunity(ctx context.Context, user userEntity.User, community communityEntity.Community) error
+	UnfollowCommunity(ctx context.Context, user userEntity.User, community communityEntity.Community) error
 
-	SetCommunityImage(id uint, imageURL string, tableName string) error
-	AddModerator(user userEntity.User, community communityEntity.Community) error
-	RemoveModerator(user userEntity.User, community communityEntity.Community) error
-	GetModeratorByCommunityID(userID, communityID uint) ([]userEntity.User, error)
-	GetModeratorByUserID(userID, communityID uint) (entity.CommunityModerator, error)
+	SetCommunityImage(ctx context.Context, id uint, imageURL string, tableName string) error
+	AddModerator(ctx context.Context, user userEntity.User, community communityEntity.Community) error
+	RemoveModerator(ctx context.Context, user userEntity.User, community communityEntity.Community) error
+	GetModeratorByCommunityID(ctx context.Context, userID, communityID uint) ([]userEntity.User, error)
+	GetModeratorByUserID(ctx context.Context, userID, communityID uint) (entity.CommunityModerator, error)
 
-	StoreReportCommunity(communityReport communityEntity.CommunityReport) error
-	UpdateReportCommunity(communityReport communityEntity.CommunityReport, userID uint) error
-	GetReportCommunity(id uint) (communityEntity.CommunityReport, error)
-	GetReports(communityID uint) ([]entity.BriefReport, error)
+	StoreReportCommunity(ctx context.Context, communityReport communityEntity.CommunityReport) error
+	UpdateReportCommunity(ctx context.Context, communityReport communityEntity.CommunityReport, userID uint) error
+	GetReportCommunity(ctx context.Context, id uint) (communityEntity.CommunityReport, error)
+	GetReports(ctx context.Context, communityID uint) ([]entity.BriefReport, error)
+
+	MuteCommunity(ctx context.Context, userID, communityID uint) error
+	UnmuteCommunity(ctx context.Context, userID, communityID uint) error
+	GetMutedCommunities(ctx context.Context, userID uint) ([]communityEntity.Community, error)
 }
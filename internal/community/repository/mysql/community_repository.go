@@ -1,30 +1,34 @@
 package mysql
 
 import (
+	"context"
 	"errors"
-	"fmt"
+	"log/slog"
 	"macaiki/internal/community"
 	"macaiki/internal/community/entity"
 	communityEntity "macaiki/internal/community/entity"
 	threadEntity "macaiki/internal/thread/entity"
 	userEntity "macaiki/internal/user/entity"
+	"macaiki/pkg/logger"
 	"macaiki/pkg/utils"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type CommunityRepositoryImpl struct {
-	db *gorm.DB
+	db     *gorm.DB
+	logger *slog.Logger
 }
 
-func NewCommunityRepository(db *gorm.DB) community.CommunityRepository {
-	return &CommunityRepositoryImpl{db}
+func NewCommunityRepository(db *gorm.DB, log *slog.Logger) community.CommunityRepository {
+	return &CommunityRepositoryImpl{db, logger.OrDefault(log)}
 }
 
-func (cr *CommunityRepositoryImpl) GetAllCommunities(userID uint, search string) ([]communityEntity.Community, error) {
+func (cr *CommunityRepositoryImpl) GetAllCommunities(ctx context.Context, userID uint, search string) ([]communityEntity.Community, error) {
 	communities := []communityEntity.Community{}
 
-	res := cr.db.Raw("SELECT c.*, !isnull(cf.user_id) AS is_followed, !isnull(cm.user_id) AS is_moderator FROM `communities` AS c LEFT JOIN (SELECT * FROM community_followers WHERE user_id = ?) AS cf ON c.id = cf.community_id LEFT JOIN (SELECT * FROM community_moderators WHERE user_id = ?) AS cm ON c.id = cm.community_id WHERE c.deleted_at IS NULL AND c.name LIKE ? ORDER BY is_moderator DESC", userID, userID, "%"+search+"%").Scan(&communities)
+	res := cr.db.WithContext(ctx).Raw("SELECT c.*, !isnull(cf.user_id) AS is_followed, !isnull(cm.user_id) AS is_moderator FROM `communities` AS c LEFT JOIN (SELECT * FROM community_followers WHERE user_id = ?) AS cf ON c.id = cf.community_id LEFT JOIN (SELECT * FROM community_moderators WHERE user_id = ?) AS cm ON c.id = cm.community_id WHERE c.deleted_at IS NULL AND c.name LIKE ? ORDER BY is_moderator DESC", userID, userID, "%"+search+"%").Scan(&communities)
 	err := res.Error
 	if err != nil {
 		return []communityEntity.Community{}, err
@@ -33,10 +37,10 @@ func (cr *CommunityRepositoryImpl) GetAllCommunities(userID uint, search string)
 	return communities, nil
 }
 
-func (cr *CommunityRepositoryImpl) GetCommunityWithDetail(userID, communityID uint) (communityEntity.Community, error) {
+func (cr *CommunityRepositoryImpl) GetCommunityWithDetail(ctx context.Context, userID, communityID uint) (communityEntity.Community, error) {
 	community := communityEntity.Community{}
 
-	res := cr.db.Raw("SELECT c.*, !isnull(cf.user_id) AS is_followed, !isnull(cm.user_id) AS is_moderator FROM `communities` AS c LEFT JOIN (SELECT * FROM community_followers WHERE user_id = ?) AS cf ON c.id = cf.community_id LEFT JOIN (SELECT * FROM community_moderators WHERE user_id = ?) AS cm ON c.id = cm.community_id WHERE c.deleted_at IS NULL AND c.id = ?", userID, userID, communityID).Scan(&community)
+	res := cr.db.WithContext(ctx).Raw("SELECT c.*, !isnull(cf.user_id) AS is_followed, !isnull(cm.user_id) AS is_moderator FROM `communities` AS c LEFT JOIN (SELECT * FROM community_followers WHERE user_id = ?) AS cf ON c.id = cf.community_id LEFT JOIN (SELECT * FROM community_moderators WHERE user_id = ?) AS cm ON c.id = cm.community_id WHERE c.deleted_at IS NULL AND c.id = ?", userID, userID, communityID).Scan(&community)
 	err := res.Error
 
 	if err != nil {
@@ -46,10 +50,10 @@ func (cr *CommunityRepositoryImpl) GetCommunityWithDetail(userID, communityID ui
 	return community, nil
 }
 
-func (cr *CommunityRepositoryImpl) GetCommunity(id uint) (communityEntity.Community, error) {
+func (cr *CommunityRepositoryImpl) GetCommunity(ctx context.Context, id uint) (communityEntity.Community, error) {
 	community := communityEntity.Community{}
 
-	res := cr.db.Find(&community, id)
+	res := cr.db.WithContext(ctx).Find(&community, id)
 	err := res.Error
 
 	if err != nil {
@@ -59,9 +63,9 @@ func (cr *CommunityRepositoryImpl) GetCommunity(id uint) (communityEntity.Commun
 	return community, nil
 }
 
-func (cr *CommunityRepositoryImpl) GetCommunityThread(userID, communityID uint) ([]threadEntity.ThreadWithDetails, error) {
+func (cr *CommunityRepositoryImpl) GetCommunityThread(ctx context.Context, userID, communityID uint) ([]threadEntity.ThreadWithDetails, error) {
 	threads := []threadEntity.ThreadWithDetails{}
-	res := cr.db.Raw("SELECT t.*, tlc.count AS upvotes_count, !isnull(tl.user_id) AS is_upvoted, u.*, (u.id = ?) AS is_mine FROM threads AS t LEFT JOIN (SELECT t.thread_id, COUNT(*) AS count FROM thread_upvotes AS t GROUP BY t.thread_id) AS tlc ON t.id = tlc.thread_id LEFT JOIN (SELECT * FROM thread_upvotes WHERE user_id = ?) AS tl ON tl.thread_id = t.id LEFT JOIN (SELECT u.*, !ISNULL(uf.user_id) AS is_followed FROM `users` AS u LEFT JOIN (SELECT * FROM user_followers WHERE follower_id = ?) AS uf ON u.id = uf.user_id WHERE u.deleted_at IS NULL) AS u ON u.id = t.user_id WHERE t.community_id = ? AND t.deleted_at IS NULL", userID, userID, userID, communityID).Scan(&threads)
+	res := cr.db.WithContext(ctx).Raw("SELECT t.*, COALESCE(tv.value, 0) AS user_vote, u.*, (u.id = ?) AS is_mine FROM threads AS t LEFT JOIN thread_votes tv ON tv.thread_id = t.id AND tv.user_id = ? LEFT JOIN (SELECT u.*, !ISNULL(uf.user_id) AS is_followed FROM `users` AS u LEFT JOIN (SELECT * FROM user_followers WHERE follower_id = ?) AS uf ON u.id = uf.user_id WHERE u.deleted_at IS NULL) AS u ON u.id = t.user_id WHERE t.community_id = ? AND t.deleted_at IS NULL AND t.status = 'published' AND NOT EXISTS (SELECT 1 FROM hidden_threads ht WHERE ht.thread_id = t.id AND ht.user_id = ?)", userID, userID, userID, communityID, userID).Scan(&threads)
 	err := res.Error
 	if err != nil {
 		return []threadEntity.ThreadWithDetails{}, err
@@ -70,10 +74,10 @@ func (cr *CommunityRepositoryImpl) GetCommunityThread(userID, communityID uint)
 	return threads, nil
 }
 
-func (cr *CommunityRepositoryImpl) GetCommunityAbout(userID, communityID uint) (communityEntity.Community, error) {
+func (cr *CommunityRepositoryImpl) GetCommunityAbout(ctx context.Context, userID, communityID uint) (communityEntity.Community, error) {
 	community := communityEntity.Community{}
 
-	res := cr.db.Raw("SELECT c.*, cm.total_moderators, cf.total_followers, cm2.is_moderator FROM communities AS c LEFT JOIN (SELECT community_id, COUNT(*) AS total_followers FROM community_followers GROUP BY community_id) AS cf ON c.id = cf.community_id LEFT JOIN (SELECT community_id, COUNT(*) AS total_moderators FROM community_moderators GROUP BY community_id) AS cm ON c.id = cm.community_id LEFT JOIN (SELECT !ISNULL(user_id) AS is_moderator FROM community_moderators WHERE user_id = ?) AS cm2 ON  c.id = cm.community_id WHERE c.id = ?", userID, communityID).Scan(&community)
+	res := cr.db.WithContext(ctx).Raw("SELECT c.*, cm.total_moderators, cf.total_followers, cm2.is_moderator FROM communities AS c LEFT JOIN (SELECT community_id, COUNT(*) AS total_followers FROM community_followers GROUP BY community_id) AS cf ON c.id = cf.community_id LEFT JOIN (SELECT community_id, COUNT(*) AS total_moderators FROM community_moderators GROUP BY community_id) AS cm ON c.id = cm.community_id LEFT JOIN (SELECT !ISNULL(user_id) AS is_moderator FROM community_moderators WHERE user_id = ?) AS cm2 ON  c.id = cm.community_id WHERE c.id = ?", userID, communityID).Scan(&community)
 	err := res.Error
 
 	if err != nil {
@@ -83,8 +87,8 @@ func (cr *CommunityRepositoryImpl) GetCommunityAbout(userID, communityID uint) (
 	return community, nil
 }
 
-func (cr *CommunityRepositoryImpl) StoreCommunity(community communityEntity.Community) error {
-	res := cr.db.Create(&community)
+func (cr *CommunityRepositoryImpl) StoreCommunity(ctx context.Context, community communityEntity.Community) error {
+	res := cr.db.WithContext(ctx).Create(&community)
 	err := res.Error
 	if err != nil {
 		return err
@@ -93,9 +97,9 @@ func (cr *CommunityRepositoryImpl) StoreCommunity(community communityEntity.Comm
 	return nil
 }
 
-func (cr *CommunityRepositoryImpl) UpdateCommunity(community communityEntity.Community, communityReq communityEntity.Community) (communityEntity.Community, error) {
+func (cr *CommunityRepositoryImpl) UpdateCommunity(ctx context.Context, community communityEntity.Community, communityReq communityEntity.Community) (communityEntity.Community, error) {
 
-	res := cr.db.Model(&community).Updates(communityReq)
+	res := cr.db.WithContext(ctx).Model(&community).Updates(communityReq)
 	err := res.Error
 	if err != nil {
 		return communityEntity.Community{}, err
@@ -104,16 +108,16 @@ func (cr *CommunityRepositoryImpl) UpdateCommunity(community communityEntity.Com
 	return community, nil
 }
 
-func (cr *CommunityRepositoryImpl) DeleteCommunity(communityID uint) error {
-	res := cr.db.Delete(&entity.Community{}, communityID)
+func (cr *CommunityRepositoryImpl) DeleteCommunity(ctx context.Context, communityID uint) error {
+	res := cr.db.WithContext(ctx).Delete(&entity.Community{}, communityID)
 	err := res.Error
 	if err != nil {
 		return err
 	}
 	return nil
 }
-func (cr *CommunityRepositoryImpl) FollowCommunity(user userEntity.User, community communityEntity.Community) error {
-	err := cr.db.Model(&community).Association("Followers").Append(&user)
+func (cr *CommunityRepositoryImpl) FollowCommunity(ctx context.Context, user userEntity.User, community communityEntity.Community) error {
+	err := cr.db.WithContext(ctx).Model(&community).Association("Followers").Append(&user)
 	if err != nil {
 		return err
 	}
@@ -121,8 +125,8 @@ func (cr *CommunityRepositoryImpl) FollowCommunity(user userEntity.User, communi
 	return nil
 }
 
-func (cr *CommunityRepositoryImpl) UnfollowCommunity(user userEntity.User, community communityEntity.Community) error {
-	err := cr.db.Model(&community).Association("Followers").Delete(&user)
+func (cr *CommunityRepositoryImpl) UnfollowCommunity(ctx context.Context, user userEntity.User, community communityEntity.Community) error {
+	err := cr.db.WithContext(ctx).Model(&community).Association("Followers").Delete(&user)
 	if err != nil {
 		return err
 	}
@@ -130,8 +134,8 @@ func (cr *CommunityRepositoryImpl) UnfollowCommunity(user userEntity.User, commu
 	return nil
 }
 
-func (cr *CommunityRepositoryImpl) SetCommunityImage(id uint, imageURL string, tableName string) error {
-	res := cr.db.Model(&communityEntity.Community{}).Where("id = ?", id).Update(tableName, imageURL)
+func (cr *CommunityRepositoryImpl) SetCommunityImage(ctx context.Context, id uint, imageURL string, tableName string) error {
+	res := cr.db.WithContext(ctx).Model(&communityEntity.Community{}).Where("id = ?", id).Update(tableName, imageURL)
 
 	if res.Error != nil {
 		return res.Error
@@ -144,8 +148,8 @@ func (cr *CommunityRepositoryImpl) SetCommunityImage(id uint, imageURL string, t
 	return nil
 }
 
-func (cr *CommunityRepositoryImpl) AddModerator(user userEntity.User, community communityEntity.Community) error {
-	err := cr.db.Model(&community).Association("Moderators").Append(&user)
+func (cr *CommunityRepositoryImpl) AddModerator(ctx context.Context, user userEntity.User, community communityEntity.Community) error {
+	err := cr.db.WithContext(ctx).Model(&community).Association("Moderators").Append(&user)
 	if err != nil {
 		return err
 	}
@@ -153,8 +157,8 @@ func (cr *CommunityRepositoryImpl) AddModerator(user userEntity.User, community
 	return nil
 }
 
-func (cr *CommunityRepositoryImpl) RemoveModerator(user userEntity.User, community communityEntity.Community) error {
-	err := cr.db.Model(&community).Association("Moderators").Delete(&user)
+func (cr *CommunityRepositoryImpl) RemoveModerator(ctx context.Context, user userEntity.User, community communityEntity.Community) error {
+	err := cr.db.WithContext(ctx).Model(&community).Association("Moderators").Delete(&user)
 	if err != nil {
 		return err
 	}
@@ -162,10 +166,10 @@ func (cr *CommunityRepositoryImpl) RemoveModerator(user userEntity.User, communi
 	return nil
 }
 
-func (cr *CommunityRepositoryImpl) GetModeratorByCommunityID(userID, communityID uint) ([]userEntity.User, error) {
+func (cr *CommunityRepositoryImpl) GetModeratorByCommunityID(ctx context.Context, userID, communityID uint) ([]userEntity.User, error) {
 	users := []userEntity.User{}
 
-	res := cr.db.Raw("SELECT u.*, !ISNULL(uf.user_id) AS is_followed, (u.id = ?) AS is_mine FROM `users` AS u LEFT JOIN (SELECT * FROM user_followers WHERE follower_id = ?) AS uf ON u.id = uf.user_id LEFT JOIN community_moderators AS cm ON cm.user_id = u.id WHERE u.deleted_at IS NULL AND cm.community_id = ?", userID, userID, communityID).Scan(&users)
+	res := cr.db.WithContext(ctx).Raw("SELECT u.*, !ISNULL(uf.user_id) AS is_followed, (u.id = ?) AS is_mine FROM `users` AS u LEFT JOIN (SELECT * FROM user_followers WHERE follower_id = ?) AS uf ON u.id = uf.user_id LEFT JOIN community_moderators AS cm ON cm.user_id = u.id WHERE u.deleted_at IS NULL AND cm.community_id = ?", userID, userID, communityID).Scan(&users)
 	err := res.Error
 	if err != nil {
 		return []userEntity.User{}, err
@@ -174,8 +178,8 @@ func (cr *CommunityRepositoryImpl) GetModeratorByCommunityID(userID, communityID
 	return users, nil
 }
 
-func (cr *CommunityRepositoryImpl) StoreReportCommunity(communityReport communityEntity.CommunityReport) error {
-	res := cr.db.Create(&communityReport)
+func (cr *CommunityRepositoryImpl) StoreReportCommunity(ctx context.Context, communityReport communityEntity.CommunityReport) error {
+	res := cr.db.WithContext(ctx).Create(&communityReport)
 	err := res.Error
 	if err != nil {
 		return err
@@ -184,8 +188,8 @@ func (cr *CommunityRepositoryImpl) StoreReportCommunity(communityReport communit
 	return nil
 }
 
-func (cr *CommunityRepositoryImpl) UpdateReportCommunity(communityReport communityEntity.CommunityReport, userID uint) error {
-	res := cr.db.Model(&communityReport).Update("user_id", userID)
+func (cr *CommunityRepositoryImpl) UpdateReportCommunity(ctx context.Context, communityReport communityEntity.CommunityReport, userID uint) error {
+	res := cr.db.WithContext(ctx).Model(&communityReport).Update("user_id", userID)
 	err := res.Error
 	if err != nil {
 		return err
@@ -194,9 +198,9 @@ func (cr *CommunityRepositoryImpl) UpdateReportCommunity(communityReport communi
 	return nil
 }
 
-func (cr *CommunityRepositoryImpl) GetReportCommunity(id uint) (communityEntity.CommunityReport, error) {
+func (cr *CommunityRepositoryImpl) GetReportCommunity(ctx context.Context, id uint) (communityEntity.CommunityReport, error) {
 	comReport := communityEntity.CommunityReport{}
-	res := cr.db.Find(&comReport, id)
+	res := cr.db.WithContext(ctx).Find(&comReport, id)
 	err := res.Error
 	if err != nil {
 		return communityEntity.CommunityReport{}, err
@@ -205,10 +209,10 @@ func (cr *CommunityRepositoryImpl) GetReportCommunity(id uint) (communityEntity.
 	return comReport, nil
 }
 
-func (cr *CommunityRepositoryImpl) GetReports(communityID uint) ([]communityEntity.BriefReport, error) {
+func (cr *CommunityRepositoryImpl) GetReports(ctx context.Context, communityID uint) ([]communityEntity.BriefReport, error) {
 	var reports []communityEntity.BriefReport
 
-	res := cr.db.Raw("SELECT tr.id AS 'thread_reports_id', NULL AS 'community_reports_id', NULL AS 'comment_reports_id', tr.created_at, tr.user_id, tr.thread_id, NULL AS community_reported_id, NULL as comment_id, rc.name AS report_category, u.username, u.profile_image_url, 'threads' AS type FROM thread_reports tr INNER JOIN report_categories rc ON tr.report_category_id = rc.id INNER JOIN users u ON u.id = tr.user_id INNER JOIN threads t ON tr.thread_id = t.id WHERE tr.deleted_at IS NULL AND tr.user_id NOT IN (SELECT cm.user_id FROM community_moderators cm) AND t.community_id = ? UNION SELECT NULL AS 'thread_reports_id', cr2.id AS 'community_reports_id', NULL AS 'comment_reports_id', cr2.created_at, cr2.user_id, NULL AS thread_id, cr2.community_reported_id, NULL AS comment_id, rc.name AS report_category, u.username, u.profile_image_url, 'users' AS type FROM community_reports cr2 INNER JOIN report_categories rc ON cr2.report_category_id = rc.id INNER JOIN users u ON u.id = cr2.user_id  WHERE cr2.deleted_at IS NULL AND cr2.user_id NOT IN (SELECT cm.user_id FROM community_moderators cm) AND cr2.community_reported_id = ? UNION SELECT NULL AS 'thread_reports_id', NULL AS 'community_reports_id', cr.id AS 'comment_reports_id', cr.created_at, cr.user_id, NULL AS thread_id, NULL AS community_reported_id, cr.comment_id, rc.name AS report_category, u.username, u.profile_image_url, 'comments' AS type FROM comment_reports cr INNER JOIN report_categories rc ON cr.report_category_id = rc.id INNER JOIN users u ON u.id = cr.user_id INNER JOIN comments c ON c.id = cr.comment_id INNER JOIN threads t ON c.thread_id = t.id WHERE cr.deleted_at IS NULL AND cr.user_id NOT IN (SELECT cm.user_id FROM community_moderators cm) AND t.community_id = ?;", communityID, communityID, communityID).Scan(&reports)
+	res := cr.db.WithContext(ctx).Raw("SELECT tr.id AS 'thread_reports_id', NULL AS 'community_reports_id', NULL AS 'comment_reports_id', tr.created_at, tr.user_id, tr.thread_id, NULL AS community_reported_id, NULL as comment_id, rc.name AS report_category, u.username, u.profile_image_url, 'threads' AS type FROM thread_reports tr INNER JOIN report_categories rc ON tr.report_category_id = rc.id INNER JOIN users u ON u.id = tr.user_id INNER JOIN threads t ON tr.thread_id = t.id WHERE tr.deleted_at IS NULL AND tr.user_id NOT IN (SELECT cm.user_id FROM community_moderators cm) AND t.community_id = ? UNION SELECT NULL AS 'thread_reports_id', cr2.id AS 'community_reports_id', NULL AS 'comment_reports_id', cr2.created_at, cr2.user_id, NULL AS thread_id, cr2.community_reported_id, NULL AS comment_id, rc.name AS report_category, u.username, u.profile_image_url, 'users' AS type FROM community_reports cr2 INNER JOIN report_categories rc ON cr2.report_category_id = rc.id INNER JOIN users u ON u.id = cr2.user_id  WHERE cr2.deleted_at IS NULL AND cr2.user_id NOT IN (SELECT cm.user_id FROM community_moderators cm) AND cr2.community_reported_id = ? UNION SELECT NULL AS 'thread_reports_id', NULL AS 'community_reports_id', cr.id AS 'comment_reports_id', cr.created_at, cr.user_id, NULL AS thread_id, NULL AS community_reported_id, cr.comment_id, rc.name AS report_category, u.username, u.profile_image_url, 'comments' AS type FROM comment_reports cr INNER JOIN report_categories rc ON cr.report_category_id = rc.id INNER JOIN users u ON u.id = cr.user_id INNER JOIN comments c ON c.id = cr.comment_id INNER JOIN threads t ON c.thread_id = t.id WHERE cr.deleted_at IS NULL AND cr.user_id NOT IN (SELECT cm.user_id FROM community_moderators cm) AND t.community_id = ?;", communityID, communityID, communityID).Scan(&reports)
 
 	if res.Error != nil {
 		return []communityEntity.BriefReport{}, utils.ErrInternalServerError
@@ -217,13 +221,13 @@ func (cr *CommunityRepositoryImpl) GetReports(communityID uint) ([]communityEnti
 	return reports, nil
 }
 
-func (cr *CommunityRepositoryImpl) GetModeratorByUserID(userID, communityID uint) (communityEntity.CommunityModerator, error) {
+func (cr *CommunityRepositoryImpl) GetModeratorByUserID(ctx context.Context, userID, communityID uint) (communityEntity.CommunityModerator, error) {
 	var communityMods communityEntity.CommunityModerator
 
-	res := cr.db.Where("user_id = ? AND community_id = ?", userID, communityID).Find(&communityMods)
+	res := cr.db.WithContext(ctx).Where("user_id = ? AND community_id = ?", userID, communityID).Find(&communityMods)
 
 	if res.Error != nil {
-		fmt.Println(res.Error)
+		cr.logger.ErrorContext(ctx, "query failed", "op", "GetModeratorByUserID", "err", res.Error)
 		if res.Error.Error() == "record not found" {
 			return communityEntity.CommunityModerator{}, utils.ErrNotFound
 		}
@@ -232,3 +236,41 @@ func (cr *CommunityRepositoryImpl) GetModeratorByUserID(userID, communityID uint
 
 	return communityMods, nil
 }
+
+func (cr *CommunityRepositoryImpl) MuteCommunity(ctx context.Context, userID, communityID uint) error {
+	muted := communityEntity.MutedCommunity{UserID: userID, CommunityID: communityID}
+	res := cr.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&muted)
+	if res.Error != nil {
+		cr.logger.ErrorContext(ctx, "query failed", "op", "MuteCommunity", "err", res.Error)
+		return utils.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (cr *CommunityRepositoryImpl) UnmuteCommunity(ctx context.Context, userID, communityID uint) error {
+	res := cr.db.WithContext(ctx).Where("user_id = ? AND community_id = ?", userID, communityID).Delete(&communityEntity.MutedCommunity{})
+	if res.Error != nil {
+		cr.logger.ErrorContext(ctx, "query failed", "op", "UnmuteCommunity", "err", res.Error)
+		return utils.ErrInternalServerError
+	}
+	if res.RowsAffected == 0 {
+		return utils.ErrNotFound
+	}
+
+	return nil
+}
+
+// GetMutedCommunities lists the communities userID muted, most recently
+// muted first
+func (cr *CommunityRepositoryImpl) GetMutedCommunities(ctx context.Context, userID uint) ([]communityEntity.Community, error) {
+	communities := []communityEntity.Community{}
+
+	res := cr.db.WithContext(ctx).Raw("SELECT c.*, !isnull(cf.user_id) AS is_followed, !isnull(cm.user_id) AS is_moderator FROM `communities` AS c INNER JOIN muted_communities mc ON mc.community_id = c.id LEFT JOIN (SELECT * FROM community_followers WHERE user_id = ?) AS cf ON c.id = cf.community_id LEFT JOIN (SELECT * FROM community_moderators WHERE user_id = ?) AS cm ON c.id = cm.community_id WHERE mc.user_id = ? AND c.deleted_at IS NULL ORDER BY mc.created_at DESC, mc.id DESC", userID, userID, userID).Scan(&communities)
+	if res.Error != nil {
+		cr.logger.ErrorContext(ctx, "query failed", "op", "GetMutedCommunities", "err", res.Error)
+		return []communityEntity.Community{}, utils.ErrInternalServerError
+	}
+
+	return communities, nil
+}
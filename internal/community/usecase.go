@@ -1,30 +1,35 @@
 package community
 
 import (
+	"context"
 	dtoCommunity "macaiki/internal/community/dto"
 	dtoThread "macaiki/internal/thread/dto"
 	"mime/multipart"
 )
 
 type CommunityUsecase interface {
-	GetAllCommunities(userID int, search string) ([]dtoCommunity.CommunityDetailResponse, error)
-	GetCommunity(userID, communityID uint) (dtoCommunity.CommunityDetailResponse, error)
-	GetCommunityAbout(userID, communityID uint) (dtoCommunity.CommunityAboutResponse, error)
-	StoreCommunity(community dtoCommunity.CommunityRequest, role string) error
-	UpdateCommunity(id uint, community dtoCommunity.CommunityRequest, role string) (dtoCommunity.CommunityUpdateResponse, error)
-	DeleteCommunity(id uint, role string) error
+	GetAllCommunities(ctx context.Context, userID int, search string) ([]dtoCommunity.CommunityDetailResponse, error)
+	GetCommunity(ctx context.Context, userID, communityID uint) (dtoCommunity.CommunityDetailResponse, error)
+	GetCommunityAbout(ctx context.Context, userID, communityID uint) (dtoCommunity.CommunityAboutResponse, error)
+	StoreCommunity(ctx context.Context, community dtoCommunity.CommunityRequest, role string) error
+	UpdateCommunity(ctx context.Context, id uint, community dtoCommunity.CommunityRequest, role string) (dtoCommunity.CommunityUpdateResponse, error)
+	DeleteCommunity(ctx context.Context, id uint, role string) error
 
-	FollowCommunity(userID, communityID uint) error
-	UnfollowCommunity(userID, communityID uint) error
-	SetImage(id uint, img *multipart.FileHeader, role string) (string, error)
-	SetBackgroundImage(id uint, img *multipart.FileHeader, role string) (string, error)
+	FollowCommunity(ctx context.Context, userID, communityID uint) error
+	UnfollowCommunity(ctx context.Context, userID, communityID uint) error
+	SetImage(ctx context.Context, id uint, img *multipart.FileHeader, role string) (string, error)
+	SetBackgroundImage(ctx context.Context, id uint, img *multipart.FileHeader, role string) (string, error)
 
-	GetThreadCommunity(userID, communityID uint) ([]dtoThread.DetailedThreadResponse, error)
-	AddModerator(moderatorReq dtoCommunity.CommunityModeratorRequest, role string) error
-	RemoveModerator(moderatorReq dtoCommunity.CommunityModeratorRequest, role string) error
+	GetThreadCommunity(ctx context.Context, userID, communityID uint) ([]dtoThread.DetailedThreadResponse, error)
+	AddModerator(ctx context.Context, moderatorReq dtoCommunity.CommunityModeratorRequest, role string) error
+	RemoveModerator(ctx context.Context, moderatorReq dtoCommunity.CommunityModeratorRequest, role string) error
 
-	ReportCommunity(userID, communityID, reportCategoryID uint) error
-	DeleteReportCommunity(reportCommunityId uint) error
-	ReportByModerator(userID, communityID uint, reportReq dtoCommunity.ReportRequest) error
-	GetReports(userID, communityID uint) ([]dtoCommunity.BriefReportResponse, error)
+	ReportCommunity(ctx context.Context, userID, communityID, reportCategoryID uint) error
+	DeleteReportCommunity(ctx context.Context, reportCommunityId uint) error
+	ReportByModerator(ctx context.Context, userID, communityID uint, reportReq dtoCommunity.ReportRequest) error
+	GetReports(ctx context.Context, userID, communityID uint) ([]dtoCommunity.BriefReportResponse, error)
+
+	MuteCommunity(ctx context.Context, userID, communityID uint) error
+	UnmuteCommunity(ctx context.Context, userID, communityID uint) error
+	GetMutedCommunities(ctx context.Context, userID uint) ([]dtoCommunity.CommunityDetailResponse, error)
 }
@@ -1,16 +1,22 @@
 package usecase
 
 import (
+	"context"
+	"log/slog"
 	community "macaiki/internal/community"
+	"macaiki/internal/job"
 	reportCategory "macaiki/internal/report_category"
 	"macaiki/internal/thread"
 	user "macaiki/internal/user"
 	cloudstorage "macaiki/pkg/cloud_storage"
+	"macaiki/pkg/logger"
+	"macaiki/pkg/metrics"
 	"macaiki/pkg/utils"
 	"mime/multipart"
 
 	dtoCommunity "macaiki/internal/community/dto"
 	"macaiki/internal/community/entity"
+	threadHelper "macaiki/internal/thread/delivery/http/helper"
 	dtoThread "macaiki/internal/thread/dto"
 	dtoUser "macaiki/internal/user/dto"
 
@@ -26,9 +32,11 @@ type CommunityUsecaseImpl struct {
 	threadRepo    thread.ThreadRepository
 	validator     *validator.Validate
 	awsS3         *cloudstorage.S3
+	jobQueue      job.Queue
+	logger        *slog.Logger
 }
 
-func NewCommunityUsecase(communityRepo community.CommunityRepository, userRepo user.UserRepository, rcRepo reportCategory.ReportCategoryRepository, threadRepo thread.ThreadRepository, validator *validator.Validate, awsS3 *cloudstorage.S3) community.CommunityUsecase {
+func NewCommunityUsecase(communityRepo community.CommunityRepository, userRepo user.UserRepository, rcRepo reportCategory.ReportCategoryRepository, threadRepo thread.ThreadRepository, validator *validator.Validate, awsS3 *cloudstorage.S3, jobQueue job.Queue, log *slog.Logger) community.CommunityUsecase {
 	return &CommunityUsecaseImpl{
 		communityRepo: communityRepo,
 		userRepo:      userRepo,
@@ -36,11 +44,13 @@ func NewCommunityUsecase(communityRepo community.CommunityRepository, userRepo u
 		rcRepo:        rcRepo,
 		validator:     validator,
 		awsS3:         awsS3,
+		jobQueue:      jobQueue,
+		logger:        logger.OrDefault(log),
 	}
 }
 
-func (cu *CommunityUsecaseImpl) GetAllCommunities(userID int, search string) ([]dtoCommunity.CommunityDetailResponse, error) {
-	communities, err := cu.communityRepo.GetAllCommunities(uint(userID), search)
+func (cu *CommunityUsecaseImpl) GetAllCommunities(ctx context.Context, userID int, search string) ([]dtoCommunity.CommunityDetailResponse, error) {
+	communities, err := cu.communityRepo.GetAllCommunities(ctx, uint(userID), search)
 	if err != nil {
 		return []dtoCommunity.CommunityDetailResponse{}, utils.ErrInternalServerError
 	}
@@ -61,8 +71,8 @@ func (cu *CommunityUsecaseImpl) GetAllCommunities(userID int, search string) ([]
 	return communitiesResp, nil
 }
 
-func (cu *CommunityUsecaseImpl) GetCommunity(userID, communityID uint) (dtoCommunity.CommunityDetailResponse, error) {
-	community, err := cu.communityRepo.GetCommunityWithDetail(userID, communityID)
+func (cu *CommunityUsecaseImpl) GetCommunity(ctx context.Context, userID, communityID uint) (dtoCommunity.CommunityDetailResponse, error) {
+	community, err := cu.communityRepo.GetCommunityWithDetail(ctx, userID, communityID)
 	if err != nil {
 		return dtoCommunity.CommunityDetailResponse{}, utils.ErrInternalServerError
 	}
@@ -84,13 +94,13 @@ func (cu *CommunityUsecaseImpl) GetCommunity(userID, communityID uint) (dtoCommu
 	return communityResp, err
 }
 
-func (cu *CommunityUsecaseImpl) GetCommunityAbout(userID, communityID uint) (dtoCommunity.CommunityAboutResponse, error) {
-	community, err := cu.communityRepo.GetCommunityAbout(userID, communityID)
+func (cu *CommunityUsecaseImpl) GetCommunityAbout(ctx context.Context, userID, communityID uint) (dtoCommunity.CommunityAboutResponse, error) {
+	community, err := cu.communityRepo.GetCommunityAbout(ctx, userID, communityID)
 	if err != nil {
 		return dtoCommunity.CommunityAboutResponse{}, utils.ErrInternalServerError
 	}
 
-	moderators, err := cu.communityRepo.GetModeratorByCommunityID(userID, communityID)
+	moderators, err := cu.communityRepo.GetModeratorByCommunityID(ctx, userID, communityID)
 	if err != nil {
 		return dtoCommunity.CommunityAboutResponse{}, utils.ErrInternalServerError
 	}
@@ -123,7 +133,7 @@ func (cu *CommunityUsecaseImpl) GetCommunityAbout(userID, communityID uint) (dto
 	return dtoCommunity, nil
 }
 
-func (cu *CommunityUsecaseImpl) StoreCommunity(community dtoCommunity.CommunityRequest, role string) error {
+func (cu *CommunityUsecaseImpl) StoreCommunity(ctx context.Context, community dtoCommunity.CommunityRequest, role string) error {
 	if role != "Admin" {
 		return utils.ErrUnauthorizedAccess
 	}
@@ -137,14 +147,14 @@ func (cu *CommunityUsecaseImpl) StoreCommunity(community dtoCommunity.CommunityR
 		Description: community.Description,
 	}
 
-	err := cu.communityRepo.StoreCommunity(communityEntity)
+	err := cu.communityRepo.StoreCommunity(ctx, communityEntity)
 	if err != nil {
 		return utils.ErrInternalServerError
 	}
 
 	return nil
 }
-func (cu *CommunityUsecaseImpl) UpdateCommunity(id uint, community dtoCommunity.CommunityRequest, role string) (dtoCommunity.CommunityUpdateResponse, error) {
+func (cu *CommunityUsecaseImpl) UpdateCommunity(ctx context.Context, id uint, community dtoCommunity.CommunityRequest, role string) (dtoCommunity.CommunityUpdateResponse, error) {
 	if role != "Admin" {
 		return dtoCommunity.CommunityUpdateResponse{}, utils.ErrUnauthorizedAccess
 	}
@@ -153,7 +163,7 @@ func (cu *CommunityUsecaseImpl) UpdateCommunity(id uint, community dtoCommunity.
 		return dtoCommunity.CommunityUpdateResponse{}, utils.ErrBadParamInput
 	}
 
-	communityDB, err := cu.communityRepo.GetCommunity(id)
+	communityDB, err := cu.communityRepo.GetCommunity(ctx, id)
 	if err != nil {
 		return dtoCommunity.CommunityUpdateResponse{}, utils.ErrInternalServerError
 	}
@@ -167,7 +177,7 @@ func (cu *CommunityUsecaseImpl) UpdateCommunity(id uint, community dtoCommunity.
 		Description: community.Description,
 	}
 
-	communityDB, err = cu.communityRepo.UpdateCommunity(communityDB, newCommunity)
+	communityDB, err = cu.communityRepo.UpdateCommunity(ctx, communityDB, newCommunity)
 	if err != nil {
 		return dtoCommunity.CommunityUpdateResponse{}, utils.ErrInternalServerError
 	}
@@ -179,12 +189,12 @@ func (cu *CommunityUsecaseImpl) UpdateCommunity(id uint, community dtoCommunity.
 	}
 	return communityResp, nil
 }
-func (cu *CommunityUsecaseImpl) DeleteCommunity(id uint, role string) error {
+func (cu *CommunityUsecaseImpl) DeleteCommunity(ctx context.Context, id uint, role string) error {
 	if role != "Admin" {
 		return utils.ErrUnauthorizedAccess
 	}
 
-	communityDB, err := cu.communityRepo.GetCommunity(id)
+	communityDB, err := cu.communityRepo.GetCommunity(ctx, id)
 	if err != nil {
 		return utils.ErrInternalServerError
 	}
@@ -193,7 +203,7 @@ func (cu *CommunityUsecaseImpl) DeleteCommunity(id uint, role string) error {
 		return utils.ErrNotFound
 	}
 
-	err = cu.communityRepo.DeleteCommunity(communityDB.ID)
+	err = cu.communityRepo.DeleteCommunity(ctx, communityDB.ID)
 	if err != nil {
 		return utils.ErrInternalServerError
 	}
@@ -201,8 +211,8 @@ func (cu *CommunityUsecaseImpl) DeleteCommunity(id uint, role string) error {
 	return nil
 }
 
-func (cu *CommunityUsecaseImpl) FollowCommunity(userID, communityID uint) error {
-	user, err := cu.userRepo.Get(userID)
+func (cu *CommunityUsecaseImpl) FollowCommunity(ctx context.Context, userID, communityID uint) error {
+	user, err := cu.userRepo.Get(ctx, userID)
 	if err != nil {
 		return utils.ErrInternalServerError
 	}
@@ -210,7 +220,7 @@ func (cu *CommunityUsecaseImpl) FollowCommunity(userID, communityID uint) error
 		return utils.ErrNotFound
 	}
 
-	community, err := cu.communityRepo.GetCommunity(communityID)
+	community, err := cu.communityRepo.GetCommunity(ctx, communityID)
 	if err != nil {
 		return utils.ErrInternalServerError
 	}
@@ -218,15 +228,15 @@ func (cu *CommunityUsecaseImpl) FollowCommunity(userID, communityID uint) error
 		return utils.ErrNotFound
 	}
 
-	err = cu.communityRepo.FollowCommunity(user, community)
+	err = cu.communityRepo.FollowCommunity(ctx, user, community)
 	if err != nil {
 		return utils.ErrInternalServerError
 	}
 
 	return nil
 }
-func (cu *CommunityUsecaseImpl) UnfollowCommunity(userID, communityID uint) error {
-	user, err := cu.userRepo.Get(userID)
+func (cu *CommunityUsecaseImpl) UnfollowCommunity(ctx context.Context, userID, communityID uint) error {
+	user, err := cu.userRepo.Get(ctx, userID)
 	if err != nil {
 		return utils.ErrInternalServerError
 	}
@@ -234,7 +244,7 @@ func (cu *CommunityUsecaseImpl) UnfollowCommunity(userID, communityID uint) erro
 		return utils.ErrNotFound
 	}
 
-	community, err := cu.communityRepo.GetCommunity(communityID)
+	community, err := cu.communityRepo.GetCommunity(ctx, communityID)
 	if err != nil {
 		return utils.ErrInternalServerError
 	}
@@ -242,7 +252,7 @@ func (cu *CommunityUsecaseImpl) UnfollowCommunity(userID, communityID uint) erro
 		return utils.ErrNotFound
 	}
 
-	err = cu.communityRepo.UnfollowCommunity(user, community)
+	err = cu.communityRepo.UnfollowCommunity(ctx, user, community)
 	if err != nil {
 		return utils.ErrInternalServerError
 	}
@@ -250,12 +260,12 @@ func (cu *CommunityUsecaseImpl) UnfollowCommunity(userID, communityID uint) erro
 	return nil
 }
 
-func (cu *CommunityUsecaseImpl) SetImage(id uint, img *multipart.FileHeader, role string) (string, error) {
+func (cu *CommunityUsecaseImpl) SetImage(ctx context.Context, id uint, img *multipart.FileHeader, role string) (string, error) {
 	if role != "Admin" {
 		return "", utils.ErrUnauthorizedAccess
 	}
 
-	community, err := cu.communityRepo.GetCommunity(id)
+	community, err := cu.communityRepo.GetCommunity(ctx, id)
 	if err != nil {
 		return "", utils.ErrInternalServerError
 	}
@@ -264,35 +274,32 @@ func (cu *CommunityUsecaseImpl) SetImage(id uint, img *multipart.FileHeader, rol
 		return "", utils.ErrNotFound
 	}
 
-	if community.CommunityImageUrl != "" {
-		err = cu.awsS3.DeleteImage(community.CommunityImageUrl, "community")
-		if err != nil {
-			return "", err
-		}
-	}
-
 	uniqueFilename := uuid.New()
-	result, err := cu.awsS3.UploadImage(uniqueFilename.String(), "community", img)
+	result, err := cu.awsS3.UploadImage(ctx, uniqueFilename.String(), "community", img)
 	if err != nil {
 		return "", err
 	}
 
 	imageURL := aws.StringValue(&result.Location)
 
-	err = cu.communityRepo.SetCommunityImage(id, imageURL, "community_image_url")
+	err = cu.communityRepo.SetCommunityImage(ctx, id, imageURL, "community_image_url")
 	if err != nil {
 		return "", err
 	}
 
-	return imageURL, err
+	if community.CommunityImageUrl != "" {
+		cu.deleteImage(ctx, community.CommunityImageUrl, "community")
+	}
+
+	return imageURL, nil
 }
 
-func (cu *CommunityUsecaseImpl) SetBackgroundImage(id uint, img *multipart.FileHeader, role string) (string, error) {
+func (cu *CommunityUsecaseImpl) SetBackgroundImage(ctx context.Context, id uint, img *multipart.FileHeader, role string) (string, error) {
 	if role != "Admin" {
 		return "", utils.ErrUnauthorizedAccess
 	}
 
-	community, err := cu.communityRepo.GetCommunity(id)
+	community, err := cu.communityRepo.GetCommunity(ctx, id)
 	if err != nil {
 		return "", utils.ErrInternalServerError
 	}
@@ -301,67 +308,48 @@ func (cu *CommunityUsecaseImpl) SetBackgroundImage(id uint, img *multipart.FileH
 		return "", utils.ErrNotFound
 	}
 
-	if community.CommunityImageUrl != "" {
-		err = cu.awsS3.DeleteImage(community.CommunityImageUrl, "community_background")
-		if err != nil {
-			return "", err
-		}
-	}
-
 	uniqueFilename := uuid.New()
-	result, err := cu.awsS3.UploadImage(uniqueFilename.String(), "community_background", img)
+	result, err := cu.awsS3.UploadImage(ctx, uniqueFilename.String(), "community_background", img)
 	if err != nil {
 		return "", err
 	}
 
 	imageURL := aws.StringValue(&result.Location)
 
-	err = cu.communityRepo.SetCommunityImage(id, imageURL, "community_background_image_url")
+	err = cu.communityRepo.SetCommunityImage(ctx, id, imageURL, "community_background_image_url")
 	if err != nil {
 		return "", err
 	}
 
-	return imageURL, err
+	if community.CommunityBackgroundImageUrl != "" {
+		cu.deleteImage(ctx, community.CommunityBackgroundImageUrl, "community_background")
+	}
+
+	return imageURL, nil
 }
 
-func (cu *CommunityUsecaseImpl) GetThreadCommunity(userID, communityID uint) ([]dtoThread.DetailedThreadResponse, error) {
-	threadsEntity, err := cu.communityRepo.GetCommunityThread(userID, communityID)
+func (cu *CommunityUsecaseImpl) GetThreadCommunity(ctx context.Context, userID, communityID uint) ([]dtoThread.DetailedThreadResponse, error) {
+	threadsEntity, err := cu.communityRepo.GetCommunityThread(ctx, userID, communityID)
 	if err != nil {
 		return []dtoThread.DetailedThreadResponse{}, utils.ErrInternalServerError
 	}
 
 	dtoThreads := []dtoThread.DetailedThreadResponse{}
 	for _, val := range threadsEntity {
-		dtoThread := dtoThread.DetailedThreadResponse{
-			ID:                    val.Thread.ID,
-			Title:                 val.Thread.Title,
-			Body:                  val.Thread.Body,
-			CommunityID:           val.Thread.CommunityID,
-			ImageURL:              val.Thread.ImageURL,
-			UpvotesCount:          val.UpvotesCount,
-			IsFollowed:            val.IsFollowed,
-			IsUpvoted:             val.IsUpvoted,
-			UserID:                val.Thread.UserID,
-			UserName:              val.User.Name,
-			UserProfession:        val.User.Profession,
-			UserProfilePictureURL: val.User.ProfileImageUrl,
-			CreatedAt:             val.Thread.CreatedAt,
-			UpdatedAt:             val.Thread.UpdatedAt,
-		}
-		dtoThreads = append(dtoThreads, dtoThread)
+		dtoThreads = append(dtoThreads, threadHelper.DomainThreadToDetailedThreadResponse(val))
 	}
 
 	return dtoThreads, nil
 }
 
-func (cu *CommunityUsecaseImpl) AddModerator(moderatorReq dtoCommunity.CommunityModeratorRequest, role string) error {
+func (cu *CommunityUsecaseImpl) AddModerator(ctx context.Context, moderatorReq dtoCommunity.CommunityModeratorRequest, role string) error {
 	if role != "Admin" {
 		return utils.ErrUnauthorizedAccess
 	}
 	if moderatorReq.UserID == 0 || moderatorReq.CommunityID == 0 {
 		return utils.ErrBadParamInput
 	}
-	user, err := cu.userRepo.Get(moderatorReq.UserID)
+	user, err := cu.userRepo.Get(ctx, moderatorReq.UserID)
 	if err != nil {
 		return utils.ErrInternalServerError
 	}
@@ -370,7 +358,7 @@ func (cu *CommunityUsecaseImpl) AddModerator(moderatorReq dtoCommunity.Community
 		return utils.ErrNotFound
 	}
 
-	community, err := cu.communityRepo.GetCommunity(moderatorReq.CommunityID)
+	community, err := cu.communityRepo.GetCommunity(ctx, moderatorReq.CommunityID)
 	if err != nil {
 		return utils.ErrInternalServerError
 	}
@@ -379,7 +367,7 @@ func (cu *CommunityUsecaseImpl) AddModerator(moderatorReq dtoCommunity.Community
 		return utils.ErrNotFound
 	}
 
-	err = cu.communityRepo.AddModerator(user, community)
+	err = cu.communityRepo.AddModerator(ctx, user, community)
 	if err != nil {
 		return utils.ErrInternalServerError
 	}
@@ -387,14 +375,14 @@ func (cu *CommunityUsecaseImpl) AddModerator(moderatorReq dtoCommunity.Community
 	return nil
 }
 
-func (cu *CommunityUsecaseImpl) RemoveModerator(moderatorReq dtoCommunity.CommunityModeratorRequest, role string) error {
+func (cu *CommunityUsecaseImpl) RemoveModerator(ctx context.Context, moderatorReq dtoCommunity.CommunityModeratorRequest, role string) error {
 	if role != "Admin" {
 		return utils.ErrUnauthorizedAccess
 	}
 	if moderatorReq.UserID == 0 || moderatorReq.CommunityID == 0 {
 		return utils.ErrBadParamInput
 	}
-	user, err := cu.userRepo.Get(moderatorReq.UserID)
+	user, err := cu.userRepo.Get(ctx, moderatorReq.UserID)
 	if err != nil {
 		return utils.ErrInternalServerError
 	}
@@ -403,7 +391,7 @@ func (cu *CommunityUsecaseImpl) RemoveModerator(moderatorReq dtoCommunity.Commun
 		return utils.ErrNotFound
 	}
 
-	community, err := cu.communityRepo.GetCommunity(moderatorReq.CommunityID)
+	community, err := cu.communityRepo.GetCommunity(ctx, moderatorReq.CommunityID)
 	if err != nil {
 		return utils.ErrInternalServerError
 	}
@@ -412,7 +400,7 @@ func (cu *CommunityUsecaseImpl) RemoveModerator(moderatorReq dtoCommunity.Commun
 		return utils.ErrNotFound
 	}
 
-	err = cu.communityRepo.RemoveModerator(user, community)
+	err = cu.communityRepo.RemoveModerator(ctx, user, community)
 	if err != nil {
 		return utils.ErrInternalServerError
 	}
@@ -420,8 +408,8 @@ func (cu *CommunityUsecaseImpl) RemoveModerator(moderatorReq dtoCommunity.Commun
 	return nil
 }
 
-func (cu *CommunityUsecaseImpl) ReportCommunity(userID, communityID, reportCategoryID uint) error {
-	community, err := cu.communityRepo.GetCommunity(communityID)
+func (cu *CommunityUsecaseImpl) ReportCommunity(ctx context.Context, userID, communityID, reportCategoryID uint) error {
+	community, err := cu.communityRepo.GetCommunity(ctx, communityID)
 	if err != nil {
 		return utils.ErrInternalServerError
 	}
@@ -430,7 +418,7 @@ func (cu *CommunityUsecaseImpl) ReportCommunity(userID, communityID, reportCateg
 		return utils.ErrNotFound
 	}
 
-	reportCategory, err := cu.rcRepo.GetReportCategory(reportCategoryID)
+	reportCategory, err := cu.rcRepo.GetReportCategory(ctx, reportCategoryID)
 	if err != nil {
 		return utils.ErrInternalServerError
 	}
@@ -439,7 +427,7 @@ func (cu *CommunityUsecaseImpl) ReportCommunity(userID, communityID, reportCateg
 		return utils.ErrNotFound
 	}
 
-	err = cu.communityRepo.StoreReportCommunity(entity.CommunityReport{
+	err = cu.communityRepo.StoreReportCommunity(ctx, entity.CommunityReport{
 		UserID:              userID,
 		CommunityReportedID: communityID,
 		ReportCategoryID:    reportCategoryID,
@@ -448,15 +436,16 @@ func (cu *CommunityUsecaseImpl) ReportCommunity(userID, communityID, reportCateg
 		return utils.ErrInternalServerError
 	}
 
+	metrics.ReportsFiled.WithLabelValues(metrics.ReportCommunity).Inc()
 	return nil
 }
 
-func (cu *CommunityUsecaseImpl) DeleteReportCommunity(reportCommunityId uint) error {
+func (cu *CommunityUsecaseImpl) DeleteReportCommunity(ctx context.Context, reportCommunityId uint) error {
 	return nil
 }
 
-func (cu *CommunityUsecaseImpl) ReportByModerator(userID, communityID uint, reportReq dtoCommunity.ReportRequest) error {
-	mods, err := cu.communityRepo.GetModeratorByUserID(userID, communityID)
+func (cu *CommunityUsecaseImpl) ReportByModerator(ctx context.Context, userID, communityID uint, reportReq dtoCommunity.ReportRequest) error {
+	mods, err := cu.communityRepo.GetModeratorByUserID(ctx, userID, communityID)
 
 	if err != nil {
 		return err
@@ -466,32 +455,32 @@ func (cu *CommunityUsecaseImpl) ReportByModerator(userID, communityID uint, repo
 	}
 
 	if reportReq.CommentReportID != 0 {
-		commentReport, err := cu.threadRepo.GetCommentReport(reportReq.CommentReportID)
+		commentReport, err := cu.threadRepo.GetCommentReport(ctx, reportReq.CommentReportID)
 		if err != nil {
 			return utils.ErrInternalServerError
 		}
 
-		err = cu.threadRepo.UpdateCommentReport(commentReport, userID)
+		err = cu.threadRepo.UpdateCommentReport(ctx, commentReport, userID)
 		if err != nil {
 			return utils.ErrInternalServerError
 		}
 	} else if reportReq.CommunityReportID != 0 {
-		communityReport, err := cu.communityRepo.GetReportCommunity(reportReq.CommunityReportID)
+		communityReport, err := cu.communityRepo.GetReportCommunity(ctx, reportReq.CommunityReportID)
 		if err != nil {
 			return utils.ErrInternalServerError
 		}
 
-		err = cu.communityRepo.UpdateReportCommunity(communityReport, userID)
+		err = cu.communityRepo.UpdateReportCommunity(ctx, communityReport, userID)
 		if err != nil {
 			return utils.ErrInternalServerError
 		}
 	} else {
-		threadReport, err := cu.threadRepo.GetThreadReport(reportReq.ThreadReportID)
+		threadReport, err := cu.threadRepo.GetThreadReport(ctx, reportReq.ThreadReportID)
 		if err != nil {
 			return utils.ErrInternalServerError
 		}
 
-		err = cu.threadRepo.UpdateThreadReport(threadReport, userID)
+		err = cu.threadRepo.UpdateThreadReport(ctx, threadReport, userID)
 		if err != nil {
 			return utils.ErrInternalServerError
 		}
@@ -500,8 +489,8 @@ func (cu *CommunityUsecaseImpl) ReportByModerator(userID, communityID uint, repo
 	return nil
 }
 
-func (cu *CommunityUsecaseImpl) GetReports(userID, communityID uint) ([]dtoCommunity.BriefReportResponse, error) {
-	mods, err := cu.communityRepo.GetModeratorByUserID(userID, communityID)
+func (cu *CommunityUsecaseImpl) GetReports(ctx context.Context, userID, communityID uint) ([]dtoCommunity.BriefReportResponse, error) {
+	mods, err := cu.communityRepo.GetModeratorByUserID(ctx, userID, communityID)
 
 	if err != nil {
 		return []dtoCommunity.BriefReportResponse{}, err
@@ -511,7 +500,7 @@ func (cu *CommunityUsecaseImpl) GetReports(userID, communityID uint) ([]dtoCommu
 		return []dtoCommunity.BriefReportResponse{}, utils.ErrUnauthorizedAccess
 	}
 
-	reports, err := cu.communityRepo.GetReports(communityID)
+	reports, err := cu.communityRepo.GetReports(ctx, communityID)
 
 	if err != nil {
 		return []dtoCommunity.BriefReportResponse{}, utils.ErrInternalServerError
@@ -538,3 +527,54 @@ func (cu *CommunityUsecaseImpl) GetReports(userID, communityID uint) ([]dtoCommu
 
 	return reportsResp, nil
 }
+
+// deleteImage removes a replaced image in the background, the new image is
+// already stored so a failure here only leaves an orphaned object
+func (cu *CommunityUsecaseImpl) deleteImage(ctx context.Context, fileName, dirName string) {
+	err := cu.jobQueue.Enqueue(ctx, cloudstorage.JobDeleteImage, cloudstorage.DeleteImagePayload{
+		FileName: fileName,
+		DirName:  dirName,
+	})
+	if err != nil {
+		cu.logger.ErrorContext(ctx, "failed to enqueue image deletion", "err", err, "file", fileName)
+	}
+}
+
+// MuteCommunity keeps the threads of communityID out of the feeds of userID
+func (cu *CommunityUsecaseImpl) MuteCommunity(ctx context.Context, userID, communityID uint) error {
+	community, err := cu.communityRepo.GetCommunity(ctx, communityID)
+	if err != nil {
+		return utils.ErrInternalServerError
+	}
+	if community.ID == 0 {
+		return utils.ErrNotFound
+	}
+
+	return cu.communityRepo.MuteCommunity(ctx, userID, communityID)
+}
+
+func (cu *CommunityUsecaseImpl) UnmuteCommunity(ctx context.Context, userID, communityID uint) error {
+	return cu.communityRepo.UnmuteCommunity(ctx, userID, communityID)
+}
+
+func (cu *CommunityUsecaseImpl) GetMutedCommunities(ctx context.Context, userID uint) ([]dtoCommunity.CommunityDetailResponse, error) {
+	communities, err := cu.communityRepo.GetMutedCommunities(ctx, userID)
+	if err != nil {
+		return []dtoCommunity.CommunityDetailResponse{}, utils.ErrInternalServerError
+	}
+
+	communitiesResp := []dtoCommunity.CommunityDetailResponse{}
+	for _, val := range communities {
+		communitiesResp = append(communitiesResp, dtoCommunity.CommunityDetailResponse{
+			ID:                          val.ID,
+			Name:                        val.Name,
+			CommunityImageUrl:           val.CommunityImageUrl,
+			CommunityBackgroundImageUrl: val.CommunityBackgroundImageUrl,
+			Description:                 val.Description,
+			IsFollowed:                  val.IsFollowed,
+			IsModerator:                 val.IsModerator,
+		})
+	}
+
+	return communitiesResp, nil
+}
@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	communityDTO "macaiki/internal/community/dto"
 	communityEntity "macaiki/internal/community/entity"
 	communityMock "macaiki/internal/community/mocks"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/go-playground/validator/v10"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"gorm.io/gorm"
 )
 
@@ -57,20 +59,20 @@ func TestGetAllCommunities(t *testing.T) {
 	mockCommunityRepo := communityMock.NewCommunityRepository(t)
 
 	t.Run("success", func(t *testing.T) {
-		mockCommunityRepo.On("GetAllCommunities", uint(1), "").Return(mockCommunityEntityArr, nil).Once()
+		mockCommunityRepo.On("GetAllCommunities", mock.Anything, uint(1), "").Return(mockCommunityEntityArr, nil).Once()
 
-		testCommunityUsecase := NewCommunityUsecase(mockCommunityRepo, nil, nil, nil, nil, nil)
-		res, err := testCommunityUsecase.GetAllCommunities(1, "")
+		testCommunityUsecase := NewCommunityUsecase(mockCommunityRepo, nil, nil, nil, nil, nil, nil, nil)
+		res, err := testCommunityUsecase.GetAllCommunities(context.Background(), 1, "")
 
 		assert.NoError(t, err)
 		assert.NotEmpty(t, res)
 	})
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		mockCommunityRepo.On("GetAllCommunities", uint(1), "").Return([]communityEntity.Community{}, utils.ErrInternalServerError).Once()
+		mockCommunityRepo.On("GetAllCommunities", mock.Anything, uint(1), "").Return([]communityEntity.Community{}, utils.ErrInternalServerError).Once()
 
-		testCommunityUsecase := NewCommunityUsecase(mockCommunityRepo, nil, nil, nil, nil, nil)
-		res, err := testCommunityUsecase.GetAllCommunities(1, "")
+		testCommunityUsecase := NewCommunityUsecase(mockCommunityRepo, nil, nil, nil, nil, nil, nil, nil)
+		res, err := testCommunityUsecase.GetAllCommunities(context.Background(), 1, "")
 
 		assert.Error(t, err)
 		assert.Empty(t, res)
@@ -81,30 +83,30 @@ func TestGetCommunity(t *testing.T) {
 	mockCommunityRepo := communityMock.NewCommunityRepository(t)
 
 	t.Run("success", func(t *testing.T) {
-		mockCommunityRepo.On("GetCommunityWithDetail", uint(1), uint(1)).Return(mockCommunityEntity, nil).Once()
+		mockCommunityRepo.On("GetCommunityWithDetail", mock.Anything, uint(1), uint(1)).Return(mockCommunityEntity, nil).Once()
 
-		testCommunityUsecase := NewCommunityUsecase(mockCommunityRepo, nil, nil, nil, nil, nil)
-		res, err := testCommunityUsecase.GetCommunity(uint(1), uint(1))
+		testCommunityUsecase := NewCommunityUsecase(mockCommunityRepo, nil, nil, nil, nil, nil, nil, nil)
+		res, err := testCommunityUsecase.GetCommunity(context.Background(), uint(1), uint(1))
 
 		assert.NoError(t, err)
 		assert.NotEmpty(t, res)
 	})
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		mockCommunityRepo.On("GetCommunityWithDetail", uint(1), uint(1)).Return(communityEntity.Community{}, utils.ErrInternalServerError).Once()
+		mockCommunityRepo.On("GetCommunityWithDetail", mock.Anything, uint(1), uint(1)).Return(communityEntity.Community{}, utils.ErrInternalServerError).Once()
 
-		testCommunityUsecase := NewCommunityUsecase(mockCommunityRepo, nil, nil, nil, nil, nil)
-		res, err := testCommunityUsecase.GetCommunity(uint(1), uint(1))
+		testCommunityUsecase := NewCommunityUsecase(mockCommunityRepo, nil, nil, nil, nil, nil, nil, nil)
+		res, err := testCommunityUsecase.GetCommunity(context.Background(), uint(1), uint(1))
 
 		assert.Error(t, err)
 		assert.Empty(t, res)
 	})
 
 	t.Run("community-not-found", func(t *testing.T) {
-		mockCommunityRepo.On("GetCommunityWithDetail", uint(1), uint(1)).Return(communityEntity.Community{}, nil).Once()
+		mockCommunityRepo.On("GetCommunityWithDetail", mock.Anything, uint(1), uint(1)).Return(communityEntity.Community{}, nil).Once()
 
-		testCommunityUsecase := NewCommunityUsecase(mockCommunityRepo, nil, nil, nil, nil, nil)
-		res, err := testCommunityUsecase.GetCommunity(uint(1), uint(1))
+		testCommunityUsecase := NewCommunityUsecase(mockCommunityRepo, nil, nil, nil, nil, nil, nil, nil)
+		res, err := testCommunityUsecase.GetCommunity(context.Background(), uint(1), uint(1))
 
 		assert.Error(t, err)
 		assert.Empty(t, res)
@@ -115,32 +117,32 @@ func TestGetCommunityAbout(t *testing.T) {
 	mockCommunityRepo := communityMock.NewCommunityRepository(t)
 
 	t.Run("success", func(t *testing.T) {
-		mockCommunityRepo.On("GetCommunityAbout", uint(1), uint(1)).Return(mockCommunityEntity, nil).Once()
-		mockCommunityRepo.On("GetModeratorByCommunityID", uint(1), uint(1)).Return(mockUserEntityArr, nil).Once()
+		mockCommunityRepo.On("GetCommunityAbout", mock.Anything, uint(1), uint(1)).Return(mockCommunityEntity, nil).Once()
+		mockCommunityRepo.On("GetModeratorByCommunityID", mock.Anything, uint(1), uint(1)).Return(mockUserEntityArr, nil).Once()
 
-		testCommunityUsecase := NewCommunityUsecase(mockCommunityRepo, nil, nil, nil, nil, nil)
-		res, err := testCommunityUsecase.GetCommunityAbout(uint(1), uint(1))
+		testCommunityUsecase := NewCommunityUsecase(mockCommunityRepo, nil, nil, nil, nil, nil, nil, nil)
+		res, err := testCommunityUsecase.GetCommunityAbout(context.Background(), uint(1), uint(1))
 
 		assert.NoError(t, err)
 		assert.NotEmpty(t, res)
 	})
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		mockCommunityRepo.On("GetCommunityAbout", uint(1), uint(1)).Return(communityEntity.Community{}, utils.ErrInternalServerError).Once()
+		mockCommunityRepo.On("GetCommunityAbout", mock.Anything, uint(1), uint(1)).Return(communityEntity.Community{}, utils.ErrInternalServerError).Once()
 
-		testCommunityUsecase := NewCommunityUsecase(mockCommunityRepo, nil, nil, nil, nil, nil)
-		res, err := testCommunityUsecase.GetCommunityAbout(uint(1), uint(1))
+		testCommunityUsecase := NewCommunityUsecase(mockCommunityRepo, nil, nil, nil, nil, nil, nil, nil)
+		res, err := testCommunityUsecase.GetCommunityAbout(context.Background(), uint(1), uint(1))
 
 		assert.Error(t, err)
 		assert.Empty(t, res)
 	})
 
 	t.Run("success", func(t *testing.T) {
-		mockCommunityRepo.On("GetCommunityAbout", uint(1), uint(1)).Return(mockCommunityEntity, nil).Once()
-		mockCommunityRepo.On("GetModeratorByCommunityID", uint(1), uint(1)).Return([]userEntity.User{}, utils.ErrInternalServerError).Once()
+		mockCommunityRepo.On("GetCommunityAbout", mock.Anything, uint(1), uint(1)).Return(mockCommunityEntity, nil).Once()
+		mockCommunityRepo.On("GetModeratorByCommunityID", mock.Anything, uint(1), uint(1)).Return([]userEntity.User{}, utils.ErrInternalServerError).Once()
 
-		testCommunityUsecase := NewCommunityUsecase(mockCommunityRepo, nil, nil, nil, nil, nil)
-		res, err := testCommunityUsecase.GetCommunityAbout(uint(1), uint(1))
+		testCommunityUsecase := NewCommunityUsecase(mockCommunityRepo, nil, nil, nil, nil, nil, nil, nil)
+		res, err := testCommunityUsecase.GetCommunityAbout(context.Background(), uint(1), uint(1))
 
 		assert.Error(t, err)
 		assert.Empty(t, res)
@@ -166,11 +168,11 @@ func TestStoreCommunity(t *testing.T) {
 	}
 
 	t.Run("success", func(t *testing.T) {
-		mockCommunityRepo.On("GetCommunity", uint(1)).Return(mockCommunityEntity, nil).Once()
-		mockCommunityRepo.On("UpdateCommunity", mockCommunityEntity, mockCommunityEntityReq).Return(mockCommunityEntity, nil)
+		mockCommunityRepo.On("GetCommunity", mock.Anything, uint(1)).Return(mockCommunityEntity, nil).Once()
+		mockCommunityRepo.On("UpdateCommunity", mock.Anything, mockCommunityEntity, mockCommunityEntityReq).Return(mockCommunityEntity, nil)
 
-		testCommunityUsecase := NewCommunityUsecase(mockCommunityRepo, nil, nil, nil, v, nil)
-		res, err := testCommunityUsecase.UpdateCommunity(uint(1), mockCommunityDTOReq, "Admin")
+		testCommunityUsecase := NewCommunityUsecase(mockCommunityRepo, nil, nil, nil, v, nil, nil, nil)
+		res, err := testCommunityUsecase.UpdateCommunity(context.Background(), uint(1), mockCommunityDTOReq, "Admin")
 
 		assert.NoError(t, err)
 		assert.NotEmpty(t, res)
@@ -179,7 +181,7 @@ func TestStoreCommunity(t *testing.T) {
 	// t.Run("internal-server-error", func(t *testing.T) {
 	// 	mockCommunityRepo.On("GetCommunity", uint(1)).Return(communityEntity.Community{}, utils.ErrInternalServerError).Once()
 
-	// 	testCommunityUsecase := NewCommunityUsecase(mockCommunityRepo, nil, nil, nil, v, nil)
+	// 	testCommunityUsecase := NewCommunityUsecase(mockCommunityRepo, nil, nil, nil, v, nil, nil, nil)
 	// 	res, err := testCommunityUsecase.UpdateCommunity(uint(1), mockCommunityDTOReq, "Admin")
 
 	// 	assert.Error(t, err)
@@ -190,7 +192,7 @@ func TestStoreCommunity(t *testing.T) {
 	// 	mockCommunityRepo.On("GetCommunity", uint(1)).Return(mockCommunityEntity, nil).Once()
 	// 	mockCommunityRepo.On("UpdateCommunity", mockCommunityEntity, mockCommunityEntityReq).Return(communityEntity.Community{}, utils.ErrInternalServerError)
 
-	// 	testCommunityUsecase := NewCommunityUsecase(mockCommunityRepo, nil, nil, nil, v, nil)
+	// 	testCommunityUsecase := NewCommunityUsecase(mockCommunityRepo, nil, nil, nil, v, nil, nil, nil)
 	// 	res, err := testCommunityUsecase.UpdateCommunity(uint(1), mockCommunityDTOReq, "Admin")
 
 	// 	assert.Error(t, err)
@@ -198,29 +200,98 @@ func TestStoreCommunity(t *testing.T) {
 	// })
 
 	t.Run("bad-param-input", func(t *testing.T) {
-		testCommunityUsecase := NewCommunityUsecase(mockCommunityRepo, nil, nil, nil, v, nil)
-		res, err := testCommunityUsecase.UpdateCommunity(uint(1), mockCommunityDTOReq, "User")
+		testCommunityUsecase := NewCommunityUsecase(mockCommunityRepo, nil, nil, nil, v, nil, nil, nil)
+		res, err := testCommunityUsecase.UpdateCommunity(context.Background(), uint(1), mockCommunityDTOReq, "User")
 
 		assert.Error(t, err)
 		assert.Empty(t, res)
 	})
 
 	t.Run("community-not-found", func(t *testing.T) {
-		mockCommunityRepo.On("GetCommunity", uint(1)).Return(communityEntity.Community{}, nil).Once()
+		mockCommunityRepo.On("GetCommunity", mock.Anything, uint(1)).Return(communityEntity.Community{}, nil).Once()
 
-		testCommunityUsecase := NewCommunityUsecase(mockCommunityRepo, nil, nil, nil, v, nil)
-		res, err := testCommunityUsecase.UpdateCommunity(uint(1), mockCommunityDTOReq, "Admin")
+		testCommunityUsecase := NewCommunityUsecase(mockCommunityRepo, nil, nil, nil, v, nil, nil, nil)
+		res, err := testCommunityUsecase.UpdateCommunity(context.Background(), uint(1), mockCommunityDTOReq, "Admin")
 
 		assert.Error(t, err)
 		assert.Empty(t, res)
 	})
 
 	t.Run("unauthorize", func(t *testing.T) {
-		testCommunityUsecase := NewCommunityUsecase(mockCommunityRepo, nil, nil, nil, v, nil)
-		res, err := testCommunityUsecase.UpdateCommunity(uint(1), mockCommunityDTOReqFail, "Admin")
+		testCommunityUsecase := NewCommunityUsecase(mockCommunityRepo, nil, nil, nil, v, nil, nil, nil)
+		res, err := testCommunityUsecase.UpdateCommunity(context.Background(), uint(1), mockCommunityDTOReqFail, "Admin")
 
 		assert.Error(t, err)
 		assert.Empty(t, res)
 	})
 
 }
+
+func TestMuteCommunity(t *testing.T) {
+	mockCommunityRepo := communityMock.NewCommunityRepository(t)
+
+	t.Run("success", func(t *testing.T) {
+		mockCommunityRepo.On("GetCommunity", mock.Anything, uint(1)).Return(mockCommunityEntity, nil).Once()
+		mockCommunityRepo.On("MuteCommunity", mock.Anything, uint(2), uint(1)).Return(nil).Once()
+
+		testCommunityUsecase := NewCommunityUsecase(mockCommunityRepo, nil, nil, nil, nil, nil, nil, nil)
+		err := testCommunityUsecase.MuteCommunity(context.Background(), uint(2), uint(1))
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("community-not-found", func(t *testing.T) {
+		mockCommunityRepo.On("GetCommunity", mock.Anything, uint(1)).Return(communityEntity.Community{}, nil).Once()
+
+		testCommunityUsecase := NewCommunityUsecase(mockCommunityRepo, nil, nil, nil, nil, nil, nil, nil)
+		err := testCommunityUsecase.MuteCommunity(context.Background(), uint(2), uint(1))
+
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+	})
+}
+
+func TestUnmuteCommunity(t *testing.T) {
+	mockCommunityRepo := communityMock.NewCommunityRepository(t)
+
+	t.Run("success", func(t *testing.T) {
+		mockCommunityRepo.On("UnmuteCommunity", mock.Anything, uint(2), uint(1)).Return(nil).Once()
+
+		testCommunityUsecase := NewCommunityUsecase(mockCommunityRepo, nil, nil, nil, nil, nil, nil, nil)
+		err := testCommunityUsecase.UnmuteCommunity(context.Background(), uint(2), uint(1))
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		mockCommunityRepo.On("UnmuteCommunity", mock.Anything, uint(2), uint(1)).Return(utils.ErrNotFound).Once()
+
+		testCommunityUsecase := NewCommunityUsecase(mockCommunityRepo, nil, nil, nil, nil, nil, nil, nil)
+		err := testCommunityUsecase.UnmuteCommunity(context.Background(), uint(2), uint(1))
+
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+	})
+}
+
+func TestGetMutedCommunities(t *testing.T) {
+	mockCommunityRepo := communityMock.NewCommunityRepository(t)
+
+	t.Run("success", func(t *testing.T) {
+		mockCommunityRepo.On("GetMutedCommunities", mock.Anything, uint(1)).Return(mockCommunityEntityArr, nil).Once()
+
+		testCommunityUsecase := NewCommunityUsecase(mockCommunityRepo, nil, nil, nil, nil, nil, nil, nil)
+		res, err := testCommunityUsecase.GetMutedCommunities(context.Background(), uint(1))
+
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+	})
+
+	t.Run("internal-server-error", func(t *testing.T) {
+		mockCommunityRepo.On("GetMutedCommunities", mock.Anything, uint(1)).Return([]communityEntity.Community{}, utils.ErrInternalServerError).Once()
+
+		testCommunityUsecase := NewCommunityUsecase(mockCommunityRepo, nil, nil, nil, nil, nil, nil, nil)
+		res, err := testCommunityUsecase.GetMutedCommunities(context.Background(), uint(1))
+
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
@@ -0,0 +1,34 @@
+package driver
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// PingDB reports whether the database is reachable
+func PingDB(db *gorm.DB) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+
+		return sqlDB.PingContext(ctx)
+	}
+}
+
+// CheckMigrations reports whether every table in Models exists
+func CheckMigrations(db *gorm.DB) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		migrator := db.WithContext(ctx).Migrator()
+		for _, model := range Models() {
+			if !migrator.HasTable(model) {
+				return fmt.Errorf("table for %T is missing", model)
+			}
+		}
+
+		return nil
+	}
+}
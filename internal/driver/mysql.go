@@ -2,12 +2,22 @@ package driver
 
 import (
 	"fmt"
-	"log"
+	"log/slog"
 	communityEntity "macaiki/internal/community/entity"
+	exportEntity "macaiki/internal/export/entity"
+	feedEntity "macaiki/internal/feed/entity"
+	identityEntity "macaiki/internal/identity/entity"
+	jobEntity "macaiki/internal/job/entity"
 	notifEntity "macaiki/internal/notification/entity"
 	reportCategoryEntity "macaiki/internal/report_category/entity"
 	threadEntity "macaiki/internal/thread/entity"
 	userEntity "macaiki/internal/user/entity"
+	"macaiki/pkg/logger"
+	"macaiki/pkg/mailer"
+	"macaiki/pkg/markdown"
+	"macaiki/pkg/metrics"
+	"macaiki/pkg/tracing"
+	"os"
 
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
@@ -15,7 +25,7 @@ import (
 
 var DB *gorm.DB
 
-func ConnectDB(driver, host, port, username, password, name string) {
+func ConnectDB(driver, host, port, username, password, name string, log *slog.Logger) {
 	var err error
 	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8&parseTime=True&loc=Local",
 		username,
@@ -26,31 +36,155 @@ func ConnectDB(driver, host, port, username, password, name string) {
 	)
 
 	if driver == "MYSQL" {
-		DB, err = gorm.Open(mysql.Open(dsn), &gorm.Config{})
+		DB, err = gorm.Open(mysql.Open(dsn), &gorm.Config{Logger: logger.NewGormLogger(log)})
 		if err != nil {
-			log.Fatal("err", err)
+			fatal(log, "failed to connect to database", err)
+		}
+		if err = DB.Use(metrics.GormPlugin{}); err != nil {
+			fatal(log, "failed to register metrics plugin", err)
+		}
+		if err = DB.Use(tracing.GormPlugin{}); err != nil {
+			fatal(log, "failed to register tracing plugin", err)
+		}
+		if err = InitialMigration(DB); err != nil {
+			fatal(log, "failed to migrate database", err)
 		}
-		InitialMigration(DB)
 	}
 }
 
-func InitialMigration(DB *gorm.DB) {
-	DB.AutoMigrate(
+func fatal(l *slog.Logger, msg string, err error) {
+	logger.OrDefault(l).Error(msg, "err", err)
+	os.Exit(1)
+}
+
+// Models lists every table the application owns, in migration order
+func Models() []interface{} {
+	return []interface{}{
 		&reportCategoryEntity.ReportCategory{},
 		&communityEntity.Community{},
 		&userEntity.User{},
 		&userEntity.UserReport{},
 		&userEntity.VerificationEmail{},
+		&userEntity.EmailChange{},
+		&userEntity.TwoFactor{},
+		&userEntity.RecoveryCode{},
+		&identityEntity.Identity{},
+		&identityEntity.AuthState{},
 		&notifEntity.Notification{},
 		&communityEntity.CommunityReport{},
+		&communityEntity.MutedCommunity{},
 		&threadEntity.Thread{},
-		&threadEntity.ThreadUpvote{},
+		&threadEntity.ThreadVote{},
 		&threadEntity.ThreadFollower{},
 		&threadEntity.Comment{},
-		&threadEntity.CommentLikes{},
-		&threadEntity.ThreadDownvote{},
+		&threadEntity.CommentVote{},
 		&threadEntity.ThreadReport{},
 		&threadEntity.CommentReport{},
 		&threadEntity.SavedThread{},
-	)
+		&threadEntity.SavedThreadCollection{},
+		&threadEntity.ThreadScore{},
+		&threadEntity.HiddenThread{},
+		&threadEntity.Attachment{},
+		&feedEntity.SeenThread{},
+		&exportEntity.Export{},
+		&mailer.OutboxMessage{},
+		&jobEntity.Job{},
+	}
+}
+
+func InitialMigration(DB *gorm.DB) error {
+	backfillThreadVotes := !DB.Migrator().HasTable(&threadEntity.ThreadVote{})
+	backfillCommentVotes := !DB.Migrator().HasTable(&threadEntity.CommentVote{})
+	renderThreads := !DB.Migrator().HasColumn(&threadEntity.Thread{}, "BodyHTML")
+	renderComments := !DB.Migrator().HasColumn(&threadEntity.Comment{}, "BodyHTML")
+	if err := DB.AutoMigrate(Models()...); err != nil {
+		return err
+	}
+	if backfillThreadVotes {
+		if err := migrateThreadVotes(DB); err != nil {
+			return err
+		}
+	}
+	if backfillCommentVotes {
+		if err := migrateCommentVotes(DB); err != nil {
+			return err
+		}
+	}
+	if renderThreads {
+		if err := migrateRenderedBodies(DB, &threadEntity.Thread{}); err != nil {
+			return err
+		}
+	}
+	if renderComments {
+		return migrateRenderedBodies(DB, &threadEntity.Comment{})
+	}
+	return nil
+}
+
+// migrateRenderedBodies renders the Markdown of the threads or comments
+// written before bodies were rendered on write. Rows are read in batches and
+// updated without touching updated_at, they were not edited.
+func migrateRenderedBodies(DB *gorm.DB, model interface{}) error {
+	type renderedBody struct {
+		ID   uint
+		Body string
+	}
+
+	var rows []renderedBody
+	return DB.Model(model).Unscoped().Select("id, body").Where("body_html = '' AND body <> ''").FindInBatches(&rows, 500, func(tx *gorm.DB, batch int) error {
+		for _, row := range rows {
+			html, text := markdown.Render(row.Body)
+			err := DB.Model(model).Unscoped().Where("id = ?", row.ID).UpdateColumns(map[string]interface{}{
+				"body_html": html,
+				"body_text": text,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Error
+}
+
+// migrateThreadVotes copies the votes held in the retired thread_upvotes and
+// thread_downvotes tables into thread_votes and computes the stored thread
+// scores from them. A user holding both keeps the upvote.
+func migrateThreadVotes(DB *gorm.DB) error {
+	return DB.Transaction(func(tx *gorm.DB) error {
+		if tx.Migrator().HasTable("thread_upvotes") {
+			err := tx.Exec(`INSERT IGNORE INTO thread_votes (user_id, thread_id, value, created_at, updated_at)
+				SELECT user_id, thread_id, 1, created_at, updated_at FROM thread_upvotes WHERE deleted_at IS NULL`).Error
+			if err != nil {
+				return err
+			}
+		}
+		if tx.Migrator().HasTable("thread_downvotes") {
+			err := tx.Exec(`INSERT IGNORE INTO thread_votes (user_id, thread_id, value, created_at, updated_at)
+				SELECT user_id, thread_id, -1, created_at, updated_at FROM thread_downvotes WHERE deleted_at IS NULL`).Error
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Exec(`UPDATE threads t
+			JOIN (SELECT thread_id, SUM(value = 1) AS ups, SUM(value = -1) AS downs FROM thread_votes GROUP BY thread_id) v ON v.thread_id = t.id
+			SET t.upvotes_count = v.ups, t.downvotes_count = v.downs, t.score = v.ups - v.downs`).Error
+	})
+}
+
+// migrateCommentVotes turns the likes held in the retired comment_likes table
+// into upvotes and computes the stored comment scores from them. Likes that
+// were taken back are skipped.
+func migrateCommentVotes(DB *gorm.DB) error {
+	return DB.Transaction(func(tx *gorm.DB) error {
+		if tx.Migrator().HasTable("comment_likes") {
+			err := tx.Exec(`INSERT IGNORE INTO comment_votes (user_id, comment_id, value, created_at, updated_at)
+				SELECT user_id, comment_id, 1, created_at, updated_at FROM comment_likes WHERE deleted_at IS NULL`).Error
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Exec(`UPDATE comments c
+			JOIN (SELECT comment_id, SUM(value = 1) AS ups, SUM(value = -1) AS downs FROM comment_votes GROUP BY comment_id) v ON v.comment_id = c.id
+			SET c.upvotes_count = v.ups, c.downvotes_count = v.downs, c.score = v.ups - v.downs`).Error
+	})
 }
@@ -0,0 +1,44 @@
+package http
+
+import (
+	"macaiki/internal/export"
+	_middL "macaiki/pkg/middleware"
+	"macaiki/pkg/response"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+type ExportHandler struct {
+	exportUsecase export.ExportUsecase
+	JWTSecret     string
+}
+
+func NewExportHandler(e *echo.Echo, exportUsecase export.ExportUsecase, JWTSecret string) {
+	handler := &ExportHandler{exportUsecase, JWTSecret}
+
+	e.GET("/api/v1/curent-user/exports", handler.GetExports, middleware.JWT([]byte(JWTSecret)))
+	e.POST("/api/v1/curent-user/exports", handler.RequestExport, middleware.JWT([]byte(JWTSecret)))
+}
+
+func (h *ExportHandler) GetExports(c echo.Context) error {
+	userID, _ := _middL.ExtractTokenUser(c)
+
+	res, err := h.exportUsecase.GetExports(c.Request().Context(), uint(userID))
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, res)
+}
+
+func (h *ExportHandler) RequestExport(c echo.Context) error {
+	userID, _ := _middL.ExtractTokenUser(c)
+
+	res, err := h.exportUsecase.RequestExport(c.Request().Context(), uint(userID))
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, res)
+}
@@ -0,0 +1,12 @@
+package dto
+
+import "time"
+
+type ExportResponse struct {
+	ID          uint       `json:"ID"`
+	Status      string     `json:"status"`
+	DownloadURL string     `json:"downloadURL,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+	ExpiredAt   *time.Time `json:"expiredAt,omitempty"`
+}
@@ -0,0 +1,120 @@
+package entity
+
+import "time"
+
+const (
+	ExportStatusPending = "pending"
+	ExportStatusRunning = "running"
+	ExportStatusReady   = "ready"
+	ExportStatusFailed  = "failed"
+	ExportStatusExpired = "expired"
+)
+
+// Export is a requested copy of everything a user has stored with us. The
+// archive lives in private storage under FileKey and is handed out through
+// presigned links until ExpiredAt.
+type Export struct {
+	ID          uint   `gorm:"primaryKey"`
+	UserID      uint   `gorm:"index"`
+	Status      string `gorm:"size:20"`
+	FileKey     string
+	CompletedAt *time.Time
+	ExpiredAt   *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// The records below are the rows written to the JSON files of the archive
+
+type ProfileRecord struct {
+	ID                 uint       `json:"ID"`
+	Username           string     `json:"username"`
+	Email              string     `json:"email"`
+	Name               string     `json:"name"`
+	Bio                string     `json:"bio"`
+	Profession         string     `json:"profession"`
+	Role               string     `json:"role"`
+	ProfileImageUrl    string     `json:"profileImageURL"`
+	BackgroundImageUrl string     `json:"backgroundImageURL"`
+	EmailVerifiedAt    *time.Time `json:"emailVerifiedAt"`
+	CreatedAt          time.Time  `json:"createdAt"`
+}
+
+type ThreadRecord struct {
+	ID          uint      `json:"ID"`
+	Title       string    `json:"title"`
+	Body        string    `json:"body"`
+	ImageURL    string    `json:"imageURL"`
+	CommunityID uint      `json:"communityID"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+type CommentRecord struct {
+	ID        uint      `json:"ID"`
+	Body      string    `json:"body"`
+	ThreadID  uint      `json:"threadID"`
+	CommentID uint      `json:"commentID"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// AttachmentRecord is an image added to the gallery of a thread or of a
+// comment, the file itself is under images/thread in the archive
+type AttachmentRecord struct {
+	ID        uint      `json:"ID"`
+	ThreadID  uint      `json:"threadID"`
+	CommentID uint      `json:"commentID"`
+	FileName  string    `json:"fileName"`
+	AltText   string    `json:"altText"`
+	Caption   string    `json:"caption"`
+	Width     int       `json:"width"`
+	Height    int       `json:"height"`
+	Position  int       `json:"position"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// VoteRecord is an upvote or downvote on a thread or a comment, Type tells
+// which one and Value holds the vote as stored, 1 or -1
+type VoteRecord struct {
+	Type      string    `json:"type"`
+	TargetID  uint      `json:"targetID"`
+	Value     int       `json:"value"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type SavedThreadRecord struct {
+	ThreadID   uint      `json:"threadID"`
+	Title      string    `json:"title"`
+	Collection string    `json:"collection"`
+	Note       string    `json:"note"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+type FollowRecord struct {
+	UserID   uint   `json:"userID"`
+	Username string `json:"username"`
+}
+
+// CommunityRecord is a community the user follows or moderates
+type CommunityRecord struct {
+	CommunityID uint   `json:"communityID"`
+	Name        string `json:"name"`
+	Role        string `json:"role"`
+}
+
+// ReportRecord is a report filed by the user against a thread, comment,
+// user or community
+type ReportRecord struct {
+	Type           string    `json:"type"`
+	TargetID       uint      `json:"targetID"`
+	ReportCategory string    `json:"reportCategory"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+type NotificationRecord struct {
+	ID                uint      `json:"ID"`
+	NotificationType  string    `json:"notificationType"`
+	NotificationRefID uint      `json:"notificationRefID"`
+	IsReaded          int       `json:"isReaded"`
+	CreatedAt         time.Time `json:"createdAt"`
+}
@@ -0,0 +1,17 @@
+package export
+
+// JobBuildExport is the job type used to assemble a data export in the
+// background, its payload is a BuildExportPayload
+const JobBuildExport = "export.build"
+
+type BuildExportPayload struct {
+	ExportID uint
+}
+
+// JobExpireExport is the job type used to remove the archive of an export
+// once its link has expired, its payload is an ExpireExportPayload
+const JobExpireExport = "export.expire"
+
+type ExpireExportPayload struct {
+	ExportID uint
+}
@@ -0,0 +1,383 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	entity "macaiki/internal/export/entity"
+
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ExportRepository is an autogenerated mock type for the ExportRepository type
+type ExportRepository struct {
+	mock.Mock
+}
+
+// GetActiveExport provides a mock function with given fields: ctx, userID, since
+func (_m *ExportRepository) GetActiveExport(ctx context.Context, userID uint, since time.Time) (entity.Export, error) {
+	ret := _m.Called(ctx, userID, since)
+
+	var r0 entity.Export
+	if rf, ok := ret.Get(0).(func(context.Context, uint, time.Time) entity.Export); ok {
+		r0 = rf(ctx, userID, since)
+	} else {
+		r0 = ret.Get(0).(entity.Export)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, time.Time) error); ok {
+		r1 = rf(ctx, userID, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAttachments provides a mock function with given fields: ctx, userID
+func (_m *ExportRepository) GetAttachments(ctx context.Context, userID uint) ([]entity.AttachmentRecord, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []entity.AttachmentRecord
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []entity.AttachmentRecord); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.AttachmentRecord)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetComments provides a mock function with given fields: ctx, userID
+func (_m *ExportRepository) GetComments(ctx context.Context, userID uint) ([]entity.CommentRecord, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []entity.CommentRecord
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []entity.CommentRecord); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.CommentRecord)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCommunities provides a mock function with given fields: ctx, userID
+func (_m *ExportRepository) GetCommunities(ctx context.Context, userID uint) ([]entity.CommunityRecord, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []entity.CommunityRecord
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []entity.CommunityRecord); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.CommunityRecord)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetExport provides a mock function with given fields: ctx, exportID
+func (_m *ExportRepository) GetExport(ctx context.Context, exportID uint) (entity.Export, error) {
+	ret := _m.Called(ctx, exportID)
+
+	var r0 entity.Export
+	if rf, ok := ret.Get(0).(func(context.Context, uint) entity.Export); ok {
+		r0 = rf(ctx, exportID)
+	} else {
+		r0 = ret.Get(0).(entity.Export)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, exportID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetExports provides a mock function with given fields: ctx, userID
+func (_m *ExportRepository) GetExports(ctx context.Context, userID uint) ([]entity.Export, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []entity.Export
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []entity.Export); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Export)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFollowers provides a mock function with given fields: ctx, userID
+func (_m *ExportRepository) GetFollowers(ctx context.Context, userID uint) ([]entity.FollowRecord, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []entity.FollowRecord
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []entity.FollowRecord); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.FollowRecord)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFollowing provides a mock function with given fields: ctx, userID
+func (_m *ExportRepository) GetFollowing(ctx context.Context, userID uint) ([]entity.FollowRecord, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []entity.FollowRecord
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []entity.FollowRecord); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.FollowRecord)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetNotifications provides a mock function with given fields: ctx, userID
+func (_m *ExportRepository) GetNotifications(ctx context.Context, userID uint) ([]entity.NotificationRecord, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []entity.NotificationRecord
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []entity.NotificationRecord); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.NotificationRecord)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProfile provides a mock function with given fields: ctx, userID
+func (_m *ExportRepository) GetProfile(ctx context.Context, userID uint) (entity.ProfileRecord, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 entity.ProfileRecord
+	if rf, ok := ret.Get(0).(func(context.Context, uint) entity.ProfileRecord); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(entity.ProfileRecord)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReports provides a mock function with given fields: ctx, userID
+func (_m *ExportRepository) GetReports(ctx context.Context, userID uint) ([]entity.ReportRecord, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []entity.ReportRecord
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []entity.ReportRecord); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ReportRecord)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSavedThreads provides a mock function with given fields: ctx, userID
+func (_m *ExportRepository) GetSavedThreads(ctx context.Context, userID uint) ([]entity.SavedThreadRecord, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []entity.SavedThreadRecord
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []entity.SavedThreadRecord); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.SavedThreadRecord)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetThreads provides a mock function with given fields: ctx, userID
+func (_m *ExportRepository) GetThreads(ctx context.Context, userID uint) ([]entity.ThreadRecord, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []entity.ThreadRecord
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []entity.ThreadRecord); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ThreadRecord)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetVotes provides a mock function with given fields: ctx, userID
+func (_m *ExportRepository) GetVotes(ctx context.Context, userID uint) ([]entity.VoteRecord, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []entity.VoteRecord
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []entity.VoteRecord); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.VoteRecord)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StoreExport provides a mock function with given fields: ctx, _a1
+func (_m *ExportRepository) StoreExport(ctx context.Context, _a1 entity.Export) (entity.Export, error) {
+	ret := _m.Called(ctx, _a1)
+
+	var r0 entity.Export
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Export) entity.Export); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Get(0).(entity.Export)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Export) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateExport provides a mock function with given fields: ctx, _a1
+func (_m *ExportRepository) UpdateExport(ctx context.Context, _a1 entity.Export) error {
+	ret := _m.Called(ctx, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Export) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewExportRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewExportRepository creates a new instance of ExportRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewExportRepository(t mockConstructorTestingTNewExportRepository) *ExportRepository {
+	mock := &ExportRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,102 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	dto "macaiki/internal/export/dto"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ExportUsecase is an autogenerated mock type for the ExportUsecase type
+type ExportUsecase struct {
+	mock.Mock
+}
+
+// BuildExport provides a mock function with given fields: ctx, exportID
+func (_m *ExportUsecase) BuildExport(ctx context.Context, exportID uint) error {
+	ret := _m.Called(ctx, exportID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, exportID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExpireExport provides a mock function with given fields: ctx, exportID
+func (_m *ExportUsecase) ExpireExport(ctx context.Context, exportID uint) error {
+	ret := _m.Called(ctx, exportID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, exportID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetExports provides a mock function with given fields: ctx, userID
+func (_m *ExportUsecase) GetExports(ctx context.Context, userID uint) ([]dto.ExportResponse, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []dto.ExportResponse
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []dto.ExportResponse); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.ExportResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RequestExport provides a mock function with given fields: ctx, userID
+func (_m *ExportUsecase) RequestExport(ctx context.Context, userID uint) (dto.ExportResponse, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 dto.ExportResponse
+	if rf, ok := ret.Get(0).(func(context.Context, uint) dto.ExportResponse); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(dto.ExportResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewExportUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewExportUsecase creates a new instance of ExportUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewExportUsecase(t mockConstructorTestingTNewExportUsecase) *ExportUsecase {
+	mock := &ExportUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
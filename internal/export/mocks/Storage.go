@@ -0,0 +1,104 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	io "io"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Storage is an autogenerated mock type for the Storage type
+type Storage struct {
+	mock.Mock
+}
+
+// DeleteObject provides a mock function with given fields: ctx, key
+func (_m *Storage) DeleteObject(ctx context.Context, key string) error {
+	ret := _m.Called(ctx, key)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetObject provides a mock function with given fields: ctx, key
+func (_m *Storage) GetObject(ctx context.Context, key string) (io.ReadCloser, error) {
+	ret := _m.Called(ctx, key)
+
+	var r0 io.ReadCloser
+	if rf, ok := ret.Get(0).(func(context.Context, string) io.ReadCloser); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PresignGetURL provides a mock function with given fields: key, ttl
+func (_m *Storage) PresignGetURL(key string, ttl time.Duration) (string, error) {
+	ret := _m.Called(key, ttl)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, time.Duration) string); ok {
+		r0 = rf(key, ttl)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, time.Duration) error); ok {
+		r1 = rf(key, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PutObject provides a mock function with given fields: ctx, key, body, contentType
+func (_m *Storage) PutObject(ctx context.Context, key string, body io.Reader, contentType string) error {
+	ret := _m.Called(ctx, key, body, contentType)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, io.Reader, string) error); ok {
+		r0 = rf(ctx, key, body, contentType)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewStorage interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewStorage creates a new instance of Storage. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewStorage(t mockConstructorTestingTNewStorage) *Storage {
+	mock := &Storage{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
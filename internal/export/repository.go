@@ -0,0 +1,27 @@
+package export
+
+import (
+	"context"
+	"macaiki/internal/export/entity"
+	"time"
+)
+
+type ExportRepository interface {
+	StoreExport(ctx context.Context, export entity.Export) (entity.Export, error)
+	GetExport(ctx context.Context, exportID uint) (entity.Export, error)
+	GetActiveExport(ctx context.Context, userID uint, since time.Time) (entity.Export, error)
+	GetExports(ctx context.Context, userID uint) ([]entity.Export, error)
+	UpdateExport(ctx context.Context, export entity.Export) error
+
+	GetProfile(ctx context.Context, userID uint) (entity.ProfileRecord, error)
+	GetThreads(ctx context.Context, userID uint) ([]entity.ThreadRecord, error)
+	GetComments(ctx context.Context, userID uint) ([]entity.CommentRecord, error)
+	GetAttachments(ctx context.Context, userID uint) ([]entity.AttachmentRecord, error)
+	GetVotes(ctx context.Context, userID uint) ([]entity.VoteRecord, error)
+	GetSavedThreads(ctx context.Context, userID uint) ([]entity.SavedThreadRecord, error)
+	GetFollowers(ctx context.Context, userID uint) ([]entity.FollowRecord, error)
+	GetFollowing(ctx context.Context, userID uint) ([]entity.FollowRecord, error)
+	GetCommunities(ctx context.Context, userID uint) ([]entity.CommunityRecord, error)
+	GetReports(ctx context.Context, userID uint) ([]entity.ReportRecord, error)
+	GetNotifications(ctx context.Context, userID uint) ([]entity.NotificationRecord, error)
+}
@@ -0,0 +1,212 @@
+package mysql
+
+import (
+	"context"
+	"log/slog"
+	"macaiki/internal/export"
+	"macaiki/internal/export/entity"
+	"macaiki/pkg/logger"
+	"macaiki/pkg/utils"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ExportRepositoryImpl struct {
+	db     *gorm.DB
+	logger *slog.Logger
+}
+
+func NewExportRepository(db *gorm.DB, log *slog.Logger) export.ExportRepository {
+	return &ExportRepositoryImpl{db, logger.OrDefault(log)}
+}
+
+func (er *ExportRepositoryImpl) StoreExport(ctx context.Context, export entity.Export) (entity.Export, error) {
+	res := er.db.WithContext(ctx).Create(&export)
+	if res.Error != nil {
+		er.logger.ErrorContext(ctx, "query failed", "op", "StoreExport", "err", res.Error)
+		return entity.Export{}, utils.ErrInternalServerError
+	}
+
+	return export, nil
+}
+
+func (er *ExportRepositoryImpl) GetExport(ctx context.Context, exportID uint) (entity.Export, error) {
+	export := entity.Export{}
+	res := er.db.WithContext(ctx).Where("id = ?", exportID).Limit(1).Find(&export)
+	if res.Error != nil {
+		er.logger.ErrorContext(ctx, "query failed", "op", "GetExport", "err", res.Error)
+		return entity.Export{}, utils.ErrInternalServerError
+	}
+	if res.RowsAffected == 0 {
+		return entity.Export{}, utils.ErrNotFound
+	}
+
+	return export, nil
+}
+
+// GetActiveExport returns an export of the user requested after since that
+// is still waiting for or being processed by the job runner
+func (er *ExportRepositoryImpl) GetActiveExport(ctx context.Context, userID uint, since time.Time) (entity.Export, error) {
+	export := entity.Export{}
+	res := er.db.WithContext(ctx).Where("user_id = ? AND status IN ? AND created_at > ?", userID, []string{entity.ExportStatusPending, entity.ExportStatusRunning}, since).Limit(1).Find(&export)
+	if res.Error != nil {
+		er.logger.ErrorContext(ctx, "query failed", "op", "GetActiveExport", "err", res.Error)
+		return entity.Export{}, utils.ErrInternalServerError
+	}
+	if res.RowsAffected == 0 {
+		return entity.Export{}, utils.ErrNotFound
+	}
+
+	return export, nil
+}
+
+func (er *ExportRepositoryImpl) GetExports(ctx context.Context, userID uint) ([]entity.Export, error) {
+	exports := []entity.Export{}
+	res := er.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at DESC").Find(&exports)
+	if res.Error != nil {
+		er.logger.ErrorContext(ctx, "query failed", "op", "GetExports", "err", res.Error)
+		return []entity.Export{}, utils.ErrInternalServerError
+	}
+
+	return exports, nil
+}
+
+func (er *ExportRepositoryImpl) UpdateExport(ctx context.Context, export entity.Export) error {
+	res := er.db.WithContext(ctx).Model(&entity.Export{ID: export.ID}).Select("status", "file_key", "completed_at", "expired_at").Updates(export)
+	if res.Error != nil {
+		er.logger.ErrorContext(ctx, "query failed", "op", "UpdateExport", "err", res.Error)
+		return utils.ErrInternalServerError
+	}
+	if res.RowsAffected == 0 {
+		return utils.ErrNotFound
+	}
+
+	return nil
+}
+
+func (er *ExportRepositoryImpl) GetProfile(ctx context.Context, userID uint) (entity.ProfileRecord, error) {
+	profile := entity.ProfileRecord{}
+	res := er.db.WithContext(ctx).Raw("SELECT id, username, email, name, bio, profession, role, profile_image_url, background_image_url, email_verified_at, created_at FROM users WHERE id = ? AND deleted_at IS NULL", userID).Scan(&profile)
+	if res.Error != nil {
+		er.logger.ErrorContext(ctx, "query failed", "op", "GetProfile", "err", res.Error)
+		return entity.ProfileRecord{}, utils.ErrInternalServerError
+	}
+	if res.RowsAffected == 0 {
+		return entity.ProfileRecord{}, utils.ErrNotFound
+	}
+
+	return profile, nil
+}
+
+func (er *ExportRepositoryImpl) GetThreads(ctx context.Context, userID uint) ([]entity.ThreadRecord, error) {
+	threads := []entity.ThreadRecord{}
+	res := er.db.WithContext(ctx).Raw("SELECT id, title, body, image_url, community_id, created_at, updated_at FROM threads WHERE user_id = ? AND deleted_at IS NULL ORDER BY created_at", userID).Scan(&threads)
+	if res.Error != nil {
+		er.logger.ErrorContext(ctx, "query failed", "op", "GetThreads", "err", res.Error)
+		return []entity.ThreadRecord{}, utils.ErrInternalServerError
+	}
+
+	return threads, nil
+}
+
+func (er *ExportRepositoryImpl) GetComments(ctx context.Context, userID uint) ([]entity.CommentRecord, error) {
+	comments := []entity.CommentRecord{}
+	res := er.db.WithContext(ctx).Raw("SELECT id, body, thread_id, comment_id, created_at FROM comments WHERE user_id = ? AND deleted_at IS NULL ORDER BY created_at", userID).Scan(&comments)
+	if res.Error != nil {
+		er.logger.ErrorContext(ctx, "query failed", "op", "GetComments", "err", res.Error)
+		return []entity.CommentRecord{}, utils.ErrInternalServerError
+	}
+
+	return comments, nil
+}
+
+// GetAttachments lists the images the user added to threads and comments that
+// are still around
+func (er *ExportRepositoryImpl) GetAttachments(ctx context.Context, userID uint) ([]entity.AttachmentRecord, error) {
+	attachments := []entity.AttachmentRecord{}
+	res := er.db.WithContext(ctx).Raw("SELECT a.id, a.thread_id, a.comment_id, a.file_name, a.alt_text, a.caption, a.width, a.height, a.position, a.created_at FROM attachments a INNER JOIN threads t ON t.id = a.thread_id AND t.deleted_at IS NULL WHERE a.user_id = ? ORDER BY a.created_at", userID).Scan(&attachments)
+	if res.Error != nil {
+		er.logger.ErrorContext(ctx, "query failed", "op", "GetAttachments", "err", res.Error)
+		return []entity.AttachmentRecord{}, utils.ErrInternalServerError
+	}
+
+	return attachments, nil
+}
+
+func (er *ExportRepositoryImpl) GetVotes(ctx context.Context, userID uint) ([]entity.VoteRecord, error) {
+	votes := []entity.VoteRecord{}
+	res := er.db.WithContext(ctx).Raw("SELECT IF(value > 0, 'thread_upvote', 'thread_downvote') AS type, thread_id AS target_id, value, updated_at AS created_at FROM thread_votes WHERE user_id = ? UNION ALL SELECT IF(value > 0, 'comment_upvote', 'comment_downvote') AS type, comment_id AS target_id, value, updated_at AS created_at FROM comment_votes WHERE user_id = ? ORDER BY created_at", userID, userID).Scan(&votes)
+	if res.Error != nil {
+		er.logger.ErrorContext(ctx, "query failed", "op", "GetVotes", "err", res.Error)
+		return []entity.VoteRecord{}, utils.ErrInternalServerError
+	}
+
+	return votes, nil
+}
+
+func (er *ExportRepositoryImpl) GetSavedThreads(ctx context.Context, userID uint) ([]entity.SavedThreadRecord, error) {
+	saved := []entity.SavedThreadRecord{}
+	res := er.db.WithContext(ctx).Raw("SELECT st.thread_id, t.title, COALESCE(c.name, '') AS collection, st.note, st.created_at FROM saved_threads st LEFT JOIN threads t ON t.id = st.thread_id LEFT JOIN saved_thread_collections c ON c.id = st.collection_id WHERE st.user_id = ? AND st.deleted_at IS NULL ORDER BY st.created_at", userID).Scan(&saved)
+	if res.Error != nil {
+		er.logger.ErrorContext(ctx, "query failed", "op", "GetSavedThreads", "err", res.Error)
+		return []entity.SavedThreadRecord{}, utils.ErrInternalServerError
+	}
+
+	return saved, nil
+}
+
+func (er *ExportRepositoryImpl) GetFollowers(ctx context.Context, userID uint) ([]entity.FollowRecord, error) {
+	followers := []entity.FollowRecord{}
+	res := er.db.WithContext(ctx).Raw("SELECT u.id AS user_id, u.username FROM user_followers uf INNER JOIN users u ON u.id = uf.follower_id WHERE uf.user_id = ? AND u.deleted_at IS NULL", userID).Scan(&followers)
+	if res.Error != nil {
+		er.logger.ErrorContext(ctx, "query failed", "op", "GetFollowers", "err", res.Error)
+		return []entity.FollowRecord{}, utils.ErrInternalServerError
+	}
+
+	return followers, nil
+}
+
+func (er *ExportRepositoryImpl) GetFollowing(ctx context.Context, userID uint) ([]entity.FollowRecord, error) {
+	following := []entity.FollowRecord{}
+	res := er.db.WithContext(ctx).Raw("SELECT u.id AS user_id, u.username FROM user_followers uf INNER JOIN users u ON u.id = uf.user_id WHERE uf.follower_id = ? AND u.deleted_at IS NULL", userID).Scan(&following)
+	if res.Error != nil {
+		er.logger.ErrorContext(ctx, "query failed", "op", "GetFollowing", "err", res.Error)
+		return []entity.FollowRecord{}, utils.ErrInternalServerError
+	}
+
+	return following, nil
+}
+
+func (er *ExportRepositoryImpl) GetCommunities(ctx context.Context, userID uint) ([]entity.CommunityRecord, error) {
+	communities := []entity.CommunityRecord{}
+	res := er.db.WithContext(ctx).Raw("SELECT c.id AS community_id, c.name, 'follower' AS role FROM community_followers cf INNER JOIN communities c ON c.id = cf.community_id WHERE cf.user_id = ? AND c.deleted_at IS NULL UNION ALL SELECT c.id AS community_id, c.name, 'moderator' AS role FROM community_moderators cm INNER JOIN communities c ON c.id = cm.community_id WHERE cm.user_id = ? AND c.deleted_at IS NULL", userID, userID).Scan(&communities)
+	if res.Error != nil {
+		er.logger.ErrorContext(ctx, "query failed", "op", "GetCommunities", "err", res.Error)
+		return []entity.CommunityRecord{}, utils.ErrInternalServerError
+	}
+
+	return communities, nil
+}
+
+func (er *ExportRepositoryImpl) GetReports(ctx context.Context, userID uint) ([]entity.ReportRecord, error) {
+	reports := []entity.ReportRecord{}
+	res := er.db.WithContext(ctx).Raw("SELECT 'thread' AS type, tr.thread_id AS target_id, rc.name AS report_category, tr.created_at FROM thread_reports tr LEFT JOIN report_categories rc ON rc.id = tr.report_category_id WHERE tr.user_id = ? AND tr.deleted_at IS NULL UNION ALL SELECT 'comment' AS type, cr.comment_id AS target_id, rc.name AS report_category, cr.created_at FROM comment_reports cr LEFT JOIN report_categories rc ON rc.id = cr.report_category_id WHERE cr.user_id = ? AND cr.deleted_at IS NULL UNION ALL SELECT 'user' AS type, ur.reported_user_id AS target_id, rc.name AS report_category, ur.created_at FROM user_reports ur LEFT JOIN report_categories rc ON rc.id = ur.report_category_id WHERE ur.user_id = ? AND ur.deleted_at IS NULL UNION ALL SELECT 'community' AS type, cr2.community_reported_id AS target_id, rc.name AS report_category, cr2.created_at FROM community_reports cr2 LEFT JOIN report_categories rc ON rc.id = cr2.report_category_id WHERE cr2.user_id = ? AND cr2.deleted_at IS NULL ORDER BY created_at", userID, userID, userID, userID).Scan(&reports)
+	if res.Error != nil {
+		er.logger.ErrorContext(ctx, "query failed", "op", "GetReports", "err", res.Error)
+		return []entity.ReportRecord{}, utils.ErrInternalServerError
+	}
+
+	return reports, nil
+}
+
+func (er *ExportRepositoryImpl) GetNotifications(ctx context.Context, userID uint) ([]entity.NotificationRecord, error) {
+	notifications := []entity.NotificationRecord{}
+	res := er.db.WithContext(ctx).Raw("SELECT id, notification_type, notification_ref_id, is_readed, created_at FROM notifications WHERE user_id = ? AND deleted_at IS NULL ORDER BY created_at", userID).Scan(&notifications)
+	if res.Error != nil {
+		er.logger.ErrorContext(ctx, "query failed", "op", "GetNotifications", "err", res.Error)
+		return []entity.NotificationRecord{}, utils.ErrInternalServerError
+	}
+
+	return notifications, nil
+}
@@ -0,0 +1,16 @@
+package export
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// Storage is the part of the object storage the export needs, it is
+// implemented by cloudstorage.S3
+type Storage interface {
+	GetObject(ctx context.Context, key string) (io.ReadCloser, error)
+	PutObject(ctx context.Context, key string, body io.Reader, contentType string) error
+	DeleteObject(ctx context.Context, key string) error
+	PresignGetURL(key string, ttl time.Duration) (string, error)
+}
@@ -0,0 +1,13 @@
+package export
+
+import (
+	"context"
+	"macaiki/internal/export/dto"
+)
+
+type ExportUsecase interface {
+	RequestExport(ctx context.Context, userID uint) (dto.ExportResponse, error)
+	GetExports(ctx context.Context, userID uint) ([]dto.ExportResponse, error)
+	BuildExport(ctx context.Context, exportID uint) error
+	ExpireExport(ctx context.Context, exportID uint) error
+}
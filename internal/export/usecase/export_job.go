@@ -0,0 +1,32 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"macaiki/internal/export"
+	"macaiki/internal/job"
+)
+
+// NewBuildExportHandler handles export.JobBuildExport jobs
+func NewBuildExportHandler(exportUsecase export.ExportUsecase) job.HandlerFunc {
+	return func(ctx context.Context, payload []byte) error {
+		p := export.BuildExportPayload{}
+		if err := json.Unmarshal(payload, &p); err != nil {
+			return err
+		}
+
+		return exportUsecase.BuildExport(ctx, p.ExportID)
+	}
+}
+
+// NewExpireExportHandler handles export.JobExpireExport jobs
+func NewExpireExportHandler(exportUsecase export.ExportUsecase) job.HandlerFunc {
+	return func(ctx context.Context, payload []byte) error {
+		p := export.ExpireExportPayload{}
+		if err := json.Unmarshal(payload, &p); err != nil {
+			return err
+		}
+
+		return exportUsecase.ExpireExport(ctx, p.ExportID)
+	}
+}
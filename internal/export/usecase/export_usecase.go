@@ -0,0 +1,397 @@
+package usecase
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"macaiki/internal/export"
+	"macaiki/internal/export/dto"
+	"macaiki/internal/export/entity"
+	"macaiki/internal/job"
+	userEntity "macaiki/internal/user/entity"
+	cloudstorage "macaiki/pkg/cloud_storage"
+	"macaiki/pkg/logger"
+	"macaiki/pkg/mailer"
+	"macaiki/pkg/utils"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// EXPORT_REQUEST_WINDOW is how long an unfinished export blocks a new request
+	EXPORT_REQUEST_WINDOW = 24 * time.Hour
+	EXPORT_DIR            = "exports"
+)
+
+type exportUsecase struct {
+	exportRepo export.ExportRepository
+	storage    export.Storage
+	mailOutbox mailer.Outbox
+	jobQueue   job.Queue
+	linkTTL    time.Duration
+	logger     *slog.Logger
+}
+
+func NewExportUsecase(exportRepo export.ExportRepository, storage export.Storage, mailOutbox mailer.Outbox, jobQueue job.Queue, linkTTL time.Duration, log *slog.Logger) export.ExportUsecase {
+	return &exportUsecase{
+		exportRepo: exportRepo,
+		storage:    storage,
+		mailOutbox: mailOutbox,
+		jobQueue:   jobQueue,
+		linkTTL:    linkTTL,
+		logger:     logger.OrDefault(log),
+	}
+}
+
+func (eu *exportUsecase) RequestExport(ctx context.Context, userID uint) (dto.ExportResponse, error) {
+	_, err := eu.exportRepo.GetActiveExport(ctx, userID, time.Now().Add(-EXPORT_REQUEST_WINDOW))
+	if err == nil {
+		return dto.ExportResponse{}, utils.ErrConflict
+	}
+	if !errors.Is(err, utils.ErrNotFound) {
+		return dto.ExportResponse{}, err
+	}
+
+	exp, err := eu.exportRepo.StoreExport(ctx, entity.Export{
+		UserID: userID,
+		Status: entity.ExportStatusPending,
+	})
+	if err != nil {
+		return dto.ExportResponse{}, err
+	}
+
+	err = eu.jobQueue.Enqueue(ctx, export.JobBuildExport, export.BuildExportPayload{ExportID: exp.ID})
+	if err != nil {
+		eu.logger.ErrorContext(ctx, "enqueue export failed", "export_id", exp.ID, "err", err)
+		return dto.ExportResponse{}, utils.ErrInternalServerError
+	}
+
+	return eu.toExportResponse(ctx, exp), nil
+}
+
+func (eu *exportUsecase) GetExports(ctx context.Context, userID uint) ([]dto.ExportResponse, error) {
+	exports, err := eu.exportRepo.GetExports(ctx, userID)
+	if err != nil {
+		return []dto.ExportResponse{}, err
+	}
+
+	res := []dto.ExportResponse{}
+	for _, exp := range exports {
+		res = append(res, eu.toExportResponse(ctx, exp))
+	}
+
+	return res, nil
+}
+
+// BuildExport collects the data of the user into a ZIP archive, uploads it
+// and emails a download link. Archives of earlier exports are removed once
+// the new one is ready.
+func (eu *exportUsecase) BuildExport(ctx context.Context, exportID uint) error {
+	exp, err := eu.exportRepo.GetExport(ctx, exportID)
+	if errors.Is(err, utils.ErrNotFound) {
+		// the export is gone, there is nothing left to retry
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if exp.Status == entity.ExportStatusReady || exp.Status == entity.ExportStatusExpired {
+		return nil
+	}
+
+	exp.Status = entity.ExportStatusRunning
+	if err := eu.exportRepo.UpdateExport(ctx, exp); err != nil {
+		return err
+	}
+
+	if err := eu.buildExport(ctx, &exp); err != nil {
+		exp.Status = entity.ExportStatusFailed
+		if updateErr := eu.exportRepo.UpdateExport(ctx, exp); updateErr != nil {
+			eu.logger.ErrorContext(ctx, "mark export failed", "export_id", exp.ID, "err", updateErr)
+		}
+		return err
+	}
+
+	eu.removePreviousExports(ctx, exp)
+	return nil
+}
+
+// ExpireExport deletes the archive of a ready export once its link has
+// expired and marks the export as expired.
+func (eu *exportUsecase) ExpireExport(ctx context.Context, exportID uint) error {
+	exp, err := eu.exportRepo.GetExport(ctx, exportID)
+	if errors.Is(err, utils.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if exp.Status != entity.ExportStatusReady || exp.FileKey == "" {
+		return nil
+	}
+	if exp.ExpiredAt != nil && time.Now().Before(*exp.ExpiredAt) {
+		return nil
+	}
+
+	if err := eu.storage.DeleteObject(ctx, exp.FileKey); err != nil {
+		return fmt.Errorf("delete export archive: %w", err)
+	}
+
+	exp.Status = entity.ExportStatusExpired
+	exp.FileKey = ""
+	return eu.exportRepo.UpdateExport(ctx, exp)
+}
+
+func (eu *exportUsecase) buildExport(ctx context.Context, exp *entity.Export) error {
+	profile, err := eu.exportRepo.GetProfile(ctx, exp.UserID)
+	if err != nil {
+		return err
+	}
+
+	archive, err := os.CreateTemp("", "export-*.zip")
+	if err != nil {
+		return fmt.Errorf("create export archive: %w", err)
+	}
+	defer os.Remove(archive.Name())
+	defer archive.Close()
+
+	if err := eu.writeArchive(ctx, archive, profile); err != nil {
+		return err
+	}
+
+	if _, err := archive.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("rewind export archive: %w", err)
+	}
+
+	key := fmt.Sprintf("%s/%d/%s.zip", EXPORT_DIR, exp.UserID, uuid.New())
+	if err := eu.storage.PutObject(ctx, key, archive, "application/zip"); err != nil {
+		return fmt.Errorf("upload export archive: %w", err)
+	}
+
+	link, err := eu.storage.PresignGetURL(key, eu.linkTTL)
+	if err != nil {
+		return fmt.Errorf("presign export archive: %w", err)
+	}
+
+	now := time.Now()
+	expiredAt := now.Add(eu.linkTTL)
+	exp.Status = entity.ExportStatusReady
+	exp.FileKey = key
+	exp.CompletedAt = &now
+	exp.ExpiredAt = &expiredAt
+	if err := eu.exportRepo.UpdateExport(ctx, *exp); err != nil {
+		return err
+	}
+
+	err = eu.jobQueue.EnqueueAt(ctx, export.JobExpireExport, export.ExpireExportPayload{ExportID: exp.ID}, expiredAt)
+	if err != nil {
+		// the archive is still removed once the user builds a newer export
+		eu.logger.ErrorContext(ctx, "enqueue export expiry failed", "export_id", exp.ID, "err", err)
+	}
+
+	return eu.mailOutbox.Enqueue(ctx, profile.Email, "export_ready", map[string]interface{}{
+		"Username":  profile.Username,
+		"Link":      link,
+		"ExpiresIn": eu.linkTTL.String(),
+	})
+}
+
+func (eu *exportUsecase) writeArchive(ctx context.Context, w io.Writer, profile entity.ProfileRecord) error {
+	zw := zip.NewWriter(w)
+	userID := profile.ID
+
+	threads, err := eu.exportRepo.GetThreads(ctx, userID)
+	if err != nil {
+		return err
+	}
+	comments, err := eu.exportRepo.GetComments(ctx, userID)
+	if err != nil {
+		return err
+	}
+	attachments, err := eu.exportRepo.GetAttachments(ctx, userID)
+	if err != nil {
+		return err
+	}
+	votes, err := eu.exportRepo.GetVotes(ctx, userID)
+	if err != nil {
+		return err
+	}
+	savedThreads, err := eu.exportRepo.GetSavedThreads(ctx, userID)
+	if err != nil {
+		return err
+	}
+	followers, err := eu.exportRepo.GetFollowers(ctx, userID)
+	if err != nil {
+		return err
+	}
+	following, err := eu.exportRepo.GetFollowing(ctx, userID)
+	if err != nil {
+		return err
+	}
+	communities, err := eu.exportRepo.GetCommunities(ctx, userID)
+	if err != nil {
+		return err
+	}
+	reports, err := eu.exportRepo.GetReports(ctx, userID)
+	if err != nil {
+		return err
+	}
+	notifications, err := eu.exportRepo.GetNotifications(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	files := []struct {
+		name string
+		data interface{}
+	}{
+		{"profile.json", profile},
+		{"threads.json", threads},
+		{"comments.json", comments},
+		{"attachments.json", attachments},
+		{"votes.json", votes},
+		{"saved_threads.json", savedThreads},
+		{"followers.json", followers},
+		{"following.json", following},
+		{"communities.json", communities},
+		{"reports.json", reports},
+		{"notifications.json", notifications},
+	}
+	for _, file := range files {
+		if err := writeJSON(zw, file.name, file.data); err != nil {
+			return err
+		}
+	}
+
+	for _, key := range imageKeys(profile, threads, attachments) {
+		if err := eu.writeImage(ctx, zw, key); err != nil {
+			return err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("close export archive: %w", err)
+	}
+	return nil
+}
+
+// writeImage copies an uploaded image into the archive. Images that are no
+// longer in storage are skipped, a missing picture should not fail the
+// whole export.
+func (eu *exportUsecase) writeImage(ctx context.Context, zw *zip.Writer, key string) error {
+	body, err := eu.storage.GetObject(ctx, key)
+	if err != nil {
+		eu.logger.WarnContext(ctx, "skip export image", "key", key, "err", err)
+		return nil
+	}
+	defer body.Close()
+
+	f, err := zw.Create("images/" + key)
+	if err != nil {
+		return fmt.Errorf("add %s to export archive: %w", key, err)
+	}
+	if _, err := io.Copy(f, body); err != nil {
+		return fmt.Errorf("copy %s into export archive: %w", key, err)
+	}
+	return nil
+}
+
+func (eu *exportUsecase) removePreviousExports(ctx context.Context, current entity.Export) {
+	exports, err := eu.exportRepo.GetExports(ctx, current.UserID)
+	if err != nil {
+		return
+	}
+
+	for _, exp := range exports {
+		if exp.ID == current.ID || exp.FileKey == "" {
+			continue
+		}
+
+		if err := eu.storage.DeleteObject(ctx, exp.FileKey); err != nil {
+			eu.logger.WarnContext(ctx, "delete previous export failed", "export_id", exp.ID, "err", err)
+			continue
+		}
+
+		exp.Status = entity.ExportStatusExpired
+		exp.FileKey = ""
+		if err := eu.exportRepo.UpdateExport(ctx, exp); err != nil {
+			eu.logger.WarnContext(ctx, "expire previous export failed", "export_id", exp.ID, "err", err)
+		}
+	}
+}
+
+func (eu *exportUsecase) toExportResponse(ctx context.Context, exp entity.Export) dto.ExportResponse {
+	res := dto.ExportResponse{
+		ID:          exp.ID,
+		Status:      exp.Status,
+		CreatedAt:   exp.CreatedAt,
+		CompletedAt: exp.CompletedAt,
+		ExpiredAt:   exp.ExpiredAt,
+	}
+	if exp.Status != entity.ExportStatusReady {
+		return res
+	}
+
+	if exp.ExpiredAt == nil || !time.Now().Before(*exp.ExpiredAt) {
+		res.Status = entity.ExportStatusExpired
+		return res
+	}
+
+	link, err := eu.storage.PresignGetURL(exp.FileKey, time.Until(*exp.ExpiredAt))
+	if err != nil {
+		eu.logger.WarnContext(ctx, "presign export failed", "export_id", exp.ID, "err", err)
+		return res
+	}
+	res.DownloadURL = link
+	return res
+}
+
+func writeJSON(zw *zip.Writer, name string, data interface{}) error {
+	f, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("add %s to export archive: %w", name, err)
+	}
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(data); err != nil {
+		return fmt.Errorf("encode %s: %w", name, err)
+	}
+	return nil
+}
+
+// imageKeys lists the storage keys of the images the user uploaded, the
+// default profile pictures are not theirs and are left out
+func imageKeys(profile entity.ProfileRecord, threads []entity.ThreadRecord, attachments []entity.AttachmentRecord) []string {
+	keys := []string{}
+	seen := map[string]bool{}
+	add := func(key string) {
+		if key == "" || seen[key] || strings.Contains(key, "..") {
+			return
+		}
+		seen[key] = true
+		keys = append(keys, key)
+	}
+
+	if profile.ProfileImageUrl != userEntity.DEFAULT_PROFILE {
+		add(cloudstorage.KeyFromURL(profile.ProfileImageUrl, "profile"))
+	}
+	if profile.BackgroundImageUrl != userEntity.DEFAULT_BACKGROUND {
+		add(cloudstorage.KeyFromURL(profile.BackgroundImageUrl, "background"))
+	}
+	for _, thread := range threads {
+		add(cloudstorage.KeyFromURL(thread.ImageURL, "thread"))
+	}
+	for _, attachment := range attachments {
+		add(cloudstorage.KeyFromURL(attachment.FileName, "thread"))
+	}
+
+	return keys
+}
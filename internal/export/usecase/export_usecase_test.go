@@ -0,0 +1,313 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"macaiki/internal/export"
+	"macaiki/internal/export/entity"
+	"macaiki/internal/export/mocks"
+	jobMocks "macaiki/internal/job/mocks"
+	userEntity "macaiki/internal/user/entity"
+	"macaiki/pkg/mailer"
+	"macaiki/pkg/utils"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	mockProfile = entity.ProfileRecord{
+		ID:                 1,
+		Username:           "jane_doe",
+		Email:              "jane.doe@example.com",
+		ProfileImageUrl:    "https://macaiki.s3.ap-southeast-3.amazonaws.com/profile/avatar.png",
+		BackgroundImageUrl: userEntity.DEFAULT_BACKGROUND,
+	}
+
+	mockThreads = []entity.ThreadRecord{
+		{ID: 1, Title: "Hello", Body: "World", ImageURL: "picture.jpg", CommunityID: 2},
+		{ID: 2, Title: "No image"},
+	}
+)
+
+func newTestUsecase(t *testing.T, exportRepo *mocks.ExportRepository, storage *mocks.Storage, queue *jobMocks.Queue) (export.ExportUsecase, *mailer.Capture) {
+	capture := mailer.NewCapture()
+	outbox := mailer.NewSyncOutbox(mailer.MustLoadTemplates(), capture)
+	return NewExportUsecase(exportRepo, storage, outbox, queue, time.Hour, nil), capture
+}
+
+func mockDatasets(exportRepo *mocks.ExportRepository) {
+	exportRepo.On("GetThreads", mock.Anything, uint(1)).Return(mockThreads, nil).Once()
+	exportRepo.On("GetComments", mock.Anything, uint(1)).Return([]entity.CommentRecord{{ID: 1, Body: "Nice", ThreadID: 1}}, nil).Once()
+	exportRepo.On("GetAttachments", mock.Anything, uint(1)).Return([]entity.AttachmentRecord{{ID: 1, ThreadID: 1, FileName: "gallery.png", Caption: "Our cat", Position: 1}}, nil).Once()
+	exportRepo.On("GetVotes", mock.Anything, uint(1)).Return([]entity.VoteRecord{{Type: "thread_upvote", TargetID: 1, Value: 1}, {Type: "comment_downvote", TargetID: 1, Value: -1}}, nil).Once()
+	exportRepo.On("GetSavedThreads", mock.Anything, uint(1)).Return([]entity.SavedThreadRecord{}, nil).Once()
+	exportRepo.On("GetFollowers", mock.Anything, uint(1)).Return([]entity.FollowRecord{{UserID: 2, Username: "john"}}, nil).Once()
+	exportRepo.On("GetFollowing", mock.Anything, uint(1)).Return([]entity.FollowRecord{}, nil).Once()
+	exportRepo.On("GetCommunities", mock.Anything, uint(1)).Return([]entity.CommunityRecord{{CommunityID: 2, Name: "Go", Role: "follower"}}, nil).Once()
+	exportRepo.On("GetReports", mock.Anything, uint(1)).Return([]entity.ReportRecord{}, nil).Once()
+	exportRepo.On("GetNotifications", mock.Anything, uint(1)).Return([]entity.NotificationRecord{}, nil).Once()
+}
+
+func TestRequestExport(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		exportRepo := mocks.NewExportRepository(t)
+		queue := jobMocks.NewQueue(t)
+		uc, _ := newTestUsecase(t, exportRepo, mocks.NewStorage(t), queue)
+
+		exportRepo.On("GetActiveExport", mock.Anything, uint(1), mock.AnythingOfType("time.Time")).Return(entity.Export{}, utils.ErrNotFound).Once()
+		exportRepo.On("StoreExport", mock.Anything, entity.Export{UserID: 1, Status: entity.ExportStatusPending}).Return(entity.Export{ID: 5, UserID: 1, Status: entity.ExportStatusPending}, nil).Once()
+		queue.On("Enqueue", mock.Anything, export.JobBuildExport, export.BuildExportPayload{ExportID: 5}).Return(nil).Once()
+
+		res, err := uc.RequestExport(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, uint(5), res.ID)
+		assert.Equal(t, entity.ExportStatusPending, res.Status)
+		assert.Empty(t, res.DownloadURL)
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		exportRepo := mocks.NewExportRepository(t)
+		uc, _ := newTestUsecase(t, exportRepo, mocks.NewStorage(t), jobMocks.NewQueue(t))
+
+		exportRepo.On("GetActiveExport", mock.Anything, uint(1), mock.AnythingOfType("time.Time")).Return(entity.Export{ID: 4}, nil).Once()
+
+		_, err := uc.RequestExport(context.Background(), 1)
+		assert.ErrorIs(t, err, utils.ErrConflict)
+	})
+
+	t.Run("internal-server-error", func(t *testing.T) {
+		exportRepo := mocks.NewExportRepository(t)
+		uc, _ := newTestUsecase(t, exportRepo, mocks.NewStorage(t), jobMocks.NewQueue(t))
+
+		exportRepo.On("GetActiveExport", mock.Anything, uint(1), mock.AnythingOfType("time.Time")).Return(entity.Export{}, utils.ErrInternalServerError).Once()
+
+		_, err := uc.RequestExport(context.Background(), 1)
+		assert.ErrorIs(t, err, utils.ErrInternalServerError)
+	})
+
+	t.Run("enqueue-failed", func(t *testing.T) {
+		exportRepo := mocks.NewExportRepository(t)
+		queue := jobMocks.NewQueue(t)
+		uc, _ := newTestUsecase(t, exportRepo, mocks.NewStorage(t), queue)
+
+		exportRepo.On("GetActiveExport", mock.Anything, uint(1), mock.AnythingOfType("time.Time")).Return(entity.Export{}, utils.ErrNotFound).Once()
+		exportRepo.On("StoreExport", mock.Anything, mock.Anything).Return(entity.Export{ID: 5, UserID: 1}, nil).Once()
+		queue.On("Enqueue", mock.Anything, export.JobBuildExport, mock.Anything).Return(errors.New("queue down")).Once()
+
+		_, err := uc.RequestExport(context.Background(), 1)
+		assert.ErrorIs(t, err, utils.ErrInternalServerError)
+	})
+}
+
+func TestGetExports(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		exportRepo := mocks.NewExportRepository(t)
+		storage := mocks.NewStorage(t)
+		uc, _ := newTestUsecase(t, exportRepo, storage, jobMocks.NewQueue(t))
+
+		valid := time.Now().Add(time.Hour)
+		expired := time.Now().Add(-time.Hour)
+		exportRepo.On("GetExports", mock.Anything, uint(1)).Return([]entity.Export{
+			{ID: 3, UserID: 1, Status: entity.ExportStatusReady, FileKey: "exports/1/new.zip", ExpiredAt: &valid},
+			{ID: 2, UserID: 1, Status: entity.ExportStatusReady, FileKey: "exports/1/old.zip", ExpiredAt: &expired},
+			{ID: 1, UserID: 1, Status: entity.ExportStatusFailed},
+		}, nil).Once()
+		storage.On("PresignGetURL", "exports/1/new.zip", mock.AnythingOfType("time.Duration")).Return("https://download/new", nil).Once()
+
+		res, err := uc.GetExports(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Len(t, res, 3)
+		assert.Equal(t, "https://download/new", res[0].DownloadURL)
+		assert.Equal(t, entity.ExportStatusExpired, res[1].Status)
+		assert.Empty(t, res[1].DownloadURL)
+		assert.Equal(t, entity.ExportStatusFailed, res[2].Status)
+	})
+
+	t.Run("internal-server-error", func(t *testing.T) {
+		exportRepo := mocks.NewExportRepository(t)
+		uc, _ := newTestUsecase(t, exportRepo, mocks.NewStorage(t), jobMocks.NewQueue(t))
+
+		exportRepo.On("GetExports", mock.Anything, uint(1)).Return([]entity.Export{}, utils.ErrInternalServerError).Once()
+
+		_, err := uc.GetExports(context.Background(), 1)
+		assert.ErrorIs(t, err, utils.ErrInternalServerError)
+	})
+}
+
+func TestBuildExport(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		exportRepo := mocks.NewExportRepository(t)
+		storage := mocks.NewStorage(t)
+		queue := jobMocks.NewQueue(t)
+		uc, capture := newTestUsecase(t, exportRepo, storage, queue)
+
+		exportRepo.On("GetExport", mock.Anything, uint(5)).Return(entity.Export{ID: 5, UserID: 1, Status: entity.ExportStatusPending}, nil).Once()
+		exportRepo.On("UpdateExport", mock.Anything, mock.MatchedBy(func(e entity.Export) bool {
+			return e.ID == 5 && e.Status == entity.ExportStatusRunning
+		})).Return(nil).Once()
+		exportRepo.On("GetProfile", mock.Anything, uint(1)).Return(mockProfile, nil).Once()
+		mockDatasets(exportRepo)
+
+		storage.On("GetObject", mock.Anything, "profile/avatar.png").Return(io.NopCloser(strings.NewReader("avatar")), nil).Once()
+		storage.On("GetObject", mock.Anything, "thread/picture.jpg").Return(nil, errors.New("no such key")).Once()
+		storage.On("GetObject", mock.Anything, "thread/gallery.png").Return(io.NopCloser(strings.NewReader("gallery")), nil).Once()
+
+		var archive []byte
+		storage.On("PutObject", mock.Anything, mock.MatchedBy(func(key string) bool {
+			return strings.HasPrefix(key, "exports/1/") && strings.HasSuffix(key, ".zip")
+		}), mock.Anything, "application/zip").Run(func(args mock.Arguments) {
+			archive, _ = io.ReadAll(args.Get(2).(io.Reader))
+		}).Return(nil).Once()
+		storage.On("PresignGetURL", mock.Anything, time.Hour).Return("https://download/export.zip", nil).Once()
+
+		exportRepo.On("UpdateExport", mock.Anything, mock.MatchedBy(func(e entity.Export) bool {
+			return e.ID == 5 && e.Status == entity.ExportStatusReady && e.FileKey != "" && e.ExpiredAt != nil
+		})).Return(nil).Once()
+		queue.On("EnqueueAt", mock.Anything, export.JobExpireExport, export.ExpireExportPayload{ExportID: 5}, mock.MatchedBy(func(runAt time.Time) bool {
+			return runAt.After(time.Now().Add(59 * time.Minute))
+		})).Return(nil).Once()
+
+		exportRepo.On("GetExports", mock.Anything, uint(1)).Return([]entity.Export{
+			{ID: 5, UserID: 1, Status: entity.ExportStatusReady, FileKey: "exports/1/new.zip"},
+			{ID: 4, UserID: 1, Status: entity.ExportStatusReady, FileKey: "exports/1/old.zip"},
+		}, nil).Once()
+		storage.On("DeleteObject", mock.Anything, "exports/1/old.zip").Return(nil).Once()
+		exportRepo.On("UpdateExport", mock.Anything, entity.Export{ID: 4, UserID: 1, Status: entity.ExportStatusExpired}).Return(nil).Once()
+
+		err := uc.BuildExport(context.Background(), 5)
+		assert.NoError(t, err)
+
+		zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+		assert.NoError(t, err)
+		files := map[string]*zip.File{}
+		for _, f := range zr.File {
+			files[f.Name] = f
+		}
+		for _, name := range []string{"profile.json", "threads.json", "comments.json", "attachments.json", "votes.json", "saved_threads.json", "followers.json", "following.json", "communities.json", "reports.json", "notifications.json", "images/profile/avatar.png", "images/thread/gallery.png"} {
+			assert.Contains(t, files, name)
+		}
+		assert.NotContains(t, files, "images/thread/picture.jpg")
+
+		rc, err := files["profile.json"].Open()
+		assert.NoError(t, err)
+		profile := entity.ProfileRecord{}
+		assert.NoError(t, json.NewDecoder(rc).Decode(&profile))
+		rc.Close()
+		assert.Equal(t, mockProfile.Email, profile.Email)
+
+		msg, ok := capture.Last()
+		assert.True(t, ok)
+		assert.Equal(t, mockProfile.Email, msg.To)
+		assert.Contains(t, msg.Body, "https://download/export.zip")
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		exportRepo := mocks.NewExportRepository(t)
+		uc, _ := newTestUsecase(t, exportRepo, mocks.NewStorage(t), jobMocks.NewQueue(t))
+
+		exportRepo.On("GetExport", mock.Anything, uint(5)).Return(entity.Export{}, utils.ErrNotFound).Once()
+
+		err := uc.BuildExport(context.Background(), 5)
+		assert.NoError(t, err)
+	})
+
+	t.Run("already-ready", func(t *testing.T) {
+		exportRepo := mocks.NewExportRepository(t)
+		uc, _ := newTestUsecase(t, exportRepo, mocks.NewStorage(t), jobMocks.NewQueue(t))
+
+		exportRepo.On("GetExport", mock.Anything, uint(5)).Return(entity.Export{ID: 5, UserID: 1, Status: entity.ExportStatusReady}, nil).Once()
+
+		err := uc.BuildExport(context.Background(), 5)
+		assert.NoError(t, err)
+	})
+
+	t.Run("upload-failed", func(t *testing.T) {
+		exportRepo := mocks.NewExportRepository(t)
+		storage := mocks.NewStorage(t)
+		uc, capture := newTestUsecase(t, exportRepo, storage, jobMocks.NewQueue(t))
+
+		exportRepo.On("GetExport", mock.Anything, uint(5)).Return(entity.Export{ID: 5, UserID: 1, Status: entity.ExportStatusPending}, nil).Once()
+		exportRepo.On("UpdateExport", mock.Anything, mock.MatchedBy(func(e entity.Export) bool {
+			return e.Status == entity.ExportStatusRunning
+		})).Return(nil).Once()
+		exportRepo.On("GetProfile", mock.Anything, uint(1)).Return(mockProfile, nil).Once()
+		mockDatasets(exportRepo)
+		storage.On("GetObject", mock.Anything, mock.Anything).Return(io.NopCloser(strings.NewReader("img")), nil).Times(3)
+		storage.On("PutObject", mock.Anything, mock.Anything, mock.Anything, "application/zip").Return(errors.New("bucket unavailable")).Once()
+		exportRepo.On("UpdateExport", mock.Anything, mock.MatchedBy(func(e entity.Export) bool {
+			return e.Status == entity.ExportStatusFailed
+		})).Return(nil).Once()
+
+		err := uc.BuildExport(context.Background(), 5)
+		assert.Error(t, err)
+		assert.Empty(t, capture.Messages())
+	})
+}
+
+func TestExpireExport(t *testing.T) {
+	expiredAt := time.Now().Add(-time.Minute)
+
+	t.Run("success", func(t *testing.T) {
+		exportRepo := mocks.NewExportRepository(t)
+		storage := mocks.NewStorage(t)
+		uc, _ := newTestUsecase(t, exportRepo, storage, jobMocks.NewQueue(t))
+
+		exportRepo.On("GetExport", mock.Anything, uint(5)).Return(entity.Export{ID: 5, UserID: 1, Status: entity.ExportStatusReady, FileKey: "exports/1/new.zip", ExpiredAt: &expiredAt}, nil).Once()
+		storage.On("DeleteObject", mock.Anything, "exports/1/new.zip").Return(nil).Once()
+		exportRepo.On("UpdateExport", mock.Anything, entity.Export{ID: 5, UserID: 1, Status: entity.ExportStatusExpired, ExpiredAt: &expiredAt}).Return(nil).Once()
+
+		err := uc.ExpireExport(context.Background(), 5)
+		assert.NoError(t, err)
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		exportRepo := mocks.NewExportRepository(t)
+		uc, _ := newTestUsecase(t, exportRepo, mocks.NewStorage(t), jobMocks.NewQueue(t))
+
+		exportRepo.On("GetExport", mock.Anything, uint(5)).Return(entity.Export{}, utils.ErrNotFound).Once()
+
+		err := uc.ExpireExport(context.Background(), 5)
+		assert.NoError(t, err)
+	})
+
+	t.Run("already-expired", func(t *testing.T) {
+		exportRepo := mocks.NewExportRepository(t)
+		uc, _ := newTestUsecase(t, exportRepo, mocks.NewStorage(t), jobMocks.NewQueue(t))
+
+		exportRepo.On("GetExport", mock.Anything, uint(5)).Return(entity.Export{ID: 5, UserID: 1, Status: entity.ExportStatusExpired, ExpiredAt: &expiredAt}, nil).Once()
+
+		err := uc.ExpireExport(context.Background(), 5)
+		assert.NoError(t, err)
+	})
+
+	t.Run("link-still-valid", func(t *testing.T) {
+		exportRepo := mocks.NewExportRepository(t)
+		uc, _ := newTestUsecase(t, exportRepo, mocks.NewStorage(t), jobMocks.NewQueue(t))
+
+		later := time.Now().Add(time.Hour)
+		exportRepo.On("GetExport", mock.Anything, uint(5)).Return(entity.Export{ID: 5, UserID: 1, Status: entity.ExportStatusReady, FileKey: "exports/1/new.zip", ExpiredAt: &later}, nil).Once()
+
+		err := uc.ExpireExport(context.Background(), 5)
+		assert.NoError(t, err)
+	})
+
+	t.Run("delete-failed", func(t *testing.T) {
+		exportRepo := mocks.NewExportRepository(t)
+		storage := mocks.NewStorage(t)
+		uc, _ := newTestUsecase(t, exportRepo, storage, jobMocks.NewQueue(t))
+
+		exportRepo.On("GetExport", mock.Anything, uint(5)).Return(entity.Export{ID: 5, UserID: 1, Status: entity.ExportStatusReady, FileKey: "exports/1/new.zip", ExpiredAt: &expiredAt}, nil).Once()
+		storage.On("DeleteObject", mock.Anything, "exports/1/new.zip").Return(errors.New("bucket unavailable")).Once()
+
+		err := uc.ExpireExport(context.Background(), 5)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,58 @@
+package http
+
+import (
+	"macaiki/internal/feed"
+	"macaiki/internal/feed/dto"
+	_middL "macaiki/pkg/middleware"
+	"macaiki/pkg/response"
+	"macaiki/pkg/utils"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+type FeedHandler struct {
+	router *echo.Echo
+	fu     feed.FeedUsecase
+}
+
+func (fh *FeedHandler) GetHomeFeed(c echo.Context) error {
+	userID, _ := _middL.ExtractTokenUser(c)
+
+	limit := 0
+	if l := c.QueryParam("limit"); l != "" {
+		var err error
+		limit, err = strconv.Atoi(l)
+		if err != nil || limit < 1 {
+			return response.ErrorResponse(c, utils.ErrBadParamInput)
+		}
+	}
+
+	res, err := fh.fu.GetHomeFeed(c.Request().Context(), uint(userID), c.QueryParam("cursor"), limit)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+	return response.SuccessResponse(c, res)
+}
+
+func (fh *FeedHandler) MarkSeen(c echo.Context) error {
+	userID, _ := _middL.ExtractTokenUser(c)
+
+	req := dto.FeedSeenRequest{}
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, utils.ErrBadParamInput)
+	}
+
+	if err := fh.fu.MarkSeen(c.Request().Context(), uint(userID), req); err != nil {
+		return response.ErrorResponse(c, err)
+	}
+	return response.SuccessResponse(c, nil)
+}
+
+func NewFeedHandler(e *echo.Echo, fu feed.FeedUsecase, JWTSecret string) *FeedHandler {
+	feedHandler := &FeedHandler{router: e, fu: fu}
+	feedHandler.router.GET("/api/v1/feed", feedHandler.GetHomeFeed, middleware.JWT([]byte(JWTSecret)))
+	feedHandler.router.POST("/api/v1/feed/seen", feedHandler.MarkSeen, middleware.JWT([]byte(JWTSecret)))
+	return feedHandler
+}
@@ -0,0 +1,20 @@
+package dto
+
+import threadDto "macaiki/internal/thread/dto"
+
+type FeedThreadResponse struct {
+	threadDto.DetailedThreadResponse
+	// Reason is why the thread is in the feed, one of followed_user,
+	// followed_community or trending
+	Reason string `json:"reason"`
+}
+
+type FeedResponse struct {
+	Threads []FeedThreadResponse `json:"threads"`
+	// NextCursor fetches the following page, it is empty on the last page
+	NextCursor string `json:"nextCursor"`
+}
+
+type FeedSeenRequest struct {
+	ThreadIDs []uint `json:"threadIDs" validate:"required,min=1,max=100"`
+}
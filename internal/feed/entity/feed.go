@@ -0,0 +1,30 @@
+package entity
+
+import (
+	threadEntity "macaiki/internal/thread/entity"
+	"time"
+)
+
+// Sources a home feed thread can come from, in the order a thread found
+// in several of them is attributed
+const (
+	SourceFollowedUser      = "followed_user"
+	SourceFollowedCommunity = "followed_community"
+	SourceTrending          = "trending"
+)
+
+// SeenThread records that a user has already been shown a thread, seen
+// threads are left out of their home feed
+type SeenThread struct {
+	UserID   uint `gorm:"primaryKey;autoIncrement:false"`
+	ThreadID uint `gorm:"primaryKey;autoIncrement:false"`
+	SeenAt   time.Time
+}
+
+// FeedThread is a home feed candidate together with the source it was
+// picked from and its rank within the feed
+type FeedThread struct {
+	threadEntity.ThreadWithDetails
+	Source string
+	Rank   float64
+}
@@ -0,0 +1,113 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	entity "macaiki/internal/thread/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// FeedRepository is an autogenerated mock type for the FeedRepository type
+type FeedRepository struct {
+	mock.Mock
+}
+
+// GetFollowedCommunityThreads provides a mock function with given fields: ctx, userID, limit
+func (_m *FeedRepository) GetFollowedCommunityThreads(ctx context.Context, userID uint, limit int) ([]entity.ThreadWithDetails, error) {
+	ret := _m.Called(ctx, userID, limit)
+
+	var r0 []entity.ThreadWithDetails
+	if rf, ok := ret.Get(0).(func(context.Context, uint, int) []entity.ThreadWithDetails); ok {
+		r0 = rf(ctx, userID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ThreadWithDetails)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, int) error); ok {
+		r1 = rf(ctx, userID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFollowedUserThreads provides a mock function with given fields: ctx, userID, limit
+func (_m *FeedRepository) GetFollowedUserThreads(ctx context.Context, userID uint, limit int) ([]entity.ThreadWithDetails, error) {
+	ret := _m.Called(ctx, userID, limit)
+
+	var r0 []entity.ThreadWithDetails
+	if rf, ok := ret.Get(0).(func(context.Context, uint, int) []entity.ThreadWithDetails); ok {
+		r0 = rf(ctx, userID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ThreadWithDetails)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, int) error); ok {
+		r1 = rf(ctx, userID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTrendingThreads provides a mock function with given fields: ctx, userID, limit
+func (_m *FeedRepository) GetTrendingThreads(ctx context.Context, userID uint, limit int) ([]entity.ThreadWithDetails, error) {
+	ret := _m.Called(ctx, userID, limit)
+
+	var r0 []entity.ThreadWithDetails
+	if rf, ok := ret.Get(0).(func(context.Context, uint, int) []entity.ThreadWithDetails); ok {
+		r0 = rf(ctx, userID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ThreadWithDetails)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, int) error); ok {
+		r1 = rf(ctx, userID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkThreadsSeen provides a mock function with given fields: ctx, userID, threadIDs
+func (_m *FeedRepository) MarkThreadsSeen(ctx context.Context, userID uint, threadIDs []uint) error {
+	ret := _m.Called(ctx, userID, threadIDs)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, []uint) error); ok {
+		r0 = rf(ctx, userID, threadIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewFeedRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewFeedRepository creates a new instance of FeedRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewFeedRepository(t mockConstructorTestingTNewFeedRepository) *FeedRepository {
+	mock := &FeedRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
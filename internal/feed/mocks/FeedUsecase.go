@@ -0,0 +1,65 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	dto "macaiki/internal/feed/dto"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// FeedUsecase is an autogenerated mock type for the FeedUsecase type
+type FeedUsecase struct {
+	mock.Mock
+}
+
+// GetHomeFeed provides a mock function with given fields: ctx, userID, cursor, limit
+func (_m *FeedUsecase) GetHomeFeed(ctx context.Context, userID uint, cursor string, limit int) (dto.FeedResponse, error) {
+	ret := _m.Called(ctx, userID, cursor, limit)
+
+	var r0 dto.FeedResponse
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, int) dto.FeedResponse); ok {
+		r0 = rf(ctx, userID, cursor, limit)
+	} else {
+		r0 = ret.Get(0).(dto.FeedResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, string, int) error); ok {
+		r1 = rf(ctx, userID, cursor, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkSeen provides a mock function with given fields: ctx, userID, req
+func (_m *FeedUsecase) MarkSeen(ctx context.Context, userID uint, req dto.FeedSeenRequest) error {
+	ret := _m.Called(ctx, userID, req)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, dto.FeedSeenRequest) error); ok {
+		r0 = rf(ctx, userID, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewFeedUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewFeedUsecase creates a new instance of FeedUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewFeedUsecase(t mockConstructorTestingTNewFeedUsecase) *FeedUsecase {
+	mock := &FeedUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
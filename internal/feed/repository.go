@@ -0,0 +1,15 @@
+package feed
+
+import (
+	"context"
+	threadEntity "macaiki/internal/thread/entity"
+)
+
+// FeedRepository supplies the candidates of a home feed. Every source
+// leaves out deleted threads and threads userID has seen or hidden.
+type FeedRepository interface {
+	GetFollowedUserThreads(ctx context.Context, userID uint, limit int) ([]threadEntity.ThreadWithDetails, error)
+	GetFollowedCommunityThreads(ctx context.Context, userID uint, limit int) ([]threadEntity.ThreadWithDetails, error)
+	GetTrendingThreads(ctx context.Context, userID uint, limit int) ([]threadEntity.ThreadWithDetails, error)
+	MarkThreadsSeen(ctx context.Context, userID uint, threadIDs []uint) error
+}
@@ -0,0 +1,137 @@
+package memory
+
+import (
+	"context"
+	"macaiki/internal/feed"
+	threadEntity "macaiki/internal/thread/entity"
+	"sort"
+	"sync"
+)
+
+// FeedRepository keeps the home feed data in memory, it backs tests of
+// code built on feed.FeedRepository. Fill the exported fields directly.
+type FeedRepository struct {
+	mu sync.Mutex
+	// Threads holds every thread, deleted ones and drafts included
+	Threads []threadEntity.ThreadWithDetails
+	// HotScores marks the trending threads with their hot score
+	HotScores map[uint]float64
+	// FollowedUsers and FollowedCommunities map a user to the IDs they follow
+	FollowedUsers       map[uint][]uint
+	FollowedCommunities map[uint][]uint
+	// Hidden and Seen map a user to the thread IDs they hid or have seen
+	Hidden map[uint][]uint
+	Seen   map[uint][]uint
+	// MutedCommunities maps a user to the community IDs they muted
+	MutedCommunities map[uint][]uint
+}
+
+func NewFeedRepository() *FeedRepository {
+	return &FeedRepository{
+		HotScores:           map[uint]float64{},
+		FollowedUsers:       map[uint][]uint{},
+		FollowedCommunities: map[uint][]uint{},
+		Hidden:              map[uint][]uint{},
+		Seen:                map[uint][]uint{},
+		MutedCommunities:    map[uint][]uint{},
+	}
+}
+
+var _ feed.FeedRepository = (*FeedRepository)(nil)
+
+func (fr *FeedRepository) GetFollowedUserThreads(ctx context.Context, userID uint, limit int) ([]threadEntity.ThreadWithDetails, error) {
+	fr.mu.Lock()
+	defer fr.mu.Unlock()
+
+	threads := fr.visible(userID, func(t threadEntity.ThreadWithDetails) bool {
+		return contains(fr.FollowedUsers[userID], t.Thread.UserID)
+	})
+	sortNewest(threads)
+	return head(threads, limit), nil
+}
+
+func (fr *FeedRepository) GetFollowedCommunityThreads(ctx context.Context, userID uint, limit int) ([]threadEntity.ThreadWithDetails, error) {
+	fr.mu.Lock()
+	defer fr.mu.Unlock()
+
+	threads := fr.visible(userID, func(t threadEntity.ThreadWithDetails) bool {
+		return contains(fr.FollowedCommunities[userID], t.CommunityID)
+	})
+	sortNewest(threads)
+	return head(threads, limit), nil
+}
+
+func (fr *FeedRepository) GetTrendingThreads(ctx context.Context, userID uint, limit int) ([]threadEntity.ThreadWithDetails, error) {
+	fr.mu.Lock()
+	defer fr.mu.Unlock()
+
+	threads := fr.visible(userID, func(t threadEntity.ThreadWithDetails) bool {
+		_, ok := fr.HotScores[t.Thread.ID]
+		return ok
+	})
+	sort.SliceStable(threads, func(i, j int) bool {
+		hi, hj := fr.HotScores[threads[i].Thread.ID], fr.HotScores[threads[j].Thread.ID]
+		if hi != hj {
+			return hi > hj
+		}
+		return threads[i].Thread.ID > threads[j].Thread.ID
+	})
+	return head(threads, limit), nil
+}
+
+func (fr *FeedRepository) MarkThreadsSeen(ctx context.Context, userID uint, threadIDs []uint) error {
+	fr.mu.Lock()
+	defer fr.mu.Unlock()
+
+	for _, threadID := range threadIDs {
+		if !contains(fr.Seen[userID], threadID) {
+			fr.Seen[userID] = append(fr.Seen[userID], threadID)
+		}
+	}
+	return nil
+}
+
+// visible returns the published threads matching source that are not
+// deleted, seen, hidden or in a muted community for userID, with the user's vote and follow state filled in
+func (fr *FeedRepository) visible(userID uint, source func(threadEntity.ThreadWithDetails) bool) []threadEntity.ThreadWithDetails {
+	threads := []threadEntity.ThreadWithDetails{}
+	for _, t := range fr.Threads {
+		if t.Thread.DeletedAt.Valid || !t.Thread.IsPublished() || !source(t) {
+			continue
+		}
+		if contains(fr.Seen[userID], t.Thread.ID) || contains(fr.Hidden[userID], t.Thread.ID) || contains(fr.MutedCommunities[userID], t.CommunityID) {
+			continue
+		}
+		if contains(fr.FollowedUsers[userID], t.Thread.UserID) {
+			t.IsFollowed = 1
+		}
+		threads = append(threads, t)
+	}
+	return threads
+}
+
+func sortNewest(threads []threadEntity.ThreadWithDetails) {
+	sort.SliceStable(threads, func(i, j int) bool {
+		ti, tj := threads[i].Thread, threads[j].Thread
+		if !ti.CreatedAt.Equal(tj.CreatedAt) {
+			return ti.CreatedAt.After(tj.CreatedAt)
+		}
+		return ti.ID > tj.ID
+	})
+}
+
+func head(threads []threadEntity.ThreadWithDetails, limit int) []threadEntity.ThreadWithDetails {
+	if len(threads) > limit {
+		return threads[:limit]
+	}
+	return threads
+}
+
+func contains(ids []uint, id uint) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
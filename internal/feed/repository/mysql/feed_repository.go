@@ -0,0 +1,67 @@
+package mysql
+
+import (
+	"context"
+	"log/slog"
+	"macaiki/internal/feed"
+	"macaiki/internal/feed/entity"
+	threadEntity "macaiki/internal/thread/entity"
+	"macaiki/pkg/logger"
+	"macaiki/pkg/utils"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type FeedRepositoryImpl struct {
+	db     *gorm.DB
+	logger *slog.Logger
+}
+
+func NewFeedRepository(db *gorm.DB, log *slog.Logger) feed.FeedRepository {
+	return &FeedRepositoryImpl{db: db, logger: logger.OrDefault(log)}
+}
+
+// candidates runs the shared home feed query, join narrows it down to one
+// source and order picks the newest or best threads of that source
+func (fr *FeedRepositoryImpl) candidates(ctx context.Context, op, join string, joinArgs []interface{}, order string, userID uint, limit int) ([]threadEntity.ThreadWithDetails, error) {
+	threads := []threadEntity.ThreadWithDetails{}
+	args := append(joinArgs, userID, userID, userID, userID, userID, limit)
+
+	res := fr.db.WithContext(ctx).Raw("SELECT t.*, COALESCE(tv.value, 0) AS user_vote, NOT ISNULL(fu.user_id) AS is_followed, users.name, users.profile_image_url, users.profession FROM threads t "+join+" LEFT JOIN thread_votes tv ON tv.thread_id = t.id AND tv.user_id = ? LEFT JOIN user_followers fu ON fu.user_id = t.user_id AND fu.follower_id = ? LEFT JOIN users ON users.id = t.user_id WHERE t.deleted_at IS NULL AND t.status = 'published' AND NOT EXISTS (SELECT 1 FROM seen_threads st WHERE st.thread_id = t.id AND st.user_id = ?) AND NOT EXISTS (SELECT 1 FROM hidden_threads ht WHERE ht.thread_id = t.id AND ht.user_id = ?) AND NOT EXISTS (SELECT 1 FROM muted_communities mc WHERE mc.community_id = t.community_id AND mc.user_id = ?) ORDER BY "+order+" LIMIT ?", args...).Scan(&threads)
+	if res.Error != nil {
+		fr.logger.ErrorContext(ctx, "query failed", "op", op, "err", res.Error)
+		return []threadEntity.ThreadWithDetails{}, utils.ErrInternalServerError
+	}
+
+	return threads, nil
+}
+
+func (fr *FeedRepositoryImpl) GetFollowedUserThreads(ctx context.Context, userID uint, limit int) ([]threadEntity.ThreadWithDetails, error) {
+	return fr.candidates(ctx, "GetFollowedUserThreads", "INNER JOIN user_followers uf ON uf.user_id = t.user_id AND uf.follower_id = ?", []interface{}{userID}, "t.created_at DESC, t.id DESC", userID, limit)
+}
+
+func (fr *FeedRepositoryImpl) GetFollowedCommunityThreads(ctx context.Context, userID uint, limit int) ([]threadEntity.ThreadWithDetails, error) {
+	return fr.candidates(ctx, "GetFollowedCommunityThreads", "INNER JOIN community_followers cf ON cf.community_id = t.community_id AND cf.user_id = ?", []interface{}{userID}, "t.created_at DESC, t.id DESC", userID, limit)
+}
+
+func (fr *FeedRepositoryImpl) GetTrendingThreads(ctx context.Context, userID uint, limit int) ([]threadEntity.ThreadWithDetails, error) {
+	return fr.candidates(ctx, "GetTrendingThreads", "INNER JOIN thread_scores ts ON ts.thread_id = t.id", nil, "ts.hot_score DESC, t.id DESC", userID, limit)
+}
+
+func (fr *FeedRepositoryImpl) MarkThreadsSeen(ctx context.Context, userID uint, threadIDs []uint) error {
+	now := time.Now()
+	seen := make([]entity.SeenThread, 0, len(threadIDs))
+	for _, threadID := range threadIDs {
+		seen = append(seen, entity.SeenThread{UserID: userID, ThreadID: threadID, SeenAt: now})
+	}
+
+	res := fr.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&seen)
+	if res.Error != nil {
+		fr.logger.ErrorContext(ctx, "query failed", "op", "MarkThreadsSeen", "err", res.Error)
+		return utils.ErrInternalServerError
+	}
+
+	return nil
+}
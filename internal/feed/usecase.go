@@ -0,0 +1,11 @@
+package feed
+
+import (
+	"context"
+	"macaiki/internal/feed/dto"
+)
+
+type FeedUsecase interface {
+	GetHomeFeed(ctx context.Context, userID uint, cursor string, limit int) (dto.FeedResponse, error)
+	MarkSeen(ctx context.Context, userID uint, req dto.FeedSeenRequest) error
+}
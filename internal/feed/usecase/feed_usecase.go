@@ -0,0 +1,209 @@
+package usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"log/slog"
+	"macaiki/internal/feed"
+	"macaiki/internal/feed/dto"
+	"macaiki/internal/feed/entity"
+	"macaiki/internal/thread"
+	threadHelper "macaiki/internal/thread/delivery/http/helper"
+	threadEntity "macaiki/internal/thread/entity"
+	"macaiki/pkg/logger"
+	"macaiki/pkg/utils"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	FEED_DEFAULT_LIMIT = 20
+	FEED_MAX_LIMIT     = 50
+	// FEED_CANDIDATES is how many threads each source contributes before
+	// they are merged. A reader only pages through these, so a feed ends
+	// after at most three times as many threads; marking threads seen makes
+	// room for older ones on the next fresh load.
+	FEED_CANDIDATES = 200
+	// FEED_MAX_SHOWN bounds the threads a cursor remembers, and with it the
+	// cursor size, the feed ends once it is reached
+	FEED_MAX_SHOWN = 3 * FEED_CANDIDATES
+
+	// feedCursorVersion leads every encoded cursor
+	feedCursorVersion = 1
+)
+
+type FeedUsecaseImpl struct {
+	repo      feed.FeedRepository
+	validator *validator.Validate
+	ranking   thread.RankingConfig
+	logger    *slog.Logger
+	now       func() time.Time
+}
+
+func NewFeedUsecase(repo feed.FeedRepository, validator *validator.Validate, ranking thread.RankingConfig, log *slog.Logger) feed.FeedUsecase {
+	return &FeedUsecaseImpl{
+		repo:      repo,
+		validator: validator,
+		ranking:   ranking,
+		logger:    logger.OrDefault(log),
+		now:       time.Now,
+	}
+}
+
+// feedCursor is where a reader is in their feed. Ranks are computed as of
+// the time of the first page, and every following page is ranked from the
+// threads not shown yet, so a thread whose votes change between pages is
+// neither repeated nor skipped.
+type feedCursor struct {
+	AsOf  int64
+	Shown map[uint]bool
+}
+
+// encodeCursor packs the shown IDs in ascending order as varint deltas
+func encodeCursor(c feedCursor) string {
+	ids := make([]uint, 0, len(c.Shown))
+	for id := range c.Shown {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	b := []byte{feedCursorVersion}
+	b = binary.AppendUvarint(b, uint64(c.AsOf))
+	prev := uint(0)
+	for _, id := range ids {
+		b = binary.AppendUvarint(b, uint64(id-prev))
+		prev = id
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeCursor(s string) (feedCursor, error) {
+	c := feedCursor{Shown: map[uint]bool{}}
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(b) == 0 || b[0] != feedCursorVersion {
+		return c, utils.ErrBadParamInput
+	}
+
+	asOf, n := binary.Uvarint(b[1:])
+	if n <= 0 || asOf == 0 || asOf > math.MaxInt64 {
+		return c, utils.ErrBadParamInput
+	}
+	c.AsOf = int64(asOf)
+
+	prev := uint64(0)
+	for b = b[1+n:]; len(b) > 0; b = b[n:] {
+		var delta uint64
+		delta, n = binary.Uvarint(b)
+		if n <= 0 || len(c.Shown) >= FEED_MAX_SHOWN {
+			return c, utils.ErrBadParamInput
+		}
+		prev += delta
+		c.Shown[uint(prev)] = true
+	}
+	return c, nil
+}
+
+func (fu *FeedUsecaseImpl) GetHomeFeed(ctx context.Context, userID uint, cursor string, limit int) (dto.FeedResponse, error) {
+	if limit <= 0 {
+		limit = FEED_DEFAULT_LIMIT
+	}
+	if limit > FEED_MAX_LIMIT {
+		limit = FEED_MAX_LIMIT
+	}
+
+	pos := feedCursor{AsOf: fu.now().Truncate(time.Second).Unix(), Shown: map[uint]bool{}}
+	if cursor != "" {
+		c, err := decodeCursor(cursor)
+		if err != nil {
+			return dto.FeedResponse{}, err
+		}
+		pos = c
+	}
+	asOf := time.Unix(pos.AsOf, 0)
+
+	candidates, err := fu.candidates(ctx, userID)
+	if err != nil {
+		return dto.FeedResponse{}, err
+	}
+
+	threads := []entity.FeedThread{}
+	for _, val := range candidates {
+		// threads posted after the first page would shift the ranking
+		// under the cursor, they show up on the next fresh load
+		if val.Thread.CreatedAt.After(asOf) || pos.Shown[val.Thread.ID] {
+			continue
+		}
+		val.Rank = fu.ranking.Hot(fu.ranking.VotePoints(val.UpvotesCount, val.DownvotesCount), val.Thread.CreatedAt, asOf)
+		threads = append(threads, val)
+	}
+
+	sort.Slice(threads, func(i, j int) bool {
+		if threads[i].Rank != threads[j].Rank {
+			return threads[i].Rank > threads[j].Rank
+		}
+		return threads[i].Thread.ID > threads[j].Thread.ID
+	})
+
+	res := dto.FeedResponse{Threads: []dto.FeedThreadResponse{}}
+	if len(threads) > limit {
+		threads = threads[:limit]
+		for _, val := range threads {
+			pos.Shown[val.Thread.ID] = true
+		}
+		if len(pos.Shown) < FEED_MAX_SHOWN {
+			res.NextCursor = encodeCursor(pos)
+		}
+	}
+	for _, val := range threads {
+		res.Threads = append(res.Threads, dto.FeedThreadResponse{
+			DetailedThreadResponse: threadHelper.DomainThreadToDetailedThreadResponse(val.ThreadWithDetails),
+			Reason:                 val.Source,
+		})
+	}
+	return res, nil
+}
+
+// candidates merges the sources of the feed, a thread found by more than one
+// source is kept once under the first of them
+func (fu *FeedUsecaseImpl) candidates(ctx context.Context, userID uint) ([]entity.FeedThread, error) {
+	sources := []struct {
+		name  string
+		fetch func(ctx context.Context, userID uint, limit int) ([]threadEntity.ThreadWithDetails, error)
+	}{
+		{entity.SourceFollowedUser, fu.repo.GetFollowedUserThreads},
+		{entity.SourceFollowedCommunity, fu.repo.GetFollowedCommunityThreads},
+		{entity.SourceTrending, fu.repo.GetTrendingThreads},
+	}
+
+	seen := map[uint]bool{}
+	threads := []entity.FeedThread{}
+	for _, source := range sources {
+		res, err := source.fetch(ctx, userID, FEED_CANDIDATES)
+		if err != nil {
+			return nil, utils.ErrInternalServerError
+		}
+		for _, val := range res {
+			if seen[val.Thread.ID] {
+				continue
+			}
+			seen[val.Thread.ID] = true
+			threads = append(threads, entity.FeedThread{ThreadWithDetails: val, Source: source.name})
+		}
+	}
+	return threads, nil
+}
+
+func (fu *FeedUsecaseImpl) MarkSeen(ctx context.Context, userID uint, req dto.FeedSeenRequest) error {
+	if err := fu.validator.Struct(req); err != nil {
+		return utils.ErrBadParamInput
+	}
+
+	if err := fu.repo.MarkThreadsSeen(ctx, userID, req.ThreadIDs); err != nil {
+		return utils.ErrInternalServerError
+	}
+	return nil
+}
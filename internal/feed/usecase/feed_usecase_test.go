@@ -0,0 +1,193 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"macaiki/internal/feed/dto"
+	"macaiki/internal/feed/entity"
+	"macaiki/internal/feed/mocks"
+	"macaiki/internal/feed/repository/memory"
+	"macaiki/internal/thread"
+	threadEntity "macaiki/internal/thread/entity"
+	"macaiki/pkg/utils"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+var feedNow = time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func newFeedThread(id, userID, communityID uint, age time.Duration, upvotes int) threadEntity.ThreadWithDetails {
+	t := threadEntity.ThreadWithDetails{}
+	t.Thread.ID = id
+	t.Thread.UserID = userID
+	t.Thread.CreatedAt = feedNow.Add(-age)
+	t.CommunityID = communityID
+	t.UpvotesCount = upvotes
+	return t
+}
+
+func newTestUsecase(repo *memory.FeedRepository) *FeedUsecaseImpl {
+	fu := NewFeedUsecase(repo, validator.New(), thread.DefaultRankingConfig(), nil).(*FeedUsecaseImpl)
+	fu.now = func() time.Time { return feedNow }
+	return fu
+}
+
+func feedIDs(res dto.FeedResponse) []uint {
+	ids := []uint{}
+	for _, val := range res.Threads {
+		ids = append(ids, val.ID)
+	}
+	return ids
+}
+
+func TestGetHomeFeed(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		repo := memory.NewFeedRepository()
+		repo.Threads = []threadEntity.ThreadWithDetails{
+			newFeedThread(1, 2, 10, time.Hour, 5),
+			newFeedThread(2, 3, 10, 2*time.Hour, 50),
+			newFeedThread(3, 4, 20, 3*time.Hour, 0),
+			newFeedThread(4, 5, 30, time.Hour, 1),
+		}
+		repo.FollowedUsers[1] = []uint{2}
+		repo.FollowedCommunities[1] = []uint{10}
+		repo.HotScores = map[uint]float64{1: 3, 2: 2, 4: 1}
+
+		res, err := newTestUsecase(repo).GetHomeFeed(context.Background(), 1, "", 0)
+		assert.NoError(t, err)
+		assert.Equal(t, []uint{2, 1, 4}, feedIDs(res))
+		assert.Equal(t, entity.SourceFollowedCommunity, res.Threads[0].Reason)
+		assert.Equal(t, entity.SourceFollowedUser, res.Threads[1].Reason)
+		assert.Equal(t, entity.SourceTrending, res.Threads[2].Reason)
+		assert.Empty(t, res.NextCursor)
+	})
+
+	t.Run("excludes-seen-hidden-muted-and-deleted", func(t *testing.T) {
+		repo := memory.NewFeedRepository()
+		repo.Threads = []threadEntity.ThreadWithDetails{
+			newFeedThread(1, 2, 10, time.Hour, 5),
+			newFeedThread(2, 2, 10, time.Hour, 5),
+			newFeedThread(3, 2, 10, time.Hour, 5),
+			newFeedThread(4, 2, 10, time.Hour, 5),
+			newFeedThread(5, 2, 20, time.Hour, 5),
+		}
+		repo.Threads[3].Thread.DeletedAt = gorm.DeletedAt{Time: feedNow, Valid: true}
+		repo.FollowedUsers[1] = []uint{2}
+		repo.Seen[1] = []uint{1}
+		repo.Hidden[1] = []uint{2}
+		repo.MutedCommunities[1] = []uint{20}
+
+		res, err := newTestUsecase(repo).GetHomeFeed(context.Background(), 1, "", 0)
+		assert.NoError(t, err)
+		assert.Equal(t, []uint{3}, feedIDs(res))
+	})
+
+	t.Run("paginates-with-cursor", func(t *testing.T) {
+		repo := memory.NewFeedRepository()
+		for i := uint(1); i <= 7; i++ {
+			// threads 3 to 5 tie on rank and are ordered by ID
+			upvotes := int(i)
+			if i >= 3 && i <= 5 {
+				upvotes = 4
+			}
+			repo.Threads = append(repo.Threads, newFeedThread(i, 2, 10, time.Hour, upvotes))
+		}
+		repo.FollowedUsers[1] = []uint{2}
+		fu := newTestUsecase(repo)
+
+		first, err := fu.GetHomeFeed(context.Background(), 1, "", 3)
+		assert.NoError(t, err)
+		assert.Equal(t, []uint{7, 6, 5}, feedIDs(first))
+		assert.NotEmpty(t, first.NextCursor)
+
+		// a thread posted and an hour passing between pages leave the
+		// following pages unchanged
+		repo.Threads = append(repo.Threads, newFeedThread(8, 2, 10, -time.Minute, 100))
+		fu.now = func() time.Time { return feedNow.Add(time.Hour) }
+
+		second, err := fu.GetHomeFeed(context.Background(), 1, first.NextCursor, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, []uint{4, 3, 2}, feedIDs(second))
+
+		third, err := fu.GetHomeFeed(context.Background(), 1, second.NextCursor, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, []uint{1}, feedIDs(third))
+		assert.Empty(t, third.NextCursor)
+	})
+
+	t.Run("votes-moving-between-pages", func(t *testing.T) {
+		repo := memory.NewFeedRepository()
+		for i := uint(1); i <= 6; i++ {
+			repo.Threads = append(repo.Threads, newFeedThread(i, 2, 10, time.Hour, int(i)))
+		}
+		repo.FollowedUsers[1] = []uint{2}
+		fu := newTestUsecase(repo)
+
+		first, err := fu.GetHomeFeed(context.Background(), 1, "", 3)
+		assert.NoError(t, err)
+		assert.Equal(t, []uint{6, 5, 4}, feedIDs(first))
+
+		// a shown thread drops below the rest and an unseen one climbs to
+		// the top, neither is repeated nor skipped
+		repo.Threads[5].UpvotesCount = 0
+		repo.Threads[0].UpvotesCount = 100
+
+		second, err := fu.GetHomeFeed(context.Background(), 1, first.NextCursor, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, []uint{1, 3, 2}, feedIDs(second))
+		assert.Empty(t, second.NextCursor)
+	})
+
+	t.Run("bad-param-input", func(t *testing.T) {
+		fu := newTestUsecase(memory.NewFeedRepository())
+
+		for _, cursor := range []string{"not a cursor!", "bm90IGpzb24", "e30", "AQ", "Ag"} {
+			_, err := fu.GetHomeFeed(context.Background(), 1, cursor, 0)
+			assert.ErrorIs(t, err, utils.ErrBadParamInput, cursor)
+		}
+	})
+
+	t.Run("internal-server-error", func(t *testing.T) {
+		repo := mocks.NewFeedRepository(t)
+		repo.On("GetFollowedUserThreads", mock.Anything, uint(1), FEED_CANDIDATES).Return(nil, errors.New("unexpected error")).Once()
+
+		_, err := NewFeedUsecase(repo, validator.New(), thread.DefaultRankingConfig(), nil).GetHomeFeed(context.Background(), 1, "", 0)
+		assert.ErrorIs(t, err, utils.ErrInternalServerError)
+	})
+}
+
+func TestMarkSeen(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		repo := memory.NewFeedRepository()
+		repo.Threads = []threadEntity.ThreadWithDetails{newFeedThread(1, 2, 10, time.Hour, 5)}
+		repo.FollowedUsers[1] = []uint{2}
+		fu := newTestUsecase(repo)
+
+		err := fu.MarkSeen(context.Background(), 1, dto.FeedSeenRequest{ThreadIDs: []uint{1}})
+		assert.NoError(t, err)
+
+		res, err := fu.GetHomeFeed(context.Background(), 1, "", 0)
+		assert.NoError(t, err)
+		assert.Empty(t, res.Threads)
+	})
+
+	t.Run("bad-param-input", func(t *testing.T) {
+		fu := newTestUsecase(memory.NewFeedRepository())
+
+		err := fu.MarkSeen(context.Background(), 1, dto.FeedSeenRequest{})
+		assert.ErrorIs(t, err, utils.ErrBadParamInput)
+	})
+
+	t.Run("internal-server-error", func(t *testing.T) {
+		repo := mocks.NewFeedRepository(t)
+		repo.On("MarkThreadsSeen", mock.Anything, uint(1), []uint{1}).Return(errors.New("unexpected error")).Once()
+
+		err := NewFeedUsecase(repo, validator.New(), thread.DefaultRankingConfig(), nil).MarkSeen(context.Background(), 1, dto.FeedSeenRequest{ThreadIDs: []uint{1}})
+		assert.ErrorIs(t, err, utils.ErrInternalServerError)
+	})
+}
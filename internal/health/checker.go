@@ -0,0 +1,15 @@
+package health
+
+import "context"
+
+// Checker is a single readiness dependency, e.g. the database or storage
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+// CheckerFunc adapts a plain function to a Checker
+type CheckerFunc func(ctx context.Context) error
+
+func (f CheckerFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
@@ -0,0 +1,37 @@
+package http
+
+import (
+	"macaiki/internal/health"
+	"macaiki/pkg/buildinfo"
+	"macaiki/pkg/response"
+
+	"github.com/labstack/echo/v4"
+)
+
+type HealthHandler struct {
+	healthUsecase health.HealthUsecase
+}
+
+func NewHealthHandler(e *echo.Echo, healthUsecase health.HealthUsecase) {
+	healthHandler := HealthHandler{healthUsecase}
+	e.GET("/healthz", healthHandler.Liveness)
+	e.GET("/readyz", healthHandler.Readiness)
+	e.GET("/version", healthHandler.Version)
+}
+
+func (healthHandler *HealthHandler) Liveness(c echo.Context) error {
+	return response.SuccessResponse(c, healthHandler.healthUsecase.Liveness())
+}
+
+func (healthHandler *HealthHandler) Readiness(c echo.Context) error {
+	dtoResponse, err := healthHandler.healthUsecase.Readiness(c.Request().Context())
+	if err != nil {
+		return response.ErrorResponseWithData(c, err, dtoResponse)
+	}
+
+	return response.SuccessResponse(c, dtoResponse)
+}
+
+func (healthHandler *HealthHandler) Version(c echo.Context) error {
+	return response.SuccessResponse(c, buildinfo.Get())
+}
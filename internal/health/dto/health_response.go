@@ -0,0 +1,18 @@
+package dto
+
+import "macaiki/pkg/buildinfo"
+
+type LivenessResponse struct {
+	Status string         `json:"status"`
+	Build  buildinfo.Info `json:"build"`
+}
+
+type CheckResponse struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+type ReadinessResponse struct {
+	Status string          `json:"status"`
+	Checks []CheckResponse `json:"checks"`
+}
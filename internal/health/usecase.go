@@ -0,0 +1,14 @@
+package health
+
+import (
+	"context"
+	"macaiki/internal/health/dto"
+)
+
+type HealthUsecase interface {
+	Liveness() dto.LivenessResponse
+	Readiness(ctx context.Context) (dto.ReadinessResponse, error)
+	// MarkShuttingDown makes readiness fail so the load balancer stops
+	// routing new requests while in-flight ones drain
+	MarkShuttingDown()
+}
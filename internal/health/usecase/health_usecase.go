@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"macaiki/internal/health"
+	"macaiki/internal/health/dto"
+	"macaiki/pkg/buildinfo"
+	"macaiki/pkg/logger"
+	"macaiki/pkg/utils"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	statusOK   = "ok"
+	statusFail = "fail"
+)
+
+type HealthUsecaseImpl struct {
+	checkers     map[string]health.Checker
+	timeout      time.Duration
+	shuttingDown int32
+	logger       *slog.Logger
+}
+
+func NewHealthUsecase(checkers map[string]health.Checker, timeout time.Duration, log *slog.Logger) health.HealthUsecase {
+	return &HealthUsecaseImpl{checkers: checkers, timeout: timeout, logger: logger.OrDefault(log)}
+}
+
+func (hu *HealthUsecaseImpl) Liveness() dto.LivenessResponse {
+	return dto.LivenessResponse{Status: statusOK, Build: buildinfo.Get()}
+}
+
+// Readiness runs every checker concurrently, the whole probe is bounded by
+// the configured timeout. The probe is public, so failures are logged and
+// only the name of the failing check is reported
+func (hu *HealthUsecaseImpl) Readiness(ctx context.Context) (dto.ReadinessResponse, error) {
+	if atomic.LoadInt32(&hu.shuttingDown) == 1 {
+		return dto.ReadinessResponse{
+			Status: statusFail,
+			Checks: []dto.CheckResponse{{Name: "shutdown", Status: statusFail}},
+		}, utils.ErrServiceUnavailable
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, hu.timeout)
+	defer cancel()
+
+	names := make([]string, 0, len(hu.checkers))
+	for name := range hu.checkers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	checks := make([]dto.CheckResponse, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+
+			checks[i] = dto.CheckResponse{Name: name, Status: statusOK}
+			if err := hu.checkers[name].Check(ctx); err != nil {
+				checks[i].Status = statusFail
+				hu.logger.WarnContext(ctx, "readiness check failed", "check", name, "err", err)
+			}
+		}(i, name)
+	}
+	wg.Wait()
+
+	res := dto.ReadinessResponse{Status: statusOK, Checks: checks}
+	for _, check := range checks {
+		if check.Status != statusOK {
+			res.Status = statusFail
+			return res, utils.ErrServiceUnavailable
+		}
+	}
+
+	return res, nil
+}
+
+func (hu *HealthUsecaseImpl) MarkShuttingDown() {
+	atomic.StoreInt32(&hu.shuttingDown, 1)
+}
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"macaiki/internal/health"
+	"macaiki/pkg/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	okChecker   = health.CheckerFunc(func(ctx context.Context) error { return nil })
+	failChecker = health.CheckerFunc(func(ctx context.Context) error { return errors.New("connection refused") })
+)
+
+func TestLiveness(t *testing.T) {
+	testHealthUsecase := NewHealthUsecase(nil, time.Second, nil)
+	res := testHealthUsecase.Liveness()
+
+	assert.Equal(t, "ok", res.Status)
+	assert.NotEmpty(t, res.Build.GoVersion)
+}
+
+func TestReadiness(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		testHealthUsecase := NewHealthUsecase(map[string]health.Checker{
+			"database": okChecker,
+			"storage":  okChecker,
+		}, time.Second, nil)
+		res, err := testHealthUsecase.Readiness(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, "ok", res.Status)
+		assert.Len(t, res.Checks, 2)
+		assert.Equal(t, "database", res.Checks[0].Name)
+	})
+
+	t.Run("check-failed", func(t *testing.T) {
+		testHealthUsecase := NewHealthUsecase(map[string]health.Checker{
+			"database": okChecker,
+			"storage":  failChecker,
+		}, time.Second, nil)
+		res, err := testHealthUsecase.Readiness(context.Background())
+
+		assert.Equal(t, utils.ErrServiceUnavailable, err)
+		assert.Equal(t, "fail", res.Status)
+		assert.Equal(t, "storage", res.Checks[1].Name)
+		assert.Equal(t, "fail", res.Checks[1].Status)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		slowChecker := health.CheckerFunc(func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		testHealthUsecase := NewHealthUsecase(map[string]health.Checker{"database": slowChecker}, 10*time.Millisecond, nil)
+		_, err := testHealthUsecase.Readiness(context.Background())
+
+		assert.Equal(t, utils.ErrServiceUnavailable, err)
+	})
+
+	t.Run("shutting-down", func(t *testing.T) {
+		testHealthUsecase := NewHealthUsecase(map[string]health.Checker{"database": okChecker}, time.Second, nil)
+		testHealthUsecase.MarkShuttingDown()
+		res, err := testHealthUsecase.Readiness(context.Background())
+
+		assert.Equal(t, utils.ErrServiceUnavailable, err)
+		assert.Equal(t, "fail", res.Status)
+	})
+}
@@ -0,0 +1,105 @@
+package http
+
+import (
+	"macaiki/internal/identity"
+	"macaiki/internal/identity/dto"
+	_middL "macaiki/pkg/middleware"
+	"macaiki/pkg/response"
+	"macaiki/pkg/utils"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+type IdentityHandler struct {
+	identityUsecase identity.IdentityUsecase
+	JWTSecret       string
+}
+
+func NewIdentityHandler(e *echo.Echo, identityUsecase identity.IdentityUsecase, JWTSecret string) {
+	handler := &IdentityHandler{identityUsecase, JWTSecret}
+
+	e.GET("/api/v1/auth/providers", handler.GetProviders)
+	e.GET("/api/v1/auth/providers/:provider", handler.Authorize)
+	e.POST("/api/v1/auth/providers/:provider/callback", handler.Login)
+
+	e.GET("/api/v1/curent-user/identities", handler.GetIdentities, middleware.JWT([]byte(JWTSecret)))
+	e.POST("/api/v1/curent-user/identities/:provider", handler.AuthorizeLink, middleware.JWT([]byte(JWTSecret)))
+	e.POST("/api/v1/curent-user/identities/:provider/callback", handler.Link, middleware.JWT([]byte(JWTSecret)))
+	e.DELETE("/api/v1/curent-user/identities/:identityID", handler.Unlink, middleware.JWT([]byte(JWTSecret)))
+}
+
+func (h *IdentityHandler) GetProviders(c echo.Context) error {
+	return response.SuccessResponse(c, h.identityUsecase.GetProviders(c.Request().Context()))
+}
+
+func (h *IdentityHandler) Authorize(c echo.Context) error {
+	res, err := h.identityUsecase.Authorize(c.Request().Context(), c.Param("provider"))
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, res)
+}
+
+func (h *IdentityHandler) Login(c echo.Context) error {
+	req := dto.CallbackRequest{}
+	c.Bind(&req)
+
+	token, err := h.identityUsecase.Login(c.Request().Context(), c.Param("provider"), req)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, token)
+}
+
+func (h *IdentityHandler) GetIdentities(c echo.Context) error {
+	userID, _ := _middL.ExtractTokenUser(c)
+
+	res, err := h.identityUsecase.GetIdentities(c.Request().Context(), uint(userID))
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, res)
+}
+
+func (h *IdentityHandler) AuthorizeLink(c echo.Context) error {
+	userID, _ := _middL.ExtractTokenUser(c)
+
+	res, err := h.identityUsecase.AuthorizeLink(c.Request().Context(), uint(userID), c.Param("provider"))
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, res)
+}
+
+func (h *IdentityHandler) Link(c echo.Context) error {
+	userID, _ := _middL.ExtractTokenUser(c)
+	req := dto.CallbackRequest{}
+	c.Bind(&req)
+
+	res, err := h.identityUsecase.Link(c.Request().Context(), uint(userID), c.Param("provider"), req)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, res)
+}
+
+func (h *IdentityHandler) Unlink(c echo.Context) error {
+	userID, _ := _middL.ExtractTokenUser(c)
+	identityID, err := strconv.Atoi(c.Param("identityID"))
+	if err != nil {
+		return response.ErrorResponse(c, utils.ErrBadParamInput)
+	}
+
+	if err := h.identityUsecase.Unlink(c.Request().Context(), uint(userID), uint(identityID)); err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, nil)
+}
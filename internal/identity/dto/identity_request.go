@@ -0,0 +1,6 @@
+package dto
+
+type CallbackRequest struct {
+	Code  string `json:"code" validate:"required"`
+	State string `json:"state" validate:"required"`
+}
@@ -0,0 +1,19 @@
+package dto
+
+import "time"
+
+type ProviderResponse struct {
+	Name string `json:"name"`
+}
+
+type AuthorizationResponse struct {
+	AuthorizationURL string    `json:"authorizationURL"`
+	ExpiredAt        time.Time `json:"expiredAt"`
+}
+
+type IdentityResponse struct {
+	ID        uint      `json:"ID"`
+	Provider  string    `json:"provider"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"createdAt"`
+}
@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+// Identity links a user to their account at an external OpenID Connect
+// provider, the pair of provider and subject is unique
+type Identity struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"index"`
+	Provider  string `gorm:"uniqueIndex:idx_identities_provider_subject;size:50"`
+	Subject   string `gorm:"uniqueIndex:idx_identities_provider_subject;size:255"`
+	Email     string `gorm:"size:75"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// AuthState is the server side half of an authorization request. It keeps
+// the PKCE verifier and nonce until the provider redirects back, only a
+// hash of the state parameter is stored.
+type AuthState struct {
+	ID        uint   `gorm:"primaryKey"`
+	StateHash string `gorm:"uniqueIndex;size:64"`
+	Provider  string `gorm:"size:50"`
+	// UserID is set when the flow links an identity to a signed in user
+	UserID    uint
+	Verifier  string
+	Nonce     string
+	ExpiredAt time.Time
+	CreatedAt time.Time
+}
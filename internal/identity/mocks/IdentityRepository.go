@@ -0,0 +1,167 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	entity "macaiki/internal/identity/entity"
+
+	userentity "macaiki/internal/user/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IdentityRepository is an autogenerated mock type for the IdentityRepository type
+type IdentityRepository struct {
+	mock.Mock
+}
+
+// ConsumeAuthState provides a mock function with given fields: ctx, stateHash
+func (_m *IdentityRepository) ConsumeAuthState(ctx context.Context, stateHash string) (entity.AuthState, error) {
+	ret := _m.Called(ctx, stateHash)
+
+	var r0 entity.AuthState
+	if rf, ok := ret.Get(0).(func(context.Context, string) entity.AuthState); ok {
+		r0 = rf(ctx, stateHash)
+	} else {
+		r0 = ret.Get(0).(entity.AuthState)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, stateHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateUserWithIdentity provides a mock function with given fields: ctx, user, _a2
+func (_m *IdentityRepository) CreateUserWithIdentity(ctx context.Context, user userentity.User, _a2 entity.Identity) (userentity.User, error) {
+	ret := _m.Called(ctx, user, _a2)
+
+	var r0 userentity.User
+	if rf, ok := ret.Get(0).(func(context.Context, userentity.User, entity.Identity) userentity.User); ok {
+		r0 = rf(ctx, user, _a2)
+	} else {
+		r0 = ret.Get(0).(userentity.User)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, userentity.User, entity.Identity) error); ok {
+		r1 = rf(ctx, user, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteIdentity provides a mock function with given fields: ctx, userID, identityID
+func (_m *IdentityRepository) DeleteIdentity(ctx context.Context, userID uint, identityID uint) error {
+	ret := _m.Called(ctx, userID, identityID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, userID, identityID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetIdentities provides a mock function with given fields: ctx, userID
+func (_m *IdentityRepository) GetIdentities(ctx context.Context, userID uint) ([]entity.Identity, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []entity.Identity
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []entity.Identity); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Identity)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetIdentity provides a mock function with given fields: ctx, provider, subject
+func (_m *IdentityRepository) GetIdentity(ctx context.Context, provider string, subject string) (entity.Identity, error) {
+	ret := _m.Called(ctx, provider, subject)
+
+	var r0 entity.Identity
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) entity.Identity); ok {
+		r0 = rf(ctx, provider, subject)
+	} else {
+		r0 = ret.Get(0).(entity.Identity)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, provider, subject)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StoreAuthState provides a mock function with given fields: ctx, state
+func (_m *IdentityRepository) StoreAuthState(ctx context.Context, state entity.AuthState) error {
+	ret := _m.Called(ctx, state)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.AuthState) error); ok {
+		r0 = rf(ctx, state)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StoreIdentity provides a mock function with given fields: ctx, _a1
+func (_m *IdentityRepository) StoreIdentity(ctx context.Context, _a1 entity.Identity) (entity.Identity, error) {
+	ret := _m.Called(ctx, _a1)
+
+	var r0 entity.Identity
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Identity) entity.Identity); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Get(0).(entity.Identity)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Identity) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewIdentityRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIdentityRepository creates a new instance of IdentityRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIdentityRepository(t mockConstructorTestingTNewIdentityRepository) *IdentityRepository {
+	mock := &IdentityRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
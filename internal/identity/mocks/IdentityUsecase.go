@@ -0,0 +1,169 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	dto "macaiki/internal/identity/dto"
+
+	userdto "macaiki/internal/user/dto"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IdentityUsecase is an autogenerated mock type for the IdentityUsecase type
+type IdentityUsecase struct {
+	mock.Mock
+}
+
+// Authorize provides a mock function with given fields: ctx, provider
+func (_m *IdentityUsecase) Authorize(ctx context.Context, provider string) (dto.AuthorizationResponse, error) {
+	ret := _m.Called(ctx, provider)
+
+	var r0 dto.AuthorizationResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string) dto.AuthorizationResponse); ok {
+		r0 = rf(ctx, provider)
+	} else {
+		r0 = ret.Get(0).(dto.AuthorizationResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, provider)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthorizeLink provides a mock function with given fields: ctx, userID, provider
+func (_m *IdentityUsecase) AuthorizeLink(ctx context.Context, userID uint, provider string) (dto.AuthorizationResponse, error) {
+	ret := _m.Called(ctx, userID, provider)
+
+	var r0 dto.AuthorizationResponse
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) dto.AuthorizationResponse); ok {
+		r0 = rf(ctx, userID, provider)
+	} else {
+		r0 = ret.Get(0).(dto.AuthorizationResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, string) error); ok {
+		r1 = rf(ctx, userID, provider)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetIdentities provides a mock function with given fields: ctx, userID
+func (_m *IdentityUsecase) GetIdentities(ctx context.Context, userID uint) ([]dto.IdentityResponse, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []dto.IdentityResponse
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []dto.IdentityResponse); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.IdentityResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProviders provides a mock function with given fields: ctx
+func (_m *IdentityUsecase) GetProviders(ctx context.Context) []dto.ProviderResponse {
+	ret := _m.Called(ctx)
+
+	var r0 []dto.ProviderResponse
+	if rf, ok := ret.Get(0).(func(context.Context) []dto.ProviderResponse); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.ProviderResponse)
+		}
+	}
+
+	return r0
+}
+
+// Link provides a mock function with given fields: ctx, userID, provider, req
+func (_m *IdentityUsecase) Link(ctx context.Context, userID uint, provider string, req dto.CallbackRequest) (dto.IdentityResponse, error) {
+	ret := _m.Called(ctx, userID, provider, req)
+
+	var r0 dto.IdentityResponse
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, dto.CallbackRequest) dto.IdentityResponse); ok {
+		r0 = rf(ctx, userID, provider, req)
+	} else {
+		r0 = ret.Get(0).(dto.IdentityResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, string, dto.CallbackRequest) error); ok {
+		r1 = rf(ctx, userID, provider, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Login provides a mock function with given fields: ctx, provider, req
+func (_m *IdentityUsecase) Login(ctx context.Context, provider string, req dto.CallbackRequest) (userdto.LoginResponse, error) {
+	ret := _m.Called(ctx, provider, req)
+
+	var r0 userdto.LoginResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, dto.CallbackRequest) userdto.LoginResponse); ok {
+		r0 = rf(ctx, provider, req)
+	} else {
+		r0 = ret.Get(0).(userdto.LoginResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, dto.CallbackRequest) error); ok {
+		r1 = rf(ctx, provider, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Unlink provides a mock function with given fields: ctx, userID, identityID
+func (_m *IdentityUsecase) Unlink(ctx context.Context, userID uint, identityID uint) error {
+	ret := _m.Called(ctx, userID, identityID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, userID, identityID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewIdentityUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIdentityUsecase creates a new instance of IdentityUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIdentityUsecase(t mockConstructorTestingTNewIdentityUsecase) *IdentityUsecase {
+	mock := &IdentityUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,87 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	oidc "macaiki/pkg/oidc"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Provider is an autogenerated mock type for the Provider type
+type Provider struct {
+	mock.Mock
+}
+
+// AuthCodeURL provides a mock function with given fields: ctx, state, nonce, verifier
+func (_m *Provider) AuthCodeURL(ctx context.Context, state string, nonce string, verifier string) (string, error) {
+	ret := _m.Called(ctx, state, nonce, verifier)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) string); ok {
+		r0 = rf(ctx, state, nonce, verifier)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, state, nonce, verifier)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Exchange provides a mock function with given fields: ctx, code, verifier, nonce
+func (_m *Provider) Exchange(ctx context.Context, code string, verifier string, nonce string) (oidc.Claims, error) {
+	ret := _m.Called(ctx, code, verifier, nonce)
+
+	var r0 oidc.Claims
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) oidc.Claims); ok {
+		r0 = rf(ctx, code, verifier, nonce)
+	} else {
+		r0 = ret.Get(0).(oidc.Claims)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, code, verifier, nonce)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Name provides a mock function with given fields:
+func (_m *Provider) Name() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewProvider interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewProvider creates a new instance of Provider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewProvider(t mockConstructorTestingTNewProvider) *Provider {
+	mock := &Provider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,13 @@
+package identity
+
+import (
+	"context"
+	"macaiki/pkg/oidc"
+)
+
+// Provider is an external OpenID Connect issuer users can sign in with
+type Provider interface {
+	Name() string
+	AuthCodeURL(ctx context.Context, state, nonce, verifier string) (string, error)
+	Exchange(ctx context.Context, code, verifier, nonce string) (oidc.Claims, error)
+}
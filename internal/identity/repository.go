@@ -0,0 +1,19 @@
+package identity
+
+import (
+	"context"
+	"macaiki/internal/identity/entity"
+	userEntity "macaiki/internal/user/entity"
+)
+
+type IdentityRepository interface {
+	StoreAuthState(ctx context.Context, state entity.AuthState) error
+	ConsumeAuthState(ctx context.Context, stateHash string) (entity.AuthState, error)
+
+	GetIdentity(ctx context.Context, provider, subject string) (entity.Identity, error)
+	GetIdentities(ctx context.Context, userID uint) ([]entity.Identity, error)
+	StoreIdentity(ctx context.Context, identity entity.Identity) (entity.Identity, error)
+	DeleteIdentity(ctx context.Context, userID, identityID uint) error
+
+	CreateUserWithIdentity(ctx context.Context, user userEntity.User, identity entity.Identity) (userEntity.User, error)
+}
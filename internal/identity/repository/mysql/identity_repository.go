@@ -0,0 +1,162 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"macaiki/internal/identity"
+	"macaiki/internal/identity/entity"
+	userEntity "macaiki/internal/user/entity"
+	"macaiki/pkg/logger"
+	"macaiki/pkg/utils"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type IdentityRepositoryImpl struct {
+	db     *gorm.DB
+	logger *slog.Logger
+}
+
+func NewIdentityRepository(db *gorm.DB, log *slog.Logger) identity.IdentityRepository {
+	return &IdentityRepositoryImpl{db, logger.OrDefault(log)}
+}
+
+// StoreAuthState saves a new authorization request and clears out the
+// expired ones that were never completed
+func (ir *IdentityRepositoryImpl) StoreAuthState(ctx context.Context, state entity.AuthState) error {
+	err := ir.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("expired_at < ?", time.Now()).Delete(&entity.AuthState{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&state).Error
+	})
+	if err != nil {
+		ir.logger.ErrorContext(ctx, "query failed", "op", "StoreAuthState", "err", err)
+		return utils.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// ConsumeAuthState returns the authorization request and deletes it so the
+// state parameter can only be redeemed once
+func (ir *IdentityRepositoryImpl) ConsumeAuthState(ctx context.Context, stateHash string) (entity.AuthState, error) {
+	state := entity.AuthState{}
+	err := ir.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("state_hash = ?", stateHash).Limit(1).Find(&state)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return utils.ErrNotFound
+		}
+
+		res = tx.Delete(&entity.AuthState{}, state.ID)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return utils.ErrNotFound
+		}
+		return nil
+	})
+	if errors.Is(err, utils.ErrNotFound) {
+		return entity.AuthState{}, utils.ErrNotFound
+	}
+	if err != nil {
+		ir.logger.ErrorContext(ctx, "query failed", "op", "ConsumeAuthState", "err", err)
+		return entity.AuthState{}, utils.ErrInternalServerError
+	}
+
+	return state, nil
+}
+
+func (ir *IdentityRepositoryImpl) GetIdentity(ctx context.Context, provider, subject string) (entity.Identity, error) {
+	identity := entity.Identity{}
+	res := ir.db.WithContext(ctx).Where("provider = ? AND subject = ?", provider, subject).Limit(1).Find(&identity)
+	if res.Error != nil {
+		ir.logger.ErrorContext(ctx, "query failed", "op", "GetIdentity", "err", res.Error)
+		return entity.Identity{}, utils.ErrInternalServerError
+	}
+
+	if res.RowsAffected == 0 {
+		return entity.Identity{}, utils.ErrNotFound
+	}
+
+	return identity, nil
+}
+
+func (ir *IdentityRepositoryImpl) GetIdentities(ctx context.Context, userID uint) ([]entity.Identity, error) {
+	identities := []entity.Identity{}
+	res := ir.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at").Find(&identities)
+	if res.Error != nil {
+		ir.logger.ErrorContext(ctx, "query failed", "op", "GetIdentities", "err", res.Error)
+		return []entity.Identity{}, utils.ErrInternalServerError
+	}
+
+	return identities, nil
+}
+
+func (ir *IdentityRepositoryImpl) StoreIdentity(ctx context.Context, identity entity.Identity) (entity.Identity, error) {
+	res := ir.db.WithContext(ctx).Create(&identity)
+	if res.Error != nil {
+		if isDuplicate(res.Error) {
+			return entity.Identity{}, utils.ErrIdentityAlreadyLinked
+		}
+		ir.logger.ErrorContext(ctx, "query failed", "op", "StoreIdentity", "err", res.Error)
+		return entity.Identity{}, utils.ErrInternalServerError
+	}
+
+	return identity, nil
+}
+
+func (ir *IdentityRepositoryImpl) DeleteIdentity(ctx context.Context, userID, identityID uint) error {
+	res := ir.db.WithContext(ctx).Where("id = ? AND user_id = ?", identityID, userID).Delete(&entity.Identity{})
+	if res.Error != nil {
+		ir.logger.ErrorContext(ctx, "query failed", "op", "DeleteIdentity", "err", res.Error)
+		return utils.ErrInternalServerError
+	}
+
+	if res.RowsAffected == 0 {
+		return utils.ErrNotFound
+	}
+
+	return nil
+}
+
+// CreateUserWithIdentity provisions a new account for a first time external
+// login, the user and the linked identity are created together
+func (ir *IdentityRepositoryImpl) CreateUserWithIdentity(ctx context.Context, user userEntity.User, identity entity.Identity) (userEntity.User, error) {
+	err := ir.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&user).Error; err != nil {
+			return err
+		}
+
+		identity.UserID = user.ID
+		return tx.Create(&identity).Error
+	})
+	if err != nil {
+		if isDuplicate(err) {
+			switch {
+			case strings.Contains(err.Error(), "idx_users_username"):
+				return userEntity.User{}, utils.ErrUsernameAlreadyUsed
+			case strings.Contains(err.Error(), "idx_users_email"):
+				return userEntity.User{}, utils.ErrEmailAlreadyUsed
+			default:
+				return userEntity.User{}, utils.ErrIdentityAlreadyLinked
+			}
+		}
+		ir.logger.ErrorContext(ctx, "query failed", "op", "CreateUserWithIdentity", "err", err)
+		return userEntity.User{}, utils.ErrInternalServerError
+	}
+
+	return user, nil
+}
+
+func isDuplicate(err error) bool {
+	return strings.HasPrefix(err.Error(), "Error 1062: Duplicate entry")
+}
@@ -0,0 +1,18 @@
+package identity
+
+import (
+	"context"
+	"macaiki/internal/identity/dto"
+	userDTO "macaiki/internal/user/dto"
+)
+
+type IdentityUsecase interface {
+	GetProviders(ctx context.Context) []dto.ProviderResponse
+	Authorize(ctx context.Context, provider string) (dto.AuthorizationResponse, error)
+	Login(ctx context.Context, provider string, req dto.CallbackRequest) (userDTO.LoginResponse, error)
+
+	AuthorizeLink(ctx context.Context, userID uint, provider string) (dto.AuthorizationResponse, error)
+	Link(ctx context.Context, userID uint, provider string, req dto.CallbackRequest) (dto.IdentityResponse, error)
+	GetIdentities(ctx context.Context, userID uint) ([]dto.IdentityResponse, error)
+	Unlink(ctx context.Context, userID, identityID uint) error
+}
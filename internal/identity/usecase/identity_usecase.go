@@ -0,0 +1,376 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"macaiki/internal/identity"
+	"macaiki/internal/identity/dto"
+	"macaiki/internal/identity/entity"
+	"macaiki/internal/user"
+	userDTO "macaiki/internal/user/dto"
+	userEntity "macaiki/internal/user/entity"
+	"macaiki/pkg/logger"
+	"macaiki/pkg/metrics"
+	"macaiki/pkg/oidc"
+	"macaiki/pkg/utils"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	AUTH_STATE_EXPIRATION = 10 * time.Minute
+
+	USERNAME_MAX_LENGTH   = 20
+	USERNAME_MAX_ATTEMPTS = 5
+)
+
+type identityUsecase struct {
+	identityRepo identity.IdentityRepository
+	userRepo     user.UserRepository
+	sessions     user.SessionIssuer
+	providers    map[string]identity.Provider
+	validator    *validator.Validate
+	logger       *slog.Logger
+}
+
+func NewIdentityUsecase(identityRepo identity.IdentityRepository, userRepo user.UserRepository, sessions user.SessionIssuer, providers []identity.Provider, validator *validator.Validate, log *slog.Logger) identity.IdentityUsecase {
+	byName := map[string]identity.Provider{}
+	for _, provider := range providers {
+		byName[provider.Name()] = provider
+	}
+
+	return &identityUsecase{
+		identityRepo: identityRepo,
+		userRepo:     userRepo,
+		sessions:     sessions,
+		providers:    byName,
+		validator:    validator,
+		logger:       logger.OrDefault(log),
+	}
+}
+
+func (iu *identityUsecase) GetProviders(ctx context.Context) []dto.ProviderResponse {
+	providers := []dto.ProviderResponse{}
+	for name := range iu.providers {
+		providers = append(providers, dto.ProviderResponse{Name: name})
+	}
+	sort.Slice(providers, func(i, j int) bool { return providers[i].Name < providers[j].Name })
+	return providers
+}
+
+func (iu *identityUsecase) Authorize(ctx context.Context, provider string) (dto.AuthorizationResponse, error) {
+	return iu.authorize(ctx, 0, provider)
+}
+
+func (iu *identityUsecase) AuthorizeLink(ctx context.Context, userID uint, provider string) (dto.AuthorizationResponse, error) {
+	return iu.authorize(ctx, userID, provider)
+}
+
+// authorize starts an authorization code flow, userID is zero for a login
+// and the signed in user when linking a new identity
+func (iu *identityUsecase) authorize(ctx context.Context, userID uint, providerName string) (dto.AuthorizationResponse, error) {
+	provider, ok := iu.providers[providerName]
+	if !ok {
+		return dto.AuthorizationResponse{}, utils.ErrNotFound
+	}
+
+	state := utils.GenerateSecureToken(32)
+	authState := entity.AuthState{
+		StateHash: utils.HashToken(state),
+		Provider:  providerName,
+		UserID:    userID,
+		Verifier:  oidc.GenerateVerifier(),
+		Nonce:     utils.GenerateSecureToken(16),
+		ExpiredAt: time.Now().Add(AUTH_STATE_EXPIRATION),
+	}
+
+	authURL, err := provider.AuthCodeURL(ctx, state, authState.Nonce, authState.Verifier)
+	if err != nil {
+		iu.logger.ErrorContext(ctx, "identity provider unavailable", "provider", providerName, "err", err)
+		return dto.AuthorizationResponse{}, utils.ErrServiceUnavailable
+	}
+
+	if err := iu.identityRepo.StoreAuthState(ctx, authState); err != nil {
+		return dto.AuthorizationResponse{}, utils.ErrInternalServerError
+	}
+
+	return dto.AuthorizationResponse{
+		AuthorizationURL: authURL,
+		ExpiredAt:        authState.ExpiredAt,
+	}, nil
+}
+
+func (iu *identityUsecase) Login(ctx context.Context, provider string, req dto.CallbackRequest) (userDTO.LoginResponse, error) {
+	claims, err := iu.callback(ctx, 0, provider, req)
+	if err != nil {
+		metrics.Logins.WithLabelValues(metrics.LoginFail).Inc()
+		return userDTO.LoginResponse{}, err
+	}
+
+	account, err := iu.findOrProvisionUser(ctx, provider, claims)
+	if err != nil {
+		metrics.Logins.WithLabelValues(metrics.LoginFail).Inc()
+		return userDTO.LoginResponse{}, err
+	}
+
+	res, err := iu.sessions.Issue(ctx, account)
+	if err != nil {
+		return userDTO.LoginResponse{}, err
+	}
+
+	if res.Token != "" {
+		metrics.Logins.WithLabelValues(metrics.LoginSuccess).Inc()
+	}
+	return res, nil
+}
+
+func (iu *identityUsecase) Link(ctx context.Context, userID uint, provider string, req dto.CallbackRequest) (dto.IdentityResponse, error) {
+	claims, err := iu.callback(ctx, userID, provider, req)
+	if err != nil {
+		return dto.IdentityResponse{}, err
+	}
+
+	linked, err := iu.identityRepo.GetIdentity(ctx, provider, claims.Subject)
+	if err == nil {
+		if linked.UserID != userID {
+			return dto.IdentityResponse{}, utils.ErrIdentityAlreadyLinked
+		}
+		return toIdentityResponse(linked), nil
+	}
+	if !errors.Is(err, utils.ErrNotFound) {
+		return dto.IdentityResponse{}, utils.ErrInternalServerError
+	}
+
+	linked, err = iu.identityRepo.StoreIdentity(ctx, entity.Identity{
+		UserID:   userID,
+		Provider: provider,
+		Subject:  claims.Subject,
+		Email:    claims.Email,
+	})
+	if err != nil {
+		return dto.IdentityResponse{}, err
+	}
+
+	return toIdentityResponse(linked), nil
+}
+
+func (iu *identityUsecase) GetIdentities(ctx context.Context, userID uint) ([]dto.IdentityResponse, error) {
+	identities, err := iu.identityRepo.GetIdentities(ctx, userID)
+	if err != nil {
+		return []dto.IdentityResponse{}, utils.ErrInternalServerError
+	}
+
+	res := []dto.IdentityResponse{}
+	for _, identity := range identities {
+		res = append(res, toIdentityResponse(identity))
+	}
+	return res, nil
+}
+
+// Unlink removes a linked identity unless it is the only way left for the
+// user to sign in
+func (iu *identityUsecase) Unlink(ctx context.Context, userID, identityID uint) error {
+	identities, err := iu.identityRepo.GetIdentities(ctx, userID)
+	if err != nil {
+		return utils.ErrInternalServerError
+	}
+
+	found := false
+	for _, identity := range identities {
+		if identity.ID == identityID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return utils.ErrNotFound
+	}
+
+	account, err := iu.userRepo.Get(ctx, userID)
+	if err != nil {
+		return utils.ErrInternalServerError
+	}
+	if account.ID == 0 {
+		return utils.ErrNotFound
+	}
+
+	if account.Password == "" && len(identities) == 1 {
+		return utils.ErrLastLoginMethod
+	}
+
+	return iu.identityRepo.DeleteIdentity(ctx, userID, identityID)
+}
+
+// callback redeems the state of an authorization request and exchanges the
+// code with the provider. The state must have been issued for the same
+// provider and, when linking, the same user.
+func (iu *identityUsecase) callback(ctx context.Context, userID uint, providerName string, req dto.CallbackRequest) (oidc.Claims, error) {
+	if err := iu.validator.Struct(req); err != nil {
+		return oidc.Claims{}, utils.ErrBadParamInput
+	}
+
+	provider, ok := iu.providers[providerName]
+	if !ok {
+		return oidc.Claims{}, utils.ErrNotFound
+	}
+
+	authState, err := iu.identityRepo.ConsumeAuthState(ctx, utils.HashToken(req.State))
+	if errors.Is(err, utils.ErrNotFound) {
+		return oidc.Claims{}, utils.ErrInvalidToken
+	}
+	if err != nil {
+		return oidc.Claims{}, utils.ErrInternalServerError
+	}
+
+	if authState.Provider != providerName || authState.UserID != userID || time.Now().After(authState.ExpiredAt) {
+		return oidc.Claims{}, utils.ErrInvalidToken
+	}
+
+	claims, err := provider.Exchange(ctx, req.Code, authState.Verifier, authState.Nonce)
+	if err != nil {
+		iu.logger.WarnContext(ctx, "external login rejected", "provider", providerName, "err", err)
+		return oidc.Claims{}, utils.ErrExternalLoginFailed
+	}
+	if claims.Subject == "" {
+		return oidc.Claims{}, utils.ErrExternalLoginFailed
+	}
+
+	return claims, nil
+}
+
+// findOrProvisionUser returns the user linked to the external account, on
+// the first login a new user is created with a username derived from the
+// claims. An existing local account with the same email is never taken over,
+// its owner has to sign in and link the identity instead.
+func (iu *identityUsecase) findOrProvisionUser(ctx context.Context, provider string, claims oidc.Claims) (userEntity.User, error) {
+	linked, err := iu.identityRepo.GetIdentity(ctx, provider, claims.Subject)
+	if err == nil {
+		account, err := iu.userRepo.Get(ctx, linked.UserID)
+		if err != nil {
+			return userEntity.User{}, utils.ErrInternalServerError
+		}
+		if account.ID == 0 {
+			return userEntity.User{}, utils.ErrExternalLoginFailed
+		}
+		return account, nil
+	}
+	if !errors.Is(err, utils.ErrNotFound) {
+		return userEntity.User{}, utils.ErrInternalServerError
+	}
+
+	if claims.Email == "" {
+		return userEntity.User{}, utils.ErrEmailRequired
+	}
+
+	existing, err := iu.userRepo.GetByEmail(ctx, claims.Email)
+	if err != nil {
+		return userEntity.User{}, utils.ErrInternalServerError
+	}
+	if existing.ID != 0 {
+		return userEntity.User{}, utils.ErrEmailAlreadyUsed
+	}
+
+	newUser := userEntity.User{
+		Email:              claims.Email,
+		Role:               "User",
+		ProfileImageUrl:    userEntity.DEFAULT_PROFILE,
+		BackgroundImageUrl: userEntity.DEFAULT_BACKGROUND,
+		Name:               claims.Name,
+		IsBanned:           0,
+	}
+	if claims.EmailVerified {
+		newUser.EmailVerifiedAt = time.Now()
+	}
+	newIdentity := entity.Identity{
+		Provider: provider,
+		Subject:  claims.Subject,
+		Email:    claims.Email,
+	}
+
+	base := baseUsername(claims)
+	for attempt := 0; attempt < USERNAME_MAX_ATTEMPTS; attempt++ {
+		username := base
+		if attempt > 0 {
+			username = suffixedUsername(base, 1000+rand.Intn(9000))
+		}
+
+		taken, err := iu.userRepo.GetByUsername(ctx, username)
+		if err != nil {
+			return userEntity.User{}, utils.ErrInternalServerError
+		}
+		if taken.ID != 0 {
+			continue
+		}
+
+		newUser.Username = username
+		if newUser.Name == "" {
+			newUser.Name = username
+		}
+
+		created, err := iu.identityRepo.CreateUserWithIdentity(ctx, newUser, newIdentity)
+		if errors.Is(err, utils.ErrUsernameAlreadyUsed) {
+			continue
+		}
+		if err != nil {
+			return userEntity.User{}, err
+		}
+
+		metrics.UserRegistrations.Inc()
+		return created, nil
+	}
+
+	iu.logger.ErrorContext(ctx, "could not find a free username", "base", base)
+	return userEntity.User{}, utils.ErrInternalServerError
+}
+
+// baseUsername picks the preferred username, falling back to the local part
+// of the email, and reduces it to lowercase letters, digits and underscores
+func baseUsername(claims oidc.Claims) string {
+	candidate := claims.PreferredUsername
+	if candidate == "" {
+		candidate, _, _ = strings.Cut(claims.Email, "@")
+	}
+
+	var b strings.Builder
+	for _, r := range strings.ToLower(candidate) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == '_' || r == '.' || r == '-' || r == ' ':
+			b.WriteRune('_')
+		}
+	}
+
+	username := strings.Trim(b.String(), "_")
+	if len(username) > USERNAME_MAX_LENGTH {
+		username = strings.TrimRight(username[:USERNAME_MAX_LENGTH], "_")
+	}
+	if len(username) < 3 {
+		username = "user" + username
+	}
+	return username
+}
+
+// suffixedUsername appends a four digit suffix to base, shortening base so the
+// result still fits USERNAME_MAX_LENGTH
+func suffixedUsername(base string, suffix int) string {
+	if len(base) > USERNAME_MAX_LENGTH-4 {
+		base = base[:USERNAME_MAX_LENGTH-4]
+	}
+	return fmt.Sprintf("%s%d", base, suffix)
+}
+
+func toIdentityResponse(identity entity.Identity) dto.IdentityResponse {
+	return dto.IdentityResponse{
+		ID:        identity.ID,
+		Provider:  identity.Provider,
+		Email:     identity.Email,
+		CreatedAt: identity.CreatedAt,
+	}
+}
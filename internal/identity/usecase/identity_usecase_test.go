@@ -0,0 +1,322 @@
+package usecase
+
+import (
+	"context"
+	"macaiki/internal/identity"
+	"macaiki/internal/identity/dto"
+	"macaiki/internal/identity/entity"
+	"macaiki/internal/identity/mocks"
+	userDTO "macaiki/internal/user/dto"
+	userEntity "macaiki/internal/user/entity"
+	userMocks "macaiki/internal/user/mocks"
+	"macaiki/pkg/oidc"
+	"macaiki/pkg/utils"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+var (
+	v = validator.New()
+
+	mockCallback = dto.CallbackRequest{Code: "code", State: "state"}
+
+	mockAuthState = entity.AuthState{
+		ID:        1,
+		StateHash: utils.HashToken("state"),
+		Provider:  "mock",
+		Verifier:  "verifier",
+		Nonce:     "nonce",
+		ExpiredAt: time.Now().Add(time.Minute),
+	}
+
+	mockClaims = oidc.Claims{
+		Subject:           "subject-1",
+		Email:             "jane.doe@example.com",
+		EmailVerified:     true,
+		Name:              "Jane Doe",
+		PreferredUsername: "Jane.Doe",
+	}
+
+	mockUser = userEntity.User{
+		Model:    gorm.Model{ID: 1},
+		Email:    "jane.doe@example.com",
+		Username: "jane_doe",
+		Role:     "User",
+	}
+)
+
+func newMockProvider(t *testing.T) *mocks.Provider {
+	provider := mocks.NewProvider(t)
+	provider.On("Name").Return("mock").Maybe()
+	return provider
+}
+
+func newTestUsecase(identityRepo *mocks.IdentityRepository, userRepo *userMocks.UserRepository, provider *mocks.Provider) identity.IdentityUsecase {
+	return NewIdentityUsecase(identityRepo, userRepo, nil, []identity.Provider{provider}, v, nil)
+}
+
+func newTestLoginUsecase(identityRepo *mocks.IdentityRepository, userRepo *userMocks.UserRepository, sessions *userMocks.SessionIssuer, provider *mocks.Provider) identity.IdentityUsecase {
+	return NewIdentityUsecase(identityRepo, userRepo, sessions, []identity.Provider{provider}, v, nil)
+}
+
+func TestAuthorize(t *testing.T) {
+	identityRepo := mocks.NewIdentityRepository(t)
+	provider := newMockProvider(t)
+
+	t.Run("success", func(t *testing.T) {
+		var state, verifier string
+		provider.On("AuthCodeURL", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string")).
+			Run(func(args mock.Arguments) {
+				state = args.String(1)
+				verifier = args.String(3)
+			}).
+			Return("https://issuer.test/authorize", nil).Once()
+		identityRepo.On("StoreAuthState", mock.Anything, mock.MatchedBy(func(s entity.AuthState) bool {
+			return s.StateHash == utils.HashToken(state) && s.Verifier == verifier && s.Provider == "mock" && s.UserID == 0
+		})).Return(nil).Once()
+
+		res, err := newTestUsecase(identityRepo, nil, provider).Authorize(context.Background(), "mock")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "https://issuer.test/authorize", res.AuthorizationURL)
+		assert.NotEmpty(t, state)
+	})
+
+	t.Run("link", func(t *testing.T) {
+		provider.On("AuthCodeURL", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("https://issuer.test/authorize", nil).Once()
+		identityRepo.On("StoreAuthState", mock.Anything, mock.MatchedBy(func(s entity.AuthState) bool {
+			return s.UserID == 1
+		})).Return(nil).Once()
+
+		_, err := newTestUsecase(identityRepo, nil, provider).AuthorizeLink(context.Background(), 1, "mock")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("unknown-provider", func(t *testing.T) {
+		_, err := newTestUsecase(identityRepo, nil, provider).Authorize(context.Background(), "other")
+
+		assert.Equal(t, utils.ErrNotFound, err)
+	})
+
+	t.Run("provider-unavailable", func(t *testing.T) {
+		provider.On("AuthCodeURL", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("", assert.AnError).Once()
+
+		_, err := newTestUsecase(identityRepo, nil, provider).Authorize(context.Background(), "mock")
+
+		assert.Equal(t, utils.ErrServiceUnavailable, err)
+	})
+}
+
+func TestLogin(t *testing.T) {
+	identityRepo := mocks.NewIdentityRepository(t)
+	userRepo := userMocks.NewUserRepository(t)
+	sessions := userMocks.NewSessionIssuer(t)
+	provider := newMockProvider(t)
+
+	t.Run("existing-identity", func(t *testing.T) {
+		identityRepo.On("ConsumeAuthState", mock.Anything, utils.HashToken("state")).Return(mockAuthState, nil).Once()
+		provider.On("Exchange", mock.Anything, "code", "verifier", "nonce").Return(mockClaims, nil).Once()
+		identityRepo.On("GetIdentity", mock.Anything, "mock", "subject-1").Return(entity.Identity{ID: 1, UserID: 1}, nil).Once()
+		userRepo.On("Get", mock.Anything, uint(1)).Return(mockUser, nil).Once()
+		sessions.On("Issue", mock.Anything, mockUser).Return(userDTO.LoginResponse{Token: "token"}, nil).Once()
+
+		res, err := newTestLoginUsecase(identityRepo, userRepo, sessions, provider).Login(context.Background(), "mock", mockCallback)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "token", res.Token)
+	})
+
+	t.Run("provision-user", func(t *testing.T) {
+		identityRepo.On("ConsumeAuthState", mock.Anything, utils.HashToken("state")).Return(mockAuthState, nil).Once()
+		provider.On("Exchange", mock.Anything, "code", "verifier", "nonce").Return(mockClaims, nil).Once()
+		identityRepo.On("GetIdentity", mock.Anything, "mock", "subject-1").Return(entity.Identity{}, utils.ErrNotFound).Once()
+		userRepo.On("GetByEmail", mock.Anything, mockClaims.Email).Return(userEntity.User{}, nil).Once()
+		userRepo.On("GetByUsername", mock.Anything, "jane_doe").Return(userEntity.User{}, nil).Once()
+		identityRepo.On("CreateUserWithIdentity", mock.Anything, mock.MatchedBy(func(u userEntity.User) bool {
+			return u.Username == "jane_doe" && u.Name == "Jane Doe" && u.Password == "" && !u.EmailVerifiedAt.IsZero()
+		}), entity.Identity{Provider: "mock", Subject: "subject-1", Email: mockClaims.Email}).Return(mockUser, nil).Once()
+		sessions.On("Issue", mock.Anything, mockUser).Return(userDTO.LoginResponse{Token: "token"}, nil).Once()
+
+		res, err := newTestLoginUsecase(identityRepo, userRepo, sessions, provider).Login(context.Background(), "mock", mockCallback)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "token", res.Token)
+	})
+
+	t.Run("provision-username-taken", func(t *testing.T) {
+		identityRepo.On("ConsumeAuthState", mock.Anything, utils.HashToken("state")).Return(mockAuthState, nil).Once()
+		provider.On("Exchange", mock.Anything, "code", "verifier", "nonce").Return(mockClaims, nil).Once()
+		identityRepo.On("GetIdentity", mock.Anything, "mock", "subject-1").Return(entity.Identity{}, utils.ErrNotFound).Once()
+		userRepo.On("GetByEmail", mock.Anything, mockClaims.Email).Return(userEntity.User{}, nil).Once()
+		userRepo.On("GetByUsername", mock.Anything, "jane_doe").Return(mockUser, nil).Once()
+		userRepo.On("GetByUsername", mock.Anything, mock.AnythingOfType("string")).Return(userEntity.User{}, nil).Once()
+		identityRepo.On("CreateUserWithIdentity", mock.Anything, mock.MatchedBy(func(u userEntity.User) bool {
+			return len(u.Username) == len("jane_doe")+4 && u.Username[:8] == "jane_doe"
+		}), mock.Anything).Return(mockUser, nil).Once()
+		sessions.On("Issue", mock.Anything, mockUser).Return(userDTO.LoginResponse{Token: "token"}, nil).Once()
+
+		_, err := newTestLoginUsecase(identityRepo, userRepo, sessions, provider).Login(context.Background(), "mock", mockCallback)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("two-factor-challenge", func(t *testing.T) {
+		identityRepo.On("ConsumeAuthState", mock.Anything, utils.HashToken("state")).Return(mockAuthState, nil).Once()
+		provider.On("Exchange", mock.Anything, "code", "verifier", "nonce").Return(mockClaims, nil).Once()
+		identityRepo.On("GetIdentity", mock.Anything, "mock", "subject-1").Return(entity.Identity{ID: 1, UserID: 1}, nil).Once()
+		userRepo.On("Get", mock.Anything, uint(1)).Return(mockUser, nil).Once()
+		sessions.On("Issue", mock.Anything, mockUser).Return(userDTO.LoginResponse{TwoFactorRequired: true, ChallengeToken: "challenge"}, nil).Once()
+
+		res, err := newTestLoginUsecase(identityRepo, userRepo, sessions, provider).Login(context.Background(), "mock", mockCallback)
+
+		assert.NoError(t, err)
+		assert.Empty(t, res.Token)
+		assert.Equal(t, "challenge", res.ChallengeToken)
+	})
+
+	t.Run("email-already-used", func(t *testing.T) {
+		identityRepo.On("ConsumeAuthState", mock.Anything, utils.HashToken("state")).Return(mockAuthState, nil).Once()
+		provider.On("Exchange", mock.Anything, "code", "verifier", "nonce").Return(mockClaims, nil).Once()
+		identityRepo.On("GetIdentity", mock.Anything, "mock", "subject-1").Return(entity.Identity{}, utils.ErrNotFound).Once()
+		userRepo.On("GetByEmail", mock.Anything, mockClaims.Email).Return(mockUser, nil).Once()
+
+		_, err := newTestLoginUsecase(identityRepo, userRepo, sessions, provider).Login(context.Background(), "mock", mockCallback)
+
+		assert.Equal(t, utils.ErrEmailAlreadyUsed, err)
+	})
+
+	t.Run("bad-param-input", func(t *testing.T) {
+		_, err := newTestLoginUsecase(identityRepo, userRepo, sessions, provider).Login(context.Background(), "mock", dto.CallbackRequest{})
+
+		assert.Equal(t, utils.ErrBadParamInput, err)
+	})
+
+	t.Run("unknown-state", func(t *testing.T) {
+		identityRepo.On("ConsumeAuthState", mock.Anything, utils.HashToken("state")).Return(entity.AuthState{}, utils.ErrNotFound).Once()
+
+		_, err := newTestLoginUsecase(identityRepo, userRepo, sessions, provider).Login(context.Background(), "mock", mockCallback)
+
+		assert.Equal(t, utils.ErrInvalidToken, err)
+	})
+
+	t.Run("expired-state", func(t *testing.T) {
+		expired := mockAuthState
+		expired.ExpiredAt = time.Now().Add(-time.Second)
+		identityRepo.On("ConsumeAuthState", mock.Anything, utils.HashToken("state")).Return(expired, nil).Once()
+
+		_, err := newTestLoginUsecase(identityRepo, userRepo, sessions, provider).Login(context.Background(), "mock", mockCallback)
+
+		assert.Equal(t, utils.ErrInvalidToken, err)
+	})
+
+	t.Run("link-state-used-for-login", func(t *testing.T) {
+		linkState := mockAuthState
+		linkState.UserID = 1
+		identityRepo.On("ConsumeAuthState", mock.Anything, utils.HashToken("state")).Return(linkState, nil).Once()
+
+		_, err := newTestLoginUsecase(identityRepo, userRepo, sessions, provider).Login(context.Background(), "mock", mockCallback)
+
+		assert.Equal(t, utils.ErrInvalidToken, err)
+	})
+
+	t.Run("exchange-failed", func(t *testing.T) {
+		identityRepo.On("ConsumeAuthState", mock.Anything, utils.HashToken("state")).Return(mockAuthState, nil).Once()
+		provider.On("Exchange", mock.Anything, "code", "verifier", "nonce").Return(oidc.Claims{}, oidc.ErrNonceMismatch).Once()
+
+		_, err := newTestLoginUsecase(identityRepo, userRepo, sessions, provider).Login(context.Background(), "mock", mockCallback)
+
+		assert.Equal(t, utils.ErrExternalLoginFailed, err)
+	})
+}
+
+func TestLink(t *testing.T) {
+	identityRepo := mocks.NewIdentityRepository(t)
+	provider := newMockProvider(t)
+
+	linkState := mockAuthState
+	linkState.UserID = 1
+
+	t.Run("success", func(t *testing.T) {
+		identityRepo.On("ConsumeAuthState", mock.Anything, utils.HashToken("state")).Return(linkState, nil).Once()
+		provider.On("Exchange", mock.Anything, "code", "verifier", "nonce").Return(mockClaims, nil).Once()
+		identityRepo.On("GetIdentity", mock.Anything, "mock", "subject-1").Return(entity.Identity{}, utils.ErrNotFound).Once()
+		identityRepo.On("StoreIdentity", mock.Anything, entity.Identity{UserID: 1, Provider: "mock", Subject: "subject-1", Email: mockClaims.Email}).
+			Return(entity.Identity{ID: 3, UserID: 1, Provider: "mock"}, nil).Once()
+
+		res, err := newTestUsecase(identityRepo, nil, provider).Link(context.Background(), 1, "mock", mockCallback)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(3), res.ID)
+	})
+
+	t.Run("already-linked-to-other-user", func(t *testing.T) {
+		identityRepo.On("ConsumeAuthState", mock.Anything, utils.HashToken("state")).Return(linkState, nil).Once()
+		provider.On("Exchange", mock.Anything, "code", "verifier", "nonce").Return(mockClaims, nil).Once()
+		identityRepo.On("GetIdentity", mock.Anything, "mock", "subject-1").Return(entity.Identity{ID: 3, UserID: 2}, nil).Once()
+
+		_, err := newTestUsecase(identityRepo, nil, provider).Link(context.Background(), 1, "mock", mockCallback)
+
+		assert.Equal(t, utils.ErrIdentityAlreadyLinked, err)
+	})
+
+	t.Run("state-of-other-user", func(t *testing.T) {
+		identityRepo.On("ConsumeAuthState", mock.Anything, utils.HashToken("state")).Return(linkState, nil).Once()
+
+		_, err := newTestUsecase(identityRepo, nil, provider).Link(context.Background(), 2, "mock", mockCallback)
+
+		assert.Equal(t, utils.ErrInvalidToken, err)
+	})
+}
+
+func TestUnlink(t *testing.T) {
+	identityRepo := mocks.NewIdentityRepository(t)
+	userRepo := userMocks.NewUserRepository(t)
+
+	t.Run("success", func(t *testing.T) {
+		identityRepo.On("GetIdentities", mock.Anything, uint(1)).Return([]entity.Identity{{ID: 3, UserID: 1}}, nil).Once()
+		withPassword := mockUser
+		withPassword.Password = "hash"
+		userRepo.On("Get", mock.Anything, uint(1)).Return(withPassword, nil).Once()
+		identityRepo.On("DeleteIdentity", mock.Anything, uint(1), uint(3)).Return(nil).Once()
+
+		err := newTestUsecase(identityRepo, userRepo, newMockProvider(t)).Unlink(context.Background(), 1, 3)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("last-login-method", func(t *testing.T) {
+		identityRepo.On("GetIdentities", mock.Anything, uint(1)).Return([]entity.Identity{{ID: 3, UserID: 1}}, nil).Once()
+		userRepo.On("Get", mock.Anything, uint(1)).Return(mockUser, nil).Once()
+
+		err := newTestUsecase(identityRepo, userRepo, newMockProvider(t)).Unlink(context.Background(), 1, 3)
+
+		assert.Equal(t, utils.ErrLastLoginMethod, err)
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		identityRepo.On("GetIdentities", mock.Anything, uint(1)).Return([]entity.Identity{{ID: 3, UserID: 1}}, nil).Once()
+
+		err := newTestUsecase(identityRepo, userRepo, newMockProvider(t)).Unlink(context.Background(), 1, 4)
+
+		assert.Equal(t, utils.ErrNotFound, err)
+	})
+}
+
+func TestBaseUsername(t *testing.T) {
+	assert.Equal(t, "jane_doe", baseUsername(oidc.Claims{PreferredUsername: "Jane.Doe"}))
+	assert.Equal(t, "john", baseUsername(oidc.Claims{Email: "john@example.com"}))
+	assert.Equal(t, "userjo", baseUsername(oidc.Claims{Email: "jo@example.com"}))
+	assert.Equal(t, "averyveryverylongnam", baseUsername(oidc.Claims{PreferredUsername: "averyveryverylongname_with_suffix"}))
+}
+
+func TestSuffixedUsername(t *testing.T) {
+	assert.Equal(t, "jane_doe1234", suffixedUsername("jane_doe", 1234))
+	assert.Equal(t, "averyveryverylon1234", suffixedUsername("averyveryverylongnam", 1234))
+	assert.Len(t, suffixedUsername("averyveryverylongnam", 9999), USERNAME_MAX_LENGTH)
+}
@@ -0,0 +1,64 @@
+package http
+
+import (
+	"macaiki/internal/job"
+	_middL "macaiki/pkg/middleware"
+	"macaiki/pkg/response"
+	"macaiki/pkg/utils"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+type JobHandler struct {
+	jobUsecase job.JobUsecase
+	JWTSecret  string
+}
+
+func NewJobHandler(e *echo.Echo, jobUsecase job.JobUsecase, JWTSecret string) {
+	jobHandler := JobHandler{jobUsecase, JWTSecret}
+	e.GET("/api/v1/admin/jobs/failed", jobHandler.GetFailedJobs, middleware.JWT([]byte(JWTSecret)))
+	e.GET("/api/v1/admin/jobs/:jobID", jobHandler.GetJob, middleware.JWT([]byte(JWTSecret)))
+	e.POST("/api/v1/admin/jobs/:jobID/retry", jobHandler.RetryJob, middleware.JWT([]byte(JWTSecret)))
+}
+
+func (jobHandler *JobHandler) GetFailedJobs(c echo.Context) error {
+	_, role := _middL.ExtractTokenUser(c)
+	dtoResponse, err := jobHandler.jobUsecase.GetFailedJobs(c.Request().Context(), role)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, dtoResponse)
+}
+
+func (jobHandler *JobHandler) GetJob(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("jobID"))
+	if err != nil {
+		return response.ErrorResponse(c, utils.ErrBadParamInput)
+	}
+
+	_, role := _middL.ExtractTokenUser(c)
+	dtoResponse, err := jobHandler.jobUsecase.GetJob(c.Request().Context(), uint(id), role)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, dtoResponse)
+}
+
+func (jobHandler *JobHandler) RetryJob(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("jobID"))
+	if err != nil {
+		return response.ErrorResponse(c, utils.ErrBadParamInput)
+	}
+
+	_, role := _middL.ExtractTokenUser(c)
+	err = jobHandler.jobUsecase.RetryJob(c.Request().Context(), uint(id), role)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, nil)
+}
@@ -0,0 +1,17 @@
+package dto
+
+import "time"
+
+type JobResponse struct {
+	ID          uint       `json:"ID"`
+	Type        string     `json:"type"`
+	Payload     string     `json:"payload"`
+	Status      string     `json:"status"`
+	Attempts    int        `json:"attempts"`
+	MaxAttempts int        `json:"maxAttempts"`
+	LastError   string     `json:"lastError"`
+	RunAt       time.Time  `json:"runAt"`
+	FinishedAt  *time.Time `json:"finishedAt"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+}
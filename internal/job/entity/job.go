@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	StatusPending = "pending"
+	StatusRunning = "running"
+	StatusDone    = "done"
+	// StatusDead marks a job that used up all of its attempts
+	StatusDead = "dead"
+)
+
+type Job struct {
+	gorm.Model
+	Type        string    `gorm:"type:varchar(100);index"`
+	Payload     string    `gorm:"type:text"`
+	Status      string    `gorm:"type:varchar(20);index:idx_job_due,priority:1"`
+	RunAt       time.Time `gorm:"index:idx_job_due,priority:2"`
+	Attempts    int
+	MaxAttempts int
+	LastError   string `gorm:"type:text"`
+	FinishedAt  *time.Time
+}
@@ -0,0 +1,169 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	entity "macaiki/internal/job/entity"
+
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// JobRepository is an autogenerated mock type for the JobRepository type
+type JobRepository struct {
+	mock.Mock
+}
+
+// ClaimDueJobs provides a mock function with given fields: ctx, limit, lease
+func (_m *JobRepository) ClaimDueJobs(ctx context.Context, limit int, lease time.Duration) ([]entity.Job, error) {
+	ret := _m.Called(ctx, limit, lease)
+
+	var r0 []entity.Job
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Duration) []entity.Job); ok {
+		r0 = rf(ctx, limit, lease)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Job)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, time.Duration) error); ok {
+		r1 = rf(ctx, limit, lease)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeadJobs provides a mock function with given fields: ctx
+func (_m *JobRepository) GetDeadJobs(ctx context.Context) ([]entity.Job, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []entity.Job
+	if rf, ok := ret.Get(0).(func(context.Context) []entity.Job); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Job)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetJob provides a mock function with given fields: ctx, jobID
+func (_m *JobRepository) GetJob(ctx context.Context, jobID uint) (entity.Job, error) {
+	ret := _m.Called(ctx, jobID)
+
+	var r0 entity.Job
+	if rf, ok := ret.Get(0).(func(context.Context, uint) entity.Job); ok {
+		r0 = rf(ctx, jobID)
+	} else {
+		r0 = ret.Get(0).(entity.Job)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, jobID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkJobDead provides a mock function with given fields: ctx, jobID, attempt, lastError
+func (_m *JobRepository) MarkJobDead(ctx context.Context, jobID uint, attempt int, lastError string) error {
+	ret := _m.Called(ctx, jobID, attempt, lastError)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, int, string) error); ok {
+		r0 = rf(ctx, jobID, attempt, lastError)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MarkJobDone provides a mock function with given fields: ctx, jobID, attempt
+func (_m *JobRepository) MarkJobDone(ctx context.Context, jobID uint, attempt int) error {
+	ret := _m.Called(ctx, jobID, attempt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, int) error); ok {
+		r0 = rf(ctx, jobID, attempt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RequeueJob provides a mock function with given fields: ctx, jobID
+func (_m *JobRepository) RequeueJob(ctx context.Context, jobID uint) error {
+	ret := _m.Called(ctx, jobID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, jobID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ScheduleRetry provides a mock function with given fields: ctx, jobID, attempt, runAt, lastError
+func (_m *JobRepository) ScheduleRetry(ctx context.Context, jobID uint, attempt int, runAt time.Time, lastError string) error {
+	ret := _m.Called(ctx, jobID, attempt, runAt, lastError)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, int, time.Time, string) error); ok {
+		r0 = rf(ctx, jobID, attempt, runAt, lastError)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StoreJob provides a mock function with given fields: ctx, _a1
+func (_m *JobRepository) StoreJob(ctx context.Context, _a1 entity.Job) error {
+	ret := _m.Called(ctx, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Job) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewJobRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewJobRepository creates a new instance of JobRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewJobRepository(t mockConstructorTestingTNewJobRepository) *JobRepository {
+	mock := &JobRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,88 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	dto "macaiki/internal/job/dto"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// JobUsecase is an autogenerated mock type for the JobUsecase type
+type JobUsecase struct {
+	mock.Mock
+}
+
+// GetFailedJobs provides a mock function with given fields: ctx, role
+func (_m *JobUsecase) GetFailedJobs(ctx context.Context, role string) ([]dto.JobResponse, error) {
+	ret := _m.Called(ctx, role)
+
+	var r0 []dto.JobResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string) []dto.JobResponse); ok {
+		r0 = rf(ctx, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.JobResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetJob provides a mock function with given fields: ctx, jobID, role
+func (_m *JobUsecase) GetJob(ctx context.Context, jobID uint, role string) (dto.JobResponse, error) {
+	ret := _m.Called(ctx, jobID, role)
+
+	var r0 dto.JobResponse
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) dto.JobResponse); ok {
+		r0 = rf(ctx, jobID, role)
+	} else {
+		r0 = ret.Get(0).(dto.JobResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, string) error); ok {
+		r1 = rf(ctx, jobID, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetryJob provides a mock function with given fields: ctx, jobID, role
+func (_m *JobUsecase) RetryJob(ctx context.Context, jobID uint, role string) error {
+	ret := _m.Called(ctx, jobID, role)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) error); ok {
+		r0 = rf(ctx, jobID, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewJobUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewJobUsecase creates a new instance of JobUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewJobUsecase(t mockConstructorTestingTNewJobUsecase) *JobUsecase {
+	mock := &JobUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,59 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Queue is an autogenerated mock type for the Queue type
+type Queue struct {
+	mock.Mock
+}
+
+// Enqueue provides a mock function with given fields: ctx, jobType, payload
+func (_m *Queue) Enqueue(ctx context.Context, jobType string, payload interface{}) error {
+	ret := _m.Called(ctx, jobType, payload)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}) error); ok {
+		r0 = rf(ctx, jobType, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnqueueAt provides a mock function with given fields: ctx, jobType, payload, runAt
+func (_m *Queue) EnqueueAt(ctx context.Context, jobType string, payload interface{}, runAt time.Time) error {
+	ret := _m.Called(ctx, jobType, payload, runAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Time) error); ok {
+		r0 = rf(ctx, jobType, payload, runAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewQueue interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewQueue creates a new instance of Queue. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewQueue(t mockConstructorTestingTNewQueue) *Queue {
+	mock := &Queue{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,19 @@
+package job
+
+import (
+	"context"
+	"time"
+)
+
+// Queue is what usecases enqueue background work into. The payload is
+// serialized as JSON and handed back to the handler registered for jobType.
+type Queue interface {
+	Enqueue(ctx context.Context, jobType string, payload interface{}) error
+	// EnqueueAt delays the job until runAt, the handler should check that
+	// the work is still wanted when it runs
+	EnqueueAt(ctx context.Context, jobType string, payload interface{}, runAt time.Time) error
+}
+
+// HandlerFunc processes a single job payload, returning an error schedules a
+// retry until the job runs out of attempts
+type HandlerFunc func(ctx context.Context, payload []byte) error
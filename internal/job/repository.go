@@ -0,0 +1,20 @@
+package job
+
+import (
+	"context"
+	"macaiki/internal/job/entity"
+	"time"
+)
+
+type JobRepository interface {
+	StoreJob(ctx context.Context, job entity.Job) error
+	ClaimDueJobs(ctx context.Context, limit int, lease time.Duration) ([]entity.Job, error)
+	// MarkJobDone, ScheduleRetry and MarkJobDead record the outcome of the
+	// given attempt, they return ErrNotFound once its lease has been lost
+	MarkJobDone(ctx context.Context, jobID uint, attempt int) error
+	ScheduleRetry(ctx context.Context, jobID uint, attempt int, runAt time.Time, lastError string) error
+	MarkJobDead(ctx context.Context, jobID uint, attempt int, lastError string) error
+	GetJob(ctx context.Context, jobID uint) (entity.Job, error)
+	GetDeadJobs(ctx context.Context) ([]entity.Job, error)
+	RequeueJob(ctx context.Context, jobID uint) error
+}
@@ -0,0 +1,174 @@
+package mysql
+
+import (
+	"context"
+	"log/slog"
+	"macaiki/internal/job"
+	"macaiki/internal/job/entity"
+	"macaiki/pkg/logger"
+	"macaiki/pkg/utils"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type JobRepositoryImpl struct {
+	db     *gorm.DB
+	logger *slog.Logger
+}
+
+func NewJobRepository(db *gorm.DB, log *slog.Logger) job.JobRepository {
+	return &JobRepositoryImpl{db, logger.OrDefault(log)}
+}
+
+func (jr *JobRepositoryImpl) StoreJob(ctx context.Context, job entity.Job) error {
+	res := jr.db.WithContext(ctx).Create(&job)
+	if res.Error != nil {
+		jr.logger.ErrorContext(ctx, "query failed", "op", "StoreJob", "err", res.Error)
+		return utils.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// ClaimDueJobs leases up to limit due jobs and counts the attempt. Jobs left
+// in "running" by a crashed worker become due again once the lease runs out,
+// a job whose next attempt would go past MaxAttempts is marked dead instead
+// so a payload that takes the process down cannot be claimed forever.
+func (jr *JobRepositoryImpl) ClaimDueJobs(ctx context.Context, limit int, lease time.Duration) ([]entity.Job, error) {
+	jobs := []entity.Job{}
+	now := time.Now()
+
+	err := jr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		due := []entity.Job{}
+		res := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status IN ? AND run_at <= ?", []string{entity.StatusPending, entity.StatusRunning}, now).
+			Order("run_at").
+			Limit(limit).
+			Find(&due)
+		if res.Error != nil || len(due) == 0 {
+			return res.Error
+		}
+
+		ids, deadIDs := []uint{}, []uint{}
+		for _, j := range due {
+			if j.Attempts >= j.MaxAttempts {
+				jr.logger.WarnContext(ctx, "job is dead", "job_id", j.ID, "type", j.Type, "attempts", j.Attempts)
+				deadIDs = append(deadIDs, j.ID)
+				continue
+			}
+
+			j.Status = entity.StatusRunning
+			j.Attempts++
+			ids = append(ids, j.ID)
+			jobs = append(jobs, j)
+		}
+
+		if len(deadIDs) > 0 {
+			err := tx.Model(&entity.Job{}).Where("id IN ?", deadIDs).Updates(map[string]interface{}{
+				"status":      entity.StatusDead,
+				"last_error":  "job did not finish within its attempts",
+				"finished_at": now,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		return tx.Model(&entity.Job{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+			"status":   entity.StatusRunning,
+			"run_at":   now.Add(lease),
+			"attempts": gorm.Expr("attempts + 1"),
+		}).Error
+	})
+	if err != nil {
+		jr.logger.ErrorContext(ctx, "query failed", "op", "ClaimDueJobs", "err", err)
+		return []entity.Job{}, utils.ErrInternalServerError
+	}
+
+	return jobs, nil
+}
+
+func (jr *JobRepositoryImpl) MarkJobDone(ctx context.Context, jobID uint, attempt int) error {
+	return jr.finishAttempt(ctx, "MarkJobDone", jobID, attempt, map[string]interface{}{
+		"status":      entity.StatusDone,
+		"last_error":  "",
+		"finished_at": time.Now(),
+	})
+}
+
+func (jr *JobRepositoryImpl) ScheduleRetry(ctx context.Context, jobID uint, attempt int, runAt time.Time, lastError string) error {
+	return jr.finishAttempt(ctx, "ScheduleRetry", jobID, attempt, map[string]interface{}{
+		"status":     entity.StatusPending,
+		"run_at":     runAt,
+		"last_error": lastError,
+	})
+}
+
+func (jr *JobRepositoryImpl) MarkJobDead(ctx context.Context, jobID uint, attempt int, lastError string) error {
+	return jr.finishAttempt(ctx, "MarkJobDead", jobID, attempt, map[string]interface{}{
+		"status":      entity.StatusDead,
+		"last_error":  lastError,
+		"finished_at": time.Now(),
+	})
+}
+
+// finishAttempt records the outcome only while the job is still leased to the
+// given attempt. Once the lease expired another runner may have claimed the
+// job again and its outcome wins, ErrNotFound reports the lost lease.
+func (jr *JobRepositoryImpl) finishAttempt(ctx context.Context, op string, jobID uint, attempt int, updates map[string]interface{}) error {
+	res := jr.db.WithContext(ctx).Model(&entity.Job{}).
+		Where("id = ? AND status = ? AND attempts = ?", jobID, entity.StatusRunning, attempt).
+		Updates(updates)
+	if res.Error != nil {
+		jr.logger.ErrorContext(ctx, "query failed", "op", op, "err", res.Error)
+		return utils.ErrInternalServerError
+	}
+	if res.RowsAffected == 0 {
+		return utils.ErrNotFound
+	}
+
+	return nil
+}
+
+func (jr *JobRepositoryImpl) GetJob(ctx context.Context, jobID uint) (entity.Job, error) {
+	job := entity.Job{}
+	res := jr.db.WithContext(ctx).Find(&job, jobID)
+	if res.Error != nil {
+		jr.logger.ErrorContext(ctx, "query failed", "op", "GetJob", "err", res.Error)
+		return entity.Job{}, utils.ErrInternalServerError
+	}
+
+	return job, nil
+}
+
+func (jr *JobRepositoryImpl) GetDeadJobs(ctx context.Context) ([]entity.Job, error) {
+	jobs := []entity.Job{}
+	res := jr.db.WithContext(ctx).Where("status = ?", entity.StatusDead).Order("updated_at desc").Find(&jobs)
+	if res.Error != nil {
+		jr.logger.ErrorContext(ctx, "query failed", "op", "GetDeadJobs", "err", res.Error)
+		return []entity.Job{}, utils.ErrInternalServerError
+	}
+
+	return jobs, nil
+}
+
+// RequeueJob gives a dead job a fresh set of attempts
+func (jr *JobRepositoryImpl) RequeueJob(ctx context.Context, jobID uint) error {
+	res := jr.db.WithContext(ctx).Model(&entity.Job{}).Where("id = ?", jobID).Updates(map[string]interface{}{
+		"status":      entity.StatusPending,
+		"attempts":    0,
+		"run_at":      time.Now(),
+		"finished_at": nil,
+	})
+	if res.Error != nil {
+		jr.logger.ErrorContext(ctx, "query failed", "op", "RequeueJob", "err", res.Error)
+		return utils.ErrInternalServerError
+	}
+
+	return nil
+}
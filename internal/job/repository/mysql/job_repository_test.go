@@ -0,0 +1,114 @@
+package mysql
+
+import (
+	"context"
+	"macaiki/internal/job/entity"
+	"macaiki/pkg/utils"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func newTestJobRepository(t *testing.T) (*JobRepositoryImpl, sqlmock.Sqlmock) {
+	mockedDB, mockObj, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	t.Cleanup(func() { mockedDB.Close() })
+
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening gorm", err)
+	}
+
+	return NewJobRepository(db, nil).(*JobRepositoryImpl), mockObj
+}
+
+func TestClaimDueJobs(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		jobRepo, mockObj := newTestJobRepository(t)
+
+		rows := sqlmock.NewRows([]string{"id", "type", "status", "attempts", "max_attempts"}).
+			AddRow(1, "test.job", entity.StatusPending, 0, 5).
+			AddRow(2, "test.job", entity.StatusRunning, 2, 5)
+
+		mockObj.ExpectBegin()
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `jobs`")).WillReturnRows(rows)
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `jobs` SET `attempts`=attempts + 1,`run_at`=?,`status`=?")).
+			WithArgs(sqlmock.AnyArg(), entity.StatusRunning, sqlmock.AnyArg(), 1, 2).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mockObj.ExpectCommit()
+
+		jobs, err := jobRepo.ClaimDueJobs(context.Background(), 4, time.Minute)
+		assert.NoError(t, err)
+		assert.Len(t, jobs, 2)
+		assert.Equal(t, 3, jobs[1].Attempts)
+		assert.Equal(t, entity.StatusRunning, jobs[0].Status)
+		assert.NoError(t, mockObj.ExpectationsWereMet())
+	})
+
+	t.Run("out-of-attempts", func(t *testing.T) {
+		jobRepo, mockObj := newTestJobRepository(t)
+
+		// job 2 took the process down on its last attempt
+		rows := sqlmock.NewRows([]string{"id", "type", "status", "attempts", "max_attempts"}).
+			AddRow(1, "test.job", entity.StatusPending, 0, 5).
+			AddRow(2, "test.job", entity.StatusRunning, 5, 5)
+
+		mockObj.ExpectBegin()
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `jobs`")).WillReturnRows(rows)
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `jobs` SET `finished_at`=?,`last_error`=?,`status`=?")).
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), entity.StatusDead, sqlmock.AnyArg(), 2).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `jobs` SET `attempts`=attempts + 1")).
+			WithArgs(sqlmock.AnyArg(), entity.StatusRunning, sqlmock.AnyArg(), 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockObj.ExpectCommit()
+
+		jobs, err := jobRepo.ClaimDueJobs(context.Background(), 4, time.Minute)
+		assert.NoError(t, err)
+		assert.Len(t, jobs, 1)
+		assert.Equal(t, uint(1), jobs[0].ID)
+		assert.NoError(t, mockObj.ExpectationsWereMet())
+	})
+}
+
+func TestMarkJobDone(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		jobRepo, mockObj := newTestJobRepository(t)
+
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `jobs` SET")).
+			WithArgs(sqlmock.AnyArg(), "", entity.StatusDone, sqlmock.AnyArg(), 1, entity.StatusRunning, 2).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockObj.ExpectCommit()
+
+		err := jobRepo.MarkJobDone(context.Background(), 1, 2)
+		assert.NoError(t, err)
+		assert.NoError(t, mockObj.ExpectationsWereMet())
+	})
+
+	t.Run("lease-lost", func(t *testing.T) {
+		jobRepo, mockObj := newTestJobRepository(t)
+
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `jobs` SET")).
+			WithArgs(sqlmock.AnyArg(), "", entity.StatusDone, sqlmock.AnyArg(), 1, entity.StatusRunning, 2).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mockObj.ExpectCommit()
+
+		err := jobRepo.MarkJobDone(context.Background(), 1, 2)
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+		assert.NoError(t, mockObj.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,218 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"macaiki/internal/job"
+	"macaiki/internal/job/entity"
+	"macaiki/pkg/logger"
+	"macaiki/pkg/tracing"
+	"macaiki/pkg/utils"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type Config struct {
+	Workers      int
+	PollInterval time.Duration
+	MaxAttempts  int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	// Lease bounds a single handler run, a claimed job is not picked up
+	// again by another runner before it expires
+	Lease time.Duration
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Workers:      4,
+		PollInterval: 2 * time.Second,
+		MaxAttempts:  5,
+		BaseBackoff:  10 * time.Second,
+		MaxBackoff:   30 * time.Minute,
+		Lease:        5 * time.Minute,
+	}
+}
+
+// Validate rejects settings the runner cannot work with, a runner without
+// workers would never run a job
+func (c Config) Validate() error {
+	switch {
+	case c.Workers <= 0:
+		return fmt.Errorf("job runner needs at least one worker, got %d", c.Workers)
+	case c.PollInterval <= 0:
+		return fmt.Errorf("job runner poll interval must be positive, got %s", c.PollInterval)
+	case c.Lease <= 0:
+		return fmt.Errorf("job runner lease must be positive, got %s", c.Lease)
+	case c.MaxAttempts <= 0:
+		return fmt.Errorf("job runner needs at least one attempt, got %d", c.MaxAttempts)
+	}
+	return nil
+}
+
+// Runner is a MySQL backed job queue processed by a fixed pool of workers
+type Runner struct {
+	repo     job.JobRepository
+	config   Config
+	handlers map[string]job.HandlerFunc
+	logger   *slog.Logger
+	mu       sync.RWMutex
+	wg       sync.WaitGroup
+}
+
+func NewRunner(repo job.JobRepository, config Config, log *slog.Logger) *Runner {
+	return &Runner{
+		repo:     repo,
+		config:   config,
+		handlers: map[string]job.HandlerFunc{},
+		logger:   logger.OrDefault(log),
+	}
+}
+
+// Register binds a handler to a job type, it must be called before Run
+func (r *Runner) Register(jobType string, handler job.HandlerFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.handlers[jobType] = handler
+}
+
+func (r *Runner) Enqueue(ctx context.Context, jobType string, payload interface{}) error {
+	return r.EnqueueAt(ctx, jobType, payload, time.Now())
+}
+
+func (r *Runner) EnqueueAt(ctx context.Context, jobType string, payload interface{}, runAt time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return r.repo.StoreJob(ctx, entity.Job{
+		Type:        jobType,
+		Payload:     string(data),
+		Status:      entity.StatusPending,
+		RunAt:       runAt,
+		MaxAttempts: r.config.MaxAttempts,
+	})
+}
+
+// Run polls for due jobs until ctx is cancelled, then waits for the jobs
+// that are already running to finish before returning
+func (r *Runner) Run(ctx context.Context) {
+	slots := make(chan struct{}, r.config.Workers)
+	ticker := time.NewTicker(r.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		r.dispatch(ctx, slots)
+
+		select {
+		case <-ctx.Done():
+			r.wg.Wait()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dispatch only claims as many jobs as there are idle workers so nothing is
+// left leased but unstarted when the runner shuts down
+func (r *Runner) dispatch(ctx context.Context, slots chan struct{}) {
+	free := cap(slots) - len(slots)
+	if free == 0 || ctx.Err() != nil {
+		return
+	}
+
+	jobs, err := r.repo.ClaimDueJobs(ctx, free, r.config.Lease)
+	if err != nil {
+		r.logger.Error("failed to claim jobs", "err", err)
+		return
+	}
+
+	for _, j := range jobs {
+		slots <- struct{}{}
+		r.wg.Add(1)
+		go func(j entity.Job) {
+			defer func() {
+				<-slots
+				r.wg.Done()
+			}()
+			r.process(j)
+		}(j)
+	}
+}
+
+// process records the outcome with a fresh context so results are persisted
+// even when the runner is shutting down
+func (r *Runner) process(j entity.Job) {
+	err := r.execute(j)
+	ctx := context.Background()
+
+	switch {
+	case err == nil:
+		err = r.repo.MarkJobDone(ctx, j.ID, j.Attempts)
+	case j.Attempts >= j.MaxAttempts:
+		r.logger.Warn("job is dead", "job_id", j.ID, "type", j.Type, "attempts", j.Attempts, "err", err)
+		err = r.repo.MarkJobDead(ctx, j.ID, j.Attempts, err.Error())
+	default:
+		r.logger.Debug("job failed, retrying", "job_id", j.ID, "type", j.Type, "attempts", j.Attempts, "err", err)
+		err = r.repo.ScheduleRetry(ctx, j.ID, j.Attempts, time.Now().Add(r.backoff(j.Attempts)), err.Error())
+	}
+
+	switch {
+	case errors.Is(err, utils.ErrNotFound):
+		r.logger.Warn("job lease lost", "job_id", j.ID, "type", j.Type, "attempts", j.Attempts)
+	case err != nil:
+		r.logger.Error("failed to update job", "job_id", j.ID, "err", err)
+	}
+}
+
+// execute runs the handler detached from the runner context so a shutdown
+// lets in-flight jobs complete, bounded by the lease
+func (r *Runner) execute(j entity.Job) (err error) {
+	r.mu.RLock()
+	handler, ok := r.handlers[j.Type]
+	r.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("no handler registered for job type %q", j.Type)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.config.Lease)
+	defer cancel()
+
+	ctx, span := tracing.Start(ctx, "job "+j.Type, trace.WithAttributes(
+		attribute.Int64("job.id", int64(j.ID)),
+		attribute.Int("job.attempt", j.Attempts),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("panic: %v", rec)
+		}
+	}()
+
+	return handler(ctx, []byte(j.Payload))
+}
+
+func (r *Runner) backoff(attempts int) time.Duration {
+	delay := r.config.BaseBackoff
+	for i := 1; i < attempts && delay < r.config.MaxBackoff; i++ {
+		delay *= 2
+	}
+
+	if delay > r.config.MaxBackoff {
+		return r.config.MaxBackoff
+	}
+	return delay
+}
@@ -0,0 +1,167 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"macaiki/internal/job/entity"
+	"macaiki/internal/job/mocks"
+	"macaiki/pkg/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func newTestRunner(t *testing.T) (*Runner, *mocks.JobRepository) {
+	repo := mocks.NewJobRepository(t)
+	config := DefaultConfig()
+	config.BaseBackoff = time.Second
+	config.MaxBackoff = 4 * time.Second
+
+	return NewRunner(repo, config, nil), repo
+}
+
+func TestEnqueue(t *testing.T) {
+	r, repo := newTestRunner(t)
+
+	repo.On("StoreJob", mock.Anything, mock.MatchedBy(func(j entity.Job) bool {
+		return j.Type == "test.job" && j.Payload == `{"ID":1}` && j.Status == entity.StatusPending && j.MaxAttempts == 5
+	})).Return(nil).Once()
+
+	err := r.Enqueue(context.Background(), "test.job", struct{ ID int }{1})
+	assert.NoError(t, err)
+}
+
+func TestEnqueueAt(t *testing.T) {
+	r, repo := newTestRunner(t)
+	runAt := time.Now().Add(24 * time.Hour)
+
+	repo.On("StoreJob", mock.Anything, mock.MatchedBy(func(j entity.Job) bool {
+		return j.Type == "test.job" && j.Status == entity.StatusPending && j.RunAt.Equal(runAt)
+	})).Return(nil).Once()
+
+	err := r.EnqueueAt(context.Background(), "test.job", struct{ ID int }{1}, runAt)
+	assert.NoError(t, err)
+}
+
+func TestProcess(t *testing.T) {
+	failing := errors.New("boom")
+
+	t.Run("done", func(t *testing.T) {
+		r, repo := newTestRunner(t)
+		r.Register("test.job", func(ctx context.Context, payload []byte) error { return nil })
+		repo.On("MarkJobDone", mock.Anything, uint(1), 1).Return(nil).Once()
+
+		r.process(entity.Job{Model: gorm.Model{ID: 1}, Type: "test.job", Attempts: 1, MaxAttempts: 5})
+	})
+
+	t.Run("retry", func(t *testing.T) {
+		r, repo := newTestRunner(t)
+		r.Register("test.job", func(ctx context.Context, payload []byte) error { return failing })
+		repo.On("ScheduleRetry", mock.Anything, uint(1), 1, mock.AnythingOfType("time.Time"), "boom").Return(nil).Once()
+
+		r.process(entity.Job{Model: gorm.Model{ID: 1}, Type: "test.job", Attempts: 1, MaxAttempts: 5})
+	})
+
+	t.Run("dead", func(t *testing.T) {
+		r, repo := newTestRunner(t)
+		r.Register("test.job", func(ctx context.Context, payload []byte) error { return failing })
+		repo.On("MarkJobDead", mock.Anything, uint(1), 5, "boom").Return(nil).Once()
+
+		r.process(entity.Job{Model: gorm.Model{ID: 1}, Type: "test.job", Attempts: 5, MaxAttempts: 5})
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		r, repo := newTestRunner(t)
+		r.Register("test.job", func(ctx context.Context, payload []byte) error { panic("oops") })
+		repo.On("MarkJobDead", mock.Anything, uint(1), 1, "panic: oops").Return(nil).Once()
+
+		r.process(entity.Job{Model: gorm.Model{ID: 1}, Type: "test.job", Attempts: 1, MaxAttempts: 1})
+	})
+
+	t.Run("lease-lost", func(t *testing.T) {
+		r, repo := newTestRunner(t)
+		r.Register("test.job", func(ctx context.Context, payload []byte) error { return nil })
+		repo.On("MarkJobDone", mock.Anything, uint(1), 2).Return(utils.ErrNotFound).Once()
+
+		r.process(entity.Job{Model: gorm.Model{ID: 1}, Type: "test.job", Attempts: 2, MaxAttempts: 5})
+	})
+
+	t.Run("no-handler", func(t *testing.T) {
+		r, repo := newTestRunner(t)
+		repo.On("ScheduleRetry", mock.Anything, uint(1), 1, mock.AnythingOfType("time.Time"), `no handler registered for job type "unknown"`).Return(nil).Once()
+
+		r.process(entity.Job{Model: gorm.Model{ID: 1}, Type: "unknown", Attempts: 1, MaxAttempts: 5})
+	})
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *Config)
+		valid  bool
+	}{
+		{name: "default", modify: func(c *Config) {}, valid: true},
+		{name: "no-workers", modify: func(c *Config) { c.Workers = 0 }},
+		{name: "negative-workers", modify: func(c *Config) { c.Workers = -1 }},
+		{name: "no-poll-interval", modify: func(c *Config) { c.PollInterval = 0 }},
+		{name: "no-lease", modify: func(c *Config) { c.Lease = 0 }},
+		{name: "no-attempts", modify: func(c *Config) { c.MaxAttempts = 0 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			tt.modify(&config)
+
+			err := config.Validate()
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	r, _ := newTestRunner(t)
+
+	assert.Equal(t, time.Second, r.backoff(1))
+	assert.Equal(t, 2*time.Second, r.backoff(2))
+	assert.Equal(t, 4*time.Second, r.backoff(3))
+	assert.Equal(t, 4*time.Second, r.backoff(10))
+}
+
+func TestRunDrainsInFlightJobs(t *testing.T) {
+	r, repo := newTestRunner(t)
+	r.config.PollInterval = 10 * time.Millisecond
+
+	started := make(chan struct{})
+	finished := false
+	r.Register("test.job", func(ctx context.Context, payload []byte) error {
+		close(started)
+		time.Sleep(50 * time.Millisecond)
+		finished = true
+		return nil
+	})
+
+	repo.On("ClaimDueJobs", mock.Anything, 4, r.config.Lease).Return([]entity.Job{{Model: gorm.Model{ID: 1}, Type: "test.job", Attempts: 1, MaxAttempts: 5}}, nil).Once()
+	repo.On("ClaimDueJobs", mock.Anything, mock.Anything, r.config.Lease).Return([]entity.Job{}, nil)
+	repo.On("MarkJobDone", mock.Anything, uint(1), 1).Return(nil).Once()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		r.Run(ctx)
+		close(done)
+	}()
+
+	<-started
+	cancel()
+	<-done
+
+	assert.True(t, finished)
+}
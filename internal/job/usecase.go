@@ -0,0 +1,12 @@
+package job
+
+import (
+	"context"
+	"macaiki/internal/job/dto"
+)
+
+type JobUsecase interface {
+	GetFailedJobs(ctx context.Context, role string) ([]dto.JobResponse, error)
+	GetJob(ctx context.Context, jobID uint, role string) (dto.JobResponse, error)
+	RetryJob(ctx context.Context, jobID uint, role string) error
+}
@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"context"
+	"macaiki/internal/job"
+	"macaiki/internal/job/dto"
+	"macaiki/internal/job/entity"
+	"macaiki/pkg/utils"
+)
+
+type JobUsecaseImpl struct {
+	jobRepo job.JobRepository
+}
+
+func NewJobUsecase(jobRepo job.JobRepository) job.JobUsecase {
+	return &JobUsecaseImpl{jobRepo: jobRepo}
+}
+
+func (ju *JobUsecaseImpl) GetFailedJobs(ctx context.Context, role string) ([]dto.JobResponse, error) {
+	if role != "Admin" {
+		return []dto.JobResponse{}, utils.ErrUnauthorizedAccess
+	}
+
+	jobs, err := ju.jobRepo.GetDeadJobs(ctx)
+	if err != nil {
+		return []dto.JobResponse{}, utils.ErrInternalServerError
+	}
+
+	dtoJobs := []dto.JobResponse{}
+	for _, val := range jobs {
+		dtoJobs = append(dtoJobs, toJobResponse(val))
+	}
+
+	return dtoJobs, nil
+}
+
+func (ju *JobUsecaseImpl) GetJob(ctx context.Context, jobID uint, role string) (dto.JobResponse, error) {
+	if role != "Admin" {
+		return dto.JobResponse{}, utils.ErrUnauthorizedAccess
+	}
+
+	job, err := ju.jobRepo.GetJob(ctx, jobID)
+	if err != nil {
+		return dto.JobResponse{}, utils.ErrInternalServerError
+	}
+	if job.ID == 0 {
+		return dto.JobResponse{}, utils.ErrNotFound
+	}
+
+	return toJobResponse(job), nil
+}
+
+func (ju *JobUsecaseImpl) RetryJob(ctx context.Context, jobID uint, role string) error {
+	if role != "Admin" {
+		return utils.ErrUnauthorizedAccess
+	}
+
+	job, err := ju.jobRepo.GetJob(ctx, jobID)
+	if err != nil {
+		return utils.ErrInternalServerError
+	}
+	if job.ID == 0 {
+		return utils.ErrNotFound
+	}
+	if job.Status != entity.StatusDead {
+		return utils.ErrBadParamInput
+	}
+
+	err = ju.jobRepo.RequeueJob(ctx, jobID)
+	if err != nil {
+		return utils.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func toJobResponse(job entity.Job) dto.JobResponse {
+	return dto.JobResponse{
+		ID:          job.ID,
+		Type:        job.Type,
+		Payload:     job.Payload,
+		Status:      job.Status,
+		Attempts:    job.Attempts,
+		MaxAttempts: job.MaxAttempts,
+		LastError:   job.LastError,
+		RunAt:       job.RunAt,
+		FinishedAt:  job.FinishedAt,
+		CreatedAt:   job.CreatedAt,
+		UpdatedAt:   job.UpdatedAt,
+	}
+}
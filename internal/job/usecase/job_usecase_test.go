@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"context"
+	"macaiki/internal/job/entity"
+	"macaiki/internal/job/mocks"
+	"macaiki/pkg/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+var (
+	mockedDeadJob = entity.Job{
+		Model:       gorm.Model{ID: 1},
+		Type:        "notification.store",
+		Payload:     `{"UserID":1}`,
+		Status:      entity.StatusDead,
+		Attempts:    5,
+		MaxAttempts: 5,
+		LastError:   "connection refused",
+	}
+
+	mockedDoneJob = entity.Job{
+		Model:  gorm.Model{ID: 2},
+		Type:   "notification.store",
+		Status: entity.StatusDone,
+	}
+)
+
+func TestGetFailedJobs(t *testing.T) {
+	mockedJobRepo := mocks.NewJobRepository(t)
+
+	t.Run("success", func(t *testing.T) {
+		mockedJobRepo.On("GetDeadJobs", mock.Anything).Return([]entity.Job{mockedDeadJob}, nil).Once()
+
+		testJobUsecase := NewJobUsecase(mockedJobRepo)
+		res, err := testJobUsecase.GetFailedJobs(context.Background(), "Admin")
+
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+		assert.Equal(t, mockedDeadJob.LastError, res[0].LastError)
+	})
+
+	t.Run("unauthorized-access", func(t *testing.T) {
+		testJobUsecase := NewJobUsecase(mockedJobRepo)
+		_, err := testJobUsecase.GetFailedJobs(context.Background(), "User")
+
+		assert.Equal(t, utils.ErrUnauthorizedAccess, err)
+	})
+
+	t.Run("internal-server-error", func(t *testing.T) {
+		mockedJobRepo.On("GetDeadJobs", mock.Anything).Return([]entity.Job{}, utils.ErrInternalServerError).Once()
+
+		testJobUsecase := NewJobUsecase(mockedJobRepo)
+		_, err := testJobUsecase.GetFailedJobs(context.Background(), "Admin")
+
+		assert.Equal(t, utils.ErrInternalServerError, err)
+	})
+}
+
+func TestGetJob(t *testing.T) {
+	mockedJobRepo := mocks.NewJobRepository(t)
+
+	t.Run("success", func(t *testing.T) {
+		mockedJobRepo.On("GetJob", mock.Anything, uint(1)).Return(mockedDeadJob, nil).Once()
+
+		testJobUsecase := NewJobUsecase(mockedJobRepo)
+		res, err := testJobUsecase.GetJob(context.Background(), uint(1), "Admin")
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(1), res.ID)
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		mockedJobRepo.On("GetJob", mock.Anything, uint(1)).Return(entity.Job{}, nil).Once()
+
+		testJobUsecase := NewJobUsecase(mockedJobRepo)
+		_, err := testJobUsecase.GetJob(context.Background(), uint(1), "Admin")
+
+		assert.Equal(t, utils.ErrNotFound, err)
+	})
+
+	t.Run("unauthorized-access", func(t *testing.T) {
+		testJobUsecase := NewJobUsecase(mockedJobRepo)
+		_, err := testJobUsecase.GetJob(context.Background(), uint(1), "User")
+
+		assert.Equal(t, utils.ErrUnauthorizedAccess, err)
+	})
+}
+
+func TestRetryJob(t *testing.T) {
+	mockedJobRepo := mocks.NewJobRepository(t)
+
+	t.Run("success", func(t *testing.T) {
+		mockedJobRepo.On("GetJob", mock.Anything, uint(1)).Return(mockedDeadJob, nil).Once()
+		mockedJobRepo.On("RequeueJob", mock.Anything, uint(1)).Return(nil).Once()
+
+		testJobUsecase := NewJobUsecase(mockedJobRepo)
+		err := testJobUsecase.RetryJob(context.Background(), uint(1), "Admin")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("job-not-dead", func(t *testing.T) {
+		mockedJobRepo.On("GetJob", mock.Anything, uint(2)).Return(mockedDoneJob, nil).Once()
+
+		testJobUsecase := NewJobUsecase(mockedJobRepo)
+		err := testJobUsecase.RetryJob(context.Background(), uint(2), "Admin")
+
+		assert.Equal(t, utils.ErrBadParamInput, err)
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		mockedJobRepo.On("GetJob", mock.Anything, uint(1)).Return(entity.Job{}, nil).Once()
+
+		testJobUsecase := NewJobUsecase(mockedJobRepo)
+		err := testJobUsecase.RetryJob(context.Background(), uint(1), "Admin")
+
+		assert.Equal(t, utils.ErrNotFound, err)
+	})
+
+	t.Run("internal-server-error", func(t *testing.T) {
+		mockedJobRepo.On("GetJob", mock.Anything, uint(1)).Return(mockedDeadJob, nil).Once()
+		mockedJobRepo.On("RequeueJob", mock.Anything, uint(1)).Return(utils.ErrInternalServerError).Once()
+
+		testJobUsecase := NewJobUsecase(mockedJobRepo)
+		err := testJobUsecase.RetryJob(context.Background(), uint(1), "Admin")
+
+		assert.Equal(t, utils.ErrInternalServerError, err)
+	})
+
+	t.Run("unauthorized-access", func(t *testing.T) {
+		testJobUsecase := NewJobUsecase(mockedJobRepo)
+		err := testJobUsecase.RetryJob(context.Background(), uint(1), "User")
+
+		assert.Equal(t, utils.ErrUnauthorizedAccess, err)
+	})
+}
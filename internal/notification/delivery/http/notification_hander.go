@@ -26,7 +26,7 @@ func NewNotificationHandler(e *echo.Echo, notifUsecase notification.Notification
 
 func (notifHandler *NotificationHandler) GetAllNotifications(c echo.Context) error {
 	userID, _ := _middL.ExtractTokenUser(c)
-	notifications, err := notifHandler.notifUsecase.GetAllNotifications(uint(userID))
+	notifications, err := notifHandler.notifUsecase.GetAllNotifications(c.Request().Context(), uint(userID))
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -36,7 +36,7 @@ func (notifHandler *NotificationHandler) GetAllNotifications(c echo.Context) err
 
 func (notifHandler *NotificationHandler) ReadAllNotifications(c echo.Context) error {
 	userID, _ := _middL.ExtractTokenUser(c)
-	notifResp, err := notifHandler.notifUsecase.ReadAllNotifications(uint(userID))
+	notifResp, err := notifHandler.notifUsecase.ReadAllNotifications(c.Request().Context(), uint(userID))
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -46,7 +46,7 @@ func (notifHandler *NotificationHandler) ReadAllNotifications(c echo.Context) er
 
 func (notifHandler *NotificationHandler) DeleteAllNotifications(c echo.Context) error {
 	userID, _ := _middL.ExtractTokenUser(c)
-	notifResp, err := notifHandler.notifUsecase.DeleteAllNotifications(uint(userID))
+	notifResp, err := notifHandler.notifUsecase.DeleteAllNotifications(c.Request().Context(), uint(userID))
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -60,7 +60,7 @@ func (notifHandler *NotificationHandler) ReadNotification(c echo.Context) error
 	if err != nil {
 		return response.ErrorResponse(c, utils.ErrBadParamInput)
 	}
-	notifResp, err := notifHandler.notifUsecase.GetNotificatoinDetail(uint(userID), uint(notifID))
+	notifResp, err := notifHandler.notifUsecase.GetNotificatoinDetail(c.Request().Context(), uint(userID), uint(notifID))
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -0,0 +1,5 @@
+package notification
+
+// JobStoreNotification is the job type used to write a notification in the
+// background, its payload is an entity.Notification
+const JobStoreNotification = "notification.store"
@@ -3,6 +3,7 @@
 package mocks
 
 import (
+	context "context"
 	entity "macaiki/internal/notification/entity"
 
 	mock "github.com/stretchr/testify/mock"
@@ -13,13 +14,13 @@ type NotificationRepository struct {
 	mock.Mock
 }
 
-// DeleleteAllNotifications provides a mock function with given fields: userID
-func (_m *NotificationRepository) DeleleteAllNotifications(userID uint) error {
-	ret := _m.Called(userID)
+// DeleleteAllNotifications provides a mock function with given fields: ctx, userID
+func (_m *NotificationRepository) DeleleteAllNotifications(ctx context.Context, userID uint) error {
+	ret := _m.Called(ctx, userID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint) error); ok {
-		r0 = rf(userID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, userID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -27,13 +28,13 @@ func (_m *NotificationRepository) DeleleteAllNotifications(userID uint) error {
 	return r0
 }
 
-// GetAllNotifications provides a mock function with given fields: userID
-func (_m *NotificationRepository) GetAllNotifications(userID uint) ([]entity.Notification, error) {
-	ret := _m.Called(userID)
+// GetAllNotifications provides a mock function with given fields: ctx, userID
+func (_m *NotificationRepository) GetAllNotifications(ctx context.Context, userID uint) ([]entity.Notification, error) {
+	ret := _m.Called(ctx, userID)
 
 	var r0 []entity.Notification
-	if rf, ok := ret.Get(0).(func(uint) []entity.Notification); ok {
-		r0 = rf(userID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []entity.Notification); ok {
+		r0 = rf(ctx, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entity.Notification)
@@ -41,8 +42,8 @@ func (_m *NotificationRepository) GetAllNotifications(userID uint) ([]entity.Not
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint) error); ok {
-		r1 = rf(userID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -50,20 +51,20 @@ func (_m *NotificationRepository) GetAllNotifications(userID uint) ([]entity.Not
 	return r0, r1
 }
 
-// GetNotification provides a mock function with given fields: notificationID
-func (_m *NotificationRepository) GetNotification(notificationID uint) (entity.Notification, error) {
-	ret := _m.Called(notificationID)
+// GetNotification provides a mock function with given fields: ctx, notificationID
+func (_m *NotificationRepository) GetNotification(ctx context.Context, notificationID uint) (entity.Notification, error) {
+	ret := _m.Called(ctx, notificationID)
 
 	var r0 entity.Notification
-	if rf, ok := ret.Get(0).(func(uint) entity.Notification); ok {
-		r0 = rf(notificationID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) entity.Notification); ok {
+		r0 = rf(ctx, notificationID)
 	} else {
 		r0 = ret.Get(0).(entity.Notification)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint) error); ok {
-		r1 = rf(notificationID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, notificationID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -71,13 +72,13 @@ func (_m *NotificationRepository) GetNotification(notificationID uint) (entity.N
 	return r0, r1
 }
 
-// ReadAllNotifications provides a mock function with given fields: userID
-func (_m *NotificationRepository) ReadAllNotifications(userID uint) error {
-	ret := _m.Called(userID)
+// ReadAllNotifications provides a mock function with given fields: ctx, userID
+func (_m *NotificationRepository) ReadAllNotifications(ctx context.Context, userID uint) error {
+	ret := _m.Called(ctx, userID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint) error); ok {
-		r0 = rf(userID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, userID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -85,13 +86,13 @@ func (_m *NotificationRepository) ReadAllNotifications(userID uint) error {
 	return r0
 }
 
-// ReadNotification provides a mock function with given fields: notificationID
-func (_m *NotificationRepository) ReadNotification(notificationID uint) error {
-	ret := _m.Called(notificationID)
+// ReadNotification provides a mock function with given fields: ctx, notificationID
+func (_m *NotificationRepository) ReadNotification(ctx context.Context, notificationID uint) error {
+	ret := _m.Called(ctx, notificationID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint) error); ok {
-		r0 = rf(notificationID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, notificationID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -99,13 +100,13 @@ func (_m *NotificationRepository) ReadNotification(notificationID uint) error {
 	return r0
 }
 
-// StoreNotification provides a mock function with given fields: _a0
-func (_m *NotificationRepository) StoreNotification(_a0 entity.Notification) error {
-	ret := _m.Called(_a0)
+// StoreNotification provides a mock function with given fields: ctx, _a1
+func (_m *NotificationRepository) StoreNotification(ctx context.Context, _a1 entity.Notification) error {
+	ret := _m.Called(ctx, _a1)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(entity.Notification) error); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Notification) error); ok {
+		r0 = rf(ctx, _a1)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -3,6 +3,7 @@
 package mocks
 
 import (
+	context "context"
 	dto "macaiki/internal/notification/dto"
 
 	mock "github.com/stretchr/testify/mock"
@@ -13,13 +14,13 @@ type NotificationUsecase struct {
 	mock.Mock
 }
 
-// DeleteAllNotifications provides a mock function with given fields: userID
-func (_m *NotificationUsecase) DeleteAllNotifications(userID uint) ([]dto.NotificationResponse, error) {
-	ret := _m.Called(userID)
+// DeleteAllNotifications provides a mock function with given fields: ctx, userID
+func (_m *NotificationUsecase) DeleteAllNotifications(ctx context.Context, userID uint) ([]dto.NotificationResponse, error) {
+	ret := _m.Called(ctx, userID)
 
 	var r0 []dto.NotificationResponse
-	if rf, ok := ret.Get(0).(func(uint) []dto.NotificationResponse); ok {
-		r0 = rf(userID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []dto.NotificationResponse); ok {
+		r0 = rf(ctx, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]dto.NotificationResponse)
@@ -27,8 +28,8 @@ func (_m *NotificationUsecase) DeleteAllNotifications(userID uint) ([]dto.Notifi
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint) error); ok {
-		r1 = rf(userID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -36,13 +37,13 @@ func (_m *NotificationUsecase) DeleteAllNotifications(userID uint) ([]dto.Notifi
 	return r0, r1
 }
 
-// GetAllNotifications provides a mock function with given fields: userID
-func (_m *NotificationUsecase) GetAllNotifications(userID uint) ([]dto.NotificationResponse, error) {
-	ret := _m.Called(userID)
+// GetAllNotifications provides a mock function with given fields: ctx, userID
+func (_m *NotificationUsecase) GetAllNotifications(ctx context.Context, userID uint) ([]dto.NotificationResponse, error) {
+	ret := _m.Called(ctx, userID)
 
 	var r0 []dto.NotificationResponse
-	if rf, ok := ret.Get(0).(func(uint) []dto.NotificationResponse); ok {
-		r0 = rf(userID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []dto.NotificationResponse); ok {
+		r0 = rf(ctx, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]dto.NotificationResponse)
@@ -50,8 +51,8 @@ func (_m *NotificationUsecase) GetAllNotifications(userID uint) ([]dto.Notificat
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint) error); ok {
-		r1 = rf(userID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -59,13 +60,13 @@ func (_m *NotificationUsecase) GetAllNotifications(userID uint) ([]dto.Notificat
 	return r0, r1
 }
 
-// GetNotificatoinDetail provides a mock function with given fields: userID, notificationID
-func (_m *NotificationUsecase) GetNotificatoinDetail(userID uint, notificationID uint) (interface{}, error) {
-	ret := _m.Called(userID, notificationID)
+// GetNotificatoinDetail provides a mock function with given fields: ctx, userID, notificationID
+func (_m *NotificationUsecase) GetNotificatoinDetail(ctx context.Context, userID uint, notificationID uint) (interface{}, error) {
+	ret := _m.Called(ctx, userID, notificationID)
 
 	var r0 interface{}
-	if rf, ok := ret.Get(0).(func(uint, uint) interface{}); ok {
-		r0 = rf(userID, notificationID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) interface{}); ok {
+		r0 = rf(ctx, userID, notificationID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(interface{})
@@ -73,8 +74,8 @@ func (_m *NotificationUsecase) GetNotificatoinDetail(userID uint, notificationID
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint, uint) error); ok {
-		r1 = rf(userID, notificationID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, userID, notificationID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -82,13 +83,13 @@ func (_m *NotificationUsecase) GetNotificatoinDetail(userID uint, notificationID
 	return r0, r1
 }
 
-// ReadAllNotifications provides a mock function with given fields: userID
-func (_m *NotificationUsecase) ReadAllNotifications(userID uint) ([]dto.NotificationResponse, error) {
-	ret := _m.Called(userID)
+// ReadAllNotifications provides a mock function with given fields: ctx, userID
+func (_m *NotificationUsecase) ReadAllNotifications(ctx context.Context, userID uint) ([]dto.NotificationResponse, error) {
+	ret := _m.Called(ctx, userID)
 
 	var r0 []dto.NotificationResponse
-	if rf, ok := ret.Get(0).(func(uint) []dto.NotificationResponse); ok {
-		r0 = rf(userID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []dto.NotificationResponse); ok {
+		r0 = rf(ctx, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]dto.NotificationResponse)
@@ -96,8 +97,8 @@ func (_m *NotificationUsecase) ReadAllNotifications(userID uint) ([]dto.Notifica
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint) error); ok {
-		r1 = rf(userID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1,12 +1,15 @@
 package notification
 
-import entity "macaiki/internal/notification/entity"
+import (
+	"context"
+	entity "macaiki/internal/notification/entity"
+)
 
 type NotificationRepository interface {
-	StoreNotification(notification entity.Notification) error
-	GetAllNotifications(userID uint) ([]entity.Notification, error)
-	GetNotification(notificationID uint) (entity.Notification, error)
-	ReadAllNotifications(userID uint) error
-	ReadNotification(notificationID uint) error
-	DeleleteAllNotifications(userID uint) error
+	StoreNotification(ctx context.Context, notification entity.Notification) error
+	GetAllNotifications(ctx context.Context, userID uint) ([]entity.Notification, error)
+	GetNotification(ctx context.Context, notificationID uint) (entity.Notification, error)
+	ReadAllNotifications(ctx context.Context, userID uint) error
+	ReadNotification(ctx context.Context, notificationID uint) error
+	DeleleteAllNotifications(ctx context.Context, userID uint) error
 }
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	notification "macaiki/internal/notification"
 	entity "macaiki/internal/notification/entity"
 
@@ -15,17 +16,17 @@ func NewNotificaionRepository(db *gorm.DB) notification.NotificationRepository {
 	return &NotificationRepositoryImpl{db: db}
 }
 
-func (nr *NotificationRepositoryImpl) StoreNotification(notification entity.Notification) error {
-	res := nr.db.Create(&notification)
+func (nr *NotificationRepositoryImpl) StoreNotification(ctx context.Context, notification entity.Notification) error {
+	res := nr.db.WithContext(ctx).Create(&notification)
 	err := res.Error
 	if err != nil {
 		return err
 	}
 	return nil
 }
-func (nr *NotificationRepositoryImpl) GetAllNotifications(userID uint) ([]entity.Notification, error) {
+func (nr *NotificationRepositoryImpl) GetAllNotifications(ctx context.Context, userID uint) ([]entity.Notification, error) {
 	notifications := []entity.Notification{}
-	res := nr.db.Where("user_id = ?", userID).Order("created_at desc").Find(&notifications)
+	res := nr.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at desc").Find(&notifications)
 	err := res.Error
 	if err != nil {
 		return []entity.Notification{}, err
@@ -33,8 +34,8 @@ func (nr *NotificationRepositoryImpl) GetAllNotifications(userID uint) ([]entity
 	return notifications, nil
 }
 
-func (nr *NotificationRepositoryImpl) ReadAllNotifications(userID uint) error {
-	res := nr.db.Model(&entity.Notification{}).Where("user_id = ?", userID).Update("is_readed", 1)
+func (nr *NotificationRepositoryImpl) ReadAllNotifications(ctx context.Context, userID uint) error {
+	res := nr.db.WithContext(ctx).Model(&entity.Notification{}).Where("user_id = ?", userID).Update("is_readed", 1)
 	err := res.Error
 	if err != nil {
 		return err
@@ -42,8 +43,8 @@ func (nr *NotificationRepositoryImpl) ReadAllNotifications(userID uint) error {
 	return nil
 }
 
-func (nr *NotificationRepositoryImpl) DeleleteAllNotifications(userID uint) error {
-	res := nr.db.Where("user_id = ?", userID).Delete(&entity.Notification{})
+func (nr *NotificationRepositoryImpl) DeleleteAllNotifications(ctx context.Context, userID uint) error {
+	res := nr.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&entity.Notification{})
 	err := res.Error
 	if err != nil {
 		return err
@@ -51,8 +52,8 @@ func (nr *NotificationRepositoryImpl) DeleleteAllNotifications(userID uint) erro
 	return nil
 }
 
-func (nr *NotificationRepositoryImpl) ReadNotification(notificationID uint) error {
-	res := nr.db.Model(&entity.Notification{}).Where("id = ?", notificationID).Update("is_readed", 1)
+func (nr *NotificationRepositoryImpl) ReadNotification(ctx context.Context, notificationID uint) error {
+	res := nr.db.WithContext(ctx).Model(&entity.Notification{}).Where("id = ?", notificationID).Update("is_readed", 1)
 	err := res.Error
 	if err != nil {
 		return err
@@ -61,9 +62,9 @@ func (nr *NotificationRepositoryImpl) ReadNotification(notificationID uint) erro
 	return nil
 }
 
-func (nr *NotificationRepositoryImpl) GetNotification(notificationID uint) (entity.Notification, error) {
+func (nr *NotificationRepositoryImpl) GetNotification(ctx context.Context, notificationID uint) (entity.Notification, error) {
 	notif := entity.Notification{}
-	res := nr.db.Find(&notif, notificationID)
+	res := nr.db.WithContext(ctx).Find(&notif, notificationID)
 	err := res.Error
 	if err != nil {
 		return entity.Notification{}, err
@@ -1,12 +1,13 @@
 package notification
 
 import (
+	"context"
 	"macaiki/internal/notification/dto"
 )
 
 type NotificationUsecase interface {
-	GetAllNotifications(userID uint) ([]dto.NotificationResponse, error)
-	ReadAllNotifications(userID uint) ([]dto.NotificationResponse, error)
-	DeleteAllNotifications(userID uint) ([]dto.NotificationResponse, error)
-	GetNotificatoinDetail(userID, notificationID uint) (interface{}, error)
+	GetAllNotifications(ctx context.Context, userID uint) ([]dto.NotificationResponse, error)
+	ReadAllNotifications(ctx context.Context, userID uint) ([]dto.NotificationResponse, error)
+	DeleteAllNotifications(ctx context.Context, userID uint) ([]dto.NotificationResponse, error)
+	GetNotificatoinDetail(ctx context.Context, userID, notificationID uint) (interface{}, error)
 }
@@ -1,13 +1,15 @@
 package usecase
 
 import (
-	"fmt"
+	"context"
+	"log/slog"
 	notification "macaiki/internal/notification"
 	dtoNotif "macaiki/internal/notification/dto"
 	thread "macaiki/internal/thread"
 	dtoThread "macaiki/internal/thread/dto"
 	user "macaiki/internal/user"
 	dtoUser "macaiki/internal/user/dto"
+	"macaiki/pkg/logger"
 	"macaiki/pkg/utils"
 )
 
@@ -15,22 +17,24 @@ type NotificationUsecaseImpl struct {
 	notifRepo  notification.NotificationRepository
 	userRepo   user.UserRepository
 	threadRepo thread.ThreadRepository
+	logger     *slog.Logger
 }
 
-func NewNotificationUsecase(notifRepo notification.NotificationRepository, userRepo user.UserRepository, threadRepo thread.ThreadRepository) notification.NotificationUsecase {
+func NewNotificationUsecase(notifRepo notification.NotificationRepository, userRepo user.UserRepository, threadRepo thread.ThreadRepository, log *slog.Logger) notification.NotificationUsecase {
 	return &NotificationUsecaseImpl{
 		notifRepo:  notifRepo,
 		userRepo:   userRepo,
 		threadRepo: threadRepo,
+		logger:     logger.OrDefault(log),
 	}
 }
 
-func (nu *NotificationUsecaseImpl) GetAllNotifications(userID uint) ([]dtoNotif.NotificationResponse, error) {
-	notifs, err := nu.notifRepo.GetAllNotifications(userID)
+func (nu *NotificationUsecaseImpl) GetAllNotifications(ctx context.Context, userID uint) ([]dtoNotif.NotificationResponse, error) {
+	notifs, err := nu.notifRepo.GetAllNotifications(ctx, userID)
 	if err != nil {
 		return []dtoNotif.NotificationResponse{}, utils.ErrInternalServerError
 	}
-	user, _ := nu.userRepo.Get(userID)
+	user, _ := nu.userRepo.Get(ctx, userID)
 
 	notifResp := []dtoNotif.NotificationResponse{}
 
@@ -44,6 +48,8 @@ func (nu *NotificationUsecaseImpl) GetAllNotifications(userID uint) ([]dtoNotif.
 		} else if val.NotificationType == "Comment Thread" {
 			// TODO: get comment from thread
 			title += " comment on your thread"
+		} else if val.NotificationType == "Reply Thread" {
+			title += " new comment on a thread you follow"
 		}
 		notifResp = append(notifResp, dtoNotif.NotificationResponse{
 			ID:                 val.ID,
@@ -61,26 +67,26 @@ func (nu *NotificationUsecaseImpl) GetAllNotifications(userID uint) ([]dtoNotif.
 	return notifResp, err
 }
 
-func (nu *NotificationUsecaseImpl) ReadAllNotifications(userID uint) ([]dtoNotif.NotificationResponse, error) {
-	err := nu.notifRepo.ReadAllNotifications(userID)
+func (nu *NotificationUsecaseImpl) ReadAllNotifications(ctx context.Context, userID uint) ([]dtoNotif.NotificationResponse, error) {
+	err := nu.notifRepo.ReadAllNotifications(ctx, userID)
 	if err != nil {
 		return []dtoNotif.NotificationResponse{}, utils.ErrInternalServerError
 	}
 
-	return nu.GetAllNotifications(userID)
+	return nu.GetAllNotifications(ctx, userID)
 }
 
-func (nu *NotificationUsecaseImpl) DeleteAllNotifications(userID uint) ([]dtoNotif.NotificationResponse, error) {
-	err := nu.notifRepo.DeleleteAllNotifications(userID)
+func (nu *NotificationUsecaseImpl) DeleteAllNotifications(ctx context.Context, userID uint) ([]dtoNotif.NotificationResponse, error) {
+	err := nu.notifRepo.DeleleteAllNotifications(ctx, userID)
 	if err != nil {
 		return []dtoNotif.NotificationResponse{}, utils.ErrInternalServerError
 	}
 
-	return nu.GetAllNotifications(userID)
+	return nu.GetAllNotifications(ctx, userID)
 }
 
-func (nu *NotificationUsecaseImpl) GetNotificatoinDetail(userID, notificationID uint) (interface{}, error) {
-	notif, err := nu.notifRepo.GetNotification(notificationID)
+func (nu *NotificationUsecaseImpl) GetNotificatoinDetail(ctx context.Context, userID, notificationID uint) (interface{}, error) {
+	notif, err := nu.notifRepo.GetNotification(ctx, notificationID)
 	if err != nil {
 		return nil, utils.ErrInternalServerError
 	}
@@ -89,14 +95,14 @@ func (nu *NotificationUsecaseImpl) GetNotificatoinDetail(userID, notificationID
 		return nil, utils.ErrUnauthorizedAccess
 	}
 
-	err = nu.notifRepo.ReadNotification(notificationID)
+	err = nu.notifRepo.ReadNotification(ctx, notificationID)
 	if err != nil {
-		fmt.Println("masuk")
+		nu.logger.ErrorContext(ctx, "failed to mark notification as read", "err", err, "notification_id", notificationID)
 		return nil, utils.ErrInternalServerError
 	}
 
 	if notif.NotificationType == "Follow You" {
-		user, err := nu.userRepo.Get(notif.NotificationRefID)
+		user, err := nu.userRepo.Get(ctx, notif.NotificationRefID)
 		if err != nil {
 			return nil, utils.ErrInternalServerError
 		}
@@ -105,9 +111,9 @@ func (nu *NotificationUsecaseImpl) GetNotificatoinDetail(userID, notificationID
 			return nil, utils.ErrNotFound
 		}
 
-		totalFollower, _ := nu.userRepo.GetFollowerNumber(user.ID)
-		totalFollowing, _ := nu.userRepo.GetFollowingNumber(user.ID)
-		totalPost, _ := nu.userRepo.GetThreadsNumber(user.ID)
+		totalFollower, _ := nu.userRepo.GetFollowerNumber(ctx, user.ID)
+		totalFollowing, _ := nu.userRepo.GetFollowingNumber(ctx, user.ID)
+		totalPost, _ := nu.userRepo.GetThreadsNumber(ctx, user.ID)
 		return dtoUser.UserDetailResponse{
 			ID:                 user.ID,
 			Username:           user.Username,
@@ -122,8 +128,8 @@ func (nu *NotificationUsecaseImpl) GetNotificatoinDetail(userID, notificationID
 			IsFollowed:         user.IsFollowed,
 			IsMine:             user.IsMine,
 		}, nil
-	} else if notif.NotificationType == "Upvote Thread" || notif.NotificationType == "Comment Thread" {
-		thread, err := nu.threadRepo.GetThreadByID(notif.NotificationRefID)
+	} else if notif.NotificationType == "Upvote Thread" || notif.NotificationType == "Comment Thread" || notif.NotificationType == "Reply Thread" {
+		thread, err := nu.threadRepo.GetThreadByID(ctx, notif.NotificationRefID)
 		if err != nil {
 			return nil, err
 		}
@@ -131,6 +137,8 @@ func (nu *NotificationUsecaseImpl) GetNotificatoinDetail(userID, notificationID
 			ID:          thread.ID,
 			Title:       thread.Title,
 			Body:        thread.Body,
+			BodyHTML:    thread.BodyHTML,
+			BodyText:    thread.BodyText,
 			CommunityID: thread.CommunityID,
 			ImageURL:    thread.ImageURL,
 			UserID:      thread.UserID,
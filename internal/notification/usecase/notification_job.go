@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"macaiki/internal/job"
+	"macaiki/internal/notification"
+	entity "macaiki/internal/notification/entity"
+	"macaiki/pkg/metrics"
+)
+
+// NewStoreNotificationHandler handles notification.JobStoreNotification jobs
+func NewStoreNotificationHandler(notifRepo notification.NotificationRepository) job.HandlerFunc {
+	return func(ctx context.Context, payload []byte) error {
+		notif := entity.Notification{}
+		if err := json.Unmarshal(payload, &notif); err != nil {
+			return err
+		}
+
+		if err := notifRepo.StoreNotification(ctx, notif); err != nil {
+			return err
+		}
+
+		metrics.NotificationsSent.WithLabelValues(notif.NotificationType).Inc()
+		return nil
+	}
+}
@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	entity "macaiki/internal/notification/entity"
 	"macaiki/internal/notification/mocks"
 	threadMocks "macaiki/internal/thread/mocks"
@@ -11,6 +12,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"gorm.io/gorm"
 )
 
@@ -19,8 +21,8 @@ func TestReadAllNotifications(t *testing.T) {
 	userMockRepo := userMocks.NewUserRepository(t)
 	threadMockRepo := threadMocks.NewThreadRepository(t)
 	t.Run("success", func(t *testing.T) {
-		notificationMockRepo.On("ReadAllNotifications", uint(1)).Return(nil).Once()
-		notificationMockRepo.On("GetAllNotifications", uint(1)).Return([]entity.Notification{
+		notificationMockRepo.On("ReadAllNotifications", mock.Anything, uint(1)).Return(nil).Once()
+		notificationMockRepo.On("GetAllNotifications", mock.Anything, uint(1)).Return([]entity.Notification{
 			{
 				Model: gorm.Model{
 					ID:        1,
@@ -34,7 +36,7 @@ func TestReadAllNotifications(t *testing.T) {
 			},
 		}, nil).Once()
 
-		userMockRepo.On("Get", uint(1)).Return(userEntity.User{
+		userMockRepo.On("Get", mock.Anything, uint(1)).Return(userEntity.User{
 			Model: gorm.Model{
 				ID:        1,
 				CreatedAt: time.Now(),
@@ -51,20 +53,20 @@ func TestReadAllNotifications(t *testing.T) {
 			Role:               "Admin",
 		}, nil).Once()
 
-		testNotificationUseCase := NewNotificationUsecase(notificationMockRepo, userMockRepo, threadMockRepo)
+		testNotificationUseCase := NewNotificationUsecase(notificationMockRepo, userMockRepo, threadMockRepo, nil)
 
-		notifications, err := testNotificationUseCase.ReadAllNotifications(uint(1))
+		notifications, err := testNotificationUseCase.ReadAllNotifications(context.Background(), uint(1))
 
 		assert.NoError(t, err)
 		assert.NotEmpty(t, notifications)
 	})
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		notificationMockRepo.On("ReadAllNotifications", uint(1)).Return(utils.ErrInternalServerError).Once()
+		notificationMockRepo.On("ReadAllNotifications", mock.Anything, uint(1)).Return(utils.ErrInternalServerError).Once()
 
-		testNotificationUseCase := NewNotificationUsecase(notificationMockRepo, userMockRepo, threadMockRepo)
+		testNotificationUseCase := NewNotificationUsecase(notificationMockRepo, userMockRepo, threadMockRepo, nil)
 
-		notifications, err := testNotificationUseCase.ReadAllNotifications(uint(1))
+		notifications, err := testNotificationUseCase.ReadAllNotifications(context.Background(), uint(1))
 
 		assert.Error(t, err)
 		assert.Empty(t, notifications)
@@ -77,9 +79,9 @@ func TestDeleteAllNotifications(t *testing.T) {
 	threadMockRepo := threadMocks.NewThreadRepository(t)
 
 	t.Run("success", func(t *testing.T) {
-		notificationMockRepo.On("DeleleteAllNotifications", uint(1)).Return(nil).Once()
+		notificationMockRepo.On("DeleleteAllNotifications", mock.Anything, uint(1)).Return(nil).Once()
 
-		notificationMockRepo.On("GetAllNotifications", uint(1)).Return([]entity.Notification{
+		notificationMockRepo.On("GetAllNotifications", mock.Anything, uint(1)).Return([]entity.Notification{
 			{
 				Model: gorm.Model{
 					ID:        1,
@@ -93,7 +95,7 @@ func TestDeleteAllNotifications(t *testing.T) {
 			},
 		}, nil).Once()
 
-		userMockRepo.On("Get", uint(1)).Return(userEntity.User{
+		userMockRepo.On("Get", mock.Anything, uint(1)).Return(userEntity.User{
 			Model: gorm.Model{
 				ID:        1,
 				CreatedAt: time.Now(),
@@ -110,20 +112,20 @@ func TestDeleteAllNotifications(t *testing.T) {
 			Role:               "Admin",
 		}, nil).Once()
 
-		testNotificationUseCase := NewNotificationUsecase(notificationMockRepo, userMockRepo, threadMockRepo)
+		testNotificationUseCase := NewNotificationUsecase(notificationMockRepo, userMockRepo, threadMockRepo, nil)
 
-		notifications, err := testNotificationUseCase.DeleteAllNotifications(uint(1))
+		notifications, err := testNotificationUseCase.DeleteAllNotifications(context.Background(), uint(1))
 
 		assert.NoError(t, err)
 		assert.NotEmpty(t, notifications)
 	})
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		notificationMockRepo.On("DeleleteAllNotifications", uint(1)).Return(utils.ErrInternalServerError).Once()
+		notificationMockRepo.On("DeleleteAllNotifications", mock.Anything, uint(1)).Return(utils.ErrInternalServerError).Once()
 
-		testNotificationUseCase := NewNotificationUsecase(notificationMockRepo, userMockRepo, threadMockRepo)
+		testNotificationUseCase := NewNotificationUsecase(notificationMockRepo, userMockRepo, threadMockRepo, nil)
 
-		notifications, err := testNotificationUseCase.DeleteAllNotifications(uint(1))
+		notifications, err := testNotificationUseCase.DeleteAllNotifications(context.Background(), uint(1))
 
 		assert.Error(t, err)
 		assert.Empty(t, notifications)
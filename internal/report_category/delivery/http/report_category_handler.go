@@ -31,7 +31,7 @@ func (rcHandler *ReportCategoryHandler) CreateReportCategory(c echo.Context) err
 	c.Bind(&rcReq)
 
 	_, role := _middL.ExtractTokenUser(c)
-	err := rcHandler.rcUsecase.CreateReportCategory(rcReq, role)
+	err := rcHandler.rcUsecase.CreateReportCategory(c.Request().Context(), rcReq, role)
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -40,7 +40,7 @@ func (rcHandler *ReportCategoryHandler) CreateReportCategory(c echo.Context) err
 }
 
 func (rcHandler *ReportCategoryHandler) GetAllReportCategories(c echo.Context) error {
-	dtoResponse, err := rcHandler.rcUsecase.GetAllReportCategory()
+	dtoResponse, err := rcHandler.rcUsecase.GetAllReportCategory(c.Request().Context())
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -54,7 +54,7 @@ func (rcHandler *ReportCategoryHandler) GetReportCategory(c echo.Context) error
 		return response.ErrorResponse(c, utils.ErrBadParamInput)
 	}
 
-	dtoResponse, err := rcHandler.rcUsecase.GetReportCategory(uint(id))
+	dtoResponse, err := rcHandler.rcUsecase.GetReportCategory(c.Request().Context(), uint(id))
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -72,7 +72,7 @@ func (rcHandler *ReportCategoryHandler) UpdateReportCategory(c echo.Context) err
 	c.Bind(&rcReq)
 
 	_, role := _middL.ExtractTokenUser(c)
-	err = rcHandler.rcUsecase.UpdateReportCategory(rcReq, uint(id), role)
+	err = rcHandler.rcUsecase.UpdateReportCategory(c.Request().Context(), rcReq, uint(id), role)
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -87,7 +87,7 @@ func (rcHandler *ReportCategoryHandler) DeleteReportCategory(c echo.Context) err
 	}
 
 	_, role := _middL.ExtractTokenUser(c)
-	err = rcHandler.rcUsecase.DeleteReportCategory(uint(id), role)
+	err = rcHandler.rcUsecase.DeleteReportCategory(c.Request().Context(), uint(id), role)
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
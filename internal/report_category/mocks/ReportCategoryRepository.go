@@ -3,6 +3,7 @@
 package mocks
 
 import (
+	context "context"
 	entity "macaiki/internal/report_category/entity"
 
 	mock "github.com/stretchr/testify/mock"
@@ -13,13 +14,13 @@ type ReportCategoryRepository struct {
 	mock.Mock
 }
 
-// DeleteReportCategory provides a mock function with given fields: reportCategory
-func (_m *ReportCategoryRepository) DeleteReportCategory(reportCategory entity.ReportCategory) error {
-	ret := _m.Called(reportCategory)
+// DeleteReportCategory provides a mock function with given fields: ctx, reportCategory
+func (_m *ReportCategoryRepository) DeleteReportCategory(ctx context.Context, reportCategory entity.ReportCategory) error {
+	ret := _m.Called(ctx, reportCategory)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(entity.ReportCategory) error); ok {
-		r0 = rf(reportCategory)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.ReportCategory) error); ok {
+		r0 = rf(ctx, reportCategory)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -27,13 +28,13 @@ func (_m *ReportCategoryRepository) DeleteReportCategory(reportCategory entity.R
 	return r0
 }
 
-// GetAllReportCategory provides a mock function with given fields:
-func (_m *ReportCategoryRepository) GetAllReportCategory() ([]entity.ReportCategory, error) {
-	ret := _m.Called()
+// GetAllReportCategory provides a mock function with given fields: ctx
+func (_m *ReportCategoryRepository) GetAllReportCategory(ctx context.Context) ([]entity.ReportCategory, error) {
+	ret := _m.Called(ctx)
 
 	var r0 []entity.ReportCategory
-	if rf, ok := ret.Get(0).(func() []entity.ReportCategory); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) []entity.ReportCategory); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entity.ReportCategory)
@@ -41,8 +42,8 @@ func (_m *ReportCategoryRepository) GetAllReportCategory() ([]entity.ReportCateg
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -50,20 +51,20 @@ func (_m *ReportCategoryRepository) GetAllReportCategory() ([]entity.ReportCateg
 	return r0, r1
 }
 
-// GetReportCategory provides a mock function with given fields: id
-func (_m *ReportCategoryRepository) GetReportCategory(id uint) (entity.ReportCategory, error) {
-	ret := _m.Called(id)
+// GetReportCategory provides a mock function with given fields: ctx, id
+func (_m *ReportCategoryRepository) GetReportCategory(ctx context.Context, id uint) (entity.ReportCategory, error) {
+	ret := _m.Called(ctx, id)
 
 	var r0 entity.ReportCategory
-	if rf, ok := ret.Get(0).(func(uint) entity.ReportCategory); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) entity.ReportCategory); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Get(0).(entity.ReportCategory)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -71,13 +72,13 @@ func (_m *ReportCategoryRepository) GetReportCategory(id uint) (entity.ReportCat
 	return r0, r1
 }
 
-// StoreReportCategory provides a mock function with given fields: reportCategory
-func (_m *ReportCategoryRepository) StoreReportCategory(reportCategory entity.ReportCategory) error {
-	ret := _m.Called(reportCategory)
+// StoreReportCategory provides a mock function with given fields: ctx, reportCategory
+func (_m *ReportCategoryRepository) StoreReportCategory(ctx context.Context, reportCategory entity.ReportCategory) error {
+	ret := _m.Called(ctx, reportCategory)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(entity.ReportCategory) error); ok {
-		r0 = rf(reportCategory)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.ReportCategory) error); ok {
+		r0 = rf(ctx, reportCategory)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -85,13 +86,13 @@ func (_m *ReportCategoryRepository) StoreReportCategory(reportCategory entity.Re
 	return r0
 }
 
-// UpdateReportCategory provides a mock function with given fields: reportCategory
-func (_m *ReportCategoryRepository) UpdateReportCategory(reportCategory entity.ReportCategory) error {
-	ret := _m.Called(reportCategory)
+// UpdateReportCategory provides a mock function with given fields: ctx, reportCategory
+func (_m *ReportCategoryRepository) UpdateReportCategory(ctx context.Context, reportCategory entity.ReportCategory) error {
+	ret := _m.Called(ctx, reportCategory)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(entity.ReportCategory) error); ok {
-		r0 = rf(reportCategory)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.ReportCategory) error); ok {
+		r0 = rf(ctx, reportCategory)
 	} else {
 		r0 = ret.Error(0)
 	}
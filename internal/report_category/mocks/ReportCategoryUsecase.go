@@ -3,6 +3,7 @@
 package mocks
 
 import (
+	context "context"
 	dto "macaiki/internal/report_category/dto"
 
 	mock "github.com/stretchr/testify/mock"
@@ -13,13 +14,13 @@ type ReportCategoryUsecase struct {
 	mock.Mock
 }
 
-// CreateReportCategory provides a mock function with given fields: reportCategory, role
-func (_m *ReportCategoryUsecase) CreateReportCategory(reportCategory dto.ReportCategoryRequest, role string) error {
-	ret := _m.Called(reportCategory, role)
+// CreateReportCategory provides a mock function with given fields: ctx, reportCategory, role
+func (_m *ReportCategoryUsecase) CreateReportCategory(ctx context.Context, reportCategory dto.ReportCategoryRequest, role string) error {
+	ret := _m.Called(ctx, reportCategory, role)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(dto.ReportCategoryRequest, string) error); ok {
-		r0 = rf(reportCategory, role)
+	if rf, ok := ret.Get(0).(func(context.Context, dto.ReportCategoryRequest, string) error); ok {
+		r0 = rf(ctx, reportCategory, role)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -27,13 +28,13 @@ func (_m *ReportCategoryUsecase) CreateReportCategory(reportCategory dto.ReportC
 	return r0
 }
 
-// DeleteReportCategory provides a mock function with given fields: id, role
-func (_m *ReportCategoryUsecase) DeleteReportCategory(id uint, role string) error {
-	ret := _m.Called(id, role)
+// DeleteReportCategory provides a mock function with given fields: ctx, id, role
+func (_m *ReportCategoryUsecase) DeleteReportCategory(ctx context.Context, id uint, role string) error {
+	ret := _m.Called(ctx, id, role)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint, string) error); ok {
-		r0 = rf(id, role)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) error); ok {
+		r0 = rf(ctx, id, role)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -41,13 +42,13 @@ func (_m *ReportCategoryUsecase) DeleteReportCategory(id uint, role string) erro
 	return r0
 }
 
-// GetAllReportCategory provides a mock function with given fields:
-func (_m *ReportCategoryUsecase) GetAllReportCategory() ([]dto.ReportCategoryResponse, error) {
-	ret := _m.Called()
+// GetAllReportCategory provides a mock function with given fields: ctx
+func (_m *ReportCategoryUsecase) GetAllReportCategory(ctx context.Context) ([]dto.ReportCategoryResponse, error) {
+	ret := _m.Called(ctx)
 
 	var r0 []dto.ReportCategoryResponse
-	if rf, ok := ret.Get(0).(func() []dto.ReportCategoryResponse); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) []dto.ReportCategoryResponse); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]dto.ReportCategoryResponse)
@@ -55,8 +56,8 @@ func (_m *ReportCategoryUsecase) GetAllReportCategory() ([]dto.ReportCategoryRes
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -64,20 +65,20 @@ func (_m *ReportCategoryUsecase) GetAllReportCategory() ([]dto.ReportCategoryRes
 	return r0, r1
 }
 
-// GetReportCategory provides a mock function with given fields: id
-func (_m *ReportCategoryUsecase) GetReportCategory(id uint) (dto.ReportCategoryResponse, error) {
-	ret := _m.Called(id)
+// GetReportCategory provides a mock function with given fields: ctx, id
+func (_m *ReportCategoryUsecase) GetReportCategory(ctx context.Context, id uint) (dto.ReportCategoryResponse, error) {
+	ret := _m.Called(ctx, id)
 
 	var r0 dto.ReportCategoryResponse
-	if rf, ok := ret.Get(0).(func(uint) dto.ReportCategoryResponse); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) dto.ReportCategoryResponse); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Get(0).(dto.ReportCategoryResponse)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -85,13 +86,13 @@ func (_m *ReportCategoryUsecase) GetReportCategory(id uint) (dto.ReportCategoryR
 	return r0, r1
 }
 
-// UpdateReportCategory provides a mock function with given fields: reportCategory, id, role
-func (_m *ReportCategoryUsecase) UpdateReportCategory(reportCategory dto.ReportCategoryRequest, id uint, role string) error {
-	ret := _m.Called(reportCategory, id, role)
+// UpdateReportCategory provides a mock function with given fields: ctx, reportCategory, id, role
+func (_m *ReportCategoryUsecase) UpdateReportCategory(ctx context.Context, reportCategory dto.ReportCategoryRequest, id uint, role string) error {
+	ret := _m.Called(ctx, reportCategory, id, role)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(dto.ReportCategoryRequest, uint, string) error); ok {
-		r0 = rf(reportCategory, id, role)
+	if rf, ok := ret.Get(0).(func(context.Context, dto.ReportCategoryRequest, uint, string) error); ok {
+		r0 = rf(ctx, reportCategory, id, role)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1,11 +1,14 @@
 package reportcategory
 
-import "macaiki/internal/report_category/entity"
+import (
+	"context"
+	"macaiki/internal/report_category/entity"
+)
 
 type ReportCategoryRepository interface {
-	StoreReportCategory(reportCategory entity.ReportCategory) error
-	GetAllReportCategory() ([]entity.ReportCategory, error)
-	GetReportCategory(id uint) (entity.ReportCategory, error)
-	UpdateReportCategory(reportCategory entity.ReportCategory) error
-	DeleteReportCategory(reportCategory entity.ReportCategory) error
+	StoreReportCategory(ctx context.Context, reportCategory entity.ReportCategory) error
+	GetAllReportCategory(ctx context.Context) ([]entity.ReportCategory, error)
+	GetReportCategory(ctx context.Context, id uint) (entity.ReportCategory, error)
+	UpdateReportCategory(ctx context.Context, reportCategory entity.ReportCategory) error
+	DeleteReportCategory(ctx context.Context, reportCategory entity.ReportCategory) error
 }
@@ -1,6 +1,7 @@
 package mysql
 
 import (
+	"context"
 	reportcategory "macaiki/internal/report_category"
 	"macaiki/internal/report_category/entity"
 
@@ -15,8 +16,8 @@ func NewReportCategoryRepository(db *gorm.DB) reportcategory.ReportCategoryRepos
 	return &ReportCategoryRepositoryImpl{db}
 }
 
-func (rcr *ReportCategoryRepositoryImpl) StoreReportCategory(reportCategory entity.ReportCategory) error {
-	tx := rcr.db.Create(&reportCategory)
+func (rcr *ReportCategoryRepositoryImpl) StoreReportCategory(ctx context.Context, reportCategory entity.ReportCategory) error {
+	tx := rcr.db.WithContext(ctx).Create(&reportCategory)
 	err := tx.Error
 	if err != nil {
 		return err
@@ -24,10 +25,10 @@ func (rcr *ReportCategoryRepositoryImpl) StoreReportCategory(reportCategory enti
 	return nil
 }
 
-func (rcr *ReportCategoryRepositoryImpl) GetAllReportCategory() ([]entity.ReportCategory, error) {
+func (rcr *ReportCategoryRepositoryImpl) GetAllReportCategory(ctx context.Context) ([]entity.ReportCategory, error) {
 	reportCategories := []entity.ReportCategory{}
 
-	tx := rcr.db.Find(&reportCategories)
+	tx := rcr.db.WithContext(ctx).Find(&reportCategories)
 	err := tx.Error
 	if err != nil {
 		return []entity.ReportCategory{}, err
@@ -36,10 +37,10 @@ func (rcr *ReportCategoryRepositoryImpl) GetAllReportCategory() ([]entity.Report
 	return reportCategories, nil
 }
 
-func (rcr *ReportCategoryRepositoryImpl) GetReportCategory(id uint) (entity.ReportCategory, error) {
+func (rcr *ReportCategoryRepositoryImpl) GetReportCategory(ctx context.Context, id uint) (entity.ReportCategory, error) {
 	reportCategory := entity.ReportCategory{}
 
-	tx := rcr.db.Find(&reportCategory, id)
+	tx := rcr.db.WithContext(ctx).Find(&reportCategory, id)
 	err := tx.Error
 	if err != nil {
 		return entity.ReportCategory{}, err
@@ -48,8 +49,8 @@ func (rcr *ReportCategoryRepositoryImpl) GetReportCategory(id uint) (entity.Repo
 	return reportCategory, nil
 }
 
-func (rcr *ReportCategoryRepositoryImpl) UpdateReportCategory(reportCategory entity.ReportCategory) error {
-	tx := rcr.db.Save(&reportCategory)
+func (rcr *ReportCategoryRepositoryImpl) UpdateReportCategory(ctx context.Context, reportCategory entity.ReportCategory) error {
+	tx := rcr.db.WithContext(ctx).Save(&reportCategory)
 	err := tx.Error
 	if err != nil {
 		return err
@@ -57,8 +58,8 @@ func (rcr *ReportCategoryRepositoryImpl) UpdateReportCategory(reportCategory ent
 	return nil
 }
 
-func (rcr *ReportCategoryRepositoryImpl) DeleteReportCategory(reportCategory entity.ReportCategory) error {
-	tx := rcr.db.Delete(&reportCategory)
+func (rcr *ReportCategoryRepositoryImpl) DeleteReportCategory(ctx context.Context, reportCategory entity.ReportCategory) error {
+	tx := rcr.db.WithContext(ctx).Delete(&reportCategory)
 	err := tx.Error
 	if err != nil {
 		return err
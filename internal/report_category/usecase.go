@@ -1,11 +1,14 @@
 package reportcategory
 
-import "macaiki/internal/report_category/dto"
+import (
+	"context"
+	"macaiki/internal/report_category/dto"
+)
 
 type ReportCategoryUsecase interface {
-	CreateReportCategory(reportCategory dto.ReportCategoryRequest, role string) error
-	GetAllReportCategory() ([]dto.ReportCategoryResponse, error)
-	GetReportCategory(id uint) (dto.ReportCategoryResponse, error)
-	UpdateReportCategory(reportCategory dto.ReportCategoryRequest, id uint, role string) error
-	DeleteReportCategory(id uint, role string) error
+	CreateReportCategory(ctx context.Context, reportCategory dto.ReportCategoryRequest, role string) error
+	GetAllReportCategory(ctx context.Context) ([]dto.ReportCategoryResponse, error)
+	GetReportCategory(ctx context.Context, id uint) (dto.ReportCategoryResponse, error)
+	UpdateReportCategory(ctx context.Context, reportCategory dto.ReportCategoryRequest, id uint, role string) error
+	DeleteReportCategory(ctx context.Context, id uint, role string) error
 }
@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	reportcategory "macaiki/internal/report_category"
 	"macaiki/internal/report_category/dto"
 	"macaiki/internal/report_category/entity"
@@ -18,7 +19,7 @@ func NewReportCategoryUsecase(rcRepo reportcategory.ReportCategoryRepository, va
 	return &ReportCategoryUsecaseImpl{rcRepo, validator}
 }
 
-func (rcu *ReportCategoryUsecaseImpl) CreateReportCategory(reportCategory dto.ReportCategoryRequest, role string) error {
+func (rcu *ReportCategoryUsecaseImpl) CreateReportCategory(ctx context.Context, reportCategory dto.ReportCategoryRequest, role string) error {
 	if role != "Admin" {
 		return utils.ErrUnauthorizedAccess
 	}
@@ -31,7 +32,7 @@ func (rcu *ReportCategoryUsecaseImpl) CreateReportCategory(reportCategory dto.Re
 		Name: reportCategory.Name,
 	}
 
-	err := rcu.rcRepo.StoreReportCategory(reportCategoryEntity)
+	err := rcu.rcRepo.StoreReportCategory(ctx, reportCategoryEntity)
 	if err != nil {
 		return utils.ErrInternalServerError
 	}
@@ -39,8 +40,8 @@ func (rcu *ReportCategoryUsecaseImpl) CreateReportCategory(reportCategory dto.Re
 	return nil
 }
 
-func (rcu *ReportCategoryUsecaseImpl) GetAllReportCategory() ([]dto.ReportCategoryResponse, error) {
-	reportCategories, err := rcu.rcRepo.GetAllReportCategory()
+func (rcu *ReportCategoryUsecaseImpl) GetAllReportCategory(ctx context.Context) ([]dto.ReportCategoryResponse, error) {
+	reportCategories, err := rcu.rcRepo.GetAllReportCategory(ctx)
 	if err != nil {
 		return []dto.ReportCategoryResponse{}, utils.ErrInternalServerError
 	}
@@ -53,8 +54,8 @@ func (rcu *ReportCategoryUsecaseImpl) GetAllReportCategory() ([]dto.ReportCatego
 	return dtoReportCategories, nil
 }
 
-func (rcu *ReportCategoryUsecaseImpl) GetReportCategory(id uint) (dto.ReportCategoryResponse, error) {
-	reportCategory, err := rcu.rcRepo.GetReportCategory(id)
+func (rcu *ReportCategoryUsecaseImpl) GetReportCategory(ctx context.Context, id uint) (dto.ReportCategoryResponse, error) {
+	reportCategory, err := rcu.rcRepo.GetReportCategory(ctx, id)
 	if err != nil {
 		return dto.ReportCategoryResponse{}, utils.ErrInternalServerError
 	}
@@ -65,7 +66,7 @@ func (rcu *ReportCategoryUsecaseImpl) GetReportCategory(id uint) (dto.ReportCate
 	return dto.ReportCategoryResponse{ID: reportCategory.ID, Name: reportCategory.Name}, nil
 }
 
-func (rcu *ReportCategoryUsecaseImpl) UpdateReportCategory(reportCategory dto.ReportCategoryRequest, id uint, role string) error {
+func (rcu *ReportCategoryUsecaseImpl) UpdateReportCategory(ctx context.Context, reportCategory dto.ReportCategoryRequest, id uint, role string) error {
 	if role != "Admin" {
 		return utils.ErrUnauthorizedAccess
 	}
@@ -74,7 +75,7 @@ func (rcu *ReportCategoryUsecaseImpl) UpdateReportCategory(reportCategory dto.Re
 		return utils.ErrBadParamInput
 	}
 
-	reportCategoryDB, err := rcu.rcRepo.GetReportCategory(id)
+	reportCategoryDB, err := rcu.rcRepo.GetReportCategory(ctx, id)
 	if err != nil {
 		return utils.ErrInternalServerError
 	}
@@ -83,7 +84,7 @@ func (rcu *ReportCategoryUsecaseImpl) UpdateReportCategory(reportCategory dto.Re
 	}
 
 	reportCategoryDB.Name = reportCategory.Name
-	err = rcu.rcRepo.UpdateReportCategory(reportCategoryDB)
+	err = rcu.rcRepo.UpdateReportCategory(ctx, reportCategoryDB)
 	if err != nil {
 		return utils.ErrInternalServerError
 	}
@@ -91,12 +92,12 @@ func (rcu *ReportCategoryUsecaseImpl) UpdateReportCategory(reportCategory dto.Re
 	return nil
 }
 
-func (rcu *ReportCategoryUsecaseImpl) DeleteReportCategory(id uint, role string) error {
+func (rcu *ReportCategoryUsecaseImpl) DeleteReportCategory(ctx context.Context, id uint, role string) error {
 	if role != "Admin" {
 		return utils.ErrUnauthorizedAccess
 	}
 
-	reportCategory, err := rcu.rcRepo.GetReportCategory(id)
+	reportCategory, err := rcu.rcRepo.GetReportCategory(ctx, id)
 	if err != nil {
 		return utils.ErrInternalServerError
 	}
@@ -104,7 +105,7 @@ func (rcu *ReportCategoryUsecaseImpl) DeleteReportCategory(id uint, role string)
 		return utils.ErrNotFound
 	}
 
-	err = rcu.rcRepo.DeleteReportCategory(reportCategory)
+	err = rcu.rcRepo.DeleteReportCategory(ctx, reportCategory)
 	if err != nil {
 		return utils.ErrInternalServerError
 	}
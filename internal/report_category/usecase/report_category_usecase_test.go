@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"macaiki/internal/report_category/dto"
 	"macaiki/internal/report_category/entity"
 	"macaiki/internal/report_category/mocks"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/go-playground/validator/v10"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 var (
@@ -37,19 +39,19 @@ func TestCreateReportCategory(t *testing.T) {
 	mockedReportCategoryRepo := mocks.NewReportCategoryRepository(t)
 
 	t.Run("success", func(t *testing.T) {
-		mockedReportCategoryRepo.On("StoreReportCategory", mockedReportCategoryEntity).Return(nil).Once()
+		mockedReportCategoryRepo.On("StoreReportCategory", mock.Anything, mockedReportCategoryEntity).Return(nil).Once()
 
 		testReportCategoryUseCase := NewReportCategoryUsecase(mockedReportCategoryRepo, validator.New())
-		err := testReportCategoryUseCase.CreateReportCategory(mockedReportCategoryDTO, "Admin")
+		err := testReportCategoryUseCase.CreateReportCategory(context.Background(), mockedReportCategoryDTO, "Admin")
 
 		assert.NoError(t, err)
 	})
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		mockedReportCategoryRepo.On("StoreReportCategory", mockedReportCategoryEntity).Return(utils.ErrInternalServerError).Once()
+		mockedReportCategoryRepo.On("StoreReportCategory", mock.Anything, mockedReportCategoryEntity).Return(utils.ErrInternalServerError).Once()
 
 		testReportCategoryUseCase := NewReportCategoryUsecase(mockedReportCategoryRepo, validator.New())
-		err := testReportCategoryUseCase.CreateReportCategory(mockedReportCategoryDTO, "Admin")
+		err := testReportCategoryUseCase.CreateReportCategory(context.Background(), mockedReportCategoryDTO, "Admin")
 
 		assert.Error(t, err)
 	})
@@ -59,20 +61,20 @@ func TestGetAllReportCategory(t *testing.T) {
 	mockedReportCategoryRepo := mocks.NewReportCategoryRepository(t)
 
 	t.Run("success", func(t *testing.T) {
-		mockedReportCategoryRepo.On("GetAllReportCategory").Return(mockedReportCategoryReturnedEntities, nil).Once()
+		mockedReportCategoryRepo.On("GetAllReportCategory", mock.Anything).Return(mockedReportCategoryReturnedEntities, nil).Once()
 
 		testReportCategoryUseCase := NewReportCategoryUsecase(mockedReportCategoryRepo, validator.New())
-		res, err := testReportCategoryUseCase.GetAllReportCategory()
+		res, err := testReportCategoryUseCase.GetAllReportCategory(context.Background())
 
 		assert.NoError(t, err)
 		assert.NotEmpty(t, res)
 	})
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		mockedReportCategoryRepo.On("GetAllReportCategory").Return([]entity.ReportCategory{}, utils.ErrInternalServerError).Once()
+		mockedReportCategoryRepo.On("GetAllReportCategory", mock.Anything).Return([]entity.ReportCategory{}, utils.ErrInternalServerError).Once()
 
 		testReportCategoryUseCase := NewReportCategoryUsecase(mockedReportCategoryRepo, validator.New())
-		res, err := testReportCategoryUseCase.GetAllReportCategory()
+		res, err := testReportCategoryUseCase.GetAllReportCategory(context.Background())
 
 		assert.Error(t, err)
 		assert.Empty(t, res)
@@ -83,20 +85,20 @@ func TestGetReportCategory(t *testing.T) {
 	mockedReportCategoryRepo := mocks.NewReportCategoryRepository(t)
 
 	t.Run("success", func(t *testing.T) {
-		mockedReportCategoryRepo.On("GetReportCategory", uint(1)).Return(mockedReportCategoryReturnedEntity, nil).Once()
+		mockedReportCategoryRepo.On("GetReportCategory", mock.Anything, uint(1)).Return(mockedReportCategoryReturnedEntity, nil).Once()
 
 		testReportCategoryUseCase := NewReportCategoryUsecase(mockedReportCategoryRepo, validator.New())
-		res, err := testReportCategoryUseCase.GetReportCategory(uint(1))
+		res, err := testReportCategoryUseCase.GetReportCategory(context.Background(), uint(1))
 
 		assert.NoError(t, err)
 		assert.NotEmpty(t, res)
 	})
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		mockedReportCategoryRepo.On("GetReportCategory", uint(1)).Return(entity.ReportCategory{}, utils.ErrInternalServerError).Once()
+		mockedReportCategoryRepo.On("GetReportCategory", mock.Anything, uint(1)).Return(entity.ReportCategory{}, utils.ErrInternalServerError).Once()
 
 		testReportCategoryUseCase := NewReportCategoryUsecase(mockedReportCategoryRepo, validator.New())
-		res, err := testReportCategoryUseCase.GetReportCategory(uint(1))
+		res, err := testReportCategoryUseCase.GetReportCategory(context.Background(), uint(1))
 
 		assert.Error(t, err)
 		assert.Empty(t, res)
@@ -107,20 +109,20 @@ func TestDeleteReportCategory(t *testing.T) {
 	mockedReportCategoryRepo := mocks.NewReportCategoryRepository(t)
 
 	t.Run("success", func(t *testing.T) {
-		mockedReportCategoryRepo.On("GetReportCategory", uint(1)).Return(mockedReportCategoryReturnedEntity, nil).Once()
-		mockedReportCategoryRepo.On("DeleteReportCategory", mockedReportCategoryReturnedEntity).Return(nil).Once()
+		mockedReportCategoryRepo.On("GetReportCategory", mock.Anything, uint(1)).Return(mockedReportCategoryReturnedEntity, nil).Once()
+		mockedReportCategoryRepo.On("DeleteReportCategory", mock.Anything, mockedReportCategoryReturnedEntity).Return(nil).Once()
 
 		testReportCategoryUseCase := NewReportCategoryUsecase(mockedReportCategoryRepo, validator.New())
-		err := testReportCategoryUseCase.DeleteReportCategory(uint(1), "Admin")
+		err := testReportCategoryUseCase.DeleteReportCategory(context.Background(), uint(1), "Admin")
 
 		assert.NoError(t, err)
 	})
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		mockedReportCategoryRepo.On("GetReportCategory", uint(1)).Return(entity.ReportCategory{}, utils.ErrInternalServerError).Once()
+		mockedReportCategoryRepo.On("GetReportCategory", mock.Anything, uint(1)).Return(entity.ReportCategory{}, utils.ErrInternalServerError).Once()
 
 		testReportCategoryUseCase := NewReportCategoryUsecase(mockedReportCategoryRepo, validator.New())
-		err := testReportCategoryUseCase.DeleteReportCategory(uint(1), "Admin")
+		err := testReportCategoryUseCase.DeleteReportCategory(context.Background(), uint(1), "Admin")
 
 		assert.Error(t, err)
 	})
@@ -0,0 +1,178 @@
+package helper
+
+import (
+	"macaiki/internal/thread/dto"
+	"macaiki/internal/thread/entity"
+)
+
+func DomainThreadToDetailedThreadResponse(thread entity.ThreadWithDetails) dto.DetailedThreadResponse {
+	return dto.DetailedThreadResponse{
+		ID:                    thread.Thread.ID,
+		Title:                 thread.Title,
+		Body:                  thread.Body,
+		BodyHTML:              thread.BodyHTML,
+		BodyText:              thread.BodyText,
+		CommunityID:           thread.CommunityID,
+		ImageURL:              thread.ImageURL,
+		UserID:                thread.Thread.UserID,
+		UserName:              thread.User.Name,
+		UserProfession:        thread.User.Profession,
+		UserProfilePictureURL: thread.User.ProfileImageUrl,
+		CreatedAt:             thread.Thread.CreatedAt,
+		UpdatedAt:             thread.Thread.UpdatedAt,
+		Score:                 thread.Score,
+		UpvotesCount:          thread.UpvotesCount,
+		DownvotesCount:        thread.DownvotesCount,
+		UserVote:              thread.UserVote,
+		IsUpvoted:             boolToInt(thread.UserVote == entity.VoteUp),
+		IsDownVoted:           boolToInt(thread.UserVote == entity.VoteDown),
+		IsFollowed:            thread.IsFollowed,
+		Preview:               DomainLinkPreviewToResponse(thread.Preview),
+	}
+}
+
+// DomainLinkPreviewToResponse maps a preview, nil when the thread has none
+func DomainLinkPreviewToResponse(preview entity.LinkPreview) *dto.LinkPreviewResponse {
+	if preview.URL == "" {
+		return nil
+	}
+
+	return &dto.LinkPreviewResponse{
+		URL:         preview.URL,
+		Title:       preview.Title,
+		Description: preview.Description,
+		ImageURL:    preview.ImageURL,
+		SiteName:    preview.SiteName,
+	}
+}
+
+func DomainThreadToListDetailedThreadResponse(threads []entity.ThreadWithDetails) []dto.DetailedThreadResponse {
+	var threadsResponse []dto.DetailedThreadResponse
+
+	for _, val := range threads {
+		threadsResponse = append(threadsResponse, DomainThreadToDetailedThreadResponse(val))
+	}
+
+	return threadsResponse
+}
+
+func DomainThreadToThreadVoteResponse(thread entity.Thread, userVote int) dto.ThreadVoteResponse {
+	return dto.ThreadVoteResponse{
+		ThreadID:       thread.ID,
+		Score:          thread.Score,
+		UpvotesCount:   thread.UpvotesCount,
+		DownvotesCount: thread.DownvotesCount,
+		UserVote:       userVote,
+	}
+}
+
+// DomainCommentToCommentResponse maps a comment as seen by userID, a zero
+// userID is an anonymous reader
+func DomainCommentToCommentResponse(comment entity.CommentDetails, userID uint) dto.CommentResponse {
+	return dto.CommentResponse{
+		ID:                    comment.Comment.ID,
+		Body:                  comment.Body,
+		BodyHTML:              comment.BodyHTML,
+		BodyText:              comment.BodyText,
+		ThreadID:              comment.ThreadID,
+		UserID:                comment.Comment.UserID,
+		Username:              comment.User.Name,
+		UserProfilePictureURL: comment.User.ProfileImageUrl,
+		CreatedAt:             comment.Comment.CreatedAt,
+		LikesCount:            comment.Comment.UpvotesCount,
+		Score:                 comment.Comment.Score,
+		UpvotesCount:          comment.Comment.UpvotesCount,
+		DownvotesCount:        comment.Comment.DownvotesCount,
+		UserVote:              comment.UserVote,
+		IsMine:                userID != 0 && comment.Comment.UserID == userID,
+	}
+}
+
+func DomainCommentToCommentVoteResponse(comment entity.Comment, userVote int) dto.CommentVoteResponse {
+	return dto.CommentVoteResponse{
+		CommentID:      comment.ID,
+		Score:          comment.Score,
+		UpvotesCount:   comment.UpvotesCount,
+		DownvotesCount: comment.DownvotesCount,
+		UserVote:       userVote,
+	}
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func DomainSavedThreadToListSavedThreadResponse(threads []entity.SavedThreadDetails) []dto.SavedThreadResponse {
+	threadsResponse := []dto.SavedThreadResponse{}
+
+	for _, val := range threads {
+		threadsResponse = append(threadsResponse, dto.SavedThreadResponse{
+			DetailedThreadResponse: DomainThreadToDetailedThreadResponse(val.ThreadWithDetails),
+			CollectionID:           val.CollectionID,
+			Note:                   val.Note,
+			SavedAt:                val.SavedAt,
+		})
+	}
+
+	return threadsResponse
+}
+
+func DomainSavedThreadCollectionToResponse(collection entity.SavedThreadCollection) dto.SavedThreadCollectionResponse {
+	return dto.SavedThreadCollectionResponse{
+		ID:         collection.ID,
+		Name:       collection.Name,
+		SavedCount: collection.SavedCount,
+		CreatedAt:  collection.CreatedAt,
+		UpdatedAt:  collection.UpdatedAt,
+	}
+}
+
+func DomainThreadToDraftThreadResponse(thread entity.Thread) dto.DraftThreadResponse {
+	return dto.DraftThreadResponse{
+		ThreadResponse: dto.ThreadResponse{
+			ID:          thread.ID,
+			Title:       thread.Title,
+			Body:        thread.Body,
+			BodyHTML:    thread.BodyHTML,
+			BodyText:    thread.BodyText,
+			CommunityID: thread.CommunityID,
+			ImageURL:    thread.ImageURL,
+			UserID:      thread.UserID,
+			CreatedAt:   thread.CreatedAt,
+			UpdatedAt:   thread.UpdatedAt,
+			Preview:     DomainLinkPreviewToResponse(thread.Preview),
+		},
+		Status:    thread.Status,
+		PublishAt: thread.PublishAt,
+	}
+}
+
+func DomainAttachmentToAttachmentResponse(attachment entity.Attachment) dto.AttachmentResponse {
+	return dto.AttachmentResponse{
+		ID:        attachment.ID,
+		ImageURL:  attachment.FileName,
+		AltText:   attachment.AltText,
+		Caption:   attachment.Caption,
+		Width:     attachment.Width,
+		Height:    attachment.Height,
+		Position:  attachment.Position,
+		CommentID: attachment.CommentID,
+	}
+}
+
+// DomainAttachmentToListAttachmentResponse maps the attachments of one
+// gallery, commentID picks the gallery and zero is the thread's own
+func DomainAttachmentToListAttachmentResponse(attachments []entity.Attachment, commentID uint) []dto.AttachmentResponse {
+	attachmentsResponse := []dto.AttachmentResponse{}
+
+	for _, val := range attachments {
+		if val.CommentID == commentID {
+			attachmentsResponse = append(attachmentsResponse, DomainAttachmentToAttachmentResponse(val))
+		}
+	}
+
+	return attachmentsResponse
+}
@@ -1,13 +1,16 @@
 package http
 
 import (
-	"fmt"
+	"context"
+	"errors"
 	"macaiki/internal/thread"
 	"macaiki/internal/thread/dto"
 	_middL "macaiki/pkg/middleware"
 	"macaiki/pkg/response"
 	"macaiki/pkg/utils"
+	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
@@ -16,6 +19,23 @@ import (
 type ThreadHandler struct {
 	router *echo.Echo
 	tu     thread.ThreadUseCase
+	// tokenUser reads the reader on public routes that show more to a
+	// signed in user
+	tokenUser func(c echo.Context) (string, bool)
+}
+
+// optionalUserID returns the ID of the signed in user, or zero for an
+// anonymous request
+func (th *ThreadHandler) optionalUserID(c echo.Context) uint {
+	id, ok := th.tokenUser(c)
+	if !ok {
+		return 0
+	}
+	u64, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		return 0
+	}
+	return uint(u64)
 }
 
 func (th *ThreadHandler) GetThreads(c echo.Context) error {
@@ -26,6 +46,7 @@ func (th *ThreadHandler) GetThreads(c echo.Context) error {
 	forYou := c.QueryParam("forYou")
 	keyword := c.QueryParam("keyword")
 	saved := c.QueryParam("saved")
+	hidden := c.QueryParam("hidden")
 	limit := c.QueryParam("limit")
 
 	var res interface{}
@@ -34,29 +55,93 @@ func (th *ThreadHandler) GetThreads(c echo.Context) error {
 		if limit != "" {
 			limitInt, err := strconv.Atoi(limit)
 			if err != nil {
-				fmt.Println(err)
 				return response.ErrorResponse(c, utils.ErrBadParamInput)
 			}
-			res, err = th.tu.GetTrendingThreads(uint(userID), limitInt)
+			res, err = th.tu.GetTrendingThreads(c.Request().Context(), uint(userID), limitInt)
 
 		} else {
-			res, err = th.tu.GetTrendingThreads(uint(userID), -1)
+			res, err = th.tu.GetTrendingThreads(c.Request().Context(), uint(userID), -1)
 		}
 	} else if community == "true" {
-		res, err = th.tu.GetThreadsFromFollowedCommunity(uint(userID))
+		res, err = th.tu.GetThreadsFromFollowedCommunity(c.Request().Context(), uint(userID))
 	} else if forYou == "true" {
-		res, err = th.tu.GetThreadsFromFollowedUsers(uint(userID))
+		res, err = th.tu.GetThreadsFromFollowedUsers(c.Request().Context(), uint(userID))
 	} else if saved == "true" {
-		res, err = th.tu.GetSavedThread(uint(userID))
+		res, err = th.tu.GetSavedThread(c.Request().Context(), uint(userID))
+	} else if hidden == "true" {
+		res, err = th.tu.GetHiddenThreads(c.Request().Context(), uint(userID))
 	} else {
-		res, err = th.tu.GetThreads(keyword, uint(userID))
+		res, err = th.tu.GetThreads(c.Request().Context(), keyword, uint(userID))
+	}
+
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, res)
+}
+
+// trendingLimit reads the optional limit query parameter, -1 when absent
+func trendingLimit(c echo.Context) (int, error) {
+	limit := c.QueryParam("limit")
+	if limit == "" {
+		return -1, nil
+	}
+
+	limitInt, err := strconv.Atoi(limit)
+	if err != nil || limitInt < 1 {
+		return 0, utils.ErrBadParamInput
+	}
+	return limitInt, nil
+}
+
+func (th *ThreadHandler) GetTrendingThreads(c echo.Context) error {
+	userID, _ := _middL.ExtractTokenUser(c)
+
+	limit, err := trendingLimit(c)
+	if err != nil {
+		return response.ErrorResponse(c, err)
 	}
 
+	res, err := th.tu.GetTrendingThreads(c.Request().Context(), uint(userID), limit)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+	return response.SuccessResponse(c, res)
+}
+
+func (th *ThreadHandler) GetTrendingThreadsByTimeframe(c echo.Context) error {
+	userID, _ := _middL.ExtractTokenUser(c)
+
+	limit, err := trendingLimit(c)
 	if err != nil {
-		fmt.Println(err)
 		return response.ErrorResponse(c, err)
 	}
 
+	res, err := th.tu.GetTrendingThreadsByTimeframe(c.Request().Context(), uint(userID), c.Param("timeframe"), limit)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+	return response.SuccessResponse(c, res)
+}
+
+func (th *ThreadHandler) GetCommunityTrendingThreads(c echo.Context) error {
+	userID, _ := _middL.ExtractTokenUser(c)
+
+	u64, err := strconv.ParseUint(c.Param("communityID"), 10, 32)
+	if err != nil {
+		return response.ErrorResponse(c, utils.ErrBadParamInput)
+	}
+
+	limit, err := trendingLimit(c)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	res, err := th.tu.GetCommunityTrendingThreads(c.Request().Context(), uint(userID), uint(u64), limit)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
 	return response.SuccessResponse(c, res)
 }
 
@@ -64,13 +149,11 @@ func (th *ThreadHandler) GetThreadByID(c echo.Context) error {
 	threadID := c.Param("threadID")
 	u64, err := strconv.ParseUint(threadID, 10, 32)
 	if err != nil {
-		fmt.Println(err)
 		return response.ErrorResponse(c, err)
 	}
 	threadIDUint := uint(u64)
-	res, err := th.tu.GetThreadByID(threadIDUint)
+	res, err := th.tu.GetThreadByID(c.Request().Context(), threadIDUint, th.optionalUserID(c))
 	if err != nil {
-		fmt.Println(err)
 		return response.ErrorResponse(c, err)
 	}
 
@@ -82,38 +165,111 @@ func (th *ThreadHandler) CreateThread(c echo.Context) error {
 
 	thread := new(dto.ThreadRequest)
 	if err := c.Bind(thread); err != nil {
-		fmt.Println(err)
 		return response.ErrorResponse(c, err)
 	}
 
-	res, err := th.tu.CreateThread(*thread, uint(userID))
+	res, err := th.tu.CreateThread(c.Request().Context(), *thread, uint(userID))
 	if err != nil {
-		fmt.Println(err)
 		return response.ErrorResponse(c, err)
 	}
 
 	return response.SuccessResponse(c, res)
 }
 
+func (th *ThreadHandler) CreateDraft(c echo.Context) error {
+	userID, _ := _middL.ExtractTokenUser(c)
+
+	thread := new(dto.ThreadRequest)
+	if err := c.Bind(thread); err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	res, err := th.tu.CreateDraft(c.Request().Context(), *thread, uint(userID))
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, res)
+}
+
+func (th *ThreadHandler) GetDrafts(c echo.Context) error {
+	userID, _ := _middL.ExtractTokenUser(c)
+
+	res, err := th.tu.GetDrafts(c.Request().Context(), uint(userID))
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, res)
+}
+
+// PublishThread reads an optional threadImg file and an optional RFC 3339
+// publishAt from the multipart form
+func (th *ThreadHandler) PublishThread(c echo.Context) error {
+	userID, _ := _middL.ExtractTokenUser(c)
+
+	u64, err := strconv.ParseUint(c.Param("threadID"), 10, 32)
+	if err != nil {
+		return response.ErrorResponse(c, utils.ErrBadParamInput)
+	}
+
+	req := dto.PublishThreadRequest{}
+	if publishAt := c.FormValue("publishAt"); publishAt != "" {
+		t, err := time.Parse(time.RFC3339, publishAt)
+		if err != nil {
+			return response.ErrorResponse(c, utils.ErrBadParamInput)
+		}
+		req.PublishAt = &t
+	}
+
+	img, err := c.FormFile("threadImg")
+	if err != nil {
+		if !errors.Is(err, http.ErrMissingFile) {
+			return response.ErrorResponse(c, err)
+		}
+		img = nil
+	}
+
+	res, err := th.tu.PublishThread(c.Request().Context(), uint(u64), uint(userID), req, img)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, res)
+}
+
+func (th *ThreadHandler) UnscheduleThread(c echo.Context) error {
+	userID, _ := _middL.ExtractTokenUser(c)
+
+	u64, err := strconv.ParseUint(c.Param("threadID"), 10, 32)
+	if err != nil {
+		return response.ErrorResponse(c, utils.ErrBadParamInput)
+	}
+
+	err = th.tu.UnscheduleThread(c.Request().Context(), uint(u64), uint(userID))
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, nil)
+}
+
 func (th *ThreadHandler) SetThreadImage(c echo.Context) error {
 	userID, _ := _middL.ExtractTokenUser(c)
 
 	threadID := c.Param("threadID")
 	u64, err := strconv.ParseUint(threadID, 10, 32)
 	if err != nil {
-		fmt.Println(err)
 		return response.ErrorResponse(c, err)
 	}
 	threadIDUint := uint(u64)
 	img, err := c.FormFile("threadImg")
 	if err != nil {
-		fmt.Println(err)
 		return response.ErrorResponse(c, err)
 	}
 
-	err = th.tu.SetThreadImage(img, threadIDUint, uint(userID))
+	err = th.tu.SetThreadImage(c.Request().Context(), img, threadIDUint, uint(userID))
 	if err != nil {
-		fmt.Println(err)
 		return response.ErrorResponse(c, err)
 	}
 
@@ -126,11 +282,10 @@ func (th *ThreadHandler) DeleteThread(c echo.Context) error {
 	threadID := c.Param("threadID")
 	u64, err := strconv.ParseUint(threadID, 10, 32)
 	if err != nil {
-		fmt.Println(err)
 		return response.ErrorResponse(c, err)
 	}
 	threadIDUint := uint(u64)
-	if err := th.tu.DeleteThread(threadIDUint, uint(userID), role); err != nil {
+	if err := th.tu.DeleteThread(c.Request().Context(), threadIDUint, uint(userID), role); err != nil {
 		return response.ErrorResponse(c, err)
 
 	}
@@ -143,20 +298,39 @@ func (th *ThreadHandler) UpdateThread(c echo.Context) error {
 	threadID := c.Param("threadID")
 	u64, err := strconv.ParseUint(threadID, 10, 32)
 	if err != nil {
-		fmt.Println(err)
 		return response.ErrorResponse(c, err)
 	}
 	threadIDUint := uint(u64)
 
 	thread := new(dto.ThreadRequest)
 	if err := c.Bind(thread); err != nil {
-		fmt.Println(err)
 		return response.ErrorResponse(c, err)
 	}
 
-	res, err := th.tu.UpdateThread(*thread, threadIDUint, uint(userID))
+	res, err := th.tu.UpdateThread(c.Request().Context(), *thread, threadIDUint, uint(userID))
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+	return response.SuccessResponse(c, res)
+}
+
+func (th *ThreadHandler) VoteThread(c echo.Context) error {
+	userID, _ := _middL.ExtractTokenUser(c)
+
+	threadID := c.Param("threadID")
+	u64, err := strconv.ParseUint(threadID, 10, 32)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+	threadIDUint := uint(u64)
+
+	vote := new(dto.ThreadVoteRequest)
+	if err := c.Bind(vote); err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	res, err := th.tu.VoteThread(c.Request().Context(), threadIDUint, uint(userID), *vote)
 	if err != nil {
-		fmt.Println(err)
 		return response.ErrorResponse(c, err)
 	}
 	return response.SuccessResponse(c, res)
@@ -168,14 +342,12 @@ func (th *ThreadHandler) UpvoteThread(c echo.Context) error {
 	threadID := c.Param("threadID")
 	u64, err := strconv.ParseUint(threadID, 10, 32)
 	if err != nil {
-		fmt.Println(err)
 		return response.ErrorResponse(c, err)
 	}
 	threadIDUint := uint(u64)
 
-	err = th.tu.UpvoteThread(threadIDUint, uint(userID))
+	err = th.tu.UpvoteThread(c.Request().Context(), threadIDUint, uint(userID))
 	if err != nil {
-		fmt.Println(err)
 		return response.ErrorResponse(c, err)
 	}
 	return response.SuccessResponse(c, nil)
@@ -185,23 +357,20 @@ func (th *ThreadHandler) AddThreadComment(c echo.Context) error {
 	threadID := c.Param("threadID")
 	u64, err := strconv.ParseUint(threadID, 10, 32)
 	if err != nil {
-		fmt.Println(err)
 		return response.ErrorResponse(c, err)
 	}
 	threadIDUint := uint(u64)
 	userID, _ := _middL.ExtractTokenUser(c)
 	comment := new(dto.CommentRequest)
 	if err := c.Bind(comment); err != nil {
-		fmt.Println(err)
 		return response.ErrorResponse(c, err)
 	}
 
 	comment.ThreadID = threadIDUint
 	comment.UserID = uint(userID)
 
-	err = th.tu.AddThreadComment(*comment)
+	err = th.tu.AddThreadComment(c.Request().Context(), *comment)
 	if err != nil {
-		fmt.Println(err)
 		return response.ErrorResponse(c, err)
 	}
 	return response.SuccessResponse(c, nil)
@@ -211,12 +380,11 @@ func (th *ThreadHandler) GetCommentsByThreadID(c echo.Context) error {
 	threadID := c.Param("threadID")
 	u64, err := strconv.ParseUint(threadID, 10, 32)
 	if err != nil {
-		fmt.Println(err)
 		return response.ErrorResponse(c, err)
 	}
 	threadIDUint := uint(u64)
 
-	comments, err := th.tu.GetCommentsByThreadID(threadIDUint)
+	comments, err := th.tu.GetCommentsByThreadID(c.Request().Context(), threadIDUint, th.optionalUserID(c), c.QueryParam("sort"))
 
 	if err != nil {
 		return response.ErrorResponse(c, err)
@@ -225,20 +393,45 @@ func (th *ThreadHandler) GetCommentsByThreadID(c echo.Context) error {
 	return response.SuccessResponse(c, comments)
 }
 
+func (th *ThreadHandler) VoteComment(c echo.Context) error {
+	userID, _ := _middL.ExtractTokenUser(c)
+
+	u64, err := strconv.ParseUint(c.Param("threadID"), 10, 32)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+	threadIDUint := uint(u64)
+
+	u64, err = strconv.ParseUint(c.Param("commentID"), 10, 32)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+	commentIDUint := uint(u64)
+
+	vote := new(dto.CommentVoteRequest)
+	if err := c.Bind(vote); err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	res, err := th.tu.VoteComment(c.Request().Context(), threadIDUint, commentIDUint, uint(userID), *vote)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+	return response.SuccessResponse(c, res)
+}
+
 func (th *ThreadHandler) LikeComment(c echo.Context) error {
 	userID, _ := _middL.ExtractTokenUser(c)
 
 	commentID := c.Param("commentID")
 	u64, err := strconv.ParseUint(commentID, 10, 32)
 	if err != nil {
-		fmt.Println(err)
 		return response.ErrorResponse(c, err)
 	}
 	commentIDUint := uint(u64)
 
-	err = th.tu.LikeComment(commentIDUint, uint(userID))
+	err = th.tu.LikeComment(c.Request().Context(), commentIDUint, uint(userID))
 	if err != nil {
-		fmt.Println(err)
 		return response.ErrorResponse(c, err)
 	}
 	return response.SuccessResponse(c, nil)
@@ -250,14 +443,12 @@ func (th *ThreadHandler) UnlikeComment(c echo.Context) error {
 	commentID := c.Param("commentID")
 	u64, err := strconv.ParseUint(commentID, 10, 32)
 	if err != nil {
-		fmt.Println(err)
 		return response.ErrorResponse(c, err)
 	}
 	commentIDUint := uint(u64)
 
-	err = th.tu.UnlikeComment(commentIDUint, uint(userID))
+	err = th.tu.UnlikeComment(c.Request().Context(), commentIDUint, uint(userID))
 	if err != nil {
-		fmt.Println(err)
 		return response.ErrorResponse(c, err)
 	}
 	return response.SuccessResponse(c, nil)
@@ -269,14 +460,12 @@ func (th *ThreadHandler) DownvoteThread(c echo.Context) error {
 	threadID := c.Param("threadID")
 	u64, err := strconv.ParseUint(threadID, 10, 32)
 	if err != nil {
-		fmt.Println(err)
 		return response.ErrorResponse(c, err)
 	}
 	threadIDUint := uint(u64)
 
-	err = th.tu.DownvoteThread(threadIDUint, uint(userID))
+	err = th.tu.DownvoteThread(c.Request().Context(), threadIDUint, uint(userID))
 	if err != nil {
-		fmt.Println(err)
 		return response.ErrorResponse(c, err)
 	}
 	return response.SuccessResponse(c, nil)
@@ -288,14 +477,12 @@ func (th *ThreadHandler) UndoDownvoteThread(c echo.Context) error {
 	threadID := c.Param("threadID")
 	u64, err := strconv.ParseUint(threadID, 10, 32)
 	if err != nil {
-		fmt.Println(err)
 		return response.ErrorResponse(c, err)
 	}
 	threadIDUint := uint(u64)
 
-	err = th.tu.UndoDownvoteThread(threadIDUint, uint(userID))
+	err = th.tu.UndoDownvoteThread(c.Request().Context(), threadIDUint, uint(userID))
 	if err != nil {
-		fmt.Println(err)
 		return response.ErrorResponse(c, err)
 	}
 	return response.SuccessResponse(c, nil)
@@ -307,14 +494,12 @@ func (th *ThreadHandler) UndoUpvoteThread(c echo.Context) error {
 	threadID := c.Param("threadID")
 	u64, err := strconv.ParseUint(threadID, 10, 32)
 	if err != nil {
-		fmt.Println(err)
 		return response.ErrorResponse(c, err)
 	}
 	threadIDUint := uint(u64)
 
-	err = th.tu.UndoUpvoteThread(threadIDUint, uint(userID))
+	err = th.tu.UndoUpvoteThread(c.Request().Context(), threadIDUint, uint(userID))
 	if err != nil {
-		fmt.Println(err)
 		return response.ErrorResponse(c, err)
 	}
 	return response.SuccessResponse(c, nil)
@@ -326,7 +511,6 @@ func (th *ThreadHandler) DeleteComment(c echo.Context) error {
 	threadID := c.Param("threadID")
 	u64, err := strconv.ParseUint(threadID, 10, 32)
 	if err != nil {
-		fmt.Println(err)
 		return response.ErrorResponse(c, err)
 	}
 
@@ -335,7 +519,7 @@ func (th *ThreadHandler) DeleteComment(c echo.Context) error {
 	commentID := c.Param("commentID")
 	u64, err = strconv.ParseUint(commentID, 10, 32)
 	commentIDUint := uint(u64)
-	if err := th.tu.DeleteComment(commentIDUint, threadIDUint, uint(userID), role); err != nil {
+	if err := th.tu.DeleteComment(c.Request().Context(), commentIDUint, threadIDUint, uint(userID), role); err != nil {
 		return response.ErrorResponse(c, err)
 	}
 	return response.SuccessResponse(c, nil)
@@ -346,14 +530,12 @@ func (th *ThreadHandler) CreateThreadReport(c echo.Context) error {
 
 	threadReport := new(dto.ThreadReportRequest)
 	if err := c.Bind(threadReport); err != nil {
-		fmt.Println(err)
 		return response.ErrorResponse(c, err)
 	}
 
 	threadID := c.Param("threadID")
 	u64, err := strconv.ParseUint(threadID, 10, 32)
 	if err != nil {
-		fmt.Println(err)
 		return response.ErrorResponse(c, err)
 	}
 	threadIDUint := uint(u64)
@@ -361,9 +543,8 @@ func (th *ThreadHandler) CreateThreadReport(c echo.Context) error {
 	threadReport.ThreadID = threadIDUint
 	threadReport.UserID = uint(userID)
 
-	err = th.tu.CreateThreadReport(*threadReport)
+	err = th.tu.CreateThreadReport(c.Request().Context(), *threadReport)
 	if err != nil {
-		fmt.Println(err)
 		return response.ErrorResponse(c, err)
 	}
 
@@ -375,14 +556,12 @@ func (th *ThreadHandler) CreateCommentReport(c echo.Context) error {
 
 	commentReport := new(dto.CommentReportRequest)
 	if err := c.Bind(commentReport); err != nil {
-		fmt.Println(err)
 		return response.ErrorResponse(c, err)
 	}
 
 	commentID := c.Param("commentID")
 	u64, err := strconv.ParseUint(commentID, 10, 32)
 	if err != nil {
-		fmt.Println(err)
 		return response.ErrorResponse(c, err)
 	}
 	commentIDUint := uint(u64)
@@ -390,9 +569,8 @@ func (th *ThreadHandler) CreateCommentReport(c echo.Context) error {
 	commentReport.CommentID = commentIDUint
 	commentReport.UserID = uint(userID)
 
-	err = th.tu.CreateCommentReport(*commentReport)
+	err = th.tu.CreateCommentReport(c.Request().Context(), *commentReport)
 	if err != nil {
-		fmt.Println(err)
 		return response.ErrorResponse(c, err)
 	}
 
@@ -405,35 +583,340 @@ func (th *ThreadHandler) StoreSavedThread(c echo.Context) error {
 	threadID := c.Param("threadID")
 	u64, err := strconv.ParseUint(threadID, 10, 32)
 	if err != nil {
-		fmt.Println(err)
 		return response.ErrorResponse(c, err)
 	}
 	threadIDUint := uint(u64)
 
-	savedThread := dto.SavedThreadRequest{
-		UserID:   uint(userID),
-		ThreadID: threadIDUint,
+	savedThread := dto.SavedThreadRequest{}
+	if err := c.Bind(&savedThread); err != nil {
+		return response.ErrorResponse(c, utils.ErrBadParamInput)
 	}
+	savedThread.UserID = uint(userID)
+	savedThread.ThreadID = threadIDUint
 
-	err = th.tu.StoreSavedThread(savedThread)
+	err = th.tu.StoreSavedThread(c.Request().Context(), savedThread)
 	if err != nil {
-		fmt.Println(err)
 		return response.ErrorResponse(c, err)
 	}
 	return response.SuccessResponse(c, nil)
 }
 
+func (th *ThreadHandler) UpdateSavedThread(c echo.Context) error {
+	userID, _ := _middL.ExtractTokenUser(c)
+
+	u64, err := strconv.ParseUint(c.Param("threadID"), 10, 32)
+	if err != nil {
+		return response.ErrorResponse(c, utils.ErrBadParamInput)
+	}
+
+	req := dto.UpdateSavedThreadRequest{}
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, utils.ErrBadParamInput)
+	}
+
+	err = th.tu.UpdateSavedThread(c.Request().Context(), uint(u64), uint(userID), req)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+	return response.SuccessResponse(c, nil)
+}
+
+func (th *ThreadHandler) DeleteSavedThread(c echo.Context) error {
+	userID, _ := _middL.ExtractTokenUser(c)
+
+	u64, err := strconv.ParseUint(c.Param("threadID"), 10, 32)
+	if err != nil {
+		return response.ErrorResponse(c, utils.ErrBadParamInput)
+	}
+
+	err = th.tu.DeleteSavedThread(c.Request().Context(), uint(u64), uint(userID))
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+	return response.SuccessResponse(c, nil)
+}
+
+func (th *ThreadHandler) GetSavedThreadCollections(c echo.Context) error {
+	userID, _ := _middL.ExtractTokenUser(c)
+
+	res, err := th.tu.GetSavedThreadCollections(c.Request().Context(), uint(userID))
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+	return response.SuccessResponse(c, res)
+}
+
+func (th *ThreadHandler) CreateSavedThreadCollection(c echo.Context) error {
+	userID, _ := _middL.ExtractTokenUser(c)
+
+	req := dto.SavedThreadCollectionRequest{}
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, utils.ErrBadParamInput)
+	}
+
+	res, err := th.tu.CreateSavedThreadCollection(c.Request().Context(), uint(userID), req)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+	return response.SuccessResponse(c, res)
+}
+
+func (th *ThreadHandler) RenameSavedThreadCollection(c echo.Context) error {
+	userID, _ := _middL.ExtractTokenUser(c)
+
+	u64, err := strconv.ParseUint(c.Param("collectionID"), 10, 32)
+	if err != nil {
+		return response.ErrorResponse(c, utils.ErrBadParamInput)
+	}
+
+	req := dto.SavedThreadCollectionRequest{}
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, utils.ErrBadParamInput)
+	}
+
+	err = th.tu.RenameSavedThreadCollection(c.Request().Context(), uint(userID), uint(u64), req)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+	return response.SuccessResponse(c, nil)
+}
+
+func (th *ThreadHandler) DeleteSavedThreadCollection(c echo.Context) error {
+	userID, _ := _middL.ExtractTokenUser(c)
+
+	u64, err := strconv.ParseUint(c.Param("collectionID"), 10, 32)
+	if err != nil {
+		return response.ErrorResponse(c, utils.ErrBadParamInput)
+	}
+
+	err = th.tu.DeleteSavedThreadCollection(c.Request().Context(), uint(userID), uint(u64))
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+	return response.SuccessResponse(c, nil)
+}
+
+// GetSavedThreadsByCollection pages through a collection, collection 0 holds
+// the saved threads outside any collection
+func (th *ThreadHandler) GetSavedThreadsByCollection(c echo.Context) error {
+	userID, _ := _middL.ExtractTokenUser(c)
+
+	u64, err := strconv.ParseUint(c.Param("collectionID"), 10, 32)
+	if err != nil {
+		return response.ErrorResponse(c, utils.ErrBadParamInput)
+	}
+
+	page, limit := 0, 0
+	if p := c.QueryParam("page"); p != "" {
+		page, err = strconv.Atoi(p)
+		if err != nil || page < 1 {
+			return response.ErrorResponse(c, utils.ErrBadParamInput)
+		}
+	}
+	if l := c.QueryParam("limit"); l != "" {
+		limit, err = strconv.Atoi(l)
+		if err != nil || limit < 1 {
+			return response.ErrorResponse(c, utils.ErrBadParamInput)
+		}
+	}
+
+	res, err := th.tu.GetSavedThreadsByCollection(c.Request().Context(), uint(userID), uint(u64), page, limit)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+	return response.SuccessResponse(c, res)
+}
+
+// threadFollowAction runs a follow or mute action of the signed in user on
+// the thread in the path
+func (th *ThreadHandler) threadFollowAction(c echo.Context, action func(ctx context.Context, threadID, userID uint) error) error {
+	userID, _ := _middL.ExtractTokenUser(c)
+
+	u64, err := strconv.ParseUint(c.Param("threadID"), 10, 32)
+	if err != nil {
+		return response.ErrorResponse(c, utils.ErrBadParamInput)
+	}
+
+	err = action(c.Request().Context(), uint(u64), uint(userID))
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+	return response.SuccessResponse(c, nil)
+}
+
+func (th *ThreadHandler) FollowThread(c echo.Context) error {
+	return th.threadFollowAction(c, th.tu.FollowThread)
+}
+
+func (th *ThreadHandler) UnfollowThread(c echo.Context) error {
+	return th.threadFollowAction(c, th.tu.UnfollowThread)
+}
+
+func (th *ThreadHandler) MuteThread(c echo.Context) error {
+	return th.threadFollowAction(c, th.tu.MuteThread)
+}
+
+func (th *ThreadHandler) UnmuteThread(c echo.Context) error {
+	return th.threadFollowAction(c, th.tu.UnmuteThread)
+}
+
+func (th *ThreadHandler) HideThread(c echo.Context) error {
+	userID, _ := _middL.ExtractTokenUser(c)
+
+	u64, err := strconv.ParseUint(c.Param("threadID"), 10, 32)
+	if err != nil {
+		return response.ErrorResponse(c, utils.ErrBadParamInput)
+	}
+
+	err = th.tu.HideThread(c.Request().Context(), uint(u64), uint(userID))
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+	return response.SuccessResponse(c, nil)
+}
+
+func (th *ThreadHandler) UnhideThread(c echo.Context) error {
+	userID, _ := _middL.ExtractTokenUser(c)
+
+	u64, err := strconv.ParseUint(c.Param("threadID"), 10, 32)
+	if err != nil {
+		return response.ErrorResponse(c, utils.ErrBadParamInput)
+	}
+
+	err = th.tu.UnhideThread(c.Request().Context(), uint(u64), uint(userID))
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+	return response.SuccessResponse(c, nil)
+}
+
+// AddAttachment reads the image file and the altText and caption fields
+// from the multipart form, on /comments/:commentID/attachments the image
+// goes to the comment's gallery
+func (th *ThreadHandler) AddAttachment(c echo.Context) error {
+	userID, _ := _middL.ExtractTokenUser(c)
+
+	threadID, err := strconv.ParseUint(c.Param("threadID"), 10, 32)
+	if err != nil {
+		return response.ErrorResponse(c, utils.ErrBadParamInput)
+	}
+	commentID := uint64(0)
+	if c.Param("commentID") != "" {
+		commentID, err = strconv.ParseUint(c.Param("commentID"), 10, 32)
+		if err != nil {
+			return response.ErrorResponse(c, utils.ErrBadParamInput)
+		}
+	}
+
+	img, err := c.FormFile("image")
+	if err != nil {
+		return response.ErrorResponse(c, utils.ErrBadParamInput)
+	}
+	req := dto.AttachmentRequest{
+		AltText: c.FormValue("altText"),
+		Caption: c.FormValue("caption"),
+	}
+
+	res, err := th.tu.AddAttachment(c.Request().Context(), uint(threadID), uint(commentID), uint(userID), req, img)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, res)
+}
+
+func (th *ThreadHandler) UpdateAttachment(c echo.Context) error {
+	userID, _ := _middL.ExtractTokenUser(c)
+
+	threadID, err := strconv.ParseUint(c.Param("threadID"), 10, 32)
+	if err != nil {
+		return response.ErrorResponse(c, utils.ErrBadParamInput)
+	}
+	attachmentID, err := strconv.ParseUint(c.Param("attachmentID"), 10, 32)
+	if err != nil {
+		return response.ErrorResponse(c, utils.ErrBadParamInput)
+	}
+
+	req := dto.AttachmentRequest{}
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, utils.ErrBadParamInput)
+	}
+
+	err = th.tu.UpdateAttachment(c.Request().Context(), uint(threadID), uint(attachmentID), uint(userID), req)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, nil)
+}
+
+func (th *ThreadHandler) ReorderAttachments(c echo.Context) error {
+	userID, _ := _middL.ExtractTokenUser(c)
+
+	threadID, err := strconv.ParseUint(c.Param("threadID"), 10, 32)
+	if err != nil {
+		return response.ErrorResponse(c, utils.ErrBadParamInput)
+	}
+
+	req := dto.ReorderAttachmentsRequest{}
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, utils.ErrBadParamInput)
+	}
+
+	err = th.tu.ReorderAttachments(c.Request().Context(), uint(threadID), uint(userID), req)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, nil)
+}
+
+func (th *ThreadHandler) DeleteAttachment(c echo.Context) error {
+	userID, role := _middL.ExtractTokenUser(c)
+
+	threadID, err := strconv.ParseUint(c.Param("threadID"), 10, 32)
+	if err != nil {
+		return response.ErrorResponse(c, utils.ErrBadParamInput)
+	}
+	attachmentID, err := strconv.ParseUint(c.Param("attachmentID"), 10, 32)
+	if err != nil {
+		return response.ErrorResponse(c, utils.ErrBadParamInput)
+	}
+
+	err = th.tu.DeleteAttachment(c.Request().Context(), uint(threadID), uint(attachmentID), uint(userID), role)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, nil)
+}
+
 func CreateNewThreadHandler(e *echo.Echo, tu thread.ThreadUseCase, JWTSecret string) *ThreadHandler {
-	threadHandler := &ThreadHandler{router: e, tu: tu}
+	threadHandler := &ThreadHandler{router: e, tu: tu, tokenUser: _middL.TokenUserID(JWTSecret)}
 	threadHandler.router.POST("/api/v1/threads", threadHandler.CreateThread, middleware.JWT([]byte(JWTSecret)))
 	threadHandler.router.DELETE("/api/v1/threads/:threadID", threadHandler.DeleteThread, middleware.JWT([]byte(JWTSecret)))
+	threadHandler.router.GET("/api/v1/threads/drafts", threadHandler.GetDrafts, middleware.JWT([]byte(JWTSecret)))
+	threadHandler.router.POST("/api/v1/threads/drafts", threadHandler.CreateDraft, middleware.JWT([]byte(JWTSecret)))
+	threadHandler.router.POST("/api/v1/threads/:threadID/publish", threadHandler.PublishThread, middleware.JWT([]byte(JWTSecret)))
+	threadHandler.router.DELETE("/api/v1/threads/:threadID/publish", threadHandler.UnscheduleThread, middleware.JWT([]byte(JWTSecret)))
 	threadHandler.router.GET("/api/v1/threads", threadHandler.GetThreads, middleware.JWT([]byte(JWTSecret)))
+	threadHandler.router.GET("/api/v1/threads/trending", threadHandler.GetTrendingThreads, middleware.JWT([]byte(JWTSecret)))
+	threadHandler.router.GET("/api/v1/threads/trending/:timeframe", threadHandler.GetTrendingThreadsByTimeframe, middleware.JWT([]byte(JWTSecret)))
+	threadHandler.router.GET("/api/v1/communities/:communityID/threads/trending", threadHandler.GetCommunityTrendingThreads, middleware.JWT([]byte(JWTSecret)))
 	threadHandler.router.GET("/api/v1/threads/:threadID", threadHandler.GetThreadByID)
 	threadHandler.router.PUT("/api/v1/threads/:threadID", threadHandler.UpdateThread, middleware.JWT([]byte(JWTSecret)))
 	threadHandler.router.PUT("/api/v1/threads/:threadID/images", threadHandler.SetThreadImage, middleware.JWT([]byte(JWTSecret)))
+	threadHandler.router.POST("/api/v1/threads/:threadID/attachments", threadHandler.AddAttachment, middleware.JWT([]byte(JWTSecret)))
+	threadHandler.router.PUT("/api/v1/threads/:threadID/attachments/order", threadHandler.ReorderAttachments, middleware.JWT([]byte(JWTSecret)))
+	threadHandler.router.PUT("/api/v1/threads/:threadID/attachments/:attachmentID", threadHandler.UpdateAttachment, middleware.JWT([]byte(JWTSecret)))
+	threadHandler.router.DELETE("/api/v1/threads/:threadID/attachments/:attachmentID", threadHandler.DeleteAttachment, middleware.JWT([]byte(JWTSecret)))
+	threadHandler.router.POST("/api/v1/threads/:threadID/comments/:commentID/attachments", threadHandler.AddAttachment, middleware.JWT([]byte(JWTSecret)))
+	threadHandler.router.DELETE("/api/v1/threads/:threadID/comments/:commentID/attachments/:attachmentID", threadHandler.DeleteAttachment, middleware.JWT([]byte(JWTSecret)))
+	threadHandler.router.PUT("/api/v1/threads/:threadID/votes", threadHandler.VoteThread, middleware.JWT([]byte(JWTSecret)))
 	threadHandler.router.POST("/api/v1/threads/:threadID/upvotes", threadHandler.UpvoteThread, middleware.JWT([]byte(JWTSecret)))
 	threadHandler.router.POST("/api/v1/threads/:threadID/comments", threadHandler.AddThreadComment, middleware.JWT([]byte(JWTSecret)))
 	threadHandler.router.GET("/api/v1/threads/:threadID/comments", threadHandler.GetCommentsByThreadID)
+	threadHandler.router.PUT("/api/v1/threads/:threadID/comments/:commentID/votes", threadHandler.VoteComment, middleware.JWT([]byte(JWTSecret)))
 	threadHandler.router.POST("/api/v1/threads/:threadID/comments/:commentID/likes", threadHandler.LikeComment, middleware.JWT([]byte(JWTSecret)))
 	threadHandler.router.POST("/api/v1/threads/:threadID/downvotes", threadHandler.DownvoteThread, middleware.JWT([]byte(JWTSecret)))
 	threadHandler.router.DELETE("/api/v1/threads/:threadID/upvotes", threadHandler.UndoUpvoteThread, middleware.JWT([]byte(JWTSecret)))
@@ -443,5 +926,18 @@ func CreateNewThreadHandler(e *echo.Echo, tu thread.ThreadUseCase, JWTSecret str
 	threadHandler.router.POST("/api/v1/threads/:threadID/reports", threadHandler.CreateThreadReport, middleware.JWT([]byte(JWTSecret)))
 	threadHandler.router.POST("/api/v1/threads/:threadID/comments/:commentID/reports", threadHandler.CreateCommentReport, middleware.JWT([]byte(JWTSecret)))
 	threadHandler.router.POST("/api/v1/threads/:threadID/saved", threadHandler.StoreSavedThread, middleware.JWT([]byte(JWTSecret)))
+	threadHandler.router.PUT("/api/v1/threads/:threadID/saved", threadHandler.UpdateSavedThread, middleware.JWT([]byte(JWTSecret)))
+	threadHandler.router.DELETE("/api/v1/threads/:threadID/saved", threadHandler.DeleteSavedThread, middleware.JWT([]byte(JWTSecret)))
+	threadHandler.router.GET("/api/v1/saved-collections", threadHandler.GetSavedThreadCollections, middleware.JWT([]byte(JWTSecret)))
+	threadHandler.router.POST("/api/v1/saved-collections", threadHandler.CreateSavedThreadCollection, middleware.JWT([]byte(JWTSecret)))
+	threadHandler.router.PUT("/api/v1/saved-collections/:collectionID", threadHandler.RenameSavedThreadCollection, middleware.JWT([]byte(JWTSecret)))
+	threadHandler.router.DELETE("/api/v1/saved-collections/:collectionID", threadHandler.DeleteSavedThreadCollection, middleware.JWT([]byte(JWTSecret)))
+	threadHandler.router.GET("/api/v1/saved-collections/:collectionID/threads", threadHandler.GetSavedThreadsByCollection, middleware.JWT([]byte(JWTSecret)))
+	threadHandler.router.POST("/api/v1/threads/:threadID/followers", threadHandler.FollowThread, middleware.JWT([]byte(JWTSecret)))
+	threadHandler.router.DELETE("/api/v1/threads/:threadID/followers", threadHandler.UnfollowThread, middleware.JWT([]byte(JWTSecret)))
+	threadHandler.router.POST("/api/v1/threads/:threadID/mute", threadHandler.MuteThread, middleware.JWT([]byte(JWTSecret)))
+	threadHandler.router.DELETE("/api/v1/threads/:threadID/mute", threadHandler.UnmuteThread, middleware.JWT([]byte(JWTSecret)))
+	threadHandler.router.POST("/api/v1/threads/:threadID/hidden", threadHandler.HideThread, middleware.JWT([]byte(JWTSecret)))
+	threadHandler.router.DELETE("/api/v1/threads/:threadID/hidden", threadHandler.UnhideThread, middleware.JWT([]byte(JWTSecret)))
 	return threadHandler
 }
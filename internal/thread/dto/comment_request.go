@@ -1,8 +1,25 @@
 package dto
 
+// CommentRequest carries a comment, Body is Markdown and counted in
+// characters
 type CommentRequest struct {
-	Body      string `json:"body"`
+	Body      string `json:"body" validate:"max=5000"`
 	UserID    uint   `json:"userID"`
 	ThreadID  uint   `json:"threadID"`
 	CommentID uint   `json:"commentID"`
 }
+
+// CommentVoteRequest carries the vote to hold on a comment, 1 for an
+// upvote, -1 for a downvote and 0 to take the vote back
+type CommentVoteRequest struct {
+	Value *int `json:"value"`
+}
+
+// Sort modes accepted when listing the comments of a thread
+const (
+	CommentSortBest          = "best"
+	CommentSortNew           = "new"
+	CommentSortOld           = "old"
+	CommentSortTop           = "top"
+	CommentSortControversial = "controversial"
+)
@@ -3,12 +3,28 @@ package dto
 import "time"
 
 type CommentResponse struct {
-	ID                    uint      `json:"id"`
-	Body                  string    `json:"body"`
-	UserID                uint      `json:"userID"`
-	Username              string    `json:"username"`
-	UserProfilePictureURL string    `json:"userProfilePictureURL"`
-	ThreadID              uint      `json:"threadID"`
-	CreatedAt             time.Time `json:"createdAt"`
-	LikesCount            int       `json:"likesCount"`
+	ID                    uint                 `json:"id"`
+	Body                  string               `json:"body"`
+	BodyHTML              string               `json:"bodyHTML"`
+	BodyText              string               `json:"bodyText"`
+	UserID                uint                 `json:"userID"`
+	Username              string               `json:"username"`
+	UserProfilePictureURL string               `json:"userProfilePictureURL"`
+	ThreadID              uint                 `json:"threadID"`
+	CreatedAt             time.Time            `json:"createdAt"`
+	LikesCount            int                  `json:"likesCount"`
+	Score                 int                  `json:"score"`
+	UpvotesCount          int                  `json:"upvotesCount"`
+	DownvotesCount        int                  `json:"downvotesCount"`
+	UserVote              int                  `json:"userVote"`
+	IsMine                bool                 `json:"isMine"`
+	Attachments           []AttachmentResponse `json:"attachments"`
+}
+
+type CommentVoteResponse struct {
+	CommentID      uint `json:"commentID"`
+	Score          int  `json:"score"`
+	UpvotesCount   int  `json:"upvotesCount"`
+	DownvotesCount int  `json:"downvotesCount"`
+	UserVote       int  `json:"userVote"`
 }
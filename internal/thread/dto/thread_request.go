@@ -1,8 +1,11 @@
 package dto
 
+import "time"
+
+// ThreadRequest carries a thread, Body is Markdown and counted in characters
 type ThreadRequest struct {
 	Title       string `json:"title"`
-	Body        string `json:"body"`
+	Body        string `json:"body" validate:"max=10000"`
 	CommunityID uint   `json:"communityID"`
 }
 
@@ -19,6 +22,45 @@ type CommentReportRequest struct {
 }
 
 type SavedThreadRequest struct {
-	UserID   uint
-	ThreadID uint
+	UserID       uint   `json:"-"`
+	ThreadID     uint   `json:"-"`
+	CollectionID *uint  `json:"collectionID"`
+	Note         string `json:"note"`
+}
+
+// UpdateSavedThreadRequest moves a saved thread or edits its note, a nil
+// field is left as is and a zero CollectionID takes the thread out of its
+// collection
+type UpdateSavedThreadRequest struct {
+	CollectionID *uint   `json:"collectionID"`
+	Note         *string `json:"note"`
+}
+
+type SavedThreadCollectionRequest struct {
+	Name string `json:"name"`
+}
+
+// ThreadVoteRequest carries the vote to hold on a thread, 1 for an upvote,
+// -1 for a downvote and 0 to take the vote back
+type ThreadVoteRequest struct {
+	Value *int `json:"value"`
+}
+
+// PublishThreadRequest publishes a draft, a PublishAt in the future
+// schedules it instead
+type PublishThreadRequest struct {
+	PublishAt *time.Time `json:"publishAt"`
+}
+
+// AttachmentRequest describes an image added to a gallery, an update
+// replaces both fields
+type AttachmentRequest struct {
+	AltText string `json:"altText" form:"altText"`
+	Caption string `json:"caption" form:"caption"`
+}
+
+// ReorderAttachmentsRequest lists every attachment of a thread gallery in
+// its new order
+type ReorderAttachmentsRequest struct {
+	AttachmentIDs []uint `json:"attachmentIDs"`
 }
@@ -5,30 +5,106 @@ import (
 )
 
 type ThreadResponse struct {
-	ID          uint      `json:"ID"`
-	Title       string    `json:"title"`
-	Body        string    `json:"body"`
-	CommunityID uint      `json:"communityID"`
-	ImageURL    string    `json:"imageURL"`
-	UserID      uint      `json:"userID"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
+	ID          uint                 `json:"ID"`
+	Title       string               `json:"title"`
+	Body        string               `json:"body"`
+	BodyHTML    string               `json:"bodyHTML"`
+	BodyText    string               `json:"bodyText"`
+	CommunityID uint                 `json:"communityID"`
+	ImageURL    string               `json:"imageURL"`
+	UserID      uint                 `json:"userID"`
+	CreatedAt   time.Time            `json:"createdAt"`
+	UpdatedAt   time.Time            `json:"updatedAt"`
+	Preview     *LinkPreviewResponse `json:"preview"`
+	// Attachments is the gallery, only filled when a single thread is read
+	Attachments []AttachmentResponse `json:"attachments,omitempty"`
 }
 
 type DetailedThreadResponse struct {
-	ID                    uint      `json:"ID"`
-	Title                 string    `json:"title"`
-	Body                  string    `json:"body"`
-	CommunityID           uint      `json:"communityID"`
-	ImageURL              string    `json:"imageURL"`
-	UserID                uint      `json:"userID"`
-	UserName              string    `json:"userName"`
-	UserProfession        string    `json:"userProfession"`
-	UserProfilePictureURL string    `json:"userProfilePictureURL"`
-	CreatedAt             time.Time `json:"createdAt"`
-	UpdatedAt             time.Time `json:"updatedAt"`
-	UpvotesCount          int       `json:"upvotesCount"`
-	IsUpvoted             int       `json:"isUpvoted"`
-	IsDownVoted           int       `json:"isDownvoted"`
-	IsFollowed            int       `json:"isFollowed"`
+	ID                    uint                 `json:"ID"`
+	Title                 string               `json:"title"`
+	Body                  string               `json:"body"`
+	BodyHTML              string               `json:"bodyHTML"`
+	BodyText              string               `json:"bodyText"`
+	CommunityID           uint                 `json:"communityID"`
+	ImageURL              string               `json:"imageURL"`
+	UserID                uint                 `json:"userID"`
+	UserName              string               `json:"userName"`
+	UserProfession        string               `json:"userProfession"`
+	UserProfilePictureURL string               `json:"userProfilePictureURL"`
+	CreatedAt             time.Time            `json:"createdAt"`
+	UpdatedAt             time.Time            `json:"updatedAt"`
+	Score                 int                  `json:"score"`
+	UpvotesCount          int                  `json:"upvotesCount"`
+	DownvotesCount        int                  `json:"downvotesCount"`
+	UserVote              int                  `json:"userVote"`
+	IsUpvoted             int                  `json:"isUpvoted"`
+	IsDownVoted           int                  `json:"isDownvoted"`
+	IsFollowed            int                  `json:"isFollowed"`
+	Preview               *LinkPreviewResponse `json:"preview"`
+}
+
+type ThreadVoteResponse struct {
+	ThreadID       uint `json:"threadID"`
+	Score          int  `json:"score"`
+	UpvotesCount   int  `json:"upvotesCount"`
+	DownvotesCount int  `json:"downvotesCount"`
+	UserVote       int  `json:"userVote"`
+}
+
+type SavedThreadResponse struct {
+	DetailedThreadResponse
+	CollectionID *uint     `json:"collectionID"`
+	Note         string    `json:"note"`
+	SavedAt      time.Time `json:"savedAt"`
+}
+
+type SavedThreadPageResponse struct {
+	Threads []SavedThreadResponse `json:"threads"`
+	Page    int                   `json:"page"`
+	Limit   int                   `json:"limit"`
+	Total   int64                 `json:"total"`
+}
+
+type SavedThreadCollectionResponse struct {
+	ID         uint      `json:"ID"`
+	Name       string    `json:"name"`
+	SavedCount int       `json:"savedCount"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+type SavedThreadCollectionsResponse struct {
+	Collections []SavedThreadCollectionResponse `json:"collections"`
+	// UnsortedCount counts the saved threads outside any collection
+	UnsortedCount int64 `json:"unsortedCount"`
+	TotalCount    int64 `json:"totalCount"`
+}
+
+// DraftThreadResponse is a thread that is not published yet, PublishAt is
+// set while it is scheduled
+type DraftThreadResponse struct {
+	ThreadResponse
+	Status    string     `json:"status"`
+	PublishAt *time.Time `json:"publishAt"`
+}
+
+type AttachmentResponse struct {
+	ID        uint   `json:"ID"`
+	ImageURL  string `json:"imageURL"`
+	AltText   string `json:"altText"`
+	Caption   string `json:"caption"`
+	Width     int    `json:"width"`
+	Height    int    `json:"height"`
+	Position  int    `json:"position"`
+	CommentID uint   `json:"commentID"`
+}
+
+// LinkPreviewResponse unfurls the first link of a thread body
+type LinkPreviewResponse struct {
+	URL         string `json:"url"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	ImageURL    string `json:"imageURL"`
+	SiteName    string `json:"siteName"`
 }
@@ -4,50 +4,90 @@ import (
 	communityentity "macaiki/internal/community/entity"
 	reportCategoryEntity "macaiki/internal/report_category/entity"
 	userEntity "macaiki/internal/user/entity"
+	"time"
 
 	"gorm.io/gorm"
 )
 
 type Thread struct {
 	gorm.Model
-	Title       string
-	Body        string
+	Title string
+	Body  string
+	// BodyHTML and BodyText are Body rendered as Markdown when it is written
+	BodyHTML    string
+	BodyText    string
 	ImageURL    string
 	UserID      uint
 	CommunityID uint
-	User        userEntity.User
-	Community   communityentity.Community
+	// Score and the vote counts mirror thread_votes, they are only changed
+	// together with a vote inside SetThreadVote
+	Score          int `gorm:"not null;default:0"`
+	UpvotesCount   int `gorm:"not null;default:0"`
+	DownvotesCount int `gorm:"not null;default:0"`
+	// Status is draft, scheduled or published. Publishing resets CreatedAt
+	// so the thread ages in feeds from the moment it went out.
+	Status    string `gorm:"size:16;not null;default:published;index"`
+	PublishAt *time.Time
+	// Preview unfurls the first link of Body, it is filled in the background
+	// after the thread is written
+	Preview   LinkPreview `gorm:"embedded;embeddedPrefix:preview_"`
+	User      userEntity.User
+	Community communityentity.Community
 }
 
-type ThreadUpvote struct {
-	gorm.Model
-	UserID   uint `gorm:"index:unique_upvote,unique"`
-	ThreadID uint `gorm:"index:unique_upvote,unique"`
-	Thread   Thread
-	User     userEntity.User
+const (
+	ThreadStatusDraft     = "draft"
+	ThreadStatusScheduled = "scheduled"
+	ThreadStatusPublished = "published"
+)
+
+// LinkPreview is the Open Graph or Twitter card metadata of a link, a zero
+// URL means there is no preview
+type LinkPreview struct {
+	URL         string `gorm:"size:2048"`
+	Title       string `gorm:"size:300"`
+	Description string `gorm:"size:1000"`
+	ImageURL    string `gorm:"size:2048"`
+	SiteName    string `gorm:"size:200"`
 }
 
-type ThreadDownvote struct {
-	gorm.Model
-	UserID   uint `gorm:"index:unique_downvote,unique"`
-	ThreadID uint `gorm:"index:unique_downvote,unique"`
-	Thread   Thread
-	User     userEntity.User
+// IsPublished reports whether the thread is out of its author's drafts, a
+// thread stored before drafts existed has no status and counts as published
+func (t Thread) IsPublished() bool {
+	return t.Status != ThreadStatusDraft && t.Status != ThreadStatusScheduled
+}
+
+const (
+	VoteDown = -1
+	VoteNone = 0
+	VoteUp   = 1
+)
+
+// ThreadVote is the single vote a user holds on a thread, Value is VoteUp
+// or VoteDown. Taking the vote back deletes the row.
+type ThreadVote struct {
+	ID        uint `gorm:"primaryKey"`
+	UserID    uint `gorm:"uniqueIndex:idx_thread_votes_user_thread"`
+	ThreadID  uint `gorm:"uniqueIndex:idx_thread_votes_user_thread;index"`
+	Value     int
+	CreatedAt time.Time
+	UpdatedAt time.Time
 }
 
 type ThreadWithDetails struct {
 	Thread
 	userEntity.User
-	UpvotesCount int
-	IsUpvoted    int
-	IsFollowed   int
-	IsDownvoted  int
+	UserVote   int
+	IsFollowed int
 }
 
+// ThreadFollower subscribes a user to the new comments of a thread. A muted
+// follower gets no notifications and is not subscribed again by commenting.
 type ThreadFollower struct {
 	gorm.Model
-	ThreadID uint
-	UserID   uint
+	ThreadID uint `gorm:"uniqueIndex:idx_thread_followers_thread_user"`
+	UserID   uint `gorm:"uniqueIndex:idx_thread_followers_thread_user"`
+	Muted    bool `gorm:"not null;default:false"`
 	Thread   Thread
 	User     userEntity.User
 }
@@ -55,26 +95,35 @@ type ThreadFollower struct {
 type Comment struct {
 	gorm.Model
 	Body      string
+	BodyHTML  string
+	BodyText  string
 	UserID    uint
 	ThreadID  uint
 	CommentID uint
-	Thread    Thread
-	User      userEntity.User
+	// like on threads, the score and counts mirror comment_votes and are
+	// only changed inside SetCommentVote
+	Score          int `gorm:"not null;default:0"`
+	UpvotesCount   int `gorm:"not null;default:0"`
+	DownvotesCount int `gorm:"not null;default:0"`
+	Thread         Thread
+	User           userEntity.User
 }
 
 type CommentDetails struct {
 	Comment
 	userEntity.User
-	LikesCount int
-	IsLiked    int
+	UserVote int
 }
 
-type CommentLikes struct {
-	gorm.Model
-	UserID    uint `gorm:"index:unique_likes,unique"`
-	CommentID uint `gorm:"index:unique_likes,unique"`
-	User      userEntity.User
-	Comment   Comment
+// CommentVote is the single vote a user holds on a comment, Value is VoteUp
+// or VoteDown. Taking the vote back deletes the row.
+type CommentVote struct {
+	ID        uint `gorm:"primaryKey"`
+	UserID    uint `gorm:"uniqueIndex:idx_comment_votes_user_comment"`
+	CommentID uint `gorm:"uniqueIndex:idx_comment_votes_user_comment;index"`
+	Value     int
+	CreatedAt time.Time
+	UpdatedAt time.Time
 }
 
 type ThreadReport struct {
@@ -103,4 +152,86 @@ type SavedThread struct {
 	ThreadID uint `gorm:"index:unique_saved_thread,unique"`
 	User     userEntity.User
 	Thread   Thread
+	// CollectionID is nil for a thread that is not filed in a collection
+	CollectionID *uint `gorm:"index"`
+	// Note is private to the user who saved the thread
+	Note string `gorm:"size:500"`
+}
+
+// SavedThreadCollection is a named folder a user files saved threads in
+type SavedThreadCollection struct {
+	ID         uint   `gorm:"primaryKey"`
+	UserID     uint   `gorm:"uniqueIndex:idx_saved_thread_collections_user_name"`
+	Name       string `gorm:"size:50;uniqueIndex:idx_saved_thread_collections_user_name"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	SavedCount int `gorm:"-:migration;<-:false"`
+}
+
+// SavedThreadDetails is a saved thread as its owner lists it
+type SavedThreadDetails struct {
+	ThreadWithDetails
+	CollectionID *uint
+	Note         string
+	SavedAt      time.Time
+}
+
+// ThreadScore holds the precomputed trending scores of a thread, the rows
+// are rebuilt periodically by RefreshThreadScores
+type ThreadScore struct {
+	ThreadID    uint    `gorm:"primaryKey;autoIncrement:false"`
+	CommunityID uint    `gorm:"index"`
+	HotScore    float64 `gorm:"index"`
+	DayScore    float64 `gorm:"index"`
+	WeekScore   float64 `gorm:"index"`
+	MonthScore  float64 `gorm:"index"`
+	AllScore    float64 `gorm:"index"`
+	ComputedAt  time.Time
+}
+
+// ThreadActivity is the vote and comment activity of a thread the trending
+// scores are computed from, the Day, Week and Month counts only include
+// votes and comments from that window
+type ThreadActivity struct {
+	ThreadID       uint
+	CommunityID    uint
+	CreatedAt      time.Time
+	UpvotesCount   int
+	DownvotesCount int
+	CommentsCount  int
+	DayUpvotes     int
+	DayDownvotes   int
+	DayComments    int
+	WeekUpvotes    int
+	WeekDownvotes  int
+	WeekComments   int
+	MonthUpvotes   int
+	MonthDownvotes int
+	MonthComments  int
+}
+
+// HiddenThread keeps a thread out of the feeds of the user who hid it
+type HiddenThread struct {
+	ID        uint `gorm:"primaryKey"`
+	UserID    uint `gorm:"uniqueIndex:idx_hidden_threads_user_thread"`
+	ThreadID  uint `gorm:"uniqueIndex:idx_hidden_threads_user_thread"`
+	CreatedAt time.Time
+}
+
+// Attachment is an image in the gallery of a thread, or of one of its
+// comments when CommentID is set. Position orders the gallery and FileName
+// is the image stored under the thread directory.
+type Attachment struct {
+	ID        uint `gorm:"primaryKey"`
+	ThreadID  uint `gorm:"index"`
+	CommentID uint `gorm:"index"`
+	UserID    uint
+	FileName  string
+	AltText   string `gorm:"size:500"`
+	Caption   string `gorm:"size:300"`
+	Width     int
+	Height    int
+	Position  int
+	CreatedAt time.Time
+	UpdatedAt time.Time
 }
@@ -0,0 +1,26 @@
+package thread
+
+// JobPublishThread is the job type used to publish a scheduled thread once
+// its publish time is reached, its payload is a PublishThreadPayload
+const JobPublishThread = "thread.publish"
+
+type PublishThreadPayload struct {
+	ThreadID uint
+}
+
+// JobUnfurlThread is the job type used to refresh the link preview of a
+// thread after its body was written, its payload is an UnfurlThreadPayload
+const JobUnfurlThread = "thread.unfurl"
+
+type UnfurlThreadPayload struct {
+	ThreadID uint
+}
+
+// JobNotifyFollowers is the job type used to tell the followers of a thread
+// about a new comment, its payload is a NotifyFollowersPayload
+const JobNotifyFollowers = "thread.notify_followers"
+
+type NotifyFollowersPayload struct {
+	ThreadID    uint
+	CommenterID uint
+}
@@ -3,9 +3,12 @@
 package mocks
 
 import (
+	context "context"
 	entity "macaiki/internal/thread/entity"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // ThreadRepository is an autogenerated mock type for the ThreadRepository type
@@ -13,13 +16,13 @@ type ThreadRepository struct {
 	mock.Mock
 }
 
-// AddThreadComment provides a mock function with given fields: comment
-func (_m *ThreadRepository) AddThreadComment(comment entity.Comment) error {
-	ret := _m.Called(comment)
+// AddThreadComment provides a mock function with given fields: ctx, comment
+func (_m *ThreadRepository) AddThreadComment(ctx context.Context, comment entity.Comment) error {
+	ret := _m.Called(ctx, comment)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(entity.Comment) error); ok {
-		r0 = rf(comment)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Comment) error); ok {
+		r0 = rf(ctx, comment)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -27,13 +30,55 @@ func (_m *ThreadRepository) AddThreadComment(comment entity.Comment) error {
 	return r0
 }
 
-// CreateCommentReport provides a mock function with given fields: commentReport
-func (_m *ThreadRepository) CreateCommentReport(commentReport entity.CommentReport) error {
-	ret := _m.Called(commentReport)
+// CountSavedThreads provides a mock function with given fields: ctx, userID, collectionID
+func (_m *ThreadRepository) CountSavedThreads(ctx context.Context, userID uint, collectionID uint) (int64, error) {
+	ret := _m.Called(ctx, userID, collectionID)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) int64); ok {
+		r0 = rf(ctx, userID, collectionID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, userID, collectionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateAttachment provides a mock function with given fields: ctx, attachment, limit
+func (_m *ThreadRepository) CreateAttachment(ctx context.Context, attachment entity.Attachment, limit int) (entity.Attachment, error) {
+	ret := _m.Called(ctx, attachment, limit)
+
+	var r0 entity.Attachment
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Attachment, int) entity.Attachment); ok {
+		r0 = rf(ctx, attachment, limit)
+	} else {
+		r0 = ret.Get(0).(entity.Attachment)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Attachment, int) error); ok {
+		r1 = rf(ctx, attachment, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateCommentReport provides a mock function with given fields: ctx, commentReport
+func (_m *ThreadRepository) CreateCommentReport(ctx context.Context, commentReport entity.CommentReport) error {
+	ret := _m.Called(ctx, commentReport)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(entity.CommentReport) error); ok {
-		r0 = rf(commentReport)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.CommentReport) error); ok {
+		r0 = rf(ctx, commentReport)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -41,20 +86,41 @@ func (_m *ThreadRepository) CreateCommentReport(commentReport entity.CommentRepo
 	return r0
 }
 
-// CreateThread provides a mock function with given fields: _a0
-func (_m *ThreadRepository) CreateThread(_a0 entity.Thread) (entity.Thread, error) {
-	ret := _m.Called(_a0)
+// CreateSavedThreadCollection provides a mock function with given fields: ctx, collection
+func (_m *ThreadRepository) CreateSavedThreadCollection(ctx context.Context, collection entity.SavedThreadCollection) (entity.SavedThreadCollection, error) {
+	ret := _m.Called(ctx, collection)
+
+	var r0 entity.SavedThreadCollection
+	if rf, ok := ret.Get(0).(func(context.Context, entity.SavedThreadCollection) entity.SavedThreadCollection); ok {
+		r0 = rf(ctx, collection)
+	} else {
+		r0 = ret.Get(0).(entity.SavedThreadCollection)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, entity.SavedThreadCollection) error); ok {
+		r1 = rf(ctx, collection)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateThread provides a mock function with given fields: ctx, _a1
+func (_m *ThreadRepository) CreateThread(ctx context.Context, _a1 entity.Thread) (entity.Thread, error) {
+	ret := _m.Called(ctx, _a1)
 
 	var r0 entity.Thread
-	if rf, ok := ret.Get(0).(func(entity.Thread) entity.Thread); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Thread) entity.Thread); ok {
+		r0 = rf(ctx, _a1)
 	} else {
 		r0 = ret.Get(0).(entity.Thread)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(entity.Thread) error); ok {
-		r1 = rf(_a0)
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Thread) error); ok {
+		r1 = rf(ctx, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -62,13 +128,41 @@ func (_m *ThreadRepository) CreateThread(_a0 entity.Thread) (entity.Thread, erro
 	return r0, r1
 }
 
-// CreateThreadReport provides a mock function with given fields: threadReport
-func (_m *ThreadRepository) CreateThreadReport(threadReport entity.ThreadReport) error {
-	ret := _m.Called(threadReport)
+// CreateThreadReport provides a mock function with given fields: ctx, threadReport
+func (_m *ThreadRepository) CreateThreadReport(ctx context.Context, threadReport entity.ThreadReport) error {
+	ret := _m.Called(ctx, threadReport)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.ThreadReport) error); ok {
+		r0 = rf(ctx, threadReport)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteAttachment provides a mock function with given fields: ctx, attachmentID
+func (_m *ThreadRepository) DeleteAttachment(ctx context.Context, attachmentID uint) error {
+	ret := _m.Called(ctx, attachmentID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, attachmentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteComment provides a mock function with given fields: ctx, commentID
+func (_m *ThreadRepository) DeleteComment(ctx context.Context, commentID uint) error {
+	ret := _m.Called(ctx, commentID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(entity.ThreadReport) error); ok {
-		r0 = rf(threadReport)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, commentID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -76,13 +170,13 @@ func (_m *ThreadRepository) CreateThreadReport(threadReport entity.ThreadReport)
 	return r0
 }
 
-// DeleteComment provides a mock function with given fields: commentID
-func (_m *ThreadRepository) DeleteComment(commentID uint) error {
-	ret := _m.Called(commentID)
+// DeleteSavedThread provides a mock function with given fields: ctx, userID, threadID
+func (_m *ThreadRepository) DeleteSavedThread(ctx context.Context, userID uint, threadID uint) error {
+	ret := _m.Called(ctx, userID, threadID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint) error); ok {
-		r0 = rf(commentID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, userID, threadID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -90,13 +184,13 @@ func (_m *ThreadRepository) DeleteComment(commentID uint) error {
 	return r0
 }
 
-// DeleteThread provides a mock function with given fields: threadID
-func (_m *ThreadRepository) DeleteThread(threadID uint) error {
-	ret := _m.Called(threadID)
+// DeleteSavedThreadCollection provides a mock function with given fields: ctx, userID, collectionID
+func (_m *ThreadRepository) DeleteSavedThreadCollection(ctx context.Context, userID uint, collectionID uint) error {
+	ret := _m.Called(ctx, userID, collectionID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint) error); ok {
-		r0 = rf(threadID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, userID, collectionID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -104,13 +198,13 @@ func (_m *ThreadRepository) DeleteThread(threadID uint) error {
 	return r0
 }
 
-// DownvoteThread provides a mock function with given fields: downvote
-func (_m *ThreadRepository) DownvoteThread(downvote entity.ThreadDownvote) error {
-	ret := _m.Called(downvote)
+// DeleteThread provides a mock function with given fields: ctx, threadID
+func (_m *ThreadRepository) DeleteThread(ctx context.Context, threadID uint) error {
+	ret := _m.Called(ctx, threadID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(entity.ThreadDownvote) error); ok {
-		r0 = rf(downvote)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, threadID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -118,20 +212,78 @@ func (_m *ThreadRepository) DownvoteThread(downvote entity.ThreadDownvote) error
 	return r0
 }
 
-// GetCommentByID provides a mock function with given fields: commentID
-func (_m *ThreadRepository) GetCommentByID(commentID uint) (entity.Comment, error) {
-	ret := _m.Called(commentID)
+// DeleteThreadFollower provides a mock function with given fields: ctx, threadID, userID
+func (_m *ThreadRepository) DeleteThreadFollower(ctx context.Context, threadID uint, userID uint) error {
+	ret := _m.Called(ctx, threadID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, threadID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAttachment provides a mock function with given fields: ctx, attachmentID
+func (_m *ThreadRepository) GetAttachment(ctx context.Context, attachmentID uint) (entity.Attachment, error) {
+	ret := _m.Called(ctx, attachmentID)
+
+	var r0 entity.Attachment
+	if rf, ok := ret.Get(0).(func(context.Context, uint) entity.Attachment); ok {
+		r0 = rf(ctx, attachmentID)
+	} else {
+		r0 = ret.Get(0).(entity.Attachment)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, attachmentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAttachmentsByThreadID provides a mock function with given fields: ctx, threadID
+func (_m *ThreadRepository) GetAttachmentsByThreadID(ctx context.Context, threadID uint) ([]entity.Attachment, error) {
+	ret := _m.Called(ctx, threadID)
+
+	var r0 []entity.Attachment
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []entity.Attachment); ok {
+		r0 = rf(ctx, threadID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Attachment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, threadID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCommentByID provides a mock function with given fields: ctx, commentID
+func (_m *ThreadRepository) GetCommentByID(ctx context.Context, commentID uint) (entity.Comment, error) {
+	ret := _m.Called(ctx, commentID)
 
 	var r0 entity.Comment
-	if rf, ok := ret.Get(0).(func(uint) entity.Comment); ok {
-		r0 = rf(commentID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) entity.Comment); ok {
+		r0 = rf(ctx, commentID)
 	} else {
 		r0 = ret.Get(0).(entity.Comment)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint) error); ok {
-		r1 = rf(commentID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, commentID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -139,20 +291,20 @@ func (_m *ThreadRepository) GetCommentByID(commentID uint) (entity.Comment, erro
 	return r0, r1
 }
 
-// GetCommentReport provides a mock function with given fields: id
-func (_m *ThreadRepository) GetCommentReport(id uint) (entity.CommentReport, error) {
-	ret := _m.Called(id)
+// GetCommentReport provides a mock function with given fields: ctx, id
+func (_m *ThreadRepository) GetCommentReport(ctx context.Context, id uint) (entity.CommentReport, error) {
+	ret := _m.Called(ctx, id)
 
 	var r0 entity.CommentReport
-	if rf, ok := ret.Get(0).(func(uint) entity.CommentReport); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) entity.CommentReport); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Get(0).(entity.CommentReport)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -160,13 +312,34 @@ func (_m *ThreadRepository) GetCommentReport(id uint) (entity.CommentReport, err
 	return r0, r1
 }
 
-// GetCommentsByThreadID provides a mock function with given fields: threadID
-func (_m *ThreadRepository) GetCommentsByThreadID(threadID uint) ([]entity.CommentDetails, error) {
-	ret := _m.Called(threadID)
+// GetCommentVote provides a mock function with given fields: ctx, commentID, userID
+func (_m *ThreadRepository) GetCommentVote(ctx context.Context, commentID uint, userID uint) (entity.CommentVote, error) {
+	ret := _m.Called(ctx, commentID, userID)
+
+	var r0 entity.CommentVote
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) entity.CommentVote); ok {
+		r0 = rf(ctx, commentID, userID)
+	} else {
+		r0 = ret.Get(0).(entity.CommentVote)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, commentID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCommentsByThreadID provides a mock function with given fields: ctx, threadID, userID
+func (_m *ThreadRepository) GetCommentsByThreadID(ctx context.Context, threadID uint, userID uint) ([]entity.CommentDetails, error) {
+	ret := _m.Called(ctx, threadID, userID)
 
 	var r0 []entity.CommentDetails
-	if rf, ok := ret.Get(0).(func(uint) []entity.CommentDetails); ok {
-		r0 = rf(threadID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) []entity.CommentDetails); ok {
+		r0 = rf(ctx, threadID, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entity.CommentDetails)
@@ -174,8 +347,8 @@ func (_m *ThreadRepository) GetCommentsByThreadID(threadID uint) ([]entity.Comme
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint) error); ok {
-		r1 = rf(threadID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, threadID, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -183,13 +356,36 @@ func (_m *ThreadRepository) GetCommentsByThreadID(threadID uint) ([]entity.Comme
 	return r0, r1
 }
 
-// GetSavedThread provides a mock function with given fields: userID
-func (_m *ThreadRepository) GetSavedThread(userID uint) ([]entity.ThreadWithDetails, error) {
-	ret := _m.Called(userID)
+// GetDraftThreads provides a mock function with given fields: ctx, userID
+func (_m *ThreadRepository) GetDraftThreads(ctx context.Context, userID uint) ([]entity.Thread, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []entity.Thread
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []entity.Thread); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Thread)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetHiddenThreads provides a mock function with given fields: ctx, userID
+func (_m *ThreadRepository) GetHiddenThreads(ctx context.Context, userID uint) ([]entity.ThreadWithDetails, error) {
+	ret := _m.Called(ctx, userID)
 
 	var r0 []entity.ThreadWithDetails
-	if rf, ok := ret.Get(0).(func(uint) []entity.ThreadWithDetails); ok {
-		r0 = rf(userID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []entity.ThreadWithDetails); ok {
+		r0 = rf(ctx, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entity.ThreadWithDetails)
@@ -197,8 +393,8 @@ func (_m *ThreadRepository) GetSavedThread(userID uint) ([]entity.ThreadWithDeta
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint) error); ok {
-		r1 = rf(userID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -206,20 +402,22 @@ func (_m *ThreadRepository) GetSavedThread(userID uint) ([]entity.ThreadWithDeta
 	return r0, r1
 }
 
-// GetThreadByID provides a mock function with given fields: threadID
-func (_m *ThreadRepository) GetThreadByID(threadID uint) (entity.Thread, error) {
-	ret := _m.Called(threadID)
+// GetRankedThreads provides a mock function with given fields: ctx, userID, communityID, timeframe, limit
+func (_m *ThreadRepository) GetRankedThreads(ctx context.Context, userID uint, communityID uint, timeframe string, limit int) ([]entity.ThreadWithDetails, error) {
+	ret := _m.Called(ctx, userID, communityID, timeframe, limit)
 
-	var r0 entity.Thread
-	if rf, ok := ret.Get(0).(func(uint) entity.Thread); ok {
-		r0 = rf(threadID)
+	var r0 []entity.ThreadWithDetails
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, string, int) []entity.ThreadWithDetails); ok {
+		r0 = rf(ctx, userID, communityID, timeframe, limit)
 	} else {
-		r0 = ret.Get(0).(entity.Thread)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ThreadWithDetails)
+		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint) error); ok {
-		r1 = rf(threadID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint, string, int) error); ok {
+		r1 = rf(ctx, userID, communityID, timeframe, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -227,20 +425,22 @@ func (_m *ThreadRepository) GetThreadByID(threadID uint) (entity.Thread, error)
 	return r0, r1
 }
 
-// GetThreadDownvotes provides a mock function with given fields: threadID, userID
-func (_m *ThreadRepository) GetThreadDownvotes(threadID uint, userID uint) (entity.ThreadDownvote, error) {
-	ret := _m.Called(threadID, userID)
+// GetSavedThread provides a mock function with given fields: ctx, userID
+func (_m *ThreadRepository) GetSavedThread(ctx context.Context, userID uint) ([]entity.ThreadWithDetails, error) {
+	ret := _m.Called(ctx, userID)
 
-	var r0 entity.ThreadDownvote
-	if rf, ok := ret.Get(0).(func(uint, uint) entity.ThreadDownvote); ok {
-		r0 = rf(threadID, userID)
+	var r0 []entity.ThreadWithDetails
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []entity.ThreadWithDetails); ok {
+		r0 = rf(ctx, userID)
 	} else {
-		r0 = ret.Get(0).(entity.ThreadDownvote)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ThreadWithDetails)
+		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint, uint) error); ok {
-		r1 = rf(threadID, userID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -248,20 +448,20 @@ func (_m *ThreadRepository) GetThreadDownvotes(threadID uint, userID uint) (enti
 	return r0, r1
 }
 
-// GetThreadReport provides a mock function with given fields: id
-func (_m *ThreadRepository) GetThreadReport(id uint) (entity.ThreadReport, error) {
-	ret := _m.Called(id)
+// GetSavedThreadByThreadID provides a mock function with given fields: ctx, userID, threadID
+func (_m *ThreadRepository) GetSavedThreadByThreadID(ctx context.Context, userID uint, threadID uint) (entity.SavedThread, error) {
+	ret := _m.Called(ctx, userID, threadID)
 
-	var r0 entity.ThreadReport
-	if rf, ok := ret.Get(0).(func(uint) entity.ThreadReport); ok {
-		r0 = rf(id)
+	var r0 entity.SavedThread
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) entity.SavedThread); ok {
+		r0 = rf(ctx, userID, threadID)
 	} else {
-		r0 = ret.Get(0).(entity.ThreadReport)
+		r0 = ret.Get(0).(entity.SavedThread)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, userID, threadID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -269,20 +469,20 @@ func (_m *ThreadRepository) GetThreadReport(id uint) (entity.ThreadReport, error
 	return r0, r1
 }
 
-// GetThreadUpvotes provides a mock function with given fields: threadID, userID
-func (_m *ThreadRepository) GetThreadUpvotes(threadID uint, userID uint) (entity.ThreadUpvote, error) {
-	ret := _m.Called(threadID, userID)
+// GetSavedThreadCollection provides a mock function with given fields: ctx, userID, collectionID
+func (_m *ThreadRepository) GetSavedThreadCollection(ctx context.Context, userID uint, collectionID uint) (entity.SavedThreadCollection, error) {
+	ret := _m.Called(ctx, userID, collectionID)
 
-	var r0 entity.ThreadUpvote
-	if rf, ok := ret.Get(0).(func(uint, uint) entity.ThreadUpvote); ok {
-		r0 = rf(threadID, userID)
+	var r0 entity.SavedThreadCollection
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) entity.SavedThreadCollection); ok {
+		r0 = rf(ctx, userID, collectionID)
 	} else {
-		r0 = ret.Get(0).(entity.ThreadUpvote)
+		r0 = ret.Get(0).(entity.SavedThreadCollection)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint, uint) error); ok {
-		r1 = rf(threadID, userID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, userID, collectionID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -290,22 +490,22 @@ func (_m *ThreadRepository) GetThreadUpvotes(threadID uint, userID uint) (entity
 	return r0, r1
 }
 
-// GetThreads provides a mock function with given fields: keyword, userID
-func (_m *ThreadRepository) GetThreads(keyword string, userID uint) ([]entity.ThreadWithDetails, error) {
-	ret := _m.Called(keyword, userID)
+// GetSavedThreadCollections provides a mock function with given fields: ctx, userID
+func (_m *ThreadRepository) GetSavedThreadCollections(ctx context.Context, userID uint) ([]entity.SavedThreadCollection, error) {
+	ret := _m.Called(ctx, userID)
 
-	var r0 []entity.ThreadWithDetails
-	if rf, ok := ret.Get(0).(func(string, uint) []entity.ThreadWithDetails); ok {
-		r0 = rf(keyword, userID)
+	var r0 []entity.SavedThreadCollection
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []entity.SavedThreadCollection); ok {
+		r0 = rf(ctx, userID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]entity.ThreadWithDetails)
+			r0 = ret.Get(0).([]entity.SavedThreadCollection)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, uint) error); ok {
-		r1 = rf(keyword, userID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -313,22 +513,152 @@ func (_m *ThreadRepository) GetThreads(keyword string, userID uint) ([]entity.Th
 	return r0, r1
 }
 
-// GetThreadsByUserID provides a mock function with given fields: userID, tokenUserID
-func (_m *ThreadRepository) GetThreadsByUserID(userID uint, tokenUserID uint) ([]entity.ThreadWithDetails, error) {
-	ret := _m.Called(userID, tokenUserID)
+// GetSavedThreadsByCollection provides a mock function with given fields: ctx, userID, collectionID, limit, offset
+func (_m *ThreadRepository) GetSavedThreadsByCollection(ctx context.Context, userID uint, collectionID uint, limit int, offset int) ([]entity.SavedThreadDetails, error) {
+	ret := _m.Called(ctx, userID, collectionID, limit, offset)
 
-	var r0 []entity.ThreadWithDetails
-	if rf, ok := ret.Get(0).(func(uint, uint) []entity.ThreadWithDetails); ok {
-		r0 = rf(userID, tokenUserID)
+	var r0 []entity.SavedThreadDetails
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, int, int) []entity.SavedThreadDetails); ok {
+		r0 = rf(ctx, userID, collectionID, limit, offset)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]entity.ThreadWithDetails)
+			r0 = ret.Get(0).([]entity.SavedThreadDetails)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint, int, int) error); ok {
+		r1 = rf(ctx, userID, collectionID, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetThreadActivity provides a mock function with given fields: ctx, now
+func (_m *ThreadRepository) GetThreadActivity(ctx context.Context, now time.Time) ([]entity.ThreadActivity, error) {
+	ret := _m.Called(ctx, now)
+
+	var r0 []entity.ThreadActivity
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []entity.ThreadActivity); ok {
+		r0 = rf(ctx, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ThreadActivity)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetThreadByID provides a mock function with given fields: ctx, threadID
+func (_m *ThreadRepository) GetThreadByID(ctx context.Context, threadID uint) (entity.Thread, error) {
+	ret := _m.Called(ctx, threadID)
+
+	var r0 entity.Thread
+	if rf, ok := ret.Get(0).(func(context.Context, uint) entity.Thread); ok {
+		r0 = rf(ctx, threadID)
+	} else {
+		r0 = ret.Get(0).(entity.Thread)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, threadID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetThreadFollower provides a mock function with given fields: ctx, threadID, userID
+func (_m *ThreadRepository) GetThreadFollower(ctx context.Context, threadID uint, userID uint) (entity.ThreadFollower, error) {
+	ret := _m.Called(ctx, threadID, userID)
+
+	var r0 entity.ThreadFollower
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) entity.ThreadFollower); ok {
+		r0 = rf(ctx, threadID, userID)
+	} else {
+		r0 = ret.Get(0).(entity.ThreadFollower)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, threadID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetThreadFollowers provides a mock function with given fields: ctx, threadID
+func (_m *ThreadRepository) GetThreadFollowers(ctx context.Context, threadID uint) ([]entity.ThreadFollower, error) {
+	ret := _m.Called(ctx, threadID)
+
+	var r0 []entity.ThreadFollower
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []entity.ThreadFollower); ok {
+		r0 = rf(ctx, threadID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ThreadFollower)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint, uint) error); ok {
-		r1 = rf(userID, tokenUserID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, threadID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetThreadReport provides a mock function with given fields: ctx, id
+func (_m *ThreadRepository) GetThreadReport(ctx context.Context, id uint) (entity.ThreadReport, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 entity.ThreadReport
+	if rf, ok := ret.Get(0).(func(context.Context, uint) entity.ThreadReport); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(entity.ThreadReport)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetThreadVote provides a mock function with given fields: ctx, threadID, userID
+func (_m *ThreadRepository) GetThreadVote(ctx context.Context, threadID uint, userID uint) (entity.ThreadVote, error) {
+	ret := _m.Called(ctx, threadID, userID)
+
+	var r0 entity.ThreadVote
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) entity.ThreadVote); ok {
+		r0 = rf(ctx, threadID, userID)
+	} else {
+		r0 = ret.Get(0).(entity.ThreadVote)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, threadID, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -336,13 +666,13 @@ func (_m *ThreadRepository) GetThreadsByUserID(userID uint, tokenUserID uint) ([
 	return r0, r1
 }
 
-// GetThreadsFromFollowedCommunity provides a mock function with given fields: userID
-func (_m *ThreadRepository) GetThreadsFromFollowedCommunity(userID uint) ([]entity.ThreadWithDetails, error) {
-	ret := _m.Called(userID)
+// GetThreads provides a mock function with given fields: ctx, keyword, userID
+func (_m *ThreadRepository) GetThreads(ctx context.Context, keyword string, userID uint) ([]entity.ThreadWithDetails, error) {
+	ret := _m.Called(ctx, keyword, userID)
 
 	var r0 []entity.ThreadWithDetails
-	if rf, ok := ret.Get(0).(func(uint) []entity.ThreadWithDetails); ok {
-		r0 = rf(userID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint) []entity.ThreadWithDetails); ok {
+		r0 = rf(ctx, keyword, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entity.ThreadWithDetails)
@@ -350,8 +680,8 @@ func (_m *ThreadRepository) GetThreadsFromFollowedCommunity(userID uint) ([]enti
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint) error); ok {
-		r1 = rf(userID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, uint) error); ok {
+		r1 = rf(ctx, keyword, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -359,13 +689,13 @@ func (_m *ThreadRepository) GetThreadsFromFollowedCommunity(userID uint) ([]enti
 	return r0, r1
 }
 
-// GetThreadsFromFollowedUsers provides a mock function with given fields: userID
-func (_m *ThreadRepository) GetThreadsFromFollowedUsers(userID uint) ([]entity.ThreadWithDetails, error) {
-	ret := _m.Called(userID)
+// GetThreadsByUserID provides a mock function with given fields: ctx, userID, tokenUserID
+func (_m *ThreadRepository) GetThreadsByUserID(ctx context.Context, userID uint, tokenUserID uint) ([]entity.ThreadWithDetails, error) {
+	ret := _m.Called(ctx, userID, tokenUserID)
 
 	var r0 []entity.ThreadWithDetails
-	if rf, ok := ret.Get(0).(func(uint) []entity.ThreadWithDetails); ok {
-		r0 = rf(userID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) []entity.ThreadWithDetails); ok {
+		r0 = rf(ctx, userID, tokenUserID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entity.ThreadWithDetails)
@@ -373,8 +703,8 @@ func (_m *ThreadRepository) GetThreadsFromFollowedUsers(userID uint) ([]entity.T
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint) error); ok {
-		r1 = rf(userID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, userID, tokenUserID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -382,13 +712,13 @@ func (_m *ThreadRepository) GetThreadsFromFollowedUsers(userID uint) ([]entity.T
 	return r0, r1
 }
 
-// GetTrendingThreads provides a mock function with given fields: userID
-func (_m *ThreadRepository) GetTrendingThreads(userID uint) ([]entity.ThreadWithDetails, error) {
-	ret := _m.Called(userID)
+// GetThreadsFromFollowedCommunity provides a mock function with given fields: ctx, userID
+func (_m *ThreadRepository) GetThreadsFromFollowedCommunity(ctx context.Context, userID uint) ([]entity.ThreadWithDetails, error) {
+	ret := _m.Called(ctx, userID)
 
 	var r0 []entity.ThreadWithDetails
-	if rf, ok := ret.Get(0).(func(uint) []entity.ThreadWithDetails); ok {
-		r0 = rf(userID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []entity.ThreadWithDetails); ok {
+		r0 = rf(ctx, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entity.ThreadWithDetails)
@@ -396,8 +726,8 @@ func (_m *ThreadRepository) GetTrendingThreads(userID uint) ([]entity.ThreadWith
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint) error); ok {
-		r1 = rf(userID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -405,13 +735,13 @@ func (_m *ThreadRepository) GetTrendingThreads(userID uint) ([]entity.ThreadWith
 	return r0, r1
 }
 
-// GetTrendingThreadsWithLimit provides a mock function with given fields: userID, limit
-func (_m *ThreadRepository) GetTrendingThreadsWithLimit(userID uint, limit int) ([]entity.ThreadWithDetails, error) {
-	ret := _m.Called(userID, limit)
+// GetThreadsFromFollowedUsers provides a mock function with given fields: ctx, userID
+func (_m *ThreadRepository) GetThreadsFromFollowedUsers(ctx context.Context, userID uint) ([]entity.ThreadWithDetails, error) {
+	ret := _m.Called(ctx, userID)
 
 	var r0 []entity.ThreadWithDetails
-	if rf, ok := ret.Get(0).(func(uint, int) []entity.ThreadWithDetails); ok {
-		r0 = rf(userID, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []entity.ThreadWithDetails); ok {
+		r0 = rf(ctx, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entity.ThreadWithDetails)
@@ -419,8 +749,8 @@ func (_m *ThreadRepository) GetTrendingThreadsWithLimit(userID uint, limit int)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint, int) error); ok {
-		r1 = rf(userID, limit)
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -428,13 +758,181 @@ func (_m *ThreadRepository) GetTrendingThreadsWithLimit(userID uint, limit int)
 	return r0, r1
 }
 
-// LikeComment provides a mock function with given fields: commentLikes
-func (_m *ThreadRepository) LikeComment(commentLikes entity.CommentLikes) error {
-	ret := _m.Called(commentLikes)
+// HideThread provides a mock function with given fields: ctx, userID, threadID
+func (_m *ThreadRepository) HideThread(ctx context.Context, userID uint, threadID uint) error {
+	ret := _m.Called(ctx, userID, threadID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, userID, threadID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PublishThread provides a mock function with given fields: ctx, threadID, status, imageURL, publishedAt
+func (_m *ThreadRepository) PublishThread(ctx context.Context, threadID uint, status string, imageURL string, publishedAt time.Time) error {
+	ret := _m.Called(ctx, threadID, status, imageURL, publishedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, string, time.Time) error); ok {
+		r0 = rf(ctx, threadID, status, imageURL, publishedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RenameSavedThreadCollection provides a mock function with given fields: ctx, userID, collectionID, name
+func (_m *ThreadRepository) RenameSavedThreadCollection(ctx context.Context, userID uint, collectionID uint, name string) error {
+	ret := _m.Called(ctx, userID, collectionID, name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, string) error); ok {
+		r0 = rf(ctx, userID, collectionID, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReorderAttachments provides a mock function with given fields: ctx, threadID, attachmentIDs
+func (_m *ThreadRepository) ReorderAttachments(ctx context.Context, threadID uint, attachmentIDs []uint) error {
+	ret := _m.Called(ctx, threadID, attachmentIDs)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, []uint) error); ok {
+		r0 = rf(ctx, threadID, attachmentIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ScheduleThread provides a mock function with given fields: ctx, threadID, status, imageURL, publishAt
+func (_m *ThreadRepository) ScheduleThread(ctx context.Context, threadID uint, status string, imageURL string, publishAt time.Time) error {
+	ret := _m.Called(ctx, threadID, status, imageURL, publishAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, string, time.Time) error); ok {
+		r0 = rf(ctx, threadID, status, imageURL, publishAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetCommentVote provides a mock function with given fields: ctx, commentID, userID, value
+func (_m *ThreadRepository) SetCommentVote(ctx context.Context, commentID uint, userID uint, value int) (entity.Comment, int, error) {
+	ret := _m.Called(ctx, commentID, userID, value)
+
+	var r0 entity.Comment
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, int) entity.Comment); ok {
+		r0 = rf(ctx, commentID, userID, value)
+	} else {
+		r0 = ret.Get(0).(entity.Comment)
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint, int) int); ok {
+		r1 = rf(ctx, commentID, userID, value)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, uint, uint, int) error); ok {
+		r2 = rf(ctx, commentID, userID, value)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SetThreadFollower provides a mock function with given fields: ctx, threadID, userID, muted
+func (_m *ThreadRepository) SetThreadFollower(ctx context.Context, threadID uint, userID uint, muted bool) error {
+	ret := _m.Called(ctx, threadID, userID, muted)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, bool) error); ok {
+		r0 = rf(ctx, threadID, userID, muted)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetThreadImage provides a mock function with given fields: ctx, imageURL, threadID
+func (_m *ThreadRepository) SetThreadImage(ctx context.Context, imageURL string, threadID uint) error {
+	ret := _m.Called(ctx, imageURL, threadID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint) error); ok {
+		r0 = rf(ctx, imageURL, threadID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetThreadPreview provides a mock function with given fields: ctx, threadID, body, preview
+func (_m *ThreadRepository) SetThreadPreview(ctx context.Context, threadID uint, body string, preview entity.LinkPreview) error {
+	ret := _m.Called(ctx, threadID, body, preview)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, entity.LinkPreview) error); ok {
+		r0 = rf(ctx, threadID, body, preview)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetThreadVote provides a mock function with given fields: ctx, threadID, userID, value
+func (_m *ThreadRepository) SetThreadVote(ctx context.Context, threadID uint, userID uint, value int) (entity.Thread, int, error) {
+	ret := _m.Called(ctx, threadID, userID, value)
+
+	var r0 entity.Thread
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, int) entity.Thread); ok {
+		r0 = rf(ctx, threadID, userID, value)
+	} else {
+		r0 = ret.Get(0).(entity.Thread)
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint, int) int); ok {
+		r1 = rf(ctx, threadID, userID, value)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, uint, uint, int) error); ok {
+		r2 = rf(ctx, threadID, userID, value)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// StoreSavedThread provides a mock function with given fields: ctx, savedThread
+func (_m *ThreadRepository) StoreSavedThread(ctx context.Context, savedThread entity.SavedThread) error {
+	ret := _m.Called(ctx, savedThread)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(entity.CommentLikes) error); ok {
-		r0 = rf(commentLikes)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.SavedThread) error); ok {
+		r0 = rf(ctx, savedThread)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -442,13 +940,13 @@ func (_m *ThreadRepository) LikeComment(commentLikes entity.CommentLikes) error
 	return r0
 }
 
-// SetThreadImage provides a mock function with given fields: imageURL, threadID
-func (_m *ThreadRepository) SetThreadImage(imageURL string, threadID uint) error {
-	ret := _m.Called(imageURL, threadID)
+// StoreThreadScores provides a mock function with given fields: ctx, scores, computedAt
+func (_m *ThreadRepository) StoreThreadScores(ctx context.Context, scores []entity.ThreadScore, computedAt time.Time) error {
+	ret := _m.Called(ctx, scores, computedAt)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, uint) error); ok {
-		r0 = rf(imageURL, threadID)
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.ThreadScore, time.Time) error); ok {
+		r0 = rf(ctx, scores, computedAt)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -456,13 +954,13 @@ func (_m *ThreadRepository) SetThreadImage(imageURL string, threadID uint) error
 	return r0
 }
 
-// StoreSavedThread provides a mock function with given fields: savedThread
-func (_m *ThreadRepository) StoreSavedThread(savedThread entity.SavedThread) error {
-	ret := _m.Called(savedThread)
+// SubscribeThread provides a mock function with given fields: ctx, threadID, userID
+func (_m *ThreadRepository) SubscribeThread(ctx context.Context, threadID uint, userID uint) error {
+	ret := _m.Called(ctx, threadID, userID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(entity.SavedThread) error); ok {
-		r0 = rf(savedThread)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, threadID, userID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -470,13 +968,13 @@ func (_m *ThreadRepository) StoreSavedThread(savedThread entity.SavedThread) err
 	return r0
 }
 
-// UndoDownvoteThread provides a mock function with given fields: threadID, userID
-func (_m *ThreadRepository) UndoDownvoteThread(threadID uint, userID uint) error {
-	ret := _m.Called(threadID, userID)
+// UnhideThread provides a mock function with given fields: ctx, userID, threadID
+func (_m *ThreadRepository) UnhideThread(ctx context.Context, userID uint, threadID uint) error {
+	ret := _m.Called(ctx, userID, threadID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint, uint) error); ok {
-		r0 = rf(threadID, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, userID, threadID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -484,13 +982,13 @@ func (_m *ThreadRepository) UndoDownvoteThread(threadID uint, userID uint) error
 	return r0
 }
 
-// UndoUpvoteThread provides a mock function with given fields: threadID, userID
-func (_m *ThreadRepository) UndoUpvoteThread(threadID uint, userID uint) error {
-	ret := _m.Called(threadID, userID)
+// UnscheduleThread provides a mock function with given fields: ctx, threadID
+func (_m *ThreadRepository) UnscheduleThread(ctx context.Context, threadID uint) error {
+	ret := _m.Called(ctx, threadID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint, uint) error); ok {
-		r0 = rf(threadID, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, threadID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -498,13 +996,13 @@ func (_m *ThreadRepository) UndoUpvoteThread(threadID uint, userID uint) error {
 	return r0
 }
 
-// UnlikeComment provides a mock function with given fields: commentID, userID
-func (_m *ThreadRepository) UnlikeComment(commentID uint, userID uint) error {
-	ret := _m.Called(commentID, userID)
+// UpdateAttachment provides a mock function with given fields: ctx, attachmentID, altText, caption
+func (_m *ThreadRepository) UpdateAttachment(ctx context.Context, attachmentID uint, altText string, caption string) error {
+	ret := _m.Called(ctx, attachmentID, altText, caption)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint, uint) error); ok {
-		r0 = rf(commentID, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, string) error); ok {
+		r0 = rf(ctx, attachmentID, altText, caption)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -512,13 +1010,13 @@ func (_m *ThreadRepository) UnlikeComment(commentID uint, userID uint) error {
 	return r0
 }
 
-// UpdateCommentReport provides a mock function with given fields: commentReport, userID
-func (_m *ThreadRepository) UpdateCommentReport(commentReport entity.CommentReport, userID uint) error {
-	ret := _m.Called(commentReport, userID)
+// UpdateCommentReport provides a mock function with given fields: ctx, commentReport, userID
+func (_m *ThreadRepository) UpdateCommentReport(ctx context.Context, commentReport entity.CommentReport, userID uint) error {
+	ret := _m.Called(ctx, commentReport, userID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(entity.CommentReport, uint) error); ok {
-		r0 = rf(commentReport, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.CommentReport, uint) error); ok {
+		r0 = rf(ctx, commentReport, userID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -526,13 +1024,13 @@ func (_m *ThreadRepository) UpdateCommentReport(commentReport entity.CommentRepo
 	return r0
 }
 
-// UpdateThread provides a mock function with given fields: threadID, _a1
-func (_m *ThreadRepository) UpdateThread(threadID uint, _a1 entity.Thread) error {
-	ret := _m.Called(threadID, _a1)
+// UpdateSavedThread provides a mock function with given fields: ctx, userID, threadID, collectionID, note
+func (_m *ThreadRepository) UpdateSavedThread(ctx context.Context, userID uint, threadID uint, collectionID *uint, note string) error {
+	ret := _m.Called(ctx, userID, threadID, collectionID, note)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint, entity.Thread) error); ok {
-		r0 = rf(threadID, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, *uint, string) error); ok {
+		r0 = rf(ctx, userID, threadID, collectionID, note)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -540,13 +1038,13 @@ func (_m *ThreadRepository) UpdateThread(threadID uint, _a1 entity.Thread) error
 	return r0
 }
 
-// UpdateThreadReport provides a mock function with given fields: threadReport, userID
-func (_m *ThreadRepository) UpdateThreadReport(threadReport entity.ThreadReport, userID uint) error {
-	ret := _m.Called(threadReport, userID)
+// UpdateThread provides a mock function with given fields: ctx, threadID, _a2
+func (_m *ThreadRepository) UpdateThread(ctx context.Context, threadID uint, _a2 entity.Thread) error {
+	ret := _m.Called(ctx, threadID, _a2)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(entity.ThreadReport, uint) error); ok {
-		r0 = rf(threadReport, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, entity.Thread) error); ok {
+		r0 = rf(ctx, threadID, _a2)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -554,13 +1052,13 @@ func (_m *ThreadRepository) UpdateThreadReport(threadReport entity.ThreadReport,
 	return r0
 }
 
-// UpvoteThread provides a mock function with given fields: threadUpvote
-func (_m *ThreadRepository) UpvoteThread(threadUpvote entity.ThreadUpvote) error {
-	ret := _m.Called(threadUpvote)
+// UpdateThreadReport provides a mock function with given fields: ctx, threadReport, userID
+func (_m *ThreadRepository) UpdateThreadReport(ctx context.Context, threadReport entity.ThreadReport, userID uint) error {
+	ret := _m.Called(ctx, threadReport, userID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(entity.ThreadUpvote) error); ok {
-		r0 = rf(threadUpvote)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.ThreadReport, uint) error); ok {
+		r0 = rf(ctx, threadReport, userID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -3,11 +3,11 @@
 package mocks
 
 import (
+	context "context"
 	dto "macaiki/internal/thread/dto"
+	multipart "mime/multipart"
 
 	mock "github.com/stretchr/testify/mock"
-
-	multipart "mime/multipart"
 )
 
 // ThreadUseCase is an autogenerated mock type for the ThreadUseCase type
@@ -15,13 +15,34 @@ type ThreadUseCase struct {
 	mock.Mock
 }
 
-// AddThreadComment provides a mock function with given fields: _a0
-func (_m *ThreadUseCase) AddThreadComment(_a0 dto.CommentRequest) error {
-	ret := _m.Called(_a0)
+// AddAttachment provides a mock function with given fields: ctx, threadID, commentID, userID, req, img
+func (_m *ThreadUseCase) AddAttachment(ctx context.Context, threadID uint, commentID uint, userID uint, req dto.AttachmentRequest, img *multipart.FileHeader) (dto.AttachmentResponse, error) {
+	ret := _m.Called(ctx, threadID, commentID, userID, req, img)
+
+	var r0 dto.AttachmentResponse
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, uint, dto.AttachmentRequest, *multipart.FileHeader) dto.AttachmentResponse); ok {
+		r0 = rf(ctx, threadID, commentID, userID, req, img)
+	} else {
+		r0 = ret.Get(0).(dto.AttachmentResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint, uint, dto.AttachmentRequest, *multipart.FileHeader) error); ok {
+		r1 = rf(ctx, threadID, commentID, userID, req, img)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddThreadComment provides a mock function with given fields: _a0, _a1
+func (_m *ThreadUseCase) AddThreadComment(_a0 context.Context, _a1 dto.CommentRequest) error {
+	ret := _m.Called(_a0, _a1)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(dto.CommentRequest) error); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, dto.CommentRequest) error); ok {
+		r0 = rf(_a0, _a1)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -29,13 +50,13 @@ func (_m *ThreadUseCase) AddThreadComment(_a0 dto.CommentRequest) error {
 	return r0
 }
 
-// CreateCommentReport provides a mock function with given fields: commentReport
-func (_m *ThreadUseCase) CreateCommentReport(commentReport dto.CommentReportRequest) error {
-	ret := _m.Called(commentReport)
+// CreateCommentReport provides a mock function with given fields: ctx, commentReport
+func (_m *ThreadUseCase) CreateCommentReport(ctx context.Context, commentReport dto.CommentReportRequest) error {
+	ret := _m.Called(ctx, commentReport)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(dto.CommentReportRequest) error); ok {
-		r0 = rf(commentReport)
+	if rf, ok := ret.Get(0).(func(context.Context, dto.CommentReportRequest) error); ok {
+		r0 = rf(ctx, commentReport)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -43,20 +64,62 @@ func (_m *ThreadUseCase) CreateCommentReport(commentReport dto.CommentReportRequ
 	return r0
 }
 
-// CreateThread provides a mock function with given fields: _a0, userID
-func (_m *ThreadUseCase) CreateThread(_a0 dto.ThreadRequest, userID uint) (dto.ThreadResponse, error) {
-	ret := _m.Called(_a0, userID)
+// CreateDraft provides a mock function with given fields: ctx, _a1, userID
+func (_m *ThreadUseCase) CreateDraft(ctx context.Context, _a1 dto.ThreadRequest, userID uint) (dto.DraftThreadResponse, error) {
+	ret := _m.Called(ctx, _a1, userID)
+
+	var r0 dto.DraftThreadResponse
+	if rf, ok := ret.Get(0).(func(context.Context, dto.ThreadRequest, uint) dto.DraftThreadResponse); ok {
+		r0 = rf(ctx, _a1, userID)
+	} else {
+		r0 = ret.Get(0).(dto.DraftThreadResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, dto.ThreadRequest, uint) error); ok {
+		r1 = rf(ctx, _a1, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateSavedThreadCollection provides a mock function with given fields: ctx, userID, req
+func (_m *ThreadUseCase) CreateSavedThreadCollection(ctx context.Context, userID uint, req dto.SavedThreadCollectionRequest) (dto.SavedThreadCollectionResponse, error) {
+	ret := _m.Called(ctx, userID, req)
+
+	var r0 dto.SavedThreadCollectionResponse
+	if rf, ok := ret.Get(0).(func(context.Context, uint, dto.SavedThreadCollectionRequest) dto.SavedThreadCollectionResponse); ok {
+		r0 = rf(ctx, userID, req)
+	} else {
+		r0 = ret.Get(0).(dto.SavedThreadCollectionResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, dto.SavedThreadCollectionRequest) error); ok {
+		r1 = rf(ctx, userID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateThread provides a mock function with given fields: ctx, _a1, userID
+func (_m *ThreadUseCase) CreateThread(ctx context.Context, _a1 dto.ThreadRequest, userID uint) (dto.ThreadResponse, error) {
+	ret := _m.Called(ctx, _a1, userID)
 
 	var r0 dto.ThreadResponse
-	if rf, ok := ret.Get(0).(func(dto.ThreadRequest, uint) dto.ThreadResponse); ok {
-		r0 = rf(_a0, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, dto.ThreadRequest, uint) dto.ThreadResponse); ok {
+		r0 = rf(ctx, _a1, userID)
 	} else {
 		r0 = ret.Get(0).(dto.ThreadResponse)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(dto.ThreadRequest, uint) error); ok {
-		r1 = rf(_a0, userID)
+	if rf, ok := ret.Get(1).(func(context.Context, dto.ThreadRequest, uint) error); ok {
+		r1 = rf(ctx, _a1, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -64,13 +127,13 @@ func (_m *ThreadUseCase) CreateThread(_a0 dto.ThreadRequest, userID uint) (dto.T
 	return r0, r1
 }
 
-// CreateThreadReport provides a mock function with given fields: threadReport
-func (_m *ThreadUseCase) CreateThreadReport(threadReport dto.ThreadReportRequest) error {
-	ret := _m.Called(threadReport)
+// CreateThreadReport provides a mock function with given fields: ctx, threadReport
+func (_m *ThreadUseCase) CreateThreadReport(ctx context.Context, threadReport dto.ThreadReportRequest) error {
+	ret := _m.Called(ctx, threadReport)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(dto.ThreadReportRequest) error); ok {
-		r0 = rf(threadReport)
+	if rf, ok := ret.Get(0).(func(context.Context, dto.ThreadReportRequest) error); ok {
+		r0 = rf(ctx, threadReport)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -78,13 +141,13 @@ func (_m *ThreadUseCase) CreateThreadReport(threadReport dto.ThreadReportRequest
 	return r0
 }
 
-// DeleteComment provides a mock function with given fields: commentID, threadID, userID, role
-func (_m *ThreadUseCase) DeleteComment(commentID uint, threadID uint, userID uint, role string) error {
-	ret := _m.Called(commentID, threadID, userID, role)
+// DeleteAttachment provides a mock function with given fields: ctx, threadID, attachmentID, userID, role
+func (_m *ThreadUseCase) DeleteAttachment(ctx context.Context, threadID uint, attachmentID uint, userID uint, role string) error {
+	ret := _m.Called(ctx, threadID, attachmentID, userID, role)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint, uint, uint, string) error); ok {
-		r0 = rf(commentID, threadID, userID, role)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, uint, string) error); ok {
+		r0 = rf(ctx, threadID, attachmentID, userID, role)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -92,13 +155,13 @@ func (_m *ThreadUseCase) DeleteComment(commentID uint, threadID uint, userID uin
 	return r0
 }
 
-// DeleteThread provides a mock function with given fields: threadID, userID, role
-func (_m *ThreadUseCase) DeleteThread(threadID uint, userID uint, role string) error {
-	ret := _m.Called(threadID, userID, role)
+// DeleteComment provides a mock function with given fields: ctx, commentID, threadID, userID, role
+func (_m *ThreadUseCase) DeleteComment(ctx context.Context, commentID uint, threadID uint, userID uint, role string) error {
+	ret := _m.Called(ctx, commentID, threadID, userID, role)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint, uint, string) error); ok {
-		r0 = rf(threadID, userID, role)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, uint, string) error); ok {
+		r0 = rf(ctx, commentID, threadID, userID, role)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -106,13 +169,13 @@ func (_m *ThreadUseCase) DeleteThread(threadID uint, userID uint, role string) e
 	return r0
 }
 
-// DownvoteThread provides a mock function with given fields: threadID, userID
-func (_m *ThreadUseCase) DownvoteThread(threadID uint, userID uint) error {
-	ret := _m.Called(threadID, userID)
+// DeleteSavedThread provides a mock function with given fields: ctx, threadID, userID
+func (_m *ThreadUseCase) DeleteSavedThread(ctx context.Context, threadID uint, userID uint) error {
+	ret := _m.Called(ctx, threadID, userID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint, uint) error); ok {
-		r0 = rf(threadID, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, threadID, userID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -120,13 +183,69 @@ func (_m *ThreadUseCase) DownvoteThread(threadID uint, userID uint) error {
 	return r0
 }
 
-// GetCommentsByThreadID provides a mock function with given fields: threadID
-func (_m *ThreadUseCase) GetCommentsByThreadID(threadID uint) ([]dto.CommentResponse, error) {
-	ret := _m.Called(threadID)
+// DeleteSavedThreadCollection provides a mock function with given fields: ctx, userID, collectionID
+func (_m *ThreadUseCase) DeleteSavedThreadCollection(ctx context.Context, userID uint, collectionID uint) error {
+	ret := _m.Called(ctx, userID, collectionID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, userID, collectionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteThread provides a mock function with given fields: ctx, threadID, userID, role
+func (_m *ThreadUseCase) DeleteThread(ctx context.Context, threadID uint, userID uint, role string) error {
+	ret := _m.Called(ctx, threadID, userID, role)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, string) error); ok {
+		r0 = rf(ctx, threadID, userID, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DownvoteThread provides a mock function with given fields: ctx, threadID, userID
+func (_m *ThreadUseCase) DownvoteThread(ctx context.Context, threadID uint, userID uint) error {
+	ret := _m.Called(ctx, threadID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, threadID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FollowThread provides a mock function with given fields: ctx, threadID, userID
+func (_m *ThreadUseCase) FollowThread(ctx context.Context, threadID uint, userID uint) error {
+	ret := _m.Called(ctx, threadID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, threadID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetCommentsByThreadID provides a mock function with given fields: ctx, threadID, userID, sort
+func (_m *ThreadUseCase) GetCommentsByThreadID(ctx context.Context, threadID uint, userID uint, sort string) ([]dto.CommentResponse, error) {
+	ret := _m.Called(ctx, threadID, userID, sort)
 
 	var r0 []dto.CommentResponse
-	if rf, ok := ret.Get(0).(func(uint) []dto.CommentResponse); ok {
-		r0 = rf(threadID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, string) []dto.CommentResponse); ok {
+		r0 = rf(ctx, threadID, userID, sort)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]dto.CommentResponse)
@@ -134,8 +253,31 @@ func (_m *ThreadUseCase) GetCommentsByThreadID(threadID uint) ([]dto.CommentResp
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint) error); ok {
-		r1 = rf(threadID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint, string) error); ok {
+		r1 = rf(ctx, threadID, userID, sort)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCommunityTrendingThreads provides a mock function with given fields: ctx, userID, communityID, limit
+func (_m *ThreadUseCase) GetCommunityTrendingThreads(ctx context.Context, userID uint, communityID uint, limit int) ([]dto.DetailedThreadResponse, error) {
+	ret := _m.Called(ctx, userID, communityID, limit)
+
+	var r0 []dto.DetailedThreadResponse
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, int) []dto.DetailedThreadResponse); ok {
+		r0 = rf(ctx, userID, communityID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.DetailedThreadResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint, int) error); ok {
+		r1 = rf(ctx, userID, communityID, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -143,13 +285,59 @@ func (_m *ThreadUseCase) GetCommentsByThreadID(threadID uint) ([]dto.CommentResp
 	return r0, r1
 }
 
-// GetSavedThread provides a mock function with given fields: userID
-func (_m *ThreadUseCase) GetSavedThread(userID uint) ([]dto.DetailedThreadResponse, error) {
-	ret := _m.Called(userID)
+// GetDrafts provides a mock function with given fields: ctx, userID
+func (_m *ThreadUseCase) GetDrafts(ctx context.Context, userID uint) ([]dto.DraftThreadResponse, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []dto.DraftThreadResponse
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []dto.DraftThreadResponse); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.DraftThreadResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetHiddenThreads provides a mock function with given fields: ctx, userID
+func (_m *ThreadUseCase) GetHiddenThreads(ctx context.Context, userID uint) ([]dto.DetailedThreadResponse, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []dto.DetailedThreadResponse
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []dto.DetailedThreadResponse); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.DetailedThreadResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSavedThread provides a mock function with given fields: ctx, userID
+func (_m *ThreadUseCase) GetSavedThread(ctx context.Context, userID uint) ([]dto.DetailedThreadResponse, error) {
+	ret := _m.Called(ctx, userID)
 
 	var r0 []dto.DetailedThreadResponse
-	if rf, ok := ret.Get(0).(func(uint) []dto.DetailedThreadResponse); ok {
-		r0 = rf(userID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []dto.DetailedThreadResponse); ok {
+		r0 = rf(ctx, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]dto.DetailedThreadResponse)
@@ -157,8 +345,8 @@ func (_m *ThreadUseCase) GetSavedThread(userID uint) ([]dto.DetailedThreadRespon
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint) error); ok {
-		r1 = rf(userID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -166,20 +354,85 @@ func (_m *ThreadUseCase) GetSavedThread(userID uint) ([]dto.DetailedThreadRespon
 	return r0, r1
 }
 
-// GetThreadByID provides a mock function with given fields: threadID
-func (_m *ThreadUseCase) GetThreadByID(threadID uint) (dto.ThreadResponse, error) {
-	ret := _m.Called(threadID)
+// GetSavedThreadCollections provides a mock function with given fields: ctx, userID
+func (_m *ThreadUseCase) GetSavedThreadCollections(ctx context.Context, userID uint) (dto.SavedThreadCollectionsResponse, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 dto.SavedThreadCollectionsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, uint) dto.SavedThreadCollectionsResponse); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(dto.SavedThreadCollectionsResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSavedThreadsByCollection provides a mock function with given fields: ctx, userID, collectionID, page, limit
+func (_m *ThreadUseCase) GetSavedThreadsByCollection(ctx context.Context, userID uint, collectionID uint, page int, limit int) (dto.SavedThreadPageResponse, error) {
+	ret := _m.Called(ctx, userID, collectionID, page, limit)
+
+	var r0 dto.SavedThreadPageResponse
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, int, int) dto.SavedThreadPageResponse); ok {
+		r0 = rf(ctx, userID, collectionID, page, limit)
+	} else {
+		r0 = ret.Get(0).(dto.SavedThreadPageResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint, int, int) error); ok {
+		r1 = rf(ctx, userID, collectionID, page, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetThreadByID provides a mock function with given fields: ctx, threadID, userID
+func (_m *ThreadUseCase) GetThreadByID(ctx context.Context, threadID uint, userID uint) (dto.ThreadResponse, error) {
+	ret := _m.Called(ctx, threadID, userID)
 
 	var r0 dto.ThreadResponse
-	if rf, ok := ret.Get(0).(func(uint) dto.ThreadResponse); ok {
-		r0 = rf(threadID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) dto.ThreadResponse); ok {
+		r0 = rf(ctx, threadID, userID)
 	} else {
 		r0 = ret.Get(0).(dto.ThreadResponse)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint) error); ok {
-		r1 = rf(threadID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, threadID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetThreads provides a mock function with given fields: ctx, keyword, userID
+func (_m *ThreadUseCase) GetThreads(ctx context.Context, keyword string, userID uint) ([]dto.DetailedThreadResponse, error) {
+	ret := _m.Called(ctx, keyword, userID)
+
+	var r0 []dto.DetailedThreadResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint) []dto.DetailedThreadResponse); ok {
+		r0 = rf(ctx, keyword, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.DetailedThreadResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, uint) error); ok {
+		r1 = rf(ctx, keyword, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -187,13 +440,13 @@ func (_m *ThreadUseCase) GetThreadByID(threadID uint) (dto.ThreadResponse, error
 	return r0, r1
 }
 
-// GetThreads provides a mock function with given fields: keyword, userID
-func (_m *ThreadUseCase) GetThreads(keyword string, userID uint) ([]dto.DetailedThreadResponse, error) {
-	ret := _m.Called(keyword, userID)
+// GetThreadsFromFollowedCommunity provides a mock function with given fields: ctx, userID
+func (_m *ThreadUseCase) GetThreadsFromFollowedCommunity(ctx context.Context, userID uint) ([]dto.DetailedThreadResponse, error) {
+	ret := _m.Called(ctx, userID)
 
 	var r0 []dto.DetailedThreadResponse
-	if rf, ok := ret.Get(0).(func(string, uint) []dto.DetailedThreadResponse); ok {
-		r0 = rf(keyword, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []dto.DetailedThreadResponse); ok {
+		r0 = rf(ctx, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]dto.DetailedThreadResponse)
@@ -201,8 +454,8 @@ func (_m *ThreadUseCase) GetThreads(keyword string, userID uint) ([]dto.Detailed
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, uint) error); ok {
-		r1 = rf(keyword, userID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -210,13 +463,13 @@ func (_m *ThreadUseCase) GetThreads(keyword string, userID uint) ([]dto.Detailed
 	return r0, r1
 }
 
-// GetThreadsFromFollowedCommunity provides a mock function with given fields: userID
-func (_m *ThreadUseCase) GetThreadsFromFollowedCommunity(userID uint) ([]dto.DetailedThreadResponse, error) {
-	ret := _m.Called(userID)
+// GetThreadsFromFollowedUsers provides a mock function with given fields: ctx, userID
+func (_m *ThreadUseCase) GetThreadsFromFollowedUsers(ctx context.Context, userID uint) ([]dto.DetailedThreadResponse, error) {
+	ret := _m.Called(ctx, userID)
 
 	var r0 []dto.DetailedThreadResponse
-	if rf, ok := ret.Get(0).(func(uint) []dto.DetailedThreadResponse); ok {
-		r0 = rf(userID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []dto.DetailedThreadResponse); ok {
+		r0 = rf(ctx, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]dto.DetailedThreadResponse)
@@ -224,8 +477,8 @@ func (_m *ThreadUseCase) GetThreadsFromFollowedCommunity(userID uint) ([]dto.Det
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint) error); ok {
-		r1 = rf(userID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -233,13 +486,13 @@ func (_m *ThreadUseCase) GetThreadsFromFollowedCommunity(userID uint) ([]dto.Det
 	return r0, r1
 }
 
-// GetThreadsFromFollowedUsers provides a mock function with given fields: userID
-func (_m *ThreadUseCase) GetThreadsFromFollowedUsers(userID uint) ([]dto.DetailedThreadResponse, error) {
-	ret := _m.Called(userID)
+// GetTrendingThreads provides a mock function with given fields: ctx, userID, limit
+func (_m *ThreadUseCase) GetTrendingThreads(ctx context.Context, userID uint, limit int) ([]dto.DetailedThreadResponse, error) {
+	ret := _m.Called(ctx, userID, limit)
 
 	var r0 []dto.DetailedThreadResponse
-	if rf, ok := ret.Get(0).(func(uint) []dto.DetailedThreadResponse); ok {
-		r0 = rf(userID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, int) []dto.DetailedThreadResponse); ok {
+		r0 = rf(ctx, userID, limit)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]dto.DetailedThreadResponse)
@@ -247,8 +500,8 @@ func (_m *ThreadUseCase) GetThreadsFromFollowedUsers(userID uint) ([]dto.Detaile
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint) error); ok {
-		r1 = rf(userID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, int) error); ok {
+		r1 = rf(ctx, userID, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -256,13 +509,13 @@ func (_m *ThreadUseCase) GetThreadsFromFollowedUsers(userID uint) ([]dto.Detaile
 	return r0, r1
 }
 
-// GetTrendingThreads provides a mock function with given fields: userID, limit
-func (_m *ThreadUseCase) GetTrendingThreads(userID uint, limit int) ([]dto.DetailedThreadResponse, error) {
-	ret := _m.Called(userID, limit)
+// GetTrendingThreadsByTimeframe provides a mock function with given fields: ctx, userID, timeframe, limit
+func (_m *ThreadUseCase) GetTrendingThreadsByTimeframe(ctx context.Context, userID uint, timeframe string, limit int) ([]dto.DetailedThreadResponse, error) {
+	ret := _m.Called(ctx, userID, timeframe, limit)
 
 	var r0 []dto.DetailedThreadResponse
-	if rf, ok := ret.Get(0).(func(uint, int) []dto.DetailedThreadResponse); ok {
-		r0 = rf(userID, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, int) []dto.DetailedThreadResponse); ok {
+		r0 = rf(ctx, userID, timeframe, limit)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]dto.DetailedThreadResponse)
@@ -270,8 +523,8 @@ func (_m *ThreadUseCase) GetTrendingThreads(userID uint, limit int) ([]dto.Detai
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint, int) error); ok {
-		r1 = rf(userID, limit)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, string, int) error); ok {
+		r1 = rf(ctx, userID, timeframe, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -279,13 +532,13 @@ func (_m *ThreadUseCase) GetTrendingThreads(userID uint, limit int) ([]dto.Detai
 	return r0, r1
 }
 
-// LikeComment provides a mock function with given fields: commentID, userID
-func (_m *ThreadUseCase) LikeComment(commentID uint, userID uint) error {
-	ret := _m.Called(commentID, userID)
+// HideThread provides a mock function with given fields: ctx, threadID, userID
+func (_m *ThreadUseCase) HideThread(ctx context.Context, threadID uint, userID uint) error {
+	ret := _m.Called(ctx, threadID, userID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint, uint) error); ok {
-		r0 = rf(commentID, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, threadID, userID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -293,13 +546,13 @@ func (_m *ThreadUseCase) LikeComment(commentID uint, userID uint) error {
 	return r0
 }
 
-// SetThreadImage provides a mock function with given fields: img, threadID, userID
-func (_m *ThreadUseCase) SetThreadImage(img *multipart.FileHeader, threadID uint, userID uint) error {
-	ret := _m.Called(img, threadID, userID)
+// LikeComment provides a mock function with given fields: ctx, commentID, userID
+func (_m *ThreadUseCase) LikeComment(ctx context.Context, commentID uint, userID uint) error {
+	ret := _m.Called(ctx, commentID, userID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*multipart.FileHeader, uint, uint) error); ok {
-		r0 = rf(img, threadID, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, commentID, userID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -307,13 +560,13 @@ func (_m *ThreadUseCase) SetThreadImage(img *multipart.FileHeader, threadID uint
 	return r0
 }
 
-// StoreSavedThread provides a mock function with given fields: savedThread
-func (_m *ThreadUseCase) StoreSavedThread(savedThread dto.SavedThreadRequest) error {
-	ret := _m.Called(savedThread)
+// MuteThread provides a mock function with given fields: ctx, threadID, userID
+func (_m *ThreadUseCase) MuteThread(ctx context.Context, threadID uint, userID uint) error {
+	ret := _m.Called(ctx, threadID, userID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(dto.SavedThreadRequest) error); ok {
-		r0 = rf(savedThread)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, threadID, userID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -321,13 +574,13 @@ func (_m *ThreadUseCase) StoreSavedThread(savedThread dto.SavedThreadRequest) er
 	return r0
 }
 
-// UndoDownvoteThread provides a mock function with given fields: threadID, userID
-func (_m *ThreadUseCase) UndoDownvoteThread(threadID uint, userID uint) error {
-	ret := _m.Called(threadID, userID)
+// NotifyFollowers provides a mock function with given fields: ctx, threadID, commenterID
+func (_m *ThreadUseCase) NotifyFollowers(ctx context.Context, threadID uint, commenterID uint) error {
+	ret := _m.Called(ctx, threadID, commenterID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint, uint) error); ok {
-		r0 = rf(threadID, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, threadID, commenterID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -335,13 +588,13 @@ func (_m *ThreadUseCase) UndoDownvoteThread(threadID uint, userID uint) error {
 	return r0
 }
 
-// UndoUpvoteThread provides a mock function with given fields: threadID, userID
-func (_m *ThreadUseCase) UndoUpvoteThread(threadID uint, userID uint) error {
-	ret := _m.Called(threadID, userID)
+// PublishScheduledThread provides a mock function with given fields: ctx, threadID
+func (_m *ThreadUseCase) PublishScheduledThread(ctx context.Context, threadID uint) error {
+	ret := _m.Called(ctx, threadID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint, uint) error); ok {
-		r0 = rf(threadID, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, threadID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -349,13 +602,34 @@ func (_m *ThreadUseCase) UndoUpvoteThread(threadID uint, userID uint) error {
 	return r0
 }
 
-// UnlikeComment provides a mock function with given fields: commentID, userID
-func (_m *ThreadUseCase) UnlikeComment(commentID uint, userID uint) error {
-	ret := _m.Called(commentID, userID)
+// PublishThread provides a mock function with given fields: ctx, threadID, userID, req, img
+func (_m *ThreadUseCase) PublishThread(ctx context.Context, threadID uint, userID uint, req dto.PublishThreadRequest, img *multipart.FileHeader) (dto.DraftThreadResponse, error) {
+	ret := _m.Called(ctx, threadID, userID, req, img)
+
+	var r0 dto.DraftThreadResponse
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, dto.PublishThreadRequest, *multipart.FileHeader) dto.DraftThreadResponse); ok {
+		r0 = rf(ctx, threadID, userID, req, img)
+	} else {
+		r0 = ret.Get(0).(dto.DraftThreadResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint, dto.PublishThreadRequest, *multipart.FileHeader) error); ok {
+		r1 = rf(ctx, threadID, userID, req, img)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RefreshThreadScores provides a mock function with given fields: ctx
+func (_m *ThreadUseCase) RefreshThreadScores(ctx context.Context) error {
+	ret := _m.Called(ctx)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint, uint) error); ok {
-		r0 = rf(commentID, userID)
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -363,20 +637,216 @@ func (_m *ThreadUseCase) UnlikeComment(commentID uint, userID uint) error {
 	return r0
 }
 
-// UpdateThread provides a mock function with given fields: _a0, threadID, userID
-func (_m *ThreadUseCase) UpdateThread(_a0 dto.ThreadRequest, threadID uint, userID uint) (dto.ThreadResponse, error) {
-	ret := _m.Called(_a0, threadID, userID)
+// RenameSavedThreadCollection provides a mock function with given fields: ctx, userID, collectionID, req
+func (_m *ThreadUseCase) RenameSavedThreadCollection(ctx context.Context, userID uint, collectionID uint, req dto.SavedThreadCollectionRequest) error {
+	ret := _m.Called(ctx, userID, collectionID, req)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, dto.SavedThreadCollectionRequest) error); ok {
+		r0 = rf(ctx, userID, collectionID, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReorderAttachments provides a mock function with given fields: ctx, threadID, userID, req
+func (_m *ThreadUseCase) ReorderAttachments(ctx context.Context, threadID uint, userID uint, req dto.ReorderAttachmentsRequest) error {
+	ret := _m.Called(ctx, threadID, userID, req)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, dto.ReorderAttachmentsRequest) error); ok {
+		r0 = rf(ctx, threadID, userID, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetThreadImage provides a mock function with given fields: ctx, img, threadID, userID
+func (_m *ThreadUseCase) SetThreadImage(ctx context.Context, img *multipart.FileHeader, threadID uint, userID uint) error {
+	ret := _m.Called(ctx, img, threadID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *multipart.FileHeader, uint, uint) error); ok {
+		r0 = rf(ctx, img, threadID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StoreSavedThread provides a mock function with given fields: ctx, savedThread
+func (_m *ThreadUseCase) StoreSavedThread(ctx context.Context, savedThread dto.SavedThreadRequest) error {
+	ret := _m.Called(ctx, savedThread)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.SavedThreadRequest) error); ok {
+		r0 = rf(ctx, savedThread)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UndoDownvoteThread provides a mock function with given fields: ctx, threadID, userID
+func (_m *ThreadUseCase) UndoDownvoteThread(ctx context.Context, threadID uint, userID uint) error {
+	ret := _m.Called(ctx, threadID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, threadID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UndoUpvoteThread provides a mock function with given fields: ctx, threadID, userID
+func (_m *ThreadUseCase) UndoUpvoteThread(ctx context.Context, threadID uint, userID uint) error {
+	ret := _m.Called(ctx, threadID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, threadID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnfollowThread provides a mock function with given fields: ctx, threadID, userID
+func (_m *ThreadUseCase) UnfollowThread(ctx context.Context, threadID uint, userID uint) error {
+	ret := _m.Called(ctx, threadID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, threadID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnfurlThread provides a mock function with given fields: ctx, threadID
+func (_m *ThreadUseCase) UnfurlThread(ctx context.Context, threadID uint) error {
+	ret := _m.Called(ctx, threadID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, threadID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnhideThread provides a mock function with given fields: ctx, threadID, userID
+func (_m *ThreadUseCase) UnhideThread(ctx context.Context, threadID uint, userID uint) error {
+	ret := _m.Called(ctx, threadID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, threadID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnlikeComment provides a mock function with given fields: ctx, commentID, userID
+func (_m *ThreadUseCase) UnlikeComment(ctx context.Context, commentID uint, userID uint) error {
+	ret := _m.Called(ctx, commentID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, commentID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnmuteThread provides a mock function with given fields: ctx, threadID, userID
+func (_m *ThreadUseCase) UnmuteThread(ctx context.Context, threadID uint, userID uint) error {
+	ret := _m.Called(ctx, threadID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, threadID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnscheduleThread provides a mock function with given fields: ctx, threadID, userID
+func (_m *ThreadUseCase) UnscheduleThread(ctx context.Context, threadID uint, userID uint) error {
+	ret := _m.Called(ctx, threadID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, threadID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateAttachment provides a mock function with given fields: ctx, threadID, attachmentID, userID, req
+func (_m *ThreadUseCase) UpdateAttachment(ctx context.Context, threadID uint, attachmentID uint, userID uint, req dto.AttachmentRequest) error {
+	ret := _m.Called(ctx, threadID, attachmentID, userID, req)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, uint, dto.AttachmentRequest) error); ok {
+		r0 = rf(ctx, threadID, attachmentID, userID, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateSavedThread provides a mock function with given fields: ctx, threadID, userID, req
+func (_m *ThreadUseCase) UpdateSavedThread(ctx context.Context, threadID uint, userID uint, req dto.UpdateSavedThreadRequest) error {
+	ret := _m.Called(ctx, threadID, userID, req)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, dto.UpdateSavedThreadRequest) error); ok {
+		r0 = rf(ctx, threadID, userID, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateThread provides a mock function with given fields: ctx, _a1, threadID, userID
+func (_m *ThreadUseCase) UpdateThread(ctx context.Context, _a1 dto.ThreadRequest, threadID uint, userID uint) (dto.ThreadResponse, error) {
+	ret := _m.Called(ctx, _a1, threadID, userID)
 
 	var r0 dto.ThreadResponse
-	if rf, ok := ret.Get(0).(func(dto.ThreadRequest, uint, uint) dto.ThreadResponse); ok {
-		r0 = rf(_a0, threadID, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, dto.ThreadRequest, uint, uint) dto.ThreadResponse); ok {
+		r0 = rf(ctx, _a1, threadID, userID)
 	} else {
 		r0 = ret.Get(0).(dto.ThreadResponse)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(dto.ThreadRequest, uint, uint) error); ok {
-		r1 = rf(_a0, threadID, userID)
+	if rf, ok := ret.Get(1).(func(context.Context, dto.ThreadRequest, uint, uint) error); ok {
+		r1 = rf(ctx, _a1, threadID, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -384,13 +854,13 @@ func (_m *ThreadUseCase) UpdateThread(_a0 dto.ThreadRequest, threadID uint, user
 	return r0, r1
 }
 
-// UpvoteThread provides a mock function with given fields: threadID, userID
-func (_m *ThreadUseCase) UpvoteThread(threadID uint, userID uint) error {
-	ret := _m.Called(threadID, userID)
+// UpvoteThread provides a mock function with given fields: ctx, threadID, userID
+func (_m *ThreadUseCase) UpvoteThread(ctx context.Context, threadID uint, userID uint) error {
+	ret := _m.Called(ctx, threadID, userID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint, uint) error); ok {
-		r0 = rf(threadID, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, threadID, userID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -398,6 +868,48 @@ func (_m *ThreadUseCase) UpvoteThread(threadID uint, userID uint) error {
 	return r0
 }
 
+// VoteComment provides a mock function with given fields: ctx, threadID, commentID, userID, vote
+func (_m *ThreadUseCase) VoteComment(ctx context.Context, threadID uint, commentID uint, userID uint, vote dto.CommentVoteRequest) (dto.CommentVoteResponse, error) {
+	ret := _m.Called(ctx, threadID, commentID, userID, vote)
+
+	var r0 dto.CommentVoteResponse
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, uint, dto.CommentVoteRequest) dto.CommentVoteResponse); ok {
+		r0 = rf(ctx, threadID, commentID, userID, vote)
+	} else {
+		r0 = ret.Get(0).(dto.CommentVoteResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint, uint, dto.CommentVoteRequest) error); ok {
+		r1 = rf(ctx, threadID, commentID, userID, vote)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VoteThread provides a mock function with given fields: ctx, threadID, userID, vote
+func (_m *ThreadUseCase) VoteThread(ctx context.Context, threadID uint, userID uint, vote dto.ThreadVoteRequest) (dto.ThreadVoteResponse, error) {
+	ret := _m.Called(ctx, threadID, userID, vote)
+
+	var r0 dto.ThreadVoteResponse
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, dto.ThreadVoteRequest) dto.ThreadVoteResponse); ok {
+		r0 = rf(ctx, threadID, userID, vote)
+	} else {
+		r0 = ret.Get(0).(dto.ThreadVoteResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint, dto.ThreadVoteRequest) error); ok {
+		r1 = rf(ctx, threadID, userID, vote)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewThreadUseCase interface {
 	mock.TestingT
 	Cleanup(func())
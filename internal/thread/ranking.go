@@ -0,0 +1,72 @@
+package thread
+
+import (
+	"macaiki/internal/thread/entity"
+	"math"
+	"time"
+)
+
+// Timeframes accepted by the trending endpoints
+const (
+	TimeframeDay   = "day"
+	TimeframeWeek  = "week"
+	TimeframeMonth = "month"
+	TimeframeAll   = "all"
+)
+
+// RankingConfig tunes how threads are ranked as trending
+type RankingConfig struct {
+	// Gravity is how fast the hot score of a thread decays with its age
+	Gravity float64
+	// DownvotePenalty is how many upvotes a single downvote cancels out
+	DownvotePenalty float64
+	// CommentWeight is what a comment counts for relative to an upvote,
+	// for the hot score only comments from the last day count
+	CommentWeight float64
+	// RefreshInterval is how often the precomputed scores are rebuilt
+	RefreshInterval time.Duration
+}
+
+func DefaultRankingConfig() RankingConfig {
+	return RankingConfig{
+		Gravity:         1.8,
+		DownvotePenalty: 1,
+		CommentWeight:   0.5,
+		RefreshInterval: 5 * time.Minute,
+	}
+}
+
+// Score computes every trending score of a thread from its activity at now
+func (rc RankingConfig) Score(activity entity.ThreadActivity, now time.Time) entity.ThreadScore {
+	points := rc.points(activity.UpvotesCount, activity.DownvotesCount, activity.DayComments)
+
+	return entity.ThreadScore{
+		ThreadID:    activity.ThreadID,
+		CommunityID: activity.CommunityID,
+		HotScore:    rc.Hot(points, activity.CreatedAt, now),
+		DayScore:    rc.points(activity.DayUpvotes, activity.DayDownvotes, activity.DayComments),
+		WeekScore:   rc.points(activity.WeekUpvotes, activity.WeekDownvotes, activity.WeekComments),
+		MonthScore:  rc.points(activity.MonthUpvotes, activity.MonthDownvotes, activity.MonthComments),
+		AllScore:    rc.points(activity.UpvotesCount, activity.DownvotesCount, activity.CommentsCount),
+		ComputedAt:  now,
+	}
+}
+
+// Hot decays points by the age in hours a thread created at createdAt has
+// at now
+func (rc RankingConfig) Hot(points float64, createdAt, now time.Time) float64 {
+	age := now.Sub(createdAt).Hours()
+	if age < 0 {
+		age = 0
+	}
+	return points / math.Pow(age+2, rc.Gravity)
+}
+
+// VotePoints is what the votes of a thread are worth before decay
+func (rc RankingConfig) VotePoints(upvotes, downvotes int) float64 {
+	return rc.points(upvotes, downvotes, 0)
+}
+
+func (rc RankingConfig) points(upvotes, downvotes, comments int) float64 {
+	return float64(upvotes) - rc.DownvotePenalty*float64(downvotes) + rc.CommentWeight*float64(comments)
+}
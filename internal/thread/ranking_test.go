@@ -0,0 +1,33 @@
+package thread
+
+import (
+	"macaiki/internal/thread/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRankingConfigScore(t *testing.T) {
+	rc := DefaultRankingConfig()
+	now := time.Now()
+
+	fresh := rc.Score(entity.ThreadActivity{ThreadID: 1, CreatedAt: now.Add(-time.Hour), UpvotesCount: 10}, now)
+	old := rc.Score(entity.ThreadActivity{ThreadID: 2, CreatedAt: now.Add(-24 * time.Hour), UpvotesCount: 10}, now)
+	assert.Greater(t, fresh.HotScore, old.HotScore)
+
+	disliked := rc.Score(entity.ThreadActivity{ThreadID: 3, CreatedAt: now.Add(-time.Hour), UpvotesCount: 10, DownvotesCount: 6}, now)
+	assert.Greater(t, fresh.HotScore, disliked.HotScore)
+
+	discussed := rc.Score(entity.ThreadActivity{ThreadID: 4, CreatedAt: now.Add(-time.Hour), UpvotesCount: 10, DayComments: 6}, now)
+	assert.Greater(t, discussed.HotScore, fresh.HotScore)
+
+	windows := rc.Score(entity.ThreadActivity{
+		UpvotesCount: 20, DownvotesCount: 2, CommentsCount: 10,
+		DayUpvotes: 1, WeekUpvotes: 5, WeekDownvotes: 1, WeekComments: 2, MonthUpvotes: 12, MonthComments: 4,
+	}, now)
+	assert.Equal(t, 1.0, windows.DayScore)
+	assert.Equal(t, 5.0, windows.WeekScore)
+	assert.Equal(t, 14.0, windows.MonthScore)
+	assert.Equal(t, 23.0, windows.AllScore)
+}
@@ -1,37 +1,67 @@
 package thread
 
-import "macaiki/internal/thread/entity"
+import (
+	"context"
+	"macaiki/internal/thread/entity"
+	"time"
+)
 
 type ThreadRepository interface {
-	CreateThread(thread entity.Thread) (entity.Thread, error)
-	DeleteThread(threadID uint) error
-	UpdateThread(threadID uint, thread entity.Thread) error
-	GetThreadByID(threadID uint) (entity.Thread, error)
-	SetThreadImage(imageURL string, threadID uint) error
-	UpvoteThread(threadUpvote entity.ThreadUpvote) error
-	UndoUpvoteThread(threadID, userID uint) error
-	GetTrendingThreads(userID uint) ([]entity.ThreadWithDetails, error)
-	GetTrendingThreadsWithLimit(userID uint, limit int) ([]entity.ThreadWithDetails, error)
-	GetThreadsFromFollowedCommunity(userID uint) ([]entity.ThreadWithDetails, error)
-	GetThreadsFromFollowedUsers(userID uint) ([]entity.ThreadWithDetails, error)
-	AddThreadComment(comment entity.Comment) error
-	GetCommentsByThreadID(threadID uint) ([]entity.CommentDetails, error)
-	GetThreads(keyword string, userID uint) ([]entity.ThreadWithDetails, error)
-	LikeComment(commentLikes entity.CommentLikes) error
-	UnlikeComment(commentID, userID uint) error
-	DownvoteThread(downvote entity.ThreadDownvote) error
-	UndoDownvoteThread(threadID, userID uint) error
-	GetThreadDownvotes(threadID, userID uint) (entity.ThreadDownvote, error)
-	GetThreadUpvotes(threadID, userID uint) (entity.ThreadUpvote, error)
-	DeleteComment(commentID uint) error
-	GetCommentByID(commentID uint) (entity.Comment, error)
-	CreateThreadReport(threadReport entity.ThreadReport) error
-	GetThreadReport(id uint) (entity.ThreadReport, error)
-	UpdateThreadReport(threadReport entity.ThreadReport, userID uint) error
-	CreateCommentReport(commentReport entity.CommentReport) error
-	GetCommentReport(id uint) (entity.CommentReport, error)
-	UpdateCommentReport(commentReport entity.CommentReport, userID uint) error
-	GetThreadsByUserID(userID, tokenUserID uint) ([]entity.ThreadWithDetails, error)
-	StoreSavedThread(savedThread entity.SavedThread) error
-	GetSavedThread(userID uint) ([]entity.ThreadWithDetails, error)
+	CreateThread(ctx context.Context, thread entity.Thread) (entity.Thread, error)
+	DeleteThread(ctx context.Context, threadID uint) error
+	UpdateThread(ctx context.Context, threadID uint, thread entity.Thread) error
+	GetThreadByID(ctx context.Context, threadID uint) (entity.Thread, error)
+	SetThreadImage(ctx context.Context, imageURL string, threadID uint) error
+	SetThreadPreview(ctx context.Context, threadID uint, body string, preview entity.LinkPreview) error
+	GetDraftThreads(ctx context.Context, userID uint) ([]entity.Thread, error)
+	PublishThread(ctx context.Context, threadID uint, status, imageURL string, publishedAt time.Time) error
+	ScheduleThread(ctx context.Context, threadID uint, status, imageURL string, publishAt time.Time) error
+	UnscheduleThread(ctx context.Context, threadID uint) error
+	GetAttachmentsByThreadID(ctx context.Context, threadID uint) ([]entity.Attachment, error)
+	GetAttachment(ctx context.Context, attachmentID uint) (entity.Attachment, error)
+	CreateAttachment(ctx context.Context, attachment entity.Attachment, limit int) (entity.Attachment, error)
+	UpdateAttachment(ctx context.Context, attachmentID uint, altText, caption string) error
+	ReorderAttachments(ctx context.Context, threadID uint, attachmentIDs []uint) error
+	DeleteAttachment(ctx context.Context, attachmentID uint) error
+	GetThreadVote(ctx context.Context, threadID, userID uint) (entity.ThreadVote, error)
+	SetThreadVote(ctx context.Context, threadID, userID uint, value int) (entity.Thread, int, error)
+	GetRankedThreads(ctx context.Context, userID, communityID uint, timeframe string, limit int) ([]entity.ThreadWithDetails, error)
+	GetThreadActivity(ctx context.Context, now time.Time) ([]entity.ThreadActivity, error)
+	StoreThreadScores(ctx context.Context, scores []entity.ThreadScore, computedAt time.Time) error
+	GetThreadsFromFollowedCommunity(ctx context.Context, userID uint) ([]entity.ThreadWithDetails, error)
+	GetThreadsFromFollowedUsers(ctx context.Context, userID uint) ([]entity.ThreadWithDetails, error)
+	AddThreadComment(ctx context.Context, comment entity.Comment) error
+	GetCommentsByThreadID(ctx context.Context, threadID, userID uint) ([]entity.CommentDetails, error)
+	GetThreads(ctx context.Context, keyword string, userID uint) ([]entity.ThreadWithDetails, error)
+	GetCommentVote(ctx context.Context, commentID, userID uint) (entity.CommentVote, error)
+	SetCommentVote(ctx context.Context, commentID, userID uint, value int) (entity.Comment, int, error)
+	DeleteComment(ctx context.Context, commentID uint) error
+	GetCommentByID(ctx context.Context, commentID uint) (entity.Comment, error)
+	CreateThreadReport(ctx context.Context, threadReport entity.ThreadReport) error
+	GetThreadReport(ctx context.Context, id uint) (entity.ThreadReport, error)
+	UpdateThreadReport(ctx context.Context, threadReport entity.ThreadReport, userID uint) error
+	CreateCommentReport(ctx context.Context, commentReport entity.CommentReport) error
+	GetCommentReport(ctx context.Context, id uint) (entity.CommentReport, error)
+	UpdateCommentReport(ctx context.Context, commentReport entity.CommentReport, userID uint) error
+	GetThreadsByUserID(ctx context.Context, userID, tokenUserID uint) ([]entity.ThreadWithDetails, error)
+	StoreSavedThread(ctx context.Context, savedThread entity.SavedThread) error
+	GetSavedThread(ctx context.Context, userID uint) ([]entity.ThreadWithDetails, error)
+	GetSavedThreadByThreadID(ctx context.Context, userID, threadID uint) (entity.SavedThread, error)
+	UpdateSavedThread(ctx context.Context, userID, threadID uint, collectionID *uint, note string) error
+	DeleteSavedThread(ctx context.Context, userID, threadID uint) error
+	GetSavedThreadsByCollection(ctx context.Context, userID, collectionID uint, limit, offset int) ([]entity.SavedThreadDetails, error)
+	CountSavedThreads(ctx context.Context, userID, collectionID uint) (int64, error)
+	CreateSavedThreadCollection(ctx context.Context, collection entity.SavedThreadCollection) (entity.SavedThreadCollection, error)
+	GetSavedThreadCollection(ctx context.Context, userID, collectionID uint) (entity.SavedThreadCollection, error)
+	GetSavedThreadCollections(ctx context.Context, userID uint) ([]entity.SavedThreadCollection, error)
+	RenameSavedThreadCollection(ctx context.Context, userID, collectionID uint, name string) error
+	DeleteSavedThreadCollection(ctx context.Context, userID, collectionID uint) error
+	GetThreadFollower(ctx context.Context, threadID, userID uint) (entity.ThreadFollower, error)
+	GetThreadFollowers(ctx context.Context, threadID uint) ([]entity.ThreadFollower, error)
+	SetThreadFollower(ctx context.Context, threadID, userID uint, muted bool) error
+	SubscribeThread(ctx context.Context, threadID, userID uint) error
+	DeleteThreadFollower(ctx context.Context, threadID, userID uint) error
+	HideThread(ctx context.Context, userID, threadID uint) error
+	UnhideThread(ctx context.Context, userID, threadID uint) error
+	GetHiddenThreads(ctx context.Context, userID uint) ([]entity.ThreadWithDetails, error)
 }
@@ -1,29 +1,41 @@
 package mysql
 
 import (
-	"fmt"
+	"context"
+	"errors"
+	"log/slog"
 	"macaiki/internal/thread"
 	"macaiki/internal/thread/entity"
+	"macaiki/pkg/logger"
 	"macaiki/pkg/utils"
 	"strings"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ThreadRepositoryImpl struct {
-	db *gorm.DB
+	db     *gorm.DB
+	logger *slog.Logger
 }
 
-func CreateNewThreadRepository(db *gorm.DB) thread.ThreadRepository {
-	return &ThreadRepositoryImpl{db: db}
+// notHidden and notMuted leave out of a feed the threads the reader hid and
+// the threads of communities they muted, each takes the reader's ID
+const (
+	notHidden = " AND NOT EXISTS (SELECT 1 FROM hidden_threads ht WHERE ht.thread_id = t.id AND ht.user_id = ?)"
+	notMuted  = " AND NOT EXISTS (SELECT 1 FROM muted_communities mc WHERE mc.community_id = t.community_id AND mc.user_id = ?)"
+)
+
+func CreateNewThreadRepository(db *gorm.DB, log *slog.Logger) thread.ThreadRepository {
+	return &ThreadRepositoryImpl{db: db, logger: logger.OrDefault(log)}
 }
 
-func (tr *ThreadRepositoryImpl) SetThreadImage(imageURL string, threadID uint) error {
-	fmt.Println(imageURL)
-	res := tr.db.Model(&entity.Thread{}).Where("id = ?", threadID).Update("image_url", imageURL)
+func (tr *ThreadRepositoryImpl) SetThreadImage(ctx context.Context, imageURL string, threadID uint) error {
+	res := tr.db.WithContext(ctx).Model(&entity.Thread{}).Where("id = ?", threadID).Update("image_url", imageURL)
 
 	if res.Error != nil {
-		fmt.Println(res.Error)
+		tr.logger.ErrorContext(ctx, "query failed", "op", "SetThreadImage", "err", res.Error)
 		if res.Error.Error() == "record not found" {
 			return utils.ErrNotFound
 		}
@@ -33,11 +45,11 @@ func (tr *ThreadRepositoryImpl) SetThreadImage(imageURL string, threadID uint) e
 	return nil
 }
 
-func (tr *ThreadRepositoryImpl) GetThreadByID(threadID uint) (entity.Thread, error) {
+func (tr *ThreadRepositoryImpl) GetThreadByID(ctx context.Context, threadID uint) (entity.Thread, error) {
 	var thread entity.Thread
-	res := tr.db.First(&thread, threadID)
+	res := tr.db.WithContext(ctx).First(&thread, threadID)
 	if res.Error != nil {
-		fmt.Println(res.Error)
+		tr.logger.ErrorContext(ctx, "query failed", "op", "GetThreadByID", "err", res.Error)
 		if res.Error.Error() == "record not found" {
 			return entity.Thread{}, utils.ErrNotFound
 		}
@@ -47,51 +59,134 @@ func (tr *ThreadRepositoryImpl) GetThreadByID(threadID uint) (entity.Thread, err
 	return thread, nil
 }
 
-func (tr *ThreadRepositoryImpl) CreateThread(thread entity.Thread) (entity.Thread, error) {
-	res := tr.db.Debug().Create(&thread)
+func (tr *ThreadRepositoryImpl) CreateThread(ctx context.Context, thread entity.Thread) (entity.Thread, error) {
+	res := tr.db.WithContext(ctx).Create(&thread)
 	if res.Error != nil {
-		fmt.Println(res.Error)
+		tr.logger.ErrorContext(ctx, "query failed", "op", "CreateThread", "err", res.Error)
 		return entity.Thread{}, utils.ErrInternalServerError
 	}
 
 	return thread, nil
 }
 
-func (tr *ThreadRepositoryImpl) DeleteThread(threadID uint) error {
-	res := tr.db.Delete(&entity.Thread{}, threadID)
+// GetDraftThreads lists the drafts and scheduled threads of userID, the
+// most recently edited first
+func (tr *ThreadRepositoryImpl) GetDraftThreads(ctx context.Context, userID uint) ([]entity.Thread, error) {
+	threads := []entity.Thread{}
+	res := tr.db.WithContext(ctx).Where("user_id = ? AND status IN ?", userID, []string{entity.ThreadStatusDraft, entity.ThreadStatusScheduled}).Order("updated_at DESC, id DESC").Find(&threads)
+	if res.Error != nil {
+		tr.logger.ErrorContext(ctx, "query failed", "op", "GetDraftThreads", "err", res.Error)
+		return []entity.Thread{}, utils.ErrInternalServerError
+	}
+
+	return threads, nil
+}
+
+// PublishThread moves threadID out of status and publishes it in a single
+// update, together with its image when imageURL is set. CreatedAt is reset
+// to publishedAt so the thread ages from its publication. It fails with
+// ErrConflict when the thread is no longer in status.
+func (tr *ThreadRepositoryImpl) PublishThread(ctx context.Context, threadID uint, status, imageURL string, publishedAt time.Time) error {
+	values := map[string]interface{}{
+		"status":     entity.ThreadStatusPublished,
+		"publish_at": nil,
+		"created_at": publishedAt,
+	}
+	if imageURL != "" {
+		values["image_url"] = imageURL
+	}
+
+	res := tr.db.WithContext(ctx).Model(&entity.Thread{}).Where("id = ? AND status = ?", threadID, status).Updates(values)
 	if res.Error != nil {
+		tr.logger.ErrorContext(ctx, "query failed", "op", "PublishThread", "err", res.Error)
 		return utils.ErrInternalServerError
 	}
+	if res.RowsAffected == 0 {
+		return utils.ErrConflict
+	}
 
-	if res.RowsAffected < 1 {
+	return nil
+}
+
+// ScheduleThread moves threadID out of status to be published at
+// publishAt, the image is stored with it when imageURL is set. It fails
+// with ErrConflict when the thread is no longer in status.
+func (tr *ThreadRepositoryImpl) ScheduleThread(ctx context.Context, threadID uint, status, imageURL string, publishAt time.Time) error {
+	values := map[string]interface{}{
+		"status":     entity.ThreadStatusScheduled,
+		"publish_at": publishAt,
+	}
+	if imageURL != "" {
+		values["image_url"] = imageURL
+	}
+
+	res := tr.db.WithContext(ctx).Model(&entity.Thread{}).Where("id = ? AND status = ?", threadID, status).Updates(values)
+	if res.Error != nil {
+		tr.logger.ErrorContext(ctx, "query failed", "op", "ScheduleThread", "err", res.Error)
+		return utils.ErrInternalServerError
+	}
+	if res.RowsAffected == 0 {
+		return utils.ErrConflict
+	}
+
+	return nil
+}
+
+// UnscheduleThread turns a scheduled thread back into a draft
+func (tr *ThreadRepositoryImpl) UnscheduleThread(ctx context.Context, threadID uint) error {
+	res := tr.db.WithContext(ctx).Model(&entity.Thread{}).Where("id = ? AND status = ?", threadID, entity.ThreadStatusScheduled).Updates(map[string]interface{}{
+		"status":     entity.ThreadStatusDraft,
+		"publish_at": nil,
+	})
+	if res.Error != nil {
+		tr.logger.ErrorContext(ctx, "query failed", "op", "UnscheduleThread", "err", res.Error)
+		return utils.ErrInternalServerError
+	}
+	if res.RowsAffected == 0 {
 		return utils.ErrNotFound
 	}
 
 	return nil
 }
 
-func (tr *ThreadRepositoryImpl) UpdateThread(threadID uint, thread entity.Thread) error {
-	res := tr.db.Model(&entity.Thread{}).Where("id", threadID).Updates(thread)
+// SetThreadPreview stores the link preview of threadID as long as its body
+// is still body, a preview fetched for an older revision is dropped. It is
+// not an edit, so updated_at is left alone.
+func (tr *ThreadRepositoryImpl) SetThreadPreview(ctx context.Context, threadID uint, body string, preview entity.LinkPreview) error {
+	res := tr.db.WithContext(ctx).Model(&entity.Thread{}).Where("id = ? AND body = ?", threadID, body).UpdateColumns(map[string]interface{}{
+		"preview_url":         preview.URL,
+		"preview_title":       preview.Title,
+		"preview_description": preview.Description,
+		"preview_image_url":   preview.ImageURL,
+		"preview_site_name":   preview.SiteName,
+	})
 	if res.Error != nil {
-		fmt.Println(res.Error)
-		if res.Error.Error() == "record not found" {
-			return utils.ErrNotFound
-		}
+		tr.logger.ErrorContext(ctx, "query failed", "op", "SetThreadPreview", "err", res.Error)
 		return utils.ErrInternalServerError
 	}
 
 	return nil
 }
 
-func (tr *ThreadRepositoryImpl) UpvoteThread(threadUpvote entity.ThreadUpvote) error {
-	res := tr.db.Create(&threadUpvote)
+func (tr *ThreadRepositoryImpl) DeleteThread(ctx context.Context, threadID uint) error {
+	res := tr.db.WithContext(ctx).Delete(&entity.Thread{}, threadID)
+	if res.Error != nil {
+		return utils.ErrInternalServerError
+	}
+
+	if res.RowsAffected < 1 {
+		return utils.ErrNotFound
+	}
+
+	return nil
+}
 
+func (tr *ThreadRepositoryImpl) UpdateThread(ctx context.Context, threadID uint, thread entity.Thread) error {
+	res := tr.db.WithContext(ctx).Model(&entity.Thread{}).Where("id", threadID).Updates(thread)
 	if res.Error != nil {
-		fmt.Println(res.Error)
-		if strings.HasPrefix(res.Error.Error(), "Error 1452: Cannot add or update a child row") {
+		tr.logger.ErrorContext(ctx, "query failed", "op", "UpdateThread", "err", res.Error)
+		if res.Error.Error() == "record not found" {
 			return utils.ErrNotFound
-		} else if strings.HasPrefix(res.Error.Error(), "Error 1062: Duplicate entry") {
-			return utils.ErrDuplicateEntry
 		}
 		return utils.ErrInternalServerError
 	}
@@ -99,197 +194,323 @@ func (tr *ThreadRepositoryImpl) UpvoteThread(threadUpvote entity.ThreadUpvote) e
 	return nil
 }
 
-func (tr *ThreadRepositoryImpl) GetTrendingThreads(userID uint) ([]entity.ThreadWithDetails, error) {
-	var threads []entity.ThreadWithDetails
-	// TODO: retrieve name, profile URL, etc
-	res := tr.db.Raw("SELECT t.*, t2.upvotes_count, NOT ISNULL(t3.id) AS is_upvoted, NOT ISNULL(t4.user_id) AS is_followed, NOT ISNULL(t5.id) AS is_downvoted, users.name, users.profile_image_url, users.profession FROM threads t LEFT JOIN (SELECT thread_id, COUNT(*) AS upvotes_count FROM thread_upvotes tu WHERE DATEDIFF(NOW(), tu.created_at) < 7 AND tu.deleted_at IS NULL GROUP BY thread_id) AS t2 ON t.id = t2.thread_id LEFT JOIN (SELECT * FROM thread_upvotes tu WHERE tu.user_id = ? AND tu.deleted_at IS NULL) AS t3 ON t.id = t3.thread_id LEFT JOIN (SELECT user_id FROM user_followers uf WHERE uf.follower_id = ?) AS t4 ON t4.user_id = t.user_id LEFT JOIN users ON users.id = t.user_id LEFT JOIN (SELECT id, thread_id FROM thread_downvotes td WHERE td.user_id = ? AND td.deleted_at IS NULL) AS t5 ON t5.thread_id = t.id WHERE t.deleted_at IS NULL ORDER BY upvotes_count DESC;", userID, userID, userID).Scan(&threads)
+// rankingColumns maps a timeframe onto the thread_scores column it is
+// ranked by, no timeframe ranks by the hot score
+var rankingColumns = map[string]string{
+	"":                    "hot_score",
+	thread.TimeframeDay:   "day_score",
+	thread.TimeframeWeek:  "week_score",
+	thread.TimeframeMonth: "month_score",
+	thread.TimeframeAll:   "all_score",
+}
+
+// GetRankedThreads lists threads by their precomputed score for timeframe,
+// threads that were not scored yet rank as zero. A zero communityID ranks
+// every community and a limit below one returns every thread.
+func (tr *ThreadRepositoryImpl) GetRankedThreads(ctx context.Context, userID, communityID uint, timeframe string, limit int) ([]entity.ThreadWithDetails, error) {
+	threads := []entity.ThreadWithDetails{}
+
+	column, ok := rankingColumns[timeframe]
+	if !ok {
+		return threads, utils.ErrBadParamInput
+	}
+
+	query := "SELECT t.*, COALESCE(tv.value, 0) AS user_vote, NOT ISNULL(t4.user_id) AS is_followed, users.name, users.profile_image_url, users.profession FROM threads t LEFT JOIN thread_scores ts ON ts.thread_id = t.id LEFT JOIN thread_votes tv ON tv.thread_id = t.id AND tv.user_id = ? LEFT JOIN (SELECT user_id FROM user_followers uf WHERE uf.follower_id = ?) AS t4 ON t4.user_id = t.user_id LEFT JOIN users ON users.id = t.user_id WHERE t.deleted_at IS NULL AND t.status = 'published'" + notHidden
+	args := []interface{}{userID, userID, userID}
+	// a muted community is left out of trending, unless it is the one
+	// being ranked
+	if communityID != 0 {
+		query += " AND t.community_id = ?"
+		args = append(args, communityID)
+	} else {
+		query += notMuted
+		args = append(args, userID)
+	}
+	// a thread without activity in a window is not trending in it
+	if timeframe == thread.TimeframeDay || timeframe == thread.TimeframeWeek || timeframe == thread.TimeframeMonth {
+		query += " AND ts." + column + " > 0"
+	}
+	query += " ORDER BY COALESCE(ts." + column + ", 0) DESC, t.created_at DESC"
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	res := tr.db.WithContext(ctx).Raw(query, args...).Scan(&threads)
 	if res.Error != nil {
-		return []entity.ThreadWithDetails{}, res.Error
+		tr.logger.ErrorContext(ctx, "query failed", "op", "GetRankedThreads", "err", res.Error)
+		return []entity.ThreadWithDetails{}, utils.ErrInternalServerError
 	}
 
 	return threads, nil
 }
 
-func (tr *ThreadRepositoryImpl) GetTrendingThreadsWithLimit(userID uint, limit int) ([]entity.ThreadWithDetails, error) {
-	var threads []entity.ThreadWithDetails
-	// TODO: retrieve name, profile URL, etc
-	res := tr.db.Raw("SELECT t.*, t2.upvotes_count, NOT ISNULL(t3.id) AS is_upvoted, NOT ISNULL(t4.user_id) AS is_followed, NOT ISNULL(t5.id) AS is_downvoted, users.name, users.profile_image_url, users.profession FROM threads t LEFT JOIN (SELECT thread_id, COUNT(*) AS upvotes_count FROM thread_upvotes tu WHERE DATEDIFF(NOW(), tu.created_at) < 7 AND tu.deleted_at IS NULL GROUP BY thread_id) AS t2 ON t.id = t2.thread_id LEFT JOIN (SELECT * FROM thread_upvotes tu WHERE tu.user_id = ? AND tu.deleted_at IS NULL) AS t3 ON t.id = t3.thread_id LEFT JOIN (SELECT user_id FROM user_followers uf WHERE uf.follower_id = ?) AS t4 ON t4.user_id = t.user_id LEFT JOIN users ON users.id = t.user_id LEFT JOIN (SELECT id, thread_id FROM thread_downvotes td WHERE td.user_id = ? AND td.deleted_at IS NULL) AS t5 ON t5.thread_id = t.id WHERE t.deleted_at IS NULL ORDER BY upvotes_count DESC LIMIT ?;", userID, userID, userID, limit).Scan(&threads)
+// GetThreadActivity counts the votes and comments of every thread, the
+// windowed counts are taken relative to now
+func (tr *ThreadRepositoryImpl) GetThreadActivity(ctx context.Context, now time.Time) ([]entity.ThreadActivity, error) {
+	activity := []entity.ThreadActivity{}
+	day, week, month := now.AddDate(0, 0, -1), now.AddDate(0, 0, -7), now.AddDate(0, 0, -30)
+
+	res := tr.db.WithContext(ctx).Raw("SELECT t.id AS thread_id, t.community_id, t.created_at, t.upvotes_count, t.downvotes_count, COALESCE(c.comments_count, 0) AS comments_count, COALESCE(v.day_upvotes, 0) AS day_upvotes, COALESCE(v.day_downvotes, 0) AS day_downvotes, COALESCE(c.day_comments, 0) AS day_comments, COALESCE(v.week_upvotes, 0) AS week_upvotes, COALESCE(v.week_downvotes, 0) AS week_downvotes, COALESCE(c.week_comments, 0) AS week_comments, COALESCE(v.month_upvotes, 0) AS month_upvotes, COALESCE(v.month_downvotes, 0) AS month_downvotes, COALESCE(c.month_comments, 0) AS month_comments FROM threads t LEFT JOIN (SELECT thread_id, SUM(value = 1 AND updated_at >= ?) AS day_upvotes, SUM(value = -1 AND updated_at >= ?) AS day_downvotes, SUM(value = 1 AND updated_at >= ?) AS week_upvotes, SUM(value = -1 AND updated_at >= ?) AS week_downvotes, SUM(value = 1) AS month_upvotes, SUM(value = -1) AS month_downvotes FROM thread_votes WHERE updated_at >= ? GROUP BY thread_id) AS v ON v.thread_id = t.id LEFT JOIN (SELECT thread_id, COUNT(*) AS comments_count, SUM(created_at >= ?) AS day_comments, SUM(created_at >= ?) AS week_comments, SUM(created_at >= ?) AS month_comments FROM comments WHERE deleted_at IS NULL GROUP BY thread_id) AS c ON c.thread_id = t.id WHERE t.deleted_at IS NULL AND t.status = 'published'", day, day, week, week, month, day, week, month).Scan(&activity)
 	if res.Error != nil {
-		return []entity.ThreadWithDetails{}, res.Error
+		tr.logger.ErrorContext(ctx, "query failed", "op", "GetThreadActivity", "err", res.Error)
+		return []entity.ThreadActivity{}, utils.ErrInternalServerError
 	}
 
-	return threads, nil
+	return activity, nil
 }
 
-func (tr *ThreadRepositoryImpl) GetThreadsFromFollowedCommunity(userID uint) ([]entity.ThreadWithDetails, error) {
+// StoreThreadScores replaces the precomputed scores with scores, rows of
+// threads that are no longer scored (e.g. deleted) are dropped
+func (tr *ThreadRepositoryImpl) StoreThreadScores(ctx context.Context, scores []entity.ThreadScore, computedAt time.Time) error {
+	err := tr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(scores) > 0 {
+			err := tx.Clauses(clause.OnConflict{UpdateAll: true}).CreateInBatches(scores, 500).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return tx.Where("computed_at < ?", computedAt).Delete(&entity.ThreadScore{}).Error
+	})
+	if err != nil {
+		tr.logger.ErrorContext(ctx, "query failed", "op", "StoreThreadScores", "err", err)
+		return utils.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (tr *ThreadRepositoryImpl) GetThreadsFromFollowedCommunity(ctx context.Context, userID uint) ([]entity.ThreadWithDetails, error) {
 	var threads []entity.ThreadWithDetails
 
-	res := tr.db.Raw("SELECT t.*, t2.upvotes_count, NOT ISNULL(t4.id) AS is_upvoted, NOT ISNULL(t5.user_id) AS is_followed, NOT ISNULL(t6.id) AS is_downvoted, users.name, users.profile_image_url, users.profession FROM threads t LEFT JOIN (SELECT thread_id, COUNT(*) AS upvotes_count FROM thread_upvotes tu WHERE tu.deleted_at IS NULL GROUP BY thread_id) AS t2 ON t.id = t2.thread_id INNER JOIN (SELECT * FROM community_followers cf WHERE cf.user_id = ?) AS t3 ON t.community_id = t3.community_id INNER JOIN users ON users.id = t.user_id LEFT JOIN (SELECT * FROM thread_upvotes tu WHERE tu.user_id = ? AND tu.deleted_at IS NULL) AS t4 ON t.id = t4.thread_id LEFT JOIN (SELECT user_id FROM user_followers uf WHERE uf.follower_id= ?) AS t5 ON t5.user_id = t.user_id LEFT JOIN (SELECT id, thread_id FROM thread_downvotes td WHERE td.user_id = ? AND td.deleted_at IS NULL) AS t6 ON t6.thread_id = t.id WHERE t.deleted_at IS NULL;", userID, userID, userID, userID).Scan(&threads)
+	res := tr.db.WithContext(ctx).Raw("SELECT t.*, COALESCE(tv.value, 0) AS user_vote, NOT ISNULL(t5.user_id) AS is_followed, users.name, users.profile_image_url, users.profession FROM threads t INNER JOIN (SELECT * FROM community_followers cf WHERE cf.user_id = ?) AS t3 ON t.community_id = t3.community_id INNER JOIN users ON users.id = t.user_id LEFT JOIN thread_votes tv ON tv.thread_id = t.id AND tv.user_id = ? LEFT JOIN (SELECT user_id FROM user_followers uf WHERE uf.follower_id= ?) AS t5 ON t5.user_id = t.user_id WHERE t.deleted_at IS NULL AND t.status = 'published'"+notHidden+notMuted, userID, userID, userID, userID, userID).Scan(&threads)
 
 	if res.Error != nil {
-		fmt.Println(res.Error)
+		tr.logger.ErrorContext(ctx, "query failed", "op", "GetThreadsFromFollowedCommunity", "err", res.Error)
 		return []entity.ThreadWithDetails{}, utils.ErrInternalServerError
 	}
 
 	return threads, nil
 }
 
-func (tr *ThreadRepositoryImpl) GetThreadsFromFollowedUsers(userID uint) ([]entity.ThreadWithDetails, error) {
+func (tr *ThreadRepositoryImpl) GetThreadsFromFollowedUsers(ctx context.Context, userID uint) ([]entity.ThreadWithDetails, error) {
 	var threads []entity.ThreadWithDetails
 
-	res := tr.db.Raw("SELECT t.*, t2.upvotes_count, NOT ISNULL(t4.id) AS is_upvoted, NOT ISNULL(t3.user_id) AS is_followed, NOT ISNULL(t5.id) AS is_downvoted, users.name, users.profile_image_url, users.profession  FROM threads t LEFT JOIN (SELECT thread_id, COUNT(*) AS upvotes_count FROM thread_upvotes tu WHERE tu.deleted_at IS NULL GROUP BY thread_id) AS t2 ON t.id = t2.thread_id INNER JOIN (SELECT user_id FROM user_followers uf WHERE uf.follower_id= ?) AS t3 ON t3.user_id = t.user_id LEFT JOIN users ON users.id = t.user_id LEFT JOIN (SELECT * FROM thread_upvotes tu WHERE tu.user_id = ? AND tu.deleted_at IS NULL) AS t4 ON t.id = t4.thread_id LEFT JOIN (SELECT id, thread_id FROM thread_downvotes td WHERE td.user_id = ? AND td.deleted_at IS NULL) AS t5 ON t5.thread_id = t.id;", userID, userID, userID).Scan(&threads)
+	res := tr.db.WithContext(ctx).Raw("SELECT t.*, COALESCE(tv.value, 0) AS user_vote, NOT ISNULL(t3.user_id) AS is_followed, users.name, users.profile_image_url, users.profession FROM threads t INNER JOIN (SELECT user_id FROM user_followers uf WHERE uf.follower_id= ?) AS t3 ON t3.user_id = t.user_id LEFT JOIN users ON users.id = t.user_id LEFT JOIN thread_votes tv ON tv.thread_id = t.id AND tv.user_id = ? WHERE t.deleted_at IS NULL AND t.status = 'published'"+notHidden+notMuted, userID, userID, userID, userID).Scan(&threads)
 
 	if res.Error != nil {
-		fmt.Println(res.Error)
+		tr.logger.ErrorContext(ctx, "query failed", "op", "GetThreadsFromFollowedUsers", "err", res.Error)
 		return []entity.ThreadWithDetails{}, utils.ErrInternalServerError
 	}
 
 	return threads, nil
 }
 
-func (tr *ThreadRepositoryImpl) AddThreadComment(comment entity.Comment) error {
-	res := tr.db.Create(&comment)
+func (tr *ThreadRepositoryImpl) AddThreadComment(ctx context.Context, comment entity.Comment) error {
+	res := tr.db.WithContext(ctx).Create(&comment)
 
 	if res.Error != nil {
-		fmt.Println(res.Error)
+		tr.logger.ErrorContext(ctx, "query failed", "op", "AddThreadComment", "err", res.Error)
 		return utils.ErrInternalServerError
 	}
 
 	return nil
 }
 
-func (tr *ThreadRepositoryImpl) GetCommentsByThreadID(threadID uint) ([]entity.CommentDetails, error) {
+func (tr *ThreadRepositoryImpl) GetCommentsByThreadID(ctx context.Context, threadID, userID uint) ([]entity.CommentDetails, error) {
 	var comments []entity.CommentDetails
-	res := tr.db.Raw("SELECT comments.*, users.*, t2.likes_count FROM comments LEFT JOIN (SELECT comment_id, COUNT(*) AS likes_count FROM comment_likes cl GROUP BY comment_id) AS t2 ON comments.id = t2.comment_id INNER JOIN users ON comments.user_id = users.id WHERE comments.thread_id = ? AND comments.deleted_at IS NULL", threadID).Scan(&comments)
+	res := tr.db.WithContext(ctx).Raw("SELECT comments.*, users.username, users.name, users.profile_image_url, COALESCE(cv.value, 0) AS user_vote FROM comments INNER JOIN users ON comments.user_id = users.id LEFT JOIN comment_votes cv ON cv.comment_id = comments.id AND cv.user_id = ? WHERE comments.thread_id = ? AND comments.deleted_at IS NULL", userID, threadID).Scan(&comments)
 
 	if res.Error != nil {
-		fmt.Println(res.Error)
+		tr.logger.ErrorContext(ctx, "query failed", "op", "GetCommentsByThreadID", "err", res.Error)
 		return []entity.CommentDetails{}, utils.ErrInternalServerError
 	}
 
 	return comments, nil
 }
 
-func (tr *ThreadRepositoryImpl) GetThreads(keyword string, userID uint) ([]entity.ThreadWithDetails, error) {
+func (tr *ThreadRepositoryImpl) GetThreads(ctx context.Context, keyword string, userID uint) ([]entity.ThreadWithDetails, error) {
 	var threads []entity.ThreadWithDetails
 
-	res := tr.db.Raw("SELECT combined.*, upvotes_count, NOT ISNULL(t4.id) AS is_upvoted, NOT ISNULL(t3.user_id) AS is_followed, NOT ISNULL(t5.id) AS is_downvoted, users.name, users.profile_image_url, users.profession FROM (SELECT * FROM threads t WHERE (t.body LIKE ? OR t.title LIKE ?) AND (t.deleted_at IS NULL) UNION SELECT t.* FROM comments c LEFT JOIN threads t ON t.id = c.thread_id WHERE c.body LIKE ? AND c.deleted_at IS NULL) AS combined LEFT JOIN (SELECT thread_id, COUNT(*) AS upvotes_count FROM thread_upvotes tu WHERE tu.deleted_at IS NULL GROUP BY thread_id) AS t2 ON combined.id = t2.thread_id LEFT JOIN (SELECT user_id FROM user_followers uf WHERE uf.follower_id= ?) AS t3 ON t3.user_id = combined.user_id LEFT JOIN users ON users.id = combined.user_id LEFT JOIN (SELECT * FROM thread_upvotes tu WHERE tu.user_id = ? AND tu.deleted_at IS NULL) AS t4 ON combined.id = t4.thread_id LEFT JOIN (SELECT id, thread_id, user_id FROM thread_downvotes td WHERE td.user_id = ? AND td.deleted_at IS NULL) AS t5 ON t5.thread_id = combined.id;", "%"+keyword+"%", "%"+keyword+"%", "%"+keyword+"%", userID, userID, userID).Scan(&threads)
+	res := tr.db.WithContext(ctx).Raw("SELECT combined.*, COALESCE(tv.value, 0) AS user_vote, NOT ISNULL(t3.user_id) AS is_followed, users.name, users.profile_image_url, users.profession FROM (SELECT * FROM threads t WHERE (t.body LIKE ? OR t.title LIKE ?) AND (t.deleted_at IS NULL AND t.status = 'published') UNION SELECT t.* FROM comments c LEFT JOIN threads t ON t.id = c.thread_id WHERE c.body LIKE ? AND c.deleted_at IS NULL AND t.status = 'published') AS combined LEFT JOIN (SELECT user_id FROM user_followers uf WHERE uf.follower_id= ?) AS t3 ON t3.user_id = combined.user_id LEFT JOIN users ON users.id = combined.user_id LEFT JOIN thread_votes tv ON tv.thread_id = combined.id AND tv.user_id = ? WHERE NOT EXISTS (SELECT 1 FROM hidden_threads ht WHERE ht.thread_id = combined.id AND ht.user_id = ?) AND NOT EXISTS (SELECT 1 FROM muted_communities mc WHERE mc.community_id = combined.community_id AND mc.user_id = ?)", "%"+keyword+"%", "%"+keyword+"%", "%"+keyword+"%", userID, userID, userID, userID).Scan(&threads)
 
 	if res.Error != nil {
-		fmt.Println(res.Error)
+		tr.logger.ErrorContext(ctx, "query failed", "op", "GetThreads", "err", res.Error)
 		return []entity.ThreadWithDetails{}, utils.ErrInternalServerError
 	}
 
 	return threads, nil
 }
 
-func (tr *ThreadRepositoryImpl) LikeComment(commentLikes entity.CommentLikes) error {
-	res := tr.db.Create(&commentLikes)
-
+func (tr *ThreadRepositoryImpl) GetThreadVote(ctx context.Context, threadID, userID uint) (entity.ThreadVote, error) {
+	var vote entity.ThreadVote
+	res := tr.db.WithContext(ctx).Where("thread_id = ? AND user_id = ?", threadID, userID).Limit(1).Find(&vote)
 	if res.Error != nil {
-		fmt.Println(res.Error)
-		if strings.HasPrefix(res.Error.Error(), "Error 1452: Cannot add or update a child row") {
-			return utils.ErrNotFound
-		}
-		return utils.ErrInternalServerError
+		tr.logger.ErrorContext(ctx, "query failed", "op", "GetThreadVote", "err", res.Error)
+		return entity.ThreadVote{}, utils.ErrInternalServerError
 	}
 
-	return nil
-}
-
-func (tr *ThreadRepositoryImpl) DownvoteThread(downvote entity.ThreadDownvote) error {
-	res := tr.db.Create(&downvote)
-
-	if res.Error != nil {
-		fmt.Println(res.Error)
-		if strings.HasPrefix(res.Error.Error(), "Error 1452: Cannot add or update a child row") {
-			return utils.ErrNotFound
-		} else if strings.HasPrefix(res.Error.Error(), "Error 1062: Duplicate entry") {
-			return utils.ErrDuplicateEntry
-		}
-		return utils.ErrInternalServerError
+	if res.RowsAffected == 0 {
+		return entity.ThreadVote{}, utils.ErrNotFound
 	}
 
-	return nil
+	return vote, nil
 }
 
-func (tr *ThreadRepositoryImpl) UndoDownvoteThread(threadID, userID uint) error {
-	res := tr.db.Unscoped().Delete(&entity.ThreadDownvote{}, "thread_id = ? AND user_id = ?", threadID, userID)
+// SetThreadVote replaces the vote userID holds on threadID with value and
+// moves the stored score and counts of the thread by the difference. The
+// thread row stays locked until both are written, so concurrent votes on
+// the same thread cannot lose an update. It returns the updated thread and
+// the vote that was replaced.
+func (tr *ThreadRepositoryImpl) SetThreadVote(ctx context.Context, threadID, userID uint, value int) (entity.Thread, int, error) {
+	var thread entity.Thread
+	previous := entity.VoteNone
 
-	if res.Error != nil {
-		fmt.Println(res.Error)
-		return utils.ErrInternalServerError
-	}
+	err := tr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// drafts cannot be voted on, they are not found
+		res := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("status = ?", entity.ThreadStatusPublished).Limit(1).Find(&thread, threadID)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return utils.ErrNotFound
+		}
 
-	if res.RowsAffected < 1 {
-		return utils.ErrNotFound
-	}
+		var vote entity.ThreadVote
+		res = tx.Where("thread_id = ? AND user_id = ?", threadID, userID).Limit(1).Find(&vote)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected > 0 {
+			previous = vote.Value
+		}
 
-	return nil
-}
+		if previous == value {
+			return nil
+		}
 
-func (tr *ThreadRepositoryImpl) UnlikeComment(commentID, userID uint) error {
-	res := tr.db.Unscoped().Delete(&entity.CommentLikes{}, "thread_id = ? AND user_id = ?", commentID, userID)
+		var err error
+		switch {
+		case value == entity.VoteNone:
+			err = tx.Delete(&vote).Error
+		case previous == entity.VoteNone:
+			err = tx.Create(&entity.ThreadVote{UserID: userID, ThreadID: threadID, Value: value}).Error
+		default:
+			err = tx.Model(&vote).Update("value", value).Error
+		}
+		if err != nil {
+			return err
+		}
 
-	if res.Error != nil {
-		fmt.Println(res.Error)
-		if res.Error.Error() == "record not found" {
-			return utils.ErrNotFound
+		thread.Score += value - previous
+		thread.UpvotesCount += voteCount(value, entity.VoteUp) - voteCount(previous, entity.VoteUp)
+		thread.DownvotesCount += voteCount(value, entity.VoteDown) - voteCount(previous, entity.VoteDown)
+
+		// votes do not count as an edit, so updated_at is left alone
+		return tx.Model(&thread).UpdateColumns(map[string]interface{}{
+			"score":           thread.Score,
+			"upvotes_count":   thread.UpvotesCount,
+			"downvotes_count": thread.DownvotesCount,
+		}).Error
+	})
+	if err != nil {
+		if errors.Is(err, utils.ErrNotFound) {
+			return entity.Thread{}, entity.VoteNone, utils.ErrNotFound
 		}
-		return utils.ErrInternalServerError
+		tr.logger.ErrorContext(ctx, "query failed", "op", "SetThreadVote", "err", err)
+		return entity.Thread{}, entity.VoteNone, utils.ErrInternalServerError
 	}
 
-	return nil
+	return thread, previous, nil
 }
 
-func (tr *ThreadRepositoryImpl) UndoUpvoteThread(commentID, userID uint) error {
-	res := tr.db.Unscoped().Delete(&entity.ThreadUpvote{}, "thread_id = ? AND user_id = ?", commentID, userID)
-
+func (tr *ThreadRepositoryImpl) GetCommentVote(ctx context.Context, commentID, userID uint) (entity.CommentVote, error) {
+	var vote entity.CommentVote
+	res := tr.db.WithContext(ctx).Where("comment_id = ? AND user_id = ?", commentID, userID).Limit(1).Find(&vote)
 	if res.Error != nil {
-		fmt.Println(res.Error)
-		return utils.ErrInternalServerError
+		tr.logger.ErrorContext(ctx, "query failed", "op", "GetCommentVote", "err", res.Error)
+		return entity.CommentVote{}, utils.ErrInternalServerError
 	}
 
-	if res.RowsAffected < 1 {
-		return utils.ErrNotFound
+	if res.RowsAffected == 0 {
+		return entity.CommentVote{}, utils.ErrNotFound
 	}
 
-	return nil
+	return vote, nil
 }
 
-func (tr *ThreadRepositoryImpl) GetThreadDownvotes(threadID, userID uint) (entity.ThreadDownvote, error) {
-	var threadDownvotes entity.ThreadDownvote
-	res := tr.db.First(&threadDownvotes, "thread_id = ? AND user_id = ?", threadID, userID)
+// SetCommentVote is SetThreadVote for comments, it returns the updated
+// comment and the vote that was replaced
+func (tr *ThreadRepositoryImpl) SetCommentVote(ctx context.Context, commentID, userID uint, value int) (entity.Comment, int, error) {
+	var comment entity.Comment
+	previous := entity.VoteNone
 
-	if res.Error != nil {
-		fmt.Println(res.Error)
-		if res.Error.Error() == "record not found" {
-			return threadDownvotes, utils.ErrNotFound
+	err := tr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Limit(1).Find(&comment, commentID)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return utils.ErrNotFound
 		}
-		return threadDownvotes, utils.ErrInternalServerError
-	}
 
-	return threadDownvotes, nil
-}
+		var vote entity.CommentVote
+		res = tx.Where("comment_id = ? AND user_id = ?", commentID, userID).Limit(1).Find(&vote)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected > 0 {
+			previous = vote.Value
+		}
 
-func (tr *ThreadRepositoryImpl) GetThreadUpvotes(threadID, userID uint) (entity.ThreadUpvote, error) {
-	var threadUpvote entity.ThreadUpvote
-	res := tr.db.First(&threadUpvote, "thread_id = ? AND user_id = ?", threadID, userID)
+		if previous == value {
+			return nil
+		}
 
-	if res.Error != nil {
-		fmt.Println(res.Error)
-		if res.Error.Error() == "record not found" {
-			return threadUpvote, utils.ErrNotFound
+		var err error
+		switch {
+		case value == entity.VoteNone:
+			err = tx.Delete(&vote).Error
+		case previous == entity.VoteNone:
+			err = tx.Create(&entity.CommentVote{UserID: userID, CommentID: commentID, Value: value}).Error
+		default:
+			err = tx.Model(&vote).Update("value", value).Error
 		}
+		if err != nil {
+			return err
+		}
+
+		comment.Score += value - previous
+		comment.UpvotesCount += voteCount(value, entity.VoteUp) - voteCount(previous, entity.VoteUp)
+		comment.DownvotesCount += voteCount(value, entity.VoteDown) - voteCount(previous, entity.VoteDown)
 
-		return threadUpvote, utils.ErrInternalServerError
+		return tx.Model(&comment).UpdateColumns(map[string]interface{}{
+			"score":           comment.Score,
+			"upvotes_count":   comment.UpvotesCount,
+			"downvotes_count": comment.DownvotesCount,
+		}).Error
+	})
+	if err != nil {
+		if errors.Is(err, utils.ErrNotFound) {
+			return entity.Comment{}, entity.VoteNone, utils.ErrNotFound
+		}
+		tr.logger.ErrorContext(ctx, "query failed", "op", "SetCommentVote", "err", err)
+		return entity.Comment{}, entity.VoteNone, utils.ErrInternalServerError
 	}
 
-	return threadUpvote, nil
+	return comment, previous, nil
+}
+
+func voteCount(vote, kind int) int {
+	if vote == kind {
+		return 1
+	}
+	return 0
 }
 
-func (tr *ThreadRepositoryImpl) DeleteComment(commentID uint) error {
-	res := tr.db.Debug().Delete(&entity.Comment{}, commentID)
+func (tr *ThreadRepositoryImpl) DeleteComment(ctx context.Context, commentID uint) error {
+	res := tr.db.WithContext(ctx).Delete(&entity.Comment{}, commentID)
 
 	if res.Error != nil {
 		return utils.ErrInternalServerError
@@ -302,8 +523,8 @@ func (tr *ThreadRepositoryImpl) DeleteComment(commentID uint) error {
 	return nil
 }
 
-func (tr *ThreadRepositoryImpl) CreateThreadReport(threadReport entity.ThreadReport) error {
-	res := tr.db.Create(&threadReport)
+func (tr *ThreadRepositoryImpl) CreateThreadReport(ctx context.Context, threadReport entity.ThreadReport) error {
+	res := tr.db.WithContext(ctx).Create(&threadReport)
 
 	if res.Error != nil {
 		return utils.ErrInternalServerError
@@ -312,10 +533,10 @@ func (tr *ThreadRepositoryImpl) CreateThreadReport(threadReport entity.ThreadRep
 	return nil
 }
 
-func (tr *ThreadRepositoryImpl) GetThreadReport(id uint) (entity.ThreadReport, error) {
+func (tr *ThreadRepositoryImpl) GetThreadReport(ctx context.Context, id uint) (entity.ThreadReport, error) {
 	threadReport := entity.ThreadReport{}
 
-	res := tr.db.Find(&threadReport, id)
+	res := tr.db.WithContext(ctx).Find(&threadReport, id)
 	err := res.Error
 	if err != nil {
 		return entity.ThreadReport{}, nil
@@ -323,8 +544,8 @@ func (tr *ThreadRepositoryImpl) GetThreadReport(id uint) (entity.ThreadReport, e
 
 	return threadReport, nil
 }
-func (tr *ThreadRepositoryImpl) UpdateThreadReport(threadReport entity.ThreadReport, userID uint) error {
-	res := tr.db.Model(&threadReport).Update("user_id", userID)
+func (tr *ThreadRepositoryImpl) UpdateThreadReport(ctx context.Context, threadReport entity.ThreadReport, userID uint) error {
+	res := tr.db.WithContext(ctx).Model(&threadReport).Update("user_id", userID)
 	err := res.Error
 	if err != nil {
 		return err
@@ -333,12 +554,12 @@ func (tr *ThreadRepositoryImpl) UpdateThreadReport(threadReport entity.ThreadRep
 	return nil
 }
 
-func (tr *ThreadRepositoryImpl) GetCommentByID(commentID uint) (entity.Comment, error) {
+func (tr *ThreadRepositoryImpl) GetCommentByID(ctx context.Context, commentID uint) (entity.Comment, error) {
 	var comment entity.Comment
-	res := tr.db.First(&comment, commentID)
+	res := tr.db.WithContext(ctx).First(&comment, commentID)
 
 	if res.Error != nil {
-		fmt.Println(res.Error)
+		tr.logger.ErrorContext(ctx, "query failed", "op", "GetCommentByID", "err", res.Error)
 		if res.Error.Error() == "record not found" {
 			return comment, utils.ErrNotFound
 		}
@@ -349,8 +570,8 @@ func (tr *ThreadRepositoryImpl) GetCommentByID(commentID uint) (entity.Comment,
 	return comment, nil
 }
 
-func (tr *ThreadRepositoryImpl) CreateCommentReport(commentReport entity.CommentReport) error {
-	res := tr.db.Create(&commentReport)
+func (tr *ThreadRepositoryImpl) CreateCommentReport(ctx context.Context, commentReport entity.CommentReport) error {
+	res := tr.db.WithContext(ctx).Create(&commentReport)
 
 	if res.Error != nil {
 		return utils.ErrInternalServerError
@@ -359,10 +580,10 @@ func (tr *ThreadRepositoryImpl) CreateCommentReport(commentReport entity.Comment
 	return nil
 }
 
-func (tr *ThreadRepositoryImpl) GetCommentReport(id uint) (entity.CommentReport, error) {
+func (tr *ThreadRepositoryImpl) GetCommentReport(ctx context.Context, id uint) (entity.CommentReport, error) {
 	commentReport := entity.CommentReport{}
 
-	res := tr.db.Find(&commentReport, id)
+	res := tr.db.WithContext(ctx).Find(&commentReport, id)
 	err := res.Error
 	if err != nil {
 		return entity.CommentReport{}, nil
@@ -371,8 +592,8 @@ func (tr *ThreadRepositoryImpl) GetCommentReport(id uint) (entity.CommentReport,
 	return commentReport, nil
 }
 
-func (tr *ThreadRepositoryImpl) UpdateCommentReport(commentReport entity.CommentReport, userID uint) error {
-	res := tr.db.Model(&commentReport).Update("user_id", userID)
+func (tr *ThreadRepositoryImpl) UpdateCommentReport(ctx context.Context, commentReport entity.CommentReport, userID uint) error {
+	res := tr.db.WithContext(ctx).Model(&commentReport).Update("user_id", userID)
 	err := res.Error
 	if err != nil {
 		return err
@@ -381,10 +602,10 @@ func (tr *ThreadRepositoryImpl) UpdateCommentReport(commentReport entity.Comment
 	return nil
 }
 
-func (tr *ThreadRepositoryImpl) GetThreadsByUserID(userID, tokenUserID uint) ([]entity.ThreadWithDetails, error) {
+func (tr *ThreadRepositoryImpl) GetThreadsByUserID(ctx context.Context, userID, tokenUserID uint) ([]entity.ThreadWithDetails, error) {
 	threads := []entity.ThreadWithDetails{}
 
-	res := tr.db.Raw("SELECT t.*, tlc.count AS upvotes_count, !isnull(tl.user_id) AS is_upvoted, u.*, (u.id = ?) AS is_mine FROM threads AS t LEFT JOIN (SELECT t.thread_id, COUNT(*) AS count FROM thread_upvotes AS t GROUP BY t.thread_id) AS tlc ON t.id = tlc.thread_id LEFT JOIN (SELECT * FROM thread_upvotes WHERE user_id = ?) AS tl ON tl.thread_id = t.id LEFT JOIN (SELECT u.*, !ISNULL(uf.user_id) AS is_followed FROM `users` AS u LEFT JOIN (SELECT * FROM user_followers WHERE follower_id = ?) AS uf ON u.id = uf.user_id WHERE u.deleted_at IS NULL) AS u ON u.id = t.user_id WHERE t.user_id = ? AND t.deleted_at IS NULL", userID, userID, userID, tokenUserID).Scan(&threads)
+	res := tr.db.WithContext(ctx).Raw("SELECT t.*, COALESCE(tv.value, 0) AS user_vote, u.*, (u.id = ?) AS is_mine FROM threads AS t LEFT JOIN thread_votes tv ON tv.thread_id = t.id AND tv.user_id = ? LEFT JOIN (SELECT u.*, !ISNULL(uf.user_id) AS is_followed FROM `users` AS u LEFT JOIN (SELECT * FROM user_followers WHERE follower_id = ?) AS uf ON u.id = uf.user_id WHERE u.deleted_at IS NULL) AS u ON u.id = t.user_id WHERE t.user_id = ? AND t.deleted_at IS NULL AND t.status = 'published'", userID, userID, userID, tokenUserID).Scan(&threads)
 
 	if res.Error != nil {
 		return []entity.ThreadWithDetails{}, utils.ErrInternalServerError
@@ -393,20 +614,274 @@ func (tr *ThreadRepositoryImpl) GetThreadsByUserID(userID, tokenUserID uint) ([]
 	return threads, nil
 }
 
-func (tr *ThreadRepositoryImpl) StoreSavedThread(savedThread entity.SavedThread) error {
-	res := tr.db.Create(&savedThread)
+func (tr *ThreadRepositoryImpl) StoreSavedThread(ctx context.Context, savedThread entity.SavedThread) error {
+	res := tr.db.WithContext(ctx).Create(&savedThread)
+
+	if res.Error != nil {
+		if isDuplicate(res.Error) {
+			return utils.ErrConflict
+		}
+		tr.logger.ErrorContext(ctx, "query failed", "op", "StoreSavedThread", "err", res.Error)
+		return utils.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// UpdateSavedThread files the saved thread under collectionID, nil leaves it
+// outside any collection, and replaces its note
+func (tr *ThreadRepositoryImpl) UpdateSavedThread(ctx context.Context, userID, threadID uint, collectionID *uint, note string) error {
+	var collection interface{}
+	if collectionID != nil {
+		collection = *collectionID
+	}
+
+	res := tr.db.WithContext(ctx).Model(&entity.SavedThread{}).Where("user_id = ? AND thread_id = ?", userID, threadID).Updates(map[string]interface{}{
+		"collection_id": collection,
+		"note":          note,
+	})
+	if res.Error != nil {
+		tr.logger.ErrorContext(ctx, "query failed", "op", "UpdateSavedThread", "err", res.Error)
+		return utils.ErrInternalServerError
+	}
+	if res.RowsAffected == 0 {
+		return utils.ErrNotFound
+	}
+
+	return nil
+}
+
+func (tr *ThreadRepositoryImpl) GetSavedThreadByThreadID(ctx context.Context, userID, threadID uint) (entity.SavedThread, error) {
+	savedThread := entity.SavedThread{}
+	res := tr.db.WithContext(ctx).Where("user_id = ? AND thread_id = ?", userID, threadID).Limit(1).Find(&savedThread)
+	if res.Error != nil {
+		tr.logger.ErrorContext(ctx, "query failed", "op", "GetSavedThreadByThreadID", "err", res.Error)
+		return entity.SavedThread{}, utils.ErrInternalServerError
+	}
+	if res.RowsAffected == 0 {
+		return entity.SavedThread{}, utils.ErrNotFound
+	}
+
+	return savedThread, nil
+}
 
+// DeleteSavedThread unsaves a thread. The row is removed rather than soft
+// deleted so the thread can be saved again.
+func (tr *ThreadRepositoryImpl) DeleteSavedThread(ctx context.Context, userID, threadID uint) error {
+	res := tr.db.WithContext(ctx).Unscoped().Where("user_id = ? AND thread_id = ?", userID, threadID).Delete(&entity.SavedThread{})
 	if res.Error != nil {
+		tr.logger.ErrorContext(ctx, "query failed", "op", "DeleteSavedThread", "err", res.Error)
 		return utils.ErrInternalServerError
 	}
+	if res.RowsAffected == 0 {
+		return utils.ErrNotFound
+	}
 
 	return nil
 }
 
-func (tr *ThreadRepositoryImpl) GetSavedThread(userID uint) ([]entity.ThreadWithDetails, error) {
+// savedInCollection narrows saved_threads st down to one collection of a
+// user, a zero collectionID matches the threads outside any collection
+func savedInCollection(collectionID uint) (string, []interface{}) {
+	if collectionID == 0 {
+		return "st.collection_id IS NULL", nil
+	}
+	return "st.collection_id = ?", []interface{}{collectionID}
+}
+
+// GetSavedThreadsByCollection pages through a collection of userID, most
+// recently saved first
+func (tr *ThreadRepositoryImpl) GetSavedThreadsByCollection(ctx context.Context, userID, collectionID uint, limit, offset int) ([]entity.SavedThreadDetails, error) {
+	threads := []entity.SavedThreadDetails{}
+
+	filter, filterArgs := savedInCollection(collectionID)
+	args := append([]interface{}{userID, userID, userID}, filterArgs...)
+	args = append(args, limit, offset)
+
+	res := tr.db.WithContext(ctx).Raw("SELECT t.*, COALESCE(tv.value, 0) AS user_vote, NOT ISNULL(t3.user_id) AS is_followed, users.name, users.profile_image_url, users.profession, st.collection_id, st.note, st.created_at AS saved_at FROM saved_threads st INNER JOIN threads t ON t.id = st.thread_id LEFT JOIN (SELECT user_id FROM user_followers uf WHERE uf.follower_id= ?) AS t3 ON t3.user_id = t.user_id LEFT JOIN users ON users.id = t.user_id LEFT JOIN thread_votes tv ON tv.thread_id = t.id AND tv.user_id = ? WHERE st.user_id = ? AND "+filter+" AND st.deleted_at IS NULL AND t.deleted_at IS NULL AND t.status = 'published' ORDER BY st.created_at DESC, st.id DESC LIMIT ? OFFSET ?", args...).Scan(&threads)
+	if res.Error != nil {
+		tr.logger.ErrorContext(ctx, "query failed", "op", "GetSavedThreadsByCollection", "err", res.Error)
+		return []entity.SavedThreadDetails{}, utils.ErrInternalServerError
+	}
+
+	return threads, nil
+}
+
+// CountSavedThreads counts the saved threads of userID in collectionID, a
+// zero collectionID counts those outside any collection
+func (tr *ThreadRepositoryImpl) CountSavedThreads(ctx context.Context, userID, collectionID uint) (int64, error) {
+	var count int64
+
+	filter, filterArgs := savedInCollection(collectionID)
+	args := append([]interface{}{userID}, filterArgs...)
+
+	res := tr.db.WithContext(ctx).Raw("SELECT COUNT(*) FROM saved_threads st INNER JOIN threads t ON t.id = st.thread_id WHERE st.user_id = ? AND "+filter+" AND st.deleted_at IS NULL AND t.deleted_at IS NULL AND t.status = 'published'", args...).Scan(&count)
+	if res.Error != nil {
+		tr.logger.ErrorContext(ctx, "query failed", "op", "CountSavedThreads", "err", res.Error)
+		return 0, utils.ErrInternalServerError
+	}
+
+	return count, nil
+}
+
+func (tr *ThreadRepositoryImpl) CreateSavedThreadCollection(ctx context.Context, collection entity.SavedThreadCollection) (entity.SavedThreadCollection, error) {
+	res := tr.db.WithContext(ctx).Create(&collection)
+	if res.Error != nil {
+		if isDuplicate(res.Error) {
+			return entity.SavedThreadCollection{}, utils.ErrConflict
+		}
+		tr.logger.ErrorContext(ctx, "query failed", "op", "CreateSavedThreadCollection", "err", res.Error)
+		return entity.SavedThreadCollection{}, utils.ErrInternalServerError
+	}
+
+	return collection, nil
+}
+
+func (tr *ThreadRepositoryImpl) GetSavedThreadCollection(ctx context.Context, userID, collectionID uint) (entity.SavedThreadCollection, error) {
+	collection := entity.SavedThreadCollection{}
+	res := tr.db.WithContext(ctx).Where("id = ? AND user_id = ?", collectionID, userID).Limit(1).Find(&collection)
+	if res.Error != nil {
+		tr.logger.ErrorContext(ctx, "query failed", "op", "GetSavedThreadCollection", "err", res.Error)
+		return entity.SavedThreadCollection{}, utils.ErrInternalServerError
+	}
+	if res.RowsAffected == 0 {
+		return entity.SavedThreadCollection{}, utils.ErrNotFound
+	}
+
+	return collection, nil
+}
+
+// GetSavedThreadCollections lists the collections of userID by name with
+// the number of threads in each
+func (tr *ThreadRepositoryImpl) GetSavedThreadCollections(ctx context.Context, userID uint) ([]entity.SavedThreadCollection, error) {
+	collections := []entity.SavedThreadCollection{}
+
+	res := tr.db.WithContext(ctx).Raw("SELECT c.*, COALESCE(s.saved_count, 0) AS saved_count FROM saved_thread_collections c LEFT JOIN (SELECT st.collection_id, COUNT(*) AS saved_count FROM saved_threads st INNER JOIN threads t ON t.id = st.thread_id WHERE st.user_id = ? AND st.deleted_at IS NULL AND t.deleted_at IS NULL AND t.status = 'published' GROUP BY st.collection_id) AS s ON s.collection_id = c.id WHERE c.user_id = ? ORDER BY c.name, c.id", userID, userID).Scan(&collections)
+	if res.Error != nil {
+		tr.logger.ErrorContext(ctx, "query failed", "op", "GetSavedThreadCollections", "err", res.Error)
+		return []entity.SavedThreadCollection{}, utils.ErrInternalServerError
+	}
+
+	return collections, nil
+}
+
+func (tr *ThreadRepositoryImpl) RenameSavedThreadCollection(ctx context.Context, userID, collectionID uint, name string) error {
+	res := tr.db.WithContext(ctx).Model(&entity.SavedThreadCollection{}).Where("id = ? AND user_id = ?", collectionID, userID).Update("name", name)
+	if res.Error != nil {
+		if isDuplicate(res.Error) {
+			return utils.ErrConflict
+		}
+		tr.logger.ErrorContext(ctx, "query failed", "op", "RenameSavedThreadCollection", "err", res.Error)
+		return utils.ErrInternalServerError
+	}
+	if res.RowsAffected == 0 {
+		return utils.ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteSavedThreadCollection removes a collection, the threads in it stay
+// saved outside any collection
+func (tr *ThreadRepositoryImpl) DeleteSavedThreadCollection(ctx context.Context, userID, collectionID uint) error {
+	err := tr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("id = ? AND user_id = ?", collectionID, userID).Delete(&entity.SavedThreadCollection{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return utils.ErrNotFound
+		}
+
+		return tx.Model(&entity.SavedThread{}).Where("user_id = ? AND collection_id = ?", userID, collectionID).Update("collection_id", nil).Error
+	})
+	if err != nil {
+		if errors.Is(err, utils.ErrNotFound) {
+			return err
+		}
+		tr.logger.ErrorContext(ctx, "query failed", "op", "DeleteSavedThreadCollection", "err", err)
+		return utils.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (tr *ThreadRepositoryImpl) GetThreadFollower(ctx context.Context, threadID, userID uint) (entity.ThreadFollower, error) {
+	follower := entity.ThreadFollower{}
+	res := tr.db.WithContext(ctx).Where("thread_id = ? AND user_id = ?", threadID, userID).Limit(1).Find(&follower)
+	if res.Error != nil {
+		tr.logger.ErrorContext(ctx, "query failed", "op", "GetThreadFollower", "err", res.Error)
+		return entity.ThreadFollower{}, utils.ErrInternalServerError
+	}
+	if res.RowsAffected == 0 {
+		return entity.ThreadFollower{}, utils.ErrNotFound
+	}
+
+	return follower, nil
+}
+
+// GetThreadFollowers lists every follower of a thread, muted ones included
+func (tr *ThreadRepositoryImpl) GetThreadFollowers(ctx context.Context, threadID uint) ([]entity.ThreadFollower, error) {
+	followers := []entity.ThreadFollower{}
+	res := tr.db.WithContext(ctx).Where("thread_id = ?", threadID).Find(&followers)
+	if res.Error != nil {
+		tr.logger.ErrorContext(ctx, "query failed", "op", "GetThreadFollowers", "err", res.Error)
+		return []entity.ThreadFollower{}, utils.ErrInternalServerError
+	}
+
+	return followers, nil
+}
+
+// SetThreadFollower follows a thread for userID, or updates whether the
+// follow is muted when it already exists
+func (tr *ThreadRepositoryImpl) SetThreadFollower(ctx context.Context, threadID, userID uint, muted bool) error {
+	follower := entity.ThreadFollower{ThreadID: threadID, UserID: userID, Muted: muted}
+	res := tr.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "thread_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"muted", "updated_at"}),
+	}).Create(&follower)
+	if res.Error != nil {
+		tr.logger.ErrorContext(ctx, "query failed", "op", "SetThreadFollower", "err", res.Error)
+		return utils.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// SubscribeThread follows a thread for userID unless they already follow or
+// muted it
+func (tr *ThreadRepositoryImpl) SubscribeThread(ctx context.Context, threadID, userID uint) error {
+	follower := entity.ThreadFollower{ThreadID: threadID, UserID: userID}
+	res := tr.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&follower)
+	if res.Error != nil {
+		tr.logger.ErrorContext(ctx, "query failed", "op", "SubscribeThread", "err", res.Error)
+		return utils.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (tr *ThreadRepositoryImpl) DeleteThreadFollower(ctx context.Context, threadID, userID uint) error {
+	res := tr.db.WithContext(ctx).Unscoped().Where("thread_id = ? AND user_id = ?", threadID, userID).Delete(&entity.ThreadFollower{})
+	if res.Error != nil {
+		tr.logger.ErrorContext(ctx, "query failed", "op", "DeleteThreadFollower", "err", res.Error)
+		return utils.ErrInternalServerError
+	}
+	if res.RowsAffected == 0 {
+		return utils.ErrNotFound
+	}
+
+	return nil
+}
+
+func isDuplicate(err error) bool {
+	return strings.HasPrefix(err.Error(), "Error 1062: Duplicate entry")
+}
+
+func (tr *ThreadRepositoryImpl) GetSavedThread(ctx context.Context, userID uint) ([]entity.ThreadWithDetails, error) {
 	var threads []entity.ThreadWithDetails
 
-	res := tr.db.Raw("SELECT t.*, t2.upvotes_count, NOT ISNULL(t4.id) AS is_upvoted, NOT ISNULL(t3.user_id) AS is_followed, NOT ISNULL(t5.id) AS is_downvoted, users.name, users.profile_image_url, users.profession FROM threads t INNER JOIN saved_threads st ON st.thread_id = t.id LEFT JOIN (SELECT thread_id, COUNT(*) AS upvotes_count FROM thread_upvotes tu GROUP BY thread_id) AS t2 ON t.id = t2.thread_id LEFT JOIN (SELECT user_id FROM user_followers uf WHERE uf.follower_id= ?) AS t3 ON t3.user_id = t.user_id LEFT JOIN users ON users.id = t.user_id LEFT JOIN (SELECT * FROM thread_upvotes tu WHERE tu.user_id = ?) AS t4 ON t.id = t4.thread_id LEFT JOIN (SELECT id, thread_id, user_id FROM thread_downvotes td WHERE td.user_id = ?) AS t5 ON t5.thread_id = t.id WHERE st.user_id = ? AND t.deleted_at IS NULL", userID, userID, userID, userID).Scan(&threads)
+	res := tr.db.WithContext(ctx).Raw("SELECT t.*, COALESCE(tv.value, 0) AS user_vote, NOT ISNULL(t3.user_id) AS is_followed, users.name, users.profile_image_url, users.profession FROM threads t INNER JOIN saved_threads st ON st.thread_id = t.id LEFT JOIN (SELECT user_id FROM user_followers uf WHERE uf.follower_id= ?) AS t3 ON t3.user_id = t.user_id LEFT JOIN users ON users.id = t.user_id LEFT JOIN thread_votes tv ON tv.thread_id = t.id AND tv.user_id = ? WHERE st.user_id = ? AND t.deleted_at IS NULL AND t.status = 'published'", userID, userID, userID).Scan(&threads)
 
 	if res.Error != nil {
 		return []entity.ThreadWithDetails{}, utils.ErrInternalServerError
@@ -414,3 +889,160 @@ func (tr *ThreadRepositoryImpl) GetSavedThread(userID uint) ([]entity.ThreadWith
 
 	return threads, nil
 }
+
+func (tr *ThreadRepositoryImpl) HideThread(ctx context.Context, userID, threadID uint) error {
+	hidden := entity.HiddenThread{UserID: userID, ThreadID: threadID}
+	res := tr.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&hidden)
+	if res.Error != nil {
+		tr.logger.ErrorContext(ctx, "query failed", "op", "HideThread", "err", res.Error)
+		return utils.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (tr *ThreadRepositoryImpl) UnhideThread(ctx context.Context, userID, threadID uint) error {
+	res := tr.db.WithContext(ctx).Where("user_id = ? AND thread_id = ?", userID, threadID).Delete(&entity.HiddenThread{})
+	if res.Error != nil {
+		tr.logger.ErrorContext(ctx, "query failed", "op", "UnhideThread", "err", res.Error)
+		return utils.ErrInternalServerError
+	}
+	if res.RowsAffected == 0 {
+		return utils.ErrNotFound
+	}
+
+	return nil
+}
+
+// GetHiddenThreads lists the threads userID hid, most recently hidden first
+func (tr *ThreadRepositoryImpl) GetHiddenThreads(ctx context.Context, userID uint) ([]entity.ThreadWithDetails, error) {
+	threads := []entity.ThreadWithDetails{}
+
+	res := tr.db.WithContext(ctx).Raw("SELECT t.*, COALESCE(tv.value, 0) AS user_vote, NOT ISNULL(t3.user_id) AS is_followed, users.name, users.profile_image_url, users.profession FROM threads t INNER JOIN hidden_threads ht ON ht.thread_id = t.id LEFT JOIN (SELECT user_id FROM user_followers uf WHERE uf.follower_id= ?) AS t3 ON t3.user_id = t.user_id LEFT JOIN users ON users.id = t.user_id LEFT JOIN thread_votes tv ON tv.thread_id = t.id AND tv.user_id = ? WHERE ht.user_id = ? AND t.deleted_at IS NULL AND t.status = 'published' ORDER BY ht.created_at DESC, ht.id DESC", userID, userID, userID).Scan(&threads)
+	if res.Error != nil {
+		tr.logger.ErrorContext(ctx, "query failed", "op", "GetHiddenThreads", "err", res.Error)
+		return []entity.ThreadWithDetails{}, utils.ErrInternalServerError
+	}
+
+	return threads, nil
+}
+
+// GetAttachmentsByThreadID lists the attachments of a thread and of its
+// comments, each gallery in its order
+func (tr *ThreadRepositoryImpl) GetAttachmentsByThreadID(ctx context.Context, threadID uint) ([]entity.Attachment, error) {
+	attachments := []entity.Attachment{}
+	res := tr.db.WithContext(ctx).Where("thread_id = ?", threadID).Order("comment_id, position, id").Find(&attachments)
+	if res.Error != nil {
+		tr.logger.ErrorContext(ctx, "query failed", "op", "GetAttachmentsByThreadID", "err", res.Error)
+		return []entity.Attachment{}, utils.ErrInternalServerError
+	}
+
+	return attachments, nil
+}
+
+func (tr *ThreadRepositoryImpl) GetAttachment(ctx context.Context, attachmentID uint) (entity.Attachment, error) {
+	attachment := entity.Attachment{}
+	res := tr.db.WithContext(ctx).Limit(1).Find(&attachment, attachmentID)
+	if res.Error != nil {
+		tr.logger.ErrorContext(ctx, "query failed", "op", "GetAttachment", "err", res.Error)
+		return entity.Attachment{}, utils.ErrInternalServerError
+	}
+	if res.RowsAffected == 0 {
+		return entity.Attachment{}, utils.ErrNotFound
+	}
+
+	return attachment, nil
+}
+
+// CreateAttachment appends attachment to the end of its gallery, the gallery
+// of the thread or of attachment.CommentID. The thread row stays locked while
+// the gallery is counted, so concurrent uploads cannot get past limit or end
+// up at the same position. A full gallery is reported as ErrBadParamInput.
+func (tr *ThreadRepositoryImpl) CreateAttachment(ctx context.Context, attachment entity.Attachment, limit int) (entity.Attachment, error) {
+	err := tr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var thread entity.Thread
+		res := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Limit(1).Find(&thread, attachment.ThreadID)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return utils.ErrNotFound
+		}
+
+		var gallery struct {
+			Count    int
+			Position int
+		}
+		err := tx.Model(&entity.Attachment{}).
+			Select("COUNT(*) AS count, COALESCE(MAX(position), 0) AS position").
+			Where("thread_id = ? AND comment_id = ?", attachment.ThreadID, attachment.CommentID).
+			Scan(&gallery).Error
+		if err != nil {
+			return err
+		}
+		if gallery.Count >= limit {
+			return utils.ErrBadParamInput
+		}
+
+		attachment.Position = gallery.Position + 1
+		return tx.Create(&attachment).Error
+	})
+	if err != nil {
+		if errors.Is(err, utils.ErrNotFound) || errors.Is(err, utils.ErrBadParamInput) {
+			return entity.Attachment{}, err
+		}
+		tr.logger.ErrorContext(ctx, "query failed", "op", "CreateAttachment", "err", err)
+		return entity.Attachment{}, utils.ErrInternalServerError
+	}
+
+	return attachment, nil
+}
+
+func (tr *ThreadRepositoryImpl) UpdateAttachment(ctx context.Context, attachmentID uint, altText, caption string) error {
+	res := tr.db.WithContext(ctx).Model(&entity.Attachment{}).Where("id = ?", attachmentID).Updates(map[string]interface{}{
+		"alt_text": altText,
+		"caption":  caption,
+	})
+	if res.Error != nil {
+		tr.logger.ErrorContext(ctx, "query failed", "op", "UpdateAttachment", "err", res.Error)
+		return utils.ErrInternalServerError
+	}
+	if res.RowsAffected == 0 {
+		return utils.ErrNotFound
+	}
+
+	return nil
+}
+
+// ReorderAttachments moves the attachments of threadID into the order of
+// attachmentIDs, positions start at one
+func (tr *ThreadRepositoryImpl) ReorderAttachments(ctx context.Context, threadID uint, attachmentIDs []uint) error {
+	err := tr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i, id := range attachmentIDs {
+			err := tx.Model(&entity.Attachment{}).Where("id = ? AND thread_id = ?", id, threadID).Update("position", i+1).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		tr.logger.ErrorContext(ctx, "query failed", "op", "ReorderAttachments", "err", err)
+		return utils.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (tr *ThreadRepositoryImpl) DeleteAttachment(ctx context.Context, attachmentID uint) error {
+	res := tr.db.WithContext(ctx).Delete(&entity.Attachment{}, attachmentID)
+	if res.Error != nil {
+		tr.logger.ErrorContext(ctx, "query failed", "op", "DeleteAttachment", "err", res.Error)
+		return utils.ErrInternalServerError
+	}
+	if res.RowsAffected == 0 {
+		return utils.ErrNotFound
+	}
+
+	return nil
+}
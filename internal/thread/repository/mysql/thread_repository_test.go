@@ -1,6 +1,7 @@
 package mysql
 
 import (
+	"context"
 	"macaiki/internal/thread/entity"
 	"macaiki/pkg/utils"
 	"regexp"
@@ -31,7 +32,7 @@ var (
 func TestSuccessfullDeleteComment(t *testing.T) {
 	mockedDB, mockObj, err := sqlmock.New()
 	db, err := gorm.Open(mysql.Dialector{
-		&mysql.Config{
+		Config: &mysql.Config{
 			Conn:                      mockedDB,
 			SkipInitializeWithVersion: true,
 		},
@@ -40,7 +41,7 @@ func TestSuccessfullDeleteComment(t *testing.T) {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
 	}
 
-	threadRepo := CreateNewThreadRepository(db)
+	threadRepo := CreateNewThreadRepository(db, nil)
 
 	defer mockedDB.Close()
 
@@ -48,14 +49,14 @@ func TestSuccessfullDeleteComment(t *testing.T) {
 	mockObj.ExpectExec(regexp.QuoteMeta("UPDATE")).WithArgs(utils.AnyTime{}, uint(1)).WillReturnResult(sqlmock.NewResult(0, 1))
 	mockObj.ExpectCommit()
 
-	err = threadRepo.DeleteComment(uint(1))
+	err = threadRepo.DeleteComment(context.Background(), uint(1))
 	assert.NoError(t, err)
 }
 
 func TestNoRowsAffectedDeleteComment(t *testing.T) {
 	mockedDB, mockObj, err := sqlmock.New()
 	db, err := gorm.Open(mysql.Dialector{
-		&mysql.Config{
+		Config: &mysql.Config{
 			Conn:                      mockedDB,
 			SkipInitializeWithVersion: true,
 		},
@@ -64,7 +65,7 @@ func TestNoRowsAffectedDeleteComment(t *testing.T) {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
 	}
 
-	threadRepo := CreateNewThreadRepository(db)
+	threadRepo := CreateNewThreadRepository(db, nil)
 
 	defer mockedDB.Close()
 
@@ -72,14 +73,14 @@ func TestNoRowsAffectedDeleteComment(t *testing.T) {
 	mockObj.ExpectExec(regexp.QuoteMeta("UPDATE")).WithArgs(utils.AnyTime{}, uint(1)).WillReturnResult(sqlmock.NewResult(0, 0))
 	mockObj.ExpectCommit()
 
-	err = threadRepo.DeleteComment(uint(1))
+	err = threadRepo.DeleteComment(context.Background(), uint(1))
 	assert.Error(t, err)
 }
 
 func TestSuccessfullDeleteThread(t *testing.T) {
 	mockedDB, mockObj, err := sqlmock.New()
 	db, err := gorm.Open(mysql.Dialector{
-		&mysql.Config{
+		Config: &mysql.Config{
 			Conn:                      mockedDB,
 			SkipInitializeWithVersion: true,
 		},
@@ -88,7 +89,7 @@ func TestSuccessfullDeleteThread(t *testing.T) {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
 	}
 
-	threadRepo := CreateNewThreadRepository(db)
+	threadRepo := CreateNewThreadRepository(db, nil)
 
 	defer mockedDB.Close()
 
@@ -96,14 +97,14 @@ func TestSuccessfullDeleteThread(t *testing.T) {
 	mockObj.ExpectExec(regexp.QuoteMeta("UPDATE")).WithArgs(utils.AnyTime{}, uint(1)).WillReturnResult(sqlmock.NewResult(0, 1))
 	mockObj.ExpectCommit()
 
-	err = threadRepo.DeleteThread(uint(1))
+	err = threadRepo.DeleteThread(context.Background(), uint(1))
 	assert.NoError(t, err)
 }
 
 func TestNoRowsAffectedDeleteThread(t *testing.T) {
 	mockedDB, mockObj, err := sqlmock.New()
 	db, err := gorm.Open(mysql.Dialector{
-		&mysql.Config{
+		Config: &mysql.Config{
 			Conn:                      mockedDB,
 			SkipInitializeWithVersion: true,
 		},
@@ -112,7 +113,7 @@ func TestNoRowsAffectedDeleteThread(t *testing.T) {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
 	}
 
-	threadRepo := CreateNewThreadRepository(db)
+	threadRepo := CreateNewThreadRepository(db, nil)
 
 	defer mockedDB.Close()
 
@@ -120,7 +121,7 @@ func TestNoRowsAffectedDeleteThread(t *testing.T) {
 	mockObj.ExpectExec(regexp.QuoteMeta("UPDATE")).WithArgs(utils.AnyTime{}, uint(1)).WillReturnResult(sqlmock.NewResult(0, 0))
 	mockObj.ExpectCommit()
 
-	err = threadRepo.DeleteThread(uint(1))
+	err = threadRepo.DeleteThread(context.Background(), uint(1))
 	assert.Error(t, err)
 }
 
@@ -136,7 +137,7 @@ func TestNoRowsAffectedDeleteThread(t *testing.T) {
 // 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
 // 	}
 
-// 	threadRepo := CreateNewThreadRepository(db)
+// 	threadRepo := CreateNewThreadRepository(db, nil)
 
 // 	defer mockedDB.Close()
 
@@ -148,3 +149,238 @@ func TestNoRowsAffectedDeleteThread(t *testing.T) {
 // 	assert.NoError(t, err)
 // 	assert.NotEmpty(t, res)
 // }
+
+func TestSetThreadVoteFromUpvoteToDownvote(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	threadRepo := CreateNewThreadRepository(db, nil)
+
+	defer mockedDB.Close()
+
+	mockObj.ExpectBegin()
+	mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `threads`")).WithArgs(entity.ThreadStatusPublished, uint(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "score", "upvotes_count", "downvotes_count"}).AddRow(1, 3, 4, 1))
+	mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `thread_votes`")).WithArgs(uint(1), uint(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "thread_id", "value"}).AddRow(7, 2, 1, 1))
+	mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `thread_votes` SET `value`=?")).WithArgs(-1, utils.AnyTime{}, uint(7)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `threads` SET")).WithArgs(2, 1, 3, uint(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mockObj.ExpectCommit()
+
+	thread, previous, err := threadRepo.SetThreadVote(context.Background(), uint(1), uint(2), entity.VoteDown)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.VoteUp, previous)
+	assert.Equal(t, 1, thread.Score)
+	assert.Equal(t, 3, thread.UpvotesCount)
+	assert.Equal(t, 2, thread.DownvotesCount)
+	assert.NoError(t, mockObj.ExpectationsWereMet())
+}
+
+func TestSetThreadVoteThreadNotFound(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	threadRepo := CreateNewThreadRepository(db, nil)
+
+	defer mockedDB.Close()
+
+	mockObj.ExpectBegin()
+	mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `threads`")).WithArgs(entity.ThreadStatusPublished, uint(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mockObj.ExpectRollback()
+
+	_, _, err = threadRepo.SetThreadVote(context.Background(), uint(1), uint(2), entity.VoteUp)
+	assert.ErrorIs(t, err, utils.ErrNotFound)
+}
+
+func TestDeleteSavedThreadCollectionUnsortsThreads(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	threadRepo := CreateNewThreadRepository(db, nil)
+
+	defer mockedDB.Close()
+
+	mockObj.ExpectBegin()
+	mockObj.ExpectExec(regexp.QuoteMeta("DELETE FROM `saved_thread_collections` WHERE id = ? AND user_id = ?")).WithArgs(uint(3), uint(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `saved_threads` SET `collection_id`=?,`updated_at`=? WHERE (user_id = ? AND collection_id = ?) AND `saved_threads`.`deleted_at` IS NULL")).
+		WithArgs(nil, utils.AnyTime{}, uint(1), uint(3)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mockObj.ExpectCommit()
+
+	err = threadRepo.DeleteSavedThreadCollection(context.Background(), uint(1), uint(3))
+	assert.NoError(t, err)
+	assert.NoError(t, mockObj.ExpectationsWereMet())
+}
+
+func TestDeleteSavedThreadCollectionNotFound(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	threadRepo := CreateNewThreadRepository(db, nil)
+
+	defer mockedDB.Close()
+
+	mockObj.ExpectBegin()
+	mockObj.ExpectExec(regexp.QuoteMeta("DELETE FROM `saved_thread_collections`")).WithArgs(uint(3), uint(1)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mockObj.ExpectRollback()
+
+	err = threadRepo.DeleteSavedThreadCollection(context.Background(), uint(1), uint(3))
+	assert.ErrorIs(t, err, utils.ErrNotFound)
+	assert.NoError(t, mockObj.ExpectationsWereMet())
+}
+
+func TestPublishThread(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	threadRepo := CreateNewThreadRepository(db, nil)
+
+	defer mockedDB.Close()
+
+	publishedAt := time.Now()
+	mockObj.ExpectBegin()
+	mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `threads` SET `created_at`=?,`image_url`=?,`publish_at`=?,`status`=?,`updated_at`=? WHERE (id = ? AND status = ?) AND `threads`.`deleted_at` IS NULL")).
+		WithArgs(publishedAt, "image.png", nil, entity.ThreadStatusPublished, utils.AnyTime{}, uint(1), entity.ThreadStatusDraft).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mockObj.ExpectCommit()
+
+	err = threadRepo.PublishThread(context.Background(), uint(1), entity.ThreadStatusDraft, "image.png", publishedAt)
+	assert.NoError(t, err)
+	assert.NoError(t, mockObj.ExpectationsWereMet())
+}
+
+func TestPublishThreadNoLongerDraft(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	threadRepo := CreateNewThreadRepository(db, nil)
+
+	defer mockedDB.Close()
+
+	mockObj.ExpectBegin()
+	mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `threads` SET `created_at`=?,`publish_at`=?,`status`=?,`updated_at`=?")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mockObj.ExpectCommit()
+
+	err = threadRepo.PublishThread(context.Background(), uint(1), entity.ThreadStatusScheduled, "", time.Now())
+	assert.ErrorIs(t, err, utils.ErrConflict)
+	assert.NoError(t, mockObj.ExpectationsWereMet())
+}
+
+func TestCreateAttachment(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	threadRepo := CreateNewThreadRepository(db, nil)
+
+	defer mockedDB.Close()
+
+	mockObj.ExpectBegin()
+	mockObj.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `threads` WHERE `threads`.`id` = ? AND `threads`.`deleted_at` IS NULL LIMIT 1 FOR UPDATE")).
+		WithArgs(uint(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mockObj.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) AS count, COALESCE(MAX(position), 0) AS position FROM `attachments` WHERE thread_id = ? AND comment_id = ?")).
+		WithArgs(uint(1), uint(0)).
+		WillReturnRows(sqlmock.NewRows([]string{"count", "position"}).AddRow(2, 3))
+	mockObj.ExpectExec(regexp.QuoteMeta("INSERT INTO `attachments`")).
+		WithArgs(uint(1), uint(0), uint(1), "cat.png", "", "", 0, 0, 4, utils.AnyTime{}, utils.AnyTime{}).
+		WillReturnResult(sqlmock.NewResult(7, 1))
+	mockObj.ExpectCommit()
+
+	res, err := threadRepo.CreateAttachment(context.Background(), entity.Attachment{ThreadID: 1, UserID: 1, FileName: "cat.png"}, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(7), res.ID)
+	assert.Equal(t, 4, res.Position)
+	assert.NoError(t, mockObj.ExpectationsWereMet())
+}
+
+func TestCreateAttachmentGalleryFull(t *testing.T) {
+	mockedDB, mockObj, err := sqlmock.New()
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	threadRepo := CreateNewThreadRepository(db, nil)
+
+	defer mockedDB.Close()
+
+	// another upload filled the gallery after the usecase checked it
+	mockObj.ExpectBegin()
+	mockObj.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `threads`")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mockObj.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) AS count")).
+		WithArgs(uint(1), uint(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"count", "position"}).AddRow(4, 4))
+	mockObj.ExpectRollback()
+
+	_, err = threadRepo.CreateAttachment(context.Background(), entity.Attachment{ThreadID: 1, CommentID: 3, UserID: 1, FileName: "cat.png"}, 4)
+	assert.ErrorIs(t, err, utils.ErrBadParamInput)
+	assert.NoError(t, mockObj.ExpectationsWereMet())
+}
@@ -1,31 +1,62 @@
 package thread
 
 import (
+	"context"
 	"macaiki/internal/thread/dto"
 	"mime/multipart"
 )
 
 type ThreadUseCase interface {
-	CreateThread(thread dto.ThreadRequest, userID uint) (dto.ThreadResponse, error)
-	DeleteThread(threadID uint, userID uint, role string) error
-	UpdateThread(thread dto.ThreadRequest, threadID uint, userID uint) (dto.ThreadResponse, error)
-	GetThreadByID(threadID uint) (dto.ThreadResponse, error)
-	SetThreadImage(img *multipart.FileHeader, threadID uint, userID uint) error
-	UpvoteThread(threadID uint, userID uint) error
-	UndoUpvoteThread(threadID, userID uint) error
-	GetTrendingThreads(userID uint, limit int) ([]dto.DetailedThreadResponse, error)
-	GetThreadsFromFollowedCommunity(userID uint) ([]dto.DetailedThreadResponse, error)
-	GetThreadsFromFollowedUsers(userID uint) ([]dto.DetailedThreadResponse, error)
-	AddThreadComment(dto.CommentRequest) error
-	GetCommentsByThreadID(threadID uint) ([]dto.CommentResponse, error)
-	GetThreads(keyword string, userID uint) ([]dto.DetailedThreadResponse, error)
-	LikeComment(commentID, userID uint) error
-	UnlikeComment(commentID, userID uint) error
-	DownvoteThread(threadID uint, userID uint) error
-	UndoDownvoteThread(threadID, userID uint) error
-	DeleteComment(commentID uint, threadID uint, userID uint, role string) error
-	CreateThreadReport(threadReport dto.ThreadReportRequest) error
-	CreateCommentReport(commentReport dto.CommentReportRequest) error
-	StoreSavedThread(savedThread dto.SavedThreadRequest) error
-	GetSavedThread(userID uint) ([]dto.DetailedThreadResponse, error)
+	CreateThread(ctx context.Context, thread dto.ThreadRequest, userID uint) (dto.ThreadResponse, error)
+	DeleteThread(ctx context.Context, threadID uint, userID uint, role string) error
+	UpdateThread(ctx context.Context, thread dto.ThreadRequest, threadID uint, userID uint) (dto.ThreadResponse, error)
+	GetThreadByID(ctx context.Context, threadID, userID uint) (dto.ThreadResponse, error)
+	CreateDraft(ctx context.Context, thread dto.ThreadRequest, userID uint) (dto.DraftThreadResponse, error)
+	GetDrafts(ctx context.Context, userID uint) ([]dto.DraftThreadResponse, error)
+	PublishThread(ctx context.Context, threadID, userID uint, req dto.PublishThreadRequest, img *multipart.FileHeader) (dto.DraftThreadResponse, error)
+	UnscheduleThread(ctx context.Context, threadID, userID uint) error
+	PublishScheduledThread(ctx context.Context, threadID uint) error
+	UnfurlThread(ctx context.Context, threadID uint) error
+	NotifyFollowers(ctx context.Context, threadID, commenterID uint) error
+	AddAttachment(ctx context.Context, threadID, commentID, userID uint, req dto.AttachmentRequest, img *multipart.FileHeader) (dto.AttachmentResponse, error)
+	UpdateAttachment(ctx context.Context, threadID, attachmentID, userID uint, req dto.AttachmentRequest) error
+	ReorderAttachments(ctx context.Context, threadID, userID uint, req dto.ReorderAttachmentsRequest) error
+	DeleteAttachment(ctx context.Context, threadID, attachmentID, userID uint, role string) error
+	SetThreadImage(ctx context.Context, img *multipart.FileHeader, threadID uint, userID uint) error
+	VoteThread(ctx context.Context, threadID, userID uint, vote dto.ThreadVoteRequest) (dto.ThreadVoteResponse, error)
+	UpvoteThread(ctx context.Context, threadID uint, userID uint) error
+	UndoUpvoteThread(ctx context.Context, threadID, userID uint) error
+	GetTrendingThreads(ctx context.Context, userID uint, limit int) ([]dto.DetailedThreadResponse, error)
+	GetCommunityTrendingThreads(ctx context.Context, userID, communityID uint, limit int) ([]dto.DetailedThreadResponse, error)
+	GetTrendingThreadsByTimeframe(ctx context.Context, userID uint, timeframe string, limit int) ([]dto.DetailedThreadResponse, error)
+	RefreshThreadScores(ctx context.Context) error
+	GetThreadsFromFollowedCommunity(ctx context.Context, userID uint) ([]dto.DetailedThreadResponse, error)
+	GetThreadsFromFollowedUsers(ctx context.Context, userID uint) ([]dto.DetailedThreadResponse, error)
+	AddThreadComment(context.Context, dto.CommentRequest) error
+	GetCommentsByThreadID(ctx context.Context, threadID, userID uint, sort string) ([]dto.CommentResponse, error)
+	GetThreads(ctx context.Context, keyword string, userID uint) ([]dto.DetailedThreadResponse, error)
+	VoteComment(ctx context.Context, threadID, commentID, userID uint, vote dto.CommentVoteRequest) (dto.CommentVoteResponse, error)
+	LikeComment(ctx context.Context, commentID, userID uint) error
+	UnlikeComment(ctx context.Context, commentID, userID uint) error
+	DownvoteThread(ctx context.Context, threadID uint, userID uint) error
+	UndoDownvoteThread(ctx context.Context, threadID, userID uint) error
+	DeleteComment(ctx context.Context, commentID uint, threadID uint, userID uint, role string) error
+	CreateThreadReport(ctx context.Context, threadReport dto.ThreadReportRequest) error
+	CreateCommentReport(ctx context.Context, commentReport dto.CommentReportRequest) error
+	StoreSavedThread(ctx context.Context, savedThread dto.SavedThreadRequest) error
+	GetSavedThread(ctx context.Context, userID uint) ([]dto.DetailedThreadResponse, error)
+	UpdateSavedThread(ctx context.Context, threadID, userID uint, req dto.UpdateSavedThreadRequest) error
+	DeleteSavedThread(ctx context.Context, threadID, userID uint) error
+	GetSavedThreadsByCollection(ctx context.Context, userID, collectionID uint, page, limit int) (dto.SavedThreadPageResponse, error)
+	GetSavedThreadCollections(ctx context.Context, userID uint) (dto.SavedThreadCollectionsResponse, error)
+	CreateSavedThreadCollection(ctx context.Context, userID uint, req dto.SavedThreadCollectionRequest) (dto.SavedThreadCollectionResponse, error)
+	RenameSavedThreadCollection(ctx context.Context, userID, collectionID uint, req dto.SavedThreadCollectionRequest) error
+	DeleteSavedThreadCollection(ctx context.Context, userID, collectionID uint) error
+	FollowThread(ctx context.Context, threadID, userID uint) error
+	UnfollowThread(ctx context.Context, threadID, userID uint) error
+	MuteThread(ctx context.Context, threadID, userID uint) error
+	UnmuteThread(ctx context.Context, threadID, userID uint) error
+	HideThread(ctx context.Context, threadID, userID uint) error
+	UnhideThread(ctx context.Context, threadID, userID uint) error
+	GetHiddenThreads(ctx context.Context, userID uint) ([]dto.DetailedThreadResponse, error)
 }
@@ -0,0 +1,192 @@
+package usecase
+
+import (
+	"context"
+	"image"
+	"macaiki/internal/thread/delivery/http/helper"
+	"macaiki/internal/thread/dto"
+	"macaiki/internal/thread/entity"
+	"macaiki/pkg/utils"
+	"mime/multipart"
+	"unicode/utf8"
+
+	// formats accepted as attachments
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+)
+
+const (
+	THREAD_ATTACHMENTS_MAX         = 10
+	COMMENT_ATTACHMENTS_MAX        = 4
+	ATTACHMENT_ALT_TEXT_MAX_LENGTH = 500
+	ATTACHMENT_CAPTION_MAX_LENGTH  = 300
+)
+
+func validAttachment(req dto.AttachmentRequest) bool {
+	return utf8.RuneCountInString(req.AltText) <= ATTACHMENT_ALT_TEXT_MAX_LENGTH && utf8.RuneCountInString(req.Caption) <= ATTACHMENT_CAPTION_MAX_LENGTH
+}
+
+// imageDimensions reads the size of img from its header, a file that is
+// not a supported image is a bad param
+func imageDimensions(img *multipart.FileHeader) (int, int, error) {
+	src, err := img.Open()
+	if err != nil {
+		return 0, 0, utils.ErrBadParamInput
+	}
+	defer src.Close()
+
+	config, _, err := image.DecodeConfig(src)
+	if err != nil {
+		return 0, 0, utils.ErrBadParamInput
+	}
+
+	return config.Width, config.Height, nil
+}
+
+// AddAttachment appends img to the gallery of a thread of userID, or to the
+// gallery of their comment when commentID is set. A gallery holds at most
+// THREAD_ATTACHMENTS_MAX images, COMMENT_ATTACHMENTS_MAX for a comment.
+func (tuc *ThreadUseCaseImpl) AddAttachment(ctx context.Context, threadID, commentID, userID uint, req dto.AttachmentRequest, img *multipart.FileHeader) (dto.AttachmentResponse, error) {
+	if img == nil || !validAttachment(req) {
+		return dto.AttachmentResponse{}, utils.ErrBadParamInput
+	}
+
+	limit := THREAD_ATTACHMENTS_MAX
+	if commentID == 0 {
+		flag, _, err := AuthorizeThreadAccess(ctx, threadID, userID, "", tuc)
+		if err != nil {
+			return dto.AttachmentResponse{}, err
+		}
+		if !flag {
+			return dto.AttachmentResponse{}, utils.ErrUnauthorizedAccess
+		}
+	} else {
+		comment, err := tuc.tr.GetCommentByID(ctx, commentID)
+		if err != nil {
+			return dto.AttachmentResponse{}, err
+		}
+		if comment.ThreadID != threadID {
+			return dto.AttachmentResponse{}, utils.ErrNotFound
+		}
+		if comment.UserID != userID {
+			return dto.AttachmentResponse{}, utils.ErrUnauthorizedAccess
+		}
+		limit = COMMENT_ATTACHMENTS_MAX
+	}
+
+	width, height, err := imageDimensions(img)
+	if err != nil {
+		return dto.AttachmentResponse{}, err
+	}
+
+	// checked again when the attachment is stored, this only saves the upload
+	attachments, err := tuc.tr.GetAttachmentsByThreadID(ctx, threadID)
+	if err != nil {
+		return dto.AttachmentResponse{}, err
+	}
+	if len(helper.DomainAttachmentToListAttachmentResponse(attachments, commentID)) >= limit {
+		return dto.AttachmentResponse{}, utils.ErrBadParamInput
+	}
+
+	fileName, err := tuc.uploadThreadImage(ctx, threadID, img)
+	if err != nil {
+		return dto.AttachmentResponse{}, err
+	}
+
+	res, err := tuc.tr.CreateAttachment(ctx, entity.Attachment{
+		ThreadID:  threadID,
+		CommentID: commentID,
+		UserID:    userID,
+		FileName:  fileName,
+		AltText:   req.AltText,
+		Caption:   req.Caption,
+		Width:     width,
+		Height:    height,
+	}, limit)
+	if err != nil {
+		tuc.deleteThreadImage(ctx, fileName)
+		return dto.AttachmentResponse{}, err
+	}
+
+	return helper.DomainAttachmentToAttachmentResponse(res), nil
+}
+
+// getOwnAttachment loads an attachment of threadID that userID added
+func (tuc *ThreadUseCaseImpl) getOwnAttachment(ctx context.Context, threadID, attachmentID, userID uint, role string) (entity.Attachment, error) {
+	attachment, err := tuc.tr.GetAttachment(ctx, attachmentID)
+	if err != nil {
+		return entity.Attachment{}, err
+	}
+	if attachment.ThreadID != threadID {
+		return entity.Attachment{}, utils.ErrNotFound
+	}
+	if attachment.UserID != userID && role != "Admin" {
+		return entity.Attachment{}, utils.ErrUnauthorizedAccess
+	}
+
+	return attachment, nil
+}
+
+func (tuc *ThreadUseCaseImpl) UpdateAttachment(ctx context.Context, threadID, attachmentID, userID uint, req dto.AttachmentRequest) error {
+	if !validAttachment(req) {
+		return utils.ErrBadParamInput
+	}
+
+	if _, err := tuc.getOwnAttachment(ctx, threadID, attachmentID, userID, ""); err != nil {
+		return err
+	}
+
+	return tuc.tr.UpdateAttachment(ctx, attachmentID, req.AltText, req.Caption)
+}
+
+// ReorderAttachments puts the gallery of a thread of userID in the order
+// of the request, which must list every image of the gallery once
+func (tuc *ThreadUseCaseImpl) ReorderAttachments(ctx context.Context, threadID, userID uint, req dto.ReorderAttachmentsRequest) error {
+	flag, _, err := AuthorizeThreadAccess(ctx, threadID, userID, "", tuc)
+	if err != nil {
+		return err
+	}
+	if !flag {
+		return utils.ErrUnauthorizedAccess
+	}
+
+	attachments, err := tuc.tr.GetAttachmentsByThreadID(ctx, threadID)
+	if err != nil {
+		return err
+	}
+	gallery := map[uint]bool{}
+	for _, val := range helper.DomainAttachmentToListAttachmentResponse(attachments, 0) {
+		gallery[val.ID] = true
+	}
+
+	if len(req.AttachmentIDs) != len(gallery) {
+		return utils.ErrBadParamInput
+	}
+	for _, id := range req.AttachmentIDs {
+		if !gallery[id] {
+			return utils.ErrBadParamInput
+		}
+		// an ID listed twice leaves another one out
+		delete(gallery, id)
+	}
+
+	return tuc.tr.ReorderAttachments(ctx, threadID, req.AttachmentIDs)
+}
+
+// DeleteAttachment removes one image from a gallery, the rest keep their
+// order. Admins can delete any attachment.
+func (tuc *ThreadUseCaseImpl) DeleteAttachment(ctx context.Context, threadID, attachmentID, userID uint, role string) error {
+	attachment, err := tuc.getOwnAttachment(ctx, threadID, attachmentID, userID, role)
+	if err != nil {
+		return err
+	}
+
+	err = tuc.tr.DeleteAttachment(ctx, attachmentID)
+	if err != nil {
+		return err
+	}
+
+	tuc.deleteThreadImage(ctx, attachment.FileName)
+	return nil
+}
@@ -0,0 +1,198 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	jobMocks "macaiki/internal/job/mocks"
+	"macaiki/internal/thread/dto"
+	"macaiki/internal/thread/entity"
+	"macaiki/internal/thread/mocks"
+	cloudstorage "macaiki/pkg/cloud_storage"
+	"macaiki/pkg/utils"
+	"mime/multipart"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// formFile builds the header of an uploaded file the way echo hands it over
+func formFile(t *testing.T, name string, content []byte) *multipart.FileHeader {
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+	part, err := w.CreateFormFile("image", name)
+	assert.NoError(t, err)
+	_, err = part.Write(content)
+	assert.NoError(t, err)
+	assert.NoError(t, w.Close())
+
+	form, err := multipart.NewReader(body, w.Boundary()).ReadForm(1 << 20)
+	assert.NoError(t, err)
+	return form.File["image"][0]
+}
+
+func pngFile(t *testing.T, width, height int) *multipart.FileHeader {
+	buf := &bytes.Buffer{}
+	assert.NoError(t, png.Encode(buf, image.NewRGBA(image.Rect(0, 0, width, height))))
+	return formFile(t, "image.png", buf.Bytes())
+}
+
+func TestImageDimensions(t *testing.T) {
+	width, height, err := imageDimensions(pngFile(t, 3, 2))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, width)
+	assert.Equal(t, 2, height)
+
+	_, _, err = imageDimensions(formFile(t, "notes.txt", []byte("not an image")))
+	assert.ErrorIs(t, err, utils.ErrBadParamInput)
+}
+
+func TestAddAttachment(t *testing.T) {
+	t.Run("gallery-full", func(t *testing.T) {
+		mockThreadRepo := mocks.NewThreadRepository(t)
+		attachments := []entity.Attachment{}
+		for i := 1; i <= THREAD_ATTACHMENTS_MAX; i++ {
+			attachments = append(attachments, entity.Attachment{ID: uint(i), ThreadID: 1, Position: i})
+		}
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(mockedEntity, nil).Once()
+		mockThreadRepo.On("GetAttachmentsByThreadID", mock.Anything, uint(1)).Return(attachments, nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{})
+		_, err := testThreadUseCase.AddAttachment(context.Background(), uint(1), uint(0), uint(1), dto.AttachmentRequest{}, pngFile(t, 1, 1))
+
+		assert.ErrorIs(t, err, utils.ErrBadParamInput)
+	})
+
+	t.Run("comment-gallery-full", func(t *testing.T) {
+		mockThreadRepo := mocks.NewThreadRepository(t)
+		attachments := []entity.Attachment{{ID: 1, ThreadID: 1, Position: 1}}
+		for i := 1; i <= COMMENT_ATTACHMENTS_MAX; i++ {
+			attachments = append(attachments, entity.Attachment{ID: uint(i + 1), ThreadID: 1, CommentID: 1, Position: i})
+		}
+		mockThreadRepo.On("GetCommentByID", mock.Anything, uint(1)).Return(mockedCommentEntity, nil).Once()
+		mockThreadRepo.On("GetAttachmentsByThreadID", mock.Anything, uint(1)).Return(attachments, nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{})
+		_, err := testThreadUseCase.AddAttachment(context.Background(), uint(1), uint(1), uint(2), dto.AttachmentRequest{}, pngFile(t, 1, 1))
+
+		assert.ErrorIs(t, err, utils.ErrBadParamInput)
+	})
+
+	t.Run("comment-of-another-user", func(t *testing.T) {
+		mockThreadRepo := mocks.NewThreadRepository(t)
+		mockThreadRepo.On("GetCommentByID", mock.Anything, uint(1)).Return(mockedCommentEntity, nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{})
+		_, err := testThreadUseCase.AddAttachment(context.Background(), uint(1), uint(1), uint(1), dto.AttachmentRequest{}, pngFile(t, 1, 1))
+
+		assert.ErrorIs(t, err, utils.ErrUnauthorizedAccess)
+	})
+
+	t.Run("not-an-image", func(t *testing.T) {
+		mockThreadRepo := mocks.NewThreadRepository(t)
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(mockedEntity, nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{})
+		_, err := testThreadUseCase.AddAttachment(context.Background(), uint(1), uint(0), uint(1), dto.AttachmentRequest{}, formFile(t, "notes.txt", []byte("text")))
+
+		assert.ErrorIs(t, err, utils.ErrBadParamInput)
+	})
+
+	t.Run("caption-too-long", func(t *testing.T) {
+		testThreadUseCase := newTestThreadUseCase(nil, testDeps{})
+		req := dto.AttachmentRequest{Caption: strings.Repeat("a", ATTACHMENT_CAPTION_MAX_LENGTH+1)}
+		_, err := testThreadUseCase.AddAttachment(context.Background(), uint(1), uint(0), uint(1), req, pngFile(t, 1, 1))
+
+		assert.ErrorIs(t, err, utils.ErrBadParamInput)
+	})
+}
+
+func TestUpdateAttachment(t *testing.T) {
+	req := dto.AttachmentRequest{AltText: "A cat", Caption: "Our cat"}
+
+	t.Run("success", func(t *testing.T) {
+		mockThreadRepo := mocks.NewThreadRepository(t)
+		mockThreadRepo.On("GetAttachment", mock.Anything, uint(5)).Return(entity.Attachment{ID: 5, ThreadID: 1, UserID: 1}, nil).Once()
+		mockThreadRepo.On("UpdateAttachment", mock.Anything, uint(5), "A cat", "Our cat").Return(nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{})
+		err := testThreadUseCase.UpdateAttachment(context.Background(), uint(1), uint(5), uint(1), req)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("other-thread", func(t *testing.T) {
+		mockThreadRepo := mocks.NewThreadRepository(t)
+		mockThreadRepo.On("GetAttachment", mock.Anything, uint(5)).Return(entity.Attachment{ID: 5, ThreadID: 2, UserID: 1}, nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{})
+		err := testThreadUseCase.UpdateAttachment(context.Background(), uint(1), uint(5), uint(1), req)
+
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+	})
+}
+
+func TestReorderAttachments(t *testing.T) {
+	attachments := []entity.Attachment{
+		{ID: 1, ThreadID: 1, Position: 1},
+		{ID: 2, ThreadID: 1, Position: 2},
+		{ID: 3, ThreadID: 1, CommentID: 1, Position: 1},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockThreadRepo := mocks.NewThreadRepository(t)
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(mockedEntity, nil).Once()
+		mockThreadRepo.On("GetAttachmentsByThreadID", mock.Anything, uint(1)).Return(attachments, nil).Once()
+		mockThreadRepo.On("ReorderAttachments", mock.Anything, uint(1), []uint{2, 1}).Return(nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{})
+		err := testThreadUseCase.ReorderAttachments(context.Background(), uint(1), uint(1), dto.ReorderAttachmentsRequest{AttachmentIDs: []uint{2, 1}})
+
+		assert.NoError(t, err)
+	})
+
+	for name, ids := range map[string][]uint{
+		"missing":   {2},
+		"duplicate": {2, 2},
+		"comment":   {3, 1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			mockThreadRepo := mocks.NewThreadRepository(t)
+			mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(mockedEntity, nil).Once()
+			mockThreadRepo.On("GetAttachmentsByThreadID", mock.Anything, uint(1)).Return(attachments, nil).Once()
+
+			testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{})
+			err := testThreadUseCase.ReorderAttachments(context.Background(), uint(1), uint(1), dto.ReorderAttachmentsRequest{AttachmentIDs: ids})
+
+			assert.ErrorIs(t, err, utils.ErrBadParamInput)
+		})
+	}
+}
+
+func TestDeleteAttachment(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockThreadRepo := mocks.NewThreadRepository(t)
+		mockJobQueue := jobMocks.NewQueue(t)
+		mockThreadRepo.On("GetAttachment", mock.Anything, uint(5)).Return(entity.Attachment{ID: 5, ThreadID: 1, UserID: 1, FileName: "cat.png"}, nil).Once()
+		mockThreadRepo.On("DeleteAttachment", mock.Anything, uint(5)).Return(nil).Once()
+		mockJobQueue.On("Enqueue", mock.Anything, cloudstorage.JobDeleteImage, cloudstorage.DeleteImagePayload{FileName: "cat.png", DirName: "thread"}).Return(nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{jobQueue: mockJobQueue})
+		err := testThreadUseCase.DeleteAttachment(context.Background(), uint(1), uint(5), uint(1), "User")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		mockThreadRepo := mocks.NewThreadRepository(t)
+		mockThreadRepo.On("GetAttachment", mock.Anything, uint(5)).Return(entity.Attachment{ID: 5, ThreadID: 1, UserID: 1}, nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{})
+		err := testThreadUseCase.DeleteAttachment(context.Background(), uint(1), uint(5), uint(2), "User")
+
+		assert.ErrorIs(t, err, utils.ErrUnauthorizedAccess)
+	})
+}
@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"macaiki/internal/thread/dto"
+	"macaiki/internal/thread/entity"
+	"math"
+	"sort"
+)
+
+// wilsonZ is the z-score of a 95% confidence interval
+const wilsonZ = 1.96
+
+type commentLess func(a, b entity.Comment) bool
+
+var commentSorts = map[string]commentLess{
+	dto.CommentSortBest: func(a, b entity.Comment) bool {
+		wa, wb := wilsonScore(a.UpvotesCount, a.DownvotesCount), wilsonScore(b.UpvotesCount, b.DownvotesCount)
+		if wa != wb {
+			return wa > wb
+		}
+		return newer(a, b)
+	},
+	dto.CommentSortTop: func(a, b entity.Comment) bool {
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		return newer(a, b)
+	},
+	dto.CommentSortNew: newer,
+	dto.CommentSortOld: func(a, b entity.Comment) bool {
+		return newer(b, a)
+	},
+	dto.CommentSortControversial: func(a, b entity.Comment) bool {
+		ca, cb := controversy(a.UpvotesCount, a.DownvotesCount), controversy(b.UpvotesCount, b.DownvotesCount)
+		if ca != cb {
+			return ca > cb
+		}
+		return newer(a, b)
+	},
+}
+
+func sortComments(comments []entity.CommentDetails, less commentLess) {
+	sort.SliceStable(comments, func(i, j int) bool {
+		return less(comments[i].Comment, comments[j].Comment)
+	})
+}
+
+func newer(a, b entity.Comment) bool {
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.After(b.CreatedAt)
+	}
+	return a.ID > b.ID
+}
+
+// wilsonScore is the lower bound of the Wilson score interval for the share
+// of upvotes, it keeps a comment with few votes from outranking one with
+// many slightly less positive votes
+func wilsonScore(ups, downs int) float64 {
+	n := float64(ups + downs)
+	if n == 0 {
+		return 0
+	}
+
+	p := float64(ups) / n
+	z2 := wilsonZ * wilsonZ
+	return (p + z2/(2*n) - wilsonZ*math.Sqrt((p*(1-p)+z2/(4*n))/n)) / (1 + z2/n)
+}
+
+// controversy grows with the number of votes and with how evenly they are
+// split, a comment voted only one way is not controversial at all
+func controversy(ups, downs int) float64 {
+	if ups <= 0 || downs <= 0 {
+		return 0
+	}
+
+	magnitude := float64(ups + downs)
+	balance := float64(ups) / float64(downs)
+	if ups > downs {
+		balance = float64(downs) / float64(ups)
+	}
+	return math.Pow(magnitude, balance)
+}
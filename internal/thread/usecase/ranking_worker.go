@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"macaiki/internal/thread"
+	"macaiki/pkg/logger"
+	"time"
+)
+
+// RunThreadRanking rebuilds the trending scores right away and then every
+// interval until ctx is cancelled
+func RunThreadRanking(ctx context.Context, tu thread.ThreadUseCase, interval time.Duration, log *slog.Logger) {
+	log = logger.OrDefault(log)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := tu.RefreshThreadScores(ctx); err != nil && ctx.Err() == nil {
+			log.Error("failed to refresh thread scores", "err", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
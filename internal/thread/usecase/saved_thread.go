@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"context"
+	"macaiki/internal/thread/delivery/http/helper"
+	"macaiki/internal/thread/dto"
+	"macaiki/internal/thread/entity"
+	"macaiki/pkg/utils"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	SAVED_NOTE_MAX_LENGTH       = 500
+	SAVED_COLLECTION_NAME_MAX   = 50
+	SAVED_THREADS_DEFAULT_LIMIT = 20
+	SAVED_THREADS_MAX_LIMIT     = 50
+)
+
+// savedCollectionID resolves the collection a thread is filed under, nil or
+// zero means no collection. A collection of another user is not found.
+func (tuc *ThreadUseCaseImpl) savedCollectionID(ctx context.Context, userID uint, collectionID *uint) (*uint, error) {
+	if collectionID == nil || *collectionID == 0 {
+		return nil, nil
+	}
+
+	collection, err := tuc.tr.GetSavedThreadCollection(ctx, userID, *collectionID)
+	if err != nil {
+		return nil, err
+	}
+	return &collection.ID, nil
+}
+
+func (tuc *ThreadUseCaseImpl) UpdateSavedThread(ctx context.Context, threadID, userID uint, req dto.UpdateSavedThreadRequest) error {
+	if req.Note != nil && utf8.RuneCountInString(*req.Note) > SAVED_NOTE_MAX_LENGTH {
+		return utils.ErrBadParamInput
+	}
+
+	saved, err := tuc.tr.GetSavedThreadByThreadID(ctx, userID, threadID)
+	if err != nil {
+		return err
+	}
+
+	collectionID := saved.CollectionID
+	if req.CollectionID != nil {
+		collectionID, err = tuc.savedCollectionID(ctx, userID, req.CollectionID)
+		if err != nil {
+			return err
+		}
+	}
+	note := saved.Note
+	if req.Note != nil {
+		note = *req.Note
+	}
+
+	return tuc.tr.UpdateSavedThread(ctx, userID, threadID, collectionID, note)
+}
+
+func (tuc *ThreadUseCaseImpl) DeleteSavedThread(ctx context.Context, threadID, userID uint) error {
+	return tuc.tr.DeleteSavedThread(ctx, userID, threadID)
+}
+
+// GetSavedThreadsByCollection pages through a collection, a zero
+// collectionID lists the saved threads outside any collection
+func (tuc *ThreadUseCaseImpl) GetSavedThreadsByCollection(ctx context.Context, userID, collectionID uint, page, limit int) (dto.SavedThreadPageResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = SAVED_THREADS_DEFAULT_LIMIT
+	}
+	if limit > SAVED_THREADS_MAX_LIMIT {
+		limit = SAVED_THREADS_MAX_LIMIT
+	}
+
+	if collectionID != 0 {
+		if _, err := tuc.tr.GetSavedThreadCollection(ctx, userID, collectionID); err != nil {
+			return dto.SavedThreadPageResponse{}, err
+		}
+	}
+
+	total, err := tuc.tr.CountSavedThreads(ctx, userID, collectionID)
+	if err != nil {
+		return dto.SavedThreadPageResponse{}, err
+	}
+
+	threads, err := tuc.tr.GetSavedThreadsByCollection(ctx, userID, collectionID, limit, (page-1)*limit)
+	if err != nil {
+		return dto.SavedThreadPageResponse{}, err
+	}
+
+	return dto.SavedThreadPageResponse{
+		Threads: helper.DomainSavedThreadToListSavedThreadResponse(threads),
+		Page:    page,
+		Limit:   limit,
+		Total:   total,
+	}, nil
+}
+
+func (tuc *ThreadUseCaseImpl) GetSavedThreadCollections(ctx context.Context, userID uint) (dto.SavedThreadCollectionsResponse, error) {
+	collections, err := tuc.tr.GetSavedThreadCollections(ctx, userID)
+	if err != nil {
+		return dto.SavedThreadCollectionsResponse{}, err
+	}
+
+	unsorted, err := tuc.tr.CountSavedThreads(ctx, userID, 0)
+	if err != nil {
+		return dto.SavedThreadCollectionsResponse{}, err
+	}
+
+	res := dto.SavedThreadCollectionsResponse{
+		Collections:   []dto.SavedThreadCollectionResponse{},
+		UnsortedCount: unsorted,
+		TotalCount:    unsorted,
+	}
+	for _, val := range collections {
+		res.Collections = append(res.Collections, helper.DomainSavedThreadCollectionToResponse(val))
+		res.TotalCount += int64(val.SavedCount)
+	}
+	return res, nil
+}
+
+// collectionName trims a collection name and checks it fits
+func collectionName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > SAVED_COLLECTION_NAME_MAX {
+		return "", utils.ErrBadParamInput
+	}
+	return name, nil
+}
+
+func (tuc *ThreadUseCaseImpl) CreateSavedThreadCollection(ctx context.Context, userID uint, req dto.SavedThreadCollectionRequest) (dto.SavedThreadCollectionResponse, error) {
+	name, err := collectionName(req.Name)
+	if err != nil {
+		return dto.SavedThreadCollectionResponse{}, err
+	}
+
+	collection, err := tuc.tr.CreateSavedThreadCollection(ctx, entity.SavedThreadCollection{UserID: userID, Name: name})
+	if err != nil {
+		return dto.SavedThreadCollectionResponse{}, err
+	}
+
+	return helper.DomainSavedThreadCollectionToResponse(collection), nil
+}
+
+func (tuc *ThreadUseCaseImpl) RenameSavedThreadCollection(ctx context.Context, userID, collectionID uint, req dto.SavedThreadCollectionRequest) error {
+	name, err := collectionName(req.Name)
+	if err != nil {
+		return err
+	}
+
+	return tuc.tr.RenameSavedThreadCollection(ctx, userID, collectionID, name)
+}
+
+// DeleteSavedThreadCollection removes a collection and keeps its threads
+// saved outside any collection
+func (tuc *ThreadUseCaseImpl) DeleteSavedThreadCollection(ctx context.Context, userID, collectionID uint) error {
+	return tuc.tr.DeleteSavedThreadCollection(ctx, userID, collectionID)
+}
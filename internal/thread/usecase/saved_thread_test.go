@@ -0,0 +1,181 @@
+package usecase
+
+import (
+	"context"
+	entityMocks "macaiki/internal/notification/mocks"
+	"macaiki/internal/thread/dto"
+	"macaiki/internal/thread/entity"
+	"macaiki/internal/thread/mocks"
+	"macaiki/pkg/utils"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func uintPtr(v uint) *uint {
+	return &v
+}
+
+func TestStoreSavedThreadInCollection(t *testing.T) {
+	mockThreadRepo := mocks.NewThreadRepository(t)
+	mockNotifRepo := entityMocks.NewNotificationRepository(t)
+
+	t.Run("success", func(t *testing.T) {
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(mockedEntity, nil).Once()
+		mockThreadRepo.On("GetSavedThreadCollection", mock.Anything, uint(1), uint(3)).Return(entity.SavedThreadCollection{ID: 3, UserID: 1}, nil).Once()
+		mockThreadRepo.On("StoreSavedThread", mock.Anything, entity.SavedThread{UserID: 1, ThreadID: 1, CollectionID: uintPtr(3), Note: "later"}).Return(nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		err := testThreadUseCase.StoreSavedThread(context.Background(), dto.SavedThreadRequest{UserID: 1, ThreadID: 1, CollectionID: uintPtr(3), Note: "later"})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("collection-not-found", func(t *testing.T) {
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(mockedEntity, nil).Once()
+		mockThreadRepo.On("GetSavedThreadCollection", mock.Anything, uint(1), uint(3)).Return(entity.SavedThreadCollection{}, utils.ErrNotFound).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		err := testThreadUseCase.StoreSavedThread(context.Background(), dto.SavedThreadRequest{UserID: 1, ThreadID: 1, CollectionID: uintPtr(3)})
+
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+	})
+
+	t.Run("bad-param-input", func(t *testing.T) {
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		err := testThreadUseCase.StoreSavedThread(context.Background(), dto.SavedThreadRequest{UserID: 1, ThreadID: 1, Note: strings.Repeat("a", SAVED_NOTE_MAX_LENGTH+1)})
+
+		assert.ErrorIs(t, err, utils.ErrBadParamInput)
+	})
+}
+
+func TestUpdateSavedThread(t *testing.T) {
+	mockThreadRepo := mocks.NewThreadRepository(t)
+	mockNotifRepo := entityMocks.NewNotificationRepository(t)
+	saved := entity.SavedThread{UserID: 1, ThreadID: 2, CollectionID: uintPtr(3), Note: "keep"}
+
+	t.Run("move", func(t *testing.T) {
+		mockThreadRepo.On("GetSavedThreadByThreadID", mock.Anything, uint(1), uint(2)).Return(saved, nil).Once()
+		mockThreadRepo.On("GetSavedThreadCollection", mock.Anything, uint(1), uint(4)).Return(entity.SavedThreadCollection{ID: 4, UserID: 1}, nil).Once()
+		mockThreadRepo.On("UpdateSavedThread", mock.Anything, uint(1), uint(2), uintPtr(4), "keep").Return(nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		err := testThreadUseCase.UpdateSavedThread(context.Background(), uint(2), uint(1), dto.UpdateSavedThreadRequest{CollectionID: uintPtr(4)})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("remove-from-collection-and-edit-note", func(t *testing.T) {
+		note := "new note"
+		mockThreadRepo.On("GetSavedThreadByThreadID", mock.Anything, uint(1), uint(2)).Return(saved, nil).Once()
+		mockThreadRepo.On("UpdateSavedThread", mock.Anything, uint(1), uint(2), (*uint)(nil), note).Return(nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		err := testThreadUseCase.UpdateSavedThread(context.Background(), uint(2), uint(1), dto.UpdateSavedThreadRequest{CollectionID: uintPtr(0), Note: &note})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		mockThreadRepo.On("GetSavedThreadByThreadID", mock.Anything, uint(1), uint(2)).Return(entity.SavedThread{}, utils.ErrNotFound).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		err := testThreadUseCase.UpdateSavedThread(context.Background(), uint(2), uint(1), dto.UpdateSavedThreadRequest{})
+
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+	})
+}
+
+func TestGetSavedThreadsByCollection(t *testing.T) {
+	mockThreadRepo := mocks.NewThreadRepository(t)
+	mockNotifRepo := entityMocks.NewNotificationRepository(t)
+
+	t.Run("success", func(t *testing.T) {
+		mockThreadRepo.On("GetSavedThreadCollection", mock.Anything, uint(1), uint(3)).Return(entity.SavedThreadCollection{ID: 3, UserID: 1}, nil).Once()
+		mockThreadRepo.On("CountSavedThreads", mock.Anything, uint(1), uint(3)).Return(int64(12), nil).Once()
+		mockThreadRepo.On("GetSavedThreadsByCollection", mock.Anything, uint(1), uint(3), 5, 10).
+			Return([]entity.SavedThreadDetails{{ThreadWithDetails: mockedDetailedThread[0], CollectionID: uintPtr(3), Note: "note"}}, nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		res, err := testThreadUseCase.GetSavedThreadsByCollection(context.Background(), uint(1), uint(3), 3, 5)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(12), res.Total)
+		assert.Equal(t, 3, res.Page)
+		assert.Len(t, res.Threads, 1)
+		assert.Equal(t, "note", res.Threads[0].Note)
+	})
+
+	t.Run("unsorted-with-default-limit", func(t *testing.T) {
+		mockThreadRepo.On("CountSavedThreads", mock.Anything, uint(1), uint(0)).Return(int64(0), nil).Once()
+		mockThreadRepo.On("GetSavedThreadsByCollection", mock.Anything, uint(1), uint(0), SAVED_THREADS_DEFAULT_LIMIT, 0).Return([]entity.SavedThreadDetails{}, nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		res, err := testThreadUseCase.GetSavedThreadsByCollection(context.Background(), uint(1), uint(0), 0, 0)
+
+		assert.NoError(t, err)
+		assert.Empty(t, res.Threads)
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		mockThreadRepo.On("GetSavedThreadCollection", mock.Anything, uint(1), uint(3)).Return(entity.SavedThreadCollection{}, utils.ErrNotFound).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		_, err := testThreadUseCase.GetSavedThreadsByCollection(context.Background(), uint(1), uint(3), 1, 20)
+
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+	})
+}
+
+func TestGetSavedThreadCollections(t *testing.T) {
+	mockThreadRepo := mocks.NewThreadRepository(t)
+	mockNotifRepo := entityMocks.NewNotificationRepository(t)
+
+	t.Run("success", func(t *testing.T) {
+		mockThreadRepo.On("GetSavedThreadCollections", mock.Anything, uint(1)).Return([]entity.SavedThreadCollection{{ID: 3, Name: "Go", SavedCount: 4}, {ID: 4, Name: "Rust", SavedCount: 1}}, nil).Once()
+		mockThreadRepo.On("CountSavedThreads", mock.Anything, uint(1), uint(0)).Return(int64(2), nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		res, err := testThreadUseCase.GetSavedThreadCollections(context.Background(), uint(1))
+
+		assert.NoError(t, err)
+		assert.Len(t, res.Collections, 2)
+		assert.Equal(t, int64(2), res.UnsortedCount)
+		assert.Equal(t, int64(7), res.TotalCount)
+	})
+}
+
+func TestCreateSavedThreadCollection(t *testing.T) {
+	mockThreadRepo := mocks.NewThreadRepository(t)
+	mockNotifRepo := entityMocks.NewNotificationRepository(t)
+
+	t.Run("success", func(t *testing.T) {
+		mockThreadRepo.On("CreateSavedThreadCollection", mock.Anything, entity.SavedThreadCollection{UserID: 1, Name: "Go"}).Return(entity.SavedThreadCollection{ID: 3, UserID: 1, Name: "Go"}, nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		res, err := testThreadUseCase.CreateSavedThreadCollection(context.Background(), uint(1), dto.SavedThreadCollectionRequest{Name: "  Go "})
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(3), res.ID)
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		mockThreadRepo.On("CreateSavedThreadCollection", mock.Anything, entity.SavedThreadCollection{UserID: 1, Name: "Go"}).Return(entity.SavedThreadCollection{}, utils.ErrConflict).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		_, err := testThreadUseCase.CreateSavedThreadCollection(context.Background(), uint(1), dto.SavedThreadCollectionRequest{Name: "Go"})
+
+		assert.ErrorIs(t, err, utils.ErrConflict)
+	})
+
+	t.Run("bad-param-input", func(t *testing.T) {
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+
+		for _, name := range []string{" ", strings.Repeat("a", SAVED_COLLECTION_NAME_MAX+1)} {
+			_, err := testThreadUseCase.CreateSavedThreadCollection(context.Background(), uint(1), dto.SavedThreadCollectionRequest{Name: name})
+			assert.ErrorIs(t, err, utils.ErrBadParamInput)
+		}
+	})
+}
@@ -0,0 +1,192 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"macaiki/internal/thread"
+	"macaiki/internal/thread/delivery/http/helper"
+	"macaiki/internal/thread/dto"
+	"macaiki/internal/thread/entity"
+	"macaiki/pkg/markdown"
+	"macaiki/pkg/metrics"
+	"macaiki/pkg/utils"
+	"mime/multipart"
+	"time"
+)
+
+// getPublishedThread loads a thread others can act on, drafts are reported
+// as not found so their existence does not leak
+func (tuc *ThreadUseCaseImpl) getPublishedThread(ctx context.Context, threadID uint) (entity.Thread, error) {
+	thread, err := tuc.tr.GetThreadByID(ctx, threadID)
+	if err != nil {
+		return entity.Thread{}, err
+	}
+	if !thread.IsPublished() {
+		return entity.Thread{}, utils.ErrNotFound
+	}
+
+	return thread, nil
+}
+
+// CreateDraft stores a thread that stays out of every feed until it is
+// published, the author follows it from the start
+func (tuc *ThreadUseCaseImpl) CreateDraft(ctx context.Context, thread dto.ThreadRequest, userID uint) (dto.DraftThreadResponse, error) {
+	if err := tuc.validator.Struct(thread); err != nil {
+		return dto.DraftThreadResponse{}, utils.ErrBadParamInput
+	}
+
+	draft := entity.Thread{
+		Title:       thread.Title,
+		Body:        thread.Body,
+		UserID:      userID,
+		CommunityID: thread.CommunityID,
+		Status:      entity.ThreadStatusDraft,
+	}
+	draft.BodyHTML, draft.BodyText = markdown.Render(thread.Body)
+
+	res, err := tuc.tr.CreateThread(ctx, draft)
+	if err != nil {
+		return dto.DraftThreadResponse{}, err
+	}
+	tuc.subscribe(ctx, res.ID, userID)
+	tuc.queueUnfurl(ctx, res.ID, res.Body, res.Preview)
+
+	return helper.DomainThreadToDraftThreadResponse(res), nil
+}
+
+func (tuc *ThreadUseCaseImpl) GetDrafts(ctx context.Context, userID uint) ([]dto.DraftThreadResponse, error) {
+	res, err := tuc.tr.GetDraftThreads(ctx, userID)
+	if err != nil {
+		return []dto.DraftThreadResponse{}, err
+	}
+
+	drafts := []dto.DraftThreadResponse{}
+	for _, val := range res {
+		drafts = append(drafts, helper.DomainThreadToDraftThreadResponse(val))
+	}
+	return drafts, nil
+}
+
+// PublishThread publishes a draft of userID together with img, when given,
+// in a single update. A PublishAt in the future schedules the thread
+// instead and a background job publishes it then. Publishing a scheduled
+// thread again publishes it right away or moves its schedule.
+func (tuc *ThreadUseCaseImpl) PublishThread(ctx context.Context, threadID, userID uint, req dto.PublishThreadRequest, img *multipart.FileHeader) (dto.DraftThreadResponse, error) {
+	flag, draft, err := AuthorizeThreadAccess(ctx, threadID, userID, "", tuc)
+	if err != nil {
+		return dto.DraftThreadResponse{}, err
+	}
+	if !flag {
+		return dto.DraftThreadResponse{}, utils.ErrUnauthorizedAccess
+	}
+	if draft.IsPublished() {
+		return dto.DraftThreadResponse{}, utils.ErrConflict
+	}
+
+	if err := tuc.canPost(ctx, userID); err != nil {
+		return dto.DraftThreadResponse{}, err
+	}
+
+	now := time.Now()
+	schedule := req.PublishAt != nil && req.PublishAt.After(now)
+
+	imageURL := ""
+	if img != nil {
+		imageURL, err = tuc.uploadThreadImage(ctx, threadID, img)
+		if err != nil {
+			return dto.DraftThreadResponse{}, err
+		}
+	}
+
+	if schedule {
+		err = tuc.tr.ScheduleThread(ctx, threadID, draft.Status, imageURL, *req.PublishAt)
+	} else {
+		err = tuc.tr.PublishThread(ctx, threadID, draft.Status, imageURL, now)
+	}
+	if err != nil {
+		return dto.DraftThreadResponse{}, err
+	}
+
+	if imageURL != "" && draft.ImageURL != "" {
+		tuc.deleteThreadImage(ctx, draft.ImageURL)
+	}
+
+	if schedule {
+		err = tuc.jobQueue.EnqueueAt(ctx, thread.JobPublishThread, thread.PublishThreadPayload{ThreadID: threadID}, *req.PublishAt)
+		if err != nil {
+			tuc.logger.ErrorContext(ctx, "failed to enqueue thread publication", "err", err, "thread_id", threadID)
+			// without a job the thread would stay scheduled forever
+			if err := tuc.tr.UnscheduleThread(ctx, threadID); err != nil {
+				tuc.logger.ErrorContext(ctx, "failed to unschedule thread", "err", err, "thread_id", threadID)
+			}
+			return dto.DraftThreadResponse{}, utils.ErrInternalServerError
+		}
+	} else {
+		metrics.ThreadsCreated.Inc()
+	}
+
+	res, err := tuc.tr.GetThreadByID(ctx, threadID)
+	if err != nil {
+		return dto.DraftThreadResponse{}, err
+	}
+
+	return helper.DomainThreadToDraftThreadResponse(res), nil
+}
+
+// UnscheduleThread turns a scheduled thread of userID back into a draft,
+// the queued job finds it unscheduled and does nothing
+func (tuc *ThreadUseCaseImpl) UnscheduleThread(ctx context.Context, threadID, userID uint) error {
+	flag, _, err := AuthorizeThreadAccess(ctx, threadID, userID, "", tuc)
+	if err != nil {
+		return err
+	}
+	if !flag {
+		return utils.ErrUnauthorizedAccess
+	}
+
+	return tuc.tr.UnscheduleThread(ctx, threadID)
+}
+
+// PublishScheduledThread publishes a scheduled thread whose time has come
+func (tuc *ThreadUseCaseImpl) PublishScheduledThread(ctx context.Context, threadID uint) error {
+	scheduled, err := tuc.tr.GetThreadByID(ctx, threadID)
+	if errors.Is(err, utils.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// the thread was deleted, unscheduled, published by hand or pushed back
+	// after this job was queued
+	now := time.Now()
+	if scheduled.Status != entity.ThreadStatusScheduled || scheduled.PublishAt == nil || now.Before(*scheduled.PublishAt) {
+		return nil
+	}
+
+	// the author may have lost the right to post since scheduling, the
+	// thread then goes back to their drafts
+	if err := tuc.canPost(ctx, scheduled.UserID); err != nil {
+		if errors.Is(err, utils.ErrInternalServerError) {
+			return err
+		}
+		tuc.logger.WarnContext(ctx, "scheduled thread not published", "thread_id", threadID, "err", err)
+
+		err = tuc.tr.UnscheduleThread(ctx, threadID)
+		if err != nil && !errors.Is(err, utils.ErrNotFound) {
+			return err
+		}
+		return nil
+	}
+
+	err = tuc.tr.PublishThread(ctx, threadID, entity.ThreadStatusScheduled, "", now)
+	if errors.Is(err, utils.ErrConflict) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	metrics.ThreadsCreated.Inc()
+	return nil
+}
@@ -0,0 +1,217 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	jobMocks "macaiki/internal/job/mocks"
+	"macaiki/internal/thread"
+	"macaiki/internal/thread/dto"
+	"macaiki/internal/thread/entity"
+	"macaiki/internal/thread/mocks"
+	userMocks "macaiki/internal/user/mocks"
+	"macaiki/pkg/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func draftOf(status string, publishAt *time.Time) entity.Thread {
+	draft := mockedEntity
+	draft.Status = status
+	draft.PublishAt = publishAt
+	return draft
+}
+
+func TestCreateDraft(t *testing.T) {
+	mockThreadRepo := mocks.NewThreadRepository(t)
+	draft := draftOf(entity.ThreadStatusDraft, nil)
+	mockThreadRepo.On("CreateThread", mock.Anything, entity.Thread{
+		Title:       "Title",
+		Body:        "Body",
+		BodyHTML:    "<p>Body</p>",
+		BodyText:    "Body",
+		UserID:      uint(1),
+		CommunityID: uint(1),
+		Status:      entity.ThreadStatusDraft,
+	}).Return(draft, nil).Once()
+	mockThreadRepo.On("SubscribeThread", mock.Anything, uint(1), uint(1)).Return(nil).Once()
+
+	testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{})
+	res, err := testThreadUseCase.CreateDraft(context.Background(), mockedThreadDTOReq, uint(1))
+
+	assert.NoError(t, err)
+	assert.Equal(t, entity.ThreadStatusDraft, res.Status)
+	assert.Nil(t, res.PublishAt)
+}
+
+func TestGetDrafts(t *testing.T) {
+	mockThreadRepo := mocks.NewThreadRepository(t)
+	publishAt := time.Now().Add(time.Hour)
+	mockThreadRepo.On("GetDraftThreads", mock.Anything, uint(1)).Return([]entity.Thread{
+		draftOf(entity.ThreadStatusScheduled, &publishAt),
+		draftOf(entity.ThreadStatusDraft, nil),
+	}, nil).Once()
+
+	testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{})
+	res, err := testThreadUseCase.GetDrafts(context.Background(), uint(1))
+
+	assert.NoError(t, err)
+	assert.Len(t, res, 2)
+	assert.Equal(t, &publishAt, res[0].PublishAt)
+}
+
+func TestPublishThread(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockThreadRepo := mocks.NewThreadRepository(t)
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(draftOf(entity.ThreadStatusDraft, nil), nil).Once()
+		mockThreadRepo.On("PublishThread", mock.Anything, uint(1), entity.ThreadStatusDraft, "", mock.AnythingOfType("time.Time")).Return(nil).Once()
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(draftOf(entity.ThreadStatusPublished, nil), nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{})
+		res, err := testThreadUseCase.PublishThread(context.Background(), uint(1), uint(1), dto.PublishThreadRequest{}, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.ThreadStatusPublished, res.Status)
+	})
+
+	t.Run("scheduled", func(t *testing.T) {
+		mockThreadRepo := mocks.NewThreadRepository(t)
+		mockJobQueue := jobMocks.NewQueue(t)
+		publishAt := time.Now().Add(time.Hour)
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(draftOf(entity.ThreadStatusDraft, nil), nil).Once()
+		mockThreadRepo.On("ScheduleThread", mock.Anything, uint(1), entity.ThreadStatusDraft, "", publishAt).Return(nil).Once()
+		mockJobQueue.On("EnqueueAt", mock.Anything, thread.JobPublishThread, thread.PublishThreadPayload{ThreadID: 1}, publishAt).Return(nil).Once()
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(draftOf(entity.ThreadStatusScheduled, &publishAt), nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{jobQueue: mockJobQueue})
+		res, err := testThreadUseCase.PublishThread(context.Background(), uint(1), uint(1), dto.PublishThreadRequest{PublishAt: &publishAt}, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.ThreadStatusScheduled, res.Status)
+	})
+
+	t.Run("enqueue-failed", func(t *testing.T) {
+		mockThreadRepo := mocks.NewThreadRepository(t)
+		mockJobQueue := jobMocks.NewQueue(t)
+		publishAt := time.Now().Add(time.Hour)
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(draftOf(entity.ThreadStatusDraft, nil), nil).Once()
+		mockThreadRepo.On("ScheduleThread", mock.Anything, uint(1), entity.ThreadStatusDraft, "", publishAt).Return(nil).Once()
+		mockJobQueue.On("EnqueueAt", mock.Anything, thread.JobPublishThread, mock.Anything, publishAt).Return(errors.New("queue down")).Once()
+		mockThreadRepo.On("UnscheduleThread", mock.Anything, uint(1)).Return(nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{jobQueue: mockJobQueue})
+		_, err := testThreadUseCase.PublishThread(context.Background(), uint(1), uint(1), dto.PublishThreadRequest{PublishAt: &publishAt}, nil)
+
+		assert.ErrorIs(t, err, utils.ErrInternalServerError)
+	})
+
+	t.Run("already-published", func(t *testing.T) {
+		mockThreadRepo := mocks.NewThreadRepository(t)
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(mockedEntity, nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{})
+		_, err := testThreadUseCase.PublishThread(context.Background(), uint(1), uint(1), dto.PublishThreadRequest{}, nil)
+
+		assert.ErrorIs(t, err, utils.ErrConflict)
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		mockThreadRepo := mocks.NewThreadRepository(t)
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(draftOf(entity.ThreadStatusDraft, nil), nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{})
+		_, err := testThreadUseCase.PublishThread(context.Background(), uint(1), uint(2), dto.PublishThreadRequest{}, nil)
+
+		assert.ErrorIs(t, err, utils.ErrUnauthorizedAccess)
+	})
+}
+
+func TestPublishScheduledThread(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockThreadRepo := mocks.NewThreadRepository(t)
+		publishAt := time.Now().Add(-time.Minute)
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(draftOf(entity.ThreadStatusScheduled, &publishAt), nil).Once()
+		mockThreadRepo.On("PublishThread", mock.Anything, uint(1), entity.ThreadStatusScheduled, "", mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{})
+		err := testThreadUseCase.PublishScheduledThread(context.Background(), uint(1))
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("author-cannot-post", func(t *testing.T) {
+		mockThreadRepo := mocks.NewThreadRepository(t)
+		mockPostingPolicy := userMocks.NewPostingPolicy(t)
+		publishAt := time.Now().Add(-time.Minute)
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(draftOf(entity.ThreadStatusScheduled, &publishAt), nil).Once()
+		mockPostingPolicy.On("CanPost", mock.Anything, uint(1)).Return(utils.ErrEmailNotVerified).Once()
+		mockThreadRepo.On("UnscheduleThread", mock.Anything, uint(1)).Return(nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{posting: mockPostingPolicy})
+		err := testThreadUseCase.PublishScheduledThread(context.Background(), uint(1))
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("policy-unavailable", func(t *testing.T) {
+		mockThreadRepo := mocks.NewThreadRepository(t)
+		mockPostingPolicy := userMocks.NewPostingPolicy(t)
+		publishAt := time.Now().Add(-time.Minute)
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(draftOf(entity.ThreadStatusScheduled, &publishAt), nil).Once()
+		mockPostingPolicy.On("CanPost", mock.Anything, uint(1)).Return(utils.ErrInternalServerError).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{posting: mockPostingPolicy})
+		err := testThreadUseCase.PublishScheduledThread(context.Background(), uint(1))
+
+		assert.ErrorIs(t, err, utils.ErrInternalServerError)
+	})
+
+	t.Run("pushed-back", func(t *testing.T) {
+		mockThreadRepo := mocks.NewThreadRepository(t)
+		publishAt := time.Now().Add(time.Hour)
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(draftOf(entity.ThreadStatusScheduled, &publishAt), nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{})
+		err := testThreadUseCase.PublishScheduledThread(context.Background(), uint(1))
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("unscheduled", func(t *testing.T) {
+		mockThreadRepo := mocks.NewThreadRepository(t)
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(draftOf(entity.ThreadStatusDraft, nil), nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{})
+		err := testThreadUseCase.PublishScheduledThread(context.Background(), uint(1))
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("deleted", func(t *testing.T) {
+		mockThreadRepo := mocks.NewThreadRepository(t)
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(entity.Thread{}, utils.ErrNotFound).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{})
+		err := testThreadUseCase.PublishScheduledThread(context.Background(), uint(1))
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestDraftsRejectInteractions(t *testing.T) {
+	mockThreadRepo := mocks.NewThreadRepository(t)
+	mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(draftOf(entity.ThreadStatusDraft, nil), nil).Times(3)
+
+	testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{})
+
+	err := testThreadUseCase.AddThreadComment(context.Background(), dto.CommentRequest{Body: "Nice", UserID: 3, ThreadID: 1})
+	assert.ErrorIs(t, err, utils.ErrNotFound)
+
+	err = testThreadUseCase.StoreSavedThread(context.Background(), dto.SavedThreadRequest{UserID: 3, ThreadID: 1})
+	assert.ErrorIs(t, err, utils.ErrNotFound)
+
+	err = testThreadUseCase.FollowThread(context.Background(), uint(1), uint(3))
+	assert.ErrorIs(t, err, utils.ErrNotFound)
+}
@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	entityNotif "macaiki/internal/notification/entity"
+	"macaiki/internal/thread"
+	"macaiki/pkg/utils"
+)
+
+// subscribe follows a thread for a user who posted in it, a failure is
+// logged but never fails the post
+func (tuc *ThreadUseCaseImpl) subscribe(ctx context.Context, threadID, userID uint) {
+	if err := tuc.tr.SubscribeThread(ctx, threadID, userID); err != nil {
+		tuc.logger.ErrorContext(ctx, "failed to subscribe to thread", "err", err, "thread_id", threadID, "user_id", userID)
+	}
+}
+
+// queueFollowerNotifications hands the notifications of a new comment to
+// the job queue, a thread with many followers never holds up the comment
+func (tuc *ThreadUseCaseImpl) queueFollowerNotifications(ctx context.Context, threadID, commenterID uint) {
+	err := tuc.jobQueue.Enqueue(ctx, thread.JobNotifyFollowers, thread.NotifyFollowersPayload{ThreadID: threadID, CommenterID: commenterID})
+	if err != nil {
+		tuc.logger.ErrorContext(ctx, "failed to enqueue follower notifications", "err", err, "thread_id", threadID)
+	}
+}
+
+// NotifyFollowers tells the followers of a thread about a new comment by
+// commenterID. The author is notified unless they muted the thread, also
+// when they never followed it. Only listing the followers is retried, a
+// notification that fails to queue is logged so a retry never repeats the
+// ones already sent.
+func (tuc *ThreadUseCaseImpl) NotifyFollowers(ctx context.Context, threadID, commenterID uint) error {
+	thread, err := tuc.tr.GetThreadByID(ctx, threadID)
+	if errors.Is(err, utils.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	followers, err := tuc.tr.GetThreadFollowers(ctx, thread.ID)
+	if err != nil {
+		return err
+	}
+
+	muted := map[uint]bool{}
+	recipients := []uint{}
+	for _, val := range followers {
+		if val.Muted {
+			muted[val.UserID] = true
+		} else if val.UserID != thread.UserID {
+			recipients = append(recipients, val.UserID)
+		}
+	}
+
+	if thread.UserID != commenterID && !muted[thread.UserID] {
+		tuc.sendNotification(ctx, entityNotif.Notification{
+			UserID:            thread.UserID,
+			NotificationRefID: thread.ID,
+			NotificationType:  "Comment Thread",
+			IsReaded:          0,
+		})
+	}
+	for _, userID := range recipients {
+		if userID == commenterID {
+			continue
+		}
+		tuc.sendNotification(ctx, entityNotif.Notification{
+			UserID:            userID,
+			NotificationRefID: thread.ID,
+			NotificationType:  "Reply Thread",
+			IsReaded:          0,
+		})
+	}
+
+	return nil
+}
+
+func (tuc *ThreadUseCaseImpl) FollowThread(ctx context.Context, threadID, userID uint) error {
+	if _, err := tuc.getPublishedThread(ctx, threadID); err != nil {
+		return err
+	}
+
+	return tuc.tr.SetThreadFollower(ctx, threadID, userID, false)
+}
+
+func (tuc *ThreadUseCaseImpl) UnfollowThread(ctx context.Context, threadID, userID uint) error {
+	return tuc.tr.DeleteThreadFollower(ctx, threadID, userID)
+}
+
+// MuteThread stops the notifications of a thread for userID, commenting on
+// it does not subscribe them again
+func (tuc *ThreadUseCaseImpl) MuteThread(ctx context.Context, threadID, userID uint) error {
+	if _, err := tuc.getPublishedThread(ctx, threadID); err != nil {
+		return err
+	}
+
+	return tuc.tr.SetThreadFollower(ctx, threadID, userID, true)
+}
+
+// UnmuteThread lifts a mute, userID goes on following the thread
+func (tuc *ThreadUseCaseImpl) UnmuteThread(ctx context.Context, threadID, userID uint) error {
+	follower, err := tuc.tr.GetThreadFollower(ctx, threadID, userID)
+	if err != nil {
+		return err
+	}
+	if !follower.Muted {
+		return utils.ErrNotFound
+	}
+
+	return tuc.tr.SetThreadFollower(ctx, threadID, userID, false)
+}
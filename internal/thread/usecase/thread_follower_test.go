@@ -0,0 +1,170 @@
+package usecase
+
+import (
+	"context"
+	jobMocks "macaiki/internal/job/mocks"
+	"macaiki/internal/notification"
+	entityNotif "macaiki/internal/notification/entity"
+	"macaiki/internal/thread"
+	"macaiki/internal/thread/dto"
+	"macaiki/internal/thread/entity"
+	"macaiki/internal/thread/mocks"
+	"macaiki/pkg/utils"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAddThreadCommentQueuesFollowerNotifications(t *testing.T) {
+	mockThreadRepo := mocks.NewThreadRepository(t)
+	mockJobQueue := jobMocks.NewQueue(t)
+	mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(mockedEntity, nil).Once()
+	mockThreadRepo.On("AddThreadComment", mock.Anything, entity.Comment{Body: "Nice", BodyHTML: "<p>Nice</p>", BodyText: "Nice", UserID: 3, ThreadID: 1}).Return(nil).Once()
+	mockThreadRepo.On("SubscribeThread", mock.Anything, uint(1), uint(3)).Return(nil).Once()
+	mockJobQueue.On("Enqueue", mock.Anything, thread.JobNotifyFollowers, thread.NotifyFollowersPayload{ThreadID: 1, CommenterID: 3}).Return(nil).Once()
+
+	testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{jobQueue: mockJobQueue})
+	err := testThreadUseCase.AddThreadComment(context.Background(), dto.CommentRequest{Body: "Nice", UserID: 3, ThreadID: 1})
+
+	assert.NoError(t, err)
+}
+
+func TestNotifyFollowers(t *testing.T) {
+	// mockedEntity is thread 1 by user 1
+	t.Run("success", func(t *testing.T) {
+		mockThreadRepo := mocks.NewThreadRepository(t)
+		mockJobQueue := jobMocks.NewQueue(t)
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(mockedEntity, nil).Once()
+		mockThreadRepo.On("GetThreadFollowers", mock.Anything, uint(1)).Return([]entity.ThreadFollower{
+			{ThreadID: 1, UserID: 1},
+			{ThreadID: 1, UserID: 2},
+			{ThreadID: 1, UserID: 3},
+			{ThreadID: 1, UserID: 4, Muted: true},
+		}, nil).Once()
+
+		sent := map[uint]string{}
+		mockJobQueue.On("Enqueue", mock.Anything, notification.JobStoreNotification, mock.Anything).Run(func(args mock.Arguments) {
+			n := args.Get(2).(entityNotif.Notification)
+			sent[n.UserID] = n.NotificationType
+		}).Return(nil).Twice()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{jobQueue: mockJobQueue})
+		err := testThreadUseCase.NotifyFollowers(context.Background(), uint(1), uint(3))
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[uint]string{1: "Comment Thread", 2: "Reply Thread"}, sent)
+	})
+
+	t.Run("author-muted", func(t *testing.T) {
+		mockThreadRepo := mocks.NewThreadRepository(t)
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(mockedEntity, nil).Once()
+		mockThreadRepo.On("GetThreadFollowers", mock.Anything, uint(1)).Return([]entity.ThreadFollower{{ThreadID: 1, UserID: 1, Muted: true}}, nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{jobQueue: jobMocks.NewQueue(t)})
+		err := testThreadUseCase.NotifyFollowers(context.Background(), uint(1), uint(3))
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("author-comments", func(t *testing.T) {
+		mockThreadRepo := mocks.NewThreadRepository(t)
+		mockJobQueue := jobMocks.NewQueue(t)
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(mockedEntity, nil).Once()
+		mockThreadRepo.On("GetThreadFollowers", mock.Anything, uint(1)).Return([]entity.ThreadFollower{{ThreadID: 1, UserID: 2}, {ThreadID: 1, UserID: 5}}, nil).Once()
+
+		notified := []uint{}
+		mockJobQueue.On("Enqueue", mock.Anything, notification.JobStoreNotification, mock.Anything).Run(func(args mock.Arguments) {
+			notified = append(notified, args.Get(2).(entityNotif.Notification).UserID)
+		}).Return(nil).Twice()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{jobQueue: mockJobQueue})
+		err := testThreadUseCase.NotifyFollowers(context.Background(), uint(1), uint(1))
+
+		assert.NoError(t, err)
+		sort.Slice(notified, func(i, j int) bool { return notified[i] < notified[j] })
+		assert.Equal(t, []uint{2, 5}, notified)
+	})
+
+	t.Run("followers-unavailable", func(t *testing.T) {
+		mockThreadRepo := mocks.NewThreadRepository(t)
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(mockedEntity, nil).Once()
+		mockThreadRepo.On("GetThreadFollowers", mock.Anything, uint(1)).Return(nil, utils.ErrInternalServerError).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{jobQueue: jobMocks.NewQueue(t)})
+		err := testThreadUseCase.NotifyFollowers(context.Background(), uint(1), uint(3))
+
+		assert.ErrorIs(t, err, utils.ErrInternalServerError)
+	})
+
+	t.Run("thread-deleted", func(t *testing.T) {
+		mockThreadRepo := mocks.NewThreadRepository(t)
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(entity.Thread{}, utils.ErrNotFound).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{})
+		err := testThreadUseCase.NotifyFollowers(context.Background(), uint(1), uint(3))
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestFollowThread(t *testing.T) {
+	mockThreadRepo := mocks.NewThreadRepository(t)
+
+	t.Run("success", func(t *testing.T) {
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(mockedEntity, nil).Once()
+		mockThreadRepo.On("SetThreadFollower", mock.Anything, uint(1), uint(2), false).Return(nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{})
+		err := testThreadUseCase.FollowThread(context.Background(), uint(1), uint(2))
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(entity.Thread{}, utils.ErrNotFound).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{})
+		err := testThreadUseCase.FollowThread(context.Background(), uint(1), uint(2))
+
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+	})
+}
+
+func TestMuteThread(t *testing.T) {
+	mockThreadRepo := mocks.NewThreadRepository(t)
+
+	t.Run("success", func(t *testing.T) {
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(mockedEntity, nil).Once()
+		mockThreadRepo.On("SetThreadFollower", mock.Anything, uint(1), uint(2), true).Return(nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{})
+		err := testThreadUseCase.MuteThread(context.Background(), uint(1), uint(2))
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestUnmuteThread(t *testing.T) {
+	mockThreadRepo := mocks.NewThreadRepository(t)
+
+	t.Run("success", func(t *testing.T) {
+		mockThreadRepo.On("GetThreadFollower", mock.Anything, uint(1), uint(2)).Return(entity.ThreadFollower{ThreadID: 1, UserID: 2, Muted: true}, nil).Once()
+		mockThreadRepo.On("SetThreadFollower", mock.Anything, uint(1), uint(2), false).Return(nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{})
+		err := testThreadUseCase.UnmuteThread(context.Background(), uint(1), uint(2))
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("not-muted", func(t *testing.T) {
+		mockThreadRepo.On("GetThreadFollower", mock.Anything, uint(1), uint(2)).Return(entity.ThreadFollower{ThreadID: 1, UserID: 2}, nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{})
+		err := testThreadUseCase.UnmuteThread(context.Background(), uint(1), uint(2))
+
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+	})
+}
@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"macaiki/internal/job"
+	"macaiki/internal/thread"
+)
+
+// NewPublishThreadHandler handles thread.JobPublishThread jobs
+func NewPublishThreadHandler(threadUsecase thread.ThreadUseCase) job.HandlerFunc {
+	return func(ctx context.Context, payload []byte) error {
+		p := thread.PublishThreadPayload{}
+		if err := json.Unmarshal(payload, &p); err != nil {
+			return err
+		}
+
+		return threadUsecase.PublishScheduledThread(ctx, p.ThreadID)
+	}
+}
+
+// NewUnfurlThreadHandler handles thread.JobUnfurlThread jobs
+func NewUnfurlThreadHandler(threadUsecase thread.ThreadUseCase) job.HandlerFunc {
+	return func(ctx context.Context, payload []byte) error {
+		p := thread.UnfurlThreadPayload{}
+		if err := json.Unmarshal(payload, &p); err != nil {
+			return err
+		}
+
+		return threadUsecase.UnfurlThread(ctx, p.ThreadID)
+	}
+}
+
+// NewNotifyFollowersHandler handles thread.JobNotifyFollowers jobs
+func NewNotifyFollowersHandler(threadUsecase thread.ThreadUseCase) job.HandlerFunc {
+	return func(ctx context.Context, payload []byte) error {
+		p := thread.NotifyFollowersPayload{}
+		if err := json.Unmarshal(payload, &p); err != nil {
+			return err
+		}
+
+		return threadUsecase.NotifyFollowers(ctx, p.ThreadID, p.CommenterID)
+	}
+}
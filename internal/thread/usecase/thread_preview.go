@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"macaiki/internal/thread"
+	"macaiki/internal/thread/entity"
+	"macaiki/pkg/unfurl"
+	"macaiki/pkg/utils"
+)
+
+// queueUnfurl refreshes the link preview in the background when body links
+// somewhere else than preview, a failure is logged but never fails the
+// write that triggered it
+func (tuc *ThreadUseCaseImpl) queueUnfurl(ctx context.Context, threadID uint, body string, preview entity.LinkPreview) {
+	if unfurl.FirstURL(body) == preview.URL {
+		return
+	}
+
+	err := tuc.jobQueue.Enqueue(ctx, thread.JobUnfurlThread, thread.UnfurlThreadPayload{ThreadID: threadID})
+	if err != nil {
+		tuc.logger.ErrorContext(ctx, "failed to enqueue link preview", "err", err, "thread_id", threadID)
+	}
+}
+
+// UnfurlThread stores the preview of the first link in a thread body, a
+// body without links drops the preview. A link that cannot be previewed
+// leaves the thread without one, network errors are left to the job retry.
+func (tuc *ThreadUseCaseImpl) UnfurlThread(ctx context.Context, threadID uint) error {
+	res, err := tuc.tr.GetThreadByID(ctx, threadID)
+	if errors.Is(err, utils.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	link := unfurl.FirstURL(res.Body)
+	if link == res.Preview.URL {
+		return nil
+	}
+
+	preview := entity.LinkPreview{}
+	if link != "" && tuc.previews != nil {
+		fetched, err := tuc.previews.Fetch(ctx, link)
+		if err != nil && !unfurl.Permanent(err) {
+			return err
+		}
+		if err == nil {
+			preview = entity.LinkPreview{
+				URL:         fetched.URL,
+				Title:       fetched.Title,
+				Description: fetched.Description,
+				ImageURL:    fetched.ImageURL,
+				SiteName:    fetched.SiteName,
+			}
+		}
+	}
+
+	return tuc.tr.SetThreadPreview(ctx, threadID, res.Body, preview)
+}
@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	jobMocks "macaiki/internal/job/mocks"
+	"macaiki/internal/thread"
+	"macaiki/internal/thread/entity"
+	"macaiki/internal/thread/mocks"
+	"macaiki/pkg/unfurl"
+	unfurlMocks "macaiki/pkg/unfurl/mocks"
+	"macaiki/pkg/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func linkedThread(body string, preview entity.LinkPreview) entity.Thread {
+	res := mockedEntity
+	res.Body = body
+	res.Preview = preview
+	return res
+}
+
+func TestCreateThreadQueuesPreview(t *testing.T) {
+	mockThreadRepo := mocks.NewThreadRepository(t)
+	mockJobQueue := jobMocks.NewQueue(t)
+	created := linkedThread("read https://example.com/post", entity.LinkPreview{})
+	mockThreadRepo.On("CreateThread", mock.Anything, mock.Anything).Return(created, nil).Once()
+	mockThreadRepo.On("SubscribeThread", mock.Anything, uint(1), uint(1)).Return(nil).Once()
+	mockJobQueue.On("Enqueue", mock.Anything, thread.JobUnfurlThread, thread.UnfurlThreadPayload{ThreadID: 1}).Return(nil).Once()
+
+	testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{jobQueue: mockJobQueue})
+	req := mockedThreadDTOReq
+	req.Body = created.Body
+	_, err := testThreadUseCase.CreateThread(context.Background(), req, uint(1))
+
+	assert.NoError(t, err)
+}
+
+func TestUnfurlThread(t *testing.T) {
+	body := "read https://example.com/post."
+	preview := entity.LinkPreview{
+		URL:         "https://example.com/post",
+		Title:       "Post",
+		Description: "A post",
+		ImageURL:    "https://example.com/post.png",
+		SiteName:    "Example",
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockThreadRepo := mocks.NewThreadRepository(t)
+		mockFetcher := unfurlMocks.NewFetcher(t)
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(linkedThread(body, entity.LinkPreview{}), nil).Once()
+		mockFetcher.On("Fetch", mock.Anything, preview.URL).Return(unfurl.Preview{
+			URL:         preview.URL,
+			Title:       preview.Title,
+			Description: preview.Description,
+			ImageURL:    preview.ImageURL,
+			SiteName:    preview.SiteName,
+		}, nil).Once()
+		mockThreadRepo.On("SetThreadPreview", mock.Anything, uint(1), body, preview).Return(nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{previews: mockFetcher})
+		err := testThreadUseCase.UnfurlThread(context.Background(), uint(1))
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("up-to-date", func(t *testing.T) {
+		mockThreadRepo := mocks.NewThreadRepository(t)
+		mockFetcher := unfurlMocks.NewFetcher(t)
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(linkedThread(body, preview), nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{previews: mockFetcher})
+		err := testThreadUseCase.UnfurlThread(context.Background(), uint(1))
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("link-removed", func(t *testing.T) {
+		mockThreadRepo := mocks.NewThreadRepository(t)
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(linkedThread("no links", preview), nil).Once()
+		mockThreadRepo.On("SetThreadPreview", mock.Anything, uint(1), "no links", entity.LinkPreview{}).Return(nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{previews: unfurlMocks.NewFetcher(t)})
+		err := testThreadUseCase.UnfurlThread(context.Background(), uint(1))
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("no-preview", func(t *testing.T) {
+		mockThreadRepo := mocks.NewThreadRepository(t)
+		mockFetcher := unfurlMocks.NewFetcher(t)
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(linkedThread(body, entity.LinkPreview{}), nil).Once()
+		mockFetcher.On("Fetch", mock.Anything, preview.URL).Return(unfurl.Preview{}, unfurl.ErrBlockedAddress).Once()
+		mockThreadRepo.On("SetThreadPreview", mock.Anything, uint(1), body, entity.LinkPreview{}).Return(nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{previews: mockFetcher})
+		err := testThreadUseCase.UnfurlThread(context.Background(), uint(1))
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("retry", func(t *testing.T) {
+		mockThreadRepo := mocks.NewThreadRepository(t)
+		mockFetcher := unfurlMocks.NewFetcher(t)
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(linkedThread(body, entity.LinkPreview{}), nil).Once()
+		mockFetcher.On("Fetch", mock.Anything, preview.URL).Return(unfurl.Preview{}, errors.New("connection reset")).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{previews: mockFetcher})
+		err := testThreadUseCase.UnfurlThread(context.Background(), uint(1))
+
+		assert.Error(t, err)
+	})
+
+	t.Run("deleted", func(t *testing.T) {
+		mockThreadRepo := mocks.NewThreadRepository(t)
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(entity.Thread{}, utils.ErrNotFound).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{previews: unfurlMocks.NewFetcher(t)})
+		err := testThreadUseCase.UnfurlThread(context.Background(), uint(1))
+
+		assert.NoError(t, err)
+	})
+}
@@ -1,30 +1,47 @@
 package usecase
 
 import (
-	"fmt"
+	"context"
+	"log/slog"
+	"macaiki/internal/job"
 	"macaiki/internal/notification"
 	entityNotif "macaiki/internal/notification/entity"
 	"macaiki/internal/thread"
+	"macaiki/internal/thread/delivery/http/helper"
 	"macaiki/internal/thread/dto"
 	"macaiki/internal/thread/entity"
+	"macaiki/internal/user"
+	"macaiki/pkg/logger"
+	"macaiki/pkg/markdown"
+	"macaiki/pkg/metrics"
+	"macaiki/pkg/unfurl"
 	"macaiki/pkg/utils"
 	"path/filepath"
+	"time"
+	"unicode/utf8"
 
 	cloudstorage "macaiki/pkg/cloud_storage"
 	"mime/multipart"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 )
 
 type ThreadUseCaseImpl struct {
-	tr    thread.ThreadRepository
-	nr    notification.NotificationRepository
-	awsS3 *cloudstorage.S3
+	tr        thread.ThreadRepository
+	nr        notification.NotificationRepository
+	awsS3     *cloudstorage.S3
+	jobQueue  job.Queue
+	posting   user.PostingPolicy
+	ranking   thread.RankingConfig
+	previews  unfurl.Fetcher
+	validator *validator.Validate
+	logger    *slog.Logger
 }
 
-func AuthorizeThreadAccess(threadID uint, userID uint, role string, tuc *ThreadUseCaseImpl) (bool, entity.Thread, error) {
-	thread, err := tuc.tr.GetThreadByID(threadID)
+func AuthorizeThreadAccess(ctx context.Context, threadID uint, userID uint, role string, tuc *ThreadUseCaseImpl) (bool, entity.Thread, error) {
+	thread, err := tuc.tr.GetThreadByID(ctx, threadID)
 	if err != nil {
 		return false, entity.Thread{}, err
 	}
@@ -40,48 +57,96 @@ func AuthorizeThreadAccess(threadID uint, userID uint, role string, tuc *ThreadU
 	return true, thread, nil
 }
 
-func CreateNewThreadUseCase(tr thread.ThreadRepository, nr notification.NotificationRepository, awsS3Instance *cloudstorage.S3) thread.ThreadUseCase {
-	return &ThreadUseCaseImpl{tr: tr, nr: nr, awsS3: awsS3Instance}
+func CreateNewThreadUseCase(tr thread.ThreadRepository, nr notification.NotificationRepository, awsS3Instance *cloudstorage.S3, jobQueue job.Queue, posting user.PostingPolicy, ranking thread.RankingConfig, previews unfurl.Fetcher, validator *validator.Validate, log *slog.Logger) thread.ThreadUseCase {
+	return &ThreadUseCaseImpl{tr: tr, nr: nr, awsS3: awsS3Instance, jobQueue: jobQueue, posting: posting, ranking: ranking, previews: previews, validator: validator, logger: logger.OrDefault(log)}
 }
 
-func (tuc *ThreadUseCaseImpl) GetThreadByID(threadID uint) (dto.ThreadResponse, error) {
+// canPost checks the posting policy, a usecase built without one lets
+// everyone post
+func (tuc *ThreadUseCaseImpl) canPost(ctx context.Context, userID uint) error {
+	if tuc.posting == nil {
+		return nil
+	}
+	return tuc.posting.CanPost(ctx, userID)
+}
+
+// sendNotification hands the notification to the job queue, a failure is
+// logged but never fails the action that triggered it
+func (tuc *ThreadUseCaseImpl) sendNotification(ctx context.Context, notif entityNotif.Notification) {
+	err := tuc.jobQueue.Enqueue(ctx, notification.JobStoreNotification, notif)
+	if err != nil {
+		tuc.logger.ErrorContext(ctx, "failed to enqueue notification", "err", err, "type", notif.NotificationType)
+	}
+}
+
+// GetThreadByID shows a thread to userID, who may be zero. A draft is only
+// shown to its author.
+func (tuc *ThreadUseCaseImpl) GetThreadByID(ctx context.Context, threadID, userID uint) (dto.ThreadResponse, error) {
 	var thread dto.ThreadResponse
-	res, err := tuc.tr.GetThreadByID(threadID)
+	res, err := tuc.tr.GetThreadByID(ctx, threadID)
+	if err != nil {
+		// a missing thread and a hidden draft must look the same
+		return dto.ThreadResponse{}, err
+	}
 
+	if !res.IsPublished() && res.UserID != userID {
+		return dto.ThreadResponse{}, utils.ErrNotFound
+	}
+
+	attachments, err := tuc.tr.GetAttachmentsByThreadID(ctx, threadID)
 	if err != nil {
-		return dto.ThreadResponse{}, utils.ErrInternalServerError
+		return dto.ThreadResponse{}, err
 	}
 
 	thread = dto.ThreadResponse{
 		ID:          res.ID,
 		Title:       res.Title,
 		Body:        res.Body,
+		BodyHTML:    res.BodyHTML,
+		BodyText:    res.BodyText,
 		CommunityID: res.CommunityID,
 		ImageURL:    res.ImageURL,
 		UserID:      res.UserID,
 		CreatedAt:   res.CreatedAt,
 		UpdatedAt:   res.UpdatedAt,
+		Preview:     helper.DomainLinkPreviewToResponse(res.Preview),
+		Attachments: helper.DomainAttachmentToListAttachmentResponse(attachments, 0),
 	}
 
 	return thread, nil
 }
 
-func (tuc *ThreadUseCaseImpl) CreateThread(thread dto.ThreadRequest, userID uint) (dto.ThreadResponse, error) {
+func (tuc *ThreadUseCaseImpl) CreateThread(ctx context.Context, thread dto.ThreadRequest, userID uint) (dto.ThreadResponse, error) {
+	if err := tuc.validator.Struct(thread); err != nil {
+		return dto.ThreadResponse{}, utils.ErrBadParamInput
+	}
+
+	if err := tuc.canPost(ctx, userID); err != nil {
+		return dto.ThreadResponse{}, err
+	}
+
 	threadEntity := entity.Thread{
 		Title:       thread.Title,
 		Body:        thread.Body,
 		UserID:      userID,
 		CommunityID: thread.CommunityID,
 	}
+	threadEntity.BodyHTML, threadEntity.BodyText = markdown.Render(thread.Body)
 
-	res, err := tuc.tr.CreateThread(threadEntity)
+	res, err := tuc.tr.CreateThread(ctx, threadEntity)
 	if err != nil {
 		return dto.ThreadResponse{}, err
 	}
+	tuc.subscribe(ctx, res.ID, userID)
+	tuc.queueUnfurl(ctx, res.ID, res.Body, res.Preview)
+
+	metrics.ThreadsCreated.Inc()
 	return dto.ThreadResponse{
 		ID:          res.ID,
 		Title:       res.Title,
 		Body:        res.Body,
+		BodyHTML:    res.BodyHTML,
+		BodyText:    res.BodyText,
 		CommunityID: res.CommunityID,
 		ImageURL:    res.ImageURL,
 		UserID:      res.UserID,
@@ -90,8 +155,8 @@ func (tuc *ThreadUseCaseImpl) CreateThread(thread dto.ThreadRequest, userID uint
 	}, nil
 }
 
-func (tuc *ThreadUseCaseImpl) SetThreadImage(img *multipart.FileHeader, threadID uint, userID uint) error {
-	flag, thread, err := AuthorizeThreadAccess(threadID, userID, "", tuc)
+func (tuc *ThreadUseCaseImpl) SetThreadImage(ctx context.Context, img *multipart.FileHeader, threadID uint, userID uint) error {
+	flag, thread, err := AuthorizeThreadAccess(ctx, threadID, userID, "", tuc)
 	if err != nil {
 		return err
 	}
@@ -100,30 +165,52 @@ func (tuc *ThreadUseCaseImpl) SetThreadImage(img *multipart.FileHeader, threadID
 		return utils.ErrUnauthorizedAccess
 	}
 
+	imageURL, err := tuc.uploadThreadImage(ctx, threadID, img)
+	if err != nil {
+		return err
+	}
+
+	err = tuc.tr.SetThreadImage(ctx, imageURL, threadID)
+	if err != nil {
+		return err
+	}
+
+	// the old image is only removed once the new one is stored
 	if thread.ImageURL != "" {
-		err = tuc.awsS3.DeleteImage(thread.ImageURL, "thread")
-		if err != nil {
-			fmt.Println(err)
-			return err
-		}
+		tuc.deleteThreadImage(ctx, thread.ImageURL)
 	}
 
+	return nil
+}
+
+// uploadThreadImage stores img under a new name and returns the name to
+// keep on the thread
+func (tuc *ThreadUseCaseImpl) uploadThreadImage(ctx context.Context, threadID uint, img *multipart.FileHeader) (string, error) {
 	uniqueFilename := uuid.New()
-	result, err := tuc.awsS3.UploadImage(uniqueFilename.String(), "thread", img)
+	result, err := tuc.awsS3.UploadImage(ctx, uniqueFilename.String(), "thread", img)
 	if err != nil {
-		fmt.Printf("failed to upload file, %v", err)
-		return err
+		tuc.logger.ErrorContext(ctx, "failed to upload thread image", "err", err, "thread_id", threadID)
+		return "", err
 	}
 
-	fmt.Printf("file uploaded to, %s\n", aws.StringValue(&result.Location))
+	tuc.logger.DebugContext(ctx, "thread image uploaded", "thread_id", threadID, "location", aws.StringValue(&result.Location))
 
-	err = tuc.tr.SetThreadImage(uniqueFilename.String()+filepath.Ext(img.Filename), threadID)
+	return uniqueFilename.String() + filepath.Ext(img.Filename), nil
+}
 
-	return err
+// deleteThreadImage queues the removal of an image that was replaced
+func (tuc *ThreadUseCaseImpl) deleteThreadImage(ctx context.Context, fileName string) {
+	err := tuc.jobQueue.Enqueue(ctx, cloudstorage.JobDeleteImage, cloudstorage.DeleteImagePayload{
+		FileName: fileName,
+		DirName:  "thread",
+	})
+	if err != nil {
+		tuc.logger.ErrorContext(ctx, "failed to enqueue image deletion", "err", err, "file", fileName)
+	}
 }
 
-func (tuc *ThreadUseCaseImpl) DeleteThread(threadID uint, userID uint, role string) error {
-	flag, _, err := AuthorizeThreadAccess(threadID, userID, role, tuc)
+func (tuc *ThreadUseCaseImpl) DeleteThread(ctx context.Context, threadID uint, userID uint, role string) error {
+	flag, _, err := AuthorizeThreadAccess(ctx, threadID, userID, role, tuc)
 	if err != nil {
 		return err
 	}
@@ -132,12 +219,16 @@ func (tuc *ThreadUseCaseImpl) DeleteThread(threadID uint, userID uint, role stri
 		return utils.ErrUnauthorizedAccess
 	}
 
-	err = tuc.tr.DeleteThread(threadID)
+	err = tuc.tr.DeleteThread(ctx, threadID)
 	return err
 }
 
-func (tuc *ThreadUseCaseImpl) UpdateThread(thread dto.ThreadRequest, threadID uint, userID uint) (dto.ThreadResponse, error) {
-	flag, _, err := AuthorizeThreadAccess(threadID, userID, "", tuc)
+func (tuc *ThreadUseCaseImpl) UpdateThread(ctx context.Context, thread dto.ThreadRequest, threadID uint, userID uint) (dto.ThreadResponse, error) {
+	if err := tuc.validator.Struct(thread); err != nil {
+		return dto.ThreadResponse{}, utils.ErrBadParamInput
+	}
+
+	flag, previous, err := AuthorizeThreadAccess(ctx, threadID, userID, "", tuc)
 	if err != nil {
 		return dto.ThreadResponse{}, err
 	}
@@ -151,8 +242,11 @@ func (tuc *ThreadUseCaseImpl) UpdateThread(thread dto.ThreadRequest, threadID ui
 		Body:        thread.Body,
 		CommunityID: thread.CommunityID,
 	}
+	if thread.Body != "" {
+		threadEntity.BodyHTML, threadEntity.BodyText = markdown.Render(thread.Body)
+	}
 
-	err = tuc.tr.UpdateThread(threadID, threadEntity)
+	err = tuc.tr.UpdateThread(ctx, threadID, threadEntity)
 	if err != nil {
 		if err.Error() == "no affected rows" {
 			return dto.ThreadResponse{}, utils.ErrBadParamInput
@@ -160,7 +254,12 @@ func (tuc *ThreadUseCaseImpl) UpdateThread(thread dto.ThreadRequest, threadID ui
 		return dto.ThreadResponse{}, utils.ErrInternalServerError
 	}
 
-	res, err := tuc.tr.GetThreadByID(threadID)
+	// an empty body is left unchanged by the update
+	if thread.Body != "" {
+		tuc.queueUnfurl(ctx, threadID, thread.Body, previous.Preview)
+	}
+
+	res, err := tuc.tr.GetThreadByID(ctx, threadID)
 
 	if err != nil {
 		return dto.ThreadResponse{}, utils.ErrInternalServerError
@@ -170,308 +269,299 @@ func (tuc *ThreadUseCaseImpl) UpdateThread(thread dto.ThreadRequest, threadID ui
 		ID:          res.ID,
 		Title:       res.Title,
 		Body:        res.Body,
+		BodyHTML:    res.BodyHTML,
+		BodyText:    res.BodyText,
 		CommunityID: res.CommunityID,
 		ImageURL:    res.ImageURL,
 		UserID:      res.UserID,
 		CreatedAt:   res.CreatedAt,
 		UpdatedAt:   res.UpdatedAt,
+		Preview:     helper.DomainLinkPreviewToResponse(res.Preview),
 	}
 
 	return threadResponse, err
 }
 
-func (tuc *ThreadUseCaseImpl) UpvoteThread(threadID uint, userID uint) error {
-	_, err := tuc.tr.GetThreadByID(threadID)
+// VoteThread sets the vote userID holds on a thread to the requested value,
+// the owner is notified when the thread gains a new upvote
+func (tuc *ThreadUseCaseImpl) VoteThread(ctx context.Context, threadID, userID uint, vote dto.ThreadVoteRequest) (dto.ThreadVoteResponse, error) {
+	if vote.Value == nil || *vote.Value < entity.VoteDown || *vote.Value > entity.VoteUp {
+		return dto.ThreadVoteResponse{}, utils.ErrBadParamInput
+	}
 
+	thread, previous, err := tuc.tr.SetThreadVote(ctx, threadID, userID, *vote.Value)
 	if err != nil {
-		return err
+		return dto.ThreadVoteResponse{}, err
 	}
 
-	downvote, err := tuc.tr.GetThreadDownvotes(threadID, userID)
+	if *vote.Value == entity.VoteUp && previous != entity.VoteUp && thread.UserID != userID {
+		tuc.sendNotification(ctx, entityNotif.Notification{
+			UserID:            thread.UserID,
+			NotificationRefID: threadID,
+			NotificationType:  "Upvote Thread",
+			IsReaded:          0,
+		})
+	}
+
+	return helper.DomainThreadToThreadVoteResponse(thread, *vote.Value), nil
+}
 
+func (tuc *ThreadUseCaseImpl) UpvoteThread(ctx context.Context, threadID uint, userID uint) error {
+	value := entity.VoteUp
+	_, err := tuc.VoteThread(ctx, threadID, userID, dto.ThreadVoteRequest{Value: &value})
+
+	return err
+}
+
+// GetTrendingThreads ranks every thread by its hot score, a limit of -1
+// returns them all
+func (tuc *ThreadUseCaseImpl) GetTrendingThreads(ctx context.Context, userID uint, limit int) ([]dto.DetailedThreadResponse, error) {
+	res, err := tuc.tr.GetRankedThreads(ctx, userID, 0, "", limit)
 	if err != nil {
-		if err != utils.ErrNotFound {
-			return err
-		}
+		return []dto.DetailedThreadResponse{}, utils.ErrInternalServerError
 	}
 
-	if downvote.ID != 0 {
-		err := tuc.tr.UndoDownvoteThread(threadID, userID)
+	return helper.DomainThreadToListDetailedThreadResponse(res), nil
+}
 
-		if err != nil {
-			return err
-		}
+// GetCommunityTrendingThreads ranks the threads of a single community by
+// their hot score
+func (tuc *ThreadUseCaseImpl) GetCommunityTrendingThreads(ctx context.Context, userID, communityID uint, limit int) ([]dto.DetailedThreadResponse, error) {
+	res, err := tuc.tr.GetRankedThreads(ctx, userID, communityID, "", limit)
+	if err != nil {
+		return []dto.DetailedThreadResponse{}, utils.ErrInternalServerError
 	}
 
-	threadUpvote := entity.ThreadUpvote{
-		ThreadID: threadID,
-		UserID:   userID,
-	}
-	err = tuc.tr.UpvoteThread(threadUpvote)
-	_ = tuc.nr.StoreNotification(entityNotif.Notification{
-		UserID:            userID,
-		NotificationRefID: threadID,
-		NotificationType:  "Upvote Thread",
-		IsReaded:          0,
-	})
-	return err
+	return helper.DomainThreadToListDetailedThreadResponse(res), nil
 }
 
-func (tuc *ThreadUseCaseImpl) GetTrendingThreads(userID uint, limit int) ([]dto.DetailedThreadResponse, error) {
-	var threads []dto.DetailedThreadResponse
-	var res []entity.ThreadWithDetails
-	var err error
-	if limit != -1 {
-		res, err = tuc.tr.GetTrendingThreadsWithLimit(userID, limit)
-	} else {
-		res, err = tuc.tr.GetTrendingThreads(userID)
+// GetTrendingThreadsByTimeframe ranks threads by their activity within the
+// last day, week or month, or over all time
+func (tuc *ThreadUseCaseImpl) GetTrendingThreadsByTimeframe(ctx context.Context, userID uint, timeframe string, limit int) ([]dto.DetailedThreadResponse, error) {
+	switch timeframe {
+	case thread.TimeframeDay, thread.TimeframeWeek, thread.TimeframeMonth, thread.TimeframeAll:
+	default:
+		return []dto.DetailedThreadResponse{}, utils.ErrBadParamInput
 	}
 
+	res, err := tuc.tr.GetRankedThreads(ctx, userID, 0, timeframe, limit)
 	if err != nil {
 		return []dto.DetailedThreadResponse{}, utils.ErrInternalServerError
 	}
 
-	for _, thread := range res {
-		threads = append(threads, dto.DetailedThreadResponse{
-			ID:                    thread.Thread.ID,
-			Title:                 thread.Title,
-			Body:                  thread.Body,
-			CommunityID:           thread.CommunityID,
-			ImageURL:              thread.ImageURL,
-			UserID:                thread.UserID,
-			UserName:              thread.User.Name,
-			UserProfession:        thread.User.Profession,
-			UserProfilePictureURL: thread.User.ProfileImageUrl,
-			CreatedAt:             thread.Thread.CreatedAt,
-			UpdatedAt:             thread.Thread.UpdatedAt,
-			UpvotesCount:          thread.UpvotesCount,
-			IsUpvoted:             thread.IsUpvoted,
-			IsFollowed:            thread.IsFollowed,
-			IsDownVoted:           thread.IsDownvoted,
-		})
+	return helper.DomainThreadToListDetailedThreadResponse(res), nil
+}
+
+// RefreshThreadScores recomputes the trending scores of every thread
+func (tuc *ThreadUseCaseImpl) RefreshThreadScores(ctx context.Context) error {
+	now := time.Now()
+	activity, err := tuc.tr.GetThreadActivity(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	scores := make([]entity.ThreadScore, 0, len(activity))
+	for _, a := range activity {
+		scores = append(scores, tuc.ranking.Score(a, now))
 	}
 
-	return threads, nil
+	return tuc.tr.StoreThreadScores(ctx, scores, now)
 }
 
-func (tuc *ThreadUseCaseImpl) GetThreadsFromFollowedCommunity(userID uint) ([]dto.DetailedThreadResponse, error) {
-	var threads []dto.DetailedThreadResponse
-	res, err := tuc.tr.GetThreadsFromFollowedCommunity(userID)
+func (tuc *ThreadUseCaseImpl) GetThreadsFromFollowedCommunity(ctx context.Context, userID uint) ([]dto.DetailedThreadResponse, error) {
+	res, err := tuc.tr.GetThreadsFromFollowedCommunity(ctx, userID)
 
 	if err != nil {
 		return []dto.DetailedThreadResponse{}, utils.ErrInternalServerError
 	}
 
-	for _, thread := range res {
-		threads = append(threads, dto.DetailedThreadResponse{
-			ID:                    thread.Thread.ID,
-			Title:                 thread.Title,
-			Body:                  thread.Body,
-			CommunityID:           thread.CommunityID,
-			ImageURL:              thread.ImageURL,
-			UserID:                thread.UserID,
-			UserName:              thread.User.Name,
-			UserProfession:        thread.User.Profession,
-			UserProfilePictureURL: thread.User.ProfileImageUrl,
-			CreatedAt:             thread.Thread.CreatedAt,
-			UpdatedAt:             thread.Thread.UpdatedAt,
-			UpvotesCount:          thread.UpvotesCount,
-			IsUpvoted:             thread.IsUpvoted,
-			IsFollowed:            thread.IsFollowed,
-			IsDownVoted:           thread.IsDownvoted,
-		})
-	}
-
-	return threads, nil
+	return helper.DomainThreadToListDetailedThreadResponse(res), nil
 }
 
-func (tuc *ThreadUseCaseImpl) GetThreadsFromFollowedUsers(userID uint) ([]dto.DetailedThreadResponse, error) {
-	var threads []dto.DetailedThreadResponse
-	res, err := tuc.tr.GetThreadsFromFollowedUsers(userID)
+func (tuc *ThreadUseCaseImpl) GetThreadsFromFollowedUsers(ctx context.Context, userID uint) ([]dto.DetailedThreadResponse, error) {
+	res, err := tuc.tr.GetThreadsFromFollowedUsers(ctx, userID)
 
 	if err != nil {
 		return []dto.DetailedThreadResponse{}, utils.ErrInternalServerError
 	}
 
-	for _, thread := range res {
-		threads = append(threads, dto.DetailedThreadResponse{
-			ID:                    thread.Thread.ID,
-			Title:                 thread.Title,
-			Body:                  thread.Body,
-			CommunityID:           thread.CommunityID,
-			ImageURL:              thread.ImageURL,
-			UserID:                thread.UserID,
-			UserName:              thread.User.Name,
-			UserProfession:        thread.User.Profession,
-			UserProfilePictureURL: thread.User.ProfileImageUrl,
-			CreatedAt:             thread.Thread.CreatedAt,
-			UpdatedAt:             thread.Thread.UpdatedAt,
-			UpvotesCount:          thread.UpvotesCount,
-			IsUpvoted:             thread.IsUpvoted,
-			IsFollowed:            thread.IsFollowed,
-			IsDownVoted:           thread.IsDownvoted,
-		})
+	return helper.DomainThreadToListDetailedThreadResponse(res), nil
+}
+
+func (tuc *ThreadUseCaseImpl) AddThreadComment(ctx context.Context, comment dto.CommentRequest) error {
+	if err := tuc.validator.Struct(comment); err != nil {
+		return utils.ErrBadParamInput
 	}
 
-	return threads, nil
-}
+	if err := tuc.canPost(ctx, comment.UserID); err != nil {
+		return err
+	}
 
-func (tuc *ThreadUseCaseImpl) AddThreadComment(comment dto.CommentRequest) error {
-	err := tuc.tr.AddThreadComment(entity.Comment{
+	thread, err := tuc.getPublishedThread(ctx, comment.ThreadID)
+	if err != nil {
+		return err
+	}
+
+	commentEntity := entity.Comment{
 		Body:      comment.Body,
 		UserID:    comment.UserID,
 		ThreadID:  comment.ThreadID,
 		CommentID: comment.CommentID,
-	})
+	}
+	commentEntity.BodyHTML, commentEntity.BodyText = markdown.Render(comment.Body)
 
-	thread, _ := tuc.tr.GetThreadByID(uint(comment.ThreadID))
+	err = tuc.tr.AddThreadComment(ctx, commentEntity)
+	if err != nil {
+		return err
+	}
 
-	_ = tuc.nr.StoreNotification(entityNotif.Notification{
-		UserID:            thread.UserID,
-		NotificationRefID: thread.ID,
-		NotificationType:  "Comment Thread",
-		IsReaded:          0,
-	})
+	tuc.subscribe(ctx, comment.ThreadID, comment.UserID)
+	tuc.queueFollowerNotifications(ctx, thread.ID, comment.UserID)
 
-	return err
+	return nil
 }
 
-func (tuc *ThreadUseCaseImpl) GetCommentsByThreadID(threadID uint) ([]dto.CommentResponse, error) {
-	var commentsResp []dto.CommentResponse
-
-	comments, err := tuc.tr.GetCommentsByThreadID(threadID)
+// GetCommentsByThreadID lists the comments of a thread in the given sort
+// mode, best when empty. userID is the reader and may be zero.
+func (tuc *ThreadUseCaseImpl) GetCommentsByThreadID(ctx context.Context, threadID, userID uint, sort string) ([]dto.CommentResponse, error) {
+	if sort == "" {
+		sort = dto.CommentSortBest
+	}
+	less, ok := commentSorts[sort]
+	if !ok {
+		return []dto.CommentResponse{}, utils.ErrBadParamInput
+	}
 
+	comments, err := tuc.tr.GetCommentsByThreadID(ctx, threadID, userID)
 	if err != nil {
 		return []dto.CommentResponse{}, err
 	}
 
+	sortComments(comments, less)
+
+	attachments := []entity.Attachment{}
+	if len(comments) > 0 {
+		attachments, err = tuc.tr.GetAttachmentsByThreadID(ctx, threadID)
+		if err != nil {
+			return []dto.CommentResponse{}, err
+		}
+	}
+
+	commentsResp := []dto.CommentResponse{}
 	for _, comment := range comments {
-		commentsResp = append(commentsResp, dto.CommentResponse{
-			ID:                    comment.Comment.ID,
-			Body:                  comment.Body,
-			ThreadID:              comment.ThreadID,
-			UserID:                comment.UserID,
-			Username:              comment.User.Name,
-			UserProfilePictureURL: comment.User.ProfileImageUrl,
-			CreatedAt:             comment.Comment.CreatedAt,
-			LikesCount:            comment.LikesCount,
-		})
+		commentResp := helper.DomainCommentToCommentResponse(comment, userID)
+		commentResp.Attachments = helper.DomainAttachmentToListAttachmentResponse(attachments, comment.Comment.ID)
+		commentsResp = append(commentsResp, commentResp)
 	}
 
 	return commentsResp, nil
 }
 
-func (tuc *ThreadUseCaseImpl) GetThreads(keyword string, userID uint) ([]dto.DetailedThreadResponse, error) {
-	var threads []dto.DetailedThreadResponse
-	res, err := tuc.tr.GetThreads(keyword, userID)
+func (tuc *ThreadUseCaseImpl) GetThreads(ctx context.Context, keyword string, userID uint) ([]dto.DetailedThreadResponse, error) {
+	res, err := tuc.tr.GetThreads(ctx, keyword, userID)
 
 	if err != nil {
 		return []dto.DetailedThreadResponse{}, utils.ErrInternalServerError
 	}
 
-	for _, thread := range res {
-		threads = append(threads, dto.DetailedThreadResponse{
-			ID:                    thread.Thread.ID,
-			Title:                 thread.Title,
-			Body:                  thread.Body,
-			CommunityID:           thread.CommunityID,
-			ImageURL:              thread.ImageURL,
-			UserID:                thread.UserID,
-			UserName:              thread.User.Name,
-			UserProfession:        thread.User.Profession,
-			UserProfilePictureURL: thread.User.ProfileImageUrl,
-			CreatedAt:             thread.Thread.CreatedAt,
-			UpdatedAt:             thread.Thread.UpdatedAt,
-			UpvotesCount:          thread.UpvotesCount,
-			IsUpvoted:             thread.IsUpvoted,
-			IsFollowed:            thread.IsFollowed,
-			IsDownVoted:           thread.IsDownvoted,
-		})
+	return helper.DomainThreadToListDetailedThreadResponse(res), nil
+}
+
+// VoteComment sets the vote userID holds on a comment of the thread to the
+// requested value
+func (tuc *ThreadUseCaseImpl) VoteComment(ctx context.Context, threadID, commentID, userID uint, vote dto.CommentVoteRequest) (dto.CommentVoteResponse, error) {
+	if vote.Value == nil || *vote.Value < entity.VoteDown || *vote.Value > entity.VoteUp {
+		return dto.CommentVoteResponse{}, utils.ErrBadParamInput
 	}
 
-	return threads, nil
-}
+	comment, err := tuc.tr.GetCommentByID(ctx, commentID)
+	if err != nil {
+		return dto.CommentVoteResponse{}, err
+	}
 
-func (tuc *ThreadUseCaseImpl) LikeComment(commentID, userID uint) error {
-	_, err := tuc.tr.GetCommentByID(commentID)
+	if threadID != 0 && comment.ThreadID != threadID {
+		return dto.CommentVoteResponse{}, utils.ErrNotFound
+	}
 
+	comment, _, err = tuc.tr.SetCommentVote(ctx, commentID, userID, *vote.Value)
 	if err != nil {
-		return err
+		return dto.CommentVoteResponse{}, err
 	}
 
-	err = tuc.tr.LikeComment(entity.CommentLikes{
-		UserID:    userID,
-		CommentID: commentID,
-	})
+	return helper.DomainCommentToCommentVoteResponse(comment, *vote.Value), nil
+}
+
+// LikeComment upvotes a comment, likes predate comment votes
+func (tuc *ThreadUseCaseImpl) LikeComment(ctx context.Context, commentID, userID uint) error {
+	value := entity.VoteUp
+	_, err := tuc.VoteComment(ctx, 0, commentID, userID, dto.CommentVoteRequest{Value: &value})
 
 	return err
 }
 
-func (tuc *ThreadUseCaseImpl) DownvoteThread(threadID uint, userID uint) error {
-	_, err := tuc.tr.GetThreadByID(threadID)
+func (tuc *ThreadUseCaseImpl) DownvoteThread(ctx context.Context, threadID uint, userID uint) error {
+	value := entity.VoteDown
+	_, err := tuc.VoteThread(ctx, threadID, userID, dto.ThreadVoteRequest{Value: &value})
 
-	if err != nil {
-		return err
-	}
-
-	upvote, err := tuc.tr.GetThreadUpvotes(threadID, userID)
+	return err
+}
 
-	if err != nil {
-		if err != utils.ErrNotFound {
-			return err
-		}
-	}
+func (tuc *ThreadUseCaseImpl) UndoDownvoteThread(ctx context.Context, threadID, userID uint) error {
+	return tuc.undoVote(ctx, threadID, userID, entity.VoteDown)
+}
 
-	if upvote.ID != 0 {
-		err := tuc.tr.UndoUpvoteThread(threadID, userID)
+func (tuc *ThreadUseCaseImpl) UndoUpvoteThread(ctx context.Context, threadID, userID uint) error {
+	return tuc.undoVote(ctx, threadID, userID, entity.VoteUp)
+}
 
-		if err != nil {
-			return err
-		}
+// undoVote clears the vote of userID on a thread, but only when it is the
+// kind of vote being undone
+func (tuc *ThreadUseCaseImpl) undoVote(ctx context.Context, threadID, userID uint, value int) error {
+	vote, err := tuc.tr.GetThreadVote(ctx, threadID, userID)
+	if err != nil {
+		return err
 	}
 
-	threadDownvote := entity.ThreadDownvote{
-		ThreadID: threadID,
-		UserID:   userID,
+	if vote.Value != value {
+		return utils.ErrNotFound
 	}
-	err = tuc.tr.DownvoteThread(threadDownvote)
 
-	return err
-}
-
-func (tuc *ThreadUseCaseImpl) UndoDownvoteThread(threadID, userID uint) error {
-	err := tuc.tr.UndoDownvoteThread(threadID, userID)
+	_, _, err = tuc.tr.SetThreadVote(ctx, threadID, userID, entity.VoteNone)
 
 	return err
 }
 
-func (tuc *ThreadUseCaseImpl) UndoUpvoteThread(threadID, userID uint) error {
-	err := tuc.tr.UndoUpvoteThread(threadID, userID)
+// UnlikeComment takes back an upvote on a comment
+func (tuc *ThreadUseCaseImpl) UnlikeComment(ctx context.Context, commentID, userID uint) error {
+	vote, err := tuc.tr.GetCommentVote(ctx, commentID, userID)
+	if err != nil {
+		return err
+	}
 
-	return err
-}
+	if vote.Value != entity.VoteUp {
+		return utils.ErrNotFound
+	}
 
-func (tuc *ThreadUseCaseImpl) UnlikeComment(commentID, userID uint) error {
-	err := tuc.tr.UnlikeComment(commentID, userID)
+	_, _, err = tuc.tr.SetCommentVote(ctx, commentID, userID, entity.VoteNone)
 
 	return err
 }
 
-func (tuc *ThreadUseCaseImpl) DeleteComment(commentID uint, threadID uint, userID uint, role string) error {
-	thread, err := tuc.tr.GetThreadByID(threadID)
+func (tuc *ThreadUseCaseImpl) DeleteComment(ctx context.Context, commentID uint, threadID uint, userID uint, role string) error {
+	thread, err := tuc.tr.GetThreadByID(ctx, threadID)
 
 	if err != nil {
 		return err
 	}
 
-	comment, err := tuc.tr.GetCommentByID(commentID)
+	comment, err := tuc.tr.GetCommentByID(ctx, commentID)
 
 	if err != nil {
 		return err
 	}
 
 	if thread.UserID == userID || role == "Admin" || comment.UserID == userID {
-		err := tuc.tr.DeleteComment(commentID)
+		err := tuc.tr.DeleteComment(ctx, commentID)
 
 		if err != nil {
 			return err
@@ -483,67 +573,88 @@ func (tuc *ThreadUseCaseImpl) DeleteComment(commentID uint, threadID uint, userI
 	return utils.ErrUnauthorizedAccess
 }
 
-func (tuc *ThreadUseCaseImpl) CreateThreadReport(threadReport dto.ThreadReportRequest) error {
-	err := tuc.tr.CreateThreadReport(entity.ThreadReport{
+func (tuc *ThreadUseCaseImpl) CreateThreadReport(ctx context.Context, threadReport dto.ThreadReportRequest) error {
+	err := tuc.tr.CreateThreadReport(ctx, entity.ThreadReport{
 		ThreadID:         threadReport.ThreadID,
 		UserID:           threadReport.UserID,
 		ReportCategoryID: threadReport.ReportCategoryID,
 	})
+	if err != nil {
+		return err
+	}
 
-	return err
+	metrics.ReportsFiled.WithLabelValues(metrics.ReportThread).Inc()
+	return nil
 }
 
-func (tuc *ThreadUseCaseImpl) CreateCommentReport(commentReport dto.CommentReportRequest) error {
-	err := tuc.tr.CreateCommentReport(entity.CommentReport{
+func (tuc *ThreadUseCaseImpl) CreateCommentReport(ctx context.Context, commentReport dto.CommentReportRequest) error {
+	err := tuc.tr.CreateCommentReport(ctx, entity.CommentReport{
 		CommentID:        commentReport.CommentID,
 		UserID:           commentReport.UserID,
 		ReportCategoryID: commentReport.ReportCategoryID,
 	})
+	if err != nil {
+		return err
+	}
 
-	return err
+	metrics.ReportsFiled.WithLabelValues(metrics.ReportComment).Inc()
+	return nil
 }
 
-func (tuc *ThreadUseCaseImpl) StoreSavedThread(savedThread dto.SavedThreadRequest) error {
-	_, err := tuc.tr.GetThreadByID(savedThread.ThreadID)
+func (tuc *ThreadUseCaseImpl) StoreSavedThread(ctx context.Context, savedThread dto.SavedThreadRequest) error {
+	if utf8.RuneCountInString(savedThread.Note) > SAVED_NOTE_MAX_LENGTH {
+		return utils.ErrBadParamInput
+	}
+
+	_, err := tuc.getPublishedThread(ctx, savedThread.ThreadID)
+
+	if err != nil {
+		return err
+	}
 
+	collectionID, err := tuc.savedCollectionID(ctx, savedThread.UserID, savedThread.CollectionID)
 	if err != nil {
 		return err
 	}
-	err = tuc.tr.StoreSavedThread(entity.SavedThread{
-		UserID:   savedThread.UserID,
-		ThreadID: savedThread.ThreadID,
+
+	err = tuc.tr.StoreSavedThread(ctx, entity.SavedThread{
+		UserID:       savedThread.UserID,
+		ThreadID:     savedThread.ThreadID,
+		CollectionID: collectionID,
+		Note:         savedThread.Note,
 	})
 
 	return err
 }
 
-func (tuc *ThreadUseCaseImpl) GetSavedThread(userID uint) ([]dto.DetailedThreadResponse, error) {
-	var threads []dto.DetailedThreadResponse
-	res, err := tuc.tr.GetSavedThread(userID)
+func (tuc *ThreadUseCaseImpl) GetSavedThread(ctx context.Context, userID uint) ([]dto.DetailedThreadResponse, error) {
+	res, err := tuc.tr.GetSavedThread(ctx, userID)
 
 	if err != nil {
 		return []dto.DetailedThreadResponse{}, err
 	}
 
-	for _, thread := range res {
-		threads = append(threads, dto.DetailedThreadResponse{
-			ID:                    thread.Thread.ID,
-			Title:                 thread.Title,
-			Body:                  thread.Body,
-			CommunityID:           thread.CommunityID,
-			ImageURL:              thread.ImageURL,
-			UserID:                thread.UserID,
-			UserName:              thread.User.Name,
-			UserProfession:        thread.User.Profession,
-			UserProfilePictureURL: thread.User.ProfileImageUrl,
-			CreatedAt:             thread.Thread.CreatedAt,
-			UpdatedAt:             thread.Thread.UpdatedAt,
-			UpvotesCount:          thread.UpvotesCount,
-			IsUpvoted:             thread.IsUpvoted,
-			IsFollowed:            thread.IsFollowed,
-			IsDownVoted:           thread.IsDownvoted,
-		})
+	return helper.DomainThreadToListDetailedThreadResponse(res), nil
+}
+
+// HideThread keeps threadID out of the feeds of userID
+func (tuc *ThreadUseCaseImpl) HideThread(ctx context.Context, threadID, userID uint) error {
+	if _, err := tuc.getPublishedThread(ctx, threadID); err != nil {
+		return err
+	}
+
+	return tuc.tr.HideThread(ctx, userID, threadID)
+}
+
+func (tuc *ThreadUseCaseImpl) UnhideThread(ctx context.Context, threadID, userID uint) error {
+	return tuc.tr.UnhideThread(ctx, userID, threadID)
+}
+
+func (tuc *ThreadUseCaseImpl) GetHiddenThreads(ctx context.Context, userID uint) ([]dto.DetailedThreadResponse, error) {
+	res, err := tuc.tr.GetHiddenThreads(ctx, userID)
+	if err != nil {
+		return []dto.DetailedThreadResponse{}, err
 	}
 
-	return threads, nil
+	return helper.DomainThreadToListDetailedThreadResponse(res), nil
 }
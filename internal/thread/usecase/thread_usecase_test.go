@@ -1,20 +1,49 @@
 package usecase
 
 import (
+	"context"
+	"macaiki/internal/job"
+	jobMocks "macaiki/internal/job/mocks"
+	"macaiki/internal/notification"
+	entityNotif "macaiki/internal/notification/entity"
 	entityMocks "macaiki/internal/notification/mocks"
+	"macaiki/internal/thread"
 	"macaiki/internal/thread/dto"
 	"macaiki/internal/thread/entity"
 	"macaiki/internal/thread/mocks"
+	"macaiki/internal/user"
 	userEntity "macaiki/internal/user/entity"
+	userMocks "macaiki/internal/user/mocks"
+	"macaiki/pkg/unfurl"
 	"macaiki/pkg/utils"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/go-playground/validator/v10"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"gorm.io/gorm"
 )
 
+// testDeps holds the dependencies a test wires into the usecase, the ones
+// left out are nil
+type testDeps struct {
+	nr       notification.NotificationRepository
+	jobQueue job.Queue
+	posting  user.PostingPolicy
+	previews unfurl.Fetcher
+}
+
+// newTestThreadUseCase builds the usecase under test with the default
+// ranking and a real validator
+func newTestThreadUseCase(tr thread.ThreadRepository, deps testDeps) thread.ThreadUseCase {
+	return CreateNewThreadUseCase(tr, deps.nr, nil, deps.jobQueue, deps.posting, thread.DefaultRankingConfig(), deps.previews, v, nil)
+}
+
 var (
+	v = validator.New()
+
 	mockedEntity = entity.Thread{
 		Model: gorm.Model{
 			ID:        1,
@@ -49,6 +78,8 @@ var (
 	mockedEntityMappedFromDTO = entity.Thread{
 		Title:       "Title",
 		Body:        "Body",
+		BodyHTML:    "<p>Body</p>",
+		BodyText:    "Body",
 		CommunityID: uint(1),
 	}
 
@@ -81,8 +112,7 @@ var (
 				Profession:         "sdfasf",
 				Role:               "User",
 			},
-			LikesCount: 1,
-			IsLiked:    1,
+			UserVote: 1,
 		},
 	}
 
@@ -97,11 +127,6 @@ var (
 	// 	CommunityID: uint(1),
 	// }
 
-	mockedLikeCommentEntity = entity.CommentLikes{
-		UserID:    uint(1),
-		CommentID: uint(1),
-	}
-
 	mockedCommentReportDTO = dto.CommentReportRequest{
 		CommentID:        1,
 		UserID:           1,
@@ -124,11 +149,6 @@ var (
 		ThreadID: 1,
 	}
 
-	mockedCommentLikesEntity = entity.CommentLikes{
-		CommentID: 1,
-		UserID:    1,
-	}
-
 	mockedDetailedThread = []entity.ThreadWithDetails{{Thread: entity.Thread{
 		Model: gorm.Model{
 			ID:        1,
@@ -176,20 +196,20 @@ func TestCreateThreadReport(t *testing.T) {
 	}
 
 	t.Run("success", func(t *testing.T) {
-		mockThreadRepo.On("CreateThreadReport", mockThreadReportEntity).Return(nil).Once()
+		mockThreadRepo.On("CreateThreadReport", mock.Anything, mockThreadReportEntity).Return(nil).Once()
 
-		testThreadUseCase := CreateNewThreadUseCase(mockThreadRepo, mockNotifRepo, nil)
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
 
-		err := testThreadUseCase.CreateThreadReport(mockThreadReportReq)
+		err := testThreadUseCase.CreateThreadReport(context.Background(), mockThreadReportReq)
 		assert.NoError(t, err)
 	})
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		mockThreadRepo.On("CreateThreadReport", mockThreadReportEntity).Return(utils.ErrInternalServerError).Once()
+		mockThreadRepo.On("CreateThreadReport", mock.Anything, mockThreadReportEntity).Return(utils.ErrInternalServerError).Once()
 
-		testThreadUseCase := CreateNewThreadUseCase(mockThreadRepo, mockNotifRepo, nil)
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
 
-		err := testThreadUseCase.CreateThreadReport(mockThreadReportReq)
+		err := testThreadUseCase.CreateThreadReport(context.Background(), mockThreadReportReq)
 		assert.Error(t, err)
 	})
 }
@@ -210,26 +230,94 @@ func TestCreateThread(t *testing.T) {
 		CommunityID: 1,
 		UserID:      1,
 	}
+	mockThreadEntity.BodyHTML = "<p>" + mockThreadEntity.Body + "</p>"
+	mockThreadEntity.BodyText = mockThreadEntity.Body
 
 	t.Run("success", func(t *testing.T) {
-		mockThreadRepo.On("CreateThread", mockThreadEntity).Return(mockedEntity, nil).Once()
+		mockThreadRepo.On("CreateThread", mock.Anything, mockThreadEntity).Return(mockedEntity, nil).Once()
+		mockThreadRepo.On("SubscribeThread", mock.Anything, uint(1), uint(1)).Return(nil).Once()
 
-		testThreadUseCase := CreateNewThreadUseCase(mockThreadRepo, mockNotifRepo, nil)
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
 
-		res, err := testThreadUseCase.CreateThread(mockThreadReq, 1)
+		res, err := testThreadUseCase.CreateThread(context.Background(), mockThreadReq, 1)
 		assert.NoError(t, err)
 		assert.NotEmpty(t, res)
 	})
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		mockThreadRepo.On("CreateThread", mockThreadEntity).Return(entity.Thread{}, utils.ErrInternalServerError).Once()
+		mockThreadRepo.On("CreateThread", mock.Anything, mockThreadEntity).Return(entity.Thread{}, utils.ErrInternalServerError).Once()
 
-		testThreadUseCase := CreateNewThreadUseCase(mockThreadRepo, mockNotifRepo, nil)
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
 
-		res, err := testThreadUseCase.CreateThread(mockThreadReq, uint(1))
+		res, err := testThreadUseCase.CreateThread(context.Background(), mockThreadReq, uint(1))
 		assert.Error(t, err)
 		assert.Empty(t, res)
 	})
+
+	t.Run("email-not-verified", func(t *testing.T) {
+		mockPostingPolicy := userMocks.NewPostingPolicy(t)
+		mockPostingPolicy.On("CanPost", mock.Anything, uint(1)).Return(utils.ErrEmailNotVerified).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo, posting: mockPostingPolicy})
+
+		res, err := testThreadUseCase.CreateThread(context.Background(), mockThreadReq, uint(1))
+		assert.Equal(t, utils.ErrEmailNotVerified, err)
+		assert.Empty(t, res)
+	})
+
+	t.Run("email-verified", func(t *testing.T) {
+		mockPostingPolicy := userMocks.NewPostingPolicy(t)
+		mockPostingPolicy.On("CanPost", mock.Anything, uint(1)).Return(nil).Once()
+		mockThreadRepo.On("CreateThread", mock.Anything, mockThreadEntity).Return(mockedEntity, nil).Once()
+		mockThreadRepo.On("SubscribeThread", mock.Anything, uint(1), uint(1)).Return(nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo, posting: mockPostingPolicy})
+
+		res, err := testThreadUseCase.CreateThread(context.Background(), mockThreadReq, uint(1))
+		assert.NoError(t, err)
+		assert.NotEmpty(t, res)
+	})
+
+	t.Run("body-too-long", func(t *testing.T) {
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+
+		res, err := testThreadUseCase.CreateThread(context.Background(), dto.ThreadRequest{
+			Title:       "Title",
+			Body:        strings.Repeat("ä", 10001),
+			CommunityID: 1,
+		}, uint(1))
+		assert.Equal(t, utils.ErrBadParamInput, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestAddThreadCommentRendersMarkdown(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockThreadRepo := mocks.NewThreadRepository(t)
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(mockedEntity, nil).Once()
+		mockThreadRepo.On("AddThreadComment", mock.Anything, entity.Comment{
+			Body:     "**so** <b>good</b> ||twist||",
+			BodyHTML: `<p><strong>so</strong> &lt;b&gt;good&lt;/b&gt; <span class="spoiler">twist</span></p>`,
+			BodyText: "so <b>good</b> [spoiler]",
+			UserID:   uint(1),
+			ThreadID: uint(1),
+		}).Return(nil).Once()
+		mockThreadRepo.On("SubscribeThread", mock.Anything, uint(1), uint(1)).Return(nil).Once()
+		mockJobQueue := jobMocks.NewQueue(t)
+		mockJobQueue.On("Enqueue", mock.Anything, thread.JobNotifyFollowers, mock.Anything).Return(nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{jobQueue: mockJobQueue})
+		err := testThreadUseCase.AddThreadComment(context.Background(), dto.CommentRequest{Body: "**so** <b>good</b> ||twist||", UserID: 1, ThreadID: 1})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("body-too-long", func(t *testing.T) {
+		testThreadUseCase := newTestThreadUseCase(mocks.NewThreadRepository(t), testDeps{})
+		err := testThreadUseCase.AddThreadComment(context.Background(), dto.CommentRequest{Body: strings.Repeat("a", 5001), UserID: 1, ThreadID: 1})
+
+		assert.Equal(t, utils.ErrBadParamInput, err)
+	})
 }
 
 func TestDeleteThread(t *testing.T) {
@@ -237,62 +325,62 @@ func TestDeleteThread(t *testing.T) {
 	mockNotifRepo := entityMocks.NewNotificationRepository(t)
 
 	t.Run("success", func(t *testing.T) {
-		mockThreadRepo.On("GetThreadByID", uint(1)).Return(mockedEntity, nil).Once()
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(mockedEntity, nil).Once()
 
-		mockThreadRepo.On("DeleteThread", uint(1)).Return(nil).Once()
+		mockThreadRepo.On("DeleteThread", mock.Anything, uint(1)).Return(nil).Once()
 
-		testThreadUseCase := CreateNewThreadUseCase(mockThreadRepo, mockNotifRepo, nil)
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
 
-		err := testThreadUseCase.DeleteThread(uint(1), uint(1), "")
+		err := testThreadUseCase.DeleteThread(context.Background(), uint(1), uint(1), "")
 		assert.NoError(t, err)
 	})
 
 	t.Run("internal-server-error-on-get-threads-by-ID", func(t *testing.T) {
-		mockThreadRepo.On("GetThreadByID", uint(1)).Return(entity.Thread{}, utils.ErrInternalServerError).Once()
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(entity.Thread{}, utils.ErrInternalServerError).Once()
 
-		testThreadUseCase := CreateNewThreadUseCase(mockThreadRepo, mockNotifRepo, nil)
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
 
-		err := testThreadUseCase.DeleteThread(uint(1), uint(1), "")
+		err := testThreadUseCase.DeleteThread(context.Background(), uint(1), uint(1), "")
 		assert.Error(t, err)
 	})
 
 	t.Run("internal-server-error-on-delete-thread", func(t *testing.T) {
-		mockThreadRepo.On("GetThreadByID", uint(1)).Return(mockedEntity, nil).Once()
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(mockedEntity, nil).Once()
 
-		mockThreadRepo.On("DeleteThread", uint(1)).Return(utils.ErrInternalServerError).Once()
+		mockThreadRepo.On("DeleteThread", mock.Anything, uint(1)).Return(utils.ErrInternalServerError).Once()
 
-		testThreadUseCase := CreateNewThreadUseCase(mockThreadRepo, mockNotifRepo, nil)
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
 
-		err := testThreadUseCase.DeleteThread(uint(1), uint(1), "")
+		err := testThreadUseCase.DeleteThread(context.Background(), uint(1), uint(1), "")
 		assert.Error(t, err)
 	})
 
 	t.Run("record-not-found", func(t *testing.T) {
-		mockThreadRepo.On("GetThreadByID", uint(1)).Return(entity.Thread{}, utils.ErrNotFound).Once()
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(entity.Thread{}, utils.ErrNotFound).Once()
 
-		testThreadUseCase := CreateNewThreadUseCase(mockThreadRepo, mockNotifRepo, nil)
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
 
-		err := testThreadUseCase.DeleteThread(uint(1), uint(1), "")
+		err := testThreadUseCase.DeleteThread(context.Background(), uint(1), uint(1), "")
 		assert.Error(t, err)
 	})
 
 	t.Run("unauthorized-access", func(t *testing.T) {
-		mockThreadRepo.On("GetThreadByID", uint(1)).Return(mockedEntity, nil).Once()
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(mockedEntity, nil).Once()
 
-		testThreadUseCase := CreateNewThreadUseCase(mockThreadRepo, mockNotifRepo, nil)
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
 
-		err := testThreadUseCase.DeleteThread(uint(1), uint(2), "")
+		err := testThreadUseCase.DeleteThread(context.Background(), uint(1), uint(2), "")
 		assert.Error(t, err)
 	})
 
 	t.Run("success-admin-deletion", func(t *testing.T) {
-		mockThreadRepo.On("GetThreadByID", uint(1)).Return(mockedEntity, nil).Once()
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(mockedEntity, nil).Once()
 
-		mockThreadRepo.On("DeleteThread", uint(1)).Return(nil).Once()
+		mockThreadRepo.On("DeleteThread", mock.Anything, uint(1)).Return(nil).Once()
 
-		testThreadUseCase := CreateNewThreadUseCase(mockThreadRepo, mockNotifRepo, nil)
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
 
-		err := testThreadUseCase.DeleteThread(uint(1), uint(3), "Admin")
+		err := testThreadUseCase.DeleteThread(context.Background(), uint(1), uint(3), "Admin")
 		assert.NoError(t, err)
 	})
 }
@@ -302,32 +390,32 @@ func TestUpdateThread(t *testing.T) {
 	mockNotifRepo := entityMocks.NewNotificationRepository(t)
 
 	t.Run("success", func(t *testing.T) {
-		mockThreadRepo.On("GetThreadByID", uint(1)).Return(mockedEntity, nil).Twice()
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(mockedEntity, nil).Twice()
 
-		mockThreadRepo.On("UpdateThread", uint(1), mockedEntityMappedFromDTO).Return(nil).Once()
+		mockThreadRepo.On("UpdateThread", mock.Anything, uint(1), mockedEntityMappedFromDTO).Return(nil).Once()
 
-		testThreadUseCase := CreateNewThreadUseCase(mockThreadRepo, mockNotifRepo, nil)
-		res, err := testThreadUseCase.UpdateThread(mockedThreadDTOReq, uint(1), uint(1))
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		res, err := testThreadUseCase.UpdateThread(context.Background(), mockedThreadDTOReq, uint(1), uint(1))
 
 		assert.NoError(t, err)
 		assert.NotEmpty(t, res)
 	})
 
 	t.Run("internal-server-error-on-get-threads-by-id", func(t *testing.T) {
-		mockThreadRepo.On("GetThreadByID", uint(1)).Return(entity.Thread{}, utils.ErrInternalServerError).Once()
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(entity.Thread{}, utils.ErrInternalServerError).Once()
 
-		testThreadUseCase := CreateNewThreadUseCase(mockThreadRepo, mockNotifRepo, nil)
-		res, err := testThreadUseCase.UpdateThread(mockedThreadDTOReq, uint(1), uint(1))
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		res, err := testThreadUseCase.UpdateThread(context.Background(), mockedThreadDTOReq, uint(1), uint(1))
 
 		assert.Error(t, err)
 		assert.Empty(t, res)
 	})
 
 	t.Run("record-not-found", func(t *testing.T) {
-		mockThreadRepo.On("GetThreadByID", uint(1)).Return(entity.Thread{}, utils.ErrNotFound).Once()
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(entity.Thread{}, utils.ErrNotFound).Once()
 
-		testThreadUseCase := CreateNewThreadUseCase(mockThreadRepo, mockNotifRepo, nil)
-		res, err := testThreadUseCase.UpdateThread(mockedThreadDTOReq, uint(1), uint(1))
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		res, err := testThreadUseCase.UpdateThread(context.Background(), mockedThreadDTOReq, uint(1), uint(1))
 
 		assert.Error(t, err)
 		assert.Empty(t, res)
@@ -339,30 +427,68 @@ func TestGetThreadByID(t *testing.T) {
 	mockNotifRepo := entityMocks.NewNotificationRepository(t)
 
 	t.Run("success", func(t *testing.T) {
-		mockThreadRepo.On("GetThreadByID", uint(1)).Return(mockedEntity, nil).Once()
-
-		testThreadUseCase := CreateNewThreadUseCase(mockThreadRepo, mockNotifRepo, nil)
-		res, err := testThreadUseCase.GetThreadByID(uint(1))
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(mockedEntity, nil).Once()
+		mockThreadRepo.On("GetAttachmentsByThreadID", mock.Anything, uint(1)).Return([]entity.Attachment{
+			{ID: 1, ThreadID: 1, FileName: "first.png", Caption: "first", Position: 1},
+			{ID: 2, ThreadID: 1, CommentID: 3, FileName: "comment.png", Position: 1},
+		}, nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		res, err := testThreadUseCase.GetThreadByID(context.Background(), uint(1), uint(0))
 		assert.NotEmpty(t, res)
 		assert.NoError(t, err)
+		assert.Len(t, res.Attachments, 1)
+		assert.Equal(t, "first", res.Attachments[0].Caption)
 	})
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		mockThreadRepo.On("GetThreadByID", uint(1)).Return(entity.Thread{}, utils.ErrInternalServerError).Once()
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(entity.Thread{}, utils.ErrInternalServerError).Once()
 
-		testThreadUseCase := CreateNewThreadUseCase(mockThreadRepo, mockNotifRepo, nil)
-		res, err := testThreadUseCase.GetThreadByID(uint(1))
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		res, err := testThreadUseCase.GetThreadByID(context.Background(), uint(1), uint(0))
 		assert.Empty(t, res)
-		assert.Error(t, err)
+		assert.ErrorIs(t, err, utils.ErrInternalServerError)
 	})
 
 	t.Run("record-not-found", func(t *testing.T) {
-		mockThreadRepo.On("GetThreadByID", uint(1)).Return(entity.Thread{}, utils.ErrNotFound).Once()
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(entity.Thread{}, utils.ErrNotFound).Once()
 
-		testThreadUseCase := CreateNewThreadUseCase(mockThreadRepo, mockNotifRepo, nil)
-		res, err := testThreadUseCase.GetThreadByID(uint(1))
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		res, err := testThreadUseCase.GetThreadByID(context.Background(), uint(1), uint(0))
 		assert.Empty(t, res)
-		assert.Error(t, err)
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+	})
+
+	t.Run("record-not-found-signed-in", func(t *testing.T) {
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(9)).Return(entity.Thread{}, utils.ErrNotFound).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		res, err := testThreadUseCase.GetThreadByID(context.Background(), uint(9), uint(2))
+		assert.Empty(t, res)
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+	})
+
+	t.Run("draft-of-another-user", func(t *testing.T) {
+		draft := mockedEntity
+		draft.Status = entity.ThreadStatusDraft
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(draft, nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		res, err := testThreadUseCase.GetThreadByID(context.Background(), uint(1), uint(2))
+		assert.Empty(t, res)
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+	})
+
+	t.Run("draft-of-author", func(t *testing.T) {
+		draft := mockedEntity
+		draft.Status = entity.ThreadStatusDraft
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(draft, nil).Once()
+		mockThreadRepo.On("GetAttachmentsByThreadID", mock.Anything, uint(1)).Return([]entity.Attachment{}, nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		res, err := testThreadUseCase.GetThreadByID(context.Background(), uint(1), uint(1))
+		assert.NoError(t, err)
+		assert.Equal(t, uint(1), res.ID)
 	})
 }
 
@@ -371,28 +497,28 @@ func TestLikeComment(t *testing.T) {
 	mockNotifRepo := entityMocks.NewNotificationRepository(t)
 
 	t.Run("success", func(t *testing.T) {
-		mockThreadRepo.On("GetCommentByID", uint(1)).Return(mockedCommentEntity, nil).Once()
-		mockThreadRepo.On("LikeComment", mockedLikeCommentEntity).Return(nil).Once()
+		mockThreadRepo.On("GetCommentByID", mock.Anything, uint(1)).Return(mockedCommentEntity, nil).Once()
+		mockThreadRepo.On("SetCommentVote", mock.Anything, uint(1), uint(1), entity.VoteUp).Return(mockedCommentEntity, entity.VoteNone, nil).Once()
 
-		testThreadUseCase := CreateNewThreadUseCase(mockThreadRepo, mockNotifRepo, nil)
-		err := testThreadUseCase.LikeComment(uint(1), uint(1))
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		err := testThreadUseCase.LikeComment(context.Background(), uint(1), uint(1))
 		assert.NoError(t, err)
 	})
 
 	t.Run("internal-server-error-like-comment", func(t *testing.T) {
-		mockThreadRepo.On("GetCommentByID", uint(1)).Return(mockedCommentEntity, nil).Once()
-		mockThreadRepo.On("LikeComment", mockedLikeCommentEntity).Return(utils.ErrInternalServerError).Once()
+		mockThreadRepo.On("GetCommentByID", mock.Anything, uint(1)).Return(mockedCommentEntity, nil).Once()
+		mockThreadRepo.On("SetCommentVote", mock.Anything, uint(1), uint(1), entity.VoteUp).Return(entity.Comment{}, entity.VoteNone, utils.ErrInternalServerError).Once()
 
-		testThreadUseCase := CreateNewThreadUseCase(mockThreadRepo, mockNotifRepo, nil)
-		err := testThreadUseCase.LikeComment(uint(1), uint(1))
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		err := testThreadUseCase.LikeComment(context.Background(), uint(1), uint(1))
 		assert.Error(t, err)
 	})
 
 	t.Run("internal-server-error-like-comment", func(t *testing.T) {
-		mockThreadRepo.On("GetCommentByID", uint(1)).Return(entity.Comment{}, utils.ErrInternalServerError).Once()
+		mockThreadRepo.On("GetCommentByID", mock.Anything, uint(1)).Return(entity.Comment{}, utils.ErrInternalServerError).Once()
 
-		testThreadUseCase := CreateNewThreadUseCase(mockThreadRepo, mockNotifRepo, nil)
-		err := testThreadUseCase.LikeComment(uint(1), uint(1))
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		err := testThreadUseCase.LikeComment(context.Background(), uint(1), uint(1))
 		assert.Error(t, err)
 	})
 }
@@ -402,19 +528,19 @@ func TestCreateCommentReport(t *testing.T) {
 	mockNotifRepo := entityMocks.NewNotificationRepository(t)
 
 	t.Run("success", func(t *testing.T) {
-		mockThreadRepo.On("CreateCommentReport", mockedCommentReportEntity).Return(nil).Once()
+		mockThreadRepo.On("CreateCommentReport", mock.Anything, mockedCommentReportEntity).Return(nil).Once()
 
-		testThreadUseCase := CreateNewThreadUseCase(mockThreadRepo, mockNotifRepo, nil)
-		err := testThreadUseCase.CreateCommentReport(mockedCommentReportDTO)
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		err := testThreadUseCase.CreateCommentReport(context.Background(), mockedCommentReportDTO)
 
 		assert.NoError(t, err)
 	})
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		mockThreadRepo.On("CreateCommentReport", mockedCommentReportEntity).Return(utils.ErrInternalServerError).Once()
+		mockThreadRepo.On("CreateCommentReport", mock.Anything, mockedCommentReportEntity).Return(utils.ErrInternalServerError).Once()
 
-		testThreadUseCase := CreateNewThreadUseCase(mockThreadRepo, mockNotifRepo, nil)
-		err := testThreadUseCase.CreateCommentReport(mockedCommentReportDTO)
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		err := testThreadUseCase.CreateCommentReport(context.Background(), mockedCommentReportDTO)
 
 		assert.Error(t, err)
 	})
@@ -425,30 +551,30 @@ func TestStoreSavedThread(t *testing.T) {
 	mockNotifRepo := entityMocks.NewNotificationRepository(t)
 
 	t.Run("success", func(t *testing.T) {
-		mockThreadRepo.On("GetThreadByID", uint(1)).Return(mockedEntity, nil).Once()
-		mockThreadRepo.On("StoreSavedThread", mockedSavedThreadEntity).Return(nil).Once()
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(mockedEntity, nil).Once()
+		mockThreadRepo.On("StoreSavedThread", mock.Anything, mockedSavedThreadEntity).Return(nil).Once()
 
-		testThreadUseCase := CreateNewThreadUseCase(mockThreadRepo, mockNotifRepo, nil)
-		err := testThreadUseCase.StoreSavedThread(mockedSavedThreadDTO)
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		err := testThreadUseCase.StoreSavedThread(context.Background(), mockedSavedThreadDTO)
 
 		assert.NoError(t, err)
 	})
 
 	t.Run("internal-server-error-get-thread-by-id", func(t *testing.T) {
-		mockThreadRepo.On("GetThreadByID", uint(1)).Return(entity.Thread{}, utils.ErrInternalServerError).Once()
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(entity.Thread{}, utils.ErrInternalServerError).Once()
 
-		testThreadUseCase := CreateNewThreadUseCase(mockThreadRepo, mockNotifRepo, nil)
-		err := testThreadUseCase.StoreSavedThread(mockedSavedThreadDTO)
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		err := testThreadUseCase.StoreSavedThread(context.Background(), mockedSavedThreadDTO)
 
 		assert.Error(t, err)
 	})
 
 	t.Run("internal-server-error-store-saved-thread", func(t *testing.T) {
-		mockThreadRepo.On("GetThreadByID", uint(1)).Return(mockedEntity, nil).Once()
-		mockThreadRepo.On("StoreSavedThread", mockedSavedThreadEntity).Return(utils.ErrInternalServerError).Once()
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(mockedEntity, nil).Once()
+		mockThreadRepo.On("StoreSavedThread", mock.Anything, mockedSavedThreadEntity).Return(utils.ErrInternalServerError).Once()
 
-		testThreadUseCase := CreateNewThreadUseCase(mockThreadRepo, mockNotifRepo, nil)
-		err := testThreadUseCase.StoreSavedThread(mockedSavedThreadDTO)
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		err := testThreadUseCase.StoreSavedThread(context.Background(), mockedSavedThreadDTO)
 
 		assert.Error(t, err)
 	})
@@ -459,19 +585,29 @@ func TestUnlikeComment(t *testing.T) {
 	mockNotifRepo := entityMocks.NewNotificationRepository(t)
 
 	t.Run("success", func(t *testing.T) {
-		mockThreadRepo.On("UnlikeComment", uint(1), uint(1)).Return(nil).Once()
+		mockThreadRepo.On("GetCommentVote", mock.Anything, uint(1), uint(1)).Return(entity.CommentVote{Value: entity.VoteUp}, nil).Once()
+		mockThreadRepo.On("SetCommentVote", mock.Anything, uint(1), uint(1), entity.VoteNone).Return(mockedCommentEntity, entity.VoteUp, nil).Once()
 
-		testThreadUseCase := CreateNewThreadUseCase(mockThreadRepo, mockNotifRepo, nil)
-		err := testThreadUseCase.UnlikeComment(uint(1), uint(1))
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		err := testThreadUseCase.UnlikeComment(context.Background(), uint(1), uint(1))
 
 		assert.NoError(t, err)
 	})
 
+	t.Run("not-found-when-downvoted", func(t *testing.T) {
+		mockThreadRepo.On("GetCommentVote", mock.Anything, uint(1), uint(1)).Return(entity.CommentVote{Value: entity.VoteDown}, nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		err := testThreadUseCase.UnlikeComment(context.Background(), uint(1), uint(1))
+
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+	})
+
 	t.Run("internal-server-error", func(t *testing.T) {
-		mockThreadRepo.On("UnlikeComment", uint(1), uint(1)).Return(utils.ErrInternalServerError).Once()
+		mockThreadRepo.On("GetCommentVote", mock.Anything, uint(1), uint(1)).Return(entity.CommentVote{}, utils.ErrInternalServerError).Once()
 
-		testThreadUseCase := CreateNewThreadUseCase(mockThreadRepo, mockNotifRepo, nil)
-		err := testThreadUseCase.UnlikeComment(uint(1), uint(1))
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		err := testThreadUseCase.UnlikeComment(context.Background(), uint(1), uint(1))
 
 		assert.Error(t, err)
 	})
@@ -482,20 +618,20 @@ func TestGetSavedThread(t *testing.T) {
 	mockNotifRepo := entityMocks.NewNotificationRepository(t)
 
 	t.Run("success", func(t *testing.T) {
-		mockThreadRepo.On("GetSavedThread", uint(1)).Return(mockedDetailedThread, nil).Once()
+		mockThreadRepo.On("GetSavedThread", mock.Anything, uint(1)).Return(mockedDetailedThread, nil).Once()
 
-		testThreadUseCase := CreateNewThreadUseCase(mockThreadRepo, mockNotifRepo, nil)
-		thread, err := testThreadUseCase.GetSavedThread(uint(1))
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		thread, err := testThreadUseCase.GetSavedThread(context.Background(), uint(1))
 
 		assert.NoError(t, err)
 		assert.NotEmpty(t, thread)
 	})
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		mockThreadRepo.On("GetSavedThread", uint(1)).Return([]entity.ThreadWithDetails{}, utils.ErrInternalServerError).Once()
+		mockThreadRepo.On("GetSavedThread", mock.Anything, uint(1)).Return([]entity.ThreadWithDetails{}, utils.ErrInternalServerError).Once()
 
-		testThreadUseCase := CreateNewThreadUseCase(mockThreadRepo, mockNotifRepo, nil)
-		thread, err := testThreadUseCase.GetSavedThread(uint(1))
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		thread, err := testThreadUseCase.GetSavedThread(context.Background(), uint(1))
 
 		assert.Error(t, err)
 		assert.Empty(t, thread)
@@ -507,30 +643,30 @@ func TestGetTrendingThreads(t *testing.T) {
 	mockNotifRepo := entityMocks.NewNotificationRepository(t)
 
 	t.Run("success", func(t *testing.T) {
-		mockThreadRepo.On("GetTrendingThreads", uint(1)).Return(mockedDetailedThread, nil).Once()
+		mockThreadRepo.On("GetRankedThreads", mock.Anything, uint(1), uint(0), "", -1).Return(mockedDetailedThread, nil).Once()
 
-		testThreadUseCase := CreateNewThreadUseCase(mockThreadRepo, mockNotifRepo, nil)
-		thread, err := testThreadUseCase.GetTrendingThreads(uint(1), -1)
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		thread, err := testThreadUseCase.GetTrendingThreads(context.Background(), uint(1), -1)
 
 		assert.NoError(t, err)
 		assert.NotEmpty(t, thread)
 	})
 
 	t.Run("success-with-limit", func(t *testing.T) {
-		mockThreadRepo.On("GetTrendingThreadsWithLimit", uint(1), 3).Return(mockedDetailedThread, nil).Once()
+		mockThreadRepo.On("GetRankedThreads", mock.Anything, uint(1), uint(0), "", 3).Return(mockedDetailedThread, nil).Once()
 
-		testThreadUseCase := CreateNewThreadUseCase(mockThreadRepo, mockNotifRepo, nil)
-		thread, err := testThreadUseCase.GetTrendingThreads(uint(1), 3)
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		thread, err := testThreadUseCase.GetTrendingThreads(context.Background(), uint(1), 3)
 
 		assert.NoError(t, err)
 		assert.NotEmpty(t, thread)
 	})
 
 	t.Run("internal-server-error-with-limit", func(t *testing.T) {
-		mockThreadRepo.On("GetTrendingThreadsWithLimit", uint(1), 3).Return([]entity.ThreadWithDetails{}, utils.ErrInternalServerError).Once()
+		mockThreadRepo.On("GetRankedThreads", mock.Anything, uint(1), uint(0), "", 3).Return([]entity.ThreadWithDetails{}, utils.ErrInternalServerError).Once()
 
-		testThreadUseCase := CreateNewThreadUseCase(mockThreadRepo, mockNotifRepo, nil)
-		thread, err := testThreadUseCase.GetTrendingThreads(uint(1), 3)
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		thread, err := testThreadUseCase.GetTrendingThreads(context.Background(), uint(1), 3)
 
 		assert.Error(t, err)
 		assert.Empty(t, thread)
@@ -542,20 +678,20 @@ func TestGetThreadsFromFollowedCommunity(t *testing.T) {
 	mockNotifRepo := entityMocks.NewNotificationRepository(t)
 
 	t.Run("success", func(t *testing.T) {
-		mockThreadRepo.On("GetThreadsFromFollowedCommunity", uint(1)).Return(mockedDetailedThread, nil).Once()
+		mockThreadRepo.On("GetThreadsFromFollowedCommunity", mock.Anything, uint(1)).Return(mockedDetailedThread, nil).Once()
 
-		testThreadUseCase := CreateNewThreadUseCase(mockThreadRepo, mockNotifRepo, nil)
-		thread, err := testThreadUseCase.GetThreadsFromFollowedCommunity(uint(1))
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		thread, err := testThreadUseCase.GetThreadsFromFollowedCommunity(context.Background(), uint(1))
 
 		assert.NoError(t, err)
 		assert.NotEmpty(t, thread)
 	})
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		mockThreadRepo.On("GetThreadsFromFollowedCommunity", uint(1)).Return([]entity.ThreadWithDetails{}, utils.ErrInternalServerError).Once()
+		mockThreadRepo.On("GetThreadsFromFollowedCommunity", mock.Anything, uint(1)).Return([]entity.ThreadWithDetails{}, utils.ErrInternalServerError).Once()
 
-		testThreadUseCase := CreateNewThreadUseCase(mockThreadRepo, mockNotifRepo, nil)
-		thread, err := testThreadUseCase.GetThreadsFromFollowedCommunity(uint(1))
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		thread, err := testThreadUseCase.GetThreadsFromFollowedCommunity(context.Background(), uint(1))
 
 		assert.Error(t, err)
 		assert.Empty(t, thread)
@@ -567,20 +703,20 @@ func TestGetThreadsFromFollowedUsers(t *testing.T) {
 	mockNotifRepo := entityMocks.NewNotificationRepository(t)
 
 	t.Run("success", func(t *testing.T) {
-		mockThreadRepo.On("GetThreadsFromFollowedUsers", uint(1)).Return(mockedDetailedThread, nil).Once()
+		mockThreadRepo.On("GetThreadsFromFollowedUsers", mock.Anything, uint(1)).Return(mockedDetailedThread, nil).Once()
 
-		testThreadUseCase := CreateNewThreadUseCase(mockThreadRepo, mockNotifRepo, nil)
-		thread, err := testThreadUseCase.GetThreadsFromFollowedUsers(uint(1))
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		thread, err := testThreadUseCase.GetThreadsFromFollowedUsers(context.Background(), uint(1))
 
 		assert.NoError(t, err)
 		assert.NotEmpty(t, thread)
 	})
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		mockThreadRepo.On("GetThreadsFromFollowedUsers", uint(1)).Return([]entity.ThreadWithDetails{}, utils.ErrInternalServerError).Once()
+		mockThreadRepo.On("GetThreadsFromFollowedUsers", mock.Anything, uint(1)).Return([]entity.ThreadWithDetails{}, utils.ErrInternalServerError).Once()
 
-		testThreadUseCase := CreateNewThreadUseCase(mockThreadRepo, mockNotifRepo, nil)
-		thread, err := testThreadUseCase.GetThreadsFromFollowedUsers(uint(1))
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		thread, err := testThreadUseCase.GetThreadsFromFollowedUsers(context.Background(), uint(1))
 
 		assert.Error(t, err)
 		assert.Empty(t, thread)
@@ -592,20 +728,20 @@ func TestGetThreads(t *testing.T) {
 	mockNotifRepo := entityMocks.NewNotificationRepository(t)
 
 	t.Run("success", func(t *testing.T) {
-		mockThreadRepo.On("GetThreads", "", uint(1)).Return(mockedDetailedThread, nil).Once()
+		mockThreadRepo.On("GetThreads", mock.Anything, "", uint(1)).Return(mockedDetailedThread, nil).Once()
 
-		testThreadUseCase := CreateNewThreadUseCase(mockThreadRepo, mockNotifRepo, nil)
-		thread, err := testThreadUseCase.GetThreads("", uint(1))
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		thread, err := testThreadUseCase.GetThreads(context.Background(), "", uint(1))
 
 		assert.NoError(t, err)
 		assert.NotEmpty(t, thread)
 	})
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		mockThreadRepo.On("GetThreads", "", uint(1)).Return([]entity.ThreadWithDetails{}, utils.ErrInternalServerError).Once()
+		mockThreadRepo.On("GetThreads", mock.Anything, "", uint(1)).Return([]entity.ThreadWithDetails{}, utils.ErrInternalServerError).Once()
 
-		testThreadUseCase := CreateNewThreadUseCase(mockThreadRepo, mockNotifRepo, nil)
-		thread, err := testThreadUseCase.GetThreads("", uint(1))
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		thread, err := testThreadUseCase.GetThreads(context.Background(), "", uint(1))
 
 		assert.Error(t, err)
 		assert.Empty(t, thread)
@@ -617,20 +753,35 @@ func TestGetCommentsByThreadID(t *testing.T) {
 	mockNotifRepo := entityMocks.NewNotificationRepository(t)
 
 	t.Run("success", func(t *testing.T) {
-		mockThreadRepo.On("GetCommentsByThreadID", uint(1)).Return(mockedDetailedCommentEntity, nil).Once()
+		mockThreadRepo.On("GetCommentsByThreadID", mock.Anything, uint(1), uint(2)).Return(mockedDetailedCommentEntity, nil).Once()
+		mockThreadRepo.On("GetAttachmentsByThreadID", mock.Anything, uint(1)).Return([]entity.Attachment{
+			{ID: 1, ThreadID: 1, FileName: "thread.png", Position: 1},
+			{ID: 2, ThreadID: 1, CommentID: 1, FileName: "comment.png", Position: 1},
+		}, nil).Once()
 
-		testThreadUseCase := CreateNewThreadUseCase(mockThreadRepo, mockNotifRepo, nil)
-		thread, err := testThreadUseCase.GetCommentsByThreadID(uint(1))
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		comments, err := testThreadUseCase.GetCommentsByThreadID(context.Background(), uint(1), uint(2), "")
 
 		assert.NoError(t, err)
-		assert.NotEmpty(t, thread)
+		assert.Len(t, comments, 1)
+		assert.Equal(t, 1, comments[0].UserVote)
+		assert.True(t, comments[0].IsMine)
+		assert.Len(t, comments[0].Attachments, 1)
+		assert.Equal(t, "comment.png", comments[0].Attachments[0].ImageURL)
+	})
+
+	t.Run("bad-sort", func(t *testing.T) {
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		_, err := testThreadUseCase.GetCommentsByThreadID(context.Background(), uint(1), uint(2), "random")
+
+		assert.ErrorIs(t, err, utils.ErrBadParamInput)
 	})
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		mockThreadRepo.On("GetCommentsByThreadID", uint(1)).Return([]entity.CommentDetails{}, utils.ErrInternalServerError).Once()
+		mockThreadRepo.On("GetCommentsByThreadID", mock.Anything, uint(1), uint(0)).Return([]entity.CommentDetails{}, utils.ErrInternalServerError).Once()
 
-		testThreadUseCase := CreateNewThreadUseCase(mockThreadRepo, mockNotifRepo, nil)
-		thread, err := testThreadUseCase.GetCommentsByThreadID(uint(1))
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		thread, err := testThreadUseCase.GetCommentsByThreadID(context.Background(), uint(1), uint(0), dto.CommentSortNew)
 
 		assert.Error(t, err)
 		assert.Empty(t, thread)
@@ -642,23 +793,320 @@ func TestDeleteComment(t *testing.T) {
 	mockNotifRepo := entityMocks.NewNotificationRepository(t)
 
 	t.Run("success", func(t *testing.T) {
-		mockThreadRepo.On("GetThreadByID", uint(1)).Return(mockedEntity, nil).Once()
-		mockThreadRepo.On("GetCommentByID", uint(1)).Return(mockedCommentEntity, nil).Once()
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(mockedEntity, nil).Once()
+		mockThreadRepo.On("GetCommentByID", mock.Anything, uint(1)).Return(mockedCommentEntity, nil).Once()
+
+		mockThreadRepo.On("DeleteComment", mock.Anything, uint(1)).Return(nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		err := testThreadUseCase.DeleteComment(context.Background(), uint(1), uint(1), uint(1), "User")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("internal-server-error", func(t *testing.T) {
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(entity.Thread{}, utils.ErrInternalServerError).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		err := testThreadUseCase.DeleteComment(context.Background(), uint(1), uint(1), uint(1), "User")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestVoteThread(t *testing.T) {
+	mockThreadRepo := mocks.NewThreadRepository(t)
+	up, down, invalid := entity.VoteUp, entity.VoteDown, 2
+	votedThread := entity.Thread{
+		Model:          gorm.Model{ID: 1},
+		UserID:         uint(2),
+		Score:          4,
+		UpvotesCount:   5,
+		DownvotesCount: 1,
+	}
+
+	t.Run("success-upvote-notifies-owner", func(t *testing.T) {
+		mockJobQueue := jobMocks.NewQueue(t)
+		mockThreadRepo.On("SetThreadVote", mock.Anything, uint(1), uint(1), entity.VoteUp).Return(votedThread, entity.VoteNone, nil).Once()
+		mockJobQueue.On("Enqueue", mock.Anything, notification.JobStoreNotification, mock.MatchedBy(func(n interface{}) bool {
+			return n.(entityNotif.Notification).UserID == uint(2)
+		})).Return(nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{jobQueue: mockJobQueue})
+		res, err := testThreadUseCase.VoteThread(context.Background(), uint(1), uint(1), dto.ThreadVoteRequest{Value: &up})
+
+		assert.NoError(t, err)
+		assert.Equal(t, dto.ThreadVoteResponse{ThreadID: 1, Score: 4, UpvotesCount: 5, DownvotesCount: 1, UserVote: 1}, res)
+	})
+
+	t.Run("success-repeated-upvote-does-not-notify", func(t *testing.T) {
+		mockJobQueue := jobMocks.NewQueue(t)
+		mockThreadRepo.On("SetThreadVote", mock.Anything, uint(1), uint(1), entity.VoteUp).Return(votedThread, entity.VoteUp, nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{jobQueue: mockJobQueue})
+		_, err := testThreadUseCase.VoteThread(context.Background(), uint(1), uint(1), dto.ThreadVoteRequest{Value: &up})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("success-downvote", func(t *testing.T) {
+		mockThreadRepo.On("SetThreadVote", mock.Anything, uint(1), uint(1), entity.VoteDown).Return(votedThread, entity.VoteUp, nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{})
+		res, err := testThreadUseCase.VoteThread(context.Background(), uint(1), uint(1), dto.ThreadVoteRequest{Value: &down})
+
+		assert.NoError(t, err)
+		assert.Equal(t, -1, res.UserVote)
+	})
+
+	t.Run("bad-param-input", func(t *testing.T) {
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{})
+
+		_, err := testThreadUseCase.VoteThread(context.Background(), uint(1), uint(1), dto.ThreadVoteRequest{Value: &invalid})
+		assert.ErrorIs(t, err, utils.ErrBadParamInput)
+
+		_, err = testThreadUseCase.VoteThread(context.Background(), uint(1), uint(1), dto.ThreadVoteRequest{})
+		assert.ErrorIs(t, err, utils.ErrBadParamInput)
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		mockThreadRepo.On("SetThreadVote", mock.Anything, uint(1), uint(1), entity.VoteDown).Return(entity.Thread{}, entity.VoteNone, utils.ErrNotFound).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{})
+		_, err := testThreadUseCase.VoteThread(context.Background(), uint(1), uint(1), dto.ThreadVoteRequest{Value: &down})
+
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+	})
+}
+
+func TestUndoUpvoteThread(t *testing.T) {
+	mockThreadRepo := mocks.NewThreadRepository(t)
+
+	t.Run("success", func(t *testing.T) {
+		mockThreadRepo.On("GetThreadVote", mock.Anything, uint(1), uint(1)).Return(entity.ThreadVote{Value: entity.VoteUp}, nil).Once()
+		mockThreadRepo.On("SetThreadVote", mock.Anything, uint(1), uint(1), entity.VoteNone).Return(mockedEntity, entity.VoteUp, nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{})
+		err := testThreadUseCase.UndoUpvoteThread(context.Background(), uint(1), uint(1))
+		assert.NoError(t, err)
+	})
+
+	t.Run("not-found-when-downvoted", func(t *testing.T) {
+		mockThreadRepo.On("GetThreadVote", mock.Anything, uint(1), uint(1)).Return(entity.ThreadVote{Value: entity.VoteDown}, nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{})
+		err := testThreadUseCase.UndoUpvoteThread(context.Background(), uint(1), uint(1))
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+	})
+}
+
+func TestVoteComment(t *testing.T) {
+	mockThreadRepo := mocks.NewThreadRepository(t)
+	down := entity.VoteDown
+
+	t.Run("success", func(t *testing.T) {
+		votedComment := mockedCommentEntity
+		votedComment.Score, votedComment.DownvotesCount = -1, 1
+		mockThreadRepo.On("GetCommentByID", mock.Anything, uint(1)).Return(mockedCommentEntity, nil).Once()
+		mockThreadRepo.On("SetCommentVote", mock.Anything, uint(1), uint(1), entity.VoteDown).Return(votedComment, entity.VoteNone, nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{})
+		res, err := testThreadUseCase.VoteComment(context.Background(), uint(1), uint(1), uint(1), dto.CommentVoteRequest{Value: &down})
+
+		assert.NoError(t, err)
+		assert.Equal(t, dto.CommentVoteResponse{CommentID: 1, Score: -1, DownvotesCount: 1, UserVote: -1}, res)
+	})
+
+	t.Run("not-found-on-other-thread", func(t *testing.T) {
+		mockThreadRepo.On("GetCommentByID", mock.Anything, uint(1)).Return(mockedCommentEntity, nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{})
+		_, err := testThreadUseCase.VoteComment(context.Background(), uint(2), uint(1), uint(1), dto.CommentVoteRequest{Value: &down})
+
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+	})
+
+	t.Run("bad-param-input", func(t *testing.T) {
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{})
+		_, err := testThreadUseCase.VoteComment(context.Background(), uint(1), uint(1), uint(1), dto.CommentVoteRequest{})
+
+		assert.ErrorIs(t, err, utils.ErrBadParamInput)
+	})
+}
+
+func TestCommentSorts(t *testing.T) {
+	now := time.Now()
+	comment := func(id uint, ups, downs int, age time.Duration) entity.CommentDetails {
+		return entity.CommentDetails{Comment: entity.Comment{
+			Model:          gorm.Model{ID: id, CreatedAt: now.Add(-age)},
+			Score:          ups - downs,
+			UpvotesCount:   ups,
+			DownvotesCount: downs,
+		}}
+	}
+	comments := []entity.CommentDetails{
+		comment(1, 1, 0, 4*time.Hour),
+		comment(2, 60, 20, 3*time.Hour),
+		comment(3, 30, 28, 2*time.Hour),
+		comment(4, 0, 0, time.Hour),
+	}
+
+	tests := map[string][]uint{
+		dto.CommentSortBest:          {2, 3, 1, 4},
+		dto.CommentSortTop:           {2, 3, 1, 4},
+		dto.CommentSortNew:           {4, 3, 2, 1},
+		dto.CommentSortOld:           {1, 2, 3, 4},
+		dto.CommentSortControversial: {3, 2, 4, 1},
+	}
+	for mode, want := range tests {
+		t.Run(mode, func(t *testing.T) {
+			sorted := append([]entity.CommentDetails{}, comments...)
+			sortComments(sorted, commentSorts[mode])
+
+			got := []uint{}
+			for _, c := range sorted {
+				got = append(got, c.Comment.ID)
+			}
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestGetCommunityTrendingThreads(t *testing.T) {
+	mockThreadRepo := mocks.NewThreadRepository(t)
+
+	t.Run("success", func(t *testing.T) {
+		mockThreadRepo.On("GetRankedThreads", mock.Anything, uint(1), uint(2), "", 10).Return(mockedDetailedThread, nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{})
+		threads, err := testThreadUseCase.GetCommunityTrendingThreads(context.Background(), uint(1), uint(2), 10)
+
+		assert.NoError(t, err)
+		assert.Len(t, threads, 1)
+	})
+}
+
+func TestGetTrendingThreadsByTimeframe(t *testing.T) {
+	mockThreadRepo := mocks.NewThreadRepository(t)
+
+	t.Run("success", func(t *testing.T) {
+		mockThreadRepo.On("GetRankedThreads", mock.Anything, uint(1), uint(0), thread.TimeframeWeek, -1).Return(mockedDetailedThread, nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{})
+		threads, err := testThreadUseCase.GetTrendingThreadsByTimeframe(context.Background(), uint(1), thread.TimeframeWeek, -1)
+
+		assert.NoError(t, err)
+		assert.Len(t, threads, 1)
+	})
+
+	t.Run("bad-timeframe", func(t *testing.T) {
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{})
+		_, err := testThreadUseCase.GetTrendingThreadsByTimeframe(context.Background(), uint(1), "year", -1)
+
+		assert.ErrorIs(t, err, utils.ErrBadParamInput)
+	})
+}
+
+func TestRefreshThreadScores(t *testing.T) {
+	mockThreadRepo := mocks.NewThreadRepository(t)
+	activity := []entity.ThreadActivity{
+		{ThreadID: 1, CommunityID: 3, CreatedAt: time.Now().Add(-time.Hour), UpvotesCount: 10, DayUpvotes: 10, DayComments: 4, CommentsCount: 4},
+		{ThreadID: 2, CommunityID: 3, CreatedAt: time.Now().Add(-48 * time.Hour), UpvotesCount: 10, WeekUpvotes: 10},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockThreadRepo.On("GetThreadActivity", mock.Anything, mock.AnythingOfType("time.Time")).Return(activity, nil).Once()
+		mockThreadRepo.On("StoreThreadScores", mock.Anything, mock.MatchedBy(func(scores []entity.ThreadScore) bool {
+			return len(scores) == 2 &&
+				scores[0].HotScore > scores[1].HotScore &&
+				scores[0].DayScore == 12 && scores[1].DayScore == 0 &&
+				scores[1].WeekScore == 10 && scores[0].CommunityID == 3
+		}), mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{})
+		err := testThreadUseCase.RefreshThreadScores(context.Background())
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("internal-server-error", func(t *testing.T) {
+		mockThreadRepo.On("GetThreadActivity", mock.Anything, mock.AnythingOfType("time.Time")).Return([]entity.ThreadActivity{}, utils.ErrInternalServerError).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{})
+		err := testThreadUseCase.RefreshThreadScores(context.Background())
+
+		assert.Error(t, err)
+	})
+}
+
+func TestHideThread(t *testing.T) {
+	mockThreadRepo := mocks.NewThreadRepository(t)
+	mockNotifRepo := entityMocks.NewNotificationRepository(t)
+
+	t.Run("success", func(t *testing.T) {
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(mockedEntity, nil).Once()
+		mockThreadRepo.On("HideThread", mock.Anything, uint(2), uint(1)).Return(nil).Once()
 
-		mockThreadRepo.On("DeleteComment", uint(1)).Return(nil).Once()
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		err := testThreadUseCase.HideThread(context.Background(), uint(1), uint(2))
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		mockThreadRepo.On("GetThreadByID", mock.Anything, uint(1)).Return(entity.Thread{}, utils.ErrNotFound).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		err := testThreadUseCase.HideThread(context.Background(), uint(1), uint(2))
+
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+	})
+}
+
+func TestUnhideThread(t *testing.T) {
+	mockThreadRepo := mocks.NewThreadRepository(t)
+	mockNotifRepo := entityMocks.NewNotificationRepository(t)
+
+	t.Run("success", func(t *testing.T) {
+		mockThreadRepo.On("UnhideThread", mock.Anything, uint(2), uint(1)).Return(nil).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		err := testThreadUseCase.UnhideThread(context.Background(), uint(1), uint(2))
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		mockThreadRepo.On("UnhideThread", mock.Anything, uint(2), uint(1)).Return(utils.ErrNotFound).Once()
+
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		err := testThreadUseCase.UnhideThread(context.Background(), uint(1), uint(2))
+
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+	})
+}
+
+func TestGetHiddenThreads(t *testing.T) {
+	mockThreadRepo := mocks.NewThreadRepository(t)
+	mockNotifRepo := entityMocks.NewNotificationRepository(t)
+
+	t.Run("success", func(t *testing.T) {
+		mockThreadRepo.On("GetHiddenThreads", mock.Anything, uint(1)).Return(mockedDetailedThread, nil).Once()
 
-		testThreadUseCase := CreateNewThreadUseCase(mockThreadRepo, mockNotifRepo, nil)
-		err := testThreadUseCase.DeleteComment(uint(1), uint(1), uint(1), "User")
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		threads, err := testThreadUseCase.GetHiddenThreads(context.Background(), uint(1))
 
 		assert.NoError(t, err)
+		assert.NotEmpty(t, threads)
 	})
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		mockThreadRepo.On("GetThreadByID", uint(1)).Return(entity.Thread{}, utils.ErrInternalServerError).Once()
+		mockThreadRepo.On("GetHiddenThreads", mock.Anything, uint(1)).Return([]entity.ThreadWithDetails{}, utils.ErrInternalServerError).Once()
 
-		testThreadUseCase := CreateNewThreadUseCase(mockThreadRepo, mockNotifRepo, nil)
-		err := testThreadUseCase.DeleteComment(uint(1), uint(1), uint(1), "User")
+		testThreadUseCase := newTestThreadUseCase(mockThreadRepo, testDeps{nr: mockNotifRepo})
+		threads, err := testThreadUseCase.GetHiddenThreads(context.Background(), uint(1))
 
 		assert.Error(t, err)
+		assert.Empty(t, threads)
 	})
 }
@@ -0,0 +1,138 @@
+package http
+
+import (
+	"macaiki/internal/user"
+	"macaiki/internal/user/dto"
+	"macaiki/pkg/response"
+	"macaiki/pkg/utils"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+
+	_middL "macaiki/pkg/middleware"
+)
+
+type TwoFactorHandler struct {
+	TwoFactorUsecase user.TwoFactorUsecase
+	JWTSecret        string
+}
+
+func NewTwoFactorHandler(e *echo.Echo, tu user.TwoFactorUsecase, JWTSecret string) {
+	handler := &TwoFactorHandler{
+		TwoFactorUsecase: tu,
+		JWTSecret:        JWTSecret,
+	}
+
+	e.POST("/api/v1/login/two-factor", handler.Login)
+	e.POST("/api/v1/login/two-factor/setup", handler.SetupLogin)
+
+	e.GET("/api/v1/curent-user/two-factor", handler.GetStatus, middleware.JWT([]byte(JWTSecret)))
+	e.POST("/api/v1/curent-user/two-factor/setup", handler.Setup, middleware.JWT([]byte(JWTSecret)))
+	e.POST("/api/v1/curent-user/two-factor/enable", handler.Enable, middleware.JWT([]byte(JWTSecret)))
+	e.POST("/api/v1/curent-user/two-factor/disable", handler.Disable, middleware.JWT([]byte(JWTSecret)))
+	e.POST("/api/v1/curent-user/two-factor/recovery-codes", handler.RegenerateRecoveryCodes, middleware.JWT([]byte(JWTSecret)))
+
+	e.PUT("/api/v1/admin/users/:userID/two-factor", handler.SetRequired, middleware.JWT([]byte(JWTSecret)))
+}
+
+func (h *TwoFactorHandler) Login(c echo.Context) error {
+	req := dto.TwoFactorLoginRequest{}
+	c.Bind(&req)
+
+	res, err := h.TwoFactorUsecase.Login(c.Request().Context(), req)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, res)
+}
+
+func (h *TwoFactorHandler) SetupLogin(c echo.Context) error {
+	req := dto.TwoFactorChallengeRequest{}
+	c.Bind(&req)
+
+	res, err := h.TwoFactorUsecase.SetupLogin(c.Request().Context(), req)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, res)
+}
+
+func (h *TwoFactorHandler) GetStatus(c echo.Context) error {
+	userID, _ := _middL.ExtractTokenUser(c)
+
+	res, err := h.TwoFactorUsecase.GetStatus(c.Request().Context(), uint(userID))
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, res)
+}
+
+func (h *TwoFactorHandler) Setup(c echo.Context) error {
+	userID, _ := _middL.ExtractTokenUser(c)
+
+	res, err := h.TwoFactorUsecase.Setup(c.Request().Context(), uint(userID))
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, res)
+}
+
+func (h *TwoFactorHandler) Enable(c echo.Context) error {
+	userID, _ := _middL.ExtractTokenUser(c)
+	req := dto.TwoFactorCodeRequest{}
+	c.Bind(&req)
+
+	res, err := h.TwoFactorUsecase.Enable(c.Request().Context(), uint(userID), req)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, res)
+}
+
+func (h *TwoFactorHandler) Disable(c echo.Context) error {
+	userID, _ := _middL.ExtractTokenUser(c)
+	req := dto.DisableTwoFactorRequest{}
+	c.Bind(&req)
+
+	if err := h.TwoFactorUsecase.Disable(c.Request().Context(), uint(userID), req); err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, nil)
+}
+
+func (h *TwoFactorHandler) RegenerateRecoveryCodes(c echo.Context) error {
+	userID, _ := _middL.ExtractTokenUser(c)
+	req := dto.TwoFactorCodeRequest{}
+	c.Bind(&req)
+
+	res, err := h.TwoFactorUsecase.RegenerateRecoveryCodes(c.Request().Context(), uint(userID), req)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, res)
+}
+
+func (h *TwoFactorHandler) SetRequired(c echo.Context) error {
+	_, role := _middL.ExtractTokenUser(c)
+	userID, err := strconv.Atoi(c.Param("userID"))
+	if err != nil {
+		return response.ErrorResponse(c, utils.ErrBadParamInput)
+	}
+
+	req := dto.TwoFactorRequirementRequest{}
+	c.Bind(&req)
+
+	if err := h.TwoFactorUsecase.SetRequired(c.Request().Context(), role, uint(userID), req); err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, nil)
+}
@@ -30,6 +30,7 @@ func NewUserHandler(e *echo.Echo, us user.UserUsecase, JWTSecret string) {
 	e.GET("/api/v1/users/:userID", handler.GetUser, middleware.JWT([]byte(JWTSecret)))
 	e.DELETE("/api/v1/users/:userID", handler.Delete, middleware.JWT([]byte(JWTSecret)))
 	e.DELETE("/api/v1/users", handler.DeleteUserByToken, middleware.JWT([]byte(JWTSecret)))
+	e.POST("/api/v1/curent-user/deletion/cancel", handler.CancelDeletion, middleware.JWT([]byte(JWTSecret)))
 
 	e.GET("/api/v1/admin/reports", handler.GetReports, middleware.JWT([]byte(JWTSecret)))
 	e.GET("/api/v1/admin/analytics", handler.GetDashboardAnalytics, middleware.JWT([]byte(JWTSecret)))
@@ -64,7 +65,9 @@ func NewUserHandler(e *echo.Echo, us user.UserUsecase, JWTSecret string) {
 	e.GET("/api/v1/users/:userID/threads", handler.GetThreadByUserID, middleware.JWT([]byte(JWTSecret)))
 
 	e.POST("api/v1/curent-user/email-verification", handler.SendOTP)
-	e.GET("api/v1/curent-user/email-verification", handler.VerifyOTP)
+	e.POST("api/v1/curent-user/email-verification/verify", handler.VerifyOTP)
+	e.POST("/api/v1/email-changes/confirm", handler.ConfirmEmailChange)
+	e.POST("/api/v1/email-changes/revert", handler.RevertEmailChange)
 }
 
 func (u *UserHandler) Login(c echo.Context) error {
@@ -72,7 +75,7 @@ func (u *UserHandler) Login(c echo.Context) error {
 
 	c.Bind(&loginInfo)
 
-	token, err := u.UserUsecase.Login(loginInfo)
+	token, err := u.UserUsecase.Login(c.Request().Context(), loginInfo)
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -84,7 +87,7 @@ func (u *UserHandler) Register(c echo.Context) error {
 	user := dto.UserRequest{}
 	c.Bind(&user)
 
-	err := u.UserUsecase.Register(user)
+	err := u.UserUsecase.Register(c.Request().Context(), user)
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -96,7 +99,7 @@ func (u *UserHandler) GetAllUsers(c echo.Context) error {
 	search := c.QueryParam("search")
 
 	userID, _ := _middL.ExtractTokenUser(c)
-	res, err := u.UserUsecase.GetAll(uint(userID), search)
+	res, err := u.UserUsecase.GetAll(c.Request().Context(), uint(userID), search)
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -113,7 +116,7 @@ func (u *UserHandler) GetUser(c echo.Context) error {
 
 	tokenUserID, _ := _middL.ExtractTokenUser(c)
 
-	res, err := u.UserUsecase.Get(uint(userID), uint(tokenUserID))
+	res, err := u.UserUsecase.Get(c.Request().Context(), uint(userID), uint(tokenUserID))
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -124,7 +127,7 @@ func (u *UserHandler) GetUser(c echo.Context) error {
 func (u *UserHandler) GetUserByToken(c echo.Context) error {
 	userID, _ := _middL.ExtractTokenUser(c)
 
-	res, err := u.UserUsecase.Get(uint(userID), uint(userID))
+	res, err := u.UserUsecase.Get(c.Request().Context(), uint(userID), uint(userID))
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -138,7 +141,7 @@ func (u *UserHandler) Update(c echo.Context) error {
 
 	userID, _ := _middL.ExtractTokenUser(c)
 
-	res, err := u.UserUsecase.Update(user, uint(userID))
+	res, err := u.UserUsecase.Update(c.Request().Context(), user, uint(userID))
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -155,18 +158,29 @@ func (u *UserHandler) Delete(c echo.Context) error {
 
 	curentUserID, curentUserRole := _middL.ExtractTokenUser(c)
 
-	err = u.UserUsecase.Delete(uint(userID), uint(curentUserID), curentUserRole)
+	res, err := u.UserUsecase.Delete(c.Request().Context(), uint(userID), uint(curentUserID), curentUserRole)
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
 
-	return response.SuccessResponse(c, nil)
+	return response.SuccessResponse(c, res)
 }
 
 func (u *UserHandler) DeleteUserByToken(c echo.Context) error {
 	curentUserID, curentUserRole := _middL.ExtractTokenUser(c)
 
-	err := u.UserUsecase.Delete(uint(curentUserID), uint(curentUserID), curentUserRole)
+	res, err := u.UserUsecase.Delete(c.Request().Context(), uint(curentUserID), uint(curentUserID), curentUserRole)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, res)
+}
+
+func (u *UserHandler) CancelDeletion(c echo.Context) error {
+	curentUserID, _ := _middL.ExtractTokenUser(c)
+
+	err := u.UserUsecase.CancelDeletion(c.Request().Context(), uint(curentUserID))
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -180,7 +194,7 @@ func (u *UserHandler) ChangeEmail(c echo.Context) error {
 
 	c.Bind(&info)
 
-	res, err := u.UserUsecase.ChangeEmail(uint(userID), info)
+	res, err := u.UserUsecase.ChangeEmail(c.Request().Context(), uint(userID), info)
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -188,13 +202,41 @@ func (u *UserHandler) ChangeEmail(c echo.Context) error {
 	return response.SuccessResponse(c, res)
 }
 
+func (u *UserHandler) ConfirmEmailChange(c echo.Context) error {
+	req := dto.EmailChangeTokenRequest{}
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, utils.ErrBadParamInput)
+	}
+
+	err := u.UserUsecase.ConfirmEmailChange(c.Request().Context(), req)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, nil)
+}
+
+func (u *UserHandler) RevertEmailChange(c echo.Context) error {
+	req := dto.EmailChangeTokenRequest{}
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, utils.ErrBadParamInput)
+	}
+
+	err := u.UserUsecase.RevertEmailChange(c.Request().Context(), req)
+	if err != nil {
+		return response.ErrorResponse(c, err)
+	}
+
+	return response.SuccessResponse(c, nil)
+}
+
 func (u *UserHandler) ChangePassword(c echo.Context) error {
 	newPasswordInfo := dto.UserChangePasswordRequest{}
 	userID, _ := _middL.ExtractTokenUser(c)
 
 	c.Bind(&newPasswordInfo)
 
-	err := u.UserUsecase.ChangePassword(uint(userID), newPasswordInfo)
+	err := u.UserUsecase.ChangePassword(c.Request().Context(), uint(userID), newPasswordInfo)
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -210,7 +252,7 @@ func (u *UserHandler) SetProfileImage(c echo.Context) error {
 		return response.ErrorResponse(c, err)
 	}
 
-	url, err := u.UserUsecase.SetProfileImage(uint(userID), img)
+	url, err := u.UserUsecase.SetProfileImage(c.Request().Context(), uint(userID), img)
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -228,7 +270,7 @@ func (u *UserHandler) SetBackgroundImage(c echo.Context) error {
 		return response.ErrorResponse(c, err)
 	}
 
-	url, err := u.UserUsecase.SetBackgroundImage(uint(userID), img)
+	url, err := u.UserUsecase.SetBackgroundImage(c.Request().Context(), uint(userID), img)
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -246,7 +288,7 @@ func (u *UserHandler) GetUserFollowers(c echo.Context) error {
 	}
 
 	tokenUserID, _ := _middL.ExtractTokenUser(c)
-	followers, err := u.UserUsecase.GetUserFollowers(uint(tokenUserID), uint(userID))
+	followers, err := u.UserUsecase.GetUserFollowers(c.Request().Context(), uint(tokenUserID), uint(userID))
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -262,7 +304,7 @@ func (u *UserHandler) GetUserFollowing(c echo.Context) error {
 	}
 
 	tokenUserID, _ := _middL.ExtractTokenUser(c)
-	followers, err := u.UserUsecase.GetUserFollowing(uint(tokenUserID), uint(userID))
+	followers, err := u.UserUsecase.GetUserFollowing(c.Request().Context(), uint(tokenUserID), uint(userID))
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -278,7 +320,7 @@ func (u *UserHandler) Follow(c echo.Context) error {
 	}
 
 	follower_id, _ := _middL.ExtractTokenUser(c)
-	err = u.UserUsecase.Follow(uint(userID), uint(follower_id))
+	err = u.UserUsecase.Follow(c.Request().Context(), uint(userID), uint(follower_id))
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -293,7 +335,7 @@ func (u *UserHandler) Unfollow(c echo.Context) error {
 	}
 
 	follower_id, _ := _middL.ExtractTokenUser(c)
-	err = u.UserUsecase.Unfollow(uint(userID), uint(follower_id))
+	err = u.UserUsecase.Unfollow(c.Request().Context(), uint(userID), uint(follower_id))
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -310,7 +352,7 @@ func (u *UserHandler) ReportUser(c echo.Context) error {
 	c.Bind(&reportInfo)
 
 	userID, _ := _middL.ExtractTokenUser(c)
-	err = u.UserUsecase.Report(uint(userID), uint(reportedUserID), reportInfo.ReportCategoryID)
+	err = u.UserUsecase.Report(c.Request().Context(), uint(userID), uint(reportedUserID), reportInfo.ReportCategoryID)
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -325,7 +367,7 @@ func (u *UserHandler) GetThreadByUserID(c echo.Context) error {
 	}
 
 	tokenUserID, _ := _middL.ExtractTokenUser(c)
-	threadResp, err := u.UserUsecase.GetThreadByToken(uint(userID), uint(tokenUserID))
+	threadResp, err := u.UserUsecase.GetThreadByToken(c.Request().Context(), uint(userID), uint(tokenUserID))
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -335,7 +377,7 @@ func (u *UserHandler) GetThreadByUserID(c echo.Context) error {
 
 func (u *UserHandler) GetThreadByToken(c echo.Context) error {
 	userID, _ := _middL.ExtractTokenUser(c)
-	threadResp, err := u.UserUsecase.GetThreadByToken(uint(userID), uint(userID))
+	threadResp, err := u.UserUsecase.GetThreadByToken(c.Request().Context(), uint(userID), uint(userID))
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -348,7 +390,7 @@ func (u *UserHandler) SendOTP(c echo.Context) error {
 
 	c.Bind(&email)
 
-	err := u.UserUsecase.SendOTP(email)
+	err := u.UserUsecase.SendOTP(c.Request().Context(), email)
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -358,7 +400,7 @@ func (u *UserHandler) SendOTP(c echo.Context) error {
 
 func (u *UserHandler) GetReports(c echo.Context) error {
 	_, role := _middL.ExtractTokenUser(c)
-	reports, err := u.UserUsecase.GetReports(role)
+	reports, err := u.UserUsecase.GetReports(c.Request().Context(), role)
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -367,10 +409,12 @@ func (u *UserHandler) GetReports(c echo.Context) error {
 }
 
 func (u *UserHandler) VerifyOTP(c echo.Context) error {
-	email := c.QueryParam("email")
-	otp := c.QueryParam("otp")
+	req := dto.VerifyOTPRequest{}
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, utils.ErrBadParamInput)
+	}
 
-	err := u.UserUsecase.VerifyOTP(email, otp)
+	err := u.UserUsecase.VerifyOTP(c.Request().Context(), req)
 
 	if err != nil {
 		return response.ErrorResponse(c, err)
@@ -385,7 +429,7 @@ func (u *UserHandler) GetReportedThread(c echo.Context) error {
 		return response.ErrorResponse(c, utils.ErrBadParamInput)
 	}
 	_, role := _middL.ExtractTokenUser(c)
-	thread, err := u.UserUsecase.GetReportedThread(role, uint(threadReportID))
+	thread, err := u.UserUsecase.GetReportedThread(c.Request().Context(), role, uint(threadReportID))
 
 	if err != nil {
 		return response.ErrorResponse(c, err)
@@ -400,7 +444,7 @@ func (u *UserHandler) GetReportedCommunity(c echo.Context) error {
 		return response.ErrorResponse(c, utils.ErrBadParamInput)
 	}
 	_, role := _middL.ExtractTokenUser(c)
-	community, err := u.UserUsecase.GetReportedCommunity(role, uint(communityReportID))
+	community, err := u.UserUsecase.GetReportedCommunity(c.Request().Context(), role, uint(communityReportID))
 
 	if err != nil {
 		return response.ErrorResponse(c, err)
@@ -415,7 +459,7 @@ func (u *UserHandler) GetReportedComment(c echo.Context) error {
 		return response.ErrorResponse(c, utils.ErrBadParamInput)
 	}
 	_, role := _middL.ExtractTokenUser(c)
-	comment, err := u.UserUsecase.GetReportedComment(role, uint(commentReportID))
+	comment, err := u.UserUsecase.GetReportedComment(c.Request().Context(), role, uint(commentReportID))
 
 	if err != nil {
 		return response.ErrorResponse(c, err)
@@ -430,7 +474,7 @@ func (u *UserHandler) GetReportedUser(c echo.Context) error {
 		return response.ErrorResponse(c, utils.ErrBadParamInput)
 	}
 	_, role := _middL.ExtractTokenUser(c)
-	user, err := u.UserUsecase.GetReportedUser(role, uint(userReportID))
+	user, err := u.UserUsecase.GetReportedUser(c.Request().Context(), role, uint(userReportID))
 
 	if err != nil {
 		return response.ErrorResponse(c, err)
@@ -441,7 +485,7 @@ func (u *UserHandler) GetReportedUser(c echo.Context) error {
 
 func (u *UserHandler) GetDashboardAnalytics(c echo.Context) error {
 	_, role := _middL.ExtractTokenUser(c)
-	analytics, err := u.UserUsecase.GetDashboardAnalytics(role)
+	analytics, err := u.UserUsecase.GetDashboardAnalytics(c.Request().Context(), role)
 	if err != nil {
 		return response.ErrorResponse(c, err)
 	}
@@ -455,7 +499,7 @@ func (u *UserHandler) BanUser(c echo.Context) error {
 		return response.ErrorResponse(c, utils.ErrBadParamInput)
 	}
 	_, role := _middL.ExtractTokenUser(c)
-	err = u.UserUsecase.BanUser(role, uint(userReportID))
+	err = u.UserUsecase.BanUser(c.Request().Context(), role, uint(userReportID))
 
 	if err != nil {
 		return response.ErrorResponse(c, err)
@@ -470,7 +514,7 @@ func (u *UserHandler) BanThread(c echo.Context) error {
 		return response.ErrorResponse(c, utils.ErrBadParamInput)
 	}
 	_, role := _middL.ExtractTokenUser(c)
-	err = u.UserUsecase.BanThread(role, uint(threadReportID))
+	err = u.UserUsecase.BanThread(c.Request().Context(), role, uint(threadReportID))
 
 	if err != nil {
 		return response.ErrorResponse(c, err)
@@ -485,7 +529,7 @@ func (u *UserHandler) BanCommunity(c echo.Context) error {
 		return response.ErrorResponse(c, utils.ErrBadParamInput)
 	}
 	_, role := _middL.ExtractTokenUser(c)
-	err = u.UserUsecase.BanCommunity(role, uint(communityReportID))
+	err = u.UserUsecase.BanCommunity(c.Request().Context(), role, uint(communityReportID))
 
 	if err != nil {
 		return response.ErrorResponse(c, err)
@@ -500,7 +544,7 @@ func (u *UserHandler) BanComment(c echo.Context) error {
 		return response.ErrorResponse(c, utils.ErrBadParamInput)
 	}
 	_, role := _middL.ExtractTokenUser(c)
-	err = u.UserUsecase.BanComment(role, uint(commentReportID))
+	err = u.UserUsecase.BanComment(c.Request().Context(), role, uint(commentReportID))
 
 	if err != nil {
 		return response.ErrorResponse(c, err)
@@ -515,7 +559,7 @@ func (u *UserHandler) DeleteThreadReport(c echo.Context) error {
 		return response.ErrorResponse(c, utils.ErrBadParamInput)
 	}
 	_, role := _middL.ExtractTokenUser(c)
-	err = u.UserUsecase.DeleteThreadReport(role, uint(threadReportID))
+	err = u.UserUsecase.DeleteThreadReport(c.Request().Context(), role, uint(threadReportID))
 
 	if err != nil {
 		return response.ErrorResponse(c, err)
@@ -530,7 +574,7 @@ func (u *UserHandler) DeleteCommentReport(c echo.Context) error {
 		return response.ErrorResponse(c, utils.ErrBadParamInput)
 	}
 	_, role := _middL.ExtractTokenUser(c)
-	err = u.UserUsecase.DeleteCommentReport(role, uint(commentReportID))
+	err = u.UserUsecase.DeleteCommentReport(c.Request().Context(), role, uint(commentReportID))
 
 	if err != nil {
 		return response.ErrorResponse(c, err)
@@ -545,7 +589,7 @@ func (u *UserHandler) DeleteUserReport(c echo.Context) error {
 		return response.ErrorResponse(c, utils.ErrBadParamInput)
 	}
 	_, role := _middL.ExtractTokenUser(c)
-	err = u.UserUsecase.DeleteUserReport(role, uint(userReportID))
+	err = u.UserUsecase.DeleteUserReport(c.Request().Context(), role, uint(userReportID))
 
 	if err != nil {
 		return response.ErrorResponse(c, err)
@@ -560,7 +604,7 @@ func (u *UserHandler) DeleteCommunityReport(c echo.Context) error {
 		return response.ErrorResponse(c, utils.ErrBadParamInput)
 	}
 	_, role := _middL.ExtractTokenUser(c)
-	err = u.UserUsecase.DeleteCommunityReport(role, uint(communityReportID))
+	err = u.UserUsecase.DeleteCommunityReport(c.Request().Context(), role, uint(communityReportID))
 
 	if err != nil {
 		return response.ErrorResponse(c, err)
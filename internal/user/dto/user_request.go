@@ -31,3 +31,34 @@ type SendOTPRequest struct {
 	Email string `json:"email"`
 	Link  string `json:"link"`
 }
+
+type VerifyOTPRequest struct {
+	Email string `json:"email" validate:"required,email"`
+	OTP   string `json:"otp" validate:"required"`
+}
+
+type EmailChangeTokenRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type TwoFactorChallengeRequest struct {
+	ChallengeToken string `json:"challengeToken" validate:"required"`
+}
+
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challengeToken" validate:"required"`
+	Code           string `json:"code" validate:"required"`
+}
+
+type DisableTwoFactorRequest struct {
+	Password string `json:"password" validate:"required"`
+	Code     string `json:"code" validate:"required"`
+}
+
+type TwoFactorRequirementRequest struct {
+	Required bool `json:"required"`
+}
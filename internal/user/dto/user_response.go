@@ -32,8 +32,30 @@ type UserUpdateResponse struct {
 	Profession string `json:"profession"`
 }
 
+// LoginResponse carries either an access token or, for accounts with two
+// factor authentication, a challenge token to finish the login with
 type LoginResponse struct {
-	Token string `json:"token"`
+	Token                  string     `json:"token"`
+	TwoFactorRequired      bool       `json:"twoFactorRequired,omitempty"`
+	TwoFactorSetupRequired bool       `json:"twoFactorSetupRequired,omitempty"`
+	ChallengeToken         string     `json:"challengeToken,omitempty"`
+	ChallengeExpiredAt     *time.Time `json:"challengeExpiredAt,omitempty"`
+	RecoveryCodes          []string   `json:"recoveryCodes,omitempty"`
+}
+
+type TwoFactorSetupResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioningURI"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+type TwoFactorStatusResponse struct {
+	Enabled           bool `json:"enabled"`
+	Required          bool `json:"required"`
+	RecoveryCodesLeft int  `json:"recoveryCodesLeft"`
 }
 
 type BriefReportResponse struct {
@@ -111,3 +133,12 @@ type ReportedUserResponse struct {
 	FollowersCount              int    `json:"followersCount"`
 	FollowingCount              int    `json:"followingCount"`
 }
+
+type EmailChangeResponse struct {
+	PendingEmail string    `json:"pendingEmail"`
+	ExpiredAt    time.Time `json:"expiredAt"`
+}
+
+type AccountDeletionResponse struct {
+	ScheduledAt time.Time `json:"scheduledAt"`
+}
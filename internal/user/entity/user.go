@@ -6,6 +6,13 @@ import (
 	"gorm.io/gorm"
 )
 
+// DEFAULT_PROFILE and DEFAULT_BACKGROUND are the images a user has until they
+// upload their own, they are shared and never deleted or exported
+const (
+	DEFAULT_PROFILE    = "https://macaiki.s3.ap-southeast-3.amazonaws.com/profile/default-avatar.png"
+	DEFAULT_BACKGROUND = "https://macaiki.s3.ap-southeast-3.amazonaws.com/background/default-background.png"
+)
+
 type User struct {
 	gorm.Model
 	Email              string `gorm:"uniqueIndex;size:75"`
@@ -19,11 +26,15 @@ type User struct {
 	Role               string
 	EmailVerifiedAt    time.Time `gorm:"default:null"`
 	IsBanned           int
-	Followers          []User       `gorm:"many2many:user_followers"`
-	Report             []UserReport `gorm:"foreignKey:UserID"`
-	Reported           []UserReport `gorm:"foreignKey:ReportedUserID"`
-	IsFollowed         int          `gorm:"-:migration;<-:false"`
-	IsMine             int          `gorm:"-:migration;<-:false"`
+	TwoFactorRequired  bool
+	// DeletionScheduledAt is set while the account waits out the grace
+	// period before it is anonymized
+	DeletionScheduledAt *time.Time
+	Followers           []User       `gorm:"many2many:user_followers"`
+	Report              []UserReport `gorm:"foreignKey:UserID"`
+	Reported            []UserReport `gorm:"foreignKey:ReportedUserID"`
+	IsFollowed          int          `gorm:"-:migration;<-:false"`
+	IsMine              int          `gorm:"-:migration;<-:false"`
 }
 
 type UserReport struct {
@@ -33,12 +44,57 @@ type UserReport struct {
 	ReportCategoryID uint
 }
 
+// VerificationEmail is a one-time code sent to confirm an email address,
+// only a bcrypt hash of the code is stored
 type VerificationEmail struct {
-	ID        uint `gorm:"primaryKey"`
-	Email     string
-	OTPCode   string
+	ID        uint   `gorm:"primaryKey"`
+	Email     string `gorm:"index;size:75"`
+	OTPHash   string
+	Attempts  int
 	ExpiredAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+// EmailChange is a requested email change awaiting confirmation from the new
+// address. The old address gets a revert token that stays valid after the
+// change has been applied. Only hashes of the tokens are stored.
+type EmailChange struct {
+	gorm.Model
+	UserID           uint   `gorm:"index"`
+	OldEmail         string `gorm:"size:75"`
+	NewEmail         string `gorm:"size:75"`
+	ConfirmTokenHash string `gorm:"uniqueIndex;size:64"`
+	RevertTokenHash  string `gorm:"uniqueIndex;size:64"`
+	ExpiredAt        time.Time
+	RevertExpiredAt  time.Time
+	ConfirmedAt      *time.Time
+	RevertedAt       *time.Time
 }
+
+// TwoFactor holds the TOTP secret of a user. EnabledAt stays nil until the
+// first code from the authenticator app has been verified, LastUsedStep
+// keeps a code from being accepted twice.
+type TwoFactor struct {
+	ID           uint   `gorm:"primaryKey"`
+	UserID       uint   `gorm:"uniqueIndex"`
+	Secret       string `gorm:"size:64"`
+	EnabledAt    *time.Time
+	LastUsedStep int64
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// RecoveryCode is a single use fallback for a lost authenticator, only a
+// hash of the code is stored
+type RecoveryCode struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"index"`
+	CodeHash  string `gorm:"size:64"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
 type BriefReport struct {
 	ThreadReportsID     uint
 	UserReportsID       uint
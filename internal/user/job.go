@@ -0,0 +1,9 @@
+package user
+
+// JobPurgeUser is the job type used to anonymize an account once its
+// deletion grace period is over, its payload is a PurgeUserPayload
+const JobPurgeUser = "user.purge"
+
+type PurgeUserPayload struct {
+	UserID uint
+}
@@ -0,0 +1,43 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PostingPolicy is an autogenerated mock type for the PostingPolicy type
+type PostingPolicy struct {
+	mock.Mock
+}
+
+// CanPost provides a mock function with given fields: ctx, userID
+func (_m *PostingPolicy) CanPost(ctx context.Context, userID uint) error {
+	ret := _m.Called(ctx, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewPostingPolicy interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewPostingPolicy creates a new instance of PostingPolicy. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewPostingPolicy(t mockConstructorTestingTNewPostingPolicy) *PostingPolicy {
+	mock := &PostingPolicy{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,52 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	dto "macaiki/internal/user/dto"
+	entity "macaiki/internal/user/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SessionIssuer is an autogenerated mock type for the SessionIssuer type
+type SessionIssuer struct {
+	mock.Mock
+}
+
+// Issue provides a mock function with given fields: ctx, _a1
+func (_m *SessionIssuer) Issue(ctx context.Context, _a1 entity.User) (dto.LoginResponse, error) {
+	ret := _m.Called(ctx, _a1)
+
+	var r0 dto.LoginResponse
+	if rf, ok := ret.Get(0).(func(context.Context, entity.User) dto.LoginResponse); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Get(0).(dto.LoginResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, entity.User) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewSessionIssuer interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewSessionIssuer creates a new instance of SessionIssuer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewSessionIssuer(t mockConstructorTestingTNewSessionIssuer) *SessionIssuer {
+	mock := &SessionIssuer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
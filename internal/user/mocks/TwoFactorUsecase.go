@@ -0,0 +1,184 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	dto "macaiki/internal/user/dto"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TwoFactorUsecase is an autogenerated mock type for the TwoFactorUsecase type
+type TwoFactorUsecase struct {
+	mock.Mock
+}
+
+// Disable provides a mock function with given fields: ctx, userID, req
+func (_m *TwoFactorUsecase) Disable(ctx context.Context, userID uint, req dto.DisableTwoFactorRequest) error {
+	ret := _m.Called(ctx, userID, req)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, dto.DisableTwoFactorRequest) error); ok {
+		r0 = rf(ctx, userID, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Enable provides a mock function with given fields: ctx, userID, req
+func (_m *TwoFactorUsecase) Enable(ctx context.Context, userID uint, req dto.TwoFactorCodeRequest) (dto.RecoveryCodesResponse, error) {
+	ret := _m.Called(ctx, userID, req)
+
+	var r0 dto.RecoveryCodesResponse
+	if rf, ok := ret.Get(0).(func(context.Context, uint, dto.TwoFactorCodeRequest) dto.RecoveryCodesResponse); ok {
+		r0 = rf(ctx, userID, req)
+	} else {
+		r0 = ret.Get(0).(dto.RecoveryCodesResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, dto.TwoFactorCodeRequest) error); ok {
+		r1 = rf(ctx, userID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStatus provides a mock function with given fields: ctx, userID
+func (_m *TwoFactorUsecase) GetStatus(ctx context.Context, userID uint) (dto.TwoFactorStatusResponse, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 dto.TwoFactorStatusResponse
+	if rf, ok := ret.Get(0).(func(context.Context, uint) dto.TwoFactorStatusResponse); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(dto.TwoFactorStatusResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Login provides a mock function with given fields: ctx, req
+func (_m *TwoFactorUsecase) Login(ctx context.Context, req dto.TwoFactorLoginRequest) (dto.LoginResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 dto.LoginResponse
+	if rf, ok := ret.Get(0).(func(context.Context, dto.TwoFactorLoginRequest) dto.LoginResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(dto.LoginResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, dto.TwoFactorLoginRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RegenerateRecoveryCodes provides a mock function with given fields: ctx, userID, req
+func (_m *TwoFactorUsecase) RegenerateRecoveryCodes(ctx context.Context, userID uint, req dto.TwoFactorCodeRequest) (dto.RecoveryCodesResponse, error) {
+	ret := _m.Called(ctx, userID, req)
+
+	var r0 dto.RecoveryCodesResponse
+	if rf, ok := ret.Get(0).(func(context.Context, uint, dto.TwoFactorCodeRequest) dto.RecoveryCodesResponse); ok {
+		r0 = rf(ctx, userID, req)
+	} else {
+		r0 = ret.Get(0).(dto.RecoveryCodesResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, dto.TwoFactorCodeRequest) error); ok {
+		r1 = rf(ctx, userID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetRequired provides a mock function with given fields: ctx, userRole, userID, req
+func (_m *TwoFactorUsecase) SetRequired(ctx context.Context, userRole string, userID uint, req dto.TwoFactorRequirementRequest) error {
+	ret := _m.Called(ctx, userRole, userID, req)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint, dto.TwoFactorRequirementRequest) error); ok {
+		r0 = rf(ctx, userRole, userID, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Setup provides a mock function with given fields: ctx, userID
+func (_m *TwoFactorUsecase) Setup(ctx context.Context, userID uint) (dto.TwoFactorSetupResponse, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 dto.TwoFactorSetupResponse
+	if rf, ok := ret.Get(0).(func(context.Context, uint) dto.TwoFactorSetupResponse); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(dto.TwoFactorSetupResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetupLogin provides a mock function with given fields: ctx, req
+func (_m *TwoFactorUsecase) SetupLogin(ctx context.Context, req dto.TwoFactorChallengeRequest) (dto.TwoFactorSetupResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 dto.TwoFactorSetupResponse
+	if rf, ok := ret.Get(0).(func(context.Context, dto.TwoFactorChallengeRequest) dto.TwoFactorSetupResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(dto.TwoFactorSetupResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, dto.TwoFactorChallengeRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewTwoFactorUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewTwoFactorUsecase creates a new instance of TwoFactorUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewTwoFactorUsecase(t mockConstructorTestingTNewTwoFactorUsecase) *TwoFactorUsecase {
+	mock := &TwoFactorUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
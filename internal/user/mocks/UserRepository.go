@@ -3,7 +3,9 @@
 package mocks
 
 import (
+	context "context"
 	entity "macaiki/internal/user/entity"
+	time "time"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -13,13 +15,50 @@ type UserRepository struct {
 	mock.Mock
 }
 
-// Delete provides a mock function with given fields: id
-func (_m *UserRepository) Delete(id uint) error {
-	ret := _m.Called(id)
+// AnonymizeUser provides a mock function with given fields: ctx, userID, placeholder
+func (_m *UserRepository) AnonymizeUser(ctx context.Context, userID uint, placeholder entity.User) ([]string, error) {
+	ret := _m.Called(ctx, userID, placeholder)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, uint, entity.User) []string); ok {
+		r0 = rf(ctx, userID, placeholder)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, entity.User) error); ok {
+		r1 = rf(ctx, userID, placeholder)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ApplyEmailChange provides a mock function with given fields: ctx, change
+func (_m *UserRepository) ApplyEmailChange(ctx context.Context, change entity.EmailChange) error {
+	ret := _m.Called(ctx, change)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.EmailChange) error); ok {
+		r0 = rf(ctx, change)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ClaimOTPAttempt provides a mock function with given fields: ctx, otpID, maxAttempts
+func (_m *UserRepository) ClaimOTPAttempt(ctx context.Context, otpID uint, maxAttempts int) error {
+	ret := _m.Called(ctx, otpID, maxAttempts)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint) error); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, int) error); ok {
+		r0 = rf(ctx, otpID, maxAttempts)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -27,13 +66,48 @@ func (_m *UserRepository) Delete(id uint) error {
 	return r0
 }
 
-// DeleteCommentReport provides a mock function with given fields: commentReportID
-func (_m *UserRepository) DeleteCommentReport(commentReportID uint) error {
-	ret := _m.Called(commentReportID)
+// ConsumeOTP provides a mock function with given fields: ctx, otpID
+func (_m *UserRepository) ConsumeOTP(ctx context.Context, otpID uint) error {
+	ret := _m.Called(ctx, otpID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, otpID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CountRecoveryCodes provides a mock function with given fields: ctx, userID
+func (_m *UserRepository) CountRecoveryCodes(ctx context.Context, userID uint) (int, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, uint) int); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *UserRepository) Delete(ctx context.Context, id uint) error {
+	ret := _m.Called(ctx, id)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint) error); ok {
-		r0 = rf(commentReportID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -41,13 +115,13 @@ func (_m *UserRepository) DeleteCommentReport(commentReportID uint) error {
 	return r0
 }
 
-// DeleteCommunityReport provides a mock function with given fields: communityReportID
-func (_m *UserRepository) DeleteCommunityReport(communityReportID uint) error {
-	ret := _m.Called(communityReportID)
+// DeleteCommentReport provides a mock function with given fields: ctx, commentReportID
+func (_m *UserRepository) DeleteCommentReport(ctx context.Context, commentReportID uint) error {
+	ret := _m.Called(ctx, commentReportID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint) error); ok {
-		r0 = rf(communityReportID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, commentReportID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -55,13 +129,13 @@ func (_m *UserRepository) DeleteCommunityReport(communityReportID uint) error {
 	return r0
 }
 
-// DeleteThreadReport provides a mock function with given fields: threadReportID
-func (_m *UserRepository) DeleteThreadReport(threadReportID uint) error {
-	ret := _m.Called(threadReportID)
+// DeleteCommunityReport provides a mock function with given fields: ctx, communityReportID
+func (_m *UserRepository) DeleteCommunityReport(ctx context.Context, communityReportID uint) error {
+	ret := _m.Called(ctx, communityReportID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint) error); ok {
-		r0 = rf(threadReportID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, communityReportID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -69,13 +143,13 @@ func (_m *UserRepository) DeleteThreadReport(threadReportID uint) error {
 	return r0
 }
 
-// DeleteUserReport provides a mock function with given fields: userReportID
-func (_m *UserRepository) DeleteUserReport(userReportID uint) error {
-	ret := _m.Called(userReportID)
+// DeleteThreadReport provides a mock function with given fields: ctx, threadReportID
+func (_m *UserRepository) DeleteThreadReport(ctx context.Context, threadReportID uint) error {
+	ret := _m.Called(ctx, threadReportID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint) error); ok {
-		r0 = rf(userReportID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, threadReportID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -83,20 +157,62 @@ func (_m *UserRepository) DeleteUserReport(userReportID uint) error {
 	return r0
 }
 
-// Follow provides a mock function with given fields: _a0, userFollower
-func (_m *UserRepository) Follow(_a0 entity.User, userFollower entity.User) (entity.User, error) {
-	ret := _m.Called(_a0, userFollower)
+// DeleteTwoFactor provides a mock function with given fields: ctx, userID
+func (_m *UserRepository) DeleteTwoFactor(ctx context.Context, userID uint) error {
+	ret := _m.Called(ctx, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteUserReport provides a mock function with given fields: ctx, userReportID
+func (_m *UserRepository) DeleteUserReport(ctx context.Context, userReportID uint) error {
+	ret := _m.Called(ctx, userReportID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, userReportID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnableTwoFactor provides a mock function with given fields: ctx, userID, step, codeHashes
+func (_m *UserRepository) EnableTwoFactor(ctx context.Context, userID uint, step int64, codeHashes []string) error {
+	ret := _m.Called(ctx, userID, step, codeHashes)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, int64, []string) error); ok {
+		r0 = rf(ctx, userID, step, codeHashes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Follow provides a mock function with given fields: ctx, _a1, userFollower
+func (_m *UserRepository) Follow(ctx context.Context, _a1 entity.User, userFollower entity.User) (entity.User, error) {
+	ret := _m.Called(ctx, _a1, userFollower)
 
 	var r0 entity.User
-	if rf, ok := ret.Get(0).(func(entity.User, entity.User) entity.User); ok {
-		r0 = rf(_a0, userFollower)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.User, entity.User) entity.User); ok {
+		r0 = rf(ctx, _a1, userFollower)
 	} else {
 		r0 = ret.Get(0).(entity.User)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(entity.User, entity.User) error); ok {
-		r1 = rf(_a0, userFollower)
+	if rf, ok := ret.Get(1).(func(context.Context, entity.User, entity.User) error); ok {
+		r1 = rf(ctx, _a1, userFollower)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -104,20 +220,20 @@ func (_m *UserRepository) Follow(_a0 entity.User, userFollower entity.User) (ent
 	return r0, r1
 }
 
-// Get provides a mock function with given fields: id
-func (_m *UserRepository) Get(id uint) (entity.User, error) {
-	ret := _m.Called(id)
+// Get provides a mock function with given fields: ctx, id
+func (_m *UserRepository) Get(ctx context.Context, id uint) (entity.User, error) {
+	ret := _m.Called(ctx, id)
 
 	var r0 entity.User
-	if rf, ok := ret.Get(0).(func(uint) entity.User); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) entity.User); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Get(0).(entity.User)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -125,13 +241,13 @@ func (_m *UserRepository) Get(id uint) (entity.User, error) {
 	return r0, r1
 }
 
-// GetAllWithDetail provides a mock function with given fields: userID, search
-func (_m *UserRepository) GetAllWithDetail(userID uint, search string) ([]entity.User, error) {
-	ret := _m.Called(userID, search)
+// GetAllWithDetail provides a mock function with given fields: ctx, userID, search
+func (_m *UserRepository) GetAllWithDetail(ctx context.Context, userID uint, search string) ([]entity.User, error) {
+	ret := _m.Called(ctx, userID, search)
 
 	var r0 []entity.User
-	if rf, ok := ret.Get(0).(func(uint, string) []entity.User); ok {
-		r0 = rf(userID, search)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) []entity.User); ok {
+		r0 = rf(ctx, userID, search)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entity.User)
@@ -139,8 +255,8 @@ func (_m *UserRepository) GetAllWithDetail(userID uint, search string) ([]entity
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint, string) error); ok {
-		r1 = rf(userID, search)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, string) error); ok {
+		r1 = rf(ctx, userID, search)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -148,20 +264,20 @@ func (_m *UserRepository) GetAllWithDetail(userID uint, search string) ([]entity
 	return r0, r1
 }
 
-// GetByEmail provides a mock function with given fields: email
-func (_m *UserRepository) GetByEmail(email string) (entity.User, error) {
-	ret := _m.Called(email)
+// GetByEmail provides a mock function with given fields: ctx, email
+func (_m *UserRepository) GetByEmail(ctx context.Context, email string) (entity.User, error) {
+	ret := _m.Called(ctx, email)
 
 	var r0 entity.User
-	if rf, ok := ret.Get(0).(func(string) entity.User); ok {
-		r0 = rf(email)
+	if rf, ok := ret.Get(0).(func(context.Context, string) entity.User); ok {
+		r0 = rf(ctx, email)
 	} else {
 		r0 = ret.Get(0).(entity.User)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(email)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -169,20 +285,20 @@ func (_m *UserRepository) GetByEmail(email string) (entity.User, error) {
 	return r0, r1
 }
 
-// GetByUsername provides a mock function with given fields: username
-func (_m *UserRepository) GetByUsername(username string) (entity.User, error) {
-	ret := _m.Called(username)
+// GetByUsername provides a mock function with given fields: ctx, username
+func (_m *UserRepository) GetByUsername(ctx context.Context, username string) (entity.User, error) {
+	ret := _m.Called(ctx, username)
 
 	var r0 entity.User
-	if rf, ok := ret.Get(0).(func(string) entity.User); ok {
-		r0 = rf(username)
+	if rf, ok := ret.Get(0).(func(context.Context, string) entity.User); ok {
+		r0 = rf(ctx, username)
 	} else {
 		r0 = ret.Get(0).(entity.User)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(username)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -190,20 +306,41 @@ func (_m *UserRepository) GetByUsername(username string) (entity.User, error) {
 	return r0, r1
 }
 
-// GetDashboardAnalytics provides a mock function with given fields:
-func (_m *UserRepository) GetDashboardAnalytics() (entity.AdminDashboardAnalytics, error) {
-	ret := _m.Called()
+// GetDashboardAnalytics provides a mock function with given fields: ctx
+func (_m *UserRepository) GetDashboardAnalytics(ctx context.Context) (entity.AdminDashboardAnalytics, error) {
+	ret := _m.Called(ctx)
 
 	var r0 entity.AdminDashboardAnalytics
-	if rf, ok := ret.Get(0).(func() entity.AdminDashboardAnalytics); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) entity.AdminDashboardAnalytics); ok {
+		r0 = rf(ctx)
 	} else {
 		r0 = ret.Get(0).(entity.AdminDashboardAnalytics)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEmailChangeByConfirmToken provides a mock function with given fields: ctx, tokenHash
+func (_m *UserRepository) GetEmailChangeByConfirmToken(ctx context.Context, tokenHash string) (entity.EmailChange, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	var r0 entity.EmailChange
+	if rf, ok := ret.Get(0).(func(context.Context, string) entity.EmailChange); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		r0 = ret.Get(0).(entity.EmailChange)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -211,13 +348,34 @@ func (_m *UserRepository) GetDashboardAnalytics() (entity.AdminDashboardAnalytic
 	return r0, r1
 }
 
-// GetFollower provides a mock function with given fields: userID, getFollowingUserID
-func (_m *UserRepository) GetFollower(userID uint, getFollowingUserID uint) ([]entity.User, error) {
-	ret := _m.Called(userID, getFollowingUserID)
+// GetEmailChangeByRevertToken provides a mock function with given fields: ctx, tokenHash
+func (_m *UserRepository) GetEmailChangeByRevertToken(ctx context.Context, tokenHash string) (entity.EmailChange, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	var r0 entity.EmailChange
+	if rf, ok := ret.Get(0).(func(context.Context, string) entity.EmailChange); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		r0 = ret.Get(0).(entity.EmailChange)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFollower provides a mock function with given fields: ctx, userID, getFollowingUserID
+func (_m *UserRepository) GetFollower(ctx context.Context, userID uint, getFollowingUserID uint) ([]entity.User, error) {
+	ret := _m.Called(ctx, userID, getFollowingUserID)
 
 	var r0 []entity.User
-	if rf, ok := ret.Get(0).(func(uint, uint) []entity.User); ok {
-		r0 = rf(userID, getFollowingUserID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) []entity.User); ok {
+		r0 = rf(ctx, userID, getFollowingUserID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entity.User)
@@ -225,8 +383,8 @@ func (_m *UserRepository) GetFollower(userID uint, getFollowingUserID uint) ([]e
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint, uint) error); ok {
-		r1 = rf(userID, getFollowingUserID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, userID, getFollowingUserID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -234,20 +392,20 @@ func (_m *UserRepository) GetFollower(userID uint, getFollowingUserID uint) ([]e
 	return r0, r1
 }
 
-// GetFollowerNumber provides a mock function with given fields: id
-func (_m *UserRepository) GetFollowerNumber(id uint) (int, error) {
-	ret := _m.Called(id)
+// GetFollowerNumber provides a mock function with given fields: ctx, id
+func (_m *UserRepository) GetFollowerNumber(ctx context.Context, id uint) (int, error) {
+	ret := _m.Called(ctx, id)
 
 	var r0 int
-	if rf, ok := ret.Get(0).(func(uint) int); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) int); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -255,13 +413,13 @@ func (_m *UserRepository) GetFollowerNumber(id uint) (int, error) {
 	return r0, r1
 }
 
-// GetFollowing provides a mock function with given fields: userID, getFollowingUserID
-func (_m *UserRepository) GetFollowing(userID uint, getFollowingUserID uint) ([]entity.User, error) {
-	ret := _m.Called(userID, getFollowingUserID)
+// GetFollowing provides a mock function with given fields: ctx, userID, getFollowingUserID
+func (_m *UserRepository) GetFollowing(ctx context.Context, userID uint, getFollowingUserID uint) ([]entity.User, error) {
+	ret := _m.Called(ctx, userID, getFollowingUserID)
 
 	var r0 []entity.User
-	if rf, ok := ret.Get(0).(func(uint, uint) []entity.User); ok {
-		r0 = rf(userID, getFollowingUserID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) []entity.User); ok {
+		r0 = rf(ctx, userID, getFollowingUserID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entity.User)
@@ -269,8 +427,8 @@ func (_m *UserRepository) GetFollowing(userID uint, getFollowingUserID uint) ([]
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint, uint) error); ok {
-		r1 = rf(userID, getFollowingUserID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, userID, getFollowingUserID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -278,20 +436,20 @@ func (_m *UserRepository) GetFollowing(userID uint, getFollowingUserID uint) ([]
 	return r0, r1
 }
 
-// GetFollowingNumber provides a mock function with given fields: id
-func (_m *UserRepository) GetFollowingNumber(id uint) (int, error) {
-	ret := _m.Called(id)
+// GetFollowingNumber provides a mock function with given fields: ctx, id
+func (_m *UserRepository) GetFollowingNumber(ctx context.Context, id uint) (int, error) {
+	ret := _m.Called(ctx, id)
 
 	var r0 int
-	if rf, ok := ret.Get(0).(func(uint) int); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) int); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -299,20 +457,20 @@ func (_m *UserRepository) GetFollowingNumber(id uint) (int, error) {
 	return r0, r1
 }
 
-// GetOTP provides a mock function with given fields: email
-func (_m *UserRepository) GetOTP(email string) (entity.VerificationEmail, error) {
-	ret := _m.Called(email)
+// GetOTP provides a mock function with given fields: ctx, email
+func (_m *UserRepository) GetOTP(ctx context.Context, email string) (entity.VerificationEmail, error) {
+	ret := _m.Called(ctx, email)
 
 	var r0 entity.VerificationEmail
-	if rf, ok := ret.Get(0).(func(string) entity.VerificationEmail); ok {
-		r0 = rf(email)
+	if rf, ok := ret.Get(0).(func(context.Context, string) entity.VerificationEmail); ok {
+		r0 = rf(ctx, email)
 	} else {
 		r0 = ret.Get(0).(entity.VerificationEmail)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(email)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -320,20 +478,20 @@ func (_m *UserRepository) GetOTP(email string) (entity.VerificationEmail, error)
 	return r0, r1
 }
 
-// GetReportedComment provides a mock function with given fields: commentReportID
-func (_m *UserRepository) GetReportedComment(commentReportID uint) (entity.ReportedComment, error) {
-	ret := _m.Called(commentReportID)
+// GetReportedComment provides a mock function with given fields: ctx, commentReportID
+func (_m *UserRepository) GetReportedComment(ctx context.Context, commentReportID uint) (entity.ReportedComment, error) {
+	ret := _m.Called(ctx, commentReportID)
 
 	var r0 entity.ReportedComment
-	if rf, ok := ret.Get(0).(func(uint) entity.ReportedComment); ok {
-		r0 = rf(commentReportID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) entity.ReportedComment); ok {
+		r0 = rf(ctx, commentReportID)
 	} else {
 		r0 = ret.Get(0).(entity.ReportedComment)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint) error); ok {
-		r1 = rf(commentReportID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, commentReportID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -341,20 +499,20 @@ func (_m *UserRepository) GetReportedComment(commentReportID uint) (entity.Repor
 	return r0, r1
 }
 
-// GetReportedCommunity provides a mock function with given fields: communityReportID
-func (_m *UserRepository) GetReportedCommunity(communityReportID uint) (entity.ReportedCommunity, error) {
-	ret := _m.Called(communityReportID)
+// GetReportedCommunity provides a mock function with given fields: ctx, communityReportID
+func (_m *UserRepository) GetReportedCommunity(ctx context.Context, communityReportID uint) (entity.ReportedCommunity, error) {
+	ret := _m.Called(ctx, communityReportID)
 
 	var r0 entity.ReportedCommunity
-	if rf, ok := ret.Get(0).(func(uint) entity.ReportedCommunity); ok {
-		r0 = rf(communityReportID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) entity.ReportedCommunity); ok {
+		r0 = rf(ctx, communityReportID)
 	} else {
 		r0 = ret.Get(0).(entity.ReportedCommunity)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint) error); ok {
-		r1 = rf(communityReportID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, communityReportID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -362,20 +520,20 @@ func (_m *UserRepository) GetReportedCommunity(communityReportID uint) (entity.R
 	return r0, r1
 }
 
-// GetReportedThread provides a mock function with given fields: threadReportID
-func (_m *UserRepository) GetReportedThread(threadReportID uint) (entity.ReportedThread, error) {
-	ret := _m.Called(threadReportID)
+// GetReportedThread provides a mock function with given fields: ctx, threadReportID
+func (_m *UserRepository) GetReportedThread(ctx context.Context, threadReportID uint) (entity.ReportedThread, error) {
+	ret := _m.Called(ctx, threadReportID)
 
 	var r0 entity.ReportedThread
-	if rf, ok := ret.Get(0).(func(uint) entity.ReportedThread); ok {
-		r0 = rf(threadReportID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) entity.ReportedThread); ok {
+		r0 = rf(ctx, threadReportID)
 	} else {
 		r0 = ret.Get(0).(entity.ReportedThread)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint) error); ok {
-		r1 = rf(threadReportID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, threadReportID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -383,20 +541,20 @@ func (_m *UserRepository) GetReportedThread(threadReportID uint) (entity.Reporte
 	return r0, r1
 }
 
-// GetReportedUser provides a mock function with given fields: userReportID
-func (_m *UserRepository) GetReportedUser(userReportID uint) (entity.ReportedUser, error) {
-	ret := _m.Called(userReportID)
+// GetReportedUser provides a mock function with given fields: ctx, userReportID
+func (_m *UserRepository) GetReportedUser(ctx context.Context, userReportID uint) (entity.ReportedUser, error) {
+	ret := _m.Called(ctx, userReportID)
 
 	var r0 entity.ReportedUser
-	if rf, ok := ret.Get(0).(func(uint) entity.ReportedUser); ok {
-		r0 = rf(userReportID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) entity.ReportedUser); ok {
+		r0 = rf(ctx, userReportID)
 	} else {
 		r0 = ret.Get(0).(entity.ReportedUser)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint) error); ok {
-		r1 = rf(userReportID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userReportID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -404,13 +562,13 @@ func (_m *UserRepository) GetReportedUser(userReportID uint) (entity.ReportedUse
 	return r0, r1
 }
 
-// GetReports provides a mock function with given fields:
-func (_m *UserRepository) GetReports() ([]entity.BriefReport, error) {
-	ret := _m.Called()
+// GetReports provides a mock function with given fields: ctx
+func (_m *UserRepository) GetReports(ctx context.Context) ([]entity.BriefReport, error) {
+	ret := _m.Called(ctx)
 
 	var r0 []entity.BriefReport
-	if rf, ok := ret.Get(0).(func() []entity.BriefReport); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) []entity.BriefReport); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entity.BriefReport)
@@ -418,8 +576,8 @@ func (_m *UserRepository) GetReports() ([]entity.BriefReport, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -427,20 +585,20 @@ func (_m *UserRepository) GetReports() ([]entity.BriefReport, error) {
 	return r0, r1
 }
 
-// GetThreadsNumber provides a mock function with given fields: id
-func (_m *UserRepository) GetThreadsNumber(id uint) (int, error) {
-	ret := _m.Called(id)
+// GetThreadsNumber provides a mock function with given fields: ctx, id
+func (_m *UserRepository) GetThreadsNumber(ctx context.Context, id uint) (int, error) {
+	ret := _m.Called(ctx, id)
 
 	var r0 int
-	if rf, ok := ret.Get(0).(func(uint) int); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) int); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -448,20 +606,41 @@ func (_m *UserRepository) GetThreadsNumber(id uint) (int, error) {
 	return r0, r1
 }
 
-// GetUserReport provides a mock function with given fields: reportID
-func (_m *UserRepository) GetUserReport(reportID uint) (entity.UserReport, error) {
-	ret := _m.Called(reportID)
+// GetTwoFactor provides a mock function with given fields: ctx, userID
+func (_m *UserRepository) GetTwoFactor(ctx context.Context, userID uint) (entity.TwoFactor, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 entity.TwoFactor
+	if rf, ok := ret.Get(0).(func(context.Context, uint) entity.TwoFactor); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(entity.TwoFactor)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserReport provides a mock function with given fields: ctx, reportID
+func (_m *UserRepository) GetUserReport(ctx context.Context, reportID uint) (entity.UserReport, error) {
+	ret := _m.Called(ctx, reportID)
 
 	var r0 entity.UserReport
-	if rf, ok := ret.Get(0).(func(uint) entity.UserReport); ok {
-		r0 = rf(reportID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) entity.UserReport); ok {
+		r0 = rf(ctx, reportID)
 	} else {
 		r0 = ret.Get(0).(entity.UserReport)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint) error); ok {
-		r1 = rf(reportID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, reportID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -469,20 +648,20 @@ func (_m *UserRepository) GetUserReport(reportID uint) (entity.UserReport, error
 	return r0, r1
 }
 
-// GetWithDetail provides a mock function with given fields: id, tokenID
-func (_m *UserRepository) GetWithDetail(id uint, tokenID uint) (entity.User, error) {
-	ret := _m.Called(id, tokenID)
+// GetWithDetail provides a mock function with given fields: ctx, id, tokenID
+func (_m *UserRepository) GetWithDetail(ctx context.Context, id uint, tokenID uint) (entity.User, error) {
+	ret := _m.Called(ctx, id, tokenID)
 
 	var r0 entity.User
-	if rf, ok := ret.Get(0).(func(uint, uint) entity.User); ok {
-		r0 = rf(id, tokenID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) entity.User); ok {
+		r0 = rf(ctx, id, tokenID)
 	} else {
 		r0 = ret.Get(0).(entity.User)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint, uint) error); ok {
-		r1 = rf(id, tokenID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, id, tokenID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -490,13 +669,55 @@ func (_m *UserRepository) GetWithDetail(id uint, tokenID uint) (entity.User, err
 	return r0, r1
 }
 
-// SetUserImage provides a mock function with given fields: id, imageURL, tableName
-func (_m *UserRepository) SetUserImage(id uint, imageURL string, tableName string) error {
-	ret := _m.Called(id, imageURL, tableName)
+// ReplaceRecoveryCodes provides a mock function with given fields: ctx, userID, codeHashes
+func (_m *UserRepository) ReplaceRecoveryCodes(ctx context.Context, userID uint, codeHashes []string) error {
+	ret := _m.Called(ctx, userID, codeHashes)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, []string) error); ok {
+		r0 = rf(ctx, userID, codeHashes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevertEmailChange provides a mock function with given fields: ctx, change
+func (_m *UserRepository) RevertEmailChange(ctx context.Context, change entity.EmailChange) error {
+	ret := _m.Called(ctx, change)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.EmailChange) error); ok {
+		r0 = rf(ctx, change)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetDeletionSchedule provides a mock function with given fields: ctx, userID, scheduledAt
+func (_m *UserRepository) SetDeletionSchedule(ctx context.Context, userID uint, scheduledAt *time.Time) error {
+	ret := _m.Called(ctx, userID, scheduledAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, *time.Time) error); ok {
+		r0 = rf(ctx, userID, scheduledAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetTwoFactorRequired provides a mock function with given fields: ctx, userID, required
+func (_m *UserRepository) SetTwoFactorRequired(ctx context.Context, userID uint, required bool) error {
+	ret := _m.Called(ctx, userID, required)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint, string, string) error); ok {
-		r0 = rf(id, imageURL, tableName)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, bool) error); ok {
+		r0 = rf(ctx, userID, required)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -504,13 +725,13 @@ func (_m *UserRepository) SetUserImage(id uint, imageURL string, tableName strin
 	return r0
 }
 
-// Store provides a mock function with given fields: _a0
-func (_m *UserRepository) Store(_a0 entity.User) error {
-	ret := _m.Called(_a0)
+// SetUserImage provides a mock function with given fields: ctx, id, imageURL, tableName
+func (_m *UserRepository) SetUserImage(ctx context.Context, id uint, imageURL string, tableName string) error {
+	ret := _m.Called(ctx, id, imageURL, tableName)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(entity.User) error); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, string) error); ok {
+		r0 = rf(ctx, id, imageURL, tableName)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -518,13 +739,13 @@ func (_m *UserRepository) Store(_a0 entity.User) error {
 	return r0
 }
 
-// StoreOTP provides a mock function with given fields: VerifyEmail
-func (_m *UserRepository) StoreOTP(VerifyEmail entity.VerificationEmail) error {
-	ret := _m.Called(VerifyEmail)
+// Store provides a mock function with given fields: ctx, _a1
+func (_m *UserRepository) Store(ctx context.Context, _a1 entity.User) error {
+	ret := _m.Called(ctx, _a1)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(entity.VerificationEmail) error); ok {
-		r0 = rf(VerifyEmail)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.User) error); ok {
+		r0 = rf(ctx, _a1)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -532,13 +753,13 @@ func (_m *UserRepository) StoreOTP(VerifyEmail entity.VerificationEmail) error {
 	return r0
 }
 
-// StoreReport provides a mock function with given fields: userReport
-func (_m *UserRepository) StoreReport(userReport entity.UserReport) error {
-	ret := _m.Called(userReport)
+// StoreEmailChange provides a mock function with given fields: ctx, change
+func (_m *UserRepository) StoreEmailChange(ctx context.Context, change entity.EmailChange) error {
+	ret := _m.Called(ctx, change)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(entity.UserReport) error); ok {
-		r0 = rf(userReport)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.EmailChange) error); ok {
+		r0 = rf(ctx, change)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -546,20 +767,62 @@ func (_m *UserRepository) StoreReport(userReport entity.UserReport) error {
 	return r0
 }
 
-// Unfollow provides a mock function with given fields: _a0, userFollower
-func (_m *UserRepository) Unfollow(_a0 entity.User, userFollower entity.User) (entity.User, error) {
-	ret := _m.Called(_a0, userFollower)
+// StoreOTP provides a mock function with given fields: ctx, VerifyEmail
+func (_m *UserRepository) StoreOTP(ctx context.Context, VerifyEmail entity.VerificationEmail) error {
+	ret := _m.Called(ctx, VerifyEmail)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.VerificationEmail) error); ok {
+		r0 = rf(ctx, VerifyEmail)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StoreReport provides a mock function with given fields: ctx, userReport
+func (_m *UserRepository) StoreReport(ctx context.Context, userReport entity.UserReport) error {
+	ret := _m.Called(ctx, userReport)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserReport) error); ok {
+		r0 = rf(ctx, userReport)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StoreTwoFactor provides a mock function with given fields: ctx, twoFactor
+func (_m *UserRepository) StoreTwoFactor(ctx context.Context, twoFactor entity.TwoFactor) error {
+	ret := _m.Called(ctx, twoFactor)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.TwoFactor) error); ok {
+		r0 = rf(ctx, twoFactor)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Unfollow provides a mock function with given fields: ctx, _a1, userFollower
+func (_m *UserRepository) Unfollow(ctx context.Context, _a1 entity.User, userFollower entity.User) (entity.User, error) {
+	ret := _m.Called(ctx, _a1, userFollower)
 
 	var r0 entity.User
-	if rf, ok := ret.Get(0).(func(entity.User, entity.User) entity.User); ok {
-		r0 = rf(_a0, userFollower)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.User, entity.User) entity.User); ok {
+		r0 = rf(ctx, _a1, userFollower)
 	} else {
 		r0 = ret.Get(0).(entity.User)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(entity.User, entity.User) error); ok {
-		r1 = rf(_a0, userFollower)
+	if rf, ok := ret.Get(1).(func(context.Context, entity.User, entity.User) error); ok {
+		r1 = rf(ctx, _a1, userFollower)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -567,20 +830,20 @@ func (_m *UserRepository) Unfollow(_a0 entity.User, userFollower entity.User) (e
 	return r0, r1
 }
 
-// Update provides a mock function with given fields: userDB, _a1
-func (_m *UserRepository) Update(userDB *entity.User, _a1 entity.User) (entity.User, error) {
-	ret := _m.Called(userDB, _a1)
+// Update provides a mock function with given fields: ctx, userDB, _a2
+func (_m *UserRepository) Update(ctx context.Context, userDB *entity.User, _a2 entity.User) (entity.User, error) {
+	ret := _m.Called(ctx, userDB, _a2)
 
 	var r0 entity.User
-	if rf, ok := ret.Get(0).(func(*entity.User, entity.User) entity.User); ok {
-		r0 = rf(userDB, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.User, entity.User) entity.User); ok {
+		r0 = rf(ctx, userDB, _a2)
 	} else {
 		r0 = ret.Get(0).(entity.User)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*entity.User, entity.User) error); ok {
-		r1 = rf(userDB, _a1)
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.User, entity.User) error); ok {
+		r1 = rf(ctx, userDB, _a2)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -588,6 +851,34 @@ func (_m *UserRepository) Update(userDB *entity.User, _a1 entity.User) (entity.U
 	return r0, r1
 }
 
+// UseRecoveryCode provides a mock function with given fields: ctx, userID, codeHash
+func (_m *UserRepository) UseRecoveryCode(ctx context.Context, userID uint, codeHash string) error {
+	ret := _m.Called(ctx, userID, codeHash)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) error); ok {
+		r0 = rf(ctx, userID, codeHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UseTwoFactorStep provides a mock function with given fields: ctx, userID, step
+func (_m *UserRepository) UseTwoFactorStep(ctx context.Context, userID uint, step int64) error {
+	ret := _m.Called(ctx, userID, step)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, int64) error); ok {
+		r0 = rf(ctx, userID, step)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewUserRepository interface {
 	mock.TestingT
 	Cleanup(func())
@@ -3,13 +3,12 @@
 package mocks
 
 import (
+	context "context"
+	threaddto "macaiki/internal/thread/dto"
 	dto "macaiki/internal/user/dto"
-
-	mock "github.com/stretchr/testify/mock"
-
 	multipart "mime/multipart"
 
-	threaddto "macaiki/internal/thread/dto"
+	mock "github.com/stretchr/testify/mock"
 )
 
 // UserUsecase is an autogenerated mock type for the UserUsecase type
@@ -17,13 +16,13 @@ type UserUsecase struct {
 	mock.Mock
 }
 
-// BanComment provides a mock function with given fields: userRole, commentReportID
-func (_m *UserUsecase) BanComment(userRole string, commentReportID uint) error {
-	ret := _m.Called(userRole, commentReportID)
+// BanComment provides a mock function with given fields: ctx, userRole, commentReportID
+func (_m *UserUsecase) BanComment(ctx context.Context, userRole string, commentReportID uint) error {
+	ret := _m.Called(ctx, userRole, commentReportID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, uint) error); ok {
-		r0 = rf(userRole, commentReportID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint) error); ok {
+		r0 = rf(ctx, userRole, commentReportID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -31,13 +30,13 @@ func (_m *UserUsecase) BanComment(userRole string, commentReportID uint) error {
 	return r0
 }
 
-// BanCommunity provides a mock function with given fields: userRole, communityReportID
-func (_m *UserUsecase) BanCommunity(userRole string, communityReportID uint) error {
-	ret := _m.Called(userRole, communityReportID)
+// BanCommunity provides a mock function with given fields: ctx, userRole, communityReportID
+func (_m *UserUsecase) BanCommunity(ctx context.Context, userRole string, communityReportID uint) error {
+	ret := _m.Called(ctx, userRole, communityReportID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, uint) error); ok {
-		r0 = rf(userRole, communityReportID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint) error); ok {
+		r0 = rf(ctx, userRole, communityReportID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -45,13 +44,13 @@ func (_m *UserUsecase) BanCommunity(userRole string, communityReportID uint) err
 	return r0
 }
 
-// BanThread provides a mock function with given fields: userRole, threadReportID
-func (_m *UserUsecase) BanThread(userRole string, threadReportID uint) error {
-	ret := _m.Called(userRole, threadReportID)
+// BanThread provides a mock function with given fields: ctx, userRole, threadReportID
+func (_m *UserUsecase) BanThread(ctx context.Context, userRole string, threadReportID uint) error {
+	ret := _m.Called(ctx, userRole, threadReportID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, uint) error); ok {
-		r0 = rf(userRole, threadReportID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint) error); ok {
+		r0 = rf(ctx, userRole, threadReportID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -59,13 +58,13 @@ func (_m *UserUsecase) BanThread(userRole string, threadReportID uint) error {
 	return r0
 }
 
-// BanUser provides a mock function with given fields: userRole, userReportID
-func (_m *UserUsecase) BanUser(userRole string, userReportID uint) error {
-	ret := _m.Called(userRole, userReportID)
+// BanUser provides a mock function with given fields: ctx, userRole, userReportID
+func (_m *UserUsecase) BanUser(ctx context.Context, userRole string, userReportID uint) error {
+	ret := _m.Called(ctx, userRole, userReportID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, uint) error); ok {
-		r0 = rf(userRole, userReportID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint) error); ok {
+		r0 = rf(ctx, userRole, userReportID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -73,20 +72,34 @@ func (_m *UserUsecase) BanUser(userRole string, userReportID uint) error {
 	return r0
 }
 
-// ChangeEmail provides a mock function with given fields: id, info
-func (_m *UserUsecase) ChangeEmail(id uint, info dto.UserLoginRequest) (string, error) {
-	ret := _m.Called(id, info)
+// CancelDeletion provides a mock function with given fields: ctx, id
+func (_m *UserUsecase) CancelDeletion(ctx context.Context, id uint) error {
+	ret := _m.Called(ctx, id)
 
-	var r0 string
-	if rf, ok := ret.Get(0).(func(uint, dto.UserLoginRequest) string); ok {
-		r0 = rf(id, info)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, id)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ChangeEmail provides a mock function with given fields: ctx, id, info
+func (_m *UserUsecase) ChangeEmail(ctx context.Context, id uint, info dto.UserLoginRequest) (dto.EmailChangeResponse, error) {
+	ret := _m.Called(ctx, id, info)
+
+	var r0 dto.EmailChangeResponse
+	if rf, ok := ret.Get(0).(func(context.Context, uint, dto.UserLoginRequest) dto.EmailChangeResponse); ok {
+		r0 = rf(ctx, id, info)
+	} else {
+		r0 = ret.Get(0).(dto.EmailChangeResponse)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint, dto.UserLoginRequest) error); ok {
-		r1 = rf(id, info)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, dto.UserLoginRequest) error); ok {
+		r1 = rf(ctx, id, info)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -94,13 +107,27 @@ func (_m *UserUsecase) ChangeEmail(id uint, info dto.UserLoginRequest) (string,
 	return r0, r1
 }
 
-// ChangePassword provides a mock function with given fields: id, passwordInfo
-func (_m *UserUsecase) ChangePassword(id uint, passwordInfo dto.UserChangePasswordRequest) error {
-	ret := _m.Called(id, passwordInfo)
+// ChangePassword provides a mock function with given fields: ctx, id, passwordInfo
+func (_m *UserUsecase) ChangePassword(ctx context.Context, id uint, passwordInfo dto.UserChangePasswordRequest) error {
+	ret := _m.Called(ctx, id, passwordInfo)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, dto.UserChangePasswordRequest) error); ok {
+		r0 = rf(ctx, id, passwordInfo)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ConfirmEmailChange provides a mock function with given fields: ctx, req
+func (_m *UserUsecase) ConfirmEmailChange(ctx context.Context, req dto.EmailChangeTokenRequest) error {
+	ret := _m.Called(ctx, req)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint, dto.UserChangePasswordRequest) error); ok {
-		r0 = rf(id, passwordInfo)
+	if rf, ok := ret.Get(0).(func(context.Context, dto.EmailChangeTokenRequest) error); ok {
+		r0 = rf(ctx, req)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -108,13 +135,34 @@ func (_m *UserUsecase) ChangePassword(id uint, passwordInfo dto.UserChangePasswo
 	return r0
 }
 
-// Delete provides a mock function with given fields: id, curentUserID, curentUser
-func (_m *UserUsecase) Delete(id uint, curentUserID uint, curentUser string) error {
-	ret := _m.Called(id, curentUserID, curentUser)
+// Delete provides a mock function with given fields: ctx, id, curentUserID, curentUser
+func (_m *UserUsecase) Delete(ctx context.Context, id uint, curentUserID uint, curentUser string) (dto.AccountDeletionResponse, error) {
+	ret := _m.Called(ctx, id, curentUserID, curentUser)
+
+	var r0 dto.AccountDeletionResponse
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, string) dto.AccountDeletionResponse); ok {
+		r0 = rf(ctx, id, curentUserID, curentUser)
+	} else {
+		r0 = ret.Get(0).(dto.AccountDeletionResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint, string) error); ok {
+		r1 = rf(ctx, id, curentUserID, curentUser)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteCommentReport provides a mock function with given fields: ctx, userRole, commentReportID
+func (_m *UserUsecase) DeleteCommentReport(ctx context.Context, userRole string, commentReportID uint) error {
+	ret := _m.Called(ctx, userRole, commentReportID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint, uint, string) error); ok {
-		r0 = rf(id, curentUserID, curentUser)
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint) error); ok {
+		r0 = rf(ctx, userRole, commentReportID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -122,13 +170,13 @@ func (_m *UserUsecase) Delete(id uint, curentUserID uint, curentUser string) err
 	return r0
 }
 
-// Follow provides a mock function with given fields: userID, userFollowerID
-func (_m *UserUsecase) Follow(userID uint, userFollowerID uint) error {
-	ret := _m.Called(userID, userFollowerID)
+// DeleteCommunityReport provides a mock function with given fields: ctx, userRole, communityReportID
+func (_m *UserUsecase) DeleteCommunityReport(ctx context.Context, userRole string, communityReportID uint) error {
+	ret := _m.Called(ctx, userRole, communityReportID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint, uint) error); ok {
-		r0 = rf(userID, userFollowerID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint) error); ok {
+		r0 = rf(ctx, userRole, communityReportID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -136,20 +184,62 @@ func (_m *UserUsecase) Follow(userID uint, userFollowerID uint) error {
 	return r0
 }
 
-// Get provides a mock function with given fields: id, tokenUserID
-func (_m *UserUsecase) Get(id uint, tokenUserID uint) (dto.UserDetailResponse, error) {
-	ret := _m.Called(id, tokenUserID)
+// DeleteThreadReport provides a mock function with given fields: ctx, userRole, threadReportID
+func (_m *UserUsecase) DeleteThreadReport(ctx context.Context, userRole string, threadReportID uint) error {
+	ret := _m.Called(ctx, userRole, threadReportID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint) error); ok {
+		r0 = rf(ctx, userRole, threadReportID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteUserReport provides a mock function with given fields: ctx, userRole, userReportID
+func (_m *UserUsecase) DeleteUserReport(ctx context.Context, userRole string, userReportID uint) error {
+	ret := _m.Called(ctx, userRole, userReportID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint) error); ok {
+		r0 = rf(ctx, userRole, userReportID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Follow provides a mock function with given fields: ctx, userID, userFollowerID
+func (_m *UserUsecase) Follow(ctx context.Context, userID uint, userFollowerID uint) error {
+	ret := _m.Called(ctx, userID, userFollowerID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, userID, userFollowerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, id, tokenUserID
+func (_m *UserUsecase) Get(ctx context.Context, id uint, tokenUserID uint) (dto.UserDetailResponse, error) {
+	ret := _m.Called(ctx, id, tokenUserID)
 
 	var r0 dto.UserDetailResponse
-	if rf, ok := ret.Get(0).(func(uint, uint) dto.UserDetailResponse); ok {
-		r0 = rf(id, tokenUserID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) dto.UserDetailResponse); ok {
+		r0 = rf(ctx, id, tokenUserID)
 	} else {
 		r0 = ret.Get(0).(dto.UserDetailResponse)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint, uint) error); ok {
-		r1 = rf(id, tokenUserID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, id, tokenUserID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -157,13 +247,13 @@ func (_m *UserUsecase) Get(id uint, tokenUserID uint) (dto.UserDetailResponse, e
 	return r0, r1
 }
 
-// GetAll provides a mock function with given fields: userID, search
-func (_m *UserUsecase) GetAll(userID uint, search string) ([]dto.UserResponse, error) {
-	ret := _m.Called(userID, search)
+// GetAll provides a mock function with given fields: ctx, userID, search
+func (_m *UserUsecase) GetAll(ctx context.Context, userID uint, search string) ([]dto.UserResponse, error) {
+	ret := _m.Called(ctx, userID, search)
 
 	var r0 []dto.UserResponse
-	if rf, ok := ret.Get(0).(func(uint, string) []dto.UserResponse); ok {
-		r0 = rf(userID, search)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) []dto.UserResponse); ok {
+		r0 = rf(ctx, userID, search)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]dto.UserResponse)
@@ -171,8 +261,8 @@ func (_m *UserUsecase) GetAll(userID uint, search string) ([]dto.UserResponse, e
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint, string) error); ok {
-		r1 = rf(userID, search)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, string) error); ok {
+		r1 = rf(ctx, userID, search)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -180,20 +270,20 @@ func (_m *UserUsecase) GetAll(userID uint, search string) ([]dto.UserResponse, e
 	return r0, r1
 }
 
-// GetDashboardAnalytics provides a mock function with given fields: userRole
-func (_m *UserUsecase) GetDashboardAnalytics(userRole string) (dto.AdminDashboardAnalytics, error) {
-	ret := _m.Called(userRole)
+// GetDashboardAnalytics provides a mock function with given fields: ctx, userRole
+func (_m *UserUsecase) GetDashboardAnalytics(ctx context.Context, userRole string) (dto.AdminDashboardAnalytics, error) {
+	ret := _m.Called(ctx, userRole)
 
 	var r0 dto.AdminDashboardAnalytics
-	if rf, ok := ret.Get(0).(func(string) dto.AdminDashboardAnalytics); ok {
-		r0 = rf(userRole)
+	if rf, ok := ret.Get(0).(func(context.Context, string) dto.AdminDashboardAnalytics); ok {
+		r0 = rf(ctx, userRole)
 	} else {
 		r0 = ret.Get(0).(dto.AdminDashboardAnalytics)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(userRole)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userRole)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -201,20 +291,20 @@ func (_m *UserUsecase) GetDashboardAnalytics(userRole string) (dto.AdminDashboar
 	return r0, r1
 }
 
-// GetReportedComment provides a mock function with given fields: userRole, commentReportID
-func (_m *UserUsecase) GetReportedComment(userRole string, commentReportID uint) (dto.ReportedCommentResponse, error) {
-	ret := _m.Called(userRole, commentReportID)
+// GetReportedComment provides a mock function with given fields: ctx, userRole, commentReportID
+func (_m *UserUsecase) GetReportedComment(ctx context.Context, userRole string, commentReportID uint) (dto.ReportedCommentResponse, error) {
+	ret := _m.Called(ctx, userRole, commentReportID)
 
 	var r0 dto.ReportedCommentResponse
-	if rf, ok := ret.Get(0).(func(string, uint) dto.ReportedCommentResponse); ok {
-		r0 = rf(userRole, commentReportID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint) dto.ReportedCommentResponse); ok {
+		r0 = rf(ctx, userRole, commentReportID)
 	} else {
 		r0 = ret.Get(0).(dto.ReportedCommentResponse)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, uint) error); ok {
-		r1 = rf(userRole, commentReportID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, uint) error); ok {
+		r1 = rf(ctx, userRole, commentReportID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -222,20 +312,20 @@ func (_m *UserUsecase) GetReportedComment(userRole string, commentReportID uint)
 	return r0, r1
 }
 
-// GetReportedCommunity provides a mock function with given fields: userRole, communityReportID
-func (_m *UserUsecase) GetReportedCommunity(userRole string, communityReportID uint) (dto.ReportedCommunityResponse, error) {
-	ret := _m.Called(userRole, communityReportID)
+// GetReportedCommunity provides a mock function with given fields: ctx, userRole, communityReportID
+func (_m *UserUsecase) GetReportedCommunity(ctx context.Context, userRole string, communityReportID uint) (dto.ReportedCommunityResponse, error) {
+	ret := _m.Called(ctx, userRole, communityReportID)
 
 	var r0 dto.ReportedCommunityResponse
-	if rf, ok := ret.Get(0).(func(string, uint) dto.ReportedCommunityResponse); ok {
-		r0 = rf(userRole, communityReportID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint) dto.ReportedCommunityResponse); ok {
+		r0 = rf(ctx, userRole, communityReportID)
 	} else {
 		r0 = ret.Get(0).(dto.ReportedCommunityResponse)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, uint) error); ok {
-		r1 = rf(userRole, communityReportID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, uint) error); ok {
+		r1 = rf(ctx, userRole, communityReportID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -243,20 +333,20 @@ func (_m *UserUsecase) GetReportedCommunity(userRole string, communityReportID u
 	return r0, r1
 }
 
-// GetReportedThread provides a mock function with given fields: userRole, threadReportID
-func (_m *UserUsecase) GetReportedThread(userRole string, threadReportID uint) (dto.ReportedThreadResponse, error) {
-	ret := _m.Called(userRole, threadReportID)
+// GetReportedThread provides a mock function with given fields: ctx, userRole, threadReportID
+func (_m *UserUsecase) GetReportedThread(ctx context.Context, userRole string, threadReportID uint) (dto.ReportedThreadResponse, error) {
+	ret := _m.Called(ctx, userRole, threadReportID)
 
 	var r0 dto.ReportedThreadResponse
-	if rf, ok := ret.Get(0).(func(string, uint) dto.ReportedThreadResponse); ok {
-		r0 = rf(userRole, threadReportID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint) dto.ReportedThreadResponse); ok {
+		r0 = rf(ctx, userRole, threadReportID)
 	} else {
 		r0 = ret.Get(0).(dto.ReportedThreadResponse)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, uint) error); ok {
-		r1 = rf(userRole, threadReportID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, uint) error); ok {
+		r1 = rf(ctx, userRole, threadReportID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -264,20 +354,20 @@ func (_m *UserUsecase) GetReportedThread(userRole string, threadReportID uint) (
 	return r0, r1
 }
 
-// GetReportedUser provides a mock function with given fields: userRole, userReportID
-func (_m *UserUsecase) GetReportedUser(userRole string, userReportID uint) (dto.ReportedUserResponse, error) {
-	ret := _m.Called(userRole, userReportID)
+// GetReportedUser provides a mock function with given fields: ctx, userRole, userReportID
+func (_m *UserUsecase) GetReportedUser(ctx context.Context, userRole string, userReportID uint) (dto.ReportedUserResponse, error) {
+	ret := _m.Called(ctx, userRole, userReportID)
 
 	var r0 dto.ReportedUserResponse
-	if rf, ok := ret.Get(0).(func(string, uint) dto.ReportedUserResponse); ok {
-		r0 = rf(userRole, userReportID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint) dto.ReportedUserResponse); ok {
+		r0 = rf(ctx, userRole, userReportID)
 	} else {
 		r0 = ret.Get(0).(dto.ReportedUserResponse)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, uint) error); ok {
-		r1 = rf(userRole, userReportID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, uint) error); ok {
+		r1 = rf(ctx, userRole, userReportID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -285,13 +375,13 @@ func (_m *UserUsecase) GetReportedUser(userRole string, userReportID uint) (dto.
 	return r0, r1
 }
 
-// GetReports provides a mock function with given fields: curentUserRole
-func (_m *UserUsecase) GetReports(curentUserRole string) ([]dto.BriefReportResponse, error) {
-	ret := _m.Called(curentUserRole)
+// GetReports provides a mock function with given fields: ctx, curentUserRole
+func (_m *UserUsecase) GetReports(ctx context.Context, curentUserRole string) ([]dto.BriefReportResponse, error) {
+	ret := _m.Called(ctx, curentUserRole)
 
 	var r0 []dto.BriefReportResponse
-	if rf, ok := ret.Get(0).(func(string) []dto.BriefReportResponse); ok {
-		r0 = rf(curentUserRole)
+	if rf, ok := ret.Get(0).(func(context.Context, string) []dto.BriefReportResponse); ok {
+		r0 = rf(ctx, curentUserRole)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]dto.BriefReportResponse)
@@ -299,8 +389,8 @@ func (_m *UserUsecase) GetReports(curentUserRole string) ([]dto.BriefReportRespo
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(curentUserRole)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, curentUserRole)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -308,13 +398,13 @@ func (_m *UserUsecase) GetReports(curentUserRole string) ([]dto.BriefReportRespo
 	return r0, r1
 }
 
-// GetThreadByToken provides a mock function with given fields: userID, tokenUserID
-func (_m *UserUsecase) GetThreadByToken(userID uint, tokenUserID uint) ([]threaddto.DetailedThreadResponse, error) {
-	ret := _m.Called(userID, tokenUserID)
+// GetThreadByToken provides a mock function with given fields: ctx, userID, tokenUserID
+func (_m *UserUsecase) GetThreadByToken(ctx context.Context, userID uint, tokenUserID uint) ([]threaddto.DetailedThreadResponse, error) {
+	ret := _m.Called(ctx, userID, tokenUserID)
 
 	var r0 []threaddto.DetailedThreadResponse
-	if rf, ok := ret.Get(0).(func(uint, uint) []threaddto.DetailedThreadResponse); ok {
-		r0 = rf(userID, tokenUserID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) []threaddto.DetailedThreadResponse); ok {
+		r0 = rf(ctx, userID, tokenUserID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]threaddto.DetailedThreadResponse)
@@ -322,8 +412,8 @@ func (_m *UserUsecase) GetThreadByToken(userID uint, tokenUserID uint) ([]thread
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint, uint) error); ok {
-		r1 = rf(userID, tokenUserID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, userID, tokenUserID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -331,13 +421,13 @@ func (_m *UserUsecase) GetThreadByToken(userID uint, tokenUserID uint) ([]thread
 	return r0, r1
 }
 
-// GetUserFollowers provides a mock function with given fields: tokenUserID, getFollowingUserID
-func (_m *UserUsecase) GetUserFollowers(tokenUserID uint, getFollowingUserID uint) ([]dto.UserResponse, error) {
-	ret := _m.Called(tokenUserID, getFollowingUserID)
+// GetUserFollowers provides a mock function with given fields: ctx, tokenUserID, getFollowingUserID
+func (_m *UserUsecase) GetUserFollowers(ctx context.Context, tokenUserID uint, getFollowingUserID uint) ([]dto.UserResponse, error) {
+	ret := _m.Called(ctx, tokenUserID, getFollowingUserID)
 
 	var r0 []dto.UserResponse
-	if rf, ok := ret.Get(0).(func(uint, uint) []dto.UserResponse); ok {
-		r0 = rf(tokenUserID, getFollowingUserID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) []dto.UserResponse); ok {
+		r0 = rf(ctx, tokenUserID, getFollowingUserID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]dto.UserResponse)
@@ -345,8 +435,8 @@ func (_m *UserUsecase) GetUserFollowers(tokenUserID uint, getFollowingUserID uin
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint, uint) error); ok {
-		r1 = rf(tokenUserID, getFollowingUserID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, tokenUserID, getFollowingUserID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -354,13 +444,13 @@ func (_m *UserUsecase) GetUserFollowers(tokenUserID uint, getFollowingUserID uin
 	return r0, r1
 }
 
-// GetUserFollowing provides a mock function with given fields: tokenUserID, getFollowingUserID
-func (_m *UserUsecase) GetUserFollowing(tokenUserID uint, getFollowingUserID uint) ([]dto.UserResponse, error) {
-	ret := _m.Called(tokenUserID, getFollowingUserID)
+// GetUserFollowing provides a mock function with given fields: ctx, tokenUserID, getFollowingUserID
+func (_m *UserUsecase) GetUserFollowing(ctx context.Context, tokenUserID uint, getFollowingUserID uint) ([]dto.UserResponse, error) {
+	ret := _m.Called(ctx, tokenUserID, getFollowingUserID)
 
 	var r0 []dto.UserResponse
-	if rf, ok := ret.Get(0).(func(uint, uint) []dto.UserResponse); ok {
-		r0 = rf(tokenUserID, getFollowingUserID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) []dto.UserResponse); ok {
+		r0 = rf(ctx, tokenUserID, getFollowingUserID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]dto.UserResponse)
@@ -368,8 +458,8 @@ func (_m *UserUsecase) GetUserFollowing(tokenUserID uint, getFollowingUserID uin
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint, uint) error); ok {
-		r1 = rf(tokenUserID, getFollowingUserID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, tokenUserID, getFollowingUserID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -377,20 +467,20 @@ func (_m *UserUsecase) GetUserFollowing(tokenUserID uint, getFollowingUserID uin
 	return r0, r1
 }
 
-// Login provides a mock function with given fields: loginInfo
-func (_m *UserUsecase) Login(loginInfo dto.UserLoginRequest) (dto.LoginResponse, error) {
-	ret := _m.Called(loginInfo)
+// Login provides a mock function with given fields: ctx, loginInfo
+func (_m *UserUsecase) Login(ctx context.Context, loginInfo dto.UserLoginRequest) (dto.LoginResponse, error) {
+	ret := _m.Called(ctx, loginInfo)
 
 	var r0 dto.LoginResponse
-	if rf, ok := ret.Get(0).(func(dto.UserLoginRequest) dto.LoginResponse); ok {
-		r0 = rf(loginInfo)
+	if rf, ok := ret.Get(0).(func(context.Context, dto.UserLoginRequest) dto.LoginResponse); ok {
+		r0 = rf(ctx, loginInfo)
 	} else {
 		r0 = ret.Get(0).(dto.LoginResponse)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(dto.UserLoginRequest) error); ok {
-		r1 = rf(loginInfo)
+	if rf, ok := ret.Get(1).(func(context.Context, dto.UserLoginRequest) error); ok {
+		r1 = rf(ctx, loginInfo)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -398,13 +488,41 @@ func (_m *UserUsecase) Login(loginInfo dto.UserLoginRequest) (dto.LoginResponse,
 	return r0, r1
 }
 
-// Register provides a mock function with given fields: _a0
-func (_m *UserUsecase) Register(_a0 dto.UserRequest) error {
-	ret := _m.Called(_a0)
+// PurgeUser provides a mock function with given fields: ctx, id
+func (_m *UserUsecase) PurgeUser(ctx context.Context, id uint) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Register provides a mock function with given fields: ctx, _a1
+func (_m *UserUsecase) Register(ctx context.Context, _a1 dto.UserRequest) error {
+	ret := _m.Called(ctx, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.UserRequest) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Report provides a mock function with given fields: ctx, userID, userReportedID, ReportCategoryID
+func (_m *UserUsecase) Report(ctx context.Context, userID uint, userReportedID uint, ReportCategoryID uint) error {
+	ret := _m.Called(ctx, userID, userReportedID, ReportCategoryID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(dto.UserRequest) error); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, uint) error); ok {
+		r0 = rf(ctx, userID, userReportedID, ReportCategoryID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -412,13 +530,13 @@ func (_m *UserUsecase) Register(_a0 dto.UserRequest) error {
 	return r0
 }
 
-// Report provides a mock function with given fields: userID, userReportedID, ReportCategoryID
-func (_m *UserUsecase) Report(userID uint, userReportedID uint, ReportCategoryID uint) error {
-	ret := _m.Called(userID, userReportedID, ReportCategoryID)
+// RevertEmailChange provides a mock function with given fields: ctx, req
+func (_m *UserUsecase) RevertEmailChange(ctx context.Context, req dto.EmailChangeTokenRequest) error {
+	ret := _m.Called(ctx, req)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint, uint, uint) error); ok {
-		r0 = rf(userID, userReportedID, ReportCategoryID)
+	if rf, ok := ret.Get(0).(func(context.Context, dto.EmailChangeTokenRequest) error); ok {
+		r0 = rf(ctx, req)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -426,13 +544,13 @@ func (_m *UserUsecase) Report(userID uint, userReportedID uint, ReportCategoryID
 	return r0
 }
 
-// SendOTP provides a mock function with given fields: email
-func (_m *UserUsecase) SendOTP(email dto.SendOTPRequest) error {
-	ret := _m.Called(email)
+// SendOTP provides a mock function with given fields: ctx, email
+func (_m *UserUsecase) SendOTP(ctx context.Context, email dto.SendOTPRequest) error {
+	ret := _m.Called(ctx, email)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(dto.SendOTPRequest) error); ok {
-		r0 = rf(email)
+	if rf, ok := ret.Get(0).(func(context.Context, dto.SendOTPRequest) error); ok {
+		r0 = rf(ctx, email)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -440,20 +558,20 @@ func (_m *UserUsecase) SendOTP(email dto.SendOTPRequest) error {
 	return r0
 }
 
-// SetBackgroundImage provides a mock function with given fields: id, img
-func (_m *UserUsecase) SetBackgroundImage(id uint, img *multipart.FileHeader) (string, error) {
-	ret := _m.Called(id, img)
+// SetBackgroundImage provides a mock function with given fields: ctx, id, img
+func (_m *UserUsecase) SetBackgroundImage(ctx context.Context, id uint, img *multipart.FileHeader) (string, error) {
+	ret := _m.Called(ctx, id, img)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(uint, *multipart.FileHeader) string); ok {
-		r0 = rf(id, img)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, *multipart.FileHeader) string); ok {
+		r0 = rf(ctx, id, img)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint, *multipart.FileHeader) error); ok {
-		r1 = rf(id, img)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, *multipart.FileHeader) error); ok {
+		r1 = rf(ctx, id, img)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -461,20 +579,20 @@ func (_m *UserUsecase) SetBackgroundImage(id uint, img *multipart.FileHeader) (s
 	return r0, r1
 }
 
-// SetProfileImage provides a mock function with given fields: id, img
-func (_m *UserUsecase) SetProfileImage(id uint, img *multipart.FileHeader) (string, error) {
-	ret := _m.Called(id, img)
+// SetProfileImage provides a mock function with given fields: ctx, id, img
+func (_m *UserUsecase) SetProfileImage(ctx context.Context, id uint, img *multipart.FileHeader) (string, error) {
+	ret := _m.Called(ctx, id, img)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(uint, *multipart.FileHeader) string); ok {
-		r0 = rf(id, img)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, *multipart.FileHeader) string); ok {
+		r0 = rf(ctx, id, img)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint, *multipart.FileHeader) error); ok {
-		r1 = rf(id, img)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, *multipart.FileHeader) error); ok {
+		r1 = rf(ctx, id, img)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -482,13 +600,13 @@ func (_m *UserUsecase) SetProfileImage(id uint, img *multipart.FileHeader) (stri
 	return r0, r1
 }
 
-// Unfollow provides a mock function with given fields: userID, userFollowerID
-func (_m *UserUsecase) Unfollow(userID uint, userFollowerID uint) error {
-	ret := _m.Called(userID, userFollowerID)
+// Unfollow provides a mock function with given fields: ctx, userID, userFollowerID
+func (_m *UserUsecase) Unfollow(ctx context.Context, userID uint, userFollowerID uint) error {
+	ret := _m.Called(ctx, userID, userFollowerID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint, uint) error); ok {
-		r0 = rf(userID, userFollowerID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, userID, userFollowerID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -496,20 +614,20 @@ func (_m *UserUsecase) Unfollow(userID uint, userFollowerID uint) error {
 	return r0
 }
 
-// Update provides a mock function with given fields: userUpdate, id
-func (_m *UserUsecase) Update(userUpdate dto.UserUpdateRequest, id uint) (dto.UserUpdateResponse, error) {
-	ret := _m.Called(userUpdate, id)
+// Update provides a mock function with given fields: ctx, userUpdate, id
+func (_m *UserUsecase) Update(ctx context.Context, userUpdate dto.UserUpdateRequest, id uint) (dto.UserUpdateResponse, error) {
+	ret := _m.Called(ctx, userUpdate, id)
 
 	var r0 dto.UserUpdateResponse
-	if rf, ok := ret.Get(0).(func(dto.UserUpdateRequest, uint) dto.UserUpdateResponse); ok {
-		r0 = rf(userUpdate, id)
+	if rf, ok := ret.Get(0).(func(context.Context, dto.UserUpdateRequest, uint) dto.UserUpdateResponse); ok {
+		r0 = rf(ctx, userUpdate, id)
 	} else {
 		r0 = ret.Get(0).(dto.UserUpdateResponse)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(dto.UserUpdateRequest, uint) error); ok {
-		r1 = rf(userUpdate, id)
+	if rf, ok := ret.Get(1).(func(context.Context, dto.UserUpdateRequest, uint) error); ok {
+		r1 = rf(ctx, userUpdate, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -517,13 +635,13 @@ func (_m *UserUsecase) Update(userUpdate dto.UserUpdateRequest, id uint) (dto.Us
 	return r0, r1
 }
 
-// VerifyOTP provides a mock function with given fields: email, OTPCode
-func (_m *UserUsecase) VerifyOTP(email string, OTPCode string) error {
-	ret := _m.Called(email, OTPCode)
+// VerifyOTP provides a mock function with given fields: ctx, req
+func (_m *UserUsecase) VerifyOTP(ctx context.Context, req dto.VerifyOTPRequest) error {
+	ret := _m.Called(ctx, req)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = rf(email, OTPCode)
+	if rf, ok := ret.Get(0).(func(context.Context, dto.VerifyOTPRequest) error); ok {
+		r0 = rf(ctx, req)
 	} else {
 		r0 = ret.Error(0)
 	}
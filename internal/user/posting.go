@@ -0,0 +1,15 @@
+package user
+
+import "context"
+
+// PostingPolicy decides whether a user may publish threads and comments
+type PostingPolicy interface {
+	CanPost(ctx context.Context, userID uint) error
+}
+
+// PostingPolicyFunc adapts a function to PostingPolicy
+type PostingPolicyFunc func(ctx context.Context, userID uint) error
+
+func (f PostingPolicyFunc) CanPost(ctx context.Context, userID uint) error {
+	return f(ctx, userID)
+}
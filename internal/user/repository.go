@@ -1,40 +1,66 @@
 package user
 
-import "macaiki/internal/user/entity"
+import (
+	"context"
+	"macaiki/internal/user/entity"
+	"time"
+)
 
 type UserRepository interface {
-	GetAllWithDetail(userID uint, search string) ([]entity.User, error)
-	Store(user entity.User) error
-	Get(id uint) (entity.User, error)
-	GetWithDetail(id, tokenID uint) (entity.User, error)
-	Update(userDB *entity.User, user entity.User) (entity.User, error)
-	Delete(id uint) error
-	GetByEmail(email string) (entity.User, error)
-	GetByUsername(username string) (entity.User, error)
-
-	GetFollowerNumber(id uint) (int, error)
-	GetFollowingNumber(id uint) (int, error)
-	GetThreadsNumber(id uint) (int, error)
-	Follow(user, userFollower entity.User) (entity.User, error)
-	Unfollow(user, userFollower entity.User) (entity.User, error)
-	GetFollower(userID, getFollowingUserID uint) ([]entity.User, error)
-	GetFollowing(userID, getFollowingUserID uint) ([]entity.User, error)
-	SetUserImage(id uint, imageURL string, tableName string) error
-
-	StoreReport(userReport entity.UserReport) error
-	StoreOTP(VerifyEmail entity.VerificationEmail) error
-	GetOTP(email string) (entity.VerificationEmail, error)
-	GetReports() ([]entity.BriefReport, error)
-	GetUserReport(reportID uint) (entity.UserReport, error)
-
-	GetDashboardAnalytics() (entity.AdminDashboardAnalytics, error)
-	GetReportedThread(threadReportID uint) (entity.ReportedThread, error)
-	GetReportedCommunity(communityReportID uint) (entity.ReportedCommunity, error)
-	GetReportedComment(commentReportID uint) (entity.ReportedComment, error)
-	GetReportedUser(userReportID uint) (entity.ReportedUser, error)
-
-	DeleteUserReport(userReportID uint) error
-	DeleteThreadReport(threadReportID uint) error
-	DeleteCommunityReport(communityReportID uint) error
-	DeleteCommentReport(commentReportID uint) error
+	GetAllWithDetail(ctx context.Context, userID uint, search string) ([]entity.User, error)
+	Store(ctx context.Context, user entity.User) error
+	Get(ctx context.Context, id uint) (entity.User, error)
+	GetWithDetail(ctx context.Context, id, tokenID uint) (entity.User, error)
+	Update(ctx context.Context, userDB *entity.User, user entity.User) (entity.User, error)
+	Delete(ctx context.Context, id uint) error
+	GetByEmail(ctx context.Context, email string) (entity.User, error)
+	GetByUsername(ctx context.Context, username string) (entity.User, error)
+
+	GetFollowerNumber(ctx context.Context, id uint) (int, error)
+	GetFollowingNumber(ctx context.Context, id uint) (int, error)
+	GetThreadsNumber(ctx context.Context, id uint) (int, error)
+	Follow(ctx context.Context, user, userFollower entity.User) (entity.User, error)
+	Unfollow(ctx context.Context, user, userFollower entity.User) (entity.User, error)
+	GetFollower(ctx context.Context, userID, getFollowingUserID uint) ([]entity.User, error)
+	GetFollowing(ctx context.Context, userID, getFollowingUserID uint) ([]entity.User, error)
+	SetUserImage(ctx context.Context, id uint, imageURL string, tableName string) error
+
+	StoreReport(ctx context.Context, userReport entity.UserReport) error
+	StoreOTP(ctx context.Context, VerifyEmail entity.VerificationEmail) error
+	GetOTP(ctx context.Context, email string) (entity.VerificationEmail, error)
+	ClaimOTPAttempt(ctx context.Context, otpID uint, maxAttempts int) error
+	ConsumeOTP(ctx context.Context, otpID uint) error
+
+	StoreEmailChange(ctx context.Context, change entity.EmailChange) error
+	GetEmailChangeByConfirmToken(ctx context.Context, tokenHash string) (entity.EmailChange, error)
+	GetEmailChangeByRevertToken(ctx context.Context, tokenHash string) (entity.EmailChange, error)
+	ApplyEmailChange(ctx context.Context, change entity.EmailChange) error
+	RevertEmailChange(ctx context.Context, change entity.EmailChange) error
+
+	GetTwoFactor(ctx context.Context, userID uint) (entity.TwoFactor, error)
+	StoreTwoFactor(ctx context.Context, twoFactor entity.TwoFactor) error
+	EnableTwoFactor(ctx context.Context, userID uint, step int64, codeHashes []string) error
+	UseTwoFactorStep(ctx context.Context, userID uint, step int64) error
+	UseRecoveryCode(ctx context.Context, userID uint, codeHash string) error
+	ReplaceRecoveryCodes(ctx context.Context, userID uint, codeHashes []string) error
+	CountRecoveryCodes(ctx context.Context, userID uint) (int, error)
+	DeleteTwoFactor(ctx context.Context, userID uint) error
+	SetTwoFactorRequired(ctx context.Context, userID uint, required bool) error
+
+	SetDeletionSchedule(ctx context.Context, userID uint, scheduledAt *time.Time) error
+	AnonymizeUser(ctx context.Context, userID uint, placeholder entity.User) ([]string, error)
+
+	GetReports(ctx context.Context) ([]entity.BriefReport, error)
+	GetUserReport(ctx context.Context, reportID uint) (entity.UserReport, error)
+
+	GetDashboardAnalytics(ctx context.Context) (entity.AdminDashboardAnalytics, error)
+	GetReportedThread(ctx context.Context, threadReportID uint) (entity.ReportedThread, error)
+	GetReportedCommunity(ctx context.Context, communityReportID uint) (entity.ReportedCommunity, error)
+	GetReportedComment(ctx context.Context, commentReportID uint) (entity.ReportedComment, error)
+	GetReportedUser(ctx context.Context, userReportID uint) (entity.ReportedUser, error)
+
+	DeleteUserReport(ctx context.Context, userReportID uint) error
+	DeleteThreadReport(ctx context.Context, threadReportID uint) error
+	DeleteCommunityReport(ctx context.Context, communityReportID uint) error
+	DeleteCommentReport(ctx context.Context, commentReportID uint) error
 }
@@ -1,29 +1,34 @@
 package mysql
 
 import (
+	"context"
 	"errors"
-	"fmt"
+	"log/slog"
 	communityEntity "macaiki/internal/community/entity"
 	threadEntity "macaiki/internal/thread/entity"
 	"macaiki/internal/user"
 	"macaiki/internal/user/entity"
+	"macaiki/pkg/logger"
 	"macaiki/pkg/utils"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
 )
 
 type MysqlUserRepository struct {
-	Db *gorm.DB
+	Db     *gorm.DB
+	logger *slog.Logger
 }
 
-func NewMysqlUserRepository(Db *gorm.DB) user.UserRepository {
-	return &MysqlUserRepository{Db}
+func NewMysqlUserRepository(Db *gorm.DB, log *slog.Logger) user.UserRepository {
+	return &MysqlUserRepository{Db, logger.OrDefault(log)}
 }
 
-func (ur *MysqlUserRepository) GetAllWithDetail(userID uint, search string) ([]entity.User, error) {
+func (ur *MysqlUserRepository) GetAllWithDetail(ctx context.Context, userID uint, search string) ([]entity.User, error) {
 	users := []entity.User{}
 
-	res := ur.Db.Raw("SELECT u.*, !ISNULL(uf.user_id) AS is_followed, (u.id = ?) AS is_mine FROM `users` AS u LEFT JOIN (SELECT * FROM user_followers WHERE follower_id = ?) AS uf ON u.id = uf.user_id WHERE u.deleted_at IS NULL AND (u.username LIKE ? OR u.name LIKE ?) ", userID, userID, "%"+search+"%", "%"+search+"%").Find(&users)
+	res := ur.Db.WithContext(ctx).Raw("SELECT u.*, !ISNULL(uf.user_id) AS is_followed, (u.id = ?) AS is_mine FROM `users` AS u LEFT JOIN (SELECT * FROM user_followers WHERE follower_id = ?) AS uf ON u.id = uf.user_id WHERE u.deleted_at IS NULL AND (u.username LIKE ? OR u.name LIKE ?) ", userID, userID, "%"+search+"%", "%"+search+"%").Find(&users)
 	err := res.Error
 	if err != nil {
 		return []entity.User{}, err
@@ -32,8 +37,8 @@ func (ur *MysqlUserRepository) GetAllWithDetail(userID uint, search string) ([]e
 	return users, nil
 }
 
-func (ur *MysqlUserRepository) Store(user entity.User) error {
-	res := ur.Db.Create(&user)
+func (ur *MysqlUserRepository) Store(ctx context.Context, user entity.User) error {
+	res := ur.Db.WithContext(ctx).Create(&user)
 	err := res.Error
 	if err != nil {
 		return err
@@ -42,9 +47,9 @@ func (ur *MysqlUserRepository) Store(user entity.User) error {
 	return nil
 }
 
-func (ur *MysqlUserRepository) Get(id uint) (entity.User, error) {
+func (ur *MysqlUserRepository) Get(ctx context.Context, id uint) (entity.User, error) {
 	user := entity.User{}
-	res := ur.Db.Find(&user, id)
+	res := ur.Db.WithContext(ctx).Find(&user, id)
 	err := res.Error
 
 	if err != nil {
@@ -54,10 +59,10 @@ func (ur *MysqlUserRepository) Get(id uint) (entity.User, error) {
 	return user, nil
 }
 
-func (ur *MysqlUserRepository) GetWithDetail(id, tokenID uint) (entity.User, error) {
+func (ur *MysqlUserRepository) GetWithDetail(ctx context.Context, id, tokenID uint) (entity.User, error) {
 	user := entity.User{}
 
-	res := ur.Db.Raw("SELECT u.*, !ISNULL(uf.user_id) AS is_followed, (u.id = ?) AS is_mine  FROM `users` AS u LEFT JOIN (SELECT * FROM user_followers WHERE follower_id = ?) AS uf ON u.id = uf.user_id WHERE u.deleted_at IS NULL AND u.id = ?", tokenID, tokenID, id).Find(&user)
+	res := ur.Db.WithContext(ctx).Raw("SELECT u.*, !ISNULL(uf.user_id) AS is_followed, (u.id = ?) AS is_mine  FROM `users` AS u LEFT JOIN (SELECT * FROM user_followers WHERE follower_id = ?) AS uf ON u.id = uf.user_id WHERE u.deleted_at IS NULL AND u.id = ?", tokenID, tokenID, id).Find(&user)
 	err := res.Error
 
 	if err != nil {
@@ -67,13 +72,13 @@ func (ur *MysqlUserRepository) GetWithDetail(id, tokenID uint) (entity.User, err
 	return user, nil
 }
 
-func (ur *MysqlUserRepository) Update(userDB *entity.User, user entity.User) (entity.User, error) {
+func (ur *MysqlUserRepository) Update(ctx context.Context, userDB *entity.User, user entity.User) (entity.User, error) {
 	if user.Password == "" {
 		user.Password = userDB.Password
 	}
 	user.ID = userDB.ID
 
-	res := ur.Db.Model(&userDB).Updates(user)
+	res := ur.Db.WithContext(ctx).Model(&userDB).Updates(user)
 	err := res.Error
 	if err != nil {
 		return entity.User{}, err
@@ -82,13 +87,13 @@ func (ur *MysqlUserRepository) Update(userDB *entity.User, user entity.User) (en
 	return user, nil
 }
 
-func (ur *MysqlUserRepository) Delete(id uint) error {
-	user, err := ur.Get(id)
+func (ur *MysqlUserRepository) Delete(ctx context.Context, id uint) error {
+	user, err := ur.Get(ctx, id)
 	if err != nil {
 		return err
 	}
 
-	res := ur.Db.Delete(&user, "id = ?", id)
+	res := ur.Db.WithContext(ctx).Delete(&user, "id = ?", id)
 	err = res.Error
 	if err != nil {
 		return err
@@ -96,10 +101,10 @@ func (ur *MysqlUserRepository) Delete(id uint) error {
 	return nil
 }
 
-func (ur *MysqlUserRepository) GetByEmail(email string) (entity.User, error) {
+func (ur *MysqlUserRepository) GetByEmail(ctx context.Context, email string) (entity.User, error) {
 	user := entity.User{}
 
-	res := ur.Db.Find(&user, "email = ?", email)
+	res := ur.Db.WithContext(ctx).Find(&user, "email = ?", email)
 	err := res.Error
 	if err != nil {
 		return entity.User{}, err
@@ -108,10 +113,10 @@ func (ur *MysqlUserRepository) GetByEmail(email string) (entity.User, error) {
 	return user, nil
 }
 
-func (ur *MysqlUserRepository) GetByUsername(username string) (entity.User, error) {
+func (ur *MysqlUserRepository) GetByUsername(ctx context.Context, username string) (entity.User, error) {
 	user := entity.User{}
 
-	res := ur.Db.Find(&user, "username = ?", username)
+	res := ur.Db.WithContext(ctx).Find(&user, "username = ?", username)
 	err := res.Error
 	if err != nil {
 		return entity.User{}, err
@@ -120,8 +125,8 @@ func (ur *MysqlUserRepository) GetByUsername(username string) (entity.User, erro
 	return user, nil
 }
 
-func (ur *MysqlUserRepository) Follow(user, userFollower entity.User) (entity.User, error) {
-	err := ur.Db.Model(&user).Association("Followers").Append(&userFollower)
+func (ur *MysqlUserRepository) Follow(ctx context.Context, user, userFollower entity.User) (entity.User, error) {
+	err := ur.Db.WithContext(ctx).Model(&user).Association("Followers").Append(&userFollower)
 	if err != nil {
 		return entity.User{}, err
 	}
@@ -129,8 +134,8 @@ func (ur *MysqlUserRepository) Follow(user, userFollower entity.User) (entity.Us
 	return user, nil
 }
 
-func (ur *MysqlUserRepository) Unfollow(user, userFollower entity.User) (entity.User, error) {
-	err := ur.Db.Model(&user).Association("Followers").Delete(&userFollower)
+func (ur *MysqlUserRepository) Unfollow(ctx context.Context, user, userFollower entity.User) (entity.User, error) {
+	err := ur.Db.WithContext(ctx).Model(&user).Association("Followers").Delete(&userFollower)
 	if err != nil {
 		return entity.User{}, err
 	}
@@ -138,9 +143,9 @@ func (ur *MysqlUserRepository) Unfollow(user, userFollower entity.User) (entity.
 	return user, nil
 }
 
-func (ur *MysqlUserRepository) GetFollowerNumber(id uint) (int, error) {
+func (ur *MysqlUserRepository) GetFollowerNumber(ctx context.Context, id uint) (int, error) {
 	var count int64
-	res := ur.Db.Raw("SELECT COUNT(*) FROM `users` LEFT JOIN `user_followers` `Followers` ON `users`.`id` = `Followers`.`follower_id` WHERE `Followers`.`user_id` = ? AND `users`.`deleted_at` IS NULL", id).Scan(&count)
+	res := ur.Db.WithContext(ctx).Raw("SELECT COUNT(*) FROM `users` LEFT JOIN `user_followers` `Followers` ON `users`.`id` = `Followers`.`follower_id` WHERE `Followers`.`user_id` = ? AND `users`.`deleted_at` IS NULL", id).Scan(&count)
 	err := res.Error
 	if err != nil {
 		return 0, err
@@ -148,9 +153,9 @@ func (ur *MysqlUserRepository) GetFollowerNumber(id uint) (int, error) {
 	return int(count), nil
 }
 
-func (ur *MysqlUserRepository) GetFollowingNumber(id uint) (int, error) {
+func (ur *MysqlUserRepository) GetFollowingNumber(ctx context.Context, id uint) (int, error) {
 	var count int64
-	res := ur.Db.Raw("SELECT COUNT(*) FROM `users` LEFT JOIN `user_followers` `Followers` ON `users`.`id` = `Followers`.`user_id` WHERE `Followers`.`follower_id` = ? AND `users`.`deleted_at` IS NULL", id).Scan(&count)
+	res := ur.Db.WithContext(ctx).Raw("SELECT COUNT(*) FROM `users` LEFT JOIN `user_followers` `Followers` ON `users`.`id` = `Followers`.`user_id` WHERE `Followers`.`follower_id` = ? AND `users`.`deleted_at` IS NULL", id).Scan(&count)
 	err := res.Error
 	if err != nil {
 		return 0, err
@@ -158,9 +163,9 @@ func (ur *MysqlUserRepository) GetFollowingNumber(id uint) (int, error) {
 	return int(count), nil
 }
 
-func (ur *MysqlUserRepository) GetThreadsNumber(id uint) (int, error) {
+func (ur *MysqlUserRepository) GetThreadsNumber(ctx context.Context, id uint) (int, error) {
 	var count int64
-	res := ur.Db.Table("threads").Where("user_id = ?", id).Count(&count)
+	res := ur.Db.WithContext(ctx).Table("threads").Where("user_id = ? AND status = ?", id, "published").Count(&count)
 	err := res.Error
 	if err != nil {
 		return 0, err
@@ -168,10 +173,10 @@ func (ur *MysqlUserRepository) GetThreadsNumber(id uint) (int, error) {
 	return int(count), nil
 }
 
-func (ur *MysqlUserRepository) GetFollower(userID, getFollowingUserID uint) ([]entity.User, error) {
+func (ur *MysqlUserRepository) GetFollower(ctx context.Context, userID, getFollowingUserID uint) ([]entity.User, error) {
 	users := []entity.User{}
 
-	res := ur.Db.Raw("SELECT u.*, !ISNULL(uf2.user_id) AS is_followed, (u.id=?) AS is_mine FROM users AS u LEFT JOIN user_followers AS uf ON u.id = uf.follower_id LEFT JOIN (SELECT * FROM user_followers WHERE user_followers.follower_id = ?) AS uf2 ON u.id = uf2.user_id WHERE uf.user_id = ? AND u.deleted_at IS NULL", userID, userID, getFollowingUserID).Scan(&users)
+	res := ur.Db.WithContext(ctx).Raw("SELECT u.*, !ISNULL(uf2.user_id) AS is_followed, (u.id=?) AS is_mine FROM users AS u LEFT JOIN user_followers AS uf ON u.id = uf.follower_id LEFT JOIN (SELECT * FROM user_followers WHERE user_followers.follower_id = ?) AS uf2 ON u.id = uf2.user_id WHERE uf.user_id = ? AND u.deleted_at IS NULL", userID, userID, getFollowingUserID).Scan(&users)
 	err := res.Error
 
 	if err != nil {
@@ -181,9 +186,9 @@ func (ur *MysqlUserRepository) GetFollower(userID, getFollowingUserID uint) ([]e
 	return users, nil
 }
 
-func (ur *MysqlUserRepository) GetFollowing(userID, getFollowingUserID uint) ([]entity.User, error) {
+func (ur *MysqlUserRepository) GetFollowing(ctx context.Context, userID, getFollowingUserID uint) ([]entity.User, error) {
 	users := []entity.User{}
-	res := ur.Db.Raw("SELECT u.*, !ISNULL(uf2.user_id) AS is_followed, (u.id=?) AS is_mine FROM users AS u LEFT JOIN user_followers uf ON u.id = uf.user_id LEFT JOIN (SELECT * FROM user_followers WHERE user_followers.follower_id = ?) AS uf2 ON u.id = uf2.user_id WHERE uf.follower_id = ? AND u.deleted_at IS NULL", userID, userID, getFollowingUserID).Scan(&users)
+	res := ur.Db.WithContext(ctx).Raw("SELECT u.*, !ISNULL(uf2.user_id) AS is_followed, (u.id=?) AS is_mine FROM users AS u LEFT JOIN user_followers uf ON u.id = uf.user_id LEFT JOIN (SELECT * FROM user_followers WHERE user_followers.follower_id = ?) AS uf2 ON u.id = uf2.user_id WHERE uf.follower_id = ? AND u.deleted_at IS NULL", userID, userID, getFollowingUserID).Scan(&users)
 	err := res.Error
 
 	if err != nil {
@@ -193,8 +198,8 @@ func (ur *MysqlUserRepository) GetFollowing(userID, getFollowingUserID uint) ([]
 	return users, nil
 }
 
-func (ur *MysqlUserRepository) SetUserImage(id uint, imageURL string, tableName string) error {
-	res := ur.Db.Model(&entity.User{}).Where("id = ?", id).Update(tableName, imageURL)
+func (ur *MysqlUserRepository) SetUserImage(ctx context.Context, id uint, imageURL string, tableName string) error {
+	res := ur.Db.WithContext(ctx).Model(&entity.User{}).Where("id = ?", id).Update(tableName, imageURL)
 
 	if res.Error != nil {
 		return res.Error
@@ -207,8 +212,8 @@ func (ur *MysqlUserRepository) SetUserImage(id uint, imageURL string, tableName
 	return nil
 }
 
-func (ur *MysqlUserRepository) StoreReport(userReport entity.UserReport) error {
-	res := ur.Db.Create(&userReport)
+func (ur *MysqlUserRepository) StoreReport(ctx context.Context, userReport entity.UserReport) error {
+	res := ur.Db.WithContext(ctx).Create(&userReport)
 	err := res.Error
 	if err != nil {
 		return err
@@ -217,30 +222,453 @@ func (ur *MysqlUserRepository) StoreReport(userReport entity.UserReport) error {
 	return nil
 }
 
-func (ur *MysqlUserRepository) StoreOTP(VerifyEmail entity.VerificationEmail) error {
-	res := ur.Db.Create(&VerifyEmail)
-	err := res.Error
+// StoreOTP stores a new code and marks every pending code for the same
+// email as used, so only the latest code can be redeemed
+func (ur *MysqlUserRepository) StoreOTP(ctx context.Context, VerifyEmail entity.VerificationEmail) error {
+	err := ur.Db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&entity.VerificationEmail{}).
+			Where("email = ? AND used_at IS NULL", VerifyEmail.Email).
+			Update("used_at", time.Now())
+		if res.Error != nil {
+			return res.Error
+		}
+
+		return tx.Create(&VerifyEmail).Error
+	})
 	if err != nil {
-		return err
+		ur.logger.ErrorContext(ctx, "query failed", "op", "StoreOTP", "err", err)
+		return utils.ErrInternalServerError
 	}
 
 	return nil
 }
 
-func (ur *MysqlUserRepository) GetOTP(email string) (entity.VerificationEmail, error) {
+// GetOTP returns the latest code for email that has not been used or
+// replaced yet
+func (ur *MysqlUserRepository) GetOTP(ctx context.Context, email string) (entity.VerificationEmail, error) {
 	VerifyEmail := entity.VerificationEmail{}
-	res := ur.Db.Where("email = ?", email).Order("id desc").First(&VerifyEmail)
-	err := res.Error
-	if err != nil {
-		return entity.VerificationEmail{}, err
+	res := ur.Db.WithContext(ctx).Where("email = ? AND used_at IS NULL", email).Order("id desc").Limit(1).Find(&VerifyEmail)
+	if res.Error != nil {
+		ur.logger.ErrorContext(ctx, "query failed", "op", "GetOTP", "err", res.Error)
+		return entity.VerificationEmail{}, utils.ErrInternalServerError
+	}
+
+	if res.RowsAffected == 0 {
+		return entity.VerificationEmail{}, utils.ErrNotFound
 	}
 
 	return VerifyEmail, nil
 }
 
-func (ur *MysqlUserRepository) GetReports() ([]entity.BriefReport, error) {
+// ClaimOTPAttempt counts a guess against an OTP in a single statement, so
+// parallel guesses cannot get past maxAttempts. It returns ErrNotFound once
+// every attempt is used.
+func (ur *MysqlUserRepository) ClaimOTPAttempt(ctx context.Context, otpID uint, maxAttempts int) error {
+	res := ur.Db.WithContext(ctx).Model(&entity.VerificationEmail{}).Where("id = ? AND attempts < ?", otpID, maxAttempts).Update("attempts", gorm.Expr("attempts + 1"))
+	if res.Error != nil {
+		ur.logger.ErrorContext(ctx, "query failed", "op", "ClaimOTPAttempt", "err", res.Error)
+		return utils.ErrInternalServerError
+	}
+	if res.RowsAffected == 0 {
+		return utils.ErrNotFound
+	}
+
+	return nil
+}
+
+func (ur *MysqlUserRepository) ConsumeOTP(ctx context.Context, otpID uint) error {
+	res := ur.Db.WithContext(ctx).Model(&entity.VerificationEmail{}).Where("id = ? AND used_at IS NULL", otpID).Update("used_at", time.Now())
+	if res.Error != nil {
+		ur.logger.ErrorContext(ctx, "query failed", "op", "ConsumeOTP", "err", res.Error)
+		return utils.ErrInternalServerError
+	}
+
+	// a concurrent request already redeemed the code
+	if res.RowsAffected == 0 {
+		return utils.ErrNotFound
+	}
+
+	return nil
+}
+
+// StoreEmailChange stores a new pending change and drops any earlier one
+// for the same user that was not confirmed yet
+func (ur *MysqlUserRepository) StoreEmailChange(ctx context.Context, change entity.EmailChange) error {
+	err := ur.Db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("user_id = ? AND confirmed_at IS NULL", change.UserID).Delete(&entity.EmailChange{})
+		if res.Error != nil {
+			return res.Error
+		}
+
+		return tx.Create(&change).Error
+	})
+	if err != nil {
+		ur.logger.ErrorContext(ctx, "query failed", "op", "StoreEmailChange", "err", err)
+		return utils.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (ur *MysqlUserRepository) GetEmailChangeByConfirmToken(ctx context.Context, tokenHash string) (entity.EmailChange, error) {
+	return ur.getEmailChange(ctx, "confirm_token_hash = ?", tokenHash)
+}
+
+func (ur *MysqlUserRepository) GetEmailChangeByRevertToken(ctx context.Context, tokenHash string) (entity.EmailChange, error) {
+	return ur.getEmailChange(ctx, "revert_token_hash = ?", tokenHash)
+}
+
+func (ur *MysqlUserRepository) getEmailChange(ctx context.Context, query string, args ...interface{}) (entity.EmailChange, error) {
+	change := entity.EmailChange{}
+	res := ur.Db.WithContext(ctx).Where(query, args...).Limit(1).Find(&change)
+	if res.Error != nil {
+		ur.logger.ErrorContext(ctx, "query failed", "op", "GetEmailChange", "err", res.Error)
+		return entity.EmailChange{}, utils.ErrInternalServerError
+	}
+
+	if res.RowsAffected == 0 {
+		return entity.EmailChange{}, utils.ErrNotFound
+	}
+
+	return change, nil
+}
+
+// ApplyEmailChange moves the user to the new address. The new address is
+// verified by the confirmation link, so the verification time is reset to
+// the moment of confirmation.
+func (ur *MysqlUserRepository) ApplyEmailChange(ctx context.Context, change entity.EmailChange) error {
+	err := ur.Db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		res := tx.Model(&entity.EmailChange{}).
+			Where("id = ? AND confirmed_at IS NULL AND reverted_at IS NULL", change.ID).
+			Update("confirmed_at", now)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return utils.ErrNotFound
+		}
+
+		res = tx.Model(&entity.User{}).
+			Where("id = ? AND email = ?", change.UserID, change.OldEmail).
+			Updates(map[string]interface{}{"email": change.NewEmail, "email_verified_at": now})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return utils.ErrConflict
+		}
+
+		return nil
+	})
+
+	return ur.emailChangeError(ctx, "ApplyEmailChange", err)
+}
+
+// RevertEmailChange cancels a pending change, or moves an applied change
+// back to the old address which the revert link just proved ownership of
+func (ur *MysqlUserRepository) RevertEmailChange(ctx context.Context, change entity.EmailChange) error {
+	err := ur.Db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		res := tx.Model(&entity.EmailChange{}).
+			Where("id = ? AND reverted_at IS NULL", change.ID).
+			Update("reverted_at", now)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return utils.ErrNotFound
+		}
+
+		if change.ConfirmedAt == nil {
+			return nil
+		}
+
+		res = tx.Model(&entity.User{}).
+			Where("id = ? AND email = ?", change.UserID, change.NewEmail).
+			Updates(map[string]interface{}{"email": change.OldEmail, "email_verified_at": now})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return utils.ErrConflict
+		}
+
+		return nil
+	})
+
+	return ur.emailChangeError(ctx, "RevertEmailChange", err)
+}
+
+func (ur *MysqlUserRepository) emailChangeError(ctx context.Context, op string, err error) error {
+	if err == nil || err == utils.ErrNotFound || err == utils.ErrConflict {
+		return err
+	}
+
+	if strings.HasPrefix(err.Error(), "Error 1062: Duplicate entry") {
+		return utils.ErrEmailAlreadyUsed
+	}
+
+	ur.logger.ErrorContext(ctx, "query failed", "op", op, "err", err)
+	return utils.ErrInternalServerError
+}
+
+func (ur *MysqlUserRepository) GetTwoFactor(ctx context.Context, userID uint) (entity.TwoFactor, error) {
+	twoFactor := entity.TwoFactor{}
+	res := ur.Db.WithContext(ctx).Where("user_id = ?", userID).Limit(1).Find(&twoFactor)
+	if res.Error != nil {
+		ur.logger.ErrorContext(ctx, "query failed", "op", "GetTwoFactor", "err", res.Error)
+		return entity.TwoFactor{}, utils.ErrInternalServerError
+	}
+
+	if res.RowsAffected == 0 {
+		return entity.TwoFactor{}, utils.ErrNotFound
+	}
+
+	return twoFactor, nil
+}
+
+// StoreTwoFactor saves a pending secret, replacing an earlier enrollment
+// that was never finished. An enabled secret is left alone.
+func (ur *MysqlUserRepository) StoreTwoFactor(ctx context.Context, twoFactor entity.TwoFactor) error {
+	err := ur.Db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("user_id = ? AND enabled_at IS NULL", twoFactor.UserID).Delete(&entity.TwoFactor{})
+		if res.Error != nil {
+			return res.Error
+		}
+
+		return tx.Create(&twoFactor).Error
+	})
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "Error 1062: Duplicate entry") {
+			return utils.ErrTwoFactorAlreadyEnabled
+		}
+		ur.logger.ErrorContext(ctx, "query failed", "op", "StoreTwoFactor", "err", err)
+		return utils.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// EnableTwoFactor turns on the pending secret and replaces the recovery
+// codes, step is the time step of the code that confirmed the enrollment
+func (ur *MysqlUserRepository) EnableTwoFactor(ctx context.Context, userID uint, step int64, codeHashes []string) error {
+	err := ur.Db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&entity.TwoFactor{}).
+			Where("user_id = ? AND enabled_at IS NULL", userID).
+			Updates(map[string]interface{}{"enabled_at": time.Now(), "last_used_step": step})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return utils.ErrConflict
+		}
+
+		return replaceRecoveryCodes(tx, userID, codeHashes)
+	})
+	if errors.Is(err, utils.ErrConflict) {
+		return utils.ErrConflict
+	}
+	if err != nil {
+		ur.logger.ErrorContext(ctx, "query failed", "op", "EnableTwoFactor", "err", err)
+		return utils.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// UseTwoFactorStep records the time step of an accepted code. It fails with
+// utils.ErrConflict when that step or a later one was already used.
+func (ur *MysqlUserRepository) UseTwoFactorStep(ctx context.Context, userID uint, step int64) error {
+	res := ur.Db.WithContext(ctx).Model(&entity.TwoFactor{}).
+		Where("user_id = ? AND last_used_step < ?", userID, step).
+		Update("last_used_step", step)
+	if res.Error != nil {
+		ur.logger.ErrorContext(ctx, "query failed", "op", "UseTwoFactorStep", "err", res.Error)
+		return utils.ErrInternalServerError
+	}
+
+	if res.RowsAffected == 0 {
+		return utils.ErrConflict
+	}
+
+	return nil
+}
+
+func (ur *MysqlUserRepository) UseRecoveryCode(ctx context.Context, userID uint, codeHash string) error {
+	res := ur.Db.WithContext(ctx).Model(&entity.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	if res.Error != nil {
+		ur.logger.ErrorContext(ctx, "query failed", "op", "UseRecoveryCode", "err", res.Error)
+		return utils.ErrInternalServerError
+	}
+
+	if res.RowsAffected == 0 {
+		return utils.ErrNotFound
+	}
+
+	return nil
+}
+
+func (ur *MysqlUserRepository) ReplaceRecoveryCodes(ctx context.Context, userID uint, codeHashes []string) error {
+	err := ur.Db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return replaceRecoveryCodes(tx, userID, codeHashes)
+	})
+	if err != nil {
+		ur.logger.ErrorContext(ctx, "query failed", "op", "ReplaceRecoveryCodes", "err", err)
+		return utils.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func replaceRecoveryCodes(tx *gorm.DB, userID uint, codeHashes []string) error {
+	if err := tx.Where("user_id = ?", userID).Delete(&entity.RecoveryCode{}).Error; err != nil {
+		return err
+	}
+
+	codes := make([]entity.RecoveryCode, 0, len(codeHashes))
+	for _, hash := range codeHashes {
+		codes = append(codes, entity.RecoveryCode{UserID: userID, CodeHash: hash})
+	}
+	if len(codes) == 0 {
+		return nil
+	}
+	return tx.Create(&codes).Error
+}
+
+func (ur *MysqlUserRepository) CountRecoveryCodes(ctx context.Context, userID uint) (int, error) {
+	var count int64
+	res := ur.Db.WithContext(ctx).Model(&entity.RecoveryCode{}).Where("user_id = ? AND used_at IS NULL", userID).Count(&count)
+	if res.Error != nil {
+		ur.logger.ErrorContext(ctx, "query failed", "op", "CountRecoveryCodes", "err", res.Error)
+		return 0, utils.ErrInternalServerError
+	}
+
+	return int(count), nil
+}
+
+func (ur *MysqlUserRepository) DeleteTwoFactor(ctx context.Context, userID uint) error {
+	err := ur.Db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&entity.TwoFactor{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("user_id = ?", userID).Delete(&entity.RecoveryCode{}).Error
+	})
+	if err != nil {
+		ur.logger.ErrorContext(ctx, "query failed", "op", "DeleteTwoFactor", "err", err)
+		return utils.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (ur *MysqlUserRepository) SetTwoFactorRequired(ctx context.Context, userID uint, required bool) error {
+	err := ur.Db.WithContext(ctx).Model(&entity.User{}).Where("id = ?", userID).Update("two_factor_required", required).Error
+	if err != nil {
+		ur.logger.ErrorContext(ctx, "query failed", "op", "SetTwoFactorRequired", "err", err)
+		return utils.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// SetDeletionSchedule starts the deletion grace period of the account, a nil
+// scheduledAt cancels it
+func (ur *MysqlUserRepository) SetDeletionSchedule(ctx context.Context, userID uint, scheduledAt *time.Time) error {
+	res := ur.Db.WithContext(ctx).Model(&entity.User{}).Where("id = ?", userID).Update("deletion_scheduled_at", scheduledAt)
+	if res.Error != nil {
+		ur.logger.ErrorContext(ctx, "query failed", "op", "SetDeletionSchedule", "err", res.Error)
+		return utils.ErrInternalServerError
+	}
+
+	if res.RowsAffected == 0 {
+		return utils.ErrNotFound
+	}
+
+	return nil
+}
+
+// AnonymizeUser replaces the personal data of the user with placeholder and
+// removes everything that only concerns them. Threads, comments and votes
+// are kept. It returns the storage keys of the data exports of the user so
+// the files can be removed as well.
+func (ur *MysqlUserRepository) AnonymizeUser(ctx context.Context, userID uint, placeholder entity.User) ([]string, error) {
+	exportKeys := []string{}
+	err := ur.Db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Table("exports").Where("user_id = ? AND file_key <> ''", userID).Pluck("file_key", &exportKeys).Error
+		if err != nil {
+			return err
+		}
+
+		cleanups := []struct {
+			query string
+			args  []interface{}
+		}{
+			{"DELETE FROM verification_emails WHERE email = (SELECT email FROM users WHERE id = ?)", []interface{}{userID}},
+			{"DELETE FROM email_changes WHERE user_id = ?", []interface{}{userID}},
+			{"DELETE FROM two_factors WHERE user_id = ?", []interface{}{userID}},
+			{"DELETE FROM recovery_codes WHERE user_id = ?", []interface{}{userID}},
+			{"DELETE FROM identities WHERE user_id = ?", []interface{}{userID}},
+			{"DELETE FROM auth_states WHERE user_id = ?", []interface{}{userID}},
+			{"DELETE FROM exports WHERE user_id = ?", []interface{}{userID}},
+			{"DELETE FROM notifications WHERE user_id = ?", []interface{}{userID}},
+			{"DELETE FROM saved_threads WHERE user_id = ?", []interface{}{userID}},
+			{"DELETE FROM saved_thread_collections WHERE user_id = ?", []interface{}{userID}},
+			{"DELETE FROM thread_followers WHERE user_id = ?", []interface{}{userID}},
+			{"DELETE FROM hidden_threads WHERE user_id = ?", []interface{}{userID}},
+			{"DELETE FROM seen_threads WHERE user_id = ?", []interface{}{userID}},
+			{"DELETE FROM user_followers WHERE user_id = ? OR follower_id = ?", []interface{}{userID, userID}},
+			{"DELETE FROM community_followers WHERE user_id = ?", []interface{}{userID}},
+			{"DELETE FROM muted_communities WHERE user_id = ?", []interface{}{userID}},
+			{"DELETE FROM community_moderators WHERE user_id = ?", []interface{}{userID}},
+		}
+		for _, cleanup := range cleanups {
+			if err := tx.Exec(cleanup.query, cleanup.args...).Error; err != nil {
+				return err
+			}
+		}
+
+		res := tx.Model(&entity.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+			"email":                 placeholder.Email,
+			"username":              placeholder.Username,
+			"password":              "",
+			"name":                  placeholder.Name,
+			"profile_image_url":     placeholder.ProfileImageUrl,
+			"background_image_url":  placeholder.BackgroundImageUrl,
+			"bio":                   "",
+			"profession":            "",
+			"email_verified_at":     nil,
+			"two_factor_required":   false,
+			"deletion_scheduled_at": nil,
+			"deleted_at":            time.Now(),
+		})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return utils.ErrNotFound
+		}
+		return nil
+	})
+	if errors.Is(err, utils.ErrNotFound) {
+		return nil, utils.ErrNotFound
+	}
+	if err != nil {
+		ur.logger.ErrorContext(ctx, "query failed", "op", "AnonymizeUser", "err", err)
+		return nil, utils.ErrInternalServerError
+	}
+
+	return exportKeys, nil
+}
+
+func (ur *MysqlUserRepository) GetReports(ctx context.Context) ([]entity.BriefReport, error) {
 	var reports []entity.BriefReport
-	res := ur.Db.Raw("SELECT tr.id AS 'thread_reports_id', NULL AS 'user_reports_id', NULL AS 'comment_reports_id', tr.created_at, tr.user_id, tr.thread_id, NULL AS reported_user_id, NULL as comment_id, rc.name AS report_category, u.username, u.profile_image_url, 'threads' AS type FROM thread_reports tr INNER JOIN report_categories rc ON tr.report_category_id = rc.id INNER JOIN users u ON u.id = tr.user_id WHERE tr.deleted_at IS NULL AND u.`role` = 'Moderator' UNION SELECT NULL AS 'thread_reports_id', ur.id AS 'user_reports_id', NULL AS 'comment_reports_id', ur.created_at, ur.user_id, NULL AS thread_id, ur.reported_user_id, NULL AS comment_id, rc.name AS report_category, u.username, u.profile_image_url, 'users' AS type FROM user_reports ur INNER JOIN report_categories rc ON ur.report_category_id = rc.id INNER JOIN users u ON u.id = ur.user_id WHERE ur.deleted_at IS NULL AND u.`role` = 'Moderator' UNION SELECT NULL AS 'thread_reports_id', NULL AS 'user_reports_id', cr.id AS 'comment_reports_id', cr.created_at, cr.user_id, NULL AS thread_id, NULL AS reported_user_id, cr.comment_id, rc.name AS report_category, u.username, u.profile_image_url, 'comments' AS type FROM comment_reports cr INNER JOIN report_categories rc ON cr.report_category_id = rc.id INNER JOIN users u ON u.id = cr.user_id WHERE cr.deleted_at IS NULL AND u.`role` = 'Moderator';").Scan(&reports)
+	res := ur.Db.WithContext(ctx).Raw("SELECT tr.id AS 'thread_reports_id', NULL AS 'user_reports_id', NULL AS 'comment_reports_id', tr.created_at, tr.user_id, tr.thread_id, NULL AS reported_user_id, NULL as comment_id, rc.name AS report_category, u.username, u.profile_image_url, 'threads' AS type FROM thread_reports tr INNER JOIN report_categories rc ON tr.report_category_id = rc.id INNER JOIN users u ON u.id = tr.user_id WHERE tr.deleted_at IS NULL AND u.`role` = 'Moderator' UNION SELECT NULL AS 'thread_reports_id', ur.id AS 'user_reports_id', NULL AS 'comment_reports_id', ur.created_at, ur.user_id, NULL AS thread_id, ur.reported_user_id, NULL AS comment_id, rc.name AS report_category, u.username, u.profile_image_url, 'users' AS type FROM user_reports ur INNER JOIN report_categories rc ON ur.report_category_id = rc.id INNER JOIN users u ON u.id = ur.user_id WHERE ur.deleted_at IS NULL AND u.`role` = 'Moderator' UNION SELECT NULL AS 'thread_reports_id', NULL AS 'user_reports_id', cr.id AS 'comment_reports_id', cr.created_at, cr.user_id, NULL AS thread_id, NULL AS reported_user_id, cr.comment_id, rc.name AS report_category, u.username, u.profile_image_url, 'comments' AS type FROM comment_reports cr INNER JOIN report_categories rc ON cr.report_category_id = rc.id INNER JOIN users u ON u.id = cr.user_id WHERE cr.deleted_at IS NULL AND u.`role` = 'Moderator';").Scan(&reports)
 
 	if res.Error != nil {
 		return []entity.BriefReport{}, utils.ErrInternalServerError
@@ -249,10 +677,10 @@ func (ur *MysqlUserRepository) GetReports() ([]entity.BriefReport, error) {
 	return reports, nil
 }
 
-func (ur *MysqlUserRepository) GetDashboardAnalytics() (entity.AdminDashboardAnalytics, error) {
+func (ur *MysqlUserRepository) GetDashboardAnalytics(ctx context.Context) (entity.AdminDashboardAnalytics, error) {
 	var adminAnalytics entity.AdminDashboardAnalytics
 
-	res := ur.Db.Raw("SELECT (SELECT COUNT(*) FROM users WHERE deleted_at IS NULL) AS users_count, (SELECT COUNT(*) FROM users WHERE `role` = 'Moderator') AS moderators_count, (SELECT COUNT(*) FROM thread_reports WHERE deleted_at IS NULL) + (SELECT COUNT(*) FROM user_reports WHERE deleted_at IS NULL) + (SELECT COUNT(*) FROM comment_reports WHERE deleted_at IS NULL) AS reports_count;").Scan(&adminAnalytics)
+	res := ur.Db.WithContext(ctx).Raw("SELECT (SELECT COUNT(*) FROM users WHERE deleted_at IS NULL) AS users_count, (SELECT COUNT(*) FROM users WHERE `role` = 'Moderator') AS moderators_count, (SELECT COUNT(*) FROM thread_reports WHERE deleted_at IS NULL) + (SELECT COUNT(*) FROM user_reports WHERE deleted_at IS NULL) + (SELECT COUNT(*) FROM comment_reports WHERE deleted_at IS NULL) AS reports_count;").Scan(&adminAnalytics)
 
 	if res.Error != nil {
 		return entity.AdminDashboardAnalytics{}, utils.ErrInternalServerError
@@ -261,10 +689,10 @@ func (ur *MysqlUserRepository) GetDashboardAnalytics() (entity.AdminDashboardAna
 	return adminAnalytics, nil
 }
 
-func (ur *MysqlUserRepository) GetReportedThread(threadReportID uint) (entity.ReportedThread, error) {
+func (ur *MysqlUserRepository) GetReportedThread(ctx context.Context, threadReportID uint) (entity.ReportedThread, error) {
 	var reportedThread entity.ReportedThread
 
-	res := ur.Db.Raw("SELECT tr.id, t.title AS thread_title, t.body AS thread_body, t.image_url AS thread_image_url, t.created_at AS thread_created_at, t2.likes_count, u.username AS reported_username, u.profile_image_url AS reported_profile_image_url, u.profession AS reported_user_profession, rc.name AS report_category, tr.created_at AS report_created_at, u2.username, u2.profile_image_url FROM thread_reports tr INNER JOIN threads t ON t.id = tr.thread_id LEFT JOIN (SELECT thread_id, COUNT(*) AS likes_count FROM thread_upvotes tu GROUP BY thread_id) AS t2 ON t.id = t2.thread_id INNER JOIN users u ON u.id = t.user_id INNER JOIN users u2 ON u2.id = tr.user_id INNER JOIN report_categories rc ON rc.id = tr.report_category_id WHERE tr.id = ?;", threadReportID).Scan(&reportedThread)
+	res := ur.Db.WithContext(ctx).Raw("SELECT tr.id, t.title AS thread_title, t.body AS thread_body, t.image_url AS thread_image_url, t.created_at AS thread_created_at, t.upvotes_count AS likes_count, u.username AS reported_username, u.profile_image_url AS reported_profile_image_url, u.profession AS reported_user_profession, rc.name AS report_category, tr.created_at AS report_created_at, u2.username, u2.profile_image_url FROM thread_reports tr INNER JOIN threads t ON t.id = tr.thread_id INNER JOIN users u ON u.id = t.user_id INNER JOIN users u2 ON u2.id = tr.user_id INNER JOIN report_categories rc ON rc.id = tr.report_category_id WHERE tr.id = ?;", threadReportID).Scan(&reportedThread)
 
 	if res.Error != nil {
 		return entity.ReportedThread{}, utils.ErrInternalServerError
@@ -277,10 +705,10 @@ func (ur *MysqlUserRepository) GetReportedThread(threadReportID uint) (entity.Re
 	return reportedThread, nil
 }
 
-func (ur *MysqlUserRepository) GetReportedCommunity(communityReportID uint) (entity.ReportedCommunity, error) {
+func (ur *MysqlUserRepository) GetReportedCommunity(ctx context.Context, communityReportID uint) (entity.ReportedCommunity, error) {
 	var reportedCommunity entity.ReportedCommunity
 
-	res := ur.Db.Raw("SELECT cr.id, cr.created_at AS report_created_at, c.name AS community_name, c.community_image_url, c.community_background_image_url, rc.name AS report_category, u.username, u.profile_image_url  FROM community_reports cr INNER JOIN communities c ON c.id = cr.community_reported_id INNER JOIN users u ON u.id = cr.user_id INNER JOIN report_categories rc ON rc.id = cr.report_category_id WHERE cr.id = ?;", communityReportID).Scan(&reportedCommunity)
+	res := ur.Db.WithContext(ctx).Raw("SELECT cr.id, cr.created_at AS report_created_at, c.name AS community_name, c.community_image_url, c.community_background_image_url, rc.name AS report_category, u.username, u.profile_image_url  FROM community_reports cr INNER JOIN communities c ON c.id = cr.community_reported_id INNER JOIN users u ON u.id = cr.user_id INNER JOIN report_categories rc ON rc.id = cr.report_category_id WHERE cr.id = ?;", communityReportID).Scan(&reportedCommunity)
 
 	if res.Error != nil {
 		return entity.ReportedCommunity{}, utils.ErrInternalServerError
@@ -293,10 +721,10 @@ func (ur *MysqlUserRepository) GetReportedCommunity(communityReportID uint) (ent
 	return reportedCommunity, nil
 }
 
-func (ur *MysqlUserRepository) GetReportedComment(commentReportID uint) (entity.ReportedComment, error) {
+func (ur *MysqlUserRepository) GetReportedComment(ctx context.Context, commentReportID uint) (entity.ReportedComment, error) {
 	var reportedComment entity.ReportedComment
 
-	res := ur.Db.Raw("SELECT cr.id, c.body AS comment_body, t2.likes_count, c.created_at AS comment_created_at, u.username, u.profile_image_url, u2.username AS reported_username, u2.profile_image_url AS reported_profile_image_url, rc.name AS report_category FROM comment_reports cr INNER JOIN comments c ON c.id = cr.comment_id INNER JOIN users u ON u.id = cr.user_id INNER JOIN users u2 ON c.user_id = u2.id INNER JOIN report_categories rc ON rc.id = cr.report_category_id LEFT JOIN (SELECT comment_id, COUNT(*) AS likes_count FROM comment_likes cl GROUP BY comment_id) AS t2 ON c.id = t2.comment_id WHERE cr.id = ?;", commentReportID).Scan(&reportedComment)
+	res := ur.Db.WithContext(ctx).Raw("SELECT cr.id, c.body AS comment_body, c.upvotes_count AS likes_count, c.created_at AS comment_created_at, u.username, u.profile_image_url, u2.username AS reported_username, u2.profile_image_url AS reported_profile_image_url, rc.name AS report_category FROM comment_reports cr INNER JOIN comments c ON c.id = cr.comment_id INNER JOIN users u ON u.id = cr.user_id INNER JOIN users u2 ON c.user_id = u2.id INNER JOIN report_categories rc ON rc.id = cr.report_category_id WHERE cr.id = ?;", commentReportID).Scan(&reportedComment)
 
 	if res.Error != nil {
 		return entity.ReportedComment{}, utils.ErrInternalServerError
@@ -309,10 +737,10 @@ func (ur *MysqlUserRepository) GetReportedComment(commentReportID uint) (entity.
 	return reportedComment, nil
 }
 
-func (ur *MysqlUserRepository) GetReportedUser(userReportID uint) (entity.ReportedUser, error) {
+func (ur *MysqlUserRepository) GetReportedUser(ctx context.Context, userReportID uint) (entity.ReportedUser, error) {
 	var reportedUser entity.ReportedUser
 
-	res := ur.Db.Raw("SELECT ur.id, u.username AS reported_user_username, u.name AS reported_user_name, u.profession AS reported_user_profession, u.bio AS reported_user_bio, u.profile_image_url AS reported_user_profile_image_url, u.background_image_url AS reported_user_background_url, u2.username AS reporting_user_username, u2.name AS reporting_user_name, reported_user_follower.followers_count, reported_user_following.following_count FROM user_reports ur INNER JOIN users u ON ur.reported_user_id = u.id INNER JOIN users u2 ON u2.id = ur.user_id LEFT JOIN (SELECT uf.follower_id, COUNT(*) AS following_count FROM user_followers uf GROUP BY uf.follower_id) reported_user_following ON reported_user_following.follower_id = ur.user_id LEFT JOIN (SELECT user_id, COUNT(*) AS followers_count FROM user_followers uf GROUP BY uf.user_id) reported_user_follower ON reported_user_follower.user_id = u.id WHERE ur.id = ?;", userReportID).Scan(&reportedUser)
+	res := ur.Db.WithContext(ctx).Raw("SELECT ur.id, u.username AS reported_user_username, u.name AS reported_user_name, u.profession AS reported_user_profession, u.bio AS reported_user_bio, u.profile_image_url AS reported_user_profile_image_url, u.background_image_url AS reported_user_background_url, u2.username AS reporting_user_username, u2.name AS reporting_user_name, reported_user_follower.followers_count, reported_user_following.following_count FROM user_reports ur INNER JOIN users u ON ur.reported_user_id = u.id INNER JOIN users u2 ON u2.id = ur.user_id LEFT JOIN (SELECT uf.follower_id, COUNT(*) AS following_count FROM user_followers uf GROUP BY uf.follower_id) reported_user_following ON reported_user_following.follower_id = ur.user_id LEFT JOIN (SELECT user_id, COUNT(*) AS followers_count FROM user_followers uf GROUP BY uf.user_id) reported_user_follower ON reported_user_follower.user_id = u.id WHERE ur.id = ?;", userReportID).Scan(&reportedUser)
 
 	if res.Error != nil {
 		return entity.ReportedUser{}, utils.ErrInternalServerError
@@ -325,8 +753,8 @@ func (ur *MysqlUserRepository) GetReportedUser(userReportID uint) (entity.Report
 	return reportedUser, nil
 }
 
-func (ur *MysqlUserRepository) DeleteUserReport(userReportID uint) error {
-	res := ur.Db.Delete(&entity.UserReport{}, userReportID)
+func (ur *MysqlUserRepository) DeleteUserReport(ctx context.Context, userReportID uint) error {
+	res := ur.Db.WithContext(ctx).Delete(&entity.UserReport{}, userReportID)
 
 	if res.Error != nil {
 		return utils.ErrInternalServerError
@@ -339,8 +767,8 @@ func (ur *MysqlUserRepository) DeleteUserReport(userReportID uint) error {
 	return nil
 }
 
-func (ur *MysqlUserRepository) DeleteThreadReport(threadReportID uint) error {
-	res := ur.Db.Delete(&threadEntity.ThreadReport{}, threadReportID)
+func (ur *MysqlUserRepository) DeleteThreadReport(ctx context.Context, threadReportID uint) error {
+	res := ur.Db.WithContext(ctx).Delete(&threadEntity.ThreadReport{}, threadReportID)
 
 	if res.Error != nil {
 		return utils.ErrInternalServerError
@@ -353,8 +781,8 @@ func (ur *MysqlUserRepository) DeleteThreadReport(threadReportID uint) error {
 	return nil
 }
 
-func (ur *MysqlUserRepository) DeleteCommunityReport(communityReportID uint) error {
-	res := ur.Db.Delete(&communityEntity.CommunityReport{}, communityReportID)
+func (ur *MysqlUserRepository) DeleteCommunityReport(ctx context.Context, communityReportID uint) error {
+	res := ur.Db.WithContext(ctx).Delete(&communityEntity.CommunityReport{}, communityReportID)
 
 	if res.Error != nil {
 		return utils.ErrInternalServerError
@@ -367,8 +795,8 @@ func (ur *MysqlUserRepository) DeleteCommunityReport(communityReportID uint) err
 	return nil
 }
 
-func (ur *MysqlUserRepository) DeleteCommentReport(commentReportID uint) error {
-	res := ur.Db.Delete(&threadEntity.CommentReport{}, commentReportID)
+func (ur *MysqlUserRepository) DeleteCommentReport(ctx context.Context, commentReportID uint) error {
+	res := ur.Db.WithContext(ctx).Delete(&threadEntity.CommentReport{}, commentReportID)
 
 	if res.Error != nil {
 		return utils.ErrInternalServerError
@@ -381,13 +809,13 @@ func (ur *MysqlUserRepository) DeleteCommentReport(commentReportID uint) error {
 	return nil
 }
 
-func (ur *MysqlUserRepository) GetUserReport(reportID uint) (entity.UserReport, error) {
+func (ur *MysqlUserRepository) GetUserReport(ctx context.Context, reportID uint) (entity.UserReport, error) {
 	var userReport entity.UserReport
 
-	res := ur.Db.First(&userReport, reportID)
+	res := ur.Db.WithContext(ctx).First(&userReport, reportID)
 
 	if res.Error != nil {
-		fmt.Println(res.Error)
+		ur.logger.ErrorContext(ctx, "query failed", "op", "GetUserReport", "err", res.Error)
 		if res.Error.Error() == "record not found" {
 			return entity.UserReport{}, utils.ErrNotFound
 		}
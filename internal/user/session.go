@@ -0,0 +1,14 @@
+package user
+
+import (
+	"context"
+	"macaiki/internal/user/dto"
+	"macaiki/internal/user/entity"
+)
+
+// SessionIssuer finishes a login once the user has proven who they are. It
+// returns an access token, or a challenge when the account needs a second
+// factor first.
+type SessionIssuer interface {
+	Issue(ctx context.Context, user entity.User) (dto.LoginResponse, error)
+}
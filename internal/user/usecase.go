@@ -1,49 +1,67 @@
 package user
 
 import (
+	"context"
 	dtoThread "macaiki/internal/thread/dto"
 	"macaiki/internal/user/dto"
 	"mime/multipart"
 )
 
 type UserUsecase interface {
-	Login(loginInfo dto.UserLoginRequest) (dto.LoginResponse, error)
-	Register(user dto.UserRequest) error
-	GetAll(userID uint, search string) ([]dto.UserResponse, error)
-	Get(id, tokenUserID uint) (dto.UserDetailResponse, error)
-	Update(userUpdate dto.UserUpdateRequest, id uint) (dto.UserUpdateResponse, error)
-	Delete(id uint, curentUserID uint, curentUser string) error
-
-	ChangeEmail(id uint, info dto.UserLoginRequest) (string, error)
-	ChangePassword(id uint, passwordInfo dto.UserChangePasswordRequest) error
-
-	SetProfileImage(id uint, img *multipart.FileHeader) (string, error)
-	SetBackgroundImage(id uint, img *multipart.FileHeader) (string, error)
-	GetUserFollowers(tokenUserID, getFollowingUserID uint) ([]dto.UserResponse, error)
-	GetUserFollowing(tokenUserID, getFollowingUserID uint) ([]dto.UserResponse, error)
-	Follow(userID, userFollowerID uint) error
-	Unfollow(userID, userFollowerID uint) error
-
-	Report(userID, userReportedID, ReportCategoryID uint) error
-
-	GetThreadByToken(userID, tokenUserID uint) ([]dtoThread.DetailedThreadResponse, error)
-	SendOTP(email dto.SendOTPRequest) error
-	VerifyOTP(email, OTPCode string) error
-
-	BanUser(userRole string, userReportID uint) error
-	BanThread(userRole string, threadReportID uint) error
-	BanComment(userRole string, commentReportID uint) error
-	BanCommunity(userRole string, communityReportID uint) error
-
-	GetReports(curentUserRole string) ([]dto.BriefReportResponse, error)
-	GetDashboardAnalytics(userRole string) (dto.AdminDashboardAnalytics, error)
-	GetReportedThread(userRole string, threadReportID uint) (dto.ReportedThreadResponse, error)
-	GetReportedCommunity(userRole string, communityReportID uint) (dto.ReportedCommunityResponse, error)
-	GetReportedComment(userRole string, commentReportID uint) (dto.ReportedCommentResponse, error)
-	GetReportedUser(userRole string, userReportID uint) (dto.ReportedUserResponse, error)
-
-	DeleteThreadReport(userRole string, threadReportID uint) error
-	DeleteUserReport(userRole string, userReportID uint) error
-	DeleteCommentReport(userRole string, commentReportID uint) error
-	DeleteCommunityReport(userRole string, communityReportID uint) error
+	Login(ctx context.Context, loginInfo dto.UserLoginRequest) (dto.LoginResponse, error)
+	Register(ctx context.Context, user dto.UserRequest) error
+	GetAll(ctx context.Context, userID uint, search string) ([]dto.UserResponse, error)
+	Get(ctx context.Context, id, tokenUserID uint) (dto.UserDetailResponse, error)
+	Update(ctx context.Context, userUpdate dto.UserUpdateRequest, id uint) (dto.UserUpdateResponse, error)
+	Delete(ctx context.Context, id uint, curentUserID uint, curentUser string) (dto.AccountDeletionResponse, error)
+	CancelDeletion(ctx context.Context, id uint) error
+	PurgeUser(ctx context.Context, id uint) error
+
+	ChangeEmail(ctx context.Context, id uint, info dto.UserLoginRequest) (dto.EmailChangeResponse, error)
+	ConfirmEmailChange(ctx context.Context, req dto.EmailChangeTokenRequest) error
+	RevertEmailChange(ctx context.Context, req dto.EmailChangeTokenRequest) error
+	ChangePassword(ctx context.Context, id uint, passwordInfo dto.UserChangePasswordRequest) error
+
+	SetProfileImage(ctx context.Context, id uint, img *multipart.FileHeader) (string, error)
+	SetBackgroundImage(ctx context.Context, id uint, img *multipart.FileHeader) (string, error)
+	GetUserFollowers(ctx context.Context, tokenUserID, getFollowingUserID uint) ([]dto.UserResponse, error)
+	GetUserFollowing(ctx context.Context, tokenUserID, getFollowingUserID uint) ([]dto.UserResponse, error)
+	Follow(ctx context.Context, userID, userFollowerID uint) error
+	Unfollow(ctx context.Context, userID, userFollowerID uint) error
+
+	Report(ctx context.Context, userID, userReportedID, ReportCategoryID uint) error
+
+	GetThreadByToken(ctx context.Context, userID, tokenUserID uint) ([]dtoThread.DetailedThreadResponse, error)
+	SendOTP(ctx context.Context, email dto.SendOTPRequest) error
+	VerifyOTP(ctx context.Context, req dto.VerifyOTPRequest) error
+
+	BanUser(ctx context.Context, userRole string, userReportID uint) error
+	BanThread(ctx context.Context, userRole string, threadReportID uint) error
+	BanComment(ctx context.Context, userRole string, commentReportID uint) error
+	BanCommunity(ctx context.Context, userRole string, communityReportID uint) error
+
+	GetReports(ctx context.Context, curentUserRole string) ([]dto.BriefReportResponse, error)
+	GetDashboardAnalytics(ctx context.Context, userRole string) (dto.AdminDashboardAnalytics, error)
+	GetReportedThread(ctx context.Context, userRole string, threadReportID uint) (dto.ReportedThreadResponse, error)
+	GetReportedCommunity(ctx context.Context, userRole string, communityReportID uint) (dto.ReportedCommunityResponse, error)
+	GetReportedComment(ctx context.Context, userRole string, commentReportID uint) (dto.ReportedCommentResponse, error)
+	GetReportedUser(ctx context.Context, userRole string, userReportID uint) (dto.ReportedUserResponse, error)
+
+	DeleteThreadReport(ctx context.Context, userRole string, threadReportID uint) error
+	DeleteUserReport(ctx context.Context, userRole string, userReportID uint) error
+	DeleteCommentReport(ctx context.Context, userRole string, commentReportID uint) error
+	DeleteCommunityReport(ctx context.Context, userRole string, communityReportID uint) error
+}
+
+type TwoFactorUsecase interface {
+	GetStatus(ctx context.Context, userID uint) (dto.TwoFactorStatusResponse, error)
+	Setup(ctx context.Context, userID uint) (dto.TwoFactorSetupResponse, error)
+	Enable(ctx context.Context, userID uint, req dto.TwoFactorCodeRequest) (dto.RecoveryCodesResponse, error)
+	Disable(ctx context.Context, userID uint, req dto.DisableTwoFactorRequest) error
+	RegenerateRecoveryCodes(ctx context.Context, userID uint, req dto.TwoFactorCodeRequest) (dto.RecoveryCodesResponse, error)
+
+	SetupLogin(ctx context.Context, req dto.TwoFactorChallengeRequest) (dto.TwoFactorSetupResponse, error)
+	Login(ctx context.Context, req dto.TwoFactorLoginRequest) (dto.LoginResponse, error)
+
+	SetRequired(ctx context.Context, userRole string, userID uint, req dto.TwoFactorRequirementRequest) error
 }
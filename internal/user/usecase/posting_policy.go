@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"context"
+	"macaiki/internal/user"
+	"macaiki/pkg/utils"
+)
+
+type postingPolicy struct {
+	userRepo              user.UserRepository
+	requireVerifiedEmails bool
+}
+
+// NewPostingPolicy returns the policy gating thread and comment creation.
+// With requireVerifiedEmail set, accounts that have not confirmed their
+// email through the OTP flow are rejected with utils.ErrEmailNotVerified.
+func NewPostingPolicy(userRepo user.UserRepository, requireVerifiedEmail bool) user.PostingPolicy {
+	return &postingPolicy{userRepo: userRepo, requireVerifiedEmails: requireVerifiedEmail}
+}
+
+func (pp *postingPolicy) CanPost(ctx context.Context, userID uint) error {
+	if !pp.requireVerifiedEmails {
+		return nil
+	}
+
+	userEntity, err := pp.userRepo.Get(ctx, userID)
+	if err != nil {
+		return utils.ErrInternalServerError
+	}
+
+	if userEntity.ID == 0 {
+		return utils.ErrNotFound
+	}
+
+	if userEntity.EmailVerifiedAt.IsZero() {
+		return utils.ErrEmailNotVerified
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"context"
+	userEntity "macaiki/internal/user/entity"
+	userMock "macaiki/internal/user/mocks"
+	"macaiki/pkg/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPostingPolicy(t *testing.T) {
+	mockUserRepo := userMock.NewUserRepository(t)
+
+	t.Run("not-required", func(t *testing.T) {
+		policy := NewPostingPolicy(mockUserRepo, false)
+
+		assert.NoError(t, policy.CanPost(context.Background(), uint(1)))
+	})
+
+	t.Run("verified", func(t *testing.T) {
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(mockUserEntity1, nil).Once()
+
+		policy := NewPostingPolicy(mockUserRepo, true)
+
+		assert.NoError(t, policy.CanPost(context.Background(), uint(1)))
+	})
+
+	t.Run("not-verified", func(t *testing.T) {
+		unverifiedUser := mockUserEntity1
+		unverifiedUser.EmailVerifiedAt = time.Time{}
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(unverifiedUser, nil).Once()
+
+		policy := NewPostingPolicy(mockUserRepo, true)
+
+		assert.Equal(t, utils.ErrEmailNotVerified, policy.CanPost(context.Background(), uint(1)))
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(userEntity.User{}, nil).Once()
+
+		policy := NewPostingPolicy(mockUserRepo, true)
+
+		assert.Equal(t, utils.ErrNotFound, policy.CanPost(context.Background(), uint(1)))
+	})
+
+	t.Run("internal-server-error", func(t *testing.T) {
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(userEntity.User{}, utils.ErrInternalServerError).Once()
+
+		policy := NewPostingPolicy(mockUserRepo, true)
+
+		assert.Equal(t, utils.ErrInternalServerError, policy.CanPost(context.Background(), uint(1)))
+	})
+}
@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"macaiki/internal/user"
+	"macaiki/internal/user/delivery/http/helper"
+	"macaiki/internal/user/dto"
+	"macaiki/internal/user/entity"
+	"macaiki/pkg/logger"
+	"macaiki/pkg/middleware"
+	"macaiki/pkg/utils"
+	"time"
+)
+
+const (
+	CHALLENGE_EXPIRATION = 5 * time.Minute
+
+	// a verify challenge is answered with a code from the authenticator or
+	// a recovery code, a setup challenge lets a user who is required to use
+	// 2FA enroll before getting an access token
+	CHALLENGE_VERIFY = "2fa"
+	CHALLENGE_SETUP  = "2fa_setup"
+)
+
+type sessionIssuer struct {
+	userRepo        user.UserRepository
+	createToken     func(userID int, role string) (string, error)
+	createChallenge func(userID int, purpose string, ttl time.Duration) (string, error)
+	logger          *slog.Logger
+}
+
+func NewSessionIssuer(userRepo user.UserRepository, log *slog.Logger) user.SessionIssuer {
+	return &sessionIssuer{
+		userRepo:        userRepo,
+		createToken:     middleware.JWTCreateToken,
+		createChallenge: middleware.JWTCreateChallengeToken,
+		logger:          logger.OrDefault(log),
+	}
+}
+
+func (si *sessionIssuer) Issue(ctx context.Context, userEntity entity.User) (dto.LoginResponse, error) {
+	twoFactor, err := si.userRepo.GetTwoFactor(ctx, userEntity.ID)
+	if err != nil && !errors.Is(err, utils.ErrNotFound) {
+		return dto.LoginResponse{}, utils.ErrInternalServerError
+	}
+
+	var purpose string
+	switch {
+	case twoFactor.EnabledAt != nil:
+		purpose = CHALLENGE_VERIFY
+	case userEntity.TwoFactorRequired:
+		purpose = CHALLENGE_SETUP
+	default:
+		token, err := si.createToken(int(userEntity.ID), userEntity.Role)
+		if err != nil {
+			si.logger.ErrorContext(ctx, "failed to create token", "err", err)
+			return dto.LoginResponse{}, utils.ErrInternalServerError
+		}
+		return helper.ToLoginResponse(token), nil
+	}
+
+	expiredAt := time.Now().Add(CHALLENGE_EXPIRATION)
+	challenge, err := si.createChallenge(int(userEntity.ID), purpose, CHALLENGE_EXPIRATION)
+	if err != nil {
+		si.logger.ErrorContext(ctx, "failed to create challenge token", "err", err)
+		return dto.LoginResponse{}, utils.ErrInternalServerError
+	}
+
+	return dto.LoginResponse{
+		TwoFactorRequired:      purpose == CHALLENGE_VERIFY,
+		TwoFactorSetupRequired: purpose == CHALLENGE_SETUP,
+		ChallengeToken:         challenge,
+		ChallengeExpiredAt:     &expiredAt,
+	}, nil
+}
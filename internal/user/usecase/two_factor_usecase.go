@@ -0,0 +1,429 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"macaiki/internal/user"
+	"macaiki/internal/user/dto"
+	"macaiki/internal/user/entity"
+	"macaiki/pkg/logger"
+	"macaiki/pkg/metrics"
+	"macaiki/pkg/middleware"
+	"macaiki/pkg/ratelimit"
+	"macaiki/pkg/totp"
+	"macaiki/pkg/utils"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	RECOVERY_CODE_COUNT = 10
+	// TOTP_SKEW is how many 30 second steps around now a code may be from
+	TOTP_SKEW = 1
+)
+
+type twoFactorUsecase struct {
+	userRepo       user.UserRepository
+	validator      *validator.Validate
+	lockout        ratelimit.Lockout
+	issuer         string
+	createToken    func(userID int, role string) (string, error)
+	parseChallenge func(token string) (int, string, error)
+	logger         *slog.Logger
+}
+
+// NewTwoFactorUsecase handles TOTP enrollment and the second step of the
+// login, issuer is the name authenticator apps show next to the account
+func NewTwoFactorUsecase(userRepo user.UserRepository, validator *validator.Validate, lockout ratelimit.Lockout, issuer string, log *slog.Logger) user.TwoFactorUsecase {
+	return &twoFactorUsecase{
+		userRepo:       userRepo,
+		validator:      validator,
+		lockout:        ratelimit.OrNoop(lockout),
+		issuer:         issuer,
+		createToken:    middleware.JWTCreateToken,
+		parseChallenge: middleware.JWTParseChallengeToken,
+		logger:         logger.OrDefault(log),
+	}
+}
+
+func (tu *twoFactorUsecase) GetStatus(ctx context.Context, userID uint) (dto.TwoFactorStatusResponse, error) {
+	userEntity, err := tu.getUser(ctx, userID)
+	if err != nil {
+		return dto.TwoFactorStatusResponse{}, err
+	}
+
+	twoFactor, err := tu.userRepo.GetTwoFactor(ctx, userID)
+	if errors.Is(err, utils.ErrNotFound) || (err == nil && twoFactor.EnabledAt == nil) {
+		return dto.TwoFactorStatusResponse{Required: userEntity.TwoFactorRequired}, nil
+	}
+	if err != nil {
+		return dto.TwoFactorStatusResponse{}, utils.ErrInternalServerError
+	}
+
+	left, err := tu.userRepo.CountRecoveryCodes(ctx, userID)
+	if err != nil {
+		return dto.TwoFactorStatusResponse{}, utils.ErrInternalServerError
+	}
+
+	return dto.TwoFactorStatusResponse{
+		Enabled:           true,
+		Required:          userEntity.TwoFactorRequired,
+		RecoveryCodesLeft: left,
+	}, nil
+}
+
+func (tu *twoFactorUsecase) Setup(ctx context.Context, userID uint) (dto.TwoFactorSetupResponse, error) {
+	userEntity, err := tu.getUser(ctx, userID)
+	if err != nil {
+		return dto.TwoFactorSetupResponse{}, err
+	}
+
+	return tu.setup(ctx, userEntity)
+}
+
+func (tu *twoFactorUsecase) Enable(ctx context.Context, userID uint, req dto.TwoFactorCodeRequest) (dto.RecoveryCodesResponse, error) {
+	if err := tu.validator.Struct(req); err != nil {
+		return dto.RecoveryCodesResponse{}, utils.ErrBadParamInput
+	}
+
+	codes, err := tu.enable(ctx, userID, req.Code)
+	if err != nil {
+		return dto.RecoveryCodesResponse{}, err
+	}
+
+	return dto.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// Disable turns 2FA off after checking the password, when the account has
+// one, and a current code
+func (tu *twoFactorUsecase) Disable(ctx context.Context, userID uint, req dto.DisableTwoFactorRequest) error {
+	if err := tu.validator.Struct(req); err != nil {
+		return utils.ErrBadParamInput
+	}
+
+	userEntity, err := tu.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if userEntity.TwoFactorRequired {
+		return utils.ErrTwoFactorRequired
+	}
+
+	twoFactor, err := tu.getEnabled(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if userEntity.Password != "" && !comparePasswords(userEntity.Password, []byte(req.Password)) {
+		return utils.ErrForbidden
+	}
+
+	if err := tu.verifyCode(ctx, twoFactor, req.Code); err != nil {
+		return err
+	}
+
+	return tu.userRepo.DeleteTwoFactor(ctx, userID)
+}
+
+func (tu *twoFactorUsecase) RegenerateRecoveryCodes(ctx context.Context, userID uint, req dto.TwoFactorCodeRequest) (dto.RecoveryCodesResponse, error) {
+	if err := tu.validator.Struct(req); err != nil {
+		return dto.RecoveryCodesResponse{}, utils.ErrBadParamInput
+	}
+
+	twoFactor, err := tu.getEnabled(ctx, userID)
+	if err != nil {
+		return dto.RecoveryCodesResponse{}, err
+	}
+
+	if err := tu.verifyCode(ctx, twoFactor, req.Code); err != nil {
+		return dto.RecoveryCodesResponse{}, err
+	}
+
+	codes, hashes := generateRecoveryCodes()
+	if err := tu.userRepo.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return dto.RecoveryCodesResponse{}, err
+	}
+
+	return dto.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// SetupLogin starts enrollment for a user who has to turn on 2FA before
+// they can sign in, the challenge token stands in for the access token
+func (tu *twoFactorUsecase) SetupLogin(ctx context.Context, req dto.TwoFactorChallengeRequest) (dto.TwoFactorSetupResponse, error) {
+	if err := tu.validator.Struct(req); err != nil {
+		return dto.TwoFactorSetupResponse{}, utils.ErrBadParamInput
+	}
+
+	userEntity, purpose, err := tu.challengeUser(ctx, req.ChallengeToken)
+	if err != nil {
+		return dto.TwoFactorSetupResponse{}, err
+	}
+	if purpose != CHALLENGE_SETUP {
+		return dto.TwoFactorSetupResponse{}, utils.ErrInvalidToken
+	}
+
+	return tu.setup(ctx, userEntity)
+}
+
+// Login answers a challenge returned by the password or external login. A
+// verify challenge takes a TOTP or recovery code, a setup challenge takes
+// the first code of the new enrollment and also returns the recovery codes.
+func (tu *twoFactorUsecase) Login(ctx context.Context, req dto.TwoFactorLoginRequest) (dto.LoginResponse, error) {
+	if err := tu.validator.Struct(req); err != nil {
+		return dto.LoginResponse{}, utils.ErrBadParamInput
+	}
+
+	userEntity, purpose, err := tu.challengeUser(ctx, req.ChallengeToken)
+	if err != nil {
+		return dto.LoginResponse{}, err
+	}
+
+	var recoveryCodes []string
+	switch purpose {
+	case CHALLENGE_SETUP:
+		recoveryCodes, err = tu.enable(ctx, userEntity.ID, req.Code)
+		if err != nil {
+			return dto.LoginResponse{}, err
+		}
+	case CHALLENGE_VERIFY:
+		twoFactor, err := tu.getEnabled(ctx, userEntity.ID)
+		if errors.Is(err, utils.ErrTwoFactorNotEnabled) {
+			return dto.LoginResponse{}, utils.ErrInvalidToken
+		}
+		if err != nil {
+			return dto.LoginResponse{}, err
+		}
+
+		if err := tu.verifyCode(ctx, twoFactor, req.Code); err != nil {
+			metrics.Logins.WithLabelValues(metrics.LoginFail).Inc()
+			return dto.LoginResponse{}, err
+		}
+	default:
+		return dto.LoginResponse{}, utils.ErrInvalidToken
+	}
+
+	token, err := tu.createToken(int(userEntity.ID), userEntity.Role)
+	if err != nil {
+		tu.logger.ErrorContext(ctx, "failed to create token", "err", err)
+		return dto.LoginResponse{}, utils.ErrInternalServerError
+	}
+
+	metrics.Logins.WithLabelValues(metrics.LoginSuccess).Inc()
+	return dto.LoginResponse{Token: token, RecoveryCodes: recoveryCodes}, nil
+}
+
+func (tu *twoFactorUsecase) SetRequired(ctx context.Context, userRole string, userID uint, req dto.TwoFactorRequirementRequest) error {
+	if userRole != "Admin" {
+		return utils.ErrUnauthorizedAccess
+	}
+
+	if _, err := tu.getUser(ctx, userID); err != nil {
+		return err
+	}
+
+	return tu.userRepo.SetTwoFactorRequired(ctx, userID, req.Required)
+}
+
+func (tu *twoFactorUsecase) setup(ctx context.Context, userEntity entity.User) (dto.TwoFactorSetupResponse, error) {
+	twoFactor, err := tu.userRepo.GetTwoFactor(ctx, userEntity.ID)
+	if err != nil && !errors.Is(err, utils.ErrNotFound) {
+		return dto.TwoFactorSetupResponse{}, utils.ErrInternalServerError
+	}
+	if twoFactor.EnabledAt != nil {
+		return dto.TwoFactorSetupResponse{}, utils.ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		tu.logger.ErrorContext(ctx, "failed to generate totp secret", "err", err)
+		return dto.TwoFactorSetupResponse{}, utils.ErrInternalServerError
+	}
+
+	err = tu.userRepo.StoreTwoFactor(ctx, entity.TwoFactor{UserID: userEntity.ID, Secret: secret})
+	if err != nil {
+		return dto.TwoFactorSetupResponse{}, err
+	}
+
+	return dto.TwoFactorSetupResponse{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(tu.issuer, userEntity.Email, secret),
+	}, nil
+}
+
+// enable confirms a pending enrollment with the first code from the
+// authenticator and returns the new recovery codes
+func (tu *twoFactorUsecase) enable(ctx context.Context, userID uint, code string) ([]string, error) {
+	twoFactor, err := tu.userRepo.GetTwoFactor(ctx, userID)
+	if errors.Is(err, utils.ErrNotFound) {
+		return nil, utils.ErrTwoFactorNotEnabled
+	}
+	if err != nil {
+		return nil, utils.ErrInternalServerError
+	}
+	if twoFactor.EnabledAt != nil {
+		return nil, utils.ErrTwoFactorAlreadyEnabled
+	}
+
+	lockoutKey := twoFactorLockoutKey(userID)
+	if err := tu.lockout.Check(ctx, lockoutKey); err != nil {
+		return nil, tu.lockoutError(ctx, err)
+	}
+
+	step, ok := totp.Validate(twoFactor.Secret, normalizeCode(code), time.Now(), TOTP_SKEW)
+	if !ok {
+		return nil, tu.failCode(ctx, lockoutKey)
+	}
+
+	codes, hashes := generateRecoveryCodes()
+	err = tu.userRepo.EnableTwoFactor(ctx, userID, step, hashes)
+	if errors.Is(err, utils.ErrConflict) {
+		return nil, utils.ErrTwoFactorAlreadyEnabled
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tu.succeedCode(ctx, lockoutKey)
+	return codes, nil
+}
+
+// verifyCode accepts a TOTP code that has not been used before or an
+// unused recovery code, wrong guesses count towards the lockout
+func (tu *twoFactorUsecase) verifyCode(ctx context.Context, twoFactor entity.TwoFactor, code string) error {
+	lockoutKey := twoFactorLockoutKey(twoFactor.UserID)
+	if err := tu.lockout.Check(ctx, lockoutKey); err != nil {
+		return tu.lockoutError(ctx, err)
+	}
+
+	code = normalizeCode(code)
+	if isTOTPCode(code) {
+		step, ok := totp.Validate(twoFactor.Secret, code, time.Now(), TOTP_SKEW)
+		if !ok {
+			return tu.failCode(ctx, lockoutKey)
+		}
+
+		err := tu.userRepo.UseTwoFactorStep(ctx, twoFactor.UserID, step)
+		if errors.Is(err, utils.ErrConflict) {
+			return tu.failCode(ctx, lockoutKey)
+		}
+		if err != nil {
+			return err
+		}
+	} else {
+		err := tu.userRepo.UseRecoveryCode(ctx, twoFactor.UserID, utils.HashToken(code))
+		if errors.Is(err, utils.ErrNotFound) {
+			return tu.failCode(ctx, lockoutKey)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	tu.succeedCode(ctx, lockoutKey)
+	return nil
+}
+
+func (tu *twoFactorUsecase) failCode(ctx context.Context, lockoutKey string) error {
+	if err := tu.lockout.Fail(ctx, lockoutKey); err != nil {
+		return tu.lockoutError(ctx, err)
+	}
+	return utils.ErrOTPInvalid
+}
+
+func (tu *twoFactorUsecase) succeedCode(ctx context.Context, lockoutKey string) {
+	if err := tu.lockout.Succeed(ctx, lockoutKey); err != nil {
+		tu.logger.WarnContext(ctx, "failed to reset 2fa failures", "err", err)
+	}
+}
+
+func (tu *twoFactorUsecase) lockoutError(ctx context.Context, err error) error {
+	var retryErr *utils.RetryAfterError
+	if errors.As(err, &retryErr) {
+		return err
+	}
+
+	tu.logger.ErrorContext(ctx, "lockout store failed", "err", err)
+	return utils.ErrInternalServerError
+}
+
+func (tu *twoFactorUsecase) challengeUser(ctx context.Context, challenge string) (entity.User, string, error) {
+	userID, purpose, err := tu.parseChallenge(challenge)
+	if err != nil {
+		return entity.User{}, "", utils.ErrInvalidToken
+	}
+
+	userEntity, err := tu.getUser(ctx, uint(userID))
+	if errors.Is(err, utils.ErrNotFound) {
+		return entity.User{}, "", utils.ErrInvalidToken
+	}
+	if err != nil {
+		return entity.User{}, "", err
+	}
+
+	return userEntity, purpose, nil
+}
+
+func (tu *twoFactorUsecase) getUser(ctx context.Context, userID uint) (entity.User, error) {
+	userEntity, err := tu.userRepo.Get(ctx, userID)
+	if err != nil {
+		return entity.User{}, utils.ErrInternalServerError
+	}
+
+	if userEntity.ID == 0 {
+		return entity.User{}, utils.ErrNotFound
+	}
+
+	return userEntity, nil
+}
+
+func (tu *twoFactorUsecase) getEnabled(ctx context.Context, userID uint) (entity.TwoFactor, error) {
+	twoFactor, err := tu.userRepo.GetTwoFactor(ctx, userID)
+	if errors.Is(err, utils.ErrNotFound) || (err == nil && twoFactor.EnabledAt == nil) {
+		return entity.TwoFactor{}, utils.ErrTwoFactorNotEnabled
+	}
+	if err != nil {
+		return entity.TwoFactor{}, utils.ErrInternalServerError
+	}
+
+	return twoFactor, nil
+}
+
+// generateRecoveryCodes returns codes formatted for the user together with
+// the hashes to store
+func generateRecoveryCodes() ([]string, []string) {
+	codes := make([]string, 0, RECOVERY_CODE_COUNT)
+	hashes := make([]string, 0, RECOVERY_CODE_COUNT)
+	for i := 0; i < RECOVERY_CODE_COUNT; i++ {
+		raw := utils.GenerateSecureToken(5)
+		codes = append(codes, fmt.Sprintf("%s-%s", raw[:5], raw[5:]))
+		hashes = append(hashes, utils.HashToken(raw))
+	}
+	return codes, hashes
+}
+
+// normalizeCode drops the separators users tend to type along with a code
+func normalizeCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
+
+func isTOTPCode(code string) bool {
+	if len(code) != totp.Digits {
+		return false
+	}
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func twoFactorLockoutKey(userID uint) string {
+	return fmt.Sprintf("2fa:%d", userID)
+}
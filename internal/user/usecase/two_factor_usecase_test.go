@@ -0,0 +1,349 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	userDTO "macaiki/internal/user/dto"
+	userEntity "macaiki/internal/user/entity"
+	userMock "macaiki/internal/user/mocks"
+	"macaiki/pkg/ratelimit"
+	"macaiki/pkg/totp"
+	"macaiki/pkg/utils"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const mockTOTPSecret = "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+
+func currentTOTPCode(t *testing.T) string {
+	code, err := totp.Code(mockTOTPSecret, totp.Step(time.Now()))
+	assert.NoError(t, err)
+	return code
+}
+
+func newTestTwoFactorUsecase(repo *userMock.UserRepository, lockout ratelimit.Lockout, challenges map[string][2]interface{}) *twoFactorUsecase {
+	tu := NewTwoFactorUsecase(repo, v, lockout, "Macaiki", nil).(*twoFactorUsecase)
+	tu.createToken = func(userID int, role string) (string, error) {
+		return "token", nil
+	}
+	tu.parseChallenge = func(token string) (int, string, error) {
+		challenge, ok := challenges[token]
+		if !ok {
+			return 0, "", errors.New("invalid challenge token")
+		}
+		return challenge[0].(int), challenge[1].(string), nil
+	}
+	return tu
+}
+
+func TestSessionIssuer(t *testing.T) {
+	mockUserRepo := userMock.NewUserRepository(t)
+	enabledAt := time.Now()
+
+	newIssuer := func() *sessionIssuer {
+		si := NewSessionIssuer(mockUserRepo, nil).(*sessionIssuer)
+		si.createToken = func(userID int, role string) (string, error) {
+			return "token", nil
+		}
+		si.createChallenge = func(userID int, purpose string, ttl time.Duration) (string, error) {
+			return purpose + "-challenge", nil
+		}
+		return si
+	}
+
+	t.Run("without-two-factor", func(t *testing.T) {
+		mockUserRepo.On("GetTwoFactor", mock.Anything, uint(1)).Return(userEntity.TwoFactor{}, utils.ErrNotFound).Once()
+
+		res, err := newIssuer().Issue(context.Background(), mockUserEntity1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "token", res.Token)
+		assert.Empty(t, res.ChallengeToken)
+	})
+
+	t.Run("two-factor-enabled", func(t *testing.T) {
+		mockUserRepo.On("GetTwoFactor", mock.Anything, uint(1)).Return(userEntity.TwoFactor{UserID: 1, EnabledAt: &enabledAt}, nil).Once()
+
+		res, err := newIssuer().Issue(context.Background(), mockUserEntity1)
+
+		assert.NoError(t, err)
+		assert.Empty(t, res.Token)
+		assert.True(t, res.TwoFactorRequired)
+		assert.Equal(t, CHALLENGE_VERIFY+"-challenge", res.ChallengeToken)
+	})
+
+	t.Run("two-factor-required", func(t *testing.T) {
+		requiredUser := mockUserEntity1
+		requiredUser.TwoFactorRequired = true
+		mockUserRepo.On("GetTwoFactor", mock.Anything, uint(1)).Return(userEntity.TwoFactor{}, utils.ErrNotFound).Once()
+
+		res, err := newIssuer().Issue(context.Background(), requiredUser)
+
+		assert.NoError(t, err)
+		assert.Empty(t, res.Token)
+		assert.True(t, res.TwoFactorSetupRequired)
+		assert.Equal(t, CHALLENGE_SETUP+"-challenge", res.ChallengeToken)
+	})
+
+	t.Run("internal-server-error", func(t *testing.T) {
+		mockUserRepo.On("GetTwoFactor", mock.Anything, uint(1)).Return(userEntity.TwoFactor{}, utils.ErrInternalServerError).Once()
+
+		_, err := newIssuer().Issue(context.Background(), mockUserEntity1)
+
+		assert.Equal(t, utils.ErrInternalServerError, err)
+	})
+}
+
+func TestTwoFactorSetup(t *testing.T) {
+	mockUserRepo := userMock.NewUserRepository(t)
+	enabledAt := time.Now()
+
+	t.Run("success", func(t *testing.T) {
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("GetTwoFactor", mock.Anything, uint(1)).Return(userEntity.TwoFactor{}, utils.ErrNotFound).Once()
+		mockUserRepo.On("StoreTwoFactor", mock.Anything, mock.MatchedBy(func(tf userEntity.TwoFactor) bool {
+			return tf.UserID == 1 && tf.Secret != "" && tf.EnabledAt == nil
+		})).Return(nil).Once()
+
+		res, err := newTestTwoFactorUsecase(mockUserRepo, nil, nil).Setup(context.Background(), uint(1))
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, res.Secret)
+		assert.True(t, strings.HasPrefix(res.ProvisioningURI, "otpauth://totp/Macaiki:dummy@gmail.com?"))
+		assert.Contains(t, res.ProvisioningURI, "secret="+res.Secret)
+	})
+
+	t.Run("already-enabled", func(t *testing.T) {
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("GetTwoFactor", mock.Anything, uint(1)).Return(userEntity.TwoFactor{UserID: 1, EnabledAt: &enabledAt}, nil).Once()
+
+		_, err := newTestTwoFactorUsecase(mockUserRepo, nil, nil).Setup(context.Background(), uint(1))
+
+		assert.Equal(t, utils.ErrTwoFactorAlreadyEnabled, err)
+	})
+}
+
+func TestTwoFactorEnable(t *testing.T) {
+	mockUserRepo := userMock.NewUserRepository(t)
+	pending := userEntity.TwoFactor{UserID: 1, Secret: mockTOTPSecret}
+
+	t.Run("success", func(t *testing.T) {
+		mockUserRepo.On("GetTwoFactor", mock.Anything, uint(1)).Return(pending, nil).Once()
+		mockUserRepo.On("EnableTwoFactor", mock.Anything, uint(1), totp.Step(time.Now()), mock.MatchedBy(func(hashes []string) bool {
+			return len(hashes) == RECOVERY_CODE_COUNT
+		})).Return(nil).Once()
+
+		res, err := newTestTwoFactorUsecase(mockUserRepo, nil, nil).Enable(context.Background(), uint(1), userDTO.TwoFactorCodeRequest{Code: currentTOTPCode(t)})
+
+		assert.NoError(t, err)
+		assert.Len(t, res.RecoveryCodes, RECOVERY_CODE_COUNT)
+	})
+
+	t.Run("wrong-code", func(t *testing.T) {
+		mockUserRepo.On("GetTwoFactor", mock.Anything, uint(1)).Return(pending, nil).Once()
+
+		_, err := newTestTwoFactorUsecase(mockUserRepo, nil, nil).Enable(context.Background(), uint(1), userDTO.TwoFactorCodeRequest{Code: "000000x"})
+
+		assert.Equal(t, utils.ErrOTPInvalid, err)
+	})
+
+	t.Run("setup-not-started", func(t *testing.T) {
+		mockUserRepo.On("GetTwoFactor", mock.Anything, uint(1)).Return(userEntity.TwoFactor{}, utils.ErrNotFound).Once()
+
+		_, err := newTestTwoFactorUsecase(mockUserRepo, nil, nil).Enable(context.Background(), uint(1), userDTO.TwoFactorCodeRequest{Code: "123456"})
+
+		assert.Equal(t, utils.ErrTwoFactorNotEnabled, err)
+	})
+
+	t.Run("bad-param-input", func(t *testing.T) {
+		_, err := newTestTwoFactorUsecase(mockUserRepo, nil, nil).Enable(context.Background(), uint(1), userDTO.TwoFactorCodeRequest{})
+
+		assert.Equal(t, utils.ErrBadParamInput, err)
+	})
+}
+
+func TestTwoFactorLogin(t *testing.T) {
+	mockUserRepo := userMock.NewUserRepository(t)
+	enabledAt := time.Now()
+	enabled := userEntity.TwoFactor{UserID: 1, Secret: mockTOTPSecret, EnabledAt: &enabledAt}
+	challenges := map[string][2]interface{}{
+		"verify": {1, CHALLENGE_VERIFY},
+		"setup":  {1, CHALLENGE_SETUP},
+	}
+
+	t.Run("totp-code", func(t *testing.T) {
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("GetTwoFactor", mock.Anything, uint(1)).Return(enabled, nil).Once()
+		mockUserRepo.On("UseTwoFactorStep", mock.Anything, uint(1), totp.Step(time.Now())).Return(nil).Once()
+
+		res, err := newTestTwoFactorUsecase(mockUserRepo, nil, challenges).Login(context.Background(), userDTO.TwoFactorLoginRequest{
+			ChallengeToken: "verify",
+			Code:           currentTOTPCode(t),
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "token", res.Token)
+	})
+
+	t.Run("replayed-code", func(t *testing.T) {
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("GetTwoFactor", mock.Anything, uint(1)).Return(enabled, nil).Once()
+		mockUserRepo.On("UseTwoFactorStep", mock.Anything, uint(1), totp.Step(time.Now())).Return(utils.ErrConflict).Once()
+
+		_, err := newTestTwoFactorUsecase(mockUserRepo, nil, challenges).Login(context.Background(), userDTO.TwoFactorLoginRequest{
+			ChallengeToken: "verify",
+			Code:           currentTOTPCode(t),
+		})
+
+		assert.Equal(t, utils.ErrOTPInvalid, err)
+	})
+
+	t.Run("recovery-code", func(t *testing.T) {
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("GetTwoFactor", mock.Anything, uint(1)).Return(enabled, nil).Once()
+		mockUserRepo.On("UseRecoveryCode", mock.Anything, uint(1), utils.HashToken("abcde12345")).Return(nil).Once()
+
+		res, err := newTestTwoFactorUsecase(mockUserRepo, nil, challenges).Login(context.Background(), userDTO.TwoFactorLoginRequest{
+			ChallengeToken: "verify",
+			Code:           "ABCDE-12345",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "token", res.Token)
+	})
+
+	t.Run("used-recovery-code", func(t *testing.T) {
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("GetTwoFactor", mock.Anything, uint(1)).Return(enabled, nil).Once()
+		mockUserRepo.On("UseRecoveryCode", mock.Anything, uint(1), utils.HashToken("abcde12345")).Return(utils.ErrNotFound).Once()
+
+		_, err := newTestTwoFactorUsecase(mockUserRepo, nil, challenges).Login(context.Background(), userDTO.TwoFactorLoginRequest{
+			ChallengeToken: "verify",
+			Code:           "abcde-12345",
+		})
+
+		assert.Equal(t, utils.ErrOTPInvalid, err)
+	})
+
+	t.Run("setup-challenge", func(t *testing.T) {
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("GetTwoFactor", mock.Anything, uint(1)).Return(userEntity.TwoFactor{UserID: 1, Secret: mockTOTPSecret}, nil).Once()
+		mockUserRepo.On("EnableTwoFactor", mock.Anything, uint(1), totp.Step(time.Now()), mock.Anything).Return(nil).Once()
+
+		res, err := newTestTwoFactorUsecase(mockUserRepo, nil, challenges).Login(context.Background(), userDTO.TwoFactorLoginRequest{
+			ChallengeToken: "setup",
+			Code:           currentTOTPCode(t),
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "token", res.Token)
+		assert.Len(t, res.RecoveryCodes, RECOVERY_CODE_COUNT)
+	})
+
+	t.Run("invalid-challenge", func(t *testing.T) {
+		_, err := newTestTwoFactorUsecase(mockUserRepo, nil, challenges).Login(context.Background(), userDTO.TwoFactorLoginRequest{
+			ChallengeToken: "forged",
+			Code:           "123456",
+		})
+
+		assert.Equal(t, utils.ErrInvalidToken, err)
+	})
+
+	t.Run("locked-out", func(t *testing.T) {
+		store := ratelimit.NewMemoryStore()
+		lockout := ratelimit.NewLockout(store, ratelimit.LockoutPolicy{MaxFailures: 2, Window: time.Minute, Duration: time.Minute})
+		tu := newTestTwoFactorUsecase(mockUserRepo, lockout, challenges)
+		req := userDTO.TwoFactorLoginRequest{ChallengeToken: "verify", Code: "000000"}
+		if code := currentTOTPCode(t); code == req.Code {
+			req.Code = "111111"
+		}
+
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(mockUserEntity1, nil).Times(3)
+		mockUserRepo.On("GetTwoFactor", mock.Anything, uint(1)).Return(enabled, nil).Times(3)
+
+		_, err := tu.Login(context.Background(), req)
+		assert.Equal(t, utils.ErrOTPInvalid, err)
+
+		_, err = tu.Login(context.Background(), req)
+		assert.ErrorIs(t, err, utils.ErrTooManyRequests)
+
+		_, err = tu.Login(context.Background(), req)
+		assert.ErrorIs(t, err, utils.ErrTooManyRequests)
+	})
+}
+
+func TestTwoFactorDisable(t *testing.T) {
+	mockUserRepo := userMock.NewUserRepository(t)
+	enabledAt := time.Now()
+	enabled := userEntity.TwoFactor{UserID: 1, Secret: mockTOTPSecret, EnabledAt: &enabledAt}
+
+	t.Run("success", func(t *testing.T) {
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("GetTwoFactor", mock.Anything, uint(1)).Return(enabled, nil).Once()
+		mockUserRepo.On("UseTwoFactorStep", mock.Anything, uint(1), totp.Step(time.Now())).Return(nil).Once()
+		mockUserRepo.On("DeleteTwoFactor", mock.Anything, uint(1)).Return(nil).Once()
+
+		err := newTestTwoFactorUsecase(mockUserRepo, nil, nil).Disable(context.Background(), uint(1), userDTO.DisableTwoFactorRequest{
+			Password: "123456",
+			Code:     currentTOTPCode(t),
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("wrong-password", func(t *testing.T) {
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("GetTwoFactor", mock.Anything, uint(1)).Return(enabled, nil).Once()
+
+		err := newTestTwoFactorUsecase(mockUserRepo, nil, nil).Disable(context.Background(), uint(1), userDTO.DisableTwoFactorRequest{
+			Password: "wrong",
+			Code:     "123456",
+		})
+
+		assert.Equal(t, utils.ErrForbidden, err)
+	})
+
+	t.Run("required", func(t *testing.T) {
+		requiredUser := mockUserEntity1
+		requiredUser.TwoFactorRequired = true
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(requiredUser, nil).Once()
+
+		err := newTestTwoFactorUsecase(mockUserRepo, nil, nil).Disable(context.Background(), uint(1), userDTO.DisableTwoFactorRequest{
+			Password: "123456",
+			Code:     "123456",
+		})
+
+		assert.Equal(t, utils.ErrTwoFactorRequired, err)
+	})
+}
+
+func TestTwoFactorSetRequired(t *testing.T) {
+	mockUserRepo := userMock.NewUserRepository(t)
+
+	t.Run("success", func(t *testing.T) {
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("SetTwoFactorRequired", mock.Anything, uint(1), true).Return(nil).Once()
+
+		err := newTestTwoFactorUsecase(mockUserRepo, nil, nil).SetRequired(context.Background(), "Admin", uint(1), userDTO.TwoFactorRequirementRequest{Required: true})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("user-not-found", func(t *testing.T) {
+		mockUserRepo.On("Get", mock.Anything, uint(3)).Return(userEntity.User{}, nil).Once()
+
+		err := newTestTwoFactorUsecase(mockUserRepo, nil, nil).SetRequired(context.Background(), "Admin", uint(3), userDTO.TwoFactorRequirementRequest{Required: true})
+
+		assert.Equal(t, utils.ErrNotFound, err)
+	})
+
+	t.Run("unauthorized-access", func(t *testing.T) {
+		err := newTestTwoFactorUsecase(mockUserRepo, nil, nil).SetRequired(context.Background(), "User", uint(2), userDTO.TwoFactorRequirementRequest{Required: true})
+
+		assert.Equal(t, utils.ErrUnauthorizedAccess, err)
+	})
+}
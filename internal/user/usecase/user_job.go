@@ -0,0 +1,20 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"macaiki/internal/job"
+	"macaiki/internal/user"
+)
+
+// NewPurgeUserHandler handles user.JobPurgeUser jobs
+func NewPurgeUserHandler(userUsecase user.UserUsecase) job.HandlerFunc {
+	return func(ctx context.Context, payload []byte) error {
+		p := user.PurgeUserPayload{}
+		if err := json.Unmarshal(payload, &p); err != nil {
+			return err
+		}
+
+		return userUsecase.PurgeUser(ctx, p.UserID)
+	}
+}
@@ -1,24 +1,32 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	comRepo "macaiki/internal/community"
+	"macaiki/internal/job"
 	"macaiki/internal/notification"
 	notificationEntity "macaiki/internal/notification/entity"
 	reportcategory "macaiki/internal/report_category"
 	"macaiki/internal/thread"
+	threadHelper "macaiki/internal/thread/delivery/http/helper"
 	dtoThread "macaiki/internal/thread/dto"
 	"macaiki/internal/user"
 	"macaiki/internal/user/delivery/http/helper"
 	"macaiki/internal/user/dto"
 	"macaiki/internal/user/entity"
 	cloudstorage "macaiki/pkg/cloud_storage"
-	goMail "macaiki/pkg/gomail"
-	"macaiki/pkg/middleware"
+	"macaiki/pkg/logger"
+	"macaiki/pkg/mailer"
+	"macaiki/pkg/metrics"
+	"macaiki/pkg/ratelimit"
 	"macaiki/pkg/utils"
 	"mime/multipart"
+	"net/url"
+	"path"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -35,15 +43,29 @@ type userUsecase struct {
 	threadRepo         thread.ThreadRepository
 	validator          *validator.Validate
 	awsS3              *cloudstorage.S3
-	goMail             *goMail.Gomail
+	mailOutbox         mailer.Outbox
+	jobQueue           job.Queue
+	lockout            ratelimit.Lockout
+	sessions           user.SessionIssuer
+	appBaseURL         string
+	logger             *slog.Logger
 }
 
-var (
-	DEFAULT_PROFILE    = "https://macaiki.s3.ap-southeast-3.amazonaws.com/profile/default-avatar.png"
-	DEFAULT_BACKGROUND = "https://macaiki.s3.ap-southeast-3.amazonaws.com/background/default-background.png"
+const (
+	OTP_EXPIRATION      = 10 * time.Minute
+	OTP_RESEND_COOLDOWN = 1 * time.Minute
+	OTP_MAX_ATTEMPTS    = 5
+
+	EMAIL_CHANGE_EXPIRATION        = 24 * time.Hour
+	EMAIL_CHANGE_REVERT_EXPIRATION = 7 * 24 * time.Hour
+
+	// DELETION_GRACE_PERIOD is how long a deleted account can be restored
+	DELETION_GRACE_PERIOD = 30 * 24 * time.Hour
+	// DELETED_USER is shown in place of the name of a deleted account
+	DELETED_USER = "[deleted user]"
 )
 
-func NewUserUsecase(userRepo user.UserRepository, reportCategoryRepo reportcategory.ReportCategoryRepository, communityRepo comRepo.CommunityRepository, notificationRepo notification.NotificationRepository, threadRepo thread.ThreadRepository, validator *validator.Validate, awsS3Instace *cloudstorage.S3, goMail *goMail.Gomail) user.UserUsecase {
+func NewUserUsecase(userRepo user.UserRepository, reportCategoryRepo reportcategory.ReportCategoryRepository, communityRepo comRepo.CommunityRepository, notificationRepo notification.NotificationRepository, threadRepo thread.ThreadRepository, validator *validator.Validate, awsS3Instace *cloudstorage.S3, mailOutbox mailer.Outbox, jobQueue job.Queue, lockout ratelimit.Lockout, sessions user.SessionIssuer, appBaseURL string, log *slog.Logger) user.UserUsecase {
 	return &userUsecase{
 		userRepo:           userRepo,
 		reportCategoryRepo: reportCategoryRepo,
@@ -52,39 +74,67 @@ func NewUserUsecase(userRepo user.UserRepository, reportCategoryRepo reportcateg
 		threadRepo:         threadRepo,
 		validator:          validator,
 		awsS3:              awsS3Instace,
-		goMail:             goMail,
+		mailOutbox:         mailOutbox,
+		jobQueue:           jobQueue,
+		lockout:            ratelimit.OrNoop(lockout),
+		sessions:           sessions,
+		appBaseURL:         appBaseURL,
+		logger:             logger.OrDefault(log),
 	}
 }
 
-func (uu *userUsecase) Login(loginInfo dto.UserLoginRequest) (dto.LoginResponse, error) {
+func (uu *userUsecase) Login(ctx context.Context, loginInfo dto.UserLoginRequest) (dto.LoginResponse, error) {
 	if err := uu.validator.Struct(loginInfo); err != nil {
 		return dto.LoginResponse{}, utils.ErrBadParamInput
 	}
 
-	userEntity, err := uu.userRepo.GetByEmail(loginInfo.Email)
+	lockoutKey := loginLockoutKey(loginInfo.Email)
+	if err := uu.lockout.Check(ctx, lockoutKey); err != nil {
+		return dto.LoginResponse{}, uu.lockoutError(ctx, err)
+	}
+
+	userEntity, err := uu.userRepo.GetByEmail(ctx, loginInfo.Email)
 	if err != nil {
 		return dto.LoginResponse{}, utils.ErrInternalServerError
 	}
 
 	if userEntity.ID == 0 || !comparePasswords(userEntity.Password, []byte(loginInfo.Password)) {
+		metrics.Logins.WithLabelValues(metrics.LoginFail).Inc()
+		if err := uu.lockout.Fail(ctx, lockoutKey); err != nil {
+			return dto.LoginResponse{}, uu.lockoutError(ctx, err)
+		}
 		return dto.LoginResponse{}, utils.ErrLoginFailed
 	}
 
-	token, err := middleware.JWTCreateToken(int(userEntity.ID), userEntity.Role)
+	if err := uu.lockout.Succeed(ctx, lockoutKey); err != nil {
+		uu.logger.WarnContext(ctx, "failed to reset login failures", "err", err)
+	}
+
+	res, err := uu.sessions.Issue(ctx, userEntity)
 	if err != nil {
 		return dto.LoginResponse{}, err
 	}
 
-	return helper.ToLoginResponse(token), nil
+	// a login that still has to pass the second factor is counted once
+	// the challenge is answered
+	if res.Token != "" {
+		metrics.Logins.WithLabelValues(metrics.LoginSuccess).Inc()
+	}
+	return res, nil
 }
 
-func (uu *userUsecase) Register(user dto.UserRequest) error {
+func (uu *userUsecase) Register(ctx context.Context, user dto.UserRequest) error {
 	// TO DO : error handling for existing username
 	if err := uu.validator.Struct(user); err != nil {
 		return utils.ErrBadParamInput
 	}
 
-	userEmail, err := uu.userRepo.GetByEmail(user.Email)
+	// usernames of deleted accounts are reserved for their placeholders
+	if strings.HasPrefix(user.Username, DELETED_USER) {
+		return utils.ErrBadParamInput
+	}
+
+	userEmail, err := uu.userRepo.GetByEmail(ctx, user.Email)
 	if err != nil {
 		return utils.ErrInternalServerError
 	}
@@ -93,7 +143,7 @@ func (uu *userUsecase) Register(user dto.UserRequest) error {
 		return utils.ErrEmailAlreadyUsed
 	}
 
-	userUsername, err := uu.userRepo.GetByUsername(user.Username)
+	userUsername, err := uu.userRepo.GetByUsername(ctx, user.Username)
 	if err != nil {
 		return utils.ErrInternalServerError
 	}
@@ -111,22 +161,23 @@ func (uu *userUsecase) Register(user dto.UserRequest) error {
 		Username:           user.Username,
 		Password:           hashAndSalt([]byte(user.Password)),
 		Role:               "User",
-		ProfileImageUrl:    DEFAULT_PROFILE,
-		BackgroundImageUrl: DEFAULT_BACKGROUND,
+		ProfileImageUrl:    entity.DEFAULT_PROFILE,
+		BackgroundImageUrl: entity.DEFAULT_BACKGROUND,
 		Name:               user.Username,
 		IsBanned:           0,
 	}
 
-	err = uu.userRepo.Store(userEntity)
+	err = uu.userRepo.Store(ctx, userEntity)
 	if err != nil {
 		return err
 	}
 
+	metrics.UserRegistrations.Inc()
 	return nil
 }
 
-func (uu *userUsecase) GetAll(userID uint, search string) ([]dto.UserResponse, error) {
-	users, err := uu.userRepo.GetAllWithDetail(userID, search)
+func (uu *userUsecase) GetAll(ctx context.Context, userID uint, search string) ([]dto.UserResponse, error) {
+	users, err := uu.userRepo.GetAllWithDetail(ctx, userID, search)
 	if err != nil {
 		return []dto.UserResponse{}, utils.ErrInternalServerError
 	}
@@ -134,8 +185,8 @@ func (uu *userUsecase) GetAll(userID uint, search string) ([]dto.UserResponse, e
 	return helper.DomainUserToListUserResponse(users), err
 }
 
-func (uu *userUsecase) Get(id, tokenUserID uint) (dto.UserDetailResponse, error) {
-	userEntity, err := uu.userRepo.GetWithDetail(id, tokenUserID)
+func (uu *userUsecase) Get(ctx context.Context, id, tokenUserID uint) (dto.UserDetailResponse, error) {
+	userEntity, err := uu.userRepo.GetWithDetail(ctx, id, tokenUserID)
 	if err != nil {
 		return dto.UserDetailResponse{}, utils.ErrInternalServerError
 	}
@@ -143,17 +194,17 @@ func (uu *userUsecase) Get(id, tokenUserID uint) (dto.UserDetailResponse, error)
 		return dto.UserDetailResponse{}, utils.ErrNotFound
 	}
 
-	totalFollowing, err := uu.userRepo.GetFollowingNumber(id)
+	totalFollowing, err := uu.userRepo.GetFollowingNumber(ctx, id)
 	if err != nil {
 		return dto.UserDetailResponse{}, utils.ErrInternalServerError
 	}
 
-	totalFollower, err := uu.userRepo.GetFollowerNumber(id)
+	totalFollower, err := uu.userRepo.GetFollowerNumber(ctx, id)
 	if err != nil {
 		return dto.UserDetailResponse{}, utils.ErrInternalServerError
 	}
 
-	totalPost, err := uu.userRepo.GetThreadsNumber(id)
+	totalPost, err := uu.userRepo.GetThreadsNumber(ctx, id)
 	if err != nil {
 		return dto.UserDetailResponse{}, utils.ErrInternalServerError
 	}
@@ -161,9 +212,9 @@ func (uu *userUsecase) Get(id, tokenUserID uint) (dto.UserDetailResponse, error)
 	userResp := helper.DomainUserToUserDetailResponse(userEntity, totalFollowing, totalFollower, totalPost)
 	return userResp, nil
 }
-func (uu *userUsecase) Update(user dto.UserUpdateRequest, id uint) (dto.UserUpdateResponse, error) {
+func (uu *userUsecase) Update(ctx context.Context, user dto.UserUpdateRequest, id uint) (dto.UserUpdateResponse, error) {
 	// validation the user exist
-	userDB, err := uu.userRepo.Get(id)
+	userDB, err := uu.userRepo.Get(ctx, id)
 	if err != nil {
 		return dto.UserUpdateResponse{}, utils.ErrInternalServerError
 	}
@@ -177,71 +228,256 @@ func (uu *userUsecase) Update(user dto.UserUpdateRequest, id uint) (dto.UserUpda
 		Profession: user.Profession,
 	}
 
-	userDB, err = uu.userRepo.Update(&userDB, userEntity)
+	userDB, err = uu.userRepo.Update(ctx, &userDB, userEntity)
 	if err != nil {
 		return dto.UserUpdateResponse{}, utils.ErrInternalServerError
 	}
 
 	return helper.DomainUserToUserUpdateResponse(userDB), nil
 }
-func (uu *userUsecase) Delete(id uint, curentUserID uint, curentUserRole string) error {
+
+// Delete schedules the account for deletion, it stays usable until the
+// grace period ends so the deletion can still be cancelled
+func (uu *userUsecase) Delete(ctx context.Context, id uint, curentUserID uint, curentUserRole string) (dto.AccountDeletionResponse, error) {
 	// validation the user exist
-	userEntity, err := uu.userRepo.Get(id)
+	userEntity, err := uu.userRepo.Get(ctx, id)
 	if err != nil {
-		return utils.ErrInternalServerError
+		return dto.AccountDeletionResponse{}, utils.ErrInternalServerError
 	}
 	if userEntity.ID == 0 {
-		return utils.ErrNotFound
+		return dto.AccountDeletionResponse{}, utils.ErrNotFound
 	}
 
 	// validation that accesses is the user itself or Admin
 	if curentUserID != id && curentUserRole != "Admin" {
-		return utils.ErrUnauthorizedAccess
+		return dto.AccountDeletionResponse{}, utils.ErrUnauthorizedAccess
 	}
 
-	err = uu.userRepo.Delete(id)
+	if userEntity.DeletionScheduledAt != nil {
+		return dto.AccountDeletionResponse{ScheduledAt: *userEntity.DeletionScheduledAt}, nil
+	}
+
+	scheduledAt := time.Now().Add(DELETION_GRACE_PERIOD)
+	err = uu.userRepo.SetDeletionSchedule(ctx, id, &scheduledAt)
+	if err != nil {
+		return dto.AccountDeletionResponse{}, utils.ErrInternalServerError
+	}
+
+	err = uu.jobQueue.EnqueueAt(ctx, user.JobPurgeUser, user.PurgeUserPayload{UserID: id}, scheduledAt)
+	if err != nil {
+		uu.logger.ErrorContext(ctx, "failed to enqueue account purge", "err", err, "user_id", id)
+		return dto.AccountDeletionResponse{}, utils.ErrInternalServerError
+	}
+
+	err = uu.mailOutbox.Enqueue(ctx, userEntity.Email, "account_deletion_scheduled", map[string]interface{}{
+		"Username":    userEntity.Username,
+		"ScheduledAt": scheduledAt.Format("2 January 2006"),
+	})
+	if err != nil {
+		uu.logger.ErrorContext(ctx, "failed to enqueue deletion email", "err", err, "user_id", id)
+	}
+
+	return dto.AccountDeletionResponse{ScheduledAt: scheduledAt}, nil
+}
+
+// CancelDeletion stops a scheduled deletion, the queued purge finds the
+// schedule cleared and does nothing
+func (uu *userUsecase) CancelDeletion(ctx context.Context, id uint) error {
+	userEntity, err := uu.userRepo.Get(ctx, id)
 	if err != nil {
 		return utils.ErrInternalServerError
 	}
-	return nil
+	if userEntity.ID == 0 {
+		return utils.ErrNotFound
+	}
+
+	if userEntity.DeletionScheduledAt == nil {
+		return utils.ErrBadParamInput
+	}
 
+	return uu.userRepo.SetDeletionSchedule(ctx, id, nil)
 }
 
-func (uu *userUsecase) ChangeEmail(id uint, info dto.UserLoginRequest) (string, error) {
+// PurgeUser anonymizes an account whose grace period is over. The username
+// and email are replaced so they can be registered again, content stays
+// under the placeholder name.
+func (uu *userUsecase) PurgeUser(ctx context.Context, id uint) error {
+	userEntity, err := uu.userRepo.Get(ctx, id)
+	if err != nil {
+		return utils.ErrInternalServerError
+	}
+
+	// the account is already gone, or the deletion was cancelled or pushed
+	// back after this job was queued
+	if userEntity.ID == 0 || userEntity.DeletionScheduledAt == nil || time.Now().Before(*userEntity.DeletionScheduledAt) {
+		return nil
+	}
+
+	exportKeys, err := uu.userRepo.AnonymizeUser(ctx, id, entity.User{
+		Email:              fmt.Sprintf("deleted-%d@deleted.invalid", id),
+		Username:           fmt.Sprintf("%s#%d", DELETED_USER, id),
+		Name:               DELETED_USER,
+		ProfileImageUrl:    entity.DEFAULT_PROFILE,
+		BackgroundImageUrl: entity.DEFAULT_BACKGROUND,
+	})
+	if errors.Is(err, utils.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if userEntity.ProfileImageUrl != entity.DEFAULT_PROFILE {
+		uu.deleteObject(ctx, cloudstorage.KeyFromURL(userEntity.ProfileImageUrl, "profile"))
+	}
+	if userEntity.BackgroundImageUrl != entity.DEFAULT_BACKGROUND {
+		uu.deleteObject(ctx, cloudstorage.KeyFromURL(userEntity.BackgroundImageUrl, "background"))
+	}
+	for _, key := range exportKeys {
+		uu.deleteObject(ctx, key)
+	}
+
+	return nil
+}
+
+// ChangeEmail starts a pending change, the account keeps its current email
+// until the link sent to the new address is opened
+func (uu *userUsecase) ChangeEmail(ctx context.Context, id uint, info dto.UserLoginRequest) (dto.EmailChangeResponse, error) {
 	if err := uu.validator.Struct(info); err != nil {
-		return "", utils.ErrBadParamInput
+		return dto.EmailChangeResponse{}, utils.ErrBadParamInput
 	}
 
-	userDB, err := uu.userRepo.Get(id)
+	userDB, err := uu.userRepo.Get(ctx, id)
 	if err != nil {
-		return "", utils.ErrInternalServerError
+		return dto.EmailChangeResponse{}, utils.ErrInternalServerError
 	}
 
-	if userDB.Email != info.Email {
-		userEmail, err := uu.userRepo.GetByEmail(info.Email)
-		if err != nil {
-			return "", utils.ErrInternalServerError
-		}
-		if userEmail.ID != 0 {
-			return "", utils.ErrEmailAlreadyUsed
-		}
+	if userDB.Email == info.Email {
+		return dto.EmailChangeResponse{}, utils.ErrBadParamInput
+	}
+
+	userEmail, err := uu.userRepo.GetByEmail(ctx, info.Email)
+	if err != nil {
+		return dto.EmailChangeResponse{}, utils.ErrInternalServerError
+	}
+	if userEmail.ID != 0 {
+		return dto.EmailChangeResponse{}, utils.ErrEmailAlreadyUsed
 	}
 
 	if !comparePasswords(userDB.Password, []byte(info.Password)) {
-		return "", utils.ErrForbidden
+		return dto.EmailChangeResponse{}, utils.ErrForbidden
 	}
 
-	userEntity := entity.User{
-		Email: info.Email,
+	confirmToken := utils.GenerateSecureToken(32)
+	revertToken := utils.GenerateSecureToken(32)
+	change := entity.EmailChange{
+		UserID:           userDB.ID,
+		OldEmail:         userDB.Email,
+		NewEmail:         info.Email,
+		ConfirmTokenHash: utils.HashToken(confirmToken),
+		RevertTokenHash:  utils.HashToken(revertToken),
+		ExpiredAt:        time.Now().Add(EMAIL_CHANGE_EXPIRATION),
+		RevertExpiredAt:  time.Now().Add(EMAIL_CHANGE_REVERT_EXPIRATION),
+	}
+	if err := uu.userRepo.StoreEmailChange(ctx, change); err != nil {
+		return dto.EmailChangeResponse{}, utils.ErrInternalServerError
+	}
+
+	err = uu.mailOutbox.Enqueue(ctx, change.NewEmail, "confirm_email_change", map[string]interface{}{
+		"Username":  userDB.Username,
+		"NewEmail":  change.NewEmail,
+		"Link":      uu.link("/email-change/confirm", confirmToken),
+		"ExpiresIn": EMAIL_CHANGE_EXPIRATION.String(),
+	})
+	if err != nil {
+		return dto.EmailChangeResponse{}, utils.ErrInternalServerError
 	}
-	userDB, err = uu.userRepo.Update(&userDB, userEntity)
+
+	err = uu.mailOutbox.Enqueue(ctx, change.OldEmail, "email_change_notice", map[string]interface{}{
+		"Username":   userDB.Username,
+		"NewEmail":   change.NewEmail,
+		"RevertLink": uu.link("/email-change/revert", revertToken),
+		"ExpiresIn":  EMAIL_CHANGE_REVERT_EXPIRATION.String(),
+	})
 	if err != nil {
-		return "", utils.ErrInternalServerError
+		return dto.EmailChangeResponse{}, utils.ErrInternalServerError
+	}
+
+	return dto.EmailChangeResponse{
+		PendingEmail: change.NewEmail,
+		ExpiredAt:    change.ExpiredAt,
+	}, nil
+}
+
+func (uu *userUsecase) ConfirmEmailChange(ctx context.Context, req dto.EmailChangeTokenRequest) error {
+	if err := uu.validator.Struct(req); err != nil {
+		return utils.ErrBadParamInput
+	}
+
+	change, err := uu.userRepo.GetEmailChangeByConfirmToken(ctx, utils.HashToken(req.Token))
+	if err == utils.ErrNotFound {
+		return utils.ErrInvalidToken
+	}
+	if err != nil {
+		return utils.ErrInternalServerError
+	}
+
+	if change.ConfirmedAt != nil || change.RevertedAt != nil || time.Now().After(change.ExpiredAt) {
+		return utils.ErrInvalidToken
+	}
+
+	userEmail, err := uu.userRepo.GetByEmail(ctx, change.NewEmail)
+	if err != nil {
+		return utils.ErrInternalServerError
+	}
+	if userEmail.ID != 0 {
+		return utils.ErrEmailAlreadyUsed
 	}
 
-	return info.Email, nil
+	return emailChangeError(uu.userRepo.ApplyEmailChange(ctx, change))
 }
-func (uu *userUsecase) ChangePassword(id uint, passwordInfo dto.UserChangePasswordRequest) error {
+
+func (uu *userUsecase) RevertEmailChange(ctx context.Context, req dto.EmailChangeTokenRequest) error {
+	if err := uu.validator.Struct(req); err != nil {
+		return utils.ErrBadParamInput
+	}
+
+	change, err := uu.userRepo.GetEmailChangeByRevertToken(ctx, utils.HashToken(req.Token))
+	if err == utils.ErrNotFound {
+		return utils.ErrInvalidToken
+	}
+	if err != nil {
+		return utils.ErrInternalServerError
+	}
+
+	if change.RevertedAt != nil || time.Now().After(change.RevertExpiredAt) {
+		return utils.ErrInvalidToken
+	}
+
+	return emailChangeError(uu.userRepo.RevertEmailChange(ctx, change))
+}
+
+// emailChangeError maps a change that was already used, or an account whose
+// email moved on in the meantime, to an invalid link
+func emailChangeError(err error) error {
+	switch err {
+	case nil:
+		return nil
+	case utils.ErrNotFound, utils.ErrConflict:
+		return utils.ErrInvalidToken
+	case utils.ErrEmailAlreadyUsed:
+		return err
+	default:
+		return utils.ErrInternalServerError
+	}
+}
+
+// link builds a client URL carrying a one-time token
+func (uu *userUsecase) link(path, token string) string {
+	return strings.TrimSuffix(uu.appBaseURL, "/") + path + "?token=" + url.QueryEscape(token)
+}
+
+func (uu *userUsecase) ChangePassword(ctx context.Context, id uint, passwordInfo dto.UserChangePasswordRequest) error {
 	if err := uu.validator.Struct(passwordInfo); err != nil {
 		return utils.ErrBadParamInput
 	}
@@ -250,7 +486,7 @@ func (uu *userUsecase) ChangePassword(id uint, passwordInfo dto.UserChangePasswo
 		return utils.ErrPasswordDontMatch
 	}
 
-	userDB, err := uu.userRepo.Get(id)
+	userDB, err := uu.userRepo.Get(ctx, id)
 	if err != nil {
 		return utils.ErrInternalServerError
 	}
@@ -259,7 +495,7 @@ func (uu *userUsecase) ChangePassword(id uint, passwordInfo dto.UserChangePasswo
 		Password: hashAndSalt([]byte(passwordInfo.NewPassword)),
 	}
 
-	_, err = uu.userRepo.Update(&userDB, userEntity)
+	_, err = uu.userRepo.Update(ctx, &userDB, userEntity)
 	if err != nil {
 		return utils.ErrInternalServerError
 	}
@@ -267,8 +503,8 @@ func (uu *userUsecase) ChangePassword(id uint, passwordInfo dto.UserChangePasswo
 	return nil
 }
 
-func (uu *userUsecase) GetUserFollowers(tokenUserID, getFollowingUserID uint) ([]dto.UserResponse, error) {
-	userEntity, err := uu.userRepo.Get(getFollowingUserID)
+func (uu *userUsecase) GetUserFollowers(ctx context.Context, tokenUserID, getFollowingUserID uint) ([]dto.UserResponse, error) {
+	userEntity, err := uu.userRepo.Get(ctx, getFollowingUserID)
 	if err != nil {
 		return []dto.UserResponse{}, utils.ErrInternalServerError
 	}
@@ -276,15 +512,15 @@ func (uu *userUsecase) GetUserFollowers(tokenUserID, getFollowingUserID uint) ([
 		return []dto.UserResponse{}, utils.ErrNotFound
 	}
 
-	followers, err := uu.userRepo.GetFollower(tokenUserID, getFollowingUserID)
+	followers, err := uu.userRepo.GetFollower(ctx, tokenUserID, getFollowingUserID)
 	if err != nil {
 		return []dto.UserResponse{}, utils.ErrInternalServerError
 	}
 	return helper.DomainUserToListUserResponse(followers), nil
 }
 
-func (uu *userUsecase) GetUserFollowing(tokenUserID, getFollowingUserID uint) ([]dto.UserResponse, error) {
-	userEntity, err := uu.userRepo.Get(getFollowingUserID)
+func (uu *userUsecase) GetUserFollowing(ctx context.Context, tokenUserID, getFollowingUserID uint) ([]dto.UserResponse, error) {
+	userEntity, err := uu.userRepo.Get(ctx, getFollowingUserID)
 	if err != nil {
 		return []dto.UserResponse{}, utils.ErrInternalServerError
 	}
@@ -292,15 +528,15 @@ func (uu *userUsecase) GetUserFollowing(tokenUserID, getFollowingUserID uint) ([
 		return []dto.UserResponse{}, utils.ErrNotFound
 	}
 
-	following, err := uu.userRepo.GetFollowing(tokenUserID, getFollowingUserID)
+	following, err := uu.userRepo.GetFollowing(ctx, tokenUserID, getFollowingUserID)
 	if err != nil {
 		return []dto.UserResponse{}, utils.ErrInternalServerError
 	}
 	return helper.DomainUserToListUserResponse(following), nil
 }
 
-func (uu *userUsecase) SetProfileImage(id uint, img *multipart.FileHeader) (string, error) {
-	user, err := uu.userRepo.Get(id)
+func (uu *userUsecase) SetProfileImage(ctx context.Context, id uint, img *multipart.FileHeader) (string, error) {
+	user, err := uu.userRepo.Get(ctx, id)
 	if err != nil {
 		return "", utils.ErrInternalServerError
 	}
@@ -309,31 +545,28 @@ func (uu *userUsecase) SetProfileImage(id uint, img *multipart.FileHeader) (stri
 		return "", utils.ErrNotFound
 	}
 
-	if user.ProfileImageUrl != DEFAULT_PROFILE {
-		err = uu.awsS3.DeleteImage(user.ProfileImageUrl, "profile")
-		if err != nil {
-			return "", err
-		}
-	}
-
 	uniqueFilename := uuid.New()
-	result, err := uu.awsS3.UploadImage(uniqueFilename.String(), "profile", img)
+	result, err := uu.awsS3.UploadImage(ctx, uniqueFilename.String(), "profile", img)
 	if err != nil {
 		return "", err
 	}
 
 	imageURL := aws.StringValue(&result.Location)
 
-	err = uu.userRepo.SetUserImage(id, imageURL, "profile_image_url")
+	err = uu.userRepo.SetUserImage(ctx, id, imageURL, "profile_image_url")
 	if err != nil {
 		return "", err
 	}
 
-	return imageURL, err
+	if user.ProfileImageUrl != entity.DEFAULT_PROFILE {
+		uu.deleteImage(ctx, user.ProfileImageUrl, "profile")
+	}
+
+	return imageURL, nil
 }
 
-func (uu *userUsecase) SetBackgroundImage(id uint, img *multipart.FileHeader) (string, error) {
-	user, err := uu.userRepo.Get(id)
+func (uu *userUsecase) SetBackgroundImage(ctx context.Context, id uint, img *multipart.FileHeader) (string, error) {
+	user, err := uu.userRepo.Get(ctx, id)
 	if err != nil {
 		return "", utils.ErrInternalServerError
 	}
@@ -342,30 +575,27 @@ func (uu *userUsecase) SetBackgroundImage(id uint, img *multipart.FileHeader) (s
 		return "", utils.ErrNotFound
 	}
 
-	if user.BackgroundImageUrl != DEFAULT_BACKGROUND {
-		err = uu.awsS3.DeleteImage(user.BackgroundImageUrl, "background")
-		if err != nil {
-			return "", err
-		}
-	}
-
 	uniqueFilename := uuid.New()
-	result, err := uu.awsS3.UploadImage(uniqueFilename.String(), "background", img)
+	result, err := uu.awsS3.UploadImage(ctx, uniqueFilename.String(), "background", img)
 	if err != nil {
 		return "", err
 	}
 
 	imageURL := aws.StringValue(&result.Location)
-	err = uu.userRepo.SetUserImage(id, imageURL, "background_image_url")
+	err = uu.userRepo.SetUserImage(ctx, id, imageURL, "background_image_url")
 	if err != nil {
 		return "", err
 	}
 
-	return imageURL, err
+	if user.BackgroundImageUrl != entity.DEFAULT_BACKGROUND {
+		uu.deleteImage(ctx, user.BackgroundImageUrl, "background")
+	}
+
+	return imageURL, nil
 }
 
-func (uu *userUsecase) Follow(userID, userFollowerID uint) error {
-	user, err := uu.userRepo.Get(userID)
+func (uu *userUsecase) Follow(ctx context.Context, userID, userFollowerID uint) error {
+	user, err := uu.userRepo.Get(ctx, userID)
 	if err != nil {
 		return utils.ErrInternalServerError
 	}
@@ -373,7 +603,7 @@ func (uu *userUsecase) Follow(userID, userFollowerID uint) error {
 		return utils.ErrNotFound
 	}
 
-	user_follower, err := uu.userRepo.Get(userFollowerID)
+	user_follower, err := uu.userRepo.Get(ctx, userFollowerID)
 	if err != nil {
 		return utils.ErrInternalServerError
 	}
@@ -387,25 +617,25 @@ func (uu *userUsecase) Follow(userID, userFollowerID uint) error {
 	}
 
 	// save to database
-	_, err = uu.userRepo.Follow(user, user_follower)
+	_, err = uu.userRepo.Follow(ctx, user, user_follower)
 	if err != nil {
 		return utils.ErrInternalServerError
 	}
-	err = uu.notificationRepo.StoreNotification(notificationEntity.Notification{
+	err = uu.jobQueue.Enqueue(ctx, notification.JobStoreNotification, notificationEntity.Notification{
 		UserID:            userID,
 		NotificationType:  "Follow You",
 		NotificationRefID: userFollowerID,
 		IsReaded:          0,
 	})
 	if err != nil {
-		fmt.Println("failed to send notification")
+		uu.logger.ErrorContext(ctx, "failed to enqueue follow notification", "err", err)
 	}
 
 	return nil
 }
 
-func (uu *userUsecase) Unfollow(userID, userFollowerID uint) error {
-	user, err := uu.userRepo.Get(userID)
+func (uu *userUsecase) Unfollow(ctx context.Context, userID, userFollowerID uint) error {
+	user, err := uu.userRepo.Get(ctx, userID)
 	if err != nil {
 		return utils.ErrInternalServerError
 	}
@@ -413,7 +643,7 @@ func (uu *userUsecase) Unfollow(userID, userFollowerID uint) error {
 		return utils.ErrNotFound
 	}
 
-	user_follower, err := uu.userRepo.Get(userFollowerID)
+	user_follower, err := uu.userRepo.Get(ctx, userFollowerID)
 	if err != nil {
 		return utils.ErrInternalServerError
 	}
@@ -421,15 +651,15 @@ func (uu *userUsecase) Unfollow(userID, userFollowerID uint) error {
 		return utils.ErrNotFound
 	}
 
-	_, err = uu.userRepo.Unfollow(user, user_follower)
+	_, err = uu.userRepo.Unfollow(ctx, user, user_follower)
 	if err != nil {
 		return utils.ErrInternalServerError
 	}
 	return nil
 }
 
-func (uu *userUsecase) Report(userID, userReportedID, reportCategoryID uint) error {
-	user, err := uu.userRepo.Get(userReportedID)
+func (uu *userUsecase) Report(ctx context.Context, userID, userReportedID, reportCategoryID uint) error {
+	user, err := uu.userRepo.Get(ctx, userReportedID)
 	if err != nil {
 		return utils.ErrInternalServerError
 	}
@@ -438,7 +668,7 @@ func (uu *userUsecase) Report(userID, userReportedID, reportCategoryID uint) err
 		return utils.ErrNotFound
 	}
 
-	reportCategory, err := uu.reportCategoryRepo.GetReportCategory(reportCategoryID)
+	reportCategory, err := uu.reportCategoryRepo.GetReportCategory(ctx, reportCategoryID)
 	if err != nil {
 		return utils.ErrInternalServerError
 	}
@@ -446,7 +676,7 @@ func (uu *userUsecase) Report(userID, userReportedID, reportCategoryID uint) err
 		return utils.ErrNotFound
 	}
 
-	err = uu.userRepo.StoreReport(entity.UserReport{
+	err = uu.userRepo.StoreReport(ctx, entity.UserReport{
 		UserID:           userID,
 		ReportedUserID:   userReportedID,
 		ReportCategoryID: reportCategoryID,
@@ -454,42 +684,30 @@ func (uu *userUsecase) Report(userID, userReportedID, reportCategoryID uint) err
 	if err != nil {
 		return utils.ErrInternalServerError
 	}
+
+	metrics.ReportsFiled.WithLabelValues(metrics.ReportUser).Inc()
 	return nil
 }
 
-func (uu *userUsecase) GetThreadByToken(userID, tokenUserID uint) ([]dtoThread.DetailedThreadResponse, error) {
+func (uu *userUsecase) GetThreadByToken(ctx context.Context, userID, tokenUserID uint) ([]dtoThread.DetailedThreadResponse, error) {
 
-	threads, err := uu.threadRepo.GetThreadsByUserID(userID, tokenUserID)
+	threads, err := uu.threadRepo.GetThreadsByUserID(ctx, userID, tokenUserID)
 	if err != nil {
 		return []dtoThread.DetailedThreadResponse{}, utils.ErrInternalServerError
 	}
 
 	dtoThreads := []dtoThread.DetailedThreadResponse{}
 	for _, val := range threads {
-		dtoThreads = append(dtoThreads, dtoThread.DetailedThreadResponse{
-			ID:                    val.Thread.ID,
-			Title:                 val.Title,
-			Body:                  val.Body,
-			CommunityID:           val.CommunityID,
-			ImageURL:              val.ImageURL,
-			UserID:                val.UserID,
-			UserName:              val.Username,
-			UserProfession:        val.Profession,
-			UserProfilePictureURL: val.ProfileImageUrl,
-			CreatedAt:             val.Thread.CreatedAt,
-			UpdatedAt:             val.Thread.UpdatedAt,
-			UpvotesCount:          val.UpvotesCount,
-			IsUpvoted:             val.IsUpvoted,
-			IsDownVoted:           val.IsDownvoted,
-			IsFollowed:            val.IsFollowed,
-		})
+		thread := threadHelper.DomainThreadToDetailedThreadResponse(val)
+		thread.UserName = val.Username
+		dtoThreads = append(dtoThreads, thread)
 	}
 
 	return dtoThreads, nil
 }
 
-func (uu *userUsecase) SendOTP(otpReq dto.SendOTPRequest) error {
-	user, err := uu.userRepo.GetByEmail(otpReq.Email)
+func (uu *userUsecase) SendOTP(ctx context.Context, otpReq dto.SendOTPRequest) error {
+	user, err := uu.userRepo.GetByEmail(ctx, otpReq.Email)
 	if err != nil {
 		return utils.ErrInternalServerError
 	}
@@ -498,54 +716,113 @@ func (uu *userUsecase) SendOTP(otpReq dto.SendOTPRequest) error {
 		return utils.ErrNotFound
 	}
 
-	OTPCode := uu.goMail.GenerateSecureToken(3)
-	err = uu.userRepo.StoreOTP(entity.VerificationEmail{
+	if !user.EmailVerifiedAt.IsZero() {
+		return utils.ErrEmailAlreadyVerified
+	}
+
+	latest, err := uu.userRepo.GetOTP(ctx, user.Email)
+	if err != nil && err != utils.ErrNotFound {
+		return utils.ErrInternalServerError
+	}
+	if err == nil {
+		if wait := OTP_RESEND_COOLDOWN - time.Since(latest.CreatedAt); wait > 0 {
+			return &utils.RetryAfterError{Err: utils.ErrTooManyRequests, RetryAfter: wait}
+		}
+	}
+
+	OTPCode := utils.GenerateSecureToken(3)
+	err = uu.userRepo.StoreOTP(ctx, entity.VerificationEmail{
 		Email:     user.Email,
-		OTPCode:   OTPCode,
-		ExpiredAt: time.Now().Add(1 * time.Minute),
+		OTPHash:   hashAndSalt([]byte(OTPCode)),
+		ExpiredAt: time.Now().Add(OTP_EXPIRATION),
 	})
 	if err != nil {
 		return utils.ErrInternalServerError
 	}
 
-	err = uu.goMail.SendMail(user.Email, user.Username, fmt.Sprintf("Thank you for registering on the Macaiki application To verify your email, please use the following OTP : %s", OTPCode))
+	err = uu.mailOutbox.Enqueue(ctx, user.Email, "verify_email", map[string]interface{}{
+		"Username":  user.Username,
+		"OTPCode":   OTPCode,
+		"ExpiresIn": OTP_EXPIRATION.String(),
+	})
 	if err != nil {
-		fmt.Println(err)
+		return utils.ErrInternalServerError
 	}
 
 	return nil
 }
 
-func (uu *userUsecase) VerifyOTP(email, OTPCode string) error {
-	EmailVerif, err := uu.userRepo.GetOTP(email)
+func (uu *userUsecase) VerifyOTP(ctx context.Context, req dto.VerifyOTPRequest) error {
+	if err := uu.validator.Struct(req); err != nil {
+		return utils.ErrBadParamInput
+	}
+
+	lockoutKey := otpLockoutKey(req.Email)
+	if err := uu.lockout.Check(ctx, lockoutKey); err != nil {
+		return uu.lockoutError(ctx, err)
+	}
+
+	otp, err := uu.userRepo.GetOTP(ctx, req.Email)
+	if err == utils.ErrNotFound {
+		return utils.ErrOTPInvalid
+	}
 	if err != nil {
 		return utils.ErrInternalServerError
 	}
 
-	if EmailVerif.OTPCode == OTPCode {
-		if time.Now().After(EmailVerif.ExpiredAt) {
-			return errors.New("OTP Is Expired")
-		}
-		user, err := uu.userRepo.GetByEmail(email)
-		if err != nil {
-			return utils.ErrInternalServerError
+	if time.Now().After(otp.ExpiredAt) {
+		return utils.ErrOTPExpired
+	}
+
+	// the attempt is claimed before the guess is compared, parallel guesses
+	// would otherwise all pass a check of the stored count
+	err = uu.userRepo.ClaimOTPAttempt(ctx, otp.ID, OTP_MAX_ATTEMPTS)
+	if err == utils.ErrNotFound {
+		return utils.ErrOTPAttemptsExceeded
+	}
+	if err != nil {
+		return utils.ErrInternalServerError
+	}
+
+	if !comparePasswords(otp.OTPHash, []byte(req.OTP)) {
+		if err := uu.lockout.Fail(ctx, lockoutKey); err != nil {
+			return uu.lockoutError(ctx, err)
 		}
-		_, _ = uu.userRepo.Update(&user, entity.User{
-			EmailVerifiedAt: time.Now(),
-		})
-	} else {
-		return errors.New("OTP Not Valid")
+		return utils.ErrOTPInvalid
+	}
+
+	err = uu.userRepo.ConsumeOTP(ctx, otp.ID)
+	if err == utils.ErrNotFound {
+		return utils.ErrOTPInvalid
+	}
+	if err != nil {
+		return utils.ErrInternalServerError
+	}
+
+	user, err := uu.userRepo.GetByEmail(ctx, req.Email)
+	if err != nil {
+		return utils.ErrInternalServerError
+	}
+	_, err = uu.userRepo.Update(ctx, &user, entity.User{
+		EmailVerifiedAt: time.Now(),
+	})
+	if err != nil {
+		return utils.ErrInternalServerError
+	}
+
+	if err := uu.lockout.Succeed(ctx, lockoutKey); err != nil {
+		uu.logger.WarnContext(ctx, "failed to reset otp failures", "err", err)
 	}
 
 	return nil
 }
 
-func (uu *userUsecase) GetReports(curentUserRole string) ([]dto.BriefReportResponse, error) {
+func (uu *userUsecase) GetReports(ctx context.Context, curentUserRole string) ([]dto.BriefReportResponse, error) {
 	if curentUserRole != "Admin" {
 		return []dto.BriefReportResponse{}, utils.ErrUnauthorizedAccess
 	}
 
-	reports, err := uu.userRepo.GetReports()
+	reports, err := uu.userRepo.GetReports(ctx)
 
 	if err != nil {
 		return []dto.BriefReportResponse{}, utils.ErrInternalServerError
@@ -574,12 +851,12 @@ func (uu *userUsecase) GetReports(curentUserRole string) ([]dto.BriefReportRespo
 	return reportsResp, nil
 }
 
-func (uu *userUsecase) GetDashboardAnalytics(userRole string) (dto.AdminDashboardAnalytics, error) {
+func (uu *userUsecase) GetDashboardAnalytics(ctx context.Context, userRole string) (dto.AdminDashboardAnalytics, error) {
 	if userRole != "Admin" {
 		return dto.AdminDashboardAnalytics{}, utils.ErrUnauthorizedAccess
 	}
 
-	analytics, err := uu.userRepo.GetDashboardAnalytics()
+	analytics, err := uu.userRepo.GetDashboardAnalytics(ctx)
 
 	if err != nil {
 		return dto.AdminDashboardAnalytics{}, utils.ErrInternalServerError
@@ -592,14 +869,14 @@ func (uu *userUsecase) GetDashboardAnalytics(userRole string) (dto.AdminDashboar
 	}, nil
 }
 
-func (uu *userUsecase) GetReportedThread(userRole string, threadReportID uint) (dto.ReportedThreadResponse, error) {
+func (uu *userUsecase) GetReportedThread(ctx context.Context, userRole string, threadReportID uint) (dto.ReportedThreadResponse, error) {
 	var reportedThreadResponse dto.ReportedThreadResponse
 
 	if userRole != "Admin" {
 		return dto.ReportedThreadResponse{}, utils.ErrUnauthorizedAccess
 	}
 
-	reportedThread, err := uu.userRepo.GetReportedThread(threadReportID)
+	reportedThread, err := uu.userRepo.GetReportedThread(ctx, threadReportID)
 
 	if err != nil {
 		return dto.ReportedThreadResponse{}, err
@@ -624,14 +901,14 @@ func (uu *userUsecase) GetReportedThread(userRole string, threadReportID uint) (
 	return reportedThreadResponse, nil
 }
 
-func (uu *userUsecase) GetReportedCommunity(userRole string, communityReportID uint) (dto.ReportedCommunityResponse, error) {
+func (uu *userUsecase) GetReportedCommunity(ctx context.Context, userRole string, communityReportID uint) (dto.ReportedCommunityResponse, error) {
 	var reportedCommunityResponse dto.ReportedCommunityResponse
 
 	if userRole != "Admin" {
 		return dto.ReportedCommunityResponse{}, utils.ErrUnauthorizedAccess
 	}
 
-	reportedCommunity, err := uu.userRepo.GetReportedCommunity(communityReportID)
+	reportedCommunity, err := uu.userRepo.GetReportedCommunity(ctx, communityReportID)
 
 	if err != nil {
 		return dto.ReportedCommunityResponse{}, err
@@ -651,14 +928,14 @@ func (uu *userUsecase) GetReportedCommunity(userRole string, communityReportID u
 	return reportedCommunityResponse, nil
 }
 
-func (uu *userUsecase) GetReportedComment(userRole string, commentReportID uint) (dto.ReportedCommentResponse, error) {
+func (uu *userUsecase) GetReportedComment(ctx context.Context, userRole string, commentReportID uint) (dto.ReportedCommentResponse, error) {
 	var reportedCommentResponse dto.ReportedCommentResponse
 
 	if userRole != "Admin" {
 		return dto.ReportedCommentResponse{}, utils.ErrUnauthorizedAccess
 	}
 
-	reportedComment, err := uu.userRepo.GetReportedComment(commentReportID)
+	reportedComment, err := uu.userRepo.GetReportedComment(ctx, commentReportID)
 
 	if err != nil {
 		return dto.ReportedCommentResponse{}, err
@@ -680,14 +957,14 @@ func (uu *userUsecase) GetReportedComment(userRole string, commentReportID uint)
 	return reportedCommentResponse, nil
 }
 
-func (uu *userUsecase) GetReportedUser(userRole string, userReportID uint) (dto.ReportedUserResponse, error) {
+func (uu *userUsecase) GetReportedUser(ctx context.Context, userRole string, userReportID uint) (dto.ReportedUserResponse, error) {
 	var reportedUserResponse dto.ReportedUserResponse
 
 	if userRole != "Admin" {
 		return dto.ReportedUserResponse{}, utils.ErrUnauthorizedAccess
 	}
 
-	reportedUser, err := uu.userRepo.GetReportedUser(userReportID)
+	reportedUser, err := uu.userRepo.GetReportedUser(ctx, userReportID)
 
 	if err != nil {
 		return dto.ReportedUserResponse{}, err
@@ -710,24 +987,24 @@ func (uu *userUsecase) GetReportedUser(userRole string, userReportID uint) (dto.
 	return reportedUserResponse, nil
 }
 
-func (uu *userUsecase) BanUser(userRole string, userReportID uint) error {
+func (uu *userUsecase) BanUser(ctx context.Context, userRole string, userReportID uint) error {
 	if userRole != "Admin" {
 		return utils.ErrUnauthorizedAccess
 	}
 
-	report, err := uu.userRepo.GetUserReport(userReportID)
+	report, err := uu.userRepo.GetUserReport(ctx, userReportID)
 
 	if err != nil {
 		return err
 	}
 
-	err = uu.userRepo.DeleteUserReport(userReportID)
+	err = uu.userRepo.DeleteUserReport(ctx, userReportID)
 
 	if err != nil {
 		return err
 	}
 
-	err = uu.userRepo.Delete(report.ReportedUserID)
+	err = uu.userRepo.Delete(ctx, report.ReportedUserID)
 
 	if err != nil {
 		return err
@@ -736,24 +1013,24 @@ func (uu *userUsecase) BanUser(userRole string, userReportID uint) error {
 	return nil
 }
 
-func (uu *userUsecase) BanThread(userRole string, threadReportID uint) error {
+func (uu *userUsecase) BanThread(ctx context.Context, userRole string, threadReportID uint) error {
 	if userRole != "Admin" {
 		return utils.ErrUnauthorizedAccess
 	}
 
-	report, err := uu.threadRepo.GetThreadReport(threadReportID)
+	report, err := uu.threadRepo.GetThreadReport(ctx, threadReportID)
 
 	if err != nil {
 		return err
 	}
 
-	err = uu.userRepo.DeleteThreadReport(threadReportID)
+	err = uu.userRepo.DeleteThreadReport(ctx, threadReportID)
 
 	if err != nil {
 		return err
 	}
 
-	err = uu.threadRepo.DeleteThread(report.ThreadID)
+	err = uu.threadRepo.DeleteThread(ctx, report.ThreadID)
 
 	if err != nil {
 		return err
@@ -762,24 +1039,24 @@ func (uu *userUsecase) BanThread(userRole string, threadReportID uint) error {
 	return nil
 }
 
-func (uu *userUsecase) BanComment(userRole string, commentReportID uint) error {
+func (uu *userUsecase) BanComment(ctx context.Context, userRole string, commentReportID uint) error {
 	if userRole != "Admin" {
 		return utils.ErrUnauthorizedAccess
 	}
 
-	report, err := uu.threadRepo.GetCommentReport(commentReportID)
+	report, err := uu.threadRepo.GetCommentReport(ctx, commentReportID)
 
 	if err != nil {
 		return err
 	}
 
-	err = uu.userRepo.DeleteCommentReport(commentReportID)
+	err = uu.userRepo.DeleteCommentReport(ctx, commentReportID)
 
 	if err != nil {
 		return err
 	}
 
-	err = uu.threadRepo.DeleteComment(report.CommentID)
+	err = uu.threadRepo.DeleteComment(ctx, report.CommentID)
 
 	if err != nil {
 		return err
@@ -788,24 +1065,24 @@ func (uu *userUsecase) BanComment(userRole string, commentReportID uint) error {
 	return nil
 }
 
-func (uu *userUsecase) BanCommunity(userRole string, communityReportID uint) error {
+func (uu *userUsecase) BanCommunity(ctx context.Context, userRole string, communityReportID uint) error {
 	if userRole != "Admin" {
 		return utils.ErrUnauthorizedAccess
 	}
 
-	report, err := uu.communityRepo.GetReportCommunity(communityReportID)
+	report, err := uu.communityRepo.GetReportCommunity(ctx, communityReportID)
 
 	if err != nil {
 		return err
 	}
 
-	err = uu.userRepo.DeleteCommunityReport(communityReportID)
+	err = uu.userRepo.DeleteCommunityReport(ctx, communityReportID)
 
 	if err != nil {
 		return err
 	}
 
-	err = uu.communityRepo.DeleteCommunity(report.CommunityReportedID)
+	err = uu.communityRepo.DeleteCommunity(ctx, report.CommunityReportedID)
 
 	if err != nil {
 		return err
@@ -814,42 +1091,42 @@ func (uu *userUsecase) BanCommunity(userRole string, communityReportID uint) err
 	return nil
 }
 
-func (uu *userUsecase) DeleteThreadReport(userRole string, threadReportID uint) error {
+func (uu *userUsecase) DeleteThreadReport(ctx context.Context, userRole string, threadReportID uint) error {
 	if userRole != "Admin" {
 		return utils.ErrUnauthorizedAccess
 	}
 
-	err := uu.userRepo.DeleteThreadReport(threadReportID)
+	err := uu.userRepo.DeleteThreadReport(ctx, threadReportID)
 
 	return err
 }
 
-func (uu *userUsecase) DeleteUserReport(userRole string, userReportID uint) error {
+func (uu *userUsecase) DeleteUserReport(ctx context.Context, userRole string, userReportID uint) error {
 	if userRole != "Admin" {
 		return utils.ErrUnauthorizedAccess
 	}
 
-	err := uu.userRepo.DeleteUserReport(userReportID)
+	err := uu.userRepo.DeleteUserReport(ctx, userReportID)
 
 	return err
 }
 
-func (uu *userUsecase) DeleteCommentReport(userRole string, commentReportID uint) error {
+func (uu *userUsecase) DeleteCommentReport(ctx context.Context, userRole string, commentReportID uint) error {
 	if userRole != "Admin" {
 		return utils.ErrUnauthorizedAccess
 	}
 
-	err := uu.userRepo.DeleteCommentReport(commentReportID)
+	err := uu.userRepo.DeleteCommentReport(ctx, commentReportID)
 
 	return err
 }
 
-func (uu *userUsecase) DeleteCommunityReport(userRole string, communityReportID uint) error {
+func (uu *userUsecase) DeleteCommunityReport(ctx context.Context, userRole string, communityReportID uint) error {
 	if userRole != "Admin" {
 		return utils.ErrUnauthorizedAccess
 	}
 
-	err := uu.userRepo.DeleteCommunityReport(communityReportID)
+	err := uu.userRepo.DeleteCommunityReport(ctx, communityReportID)
 
 	return err
 }
@@ -863,7 +1140,7 @@ func hashAndSalt(pwd []byte) string {
 	// than the MinCost (4)
 	hash, err := bcrypt.GenerateFromPassword(pwd, bcrypt.MinCost)
 	if err != nil {
-		log.Println("err", err)
+		slog.Error("failed to hash password", "err", err)
 	}
 	// GenerateFromPassword returns a byte slice so we need to
 	// convert the bytes to a string and return it
@@ -875,10 +1152,46 @@ func comparePasswords(hashedPwd string, plainPwd []byte) bool {
 	// will be a string so we'll need to convert it to a byte slice
 	byteHash := []byte(hashedPwd)
 	err := bcrypt.CompareHashAndPassword(byteHash, plainPwd)
+	return err == nil
+}
+
+func loginLockoutKey(email string) string {
+	return "login:" + strings.ToLower(email)
+}
+
+func otpLockoutKey(email string) string {
+	return "otp:" + strings.ToLower(email)
+}
+
+// lockoutError passes lockouts through to the client and hides failures of
+// the lockout store itself
+func (uu *userUsecase) lockoutError(ctx context.Context, err error) error {
+	var retryErr *utils.RetryAfterError
+	if errors.As(err, &retryErr) {
+		return err
+	}
+
+	uu.logger.ErrorContext(ctx, "lockout store failed", "err", err)
+	return utils.ErrInternalServerError
+}
+
+// deleteImage removes a replaced image in the background, the new image is
+// already stored so a failure here only leaves an orphaned object
+func (uu *userUsecase) deleteImage(ctx context.Context, fileName, dirName string) {
+	err := uu.jobQueue.Enqueue(ctx, cloudstorage.JobDeleteImage, cloudstorage.DeleteImagePayload{
+		FileName: fileName,
+		DirName:  dirName,
+	})
 	if err != nil {
-		log.Println("err", err)
-		return false
+		uu.logger.ErrorContext(ctx, "failed to enqueue image deletion", "err", err, "file", fileName)
+	}
+}
+
+// deleteObject removes a stored file by its full key in the background
+func (uu *userUsecase) deleteObject(ctx context.Context, key string) {
+	if key == "" {
+		return
 	}
 
-	return true
+	uu.deleteImage(ctx, path.Base(key), path.Dir(key))
 }
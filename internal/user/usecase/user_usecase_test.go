@@ -1,10 +1,13 @@
 package usecase
 
 import (
+	"context"
+	"errors"
 	communityEntity "macaiki/internal/community/entity"
 	communityMock "macaiki/internal/community/mocks"
+	jobMock "macaiki/internal/job/mocks"
+	"macaiki/internal/notification"
 	notifEntity "macaiki/internal/notification/entity"
-	notifMock "macaiki/internal/notification/mocks"
 	rcEntity "macaiki/internal/report_category/entity"
 	reportCategoryMock "macaiki/internal/report_category/mocks"
 	threadEntity "macaiki/internal/thread/entity"
@@ -12,6 +15,9 @@ import (
 	userDTO "macaiki/internal/user/dto"
 	userEntity "macaiki/internal/user/entity"
 	userMock "macaiki/internal/user/mocks"
+	cloudstorage "macaiki/pkg/cloud_storage"
+	"macaiki/pkg/mailer"
+	"macaiki/pkg/ratelimit"
 	"macaiki/pkg/utils"
 	"testing"
 	"time"
@@ -199,7 +205,7 @@ var (
 // 	t.Run("success", func(t *testing.T) {
 // 		mockUserRepo.On("GetByEmail", mockUserEntity1.Email).Return(mockUserEntity1, nil).Once()
 
-// 		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil)
+// 		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil, nil, nil, nil, "", nil)
 // 		res, err := testUserUsecase.Login(loginInfo)
 
 // 		assert.NoError(t, err)
@@ -222,7 +228,7 @@ var (
 // 		mockUserRepo.On("GetByUsername", mockUserReq.Username).Return(userEntity.User{}, nil).Once()
 // 		mockUserRepo.On("Store", mockUserEntity1).Return(nil).Once()
 
-// 		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil)
+// 		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil, nil, nil, nil, "", nil)
 // 		err := testUserUsecase.Register(mockUserReq)
 
 // 		assert.NoError(t, err)
@@ -233,20 +239,20 @@ func TestGetAll(t *testing.T) {
 	mockUserRepo := userMock.NewUserRepository(t)
 
 	t.Run("success", func(t *testing.T) {
-		mockUserRepo.On("GetAllWithDetail", uint(1), "").Return(mockedUserArr, nil).Once()
+		mockUserRepo.On("GetAllWithDetail", mock.Anything, uint(1), "").Return(mockedUserArr, nil).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
-		res, err := testUserUsecase.GetAll(uint(1), "")
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+		res, err := testUserUsecase.GetAll(context.Background(), uint(1), "")
 
 		assert.NoError(t, err)
 		assert.NotEmpty(t, res)
 	})
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		mockUserRepo.On("GetAllWithDetail", uint(1), "").Return(mockedUserArr, utils.ErrInternalServerError).Once()
+		mockUserRepo.On("GetAllWithDetail", mock.Anything, uint(1), "").Return(mockedUserArr, utils.ErrInternalServerError).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
-		res, err := testUserUsecase.GetAll(uint(1), "")
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+		res, err := testUserUsecase.GetAll(context.Background(), uint(1), "")
 
 		assert.Error(t, err)
 		assert.Empty(t, res)
@@ -257,69 +263,69 @@ func TestGet(t *testing.T) {
 	mockUserRepo := userMock.NewUserRepository(t)
 
 	t.Run("success", func(t *testing.T) {
-		mockUserRepo.On("GetWithDetail", uint(1), uint(1)).Return(mockUserEntity1, nil).Once()
-		mockUserRepo.On("GetFollowingNumber", uint(1)).Return(10, nil).Once()
-		mockUserRepo.On("GetFollowerNumber", uint(1)).Return(10, nil).Once()
-		mockUserRepo.On("GetThreadsNumber", uint(1)).Return(10, nil).Once()
+		mockUserRepo.On("GetWithDetail", mock.Anything, uint(1), uint(1)).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("GetFollowingNumber", mock.Anything, uint(1)).Return(10, nil).Once()
+		mockUserRepo.On("GetFollowerNumber", mock.Anything, uint(1)).Return(10, nil).Once()
+		mockUserRepo.On("GetThreadsNumber", mock.Anything, uint(1)).Return(10, nil).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
-		res, err := testUserUsecase.Get(uint(1), uint(1))
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+		res, err := testUserUsecase.Get(context.Background(), uint(1), uint(1))
 
 		assert.NoError(t, err)
 		assert.NotEmpty(t, res)
 	})
 
 	t.Run("user-not-found", func(t *testing.T) {
-		mockUserRepo.On("GetWithDetail", uint(1), uint(1)).Return(userEntity.User{}, nil).Once()
+		mockUserRepo.On("GetWithDetail", mock.Anything, uint(1), uint(1)).Return(userEntity.User{}, nil).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
-		res, err := testUserUsecase.Get(uint(1), uint(1))
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+		res, err := testUserUsecase.Get(context.Background(), uint(1), uint(1))
 
 		assert.Error(t, err)
 		assert.Empty(t, res)
 	})
 
 	t.Run("internal-server-error-1", func(t *testing.T) {
-		mockUserRepo.On("GetWithDetail", uint(1), uint(1)).Return(userEntity.User{}, utils.ErrInternalServerError).Once()
+		mockUserRepo.On("GetWithDetail", mock.Anything, uint(1), uint(1)).Return(userEntity.User{}, utils.ErrInternalServerError).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
-		res, err := testUserUsecase.Get(uint(1), uint(1))
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+		res, err := testUserUsecase.Get(context.Background(), uint(1), uint(1))
 
 		assert.Error(t, err)
 		assert.Empty(t, res)
 	})
 
 	t.Run("internal-server-error-2", func(t *testing.T) {
-		mockUserRepo.On("GetWithDetail", uint(1), uint(1)).Return(mockUserEntity1, nil).Once()
-		mockUserRepo.On("GetFollowingNumber", uint(1)).Return(0, utils.ErrInternalServerError).Once()
+		mockUserRepo.On("GetWithDetail", mock.Anything, uint(1), uint(1)).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("GetFollowingNumber", mock.Anything, uint(1)).Return(0, utils.ErrInternalServerError).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
-		res, err := testUserUsecase.Get(uint(1), uint(1))
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+		res, err := testUserUsecase.Get(context.Background(), uint(1), uint(1))
 
 		assert.Error(t, err)
 		assert.Empty(t, res)
 	})
 
 	t.Run("internal-server-error-3", func(t *testing.T) {
-		mockUserRepo.On("GetWithDetail", uint(1), uint(1)).Return(mockUserEntity1, nil).Once()
-		mockUserRepo.On("GetFollowingNumber", uint(1)).Return(10, nil).Once()
-		mockUserRepo.On("GetFollowerNumber", uint(1)).Return(10, utils.ErrInternalServerError).Once()
+		mockUserRepo.On("GetWithDetail", mock.Anything, uint(1), uint(1)).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("GetFollowingNumber", mock.Anything, uint(1)).Return(10, nil).Once()
+		mockUserRepo.On("GetFollowerNumber", mock.Anything, uint(1)).Return(10, utils.ErrInternalServerError).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
-		res, err := testUserUsecase.Get(uint(1), uint(1))
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+		res, err := testUserUsecase.Get(context.Background(), uint(1), uint(1))
 
 		assert.Error(t, err)
 		assert.Empty(t, res)
 	})
 
 	t.Run("internal-server-error-4", func(t *testing.T) {
-		mockUserRepo.On("GetWithDetail", uint(1), uint(1)).Return(mockUserEntity1, nil).Once()
-		mockUserRepo.On("GetFollowingNumber", uint(1)).Return(10, nil).Once()
-		mockUserRepo.On("GetFollowerNumber", uint(1)).Return(10, nil).Once()
-		mockUserRepo.On("GetThreadsNumber", uint(1)).Return(10, utils.ErrInternalServerError).Once()
+		mockUserRepo.On("GetWithDetail", mock.Anything, uint(1), uint(1)).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("GetFollowingNumber", mock.Anything, uint(1)).Return(10, nil).Once()
+		mockUserRepo.On("GetFollowerNumber", mock.Anything, uint(1)).Return(10, nil).Once()
+		mockUserRepo.On("GetThreadsNumber", mock.Anything, uint(1)).Return(10, utils.ErrInternalServerError).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
-		res, err := testUserUsecase.Get(uint(1), uint(1))
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+		res, err := testUserUsecase.Get(context.Background(), uint(1), uint(1))
 
 		assert.Error(t, err)
 		assert.Empty(t, res)
@@ -342,46 +348,46 @@ func TestUpdate(t *testing.T) {
 	}
 
 	t.Run("success", func(t *testing.T) {
-		mockUserRepo.On("Get", uint(1)).Return(mockUserEntity1, nil).Once()
-		mockUserRepo.On("Update", &mockUserEntity1, mockUserEntityUpdate).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("Update", mock.Anything, &mockUserEntity1, mockUserEntityUpdate).Return(mockUserEntity1, nil).Once()
 
-		testUseUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
+		testUseUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
 
-		res, err := testUseUsecase.Update(mockUserUpdateDTO, uint(1))
+		res, err := testUseUsecase.Update(context.Background(), mockUserUpdateDTO, uint(1))
 
 		assert.NoError(t, err)
 		assert.NotEmpty(t, res)
 	})
 
 	t.Run("user-not-found", func(t *testing.T) {
-		mockUserRepo.On("Get", uint(1)).Return(userEntity.User{}, nil).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(userEntity.User{}, nil).Once()
 
-		testUseUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
+		testUseUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
 
-		res, err := testUseUsecase.Update(mockUserUpdateDTO, uint(1))
+		res, err := testUseUsecase.Update(context.Background(), mockUserUpdateDTO, uint(1))
 
 		assert.Error(t, err)
 		assert.Empty(t, res)
 	})
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		mockUserRepo.On("Get", uint(1)).Return(userEntity.User{}, utils.ErrInternalServerError).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(userEntity.User{}, utils.ErrInternalServerError).Once()
 
-		testUseUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
+		testUseUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
 
-		res, err := testUseUsecase.Update(mockUserUpdateDTO, uint(1))
+		res, err := testUseUsecase.Update(context.Background(), mockUserUpdateDTO, uint(1))
 
 		assert.Error(t, err)
 		assert.Empty(t, res)
 	})
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		mockUserRepo.On("Get", uint(1)).Return(mockUserEntity1, nil).Once()
-		mockUserRepo.On("Update", &mockUserEntity1, mockUserEntityUpdate).Return(userEntity.User{}, utils.ErrInternalServerError).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("Update", mock.Anything, &mockUserEntity1, mockUserEntityUpdate).Return(userEntity.User{}, utils.ErrInternalServerError).Once()
 
-		testUseUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
+		testUseUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
 
-		res, err := testUseUsecase.Update(mockUserUpdateDTO, uint(1))
+		res, err := testUseUsecase.Update(context.Background(), mockUserUpdateDTO, uint(1))
 
 		assert.Error(t, err)
 		assert.Empty(t, res)
@@ -392,56 +398,198 @@ func TestDelete(t *testing.T) {
 	mockUserRepo := userMock.NewUserRepository(t)
 
 	t.Run("success", func(t *testing.T) {
-		mockUserRepo.On("Get", uint(1)).Return(mockUserEntity1, nil).Once()
-		mockUserRepo.On("Delete", uint(1)).Return(nil).Once()
+		capture := mailer.NewCapture()
+		outbox := mailer.NewSyncOutbox(mailer.MustLoadTemplates(), capture)
+		mockJobQueue := jobMock.NewQueue(t)
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("SetDeletionSchedule", mock.Anything, uint(1), mock.AnythingOfType("*time.Time")).Return(nil).Once()
+		mockJobQueue.On("EnqueueAt", mock.Anything, "user.purge", mock.Anything, mock.AnythingOfType("time.Time")).Return(nil).Once()
 
-		err := testUserUsecase.Delete(uint(1), uint(1), "Admin")
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, outbox, mockJobQueue, nil, nil, "", nil)
+
+		res, err := testUserUsecase.Delete(context.Background(), uint(1), uint(1), "User")
 
 		assert.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(DELETION_GRACE_PERIOD), res.ScheduledAt, time.Minute)
+
+		msg, ok := capture.Last()
+		assert.True(t, ok)
+		assert.Equal(t, mockUserEntity1.Email, msg.To)
+		assert.Contains(t, msg.Body, res.ScheduledAt.Format("2 January 2006"))
+	})
+
+	t.Run("already-scheduled", func(t *testing.T) {
+		scheduledAt := time.Now().Add(24 * time.Hour)
+		scheduledUser := mockUserEntity1
+		scheduledUser.DeletionScheduledAt = &scheduledAt
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(scheduledUser, nil).Once()
+
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+
+		res, err := testUserUsecase.Delete(context.Background(), uint(1), uint(1), "User")
+
+		assert.NoError(t, err)
+		assert.Equal(t, scheduledAt, res.ScheduledAt)
 	})
 
 	t.Run("user-not-found", func(t *testing.T) {
-		mockUserRepo.On("Get", uint(1)).Return(userEntity.User{}, nil).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(userEntity.User{}, nil).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
 
-		err := testUserUsecase.Delete(uint(1), uint(1), "Admin")
+		_, err := testUserUsecase.Delete(context.Background(), uint(1), uint(1), "Admin")
 
-		assert.Error(t, err)
+		assert.ErrorIs(t, err, utils.ErrNotFound)
 	})
 
 	t.Run("unautorize", func(t *testing.T) {
-		mockUserRepo.On("Get", uint(1)).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(mockUserEntity1, nil).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
 
-		err := testUserUsecase.Delete(uint(1), uint(2), "User")
+		_, err := testUserUsecase.Delete(context.Background(), uint(1), uint(2), "User")
 
-		assert.Error(t, err)
+		assert.ErrorIs(t, err, utils.ErrUnauthorizedAccess)
 	})
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		mockUserRepo.On("Get", uint(1)).Return(mockUserEntity1, nil).Once()
-		mockUserRepo.On("Delete", uint(1)).Return(utils.ErrInternalServerError).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("SetDeletionSchedule", mock.Anything, uint(1), mock.Anything).Return(utils.ErrInternalServerError).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
 
-		err := testUserUsecase.Delete(uint(1), uint(1), "Admin")
+		_, err := testUserUsecase.Delete(context.Background(), uint(1), uint(1), "Admin")
 
 		assert.Error(t, err)
 	})
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		mockUserRepo.On("Get", uint(1)).Return(userEntity.User{}, utils.ErrInternalServerError).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(userEntity.User{}, utils.ErrInternalServerError).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
 
-		err := testUserUsecase.Delete(uint(1), uint(1), "Admin")
+		_, err := testUserUsecase.Delete(context.Background(), uint(1), uint(1), "Admin")
 
 		assert.Error(t, err)
 	})
+
+	t.Run("enqueue-failed", func(t *testing.T) {
+		mockJobQueue := jobMock.NewQueue(t)
+
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("SetDeletionSchedule", mock.Anything, uint(1), mock.Anything).Return(nil).Once()
+		mockJobQueue.On("EnqueueAt", mock.Anything, "user.purge", mock.Anything, mock.Anything).Return(errors.New("queue down")).Once()
+
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, mockJobQueue, nil, nil, "", nil)
+
+		_, err := testUserUsecase.Delete(context.Background(), uint(1), uint(1), "User")
+
+		assert.ErrorIs(t, err, utils.ErrInternalServerError)
+	})
+}
+
+func TestCancelDeletion(t *testing.T) {
+	mockUserRepo := userMock.NewUserRepository(t)
+	scheduledAt := time.Now().Add(24 * time.Hour)
+	scheduledUser := mockUserEntity1
+	scheduledUser.DeletionScheduledAt = &scheduledAt
+
+	t.Run("success", func(t *testing.T) {
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(scheduledUser, nil).Once()
+		mockUserRepo.On("SetDeletionSchedule", mock.Anything, uint(1), (*time.Time)(nil)).Return(nil).Once()
+
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+
+		err := testUserUsecase.CancelDeletion(context.Background(), uint(1))
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("not-scheduled", func(t *testing.T) {
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(mockUserEntity1, nil).Once()
+
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+
+		err := testUserUsecase.CancelDeletion(context.Background(), uint(1))
+
+		assert.ErrorIs(t, err, utils.ErrBadParamInput)
+	})
+
+	t.Run("internal-server-error", func(t *testing.T) {
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(userEntity.User{}, utils.ErrInternalServerError).Once()
+
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+
+		err := testUserUsecase.CancelDeletion(context.Background(), uint(1))
+
+		assert.ErrorIs(t, err, utils.ErrInternalServerError)
+	})
+}
+
+func TestPurgeUser(t *testing.T) {
+	mockUserRepo := userMock.NewUserRepository(t)
+	dueAt := time.Now().Add(-time.Minute)
+	dueUser := mockUserEntity1
+	dueUser.DeletionScheduledAt = &dueAt
+
+	isPlaceholder := mock.MatchedBy(func(u userEntity.User) bool {
+		return u.Name == DELETED_USER &&
+			u.Username == DELETED_USER+"#1" &&
+			u.Email == "deleted-1@deleted.invalid" &&
+			u.ProfileImageUrl == userEntity.DEFAULT_PROFILE &&
+			u.BackgroundImageUrl == userEntity.DEFAULT_BACKGROUND
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockJobQueue := jobMock.NewQueue(t)
+
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(dueUser, nil).Once()
+		mockUserRepo.On("AnonymizeUser", mock.Anything, uint(1), isPlaceholder).Return([]string{"exports/1/archive.zip"}, nil).Once()
+		mockJobQueue.On("Enqueue", mock.Anything, "storage.delete_image", mock.Anything).Return(nil).Times(3)
+
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, mockJobQueue, nil, nil, "", nil)
+
+		err := testUserUsecase.PurgeUser(context.Background(), uint(1))
+
+		assert.NoError(t, err)
+		mockJobQueue.AssertCalled(t, "Enqueue", mock.Anything, "storage.delete_image", cloudstorage.DeleteImagePayload{FileName: "dummy", DirName: "profile"})
+		mockJobQueue.AssertCalled(t, "Enqueue", mock.Anything, "storage.delete_image", cloudstorage.DeleteImagePayload{FileName: "archive.zip", DirName: "exports/1"})
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(mockUserEntity1, nil).Once()
+
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+
+		err := testUserUsecase.PurgeUser(context.Background(), uint(1))
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("rescheduled", func(t *testing.T) {
+		laterAt := time.Now().Add(24 * time.Hour)
+		laterUser := mockUserEntity1
+		laterUser.DeletionScheduledAt = &laterAt
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(laterUser, nil).Once()
+
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+
+		err := testUserUsecase.PurgeUser(context.Background(), uint(1))
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("internal-server-error", func(t *testing.T) {
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(dueUser, nil).Once()
+		mockUserRepo.On("AnonymizeUser", mock.Anything, uint(1), isPlaceholder).Return(nil, utils.ErrInternalServerError).Once()
+
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+
+		err := testUserUsecase.PurgeUser(context.Background(), uint(1))
+
+		assert.ErrorIs(t, err, utils.ErrInternalServerError)
+	})
 }
 
 func TestChangeEmail(t *testing.T) {
@@ -462,92 +610,316 @@ func TestChangeEmail(t *testing.T) {
 		Password: "1234567",
 	}
 
-	mockEntityReq := userEntity.User{
-		Email: "dummyupdate@gmail.com",
-	}
+	isPendingChange := mock.MatchedBy(func(change userEntity.EmailChange) bool {
+		return change.UserID == mockUserEntity1.ID &&
+			change.OldEmail == mockUserEntity1.Email &&
+			change.NewEmail == mockInfoDTOReqSuccess.Email &&
+			change.ConfirmTokenHash != "" &&
+			change.RevertTokenHash != "" &&
+			change.ConfirmTokenHash != change.RevertTokenHash
+	})
 
 	t.Run("success", func(t *testing.T) {
-		mockUserRepo.On("Get", uint(1)).Return(mockUserEntity1, nil).Once()
-		mockUserRepo.On("GetByEmail", mockInfoDTOReqSuccess.Email).Return(userEntity.User{}, nil).Once()
-		mockUserRepo.On("Update", &mockUserEntity1, mockEntityReq).Return(mockUserEntity1, nil).Once()
+		capture := mailer.NewCapture()
+		outbox := mailer.NewSyncOutbox(mailer.MustLoadTemplates(), capture)
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil)
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("GetByEmail", mock.Anything, mockInfoDTOReqSuccess.Email).Return(userEntity.User{}, nil).Once()
+		mockUserRepo.On("StoreEmailChange", mock.Anything, isPendingChange).Return(nil).Once()
 
-		res, err := testUserUsecase.ChangeEmail(uint(1), mockInfoDTOReqSuccess)
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, outbox, nil, nil, nil, "https://macaiki.test", nil)
+
+		res, err := testUserUsecase.ChangeEmail(context.Background(), uint(1), mockInfoDTOReqSuccess)
 
 		assert.NoError(t, err)
-		assert.NotEmpty(t, res)
+		assert.Equal(t, mockInfoDTOReqSuccess.Email, res.PendingEmail)
+
+		messages := capture.Messages()
+		if assert.Len(t, messages, 2) {
+			assert.Equal(t, mockInfoDTOReqSuccess.Email, messages[0].To)
+			assert.Contains(t, messages[0].Body, "https://macaiki.test/email-change/confirm?token=")
+			assert.Equal(t, mockUserEntity1.Email, messages[1].To)
+			assert.Contains(t, messages[1].Body, "https://macaiki.test/email-change/revert?token=")
+		}
 	})
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		mockUserRepo.On("Get", uint(1)).Return(userEntity.User{}, utils.ErrInternalServerError).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(userEntity.User{}, utils.ErrInternalServerError).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil)
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil, nil, nil, nil, "", nil)
 
-		res, err := testUserUsecase.ChangeEmail(uint(1), mockInfoDTOReqSuccess)
+		res, err := testUserUsecase.ChangeEmail(context.Background(), uint(1), mockInfoDTOReqSuccess)
 
 		assert.Error(t, err)
 		assert.Empty(t, res)
 	})
 
-	t.Run("success", func(t *testing.T) {
-		mockUserRepo.On("Get", uint(1)).Return(mockUserEntity1, nil).Once()
-		mockUserRepo.On("GetByEmail", mockInfoDTOReqSuccess.Email).Return(userEntity.User{}, utils.ErrInternalServerError).Once()
+	t.Run("get-by-email-failed", func(t *testing.T) {
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("GetByEmail", mock.Anything, mockInfoDTOReqSuccess.Email).Return(userEntity.User{}, utils.ErrInternalServerError).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil)
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil, nil, nil, nil, "", nil)
 
-		res, err := testUserUsecase.ChangeEmail(uint(1), mockInfoDTOReqSuccess)
+		res, err := testUserUsecase.ChangeEmail(context.Background(), uint(1), mockInfoDTOReqSuccess)
 
 		assert.Error(t, err)
 		assert.Empty(t, res)
 	})
 
-	t.Run("success", func(t *testing.T) {
-		mockUserRepo.On("Get", uint(1)).Return(mockUserEntity1, nil).Once()
-		mockUserRepo.On("GetByEmail", mockInfoDTOReqSuccess.Email).Return(userEntity.User{}, nil).Once()
-		mockUserRepo.On("Update", &mockUserEntity1, mockEntityReq).Return(userEntity.User{}, utils.ErrInternalServerError).Once()
+	t.Run("store-failed", func(t *testing.T) {
+		capture := mailer.NewCapture()
+		outbox := mailer.NewSyncOutbox(mailer.MustLoadTemplates(), capture)
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil)
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("GetByEmail", mock.Anything, mockInfoDTOReqSuccess.Email).Return(userEntity.User{}, nil).Once()
+		mockUserRepo.On("StoreEmailChange", mock.Anything, isPendingChange).Return(utils.ErrInternalServerError).Once()
 
-		res, err := testUserUsecase.ChangeEmail(uint(1), mockInfoDTOReqSuccess)
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, outbox, nil, nil, nil, "", nil)
 
-		assert.Error(t, err)
+		res, err := testUserUsecase.ChangeEmail(context.Background(), uint(1), mockInfoDTOReqSuccess)
+
+		assert.Equal(t, utils.ErrInternalServerError, err)
 		assert.Empty(t, res)
+		assert.Empty(t, capture.Messages())
 	})
+
 	t.Run("bad-param-input", func(t *testing.T) {
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil)
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil, nil, nil, nil, "", nil)
 
-		res, err := testUserUsecase.ChangeEmail(uint(1), mockInfoDTOReqSuccessFail1)
+		res, err := testUserUsecase.ChangeEmail(context.Background(), uint(1), mockInfoDTOReqSuccessFail1)
 
 		assert.Error(t, err)
 		assert.Empty(t, res)
 	})
 
+	t.Run("same-email", func(t *testing.T) {
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(mockUserEntity1, nil).Once()
+
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil, nil, nil, nil, "", nil)
+
+		res, err := testUserUsecase.ChangeEmail(context.Background(), uint(1), userDTO.UserLoginRequest{
+			Email:    mockUserEntity1.Email,
+			Password: "123456",
+		})
+
+		assert.Equal(t, utils.ErrBadParamInput, err)
+		assert.Empty(t, res)
+	})
+
 	t.Run("email-already-used", func(t *testing.T) {
-		mockUserRepo.On("Get", uint(1)).Return(mockUserEntity1, nil).Once()
-		mockUserRepo.On("GetByEmail", mockInfoDTOReqSuccess.Email).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("GetByEmail", mock.Anything, mockInfoDTOReqSuccess.Email).Return(mockUserEntity1, nil).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil)
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil, nil, nil, nil, "", nil)
 
-		res, err := testUserUsecase.ChangeEmail(uint(1), mockInfoDTOReqSuccess)
+		res, err := testUserUsecase.ChangeEmail(context.Background(), uint(1), mockInfoDTOReqSuccess)
 
 		assert.Error(t, err)
 		assert.Empty(t, res)
 	})
 
 	t.Run("forbidden", func(t *testing.T) {
-		mockUserRepo.On("Get", uint(1)).Return(mockUserEntity1, nil).Once()
-		mockUserRepo.On("GetByEmail", mockInfoDTOReqSuccess.Email).Return(userEntity.User{}, nil).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("GetByEmail", mock.Anything, mockInfoDTOReqSuccess.Email).Return(userEntity.User{}, nil).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil)
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil, nil, nil, nil, "", nil)
 
-		res, err := testUserUsecase.ChangeEmail(uint(1), mockInfoDTOReqSuccessFail2)
+		res, err := testUserUsecase.ChangeEmail(context.Background(), uint(1), mockInfoDTOReqSuccessFail2)
 
 		assert.Error(t, err)
 		assert.Empty(t, res)
 	})
 }
 
+func TestConfirmEmailChange(t *testing.T) {
+	mockUserRepo := userMock.NewUserRepository(t)
+
+	req := userDTO.EmailChangeTokenRequest{Token: "confirm-token"}
+	confirmedAt := time.Now()
+	mockChange := userEntity.EmailChange{
+		Model:            gorm.Model{ID: 1},
+		UserID:           mockUserEntity1.ID,
+		OldEmail:         mockUserEntity1.Email,
+		NewEmail:         "dummyupdate@gmail.com",
+		ConfirmTokenHash: utils.HashToken(req.Token),
+		ExpiredAt:        time.Now().Add(time.Hour),
+		RevertExpiredAt:  time.Now().Add(time.Hour),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockUserRepo.On("GetEmailChangeByConfirmToken", mock.Anything, utils.HashToken(req.Token)).Return(mockChange, nil).Once()
+		mockUserRepo.On("GetByEmail", mock.Anything, mockChange.NewEmail).Return(userEntity.User{}, nil).Once()
+		mockUserRepo.On("ApplyEmailChange", mock.Anything, mockChange).Return(nil).Once()
+
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.ConfirmEmailChange(context.Background(), req)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("bad-param-input", func(t *testing.T) {
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.ConfirmEmailChange(context.Background(), userDTO.EmailChangeTokenRequest{})
+
+		assert.Equal(t, utils.ErrBadParamInput, err)
+	})
+
+	t.Run("unknown-token", func(t *testing.T) {
+		mockUserRepo.On("GetEmailChangeByConfirmToken", mock.Anything, utils.HashToken(req.Token)).Return(userEntity.EmailChange{}, utils.ErrNotFound).Once()
+
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.ConfirmEmailChange(context.Background(), req)
+
+		assert.Equal(t, utils.ErrInvalidToken, err)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		expiredChange := mockChange
+		expiredChange.ExpiredAt = time.Now().Add(-time.Second)
+		mockUserRepo.On("GetEmailChangeByConfirmToken", mock.Anything, utils.HashToken(req.Token)).Return(expiredChange, nil).Once()
+
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.ConfirmEmailChange(context.Background(), req)
+
+		assert.Equal(t, utils.ErrInvalidToken, err)
+	})
+
+	t.Run("already-confirmed", func(t *testing.T) {
+		usedChange := mockChange
+		usedChange.ConfirmedAt = &confirmedAt
+		mockUserRepo.On("GetEmailChangeByConfirmToken", mock.Anything, utils.HashToken(req.Token)).Return(usedChange, nil).Once()
+
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.ConfirmEmailChange(context.Background(), req)
+
+		assert.Equal(t, utils.ErrInvalidToken, err)
+	})
+
+	t.Run("reverted", func(t *testing.T) {
+		revertedChange := mockChange
+		revertedChange.RevertedAt = &confirmedAt
+		mockUserRepo.On("GetEmailChangeByConfirmToken", mock.Anything, utils.HashToken(req.Token)).Return(revertedChange, nil).Once()
+
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.ConfirmEmailChange(context.Background(), req)
+
+		assert.Equal(t, utils.ErrInvalidToken, err)
+	})
+
+	t.Run("email-taken-meanwhile", func(t *testing.T) {
+		mockUserRepo.On("GetEmailChangeByConfirmToken", mock.Anything, utils.HashToken(req.Token)).Return(mockChange, nil).Once()
+		mockUserRepo.On("GetByEmail", mock.Anything, mockChange.NewEmail).Return(mockUserEntity2, nil).Once()
+
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.ConfirmEmailChange(context.Background(), req)
+
+		assert.Equal(t, utils.ErrEmailAlreadyUsed, err)
+	})
+
+	t.Run("apply-conflict", func(t *testing.T) {
+		mockUserRepo.On("GetEmailChangeByConfirmToken", mock.Anything, utils.HashToken(req.Token)).Return(mockChange, nil).Once()
+		mockUserRepo.On("GetByEmail", mock.Anything, mockChange.NewEmail).Return(userEntity.User{}, nil).Once()
+		mockUserRepo.On("ApplyEmailChange", mock.Anything, mockChange).Return(utils.ErrConflict).Once()
+
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.ConfirmEmailChange(context.Background(), req)
+
+		assert.Equal(t, utils.ErrInvalidToken, err)
+	})
+
+	t.Run("internal-server-error", func(t *testing.T) {
+		mockUserRepo.On("GetEmailChangeByConfirmToken", mock.Anything, utils.HashToken(req.Token)).Return(mockChange, nil).Once()
+		mockUserRepo.On("GetByEmail", mock.Anything, mockChange.NewEmail).Return(userEntity.User{}, nil).Once()
+		mockUserRepo.On("ApplyEmailChange", mock.Anything, mockChange).Return(errors.New("db down")).Once()
+
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.ConfirmEmailChange(context.Background(), req)
+
+		assert.Equal(t, utils.ErrInternalServerError, err)
+	})
+}
+
+func TestRevertEmailChange(t *testing.T) {
+	mockUserRepo := userMock.NewUserRepository(t)
+
+	req := userDTO.EmailChangeTokenRequest{Token: "revert-token"}
+	now := time.Now()
+	mockChange := userEntity.EmailChange{
+		Model:           gorm.Model{ID: 1},
+		UserID:          mockUserEntity1.ID,
+		OldEmail:        mockUserEntity1.Email,
+		NewEmail:        "dummyupdate@gmail.com",
+		RevertTokenHash: utils.HashToken(req.Token),
+		ExpiredAt:       time.Now().Add(-time.Hour),
+		RevertExpiredAt: time.Now().Add(time.Hour),
+	}
+
+	t.Run("cancel-pending", func(t *testing.T) {
+		mockUserRepo.On("GetEmailChangeByRevertToken", mock.Anything, utils.HashToken(req.Token)).Return(mockChange, nil).Once()
+		mockUserRepo.On("RevertEmailChange", mock.Anything, mockChange).Return(nil).Once()
+
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.RevertEmailChange(context.Background(), req)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("revert-confirmed", func(t *testing.T) {
+		confirmedChange := mockChange
+		confirmedChange.ConfirmedAt = &now
+		mockUserRepo.On("GetEmailChangeByRevertToken", mock.Anything, utils.HashToken(req.Token)).Return(confirmedChange, nil).Once()
+		mockUserRepo.On("RevertEmailChange", mock.Anything, confirmedChange).Return(nil).Once()
+
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.RevertEmailChange(context.Background(), req)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("unknown-token", func(t *testing.T) {
+		mockUserRepo.On("GetEmailChangeByRevertToken", mock.Anything, utils.HashToken(req.Token)).Return(userEntity.EmailChange{}, utils.ErrNotFound).Once()
+
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.RevertEmailChange(context.Background(), req)
+
+		assert.Equal(t, utils.ErrInvalidToken, err)
+	})
+
+	t.Run("already-reverted", func(t *testing.T) {
+		revertedChange := mockChange
+		revertedChange.RevertedAt = &now
+		mockUserRepo.On("GetEmailChangeByRevertToken", mock.Anything, utils.HashToken(req.Token)).Return(revertedChange, nil).Once()
+
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.RevertEmailChange(context.Background(), req)
+
+		assert.Equal(t, utils.ErrInvalidToken, err)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		expiredChange := mockChange
+		expiredChange.RevertExpiredAt = time.Now().Add(-time.Second)
+		mockUserRepo.On("GetEmailChangeByRevertToken", mock.Anything, utils.HashToken(req.Token)).Return(expiredChange, nil).Once()
+
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.RevertEmailChange(context.Background(), req)
+
+		assert.Equal(t, utils.ErrInvalidToken, err)
+	})
+
+	t.Run("old-email-taken", func(t *testing.T) {
+		confirmedChange := mockChange
+		confirmedChange.ConfirmedAt = &now
+		mockUserRepo.On("GetEmailChangeByRevertToken", mock.Anything, utils.HashToken(req.Token)).Return(confirmedChange, nil).Once()
+		mockUserRepo.On("RevertEmailChange", mock.Anything, confirmedChange).Return(utils.ErrEmailAlreadyUsed).Once()
+
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.RevertEmailChange(context.Background(), req)
+
+		assert.Equal(t, utils.ErrEmailAlreadyUsed, err)
+	})
+}
+
 func TestChangePassword(t *testing.T) {
 	mockUserRepo := userMock.NewUserRepository(t)
 
@@ -567,49 +939,49 @@ func TestChangePassword(t *testing.T) {
 	}
 
 	t.Run("success", func(t *testing.T) {
-		mockUserRepo.On("Get", mock.Anything).Return(mockUserEntity1, nil).Once()
-		mockUserRepo.On("Update", mock.Anything, mock.Anything).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("Get", mock.Anything, mock.Anything).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("Update", mock.Anything, mock.Anything, mock.Anything).Return(mockUserEntity1, nil).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil)
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil, nil, nil, nil, "", nil)
 
-		err := testUserUsecase.ChangePassword(uint(1), mockPasswordInfoDTOReqSuccess)
+		err := testUserUsecase.ChangePassword(context.Background(), uint(1), mockPasswordInfoDTOReqSuccess)
 
 		assert.NoError(t, err)
 	})
 
 	t.Run("bad-param-input", func(t *testing.T) {
-		testUserUsecase := NewUserUsecase(nil, nil, nil, nil, nil, v, nil, nil)
+		testUserUsecase := NewUserUsecase(nil, nil, nil, nil, nil, v, nil, nil, nil, nil, nil, "", nil)
 
-		err := testUserUsecase.ChangePassword(uint(1), mockPasswordInfoDTOReqFail2)
+		err := testUserUsecase.ChangePassword(context.Background(), uint(1), mockPasswordInfoDTOReqFail2)
 
 		assert.Error(t, err)
 	})
 
 	t.Run("password-dont-match", func(t *testing.T) {
-		testUserUsecase := NewUserUsecase(nil, nil, nil, nil, nil, v, nil, nil)
+		testUserUsecase := NewUserUsecase(nil, nil, nil, nil, nil, v, nil, nil, nil, nil, nil, "", nil)
 
-		err := testUserUsecase.ChangePassword(uint(1), mockPasswordInfoDTOReqFail1)
+		err := testUserUsecase.ChangePassword(context.Background(), uint(1), mockPasswordInfoDTOReqFail1)
 
 		assert.Error(t, err)
 	})
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		mockUserRepo.On("Get", mock.Anything).Return(userEntity.User{}, utils.ErrInternalServerError).Once()
+		mockUserRepo.On("Get", mock.Anything, mock.Anything).Return(userEntity.User{}, utils.ErrInternalServerError).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil)
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil, nil, nil, nil, "", nil)
 
-		err := testUserUsecase.ChangePassword(uint(1), mockPasswordInfoDTOReqSuccess)
+		err := testUserUsecase.ChangePassword(context.Background(), uint(1), mockPasswordInfoDTOReqSuccess)
 
 		assert.Error(t, err)
 	})
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		mockUserRepo.On("Get", mock.Anything).Return(mockUserEntity1, nil).Once()
-		mockUserRepo.On("Update", mock.Anything, mock.Anything).Return(userEntity.User{}, utils.ErrInternalServerError).Once()
+		mockUserRepo.On("Get", mock.Anything, mock.Anything).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("Update", mock.Anything, mock.Anything, mock.Anything).Return(userEntity.User{}, utils.ErrInternalServerError).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil)
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil, nil, nil, nil, "", nil)
 
-		err := testUserUsecase.ChangePassword(uint(1), mockPasswordInfoDTOReqSuccess)
+		err := testUserUsecase.ChangePassword(context.Background(), uint(1), mockPasswordInfoDTOReqSuccess)
 
 		assert.Error(t, err)
 	})
@@ -619,43 +991,43 @@ func TestGetUserFollowers(t *testing.T) {
 	mockUserRepo := userMock.NewUserRepository(t)
 
 	t.Run("success", func(t *testing.T) {
-		mockUserRepo.On("Get", uint(1)).Return(mockUserEntity1, nil).Once()
-		mockUserRepo.On("GetFollower", uint(1), uint(1)).Return(mockedUserArr, nil).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("GetFollower", mock.Anything, uint(1), uint(1)).Return(mockedUserArr, nil).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
 
-		_, err := testUserUsecase.GetUserFollowers(uint(1), uint(1))
+		_, err := testUserUsecase.GetUserFollowers(context.Background(), uint(1), uint(1))
 
 		assert.NoError(t, err)
 	})
 
 	t.Run("user-not-found", func(t *testing.T) {
-		mockUserRepo.On("Get", uint(1)).Return(userEntity.User{}, nil).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(userEntity.User{}, nil).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
 
-		_, err := testUserUsecase.GetUserFollowers(uint(1), uint(1))
+		_, err := testUserUsecase.GetUserFollowers(context.Background(), uint(1), uint(1))
 
 		assert.Error(t, err)
 	})
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		mockUserRepo.On("Get", uint(1)).Return(userEntity.User{}, utils.ErrInternalServerError).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(userEntity.User{}, utils.ErrInternalServerError).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
 
-		_, err := testUserUsecase.GetUserFollowers(uint(1), uint(1))
+		_, err := testUserUsecase.GetUserFollowers(context.Background(), uint(1), uint(1))
 
 		assert.Error(t, err)
 	})
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		mockUserRepo.On("Get", uint(1)).Return(mockUserEntity1, nil).Once()
-		mockUserRepo.On("GetFollower", uint(1), uint(1)).Return([]userEntity.User{}, utils.ErrInternalServerError).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("GetFollower", mock.Anything, uint(1), uint(1)).Return([]userEntity.User{}, utils.ErrInternalServerError).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
 
-		_, err := testUserUsecase.GetUserFollowers(uint(1), uint(1))
+		_, err := testUserUsecase.GetUserFollowers(context.Background(), uint(1), uint(1))
 
 		assert.Error(t, err)
 	})
@@ -665,43 +1037,43 @@ func TestGetUserFollowing(t *testing.T) {
 	mockUserRepo := userMock.NewUserRepository(t)
 
 	t.Run("success", func(t *testing.T) {
-		mockUserRepo.On("Get", uint(1)).Return(mockUserEntity1, nil).Once()
-		mockUserRepo.On("GetFollowing", uint(1), uint(1)).Return(mockedUserArr, nil).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("GetFollowing", mock.Anything, uint(1), uint(1)).Return(mockedUserArr, nil).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
 
-		_, err := testUserUsecase.GetUserFollowing(uint(1), uint(1))
+		_, err := testUserUsecase.GetUserFollowing(context.Background(), uint(1), uint(1))
 
 		assert.NoError(t, err)
 	})
 
 	t.Run("user-not-found", func(t *testing.T) {
-		mockUserRepo.On("Get", uint(1)).Return(userEntity.User{}, nil).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(userEntity.User{}, nil).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
 
-		_, err := testUserUsecase.GetUserFollowing(uint(1), uint(1))
+		_, err := testUserUsecase.GetUserFollowing(context.Background(), uint(1), uint(1))
 
 		assert.Error(t, err)
 	})
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		mockUserRepo.On("Get", uint(1)).Return(userEntity.User{}, utils.ErrInternalServerError).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(userEntity.User{}, utils.ErrInternalServerError).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
 
-		_, err := testUserUsecase.GetUserFollowing(uint(1), uint(1))
+		_, err := testUserUsecase.GetUserFollowing(context.Background(), uint(1), uint(1))
 
 		assert.Error(t, err)
 	})
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		mockUserRepo.On("Get", uint(1)).Return(mockUserEntity1, nil).Once()
-		mockUserRepo.On("GetFollowing", uint(1), uint(1)).Return([]userEntity.User{}, utils.ErrInternalServerError).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("GetFollowing", mock.Anything, uint(1), uint(1)).Return([]userEntity.User{}, utils.ErrInternalServerError).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
 
-		_, err := testUserUsecase.GetUserFollowing(uint(1), uint(1))
+		_, err := testUserUsecase.GetUserFollowing(context.Background(), uint(1), uint(1))
 
 		assert.Error(t, err)
 	})
@@ -719,7 +1091,7 @@ func TestSetBackgroundImage(t *testing.T) {}
 
 func TestFollow(t *testing.T) {
 	mockUserRepo := userMock.NewUserRepository(t)
-	mockNotifRepo := notifMock.NewNotificationRepository(t)
+	mockJobQueue := jobMock.NewQueue(t)
 
 	mockNotifEntity := notifEntity.Notification{
 		UserID:            1,
@@ -728,92 +1100,92 @@ func TestFollow(t *testing.T) {
 		IsReaded:          0,
 	}
 	t.Run("success", func(t *testing.T) {
-		mockUserRepo.On("Get", uint(1)).Return(mockUserEntity1, nil).Once()
-		mockUserRepo.On("Get", uint(2)).Return(mockUserEntity2, nil).Once()
-		mockUserRepo.On("Follow", mockUserEntity1, mockUserEntity2).Return(mockUserEntity1, nil).Once()
-		mockNotifRepo.On("StoreNotification", mockNotifEntity).Return(nil).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(2)).Return(mockUserEntity2, nil).Once()
+		mockUserRepo.On("Follow", mock.Anything, mockUserEntity1, mockUserEntity2).Return(mockUserEntity1, nil).Once()
+		mockJobQueue.On("Enqueue", mock.Anything, notification.JobStoreNotification, mockNotifEntity).Return(nil).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, mockNotifRepo, nil, nil, nil, nil)
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, mockJobQueue, nil, nil, "", nil)
 
-		err := testUserUsecase.Follow(uint(1), uint(2))
+		err := testUserUsecase.Follow(context.Background(), uint(1), uint(2))
 
 		assert.NoError(t, err)
 	})
 
 	t.Run("user-not-found", func(t *testing.T) {
-		mockUserRepo.On("Get", uint(1)).Return(userEntity.User{}, nil).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(userEntity.User{}, nil).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, mockNotifRepo, nil, nil, nil, nil)
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, mockJobQueue, nil, nil, "", nil)
 
-		err := testUserUsecase.Follow(uint(1), uint(2))
+		err := testUserUsecase.Follow(context.Background(), uint(1), uint(2))
 
 		assert.Error(t, err)
 	})
 
 	t.Run("user-not-found", func(t *testing.T) {
-		mockUserRepo.On("Get", uint(1)).Return(mockUserEntity1, nil).Once()
-		mockUserRepo.On("Get", uint(2)).Return(userEntity.User{}, nil).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(2)).Return(userEntity.User{}, nil).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, mockNotifRepo, nil, nil, nil, nil)
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, mockJobQueue, nil, nil, "", nil)
 
-		err := testUserUsecase.Follow(uint(1), uint(2))
+		err := testUserUsecase.Follow(context.Background(), uint(1), uint(2))
 
 		assert.Error(t, err)
 	})
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		mockUserRepo.On("Get", uint(1)).Return(userEntity.User{}, utils.ErrInternalServerError).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(userEntity.User{}, utils.ErrInternalServerError).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, mockNotifRepo, nil, nil, nil, nil)
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, mockJobQueue, nil, nil, "", nil)
 
-		err := testUserUsecase.Follow(uint(1), uint(2))
+		err := testUserUsecase.Follow(context.Background(), uint(1), uint(2))
 
 		assert.Error(t, err)
 	})
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		mockUserRepo.On("Get", uint(1)).Return(mockUserEntity1, nil).Once()
-		mockUserRepo.On("Get", uint(2)).Return(userEntity.User{}, utils.ErrInternalServerError).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(2)).Return(userEntity.User{}, utils.ErrInternalServerError).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, mockNotifRepo, nil, nil, nil, nil)
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, mockJobQueue, nil, nil, "", nil)
 
-		err := testUserUsecase.Follow(uint(1), uint(2))
+		err := testUserUsecase.Follow(context.Background(), uint(1), uint(2))
 
 		assert.Error(t, err)
 	})
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		mockUserRepo.On("Get", uint(1)).Return(mockUserEntity1, nil).Once()
-		mockUserRepo.On("Get", uint(2)).Return(mockUserEntity2, nil).Once()
-		mockUserRepo.On("Follow", mockUserEntity1, mockUserEntity2).Return(userEntity.User{}, utils.ErrInternalServerError).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(2)).Return(mockUserEntity2, nil).Once()
+		mockUserRepo.On("Follow", mock.Anything, mockUserEntity1, mockUserEntity2).Return(userEntity.User{}, utils.ErrInternalServerError).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, mockNotifRepo, nil, nil, nil, nil)
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, mockJobQueue, nil, nil, "", nil)
 
-		err := testUserUsecase.Follow(uint(1), uint(2))
+		err := testUserUsecase.Follow(context.Background(), uint(1), uint(2))
 
 		assert.Error(t, err)
 	})
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		mockUserRepo.On("Get", uint(1)).Return(mockUserEntity1, nil).Once()
-		mockUserRepo.On("Get", uint(2)).Return(mockUserEntity2, nil).Once()
-		mockUserRepo.On("Follow", mockUserEntity1, mockUserEntity2).Return(mockUserEntity1, nil).Once()
-		mockNotifRepo.On("StoreNotification", mockNotifEntity).Return(utils.ErrInternalServerError).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(2)).Return(mockUserEntity2, nil).Once()
+		mockUserRepo.On("Follow", mock.Anything, mockUserEntity1, mockUserEntity2).Return(mockUserEntity1, nil).Once()
+		mockJobQueue.On("Enqueue", mock.Anything, notification.JobStoreNotification, mockNotifEntity).Return(utils.ErrInternalServerError).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, mockNotifRepo, nil, nil, nil, nil)
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, mockJobQueue, nil, nil, "", nil)
 
-		err := testUserUsecase.Follow(uint(1), uint(2))
+		err := testUserUsecase.Follow(context.Background(), uint(1), uint(2))
 
 		assert.NoError(t, err)
 	})
 
 	t.Run("bad-param-input", func(t *testing.T) {
-		mockUserRepo.On("Get", uint(1)).Return(mockUserEntity1, nil).Once()
-		mockUserRepo.On("Get", uint(1)).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(mockUserEntity1, nil).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, mockNotifRepo, nil, nil, nil, nil)
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, mockJobQueue, nil, nil, "", nil)
 
-		err := testUserUsecase.Follow(uint(1), uint(1))
+		err := testUserUsecase.Follow(context.Background(), uint(1), uint(1))
 
 		assert.Error(t, err)
 	})
@@ -823,79 +1195,79 @@ func TestUnfollow(t *testing.T) {
 	mockUserRepo := userMock.NewUserRepository(t)
 
 	t.Run("success", func(t *testing.T) {
-		mockUserRepo.On("Get", uint(1)).Return(mockUserEntity1, nil).Once()
-		mockUserRepo.On("Get", uint(2)).Return(mockUserEntity2, nil).Once()
-		mockUserRepo.On("Unfollow", mockUserEntity1, mockUserEntity2).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(2)).Return(mockUserEntity2, nil).Once()
+		mockUserRepo.On("Unfollow", mock.Anything, mockUserEntity1, mockUserEntity2).Return(mockUserEntity1, nil).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
 
-		err := testUserUsecase.Unfollow(uint(1), uint(2))
+		err := testUserUsecase.Unfollow(context.Background(), uint(1), uint(2))
 
 		assert.NoError(t, err)
 	})
 
 	t.Run("user-not-found", func(t *testing.T) {
-		mockUserRepo.On("Get", uint(1)).Return(userEntity.User{}, nil).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(userEntity.User{}, nil).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
 
-		err := testUserUsecase.Unfollow(uint(1), uint(2))
+		err := testUserUsecase.Unfollow(context.Background(), uint(1), uint(2))
 
 		assert.Error(t, err)
 	})
 
 	t.Run("user-not-found", func(t *testing.T) {
-		mockUserRepo.On("Get", uint(1)).Return(mockUserEntity1, nil).Once()
-		mockUserRepo.On("Get", uint(2)).Return(userEntity.User{}, nil).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(2)).Return(userEntity.User{}, nil).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
 
-		err := testUserUsecase.Unfollow(uint(1), uint(2))
+		err := testUserUsecase.Unfollow(context.Background(), uint(1), uint(2))
 
 		assert.Error(t, err)
 	})
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		mockUserRepo.On("Get", uint(1)).Return(userEntity.User{}, utils.ErrInternalServerError).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(userEntity.User{}, utils.ErrInternalServerError).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
 
-		err := testUserUsecase.Unfollow(uint(1), uint(2))
+		err := testUserUsecase.Unfollow(context.Background(), uint(1), uint(2))
 
 		assert.Error(t, err)
 	})
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		mockUserRepo.On("Get", uint(1)).Return(mockUserEntity1, nil).Once()
-		mockUserRepo.On("Get", uint(2)).Return(userEntity.User{}, utils.ErrInternalServerError).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(2)).Return(userEntity.User{}, utils.ErrInternalServerError).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
 
-		err := testUserUsecase.Unfollow(uint(1), uint(2))
+		err := testUserUsecase.Unfollow(context.Background(), uint(1), uint(2))
 
 		assert.Error(t, err)
 	})
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		mockUserRepo.On("Get", uint(1)).Return(mockUserEntity1, nil).Once()
-		mockUserRepo.On("Get", uint(2)).Return(mockUserEntity2, nil).Once()
-		mockUserRepo.On("Unfollow", mockUserEntity1, mockUserEntity2).Return(userEntity.User{}, utils.ErrInternalServerError).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(2)).Return(mockUserEntity2, nil).Once()
+		mockUserRepo.On("Unfollow", mock.Anything, mockUserEntity1, mockUserEntity2).Return(userEntity.User{}, utils.ErrInternalServerError).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
 
-		err := testUserUsecase.Unfollow(uint(1), uint(2))
+		err := testUserUsecase.Unfollow(context.Background(), uint(1), uint(2))
 
 		assert.Error(t, err)
 	})
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		mockUserRepo.On("Get", uint(1)).Return(mockUserEntity1, nil).Once()
-		mockUserRepo.On("Get", uint(2)).Return(mockUserEntity2, nil).Once()
-		mockUserRepo.On("Unfollow", mockUserEntity1, mockUserEntity2).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(2)).Return(mockUserEntity2, nil).Once()
+		mockUserRepo.On("Unfollow", mock.Anything, mockUserEntity1, mockUserEntity2).Return(mockUserEntity1, nil).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
 
-		err := testUserUsecase.Unfollow(uint(1), uint(2))
+		err := testUserUsecase.Unfollow(context.Background(), uint(1), uint(2))
 
 		assert.NoError(t, err)
 	})
@@ -906,66 +1278,66 @@ func TestReport(t *testing.T) {
 	mockReportCategoryRepo := reportCategoryMock.NewReportCategoryRepository(t)
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		mockUserRepo.On("Get", uint(1)).Return(mockUserEntity1, nil).Once()
-		mockReportCategoryRepo.On("GetReportCategory", uint(1)).Return(mockRcEntity, utils.ErrInternalServerError).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(1)).Return(mockUserEntity1, nil).Once()
+		mockReportCategoryRepo.On("GetReportCategory", mock.Anything, uint(1)).Return(mockRcEntity, utils.ErrInternalServerError).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, mockReportCategoryRepo, nil, nil, nil, nil, nil, nil)
+		testUserUsecase := NewUserUsecase(mockUserRepo, mockReportCategoryRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
 
-		err := testUserUsecase.Report(uint(2), uint(1), uint(1))
+		err := testUserUsecase.Report(context.Background(), uint(2), uint(1), uint(1))
 
 		assert.Error(t, err)
 	})
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		mockUserRepo.On("Get", uint(2)).Return(userEntity.User{}, utils.ErrInternalServerError).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(2)).Return(userEntity.User{}, utils.ErrInternalServerError).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, mockReportCategoryRepo, nil, nil, nil, nil, nil, nil)
+		testUserUsecase := NewUserUsecase(mockUserRepo, mockReportCategoryRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
 
-		err := testUserUsecase.Report(uint(1), uint(2), uint(1))
+		err := testUserUsecase.Report(context.Background(), uint(1), uint(2), uint(1))
 
 		assert.Error(t, err)
 	})
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		mockUserRepo.On("Get", uint(2)).Return(mockUserEntity2, nil).Once()
-		mockReportCategoryRepo.On("GetReportCategory", uint(1)).Return(mockRcEntity, nil).Once()
-		mockUserRepo.On("StoreReport", mockUserReportEntity).Return(utils.ErrInternalServerError).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(2)).Return(mockUserEntity2, nil).Once()
+		mockReportCategoryRepo.On("GetReportCategory", mock.Anything, uint(1)).Return(mockRcEntity, nil).Once()
+		mockUserRepo.On("StoreReport", mock.Anything, mockUserReportEntity).Return(utils.ErrInternalServerError).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, mockReportCategoryRepo, nil, nil, nil, nil, nil, nil)
+		testUserUsecase := NewUserUsecase(mockUserRepo, mockReportCategoryRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
 
-		err := testUserUsecase.Report(uint(1), uint(2), uint(1))
+		err := testUserUsecase.Report(context.Background(), uint(1), uint(2), uint(1))
 
 		assert.Error(t, err)
 	})
 
 	t.Run("success", func(t *testing.T) {
-		mockUserRepo.On("Get", uint(2)).Return(mockUserEntity2, nil).Once()
-		mockReportCategoryRepo.On("GetReportCategory", uint(1)).Return(mockRcEntity, nil).Once()
-		mockUserRepo.On("StoreReport", mockUserReportEntity).Return(nil).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(2)).Return(mockUserEntity2, nil).Once()
+		mockReportCategoryRepo.On("GetReportCategory", mock.Anything, uint(1)).Return(mockRcEntity, nil).Once()
+		mockUserRepo.On("StoreReport", mock.Anything, mockUserReportEntity).Return(nil).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, mockReportCategoryRepo, nil, nil, nil, nil, nil, nil)
+		testUserUsecase := NewUserUsecase(mockUserRepo, mockReportCategoryRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
 
-		err := testUserUsecase.Report(uint(1), uint(2), uint(1))
+		err := testUserUsecase.Report(context.Background(), uint(1), uint(2), uint(1))
 
 		assert.NoError(t, err)
 	})
 
 	t.Run("user-not-found", func(t *testing.T) {
-		mockUserRepo.On("Get", uint(2)).Return(userEntity.User{}, nil).Once()
+		mockUserRepo.On("Get", mock.Anything, uint(2)).Return(userEntity.User{}, nil).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, mockReportCategoryRepo, nil, nil, nil, nil, nil, nil)
+		testUserUsecase := NewUserUsecase(mockUserRepo, mockReportCategoryRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
 
-		err := testUserUsecase.Report(uint(1), uint(2), uint(1))
+		err := testUserUsecase.Report(context.Background(), uint(1), uint(2), uint(1))
 
 		assert.Error(t, err)
 	})
 
 	t.Run("report-category-not-found", func(t *testing.T) {
-		mockUserRepo.On("Get", uint(2)).Return(mockUserEntity2, nil).Once()
-		mockReportCategoryRepo.On("GetReportCategory", uint(1)).Return(rcEntity.ReportCategory{}, nil)
-		testUserUsecase := NewUserUsecase(mockUserRepo, mockReportCategoryRepo, nil, nil, nil, nil, nil, nil)
+		mockUserRepo.On("Get", mock.Anything, uint(2)).Return(mockUserEntity2, nil).Once()
+		mockReportCategoryRepo.On("GetReportCategory", mock.Anything, uint(1)).Return(rcEntity.ReportCategory{}, nil)
+		testUserUsecase := NewUserUsecase(mockUserRepo, mockReportCategoryRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
 
-		err := testUserUsecase.Report(uint(1), uint(2), uint(1))
+		err := testUserUsecase.Report(context.Background(), uint(1), uint(2), uint(1))
 
 		assert.Error(t, err)
 	})
@@ -981,17 +1353,17 @@ func TestGetThreadByToken(t *testing.T) {
 				CreatedAt: time.Now(),
 				UpdatedAt: time.Now(),
 			},
-			Title:       "dummy",
-			Body:        "dummy",
-			ImageURL:    "dummy",
-			UserID:      uint(1),
-			CommunityID: uint(1),
+			Title:        "dummy",
+			Body:         "dummy",
+			ImageURL:     "dummy",
+			UserID:       uint(1),
+			CommunityID:  uint(1),
+			Score:        1,
+			UpvotesCount: 1,
 		},
-		User:         mockUserEntity1,
-		UpvotesCount: 1,
-		IsUpvoted:    1,
-		IsFollowed:   1,
-		IsDownvoted:  0,
+		User:       mockUserEntity1,
+		UserVote:   1,
+		IsFollowed: 1,
 	}
 
 	mockThreadWithDetailEntityArr := []threadEntity.ThreadWithDetails{
@@ -999,28 +1371,230 @@ func TestGetThreadByToken(t *testing.T) {
 	}
 
 	t.Run("success", func(t *testing.T) {
-		mockThreadRepo.On("GetThreadsByUserID", uint(1), uint(1)).Return(mockThreadWithDetailEntityArr, nil).Once()
+		mockThreadRepo.On("GetThreadsByUserID", mock.Anything, uint(1), uint(1)).Return(mockThreadWithDetailEntityArr, nil).Once()
 
-		testUserUsecase := NewUserUsecase(nil, nil, nil, nil, mockThreadRepo, nil, nil, nil)
-		res, err := testUserUsecase.GetThreadByToken(uint(1), uint(1))
+		testUserUsecase := NewUserUsecase(nil, nil, nil, nil, mockThreadRepo, nil, nil, nil, nil, nil, nil, "", nil)
+		res, err := testUserUsecase.GetThreadByToken(context.Background(), uint(1), uint(1))
 
 		assert.NoError(t, err)
 		assert.NotEmpty(t, res)
 	})
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		mockThreadRepo.On("GetThreadsByUserID", uint(1), uint(1)).Return([]threadEntity.ThreadWithDetails{}, utils.ErrInternalServerError).Once()
+		mockThreadRepo.On("GetThreadsByUserID", mock.Anything, uint(1), uint(1)).Return([]threadEntity.ThreadWithDetails{}, utils.ErrInternalServerError).Once()
 
-		testUserUsecase := NewUserUsecase(nil, nil, nil, nil, mockThreadRepo, nil, nil, nil)
-		res, err := testUserUsecase.GetThreadByToken(uint(1), uint(1))
+		testUserUsecase := NewUserUsecase(nil, nil, nil, nil, mockThreadRepo, nil, nil, nil, nil, nil, nil, "", nil)
+		res, err := testUserUsecase.GetThreadByToken(context.Background(), uint(1), uint(1))
 
 		assert.Error(t, err)
 		assert.Empty(t, res)
 	})
 }
 
-func TestSendOTP(t *testing.T)   {}
-func TestVerifyOTP(t *testing.T) {}
+func TestSendOTP(t *testing.T) {
+	mockUserRepo := userMock.NewUserRepository(t)
+	otpReq := userDTO.SendOTPRequest{Email: mockUserEntity1.Email}
+
+	unverifiedUser := mockUserEntity1
+	unverifiedUser.EmailVerifiedAt = time.Time{}
+
+	t.Run("success", func(t *testing.T) {
+		capture := mailer.NewCapture()
+		outbox := mailer.NewSyncOutbox(mailer.MustLoadTemplates(), capture)
+
+		mockUserRepo.On("GetByEmail", mock.Anything, mockUserEntity1.Email).Return(unverifiedUser, nil).Once()
+		mockUserRepo.On("GetOTP", mock.Anything, mockUserEntity1.Email).Return(userEntity.VerificationEmail{}, utils.ErrNotFound).Once()
+		mockUserRepo.On("StoreOTP", mock.Anything, mock.MatchedBy(func(otp userEntity.VerificationEmail) bool {
+			return otp.Email == mockUserEntity1.Email && otp.OTPHash != "" && otp.ExpiredAt.After(time.Now())
+		})).Return(nil).Once()
+
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, outbox, nil, nil, nil, "", nil)
+		err := testUserUsecase.SendOTP(context.Background(), otpReq)
+
+		assert.NoError(t, err)
+		msg, ok := capture.Last()
+		assert.True(t, ok)
+		assert.Equal(t, mockUserEntity1.Email, msg.To)
+		assert.Equal(t, "Verify your Macaiki email", msg.Subject)
+		assert.Contains(t, msg.Body, mockUserEntity1.Username)
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		capture := mailer.NewCapture()
+		outbox := mailer.NewSyncOutbox(mailer.MustLoadTemplates(), capture)
+
+		mockUserRepo.On("GetByEmail", mock.Anything, mockUserEntity1.Email).Return(userEntity.User{}, nil).Once()
+
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, outbox, nil, nil, nil, "", nil)
+		err := testUserUsecase.SendOTP(context.Background(), otpReq)
+
+		assert.Equal(t, utils.ErrNotFound, err)
+		assert.Empty(t, capture.Messages())
+	})
+
+	t.Run("already-verified", func(t *testing.T) {
+		capture := mailer.NewCapture()
+		outbox := mailer.NewSyncOutbox(mailer.MustLoadTemplates(), capture)
+
+		mockUserRepo.On("GetByEmail", mock.Anything, mockUserEntity1.Email).Return(mockUserEntity1, nil).Once()
+
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, outbox, nil, nil, nil, "", nil)
+		err := testUserUsecase.SendOTP(context.Background(), otpReq)
+
+		assert.Equal(t, utils.ErrEmailAlreadyVerified, err)
+		assert.Empty(t, capture.Messages())
+	})
+
+	t.Run("resend-cooldown", func(t *testing.T) {
+		capture := mailer.NewCapture()
+		outbox := mailer.NewSyncOutbox(mailer.MustLoadTemplates(), capture)
+
+		mockUserRepo.On("GetByEmail", mock.Anything, mockUserEntity1.Email).Return(unverifiedUser, nil).Once()
+		mockUserRepo.On("GetOTP", mock.Anything, mockUserEntity1.Email).Return(userEntity.VerificationEmail{
+			ID:        1,
+			Email:     mockUserEntity1.Email,
+			CreatedAt: time.Now().Add(-10 * time.Second),
+		}, nil).Once()
+
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, outbox, nil, nil, nil, "", nil)
+		err := testUserUsecase.SendOTP(context.Background(), otpReq)
+
+		var retryErr *utils.RetryAfterError
+		assert.ErrorAs(t, err, &retryErr)
+		assert.ErrorIs(t, err, utils.ErrTooManyRequests)
+		assert.Greater(t, retryErr.RetryAfter, time.Duration(0))
+		assert.Empty(t, capture.Messages())
+	})
+
+	t.Run("store-otp-failed", func(t *testing.T) {
+		capture := mailer.NewCapture()
+		outbox := mailer.NewSyncOutbox(mailer.MustLoadTemplates(), capture)
+
+		mockUserRepo.On("GetByEmail", mock.Anything, mockUserEntity1.Email).Return(unverifiedUser, nil).Once()
+		mockUserRepo.On("GetOTP", mock.Anything, mockUserEntity1.Email).Return(userEntity.VerificationEmail{}, utils.ErrNotFound).Once()
+		mockUserRepo.On("StoreOTP", mock.Anything, mock.Anything).Return(utils.ErrInternalServerError).Once()
+
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, outbox, nil, nil, nil, "", nil)
+		err := testUserUsecase.SendOTP(context.Background(), otpReq)
+
+		assert.Equal(t, utils.ErrInternalServerError, err)
+		assert.Empty(t, capture.Messages())
+	})
+
+	t.Run("mail-failed", func(t *testing.T) {
+		capture := mailer.NewCapture()
+		capture.FailWith(errors.New("smtp down"))
+		outbox := mailer.NewSyncOutbox(mailer.MustLoadTemplates(), capture)
+
+		mockUserRepo.On("GetByEmail", mock.Anything, mockUserEntity1.Email).Return(unverifiedUser, nil).Once()
+		mockUserRepo.On("GetOTP", mock.Anything, mockUserEntity1.Email).Return(userEntity.VerificationEmail{}, utils.ErrNotFound).Once()
+		mockUserRepo.On("StoreOTP", mock.Anything, mock.Anything).Return(nil).Once()
+
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, outbox, nil, nil, nil, "", nil)
+		err := testUserUsecase.SendOTP(context.Background(), otpReq)
+
+		assert.Equal(t, utils.ErrInternalServerError, err)
+	})
+}
+
+func TestVerifyOTP(t *testing.T) {
+	mockUserRepo := userMock.NewUserRepository(t)
+	v := validator.New()
+
+	req := userDTO.VerifyOTPRequest{Email: mockUserEntity1.Email, OTP: "abc123"}
+	mockOTP := userEntity.VerificationEmail{
+		ID:        1,
+		Email:     mockUserEntity1.Email,
+		OTPHash:   hashAndSalt([]byte("abc123")),
+		ExpiredAt: time.Now().Add(time.Minute),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockUserRepo.On("GetOTP", mock.Anything, req.Email).Return(mockOTP, nil).Once()
+		mockUserRepo.On("ClaimOTPAttempt", mock.Anything, mockOTP.ID, OTP_MAX_ATTEMPTS).Return(nil).Once()
+		mockUserRepo.On("ConsumeOTP", mock.Anything, mockOTP.ID).Return(nil).Once()
+		mockUserRepo.On("GetByEmail", mock.Anything, req.Email).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("Update", mock.Anything, mock.Anything, mock.MatchedBy(func(u userEntity.User) bool {
+			return !u.EmailVerifiedAt.IsZero()
+		})).Return(mockUserEntity1, nil).Once()
+
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.VerifyOTP(context.Background(), req)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("bad-request", func(t *testing.T) {
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.VerifyOTP(context.Background(), userDTO.VerifyOTPRequest{Email: req.Email})
+
+		assert.Equal(t, utils.ErrBadParamInput, err)
+	})
+
+	t.Run("no-pending-otp", func(t *testing.T) {
+		mockUserRepo.On("GetOTP", mock.Anything, req.Email).Return(userEntity.VerificationEmail{}, utils.ErrNotFound).Once()
+
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.VerifyOTP(context.Background(), req)
+
+		assert.Equal(t, utils.ErrOTPInvalid, err)
+	})
+
+	t.Run("wrong-otp", func(t *testing.T) {
+		mockUserRepo.On("GetOTP", mock.Anything, req.Email).Return(mockOTP, nil).Once()
+		mockUserRepo.On("ClaimOTPAttempt", mock.Anything, mockOTP.ID, OTP_MAX_ATTEMPTS).Return(nil).Once()
+
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.VerifyOTP(context.Background(), userDTO.VerifyOTPRequest{Email: req.Email, OTP: "000000"})
+
+		assert.Equal(t, utils.ErrOTPInvalid, err)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		expiredOTP := mockOTP
+		expiredOTP.ExpiredAt = time.Now().Add(-time.Second)
+		mockUserRepo.On("GetOTP", mock.Anything, req.Email).Return(expiredOTP, nil).Once()
+
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.VerifyOTP(context.Background(), req)
+
+		assert.Equal(t, utils.ErrOTPExpired, err)
+	})
+
+	t.Run("attempts-exceeded", func(t *testing.T) {
+		mockUserRepo.On("GetOTP", mock.Anything, req.Email).Return(mockOTP, nil).Once()
+		mockUserRepo.On("ClaimOTPAttempt", mock.Anything, mockOTP.ID, OTP_MAX_ATTEMPTS).Return(utils.ErrNotFound).Once()
+
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.VerifyOTP(context.Background(), req)
+
+		assert.Equal(t, utils.ErrOTPAttemptsExceeded, err)
+	})
+
+	t.Run("already-consumed", func(t *testing.T) {
+		mockUserRepo.On("GetOTP", mock.Anything, req.Email).Return(mockOTP, nil).Once()
+		mockUserRepo.On("ClaimOTPAttempt", mock.Anything, mockOTP.ID, OTP_MAX_ATTEMPTS).Return(nil).Once()
+		mockUserRepo.On("ConsumeOTP", mock.Anything, mockOTP.ID).Return(utils.ErrNotFound).Once()
+
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.VerifyOTP(context.Background(), req)
+
+		assert.Equal(t, utils.ErrOTPInvalid, err)
+	})
+
+	t.Run("update-failed", func(t *testing.T) {
+		mockUserRepo.On("GetOTP", mock.Anything, req.Email).Return(mockOTP, nil).Once()
+		mockUserRepo.On("ClaimOTPAttempt", mock.Anything, mockOTP.ID, OTP_MAX_ATTEMPTS).Return(nil).Once()
+		mockUserRepo.On("ConsumeOTP", mock.Anything, mockOTP.ID).Return(nil).Once()
+		mockUserRepo.On("GetByEmail", mock.Anything, req.Email).Return(mockUserEntity1, nil).Once()
+		mockUserRepo.On("Update", mock.Anything, mock.Anything, mock.Anything).Return(userEntity.User{}, utils.ErrInternalServerError).Once()
+
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.VerifyOTP(context.Background(), req)
+
+		assert.Equal(t, utils.ErrInternalServerError, err)
+	})
+}
 
 func TestGetReports(t *testing.T) {
 	mockUserRepo := userMock.NewUserRepository(t)
@@ -1028,31 +1602,31 @@ func TestGetReports(t *testing.T) {
 	mockBriefReportEntityArr := []userEntity.BriefReport{mockBriefReportEntity}
 
 	t.Run("success", func(t *testing.T) {
-		mockUserRepo.On("GetReports").Return(mockBriefReportEntityArr, nil).Once()
+		mockUserRepo.On("GetReports", mock.Anything).Return(mockBriefReportEntityArr, nil).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
 
-		res, err := testUserUsecase.GetReports("Admin")
+		res, err := testUserUsecase.GetReports(context.Background(), "Admin")
 
 		assert.NoError(t, err)
 		assert.NotEmpty(t, res)
 	})
 
 	t.Run("unauthorize-access", func(t *testing.T) {
-		testUserUsecase := NewUserUsecase(nil, nil, nil, nil, nil, nil, nil, nil)
+		testUserUsecase := NewUserUsecase(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
 
-		res, err := testUserUsecase.GetReports("User")
+		res, err := testUserUsecase.GetReports(context.Background(), "User")
 
 		assert.Error(t, err)
 		assert.Empty(t, res)
 	})
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		mockUserRepo.On("GetReports").Return([]userEntity.BriefReport{}, utils.ErrInternalServerError).Once()
+		mockUserRepo.On("GetReports", mock.Anything).Return([]userEntity.BriefReport{}, utils.ErrInternalServerError).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
 
-		res, err := testUserUsecase.GetReports("Admin")
+		res, err := testUserUsecase.GetReports(context.Background(), "Admin")
 
 		assert.Error(t, err)
 		assert.Empty(t, res)
@@ -1063,28 +1637,28 @@ func TestGetDashboardAnalytics(t *testing.T) {
 	mockUserRepo := userMock.NewUserRepository(t)
 
 	t.Run("success", func(t *testing.T) {
-		mockUserRepo.On("GetDashboardAnalytics").Return(mockAdminDashboardAnalyticsEntity, nil).Once()
+		mockUserRepo.On("GetDashboardAnalytics", mock.Anything).Return(mockAdminDashboardAnalyticsEntity, nil).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
-		res, err := testUserUsecase.GetDashboardAnalytics("Admin")
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+		res, err := testUserUsecase.GetDashboardAnalytics(context.Background(), "Admin")
 
 		assert.NoError(t, err)
 		assert.NotEmpty(t, res)
 	})
 
 	t.Run("unauthorize-access", func(t *testing.T) {
-		testUserUsecase := NewUserUsecase(nil, nil, nil, nil, nil, nil, nil, nil)
-		res, err := testUserUsecase.GetDashboardAnalytics("User")
+		testUserUsecase := NewUserUsecase(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+		res, err := testUserUsecase.GetDashboardAnalytics(context.Background(), "User")
 
 		assert.Error(t, err)
 		assert.Empty(t, res)
 	})
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		mockUserRepo.On("GetDashboardAnalytics").Return(userEntity.AdminDashboardAnalytics{}, utils.ErrInternalServerError).Once()
+		mockUserRepo.On("GetDashboardAnalytics", mock.Anything).Return(userEntity.AdminDashboardAnalytics{}, utils.ErrInternalServerError).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
-		res, err := testUserUsecase.GetDashboardAnalytics("Admin")
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+		res, err := testUserUsecase.GetDashboardAnalytics(context.Background(), "Admin")
 
 		assert.Error(t, err)
 		assert.Empty(t, res)
@@ -1095,28 +1669,28 @@ func TestGetReportedThread(t *testing.T) {
 	mockUserRepo := userMock.NewUserRepository(t)
 
 	t.Run("success", func(t *testing.T) {
-		mockUserRepo.On("GetReportedThread", uint(1)).Return(mockReportedThreadEntity, nil).Once()
+		mockUserRepo.On("GetReportedThread", mock.Anything, uint(1)).Return(mockReportedThreadEntity, nil).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
-		res, err := testUserUsecase.GetReportedThread("Admin", uint(1))
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+		res, err := testUserUsecase.GetReportedThread(context.Background(), "Admin", uint(1))
 
 		assert.NoError(t, err)
 		assert.NotEmpty(t, res)
 	})
 
 	t.Run("unauthorize-access", func(t *testing.T) {
-		testUserUsecase := NewUserUsecase(nil, nil, nil, nil, nil, nil, nil, nil)
-		res, err := testUserUsecase.GetReportedThread("User", uint(1))
+		testUserUsecase := NewUserUsecase(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+		res, err := testUserUsecase.GetReportedThread(context.Background(), "User", uint(1))
 
 		assert.Error(t, err)
 		assert.Empty(t, res)
 	})
 
 	t.Run("internal-server-error", func(t *testing.T) {
-		mockUserRepo.On("GetReportedThread", uint(1)).Return(userEntity.ReportedThread{}, utils.ErrInternalServerError).Once()
+		mockUserRepo.On("GetReportedThread", mock.Anything, uint(1)).Return(userEntity.ReportedThread{}, utils.ErrInternalServerError).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
-		res, err := testUserUsecase.GetReportedThread("Admin", uint(1))
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+		res, err := testUserUsecase.GetReportedThread(context.Background(), "Admin", uint(1))
 
 		assert.Error(t, err)
 		assert.Empty(t, res)
@@ -1127,28 +1701,28 @@ func TestGetReportedCommunity(t *testing.T) {
 	mockUserRepo := userMock.NewUserRepository(t)
 
 	t.Run("success", func(t *testing.T) {
-		mockUserRepo.On("GetReportedCommunity", uint(1)).Return(mockReportedCommunityEntity, nil).Once()
+		mockUserRepo.On("GetReportedCommunity", mock.Anything, uint(1)).Return(mockReportedCommunityEntity, nil).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
-		res, err := testUserUsecase.GetReportedCommunity("Admin", uint(1))
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+		res, err := testUserUsecase.GetReportedCommunity(context.Background(), "Admin", uint(1))
 
 		assert.NoError(t, err)
 		assert.NotEmpty(t, res)
 	})
 
 	t.Run("unauthorize-access", func(t *testing.T) {
-		testUserUsecase := NewUserUsecase(nil, nil, nil, nil, nil, nil, nil, nil)
-		res, err := testUserUsecase.GetReportedCommunity("User", uint(1))
+		testUserUsecase := NewUserUsecase(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+		res, err := testUserUsecase.GetReportedCommunity(context.Background(), "User", uint(1))
 
 		assert.Error(t, err)
 		assert.Empty(t, res)
 	})
 
 	t.Run("inteernal-server-error", func(t *testing.T) {
-		mockUserRepo.On("GetReportedCommunity", uint(1)).Return(userEntity.ReportedCommunity{}, utils.ErrInternalServerError).Once()
+		mockUserRepo.On("GetReportedCommunity", mock.Anything, uint(1)).Return(userEntity.ReportedCommunity{}, utils.ErrInternalServerError).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
-		res, err := testUserUsecase.GetReportedCommunity("Admin", uint(1))
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+		res, err := testUserUsecase.GetReportedCommunity(context.Background(), "Admin", uint(1))
 
 		assert.Error(t, err)
 		assert.Empty(t, res)
@@ -1159,28 +1733,28 @@ func TestGetReportedComment(t *testing.T) {
 	mockUserRepo := userMock.NewUserRepository(t)
 
 	t.Run("success", func(t *testing.T) {
-		mockUserRepo.On("GetReportedComment", uint(1)).Return(mockReportedCommentEntity, nil).Once()
+		mockUserRepo.On("GetReportedComment", mock.Anything, uint(1)).Return(mockReportedCommentEntity, nil).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
-		res, err := testUserUsecase.GetReportedComment("Admin", uint(1))
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+		res, err := testUserUsecase.GetReportedComment(context.Background(), "Admin", uint(1))
 
 		assert.NoError(t, err)
 		assert.NotEmpty(t, res)
 	})
 
 	t.Run("unauthorize-access", func(t *testing.T) {
-		testUserUsecase := NewUserUsecase(nil, nil, nil, nil, nil, nil, nil, nil)
-		res, err := testUserUsecase.GetReportedComment("User", uint(1))
+		testUserUsecase := NewUserUsecase(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+		res, err := testUserUsecase.GetReportedComment(context.Background(), "User", uint(1))
 
 		assert.Error(t, err)
 		assert.Empty(t, res)
 	})
 
 	t.Run("inteernal-server-error", func(t *testing.T) {
-		mockUserRepo.On("GetReportedComment", uint(1)).Return(userEntity.ReportedComment{}, utils.ErrInternalServerError).Once()
+		mockUserRepo.On("GetReportedComment", mock.Anything, uint(1)).Return(userEntity.ReportedComment{}, utils.ErrInternalServerError).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
-		res, err := testUserUsecase.GetReportedComment("Admin", uint(1))
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+		res, err := testUserUsecase.GetReportedComment(context.Background(), "Admin", uint(1))
 
 		assert.Error(t, err)
 		assert.Empty(t, res)
@@ -1191,28 +1765,28 @@ func TestGetReportedUser(t *testing.T) {
 	mockUserRepo := userMock.NewUserRepository(t)
 
 	t.Run("success", func(t *testing.T) {
-		mockUserRepo.On("GetReportedUser", uint(1)).Return(mockReportedUserEntity, nil).Once()
+		mockUserRepo.On("GetReportedUser", mock.Anything, uint(1)).Return(mockReportedUserEntity, nil).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
-		res, err := testUserUsecase.GetReportedUser("Admin", uint(1))
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+		res, err := testUserUsecase.GetReportedUser(context.Background(), "Admin", uint(1))
 
 		assert.NoError(t, err)
 		assert.NotEmpty(t, res)
 	})
 
 	t.Run("unauthorize-access", func(t *testing.T) {
-		testUserUsecase := NewUserUsecase(nil, nil, nil, nil, nil, nil, nil, nil)
-		res, err := testUserUsecase.GetReportedUser("User", uint(1))
+		testUserUsecase := NewUserUsecase(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+		res, err := testUserUsecase.GetReportedUser(context.Background(), "User", uint(1))
 
 		assert.Error(t, err)
 		assert.Empty(t, res)
 	})
 
 	t.Run("inteernal-server-error", func(t *testing.T) {
-		mockUserRepo.On("GetReportedUser", uint(1)).Return(userEntity.ReportedUser{}, utils.ErrInternalServerError).Once()
+		mockUserRepo.On("GetReportedUser", mock.Anything, uint(1)).Return(userEntity.ReportedUser{}, utils.ErrInternalServerError).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
-		res, err := testUserUsecase.GetReportedUser("Admin", uint(1))
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+		res, err := testUserUsecase.GetReportedUser(context.Background(), "Admin", uint(1))
 
 		assert.Error(t, err)
 		assert.Empty(t, res)
@@ -1223,49 +1797,49 @@ func TestBanUser(t *testing.T) {
 	mockUserRepo := userMock.NewUserRepository(t)
 
 	t.Run("success", func(t *testing.T) {
-		mockUserRepo.On("GetUserReport", uint(1)).Return(mockUserReportEntity, nil).Once()
-		mockUserRepo.On("DeleteUserReport", uint(1)).Return(nil).Once()
-		mockUserRepo.On("Delete", mockUserReportEntity.ReportedUserID).Return(nil).Once()
+		mockUserRepo.On("GetUserReport", mock.Anything, uint(1)).Return(mockUserReportEntity, nil).Once()
+		mockUserRepo.On("DeleteUserReport", mock.Anything, uint(1)).Return(nil).Once()
+		mockUserRepo.On("Delete", mock.Anything, mockUserReportEntity.ReportedUserID).Return(nil).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
-		err := testUserUsecase.BanUser("Admin", uint(1))
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.BanUser(context.Background(), "Admin", uint(1))
 
 		assert.NoError(t, err)
 	})
 
 	t.Run("unauthorize-access", func(t *testing.T) {
-		testUserUsecase := NewUserUsecase(nil, nil, nil, nil, nil, nil, nil, nil)
-		err := testUserUsecase.BanUser("User", uint(1))
+		testUserUsecase := NewUserUsecase(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.BanUser(context.Background(), "User", uint(1))
 
 		assert.Error(t, err)
 	})
 
 	t.Run("error", func(t *testing.T) {
-		mockUserRepo.On("GetUserReport", uint(1)).Return(userEntity.UserReport{}, utils.ErrInternalServerError).Once()
+		mockUserRepo.On("GetUserReport", mock.Anything, uint(1)).Return(userEntity.UserReport{}, utils.ErrInternalServerError).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
-		err := testUserUsecase.BanUser("Admin", uint(1))
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.BanUser(context.Background(), "Admin", uint(1))
 
 		assert.Error(t, err)
 	})
 
 	t.Run("error", func(t *testing.T) {
-		mockUserRepo.On("GetUserReport", uint(1)).Return(mockUserReportEntity, nil).Once()
-		mockUserRepo.On("DeleteUserReport", uint(1)).Return(utils.ErrInternalServerError).Once()
+		mockUserRepo.On("GetUserReport", mock.Anything, uint(1)).Return(mockUserReportEntity, nil).Once()
+		mockUserRepo.On("DeleteUserReport", mock.Anything, uint(1)).Return(utils.ErrInternalServerError).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
-		err := testUserUsecase.BanUser("Admin", uint(1))
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.BanUser(context.Background(), "Admin", uint(1))
 
 		assert.Error(t, err)
 	})
 
 	t.Run("error", func(t *testing.T) {
-		mockUserRepo.On("GetUserReport", uint(1)).Return(mockUserReportEntity, nil).Once()
-		mockUserRepo.On("DeleteUserReport", uint(1)).Return(nil).Once()
-		mockUserRepo.On("Delete", mockUserReportEntity.ReportedUserID).Return(utils.ErrInternalServerError).Once()
+		mockUserRepo.On("GetUserReport", mock.Anything, uint(1)).Return(mockUserReportEntity, nil).Once()
+		mockUserRepo.On("DeleteUserReport", mock.Anything, uint(1)).Return(nil).Once()
+		mockUserRepo.On("Delete", mock.Anything, mockUserReportEntity.ReportedUserID).Return(utils.ErrInternalServerError).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
-		err := testUserUsecase.BanUser("Admin", uint(1))
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.BanUser(context.Background(), "Admin", uint(1))
 
 		assert.Error(t, err)
 	})
@@ -1290,49 +1864,49 @@ func TestBanThread(t *testing.T) {
 	}
 
 	t.Run("success", func(t *testing.T) {
-		mockThreadRepo.On("GetThreadReport", uint(1)).Return(mockThreadReportEntity, nil).Once()
-		mockUserRepo.On("DeleteThreadReport", uint(1)).Return(nil).Once()
-		mockThreadRepo.On("DeleteThread", mockThreadReportEntity.ThreadID).Return(nil).Once()
+		mockThreadRepo.On("GetThreadReport", mock.Anything, uint(1)).Return(mockThreadReportEntity, nil).Once()
+		mockUserRepo.On("DeleteThreadReport", mock.Anything, uint(1)).Return(nil).Once()
+		mockThreadRepo.On("DeleteThread", mock.Anything, mockThreadReportEntity.ThreadID).Return(nil).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, mockThreadRepo, nil, nil, nil)
-		err := testUserUsecase.BanThread("Admin", uint(1))
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, mockThreadRepo, nil, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.BanThread(context.Background(), "Admin", uint(1))
 
 		assert.NoError(t, err)
 	})
 
 	t.Run("unauthorize-access", func(t *testing.T) {
-		testUserUsecase := NewUserUsecase(nil, nil, nil, nil, nil, nil, nil, nil)
-		err := testUserUsecase.BanThread("User", uint(1))
+		testUserUsecase := NewUserUsecase(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.BanThread(context.Background(), "User", uint(1))
 
 		assert.Error(t, err)
 	})
 
 	t.Run("error", func(t *testing.T) {
-		mockThreadRepo.On("GetThreadReport", uint(1)).Return(threadEntity.ThreadReport{}, utils.ErrInternalServerError).Once()
+		mockThreadRepo.On("GetThreadReport", mock.Anything, uint(1)).Return(threadEntity.ThreadReport{}, utils.ErrInternalServerError).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, mockThreadRepo, nil, nil, nil)
-		err := testUserUsecase.BanThread("Admin", uint(1))
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, mockThreadRepo, nil, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.BanThread(context.Background(), "Admin", uint(1))
 
 		assert.Error(t, err)
 	})
 
 	t.Run("error", func(t *testing.T) {
-		mockThreadRepo.On("GetThreadReport", uint(1)).Return(mockThreadReportEntity, nil).Once()
-		mockUserRepo.On("DeleteThreadReport", uint(1)).Return(utils.ErrInternalServerError).Once()
+		mockThreadRepo.On("GetThreadReport", mock.Anything, uint(1)).Return(mockThreadReportEntity, nil).Once()
+		mockUserRepo.On("DeleteThreadReport", mock.Anything, uint(1)).Return(utils.ErrInternalServerError).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, mockThreadRepo, nil, nil, nil)
-		err := testUserUsecase.BanThread("Admin", uint(1))
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, mockThreadRepo, nil, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.BanThread(context.Background(), "Admin", uint(1))
 
 		assert.Error(t, err)
 	})
 
 	t.Run("error", func(t *testing.T) {
-		mockThreadRepo.On("GetThreadReport", uint(1)).Return(mockThreadReportEntity, nil).Once()
-		mockUserRepo.On("DeleteThreadReport", uint(1)).Return(nil).Once()
-		mockThreadRepo.On("DeleteThread", mockThreadReportEntity.ThreadID).Return(utils.ErrInternalServerError).Once()
+		mockThreadRepo.On("GetThreadReport", mock.Anything, uint(1)).Return(mockThreadReportEntity, nil).Once()
+		mockUserRepo.On("DeleteThreadReport", mock.Anything, uint(1)).Return(nil).Once()
+		mockThreadRepo.On("DeleteThread", mock.Anything, mockThreadReportEntity.ThreadID).Return(utils.ErrInternalServerError).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, mockThreadRepo, nil, nil, nil)
-		err := testUserUsecase.BanThread("Admin", uint(1))
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, mockThreadRepo, nil, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.BanThread(context.Background(), "Admin", uint(1))
 
 		assert.Error(t, err)
 	})
@@ -1357,49 +1931,49 @@ func TestBanComment(t *testing.T) {
 	}
 
 	t.Run("success", func(t *testing.T) {
-		mockThreadRepo.On("GetCommentReport", uint(1)).Return(mockCommentReportEntity, nil).Once()
-		mockUserRepo.On("DeleteCommentReport", uint(1)).Return(nil).Once()
-		mockThreadRepo.On("DeleteComment", mockCommentReportEntity.CommentID).Return(nil).Once()
+		mockThreadRepo.On("GetCommentReport", mock.Anything, uint(1)).Return(mockCommentReportEntity, nil).Once()
+		mockUserRepo.On("DeleteCommentReport", mock.Anything, uint(1)).Return(nil).Once()
+		mockThreadRepo.On("DeleteComment", mock.Anything, mockCommentReportEntity.CommentID).Return(nil).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, mockThreadRepo, nil, nil, nil)
-		err := testUserUsecase.BanComment("Admin", uint(1))
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, mockThreadRepo, nil, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.BanComment(context.Background(), "Admin", uint(1))
 
 		assert.NoError(t, err)
 	})
 
 	t.Run("unauthorize-access", func(t *testing.T) {
-		testUserUsecase := NewUserUsecase(nil, nil, nil, nil, nil, nil, nil, nil)
-		err := testUserUsecase.BanComment("User", uint(1))
+		testUserUsecase := NewUserUsecase(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.BanComment(context.Background(), "User", uint(1))
 
 		assert.Error(t, err)
 	})
 
 	t.Run("error", func(t *testing.T) {
-		mockThreadRepo.On("GetCommentReport", uint(1)).Return(threadEntity.CommentReport{}, utils.ErrInternalServerError).Once()
+		mockThreadRepo.On("GetCommentReport", mock.Anything, uint(1)).Return(threadEntity.CommentReport{}, utils.ErrInternalServerError).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, mockThreadRepo, nil, nil, nil)
-		err := testUserUsecase.BanComment("Admin", uint(1))
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, mockThreadRepo, nil, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.BanComment(context.Background(), "Admin", uint(1))
 
 		assert.Error(t, err)
 	})
 
 	t.Run("error", func(t *testing.T) {
-		mockThreadRepo.On("GetCommentReport", uint(1)).Return(mockCommentReportEntity, nil).Once()
-		mockUserRepo.On("DeleteCommentReport", uint(1)).Return(utils.ErrInternalServerError).Once()
+		mockThreadRepo.On("GetCommentReport", mock.Anything, uint(1)).Return(mockCommentReportEntity, nil).Once()
+		mockUserRepo.On("DeleteCommentReport", mock.Anything, uint(1)).Return(utils.ErrInternalServerError).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, mockThreadRepo, nil, nil, nil)
-		err := testUserUsecase.BanComment("Admin", uint(1))
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, mockThreadRepo, nil, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.BanComment(context.Background(), "Admin", uint(1))
 
 		assert.Error(t, err)
 	})
 
 	t.Run("error", func(t *testing.T) {
-		mockThreadRepo.On("GetCommentReport", uint(1)).Return(mockCommentReportEntity, nil).Once()
-		mockUserRepo.On("DeleteCommentReport", uint(1)).Return(nil).Once()
-		mockThreadRepo.On("DeleteComment", mockCommentReportEntity.CommentID).Return(utils.ErrInternalServerError).Once()
+		mockThreadRepo.On("GetCommentReport", mock.Anything, uint(1)).Return(mockCommentReportEntity, nil).Once()
+		mockUserRepo.On("DeleteCommentReport", mock.Anything, uint(1)).Return(nil).Once()
+		mockThreadRepo.On("DeleteComment", mock.Anything, mockCommentReportEntity.CommentID).Return(utils.ErrInternalServerError).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, mockThreadRepo, nil, nil, nil)
-		err := testUserUsecase.BanComment("Admin", uint(1))
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, mockThreadRepo, nil, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.BanComment(context.Background(), "Admin", uint(1))
 
 		assert.Error(t, err)
 	})
@@ -1421,49 +1995,49 @@ func TestBanCommunity(t *testing.T) {
 	}
 
 	t.Run("success", func(t *testing.T) {
-		mockCommunityRepo.On("GetReportCommunity", uint(1)).Return(mockCommunityReportEntity, nil).Once()
-		mockUserRepo.On("DeleteCommunityReport", uint(1)).Return(nil).Once()
-		mockCommunityRepo.On("DeleteCommunity", mockCommunityReportEntity.CommunityReportedID).Return(nil).Once()
+		mockCommunityRepo.On("GetReportCommunity", mock.Anything, uint(1)).Return(mockCommunityReportEntity, nil).Once()
+		mockUserRepo.On("DeleteCommunityReport", mock.Anything, uint(1)).Return(nil).Once()
+		mockCommunityRepo.On("DeleteCommunity", mock.Anything, mockCommunityReportEntity.CommunityReportedID).Return(nil).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, mockCommunityRepo, nil, nil, nil, nil, nil)
-		err := testUserUsecase.BanCommunity("Admin", uint(1))
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, mockCommunityRepo, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.BanCommunity(context.Background(), "Admin", uint(1))
 
 		assert.NoError(t, err)
 	})
 
 	t.Run("unauthorize-access", func(t *testing.T) {
-		testUserUsecase := NewUserUsecase(nil, nil, nil, nil, nil, nil, nil, nil)
-		err := testUserUsecase.BanCommunity("User", uint(1))
+		testUserUsecase := NewUserUsecase(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.BanCommunity(context.Background(), "User", uint(1))
 
 		assert.Error(t, err)
 	})
 
 	t.Run("error", func(t *testing.T) {
-		mockCommunityRepo.On("GetReportCommunity", uint(1)).Return(communityEntity.CommunityReport{}, utils.ErrInternalServerError).Once()
+		mockCommunityRepo.On("GetReportCommunity", mock.Anything, uint(1)).Return(communityEntity.CommunityReport{}, utils.ErrInternalServerError).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, mockCommunityRepo, nil, nil, nil, nil, nil)
-		err := testUserUsecase.BanCommunity("Admin", uint(1))
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, mockCommunityRepo, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.BanCommunity(context.Background(), "Admin", uint(1))
 
 		assert.Error(t, err)
 	})
 
 	t.Run("error", func(t *testing.T) {
-		mockCommunityRepo.On("GetReportCommunity", uint(1)).Return(mockCommunityReportEntity, nil).Once()
-		mockUserRepo.On("DeleteCommunityReport", uint(1)).Return(utils.ErrInternalServerError).Once()
+		mockCommunityRepo.On("GetReportCommunity", mock.Anything, uint(1)).Return(mockCommunityReportEntity, nil).Once()
+		mockUserRepo.On("DeleteCommunityReport", mock.Anything, uint(1)).Return(utils.ErrInternalServerError).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, mockCommunityRepo, nil, nil, nil, nil, nil)
-		err := testUserUsecase.BanCommunity("Admin", uint(1))
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, mockCommunityRepo, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.BanCommunity(context.Background(), "Admin", uint(1))
 
 		assert.Error(t, err)
 	})
 
 	t.Run("error", func(t *testing.T) {
-		mockCommunityRepo.On("GetReportCommunity", uint(1)).Return(mockCommunityReportEntity, nil).Once()
-		mockUserRepo.On("DeleteCommunityReport", uint(1)).Return(nil).Once()
-		mockCommunityRepo.On("DeleteCommunity", mockCommunityReportEntity.CommunityReportedID).Return(utils.ErrInternalServerError).Once()
+		mockCommunityRepo.On("GetReportCommunity", mock.Anything, uint(1)).Return(mockCommunityReportEntity, nil).Once()
+		mockUserRepo.On("DeleteCommunityReport", mock.Anything, uint(1)).Return(nil).Once()
+		mockCommunityRepo.On("DeleteCommunity", mock.Anything, mockCommunityReportEntity.CommunityReportedID).Return(utils.ErrInternalServerError).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, mockCommunityRepo, nil, nil, nil, nil, nil)
-		err := testUserUsecase.BanCommunity("Admin", uint(1))
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, mockCommunityRepo, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.BanCommunity(context.Background(), "Admin", uint(1))
 
 		assert.Error(t, err)
 	})
@@ -1473,17 +2047,17 @@ func TestDeleteThreadReport(t *testing.T) {
 	mockUserRepo := userMock.NewUserRepository(t)
 
 	t.Run("success", func(t *testing.T) {
-		mockUserRepo.On("DeleteThreadReport", uint(1)).Return(nil).Once()
+		mockUserRepo.On("DeleteThreadReport", mock.Anything, uint(1)).Return(nil).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
-		err := testUserUsecase.DeleteThreadReport("Admin", uint(1))
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.DeleteThreadReport(context.Background(), "Admin", uint(1))
 
 		assert.NoError(t, err)
 	})
 
 	t.Run("unauthorize-access", func(t *testing.T) {
-		testUserUsecase := NewUserUsecase(nil, nil, nil, nil, nil, nil, nil, nil)
-		err := testUserUsecase.DeleteThreadReport("User", uint(1))
+		testUserUsecase := NewUserUsecase(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.DeleteThreadReport(context.Background(), "User", uint(1))
 
 		assert.Error(t, err)
 	})
@@ -1493,17 +2067,17 @@ func TestDeleteUserReport(t *testing.T) {
 	mockUserRepo := userMock.NewUserRepository(t)
 
 	t.Run("success", func(t *testing.T) {
-		mockUserRepo.On("DeleteUserReport", uint(1)).Return(nil).Once()
+		mockUserRepo.On("DeleteUserReport", mock.Anything, uint(1)).Return(nil).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
-		err := testUserUsecase.DeleteUserReport("Admin", uint(1))
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.DeleteUserReport(context.Background(), "Admin", uint(1))
 
 		assert.NoError(t, err)
 	})
 
 	t.Run("unauthorize-access", func(t *testing.T) {
-		testUserUsecase := NewUserUsecase(nil, nil, nil, nil, nil, nil, nil, nil)
-		err := testUserUsecase.DeleteUserReport("User", uint(1))
+		testUserUsecase := NewUserUsecase(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.DeleteUserReport(context.Background(), "User", uint(1))
 
 		assert.Error(t, err)
 	})
@@ -1513,17 +2087,17 @@ func TestDeleteCommentReport(t *testing.T) {
 	mockUserRepo := userMock.NewUserRepository(t)
 
 	t.Run("success", func(t *testing.T) {
-		mockUserRepo.On("DeleteCommentReport", uint(1)).Return(nil).Once()
+		mockUserRepo.On("DeleteCommentReport", mock.Anything, uint(1)).Return(nil).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
-		err := testUserUsecase.DeleteCommentReport("Admin", uint(1))
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.DeleteCommentReport(context.Background(), "Admin", uint(1))
 
 		assert.NoError(t, err)
 	})
 
 	t.Run("unauthorize-access", func(t *testing.T) {
-		testUserUsecase := NewUserUsecase(nil, nil, nil, nil, nil, nil, nil, nil)
-		err := testUserUsecase.DeleteCommentReport("User", uint(1))
+		testUserUsecase := NewUserUsecase(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.DeleteCommentReport(context.Background(), "User", uint(1))
 
 		assert.Error(t, err)
 	})
@@ -1532,18 +2106,102 @@ func TestDeleteCommunityReport(t *testing.T) {
 	mockUserRepo := userMock.NewUserRepository(t)
 
 	t.Run("success", func(t *testing.T) {
-		mockUserRepo.On("DeleteCommunityReport", uint(1)).Return(nil).Once()
+		mockUserRepo.On("DeleteCommunityReport", mock.Anything, uint(1)).Return(nil).Once()
 
-		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil)
-		err := testUserUsecase.DeleteCommunityReport("Admin", uint(1))
+		testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.DeleteCommunityReport(context.Background(), "Admin", uint(1))
 
 		assert.NoError(t, err)
 	})
 
 	t.Run("unauthorize-access", func(t *testing.T) {
-		testUserUsecase := NewUserUsecase(nil, nil, nil, nil, nil, nil, nil, nil)
-		err := testUserUsecase.DeleteCommunityReport("User", uint(1))
+		testUserUsecase := NewUserUsecase(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+		err := testUserUsecase.DeleteCommunityReport(context.Background(), "User", uint(1))
 
 		assert.Error(t, err)
 	})
 }
+
+func TestLoginLockout(t *testing.T) {
+	mockUserRepo := userMock.NewUserRepository(t)
+	lockout := ratelimit.NewLockout(ratelimit.NewMemoryStore(), ratelimit.LockoutPolicy{
+		MaxFailures: 2,
+		Window:      time.Minute,
+		Duration:    time.Minute,
+	})
+	testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, v, nil, nil, nil, lockout, nil, "", nil)
+
+	loginInfo := userDTO.UserLoginRequest{
+		Email:    mockUserEntity1.Email,
+		Password: "wrong-password",
+	}
+
+	t.Run("login-failed", func(t *testing.T) {
+		mockUserRepo.On("GetByEmail", mock.Anything, mockUserEntity1.Email).Return(mockUserEntity1, nil).Once()
+
+		_, err := testUserUsecase.Login(context.Background(), loginInfo)
+
+		assert.Equal(t, utils.ErrLoginFailed, err)
+	})
+
+	t.Run("locked-after-max-failures", func(t *testing.T) {
+		mockUserRepo.On("GetByEmail", mock.Anything, mockUserEntity1.Email).Return(mockUserEntity1, nil).Once()
+
+		_, err := testUserUsecase.Login(context.Background(), loginInfo)
+
+		var retryErr *utils.RetryAfterError
+		assert.ErrorAs(t, err, &retryErr)
+		assert.ErrorIs(t, err, utils.ErrTooManyRequests)
+		assert.Equal(t, time.Minute, retryErr.RetryAfter)
+	})
+
+	t.Run("locked", func(t *testing.T) {
+		loginInfo.Password = "123456"
+
+		_, err := testUserUsecase.Login(context.Background(), loginInfo)
+
+		assert.ErrorIs(t, err, utils.ErrTooManyRequests)
+	})
+}
+
+func TestVerifyOTPLockout(t *testing.T) {
+	mockUserRepo := userMock.NewUserRepository(t)
+	lockout := ratelimit.NewLockout(ratelimit.NewMemoryStore(), ratelimit.LockoutPolicy{
+		MaxFailures: 2,
+		Window:      time.Minute,
+		Duration:    time.Minute,
+	})
+	testUserUsecase := NewUserUsecase(mockUserRepo, nil, nil, nil, nil, validator.New(), nil, nil, nil, lockout, nil, "", nil)
+
+	verification := userEntity.VerificationEmail{
+		ID:        1,
+		Email:     mockUserEntity1.Email,
+		OTPHash:   hashAndSalt([]byte("abc123")),
+		ExpiredAt: time.Now().Add(time.Minute),
+	}
+	wrongReq := userDTO.VerifyOTPRequest{Email: mockUserEntity1.Email, OTP: "000000"}
+
+	t.Run("invalid-otp", func(t *testing.T) {
+		mockUserRepo.On("GetOTP", mock.Anything, mockUserEntity1.Email).Return(verification, nil).Once()
+		mockUserRepo.On("ClaimOTPAttempt", mock.Anything, verification.ID, OTP_MAX_ATTEMPTS).Return(nil).Once()
+
+		err := testUserUsecase.VerifyOTP(context.Background(), wrongReq)
+
+		assert.Equal(t, utils.ErrOTPInvalid, err)
+	})
+
+	t.Run("locked-after-max-failures", func(t *testing.T) {
+		mockUserRepo.On("GetOTP", mock.Anything, mockUserEntity1.Email).Return(verification, nil).Once()
+		mockUserRepo.On("ClaimOTPAttempt", mock.Anything, verification.ID, OTP_MAX_ATTEMPTS).Return(nil).Once()
+
+		err := testUserUsecase.VerifyOTP(context.Background(), wrongReq)
+
+		assert.ErrorIs(t, err, utils.ErrTooManyRequests)
+	})
+
+	t.Run("locked", func(t *testing.T) {
+		err := testUserUsecase.VerifyOTP(context.Background(), userDTO.VerifyOTPRequest{Email: mockUserEntity1.Email, OTP: "abc123"})
+
+		assert.ErrorIs(t, err, utils.ErrTooManyRequests)
+	})
+}
@@ -0,0 +1,54 @@
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+	"time"
+)
+
+// Set at build time with
+// -ldflags "-X macaiki/pkg/buildinfo.Version=... -X macaiki/pkg/buildinfo.Commit=... -X macaiki/pkg/buildinfo.BuildTime=..."
+var (
+	Version   = "dev"
+	Commit    = ""
+	BuildTime = ""
+)
+
+var startedAt = time.Now()
+
+type Info struct {
+	Version   string    `json:"version"`
+	Commit    string    `json:"commit"`
+	BuildTime string    `json:"buildTime"`
+	GoVersion string    `json:"goVersion"`
+	StartedAt time.Time `json:"startedAt"`
+}
+
+// Get returns the build information, falling back to the VCS stamp the go
+// toolchain embeds when the ldflags were not set
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+		StartedAt: startedAt,
+	}
+
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range bi.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = setting.Value
+				}
+			case "vcs.time":
+				if info.BuildTime == "" {
+					info.BuildTime = setting.Value
+				}
+			}
+		}
+	}
+
+	return info
+}
@@ -1,17 +1,34 @@
 package cloudstorage
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"macaiki/pkg/tracing"
 	"mime/multipart"
+	"net/url"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// JobDeleteImage is the job type used to delete an image in the background
+const JobDeleteImage = "storage.delete_image"
+
+type DeleteImagePayload struct {
+	FileName string
+	DirName  string
+}
+
 type S3 struct {
 	AwsAccessKey string
 	AwsSecretKey string
@@ -35,13 +52,17 @@ func (s *S3) CreateAWSSession() (*session.Session, error) {
 		})
 
 	if err != nil {
-		fmt.Println("AWS session was not created successfully...")
+		return nil, fmt.Errorf("create aws session: %w", err)
 	}
 
-	return sess, err
+	return sess, nil
 }
 
-func (s *S3) UploadImage(fileName string, dirName string, img *multipart.FileHeader) (*s3manager.UploadOutput, error) {
+func (s *S3) UploadImage(ctx context.Context, fileName string, dirName string, img *multipart.FileHeader) (_ *s3manager.UploadOutput, err error) {
+	key := dirName + "/" + fileName + filepath.Ext(img.Filename)
+	ctx, span := s.startSpan(ctx, "s3.upload", key)
+	defer func() { tracing.End(span, err) }()
+
 	src, err := img.Open()
 	if err != nil {
 		return nil, err
@@ -54,9 +75,9 @@ func (s *S3) UploadImage(fileName string, dirName string, img *multipart.FileHea
 	uploader := s3manager.NewUploader(sess)
 
 	// Upload the file to S3.
-	result, err := uploader.Upload(&s3manager.UploadInput{
+	result, err := uploader.UploadWithContext(ctx, &s3manager.UploadInput{
 		Bucket: aws.String(s.BucketName),
-		Key:    aws.String(dirName + "/" + fileName + filepath.Ext(img.Filename)),
+		Key:    aws.String(key),
 		Body:   src,
 		ACL:    aws.String("public-read"),
 	})
@@ -64,21 +85,25 @@ func (s *S3) UploadImage(fileName string, dirName string, img *multipart.FileHea
 	return result, err
 }
 
-func (s *S3) DeleteImage(fileName string, dirName string) error {
+func (s *S3) DeleteImage(ctx context.Context, fileName string, dirName string) (err error) {
+	key := dirName + "/" + fileName
+	ctx, span := s.startSpan(ctx, "s3.delete", key)
+	defer func() { tracing.End(span, err) }()
+
 	sess := session.Must(s.CreateAWSSession())
 	svc := s3.New(sess)
 
-	_, err := svc.DeleteObject(&s3.DeleteObjectInput{
+	_, err = svc.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
 		Bucket: aws.String(s.BucketName),
-		Key:    aws.String(dirName + "/" + fileName),
+		Key:    aws.String(key),
 	})
 	if err != nil {
 		return err
 	}
 
-	err = svc.WaitUntilObjectNotExists(&s3.HeadObjectInput{
+	err = svc.WaitUntilObjectNotExistsWithContext(ctx, &s3.HeadObjectInput{
 		Bucket: aws.String(s.BucketName),
-		Key:    aws.String(dirName + "/" + fileName),
+		Key:    aws.String(key),
 	})
 	if err != nil {
 		return err
@@ -86,3 +111,134 @@ func (s *S3) DeleteImage(fileName string, dirName string) error {
 
 	return nil
 }
+
+// PutObject stores a private object, it is used for files that are only
+// handed out through presigned links
+func (s *S3) PutObject(ctx context.Context, key string, body io.Reader, contentType string) (err error) {
+	ctx, span := s.startSpan(ctx, "s3.put", key)
+	defer func() { tracing.End(span, err) }()
+
+	sess, err := s.CreateAWSSession()
+	if err != nil {
+		return err
+	}
+
+	uploader := s3manager.NewUploader(sess)
+	_, err = uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket:      aws.String(s.BucketName),
+		Key:         aws.String(key),
+		Body:        body,
+		ContentType: aws.String(contentType),
+	})
+	return err
+}
+
+// GetObject opens an object for reading, the caller closes the body
+func (s *S3) GetObject(ctx context.Context, key string) (_ io.ReadCloser, err error) {
+	ctx, span := s.startSpan(ctx, "s3.get", key)
+	defer func() { tracing.End(span, err) }()
+
+	sess, err := s.CreateAWSSession()
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := s3.New(sess).GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.BucketName),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.Body, nil
+}
+
+// DeleteObject removes an object without waiting for the deletion to show
+func (s *S3) DeleteObject(ctx context.Context, key string) (err error) {
+	ctx, span := s.startSpan(ctx, "s3.delete", key)
+	defer func() { tracing.End(span, err) }()
+
+	sess, err := s.CreateAWSSession()
+	if err != nil {
+		return err
+	}
+
+	_, err = s3.New(sess).DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.BucketName),
+		Key:    aws.String(key),
+	})
+	return err
+}
+
+// PresignGetURL returns a link that downloads the object until ttl passes
+func (s *S3) PresignGetURL(key string, ttl time.Duration) (string, error) {
+	sess, err := s.CreateAWSSession()
+	if err != nil {
+		return "", err
+	}
+
+	req, _ := s3.New(sess).GetObjectRequest(&s3.GetObjectInput{
+		Bucket: aws.String(s.BucketName),
+		Key:    aws.String(key),
+	})
+	return req.Presign(ttl)
+}
+
+// KeyFromURL returns the object key of an uploaded file. Profile images are
+// stored as the full upload location while other images only keep the file
+// name, so a value without a scheme is joined with dirName.
+func KeyFromURL(value, dirName string) string {
+	if value == "" {
+		return ""
+	}
+
+	u, err := url.Parse(value)
+	if err != nil || u.Scheme == "" {
+		return dirName + "/" + value
+	}
+	return strings.TrimPrefix(u.Path, "/")
+}
+
+// HandleDeleteImage handles JobDeleteImage jobs
+func (s *S3) HandleDeleteImage(ctx context.Context, payload []byte) error {
+	p := DeleteImagePayload{}
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return err
+	}
+
+	return s.DeleteImage(ctx, p.FileName, p.DirName)
+}
+
+func (s *S3) startSpan(ctx context.Context, name, key string) (context.Context, trace.Span) {
+	return tracing.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("aws.s3.bucket", s.BucketName),
+			attribute.String("aws.s3.key", key),
+		))
+}
+
+// CheckWritable writes and removes a small probe object to make sure the
+// bucket accepts uploads with the configured credentials
+func (s *S3) CheckWritable(ctx context.Context) error {
+	sess, err := s.CreateAWSSession()
+	if err != nil {
+		return err
+	}
+	svc := s3.New(sess)
+	key := aws.String("healthcheck/readyz")
+
+	_, err = svc.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(s.BucketName),
+		Key:    key,
+		Body:   strings.NewReader("ok"),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = svc.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.BucketName),
+		Key:    key,
+	})
+	return err
+}
@@ -0,0 +1,57 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+const slowQueryThreshold = 200 * time.Millisecond
+
+// GormLogger sends GORM's output through slog so SQL errors and slow
+// queries carry the request ID of the context they ran with
+type GormLogger struct {
+	logger *slog.Logger
+}
+
+func NewGormLogger(l *slog.Logger) *GormLogger {
+	return &GormLogger{logger: OrDefault(l)}
+}
+
+// LogMode is a no-op, the level is controlled by the slog handler
+func (gl *GormLogger) LogMode(gormlogger.LogLevel) gormlogger.Interface {
+	return gl
+}
+
+func (gl *GormLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	gl.logger.InfoContext(ctx, fmt.Sprintf(msg, args...))
+}
+
+func (gl *GormLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	gl.logger.WarnContext(ctx, fmt.Sprintf(msg, args...))
+}
+
+func (gl *GormLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	gl.logger.ErrorContext(ctx, fmt.Sprintf(msg, args...))
+}
+
+func (gl *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	elapsed := time.Since(begin)
+
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound):
+		sql, rows := fc()
+		gl.logger.ErrorContext(ctx, "sql error", "err", err, "sql", sql, "rows", rows, "elapsed", elapsed)
+	case elapsed > slowQueryThreshold:
+		sql, rows := fc()
+		gl.logger.WarnContext(ctx, "slow sql", "sql", sql, "rows", rows, "elapsed", elapsed)
+	case gl.logger.Enabled(ctx, slog.LevelDebug):
+		sql, rows := fc()
+		gl.logger.DebugContext(ctx, "sql", "sql", sql, "rows", rows, "elapsed", elapsed)
+	}
+}
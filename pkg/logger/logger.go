@@ -0,0 +1,73 @@
+package logger
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+type contextKey struct{}
+
+var requestIDKey = contextKey{}
+
+// New builds the application logger. A truthy debug value gives human
+// readable text at debug level, anything else JSON at info level.
+func New(debug string) *slog.Logger {
+	return newLogger(os.Stdout, debug)
+}
+
+func newLogger(w io.Writer, debug string) *slog.Logger {
+	var handler slog.Handler
+	if isDebug, _ := strconv.ParseBool(debug); isDebug {
+		handler = slog.NewTextHandler(w, &slog.HandlerOptions{Level: slog.LevelDebug})
+	} else {
+		handler = slog.NewJSONHandler(w, &slog.HandlerOptions{Level: slog.LevelInfo})
+	}
+
+	return slog.New(ContextHandler{handler})
+}
+
+// OrDefault lets constructors accept a nil logger, e.g. in tests
+func OrDefault(l *slog.Logger) *slog.Logger {
+	if l == nil {
+		return slog.Default()
+	}
+	return l
+}
+
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey).(string)
+	return requestID
+}
+
+// ContextHandler adds the request ID and trace ID carried by the context to
+// every record logged with one of the *Context methods
+type ContextHandler struct {
+	slog.Handler
+}
+
+func (h ContextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if requestID := RequestID(ctx); requestID != "" {
+		r.AddAttrs(slog.String("request_id", requestID))
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		r.AddAttrs(slog.String("trace_id", sc.TraceID().String()))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h ContextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return ContextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h ContextHandler) WithGroup(name string) slog.Handler {
+	return ContextHandler{h.Handler.WithGroup(name)}
+}
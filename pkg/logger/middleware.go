@@ -0,0 +1,66 @@
+package logger
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+const HeaderRequestID = "X-Request-ID"
+
+// RequestIDMiddleware reuses the caller's X-Request-ID or generates one,
+// echoes it back and stores it in the request context
+func RequestIDMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			requestID := req.Header.Get(HeaderRequestID)
+			if requestID == "" || len(requestID) > 64 {
+				requestID = uuid.NewString()
+			}
+
+			c.Response().Header().Set(HeaderRequestID, requestID)
+			c.SetRequest(req.WithContext(WithRequestID(req.Context(), requestID)))
+			return next(c)
+		}
+	}
+}
+
+// RequestLoggerMiddleware logs one line per request, server errors at error
+// level and everything else at info
+func RequestLoggerMiddleware(l *slog.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			err := next(c)
+			if err != nil {
+				// let echo write the error so the logged status is the real one
+				c.Error(err)
+			}
+
+			req := c.Request()
+			status := c.Response().Status
+			attrs := []any{
+				"method", req.Method,
+				"route", c.Path(),
+				"uri", req.RequestURI,
+				"status", status,
+				"latency", time.Since(start),
+				"remote_ip", c.RealIP(),
+			}
+			if err != nil {
+				attrs = append(attrs, "err", err)
+			}
+
+			level := slog.LevelInfo
+			if status >= 500 {
+				level = slog.LevelError
+			}
+			l.Log(req.Context(), level, "http request", attrs...)
+
+			return nil
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package mailer
+
+import (
+	"context"
+	"sync"
+)
+
+// Capture is an in-memory Mailer that records every message instead of
+// delivering it, so tests can assert on the mail that would have been sent.
+type Capture struct {
+	mu       sync.Mutex
+	messages []Message
+	err      error
+}
+
+func NewCapture() *Capture {
+	return &Capture{}
+}
+
+func (c *Capture) Send(ctx context.Context, msg Message) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.err != nil {
+		return c.err
+	}
+
+	c.messages = append(c.messages, msg)
+	return nil
+}
+
+// FailWith makes every following Send return err, pass nil to recover
+func (c *Capture) FailWith(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.err = err
+}
+
+func (c *Capture) Messages() []Message {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	messages := make([]Message, len(c.messages))
+	copy(messages, c.messages)
+	return messages
+}
+
+func (c *Capture) Last() (Message, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.messages) == 0 {
+		return Message{}, false
+	}
+	return c.messages[len(c.messages)-1], true
+}
+
+func (c *Capture) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.messages = nil
+}
@@ -0,0 +1,20 @@
+package mailer
+
+import "context"
+
+// Message is a fully rendered email ready to be delivered
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers a rendered message to its recipient
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// Outbox queues templated emails for delivery
+type Outbox interface {
+	Enqueue(ctx context.Context, to, template string, data interface{}) error
+}
@@ -0,0 +1,76 @@
+package mailer
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	OutboxStatusPending = "pending"
+	OutboxStatusSending = "sending"
+	OutboxStatusSent    = "sent"
+	OutboxStatusFailed  = "failed"
+)
+
+// OutboxMessage is a persisted email waiting to be drained by the Worker
+type OutboxMessage struct {
+	gorm.Model
+	Recipient     string `gorm:"size:255"`
+	Template      string `gorm:"size:64"`
+	Subject       string
+	Body          string    `gorm:"type:text"`
+	Status        string    `gorm:"size:16;index:idx_outbox_due,priority:1"`
+	NextAttemptAt time.Time `gorm:"index:idx_outbox_due,priority:2"`
+	Attempts      int
+	LastError     string `gorm:"type:text"`
+	SentAt        *time.Time
+}
+
+// DBOutbox renders the template up front and stores the message so it
+// survives restarts, delivery is done by the Worker.
+type DBOutbox struct {
+	db        *gorm.DB
+	templates *Templates
+}
+
+func NewDBOutbox(db *gorm.DB, templates *Templates) *DBOutbox {
+	return &DBOutbox{db: db, templates: templates}
+}
+
+func (o *DBOutbox) Enqueue(ctx context.Context, to, template string, data interface{}) error {
+	msg, err := o.templates.Message(to, template, data)
+	if err != nil {
+		return err
+	}
+
+	return o.db.WithContext(ctx).Create(&OutboxMessage{
+		Recipient:     msg.To,
+		Template:      template,
+		Subject:       msg.Subject,
+		Body:          msg.Body,
+		Status:        OutboxStatusPending,
+		NextAttemptAt: time.Now(),
+	}).Error
+}
+
+// SyncOutbox delivers straight through the Mailer, it is meant for tests
+// (together with Capture) and local runs without a database worker.
+type SyncOutbox struct {
+	templates *Templates
+	mailer    Mailer
+}
+
+func NewSyncOutbox(templates *Templates, mailer Mailer) *SyncOutbox {
+	return &SyncOutbox{templates: templates, mailer: mailer}
+}
+
+func (o *SyncOutbox) Enqueue(ctx context.Context, to, template string, data interface{}) error {
+	msg, err := o.templates.Message(to, template, data)
+	if err != nil {
+		return err
+	}
+
+	return o.mailer.Send(ctx, msg)
+}
@@ -0,0 +1,197 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"macaiki/pkg/tracing"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gopkg.in/gomail.v2"
+)
+
+const (
+	TLSModeStartTLS = "starttls"
+	TLSModeImplicit = "tls"
+)
+
+type SMTPConfig struct {
+	Host       string
+	Port       int
+	Username   string
+	Password   string
+	From       string
+	TLSMode    string
+	SkipVerify bool
+}
+
+type SMTPMailer struct {
+	config    SMTPConfig
+	tlsConfig *tls.Config
+}
+
+func NewSMTPMailer(config SMTPConfig) *SMTPMailer {
+	if config.From == "" {
+		config.From = config.Username
+	}
+
+	return &SMTPMailer{
+		config: config,
+		tlsConfig: &tls.Config{
+			ServerName:         config.Host,
+			InsecureSkipVerify: config.SkipVerify,
+		},
+	}
+}
+
+// Send delivers msg over a new connection. The connection is bound to ctx,
+// its deadline applies to every exchange with the server and cancelling it
+// aborts the delivery.
+func (sm *SMTPMailer) Send(ctx context.Context, msg Message) (err error) {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	_, span := tracing.Start(ctx, "smtp.send", trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("smtp.host", sm.config.Host)))
+	defer func() { tracing.End(span, err) }()
+
+	m := gomail.NewMessage()
+	m.SetHeader("From", sm.config.From)
+	m.SetHeader("To", msg.To)
+	m.SetHeader("Subject", msg.Subject)
+	m.SetBody("text/html", msg.Body)
+
+	if err := sm.send(ctx, msg.To, m); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		return fmt.Errorf("smtp send to %s:%d: %w", sm.config.Host, sm.config.Port, err)
+	}
+
+	return nil
+}
+
+func (sm *SMTPMailer) send(ctx context.Context, to string, m *gomail.Message) error {
+	conn, err := sm.dial(ctx)
+	if err != nil {
+		return err
+	}
+	// closing the connection unblocks whatever exchange is in flight
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			conn.Close()
+			return err
+		}
+	}
+
+	c, err := smtp.NewClient(conn, sm.config.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	// implicit TLS (usually port 465), otherwise upgrade with STARTTLS when offered
+	if sm.config.TLSMode != TLSModeImplicit {
+		if ok, _ := c.Extension("STARTTLS"); ok {
+			if err := c.StartTLS(sm.tlsConfig); err != nil {
+				return err
+			}
+		}
+	}
+
+	if sm.config.Username != "" {
+		if ok, auths := c.Extension("AUTH"); ok {
+			if err := c.Auth(sm.auth(auths)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := c.Mail(sm.config.From); err != nil {
+		return err
+	}
+	if err := c.Rcpt(to); err != nil {
+		return err
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := m.WriteTo(w); err != nil {
+		w.Close()
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return c.Quit()
+}
+
+func (sm *SMTPMailer) dial(ctx context.Context) (net.Conn, error) {
+	addr := net.JoinHostPort(sm.config.Host, strconv.Itoa(sm.config.Port))
+	if sm.config.TLSMode == TLSModeImplicit {
+		d := tls.Dialer{Config: sm.tlsConfig}
+		return d.DialContext(ctx, "tcp", addr)
+	}
+
+	d := net.Dialer{}
+	return d.DialContext(ctx, "tcp", addr)
+}
+
+// auth picks the mechanism the same way gomail does, preferring CRAM-MD5 and
+// falling back to LOGIN only when PLAIN is not offered
+func (sm *SMTPMailer) auth(mechanisms string) smtp.Auth {
+	switch {
+	case strings.Contains(mechanisms, "CRAM-MD5"):
+		return smtp.CRAMMD5Auth(sm.config.Username, sm.config.Password)
+	case strings.Contains(mechanisms, "LOGIN") && !strings.Contains(mechanisms, "PLAIN"):
+		return &loginAuth{username: sm.config.Username, password: sm.config.Password, host: sm.config.Host}
+	default:
+		return smtp.PlainAuth("", sm.config.Username, sm.config.Password, sm.config.Host)
+	}
+}
+
+// loginAuth implements the LOGIN mechanism, which net/smtp does not provide
+type loginAuth struct {
+	username string
+	password string
+	host     string
+}
+
+func (a *loginAuth) Start(server *smtp.ServerInfo) (string, []byte, error) {
+	if !server.TLS && server.Name != "localhost" && server.Name != "127.0.0.1" {
+		return "", nil, errors.New("smtp: unencrypted connection")
+	}
+	if server.Name != a.host {
+		return "", nil, errors.New("smtp: wrong host name")
+	}
+	return "LOGIN", nil, nil
+}
+
+func (a *loginAuth) Next(fromServer []byte, more bool) ([]byte, error) {
+	if !more {
+		return nil, nil
+	}
+
+	switch {
+	case bytes.EqualFold(fromServer, []byte("Username:")):
+		return []byte(a.username), nil
+	case bytes.EqualFold(fromServer, []byte("Password:")):
+		return []byte(a.password), nil
+	default:
+		return nil, fmt.Errorf("smtp: unexpected server challenge: %s", fromServer)
+	}
+}
@@ -0,0 +1,109 @@
+package mailer
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// serveSMTP answers a single session with the bare minimum of the protocol
+// and returns the DATA it received
+func serveSMTP(ln net.Listener) <-chan string {
+	data := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+		reply("220 localhost ready")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			switch cmd := strings.ToUpper(strings.TrimSpace(line)); {
+			case strings.HasPrefix(cmd, "EHLO"):
+				reply("250 localhost")
+			case cmd == "DATA":
+				reply("354 go ahead")
+				body := strings.Builder{}
+				for {
+					line, err := r.ReadString('\n')
+					if err != nil || line == ".\r\n" {
+						break
+					}
+					body.WriteString(line)
+				}
+				data <- body.String()
+				reply("250 queued")
+			case cmd == "QUIT":
+				reply("221 bye")
+				return
+			default:
+				reply("250 ok")
+			}
+		}
+	}()
+	return data
+}
+
+func newTestSMTPMailer(ln net.Listener) *SMTPMailer {
+	addr := ln.Addr().(*net.TCPAddr)
+	return NewSMTPMailer(SMTPConfig{Host: "127.0.0.1", Port: addr.Port, From: "noreply@macaiki.com"})
+}
+
+func TestSMTPSend(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+		defer ln.Close()
+		data := serveSMTP(ln)
+
+		err = newTestSMTPMailer(ln).Send(context.Background(), Message{To: "jane.doe@example.com", Subject: "Hello", Body: "<p>Hi</p>"})
+		assert.NoError(t, err)
+
+		body := <-data
+		assert.Contains(t, body, "To: jane.doe@example.com")
+		assert.Contains(t, body, "<p>Hi</p>")
+	})
+
+	t.Run("server-hangs", func(t *testing.T) {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+		defer ln.Close()
+		go func() {
+			conn, err := ln.Accept()
+			if err == nil {
+				// never greet the client, wait for it to hang up
+				io.Copy(io.Discard, conn)
+				conn.Close()
+			}
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		err = newTestSMTPMailer(ln).Send(ctx, Message{To: "jane.doe@example.com", Subject: "Hello", Body: "Hi"})
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), 500*time.Millisecond)
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := NewSMTPMailer(SMTPConfig{Host: "127.0.0.1", Port: 1}).Send(ctx, Message{To: "jane.doe@example.com"})
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
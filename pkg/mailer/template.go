@@ -0,0 +1,89 @@
+package mailer
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html"
+	"html/template"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+//go:embed templates/*.html
+var templateFS embed.FS
+
+const layoutFile = "layout.html"
+
+// Templates holds the named HTML email templates. Every template defines a
+// "subject" and a "content" block, the content is wrapped by layout.html.
+type Templates struct {
+	set map[string]*template.Template
+}
+
+func LoadTemplates() (*Templates, error) {
+	return loadTemplates(templateFS, "templates")
+}
+
+func MustLoadTemplates() *Templates {
+	t, err := LoadTemplates()
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func loadTemplates(fsys fs.FS, dir string) (*Templates, error) {
+	files, err := fs.Glob(fsys, path.Join(dir, "*.html"))
+	if err != nil {
+		return nil, err
+	}
+
+	layout := path.Join(dir, layoutFile)
+	set := map[string]*template.Template{}
+	for _, file := range files {
+		if file == layout {
+			continue
+		}
+
+		tmpl, err := template.ParseFS(fsys, layout, file)
+		if err != nil {
+			return nil, fmt.Errorf("parse mail template %s: %w", file, err)
+		}
+
+		name := strings.TrimSuffix(path.Base(file), ".html")
+		set[name] = tmpl
+	}
+
+	return &Templates{set: set}, nil
+}
+
+// Render executes the named template and returns the subject and HTML body
+func (t *Templates) Render(name string, data interface{}) (string, string, error) {
+	tmpl, ok := t.set[name]
+	if !ok {
+		return "", "", fmt.Errorf("mail template %q not found", name)
+	}
+
+	var subject, body bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return "", "", err
+	}
+	if err := tmpl.ExecuteTemplate(&body, "layout", data); err != nil {
+		return "", "", err
+	}
+
+	// subjects are plain text header values, undo the HTML escaping
+	return strings.TrimSpace(html.UnescapeString(subject.String())), body.String(), nil
+}
+
+// Message renders the named template into a Message addressed to `to`
+func (t *Templates) Message(to, name string, data interface{}) (Message, error) {
+	subject, body, err := t.Render(name, data)
+	if err != nil {
+		return Message{}, err
+	}
+
+	return Message{To: to, Subject: subject, Body: body}, nil
+}
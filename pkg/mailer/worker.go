@@ -0,0 +1,175 @@
+package mailer
+
+import (
+	"context"
+	"log/slog"
+	"macaiki/pkg/logger"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WorkerConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	MaxAttempts  int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	// SendTimeout bounds a single delivery. A claimed batch is leased for
+	// SendTimeout per message, since the messages are sent one after another,
+	// before another worker may pick them up again.
+	SendTimeout time.Duration
+}
+
+func DefaultWorkerConfig() WorkerConfig {
+	return WorkerConfig{
+		PollInterval: 5 * time.Second,
+		BatchSize:    20,
+		MaxAttempts:  8,
+		BaseBackoff:  30 * time.Second,
+		MaxBackoff:   time.Hour,
+		SendTimeout:  time.Minute,
+	}
+}
+
+// Worker drains the mail outbox table, retrying failed deliveries with
+// exponential backoff until MaxAttempts is reached.
+type Worker struct {
+	db     *gorm.DB
+	mailer Mailer
+	config WorkerConfig
+	logger *slog.Logger
+}
+
+func NewWorker(db *gorm.DB, mailer Mailer, config WorkerConfig, log *slog.Logger) *Worker {
+	return &Worker{db: db, mailer: mailer, config: config, logger: logger.OrDefault(log)}
+}
+
+// Run polls the outbox until ctx is cancelled
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.Drain(ctx); err != nil && ctx.Err() == nil {
+			w.logger.Error("failed to drain mail outbox", "err", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Drain delivers every message that is currently due
+func (w *Worker) Drain(ctx context.Context) error {
+	for ctx.Err() == nil {
+		messages, err := w.claim(ctx)
+		if err != nil {
+			return err
+		}
+		if len(messages) == 0 {
+			return nil
+		}
+
+		for _, msg := range messages {
+			w.deliver(ctx, msg)
+		}
+	}
+
+	return nil
+}
+
+// claim leases a batch of due messages and counts the attempt, the attempt
+// number identifies the lease when the result is recorded. Rows stuck in
+// "sending" (e.g. the process died mid delivery) become due again once their
+// lease runs out.
+func (w *Worker) claim(ctx context.Context) ([]OutboxMessage, error) {
+	messages := []OutboxMessage{}
+	now := time.Now()
+
+	err := w.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status IN ? AND next_attempt_at <= ?", []string{OutboxStatusPending, OutboxStatusSending}, now).
+			Order("id").
+			Limit(w.config.BatchSize).
+			Find(&messages)
+		if res.Error != nil || len(messages) == 0 {
+			return res.Error
+		}
+
+		ids := make([]uint, 0, len(messages))
+		for _, msg := range messages {
+			ids = append(ids, msg.ID)
+		}
+
+		err := tx.Model(&OutboxMessage{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+			"status":          OutboxStatusSending,
+			"next_attempt_at": now.Add(time.Duration(len(messages)) * w.config.SendTimeout),
+			"attempts":        gorm.Expr("attempts + 1"),
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		for i := range messages {
+			messages[i].Attempts++
+		}
+		return nil
+	})
+
+	return messages, err
+}
+
+func (w *Worker) deliver(ctx context.Context, msg OutboxMessage) {
+	sendCtx, cancel := context.WithTimeout(ctx, w.config.SendTimeout)
+	defer cancel()
+
+	err := w.mailer.Send(sendCtx, Message{To: msg.Recipient, Subject: msg.Subject, Body: msg.Body})
+	attempts := msg.Attempts
+
+	updates := map[string]interface{}{}
+	if err == nil {
+		now := time.Now()
+		updates["status"] = OutboxStatusSent
+		updates["sent_at"] = &now
+		updates["last_error"] = ""
+	} else if attempts >= w.config.MaxAttempts {
+		w.logger.Warn("giving up on mail", "message_id", msg.ID, "template", msg.Template, "attempts", attempts, "err", err)
+		updates["status"] = OutboxStatusFailed
+		updates["last_error"] = err.Error()
+	} else {
+		updates["status"] = OutboxStatusPending
+		updates["next_attempt_at"] = time.Now().Add(w.backoff(attempts))
+		updates["last_error"] = err.Error()
+	}
+
+	// the delivery already happened, record it even if ctx is being cancelled.
+	// Once the lease ran out another worker may have claimed the message, its
+	// result wins.
+	res := w.db.Model(&OutboxMessage{}).
+		Where("id = ? AND status = ? AND attempts = ?", msg.ID, OutboxStatusSending, msg.Attempts).
+		Updates(updates)
+	if res.Error != nil {
+		w.logger.Error("failed to update mail outbox message", "message_id", msg.ID, "err", res.Error)
+		return
+	}
+	if res.RowsAffected == 0 {
+		w.logger.Warn("mail outbox lease lost", "message_id", msg.ID, "attempts", msg.Attempts)
+	}
+}
+
+func (w *Worker) backoff(attempts int) time.Duration {
+	delay := w.config.BaseBackoff
+	for i := 1; i < attempts && delay < w.config.MaxBackoff; i++ {
+		delay *= 2
+	}
+
+	if delay > w.config.MaxBackoff {
+		return w.config.MaxBackoff
+	}
+	return delay
+}
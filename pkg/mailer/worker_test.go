@@ -0,0 +1,119 @@
+package mailer
+
+import (
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// slowMailer ignores ctx and takes longer than the lease it was given
+type slowMailer struct {
+	delay time.Duration
+	sent  []Message
+}
+
+func (m *slowMailer) Send(ctx context.Context, msg Message) error {
+	time.Sleep(m.delay)
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+// leaseUntil matches a lease that ends at least d from now
+type leaseUntil time.Duration
+
+func (d leaseUntil) Match(v driver.Value) bool {
+	t, ok := v.(time.Time)
+	return ok && !t.Before(time.Now().Add(time.Duration(d)-time.Second))
+}
+
+func newTestWorker(t *testing.T, mailer Mailer, config WorkerConfig) (*Worker, sqlmock.Sqlmock) {
+	mockedDB, mockObj, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	t.Cleanup(func() { mockedDB.Close() })
+
+	db, err := gorm.Open(mysql.Dialector{
+		Config: &mysql.Config{
+			Conn:                      mockedDB,
+			SkipInitializeWithVersion: true,
+		},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening gorm", err)
+	}
+
+	return NewWorker(db, mailer, config, nil), mockObj
+}
+
+func TestWorkerDrain(t *testing.T) {
+	t.Run("lease-expired-during-slow-send", func(t *testing.T) {
+		config := DefaultWorkerConfig()
+		config.SendTimeout = 10 * time.Millisecond
+		mailer := &slowMailer{delay: 30 * time.Millisecond}
+		w, mockObj := newTestWorker(t, mailer, config)
+
+		rows := sqlmock.NewRows([]string{"id", "recipient", "subject", "body", "status", "attempts"}).
+			AddRow(1, "jane.doe@example.com", "Hello", "Hi", OutboxStatusPending, 0).
+			AddRow(2, "john.doe@example.com", "Hello", "Hi", OutboxStatusPending, 2)
+
+		mockObj.ExpectBegin()
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `outbox_messages`")).WillReturnRows(rows)
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `outbox_messages` SET `attempts`=attempts + 1,`next_attempt_at`=?,`status`=?")).
+			WithArgs(leaseUntil(0), OutboxStatusSending, sqlmock.AnyArg(), 1, 2).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mockObj.ExpectCommit()
+
+		// the first send outlives the lease of the whole batch and another
+		// worker claims the message again, its result must not be overwritten
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `outbox_messages` SET")).
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), OutboxStatusSent, sqlmock.AnyArg(), 1, OutboxStatusSending, 1).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mockObj.ExpectCommit()
+		mockObj.ExpectBegin()
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `outbox_messages` SET")).
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), OutboxStatusSent, sqlmock.AnyArg(), 2, OutboxStatusSending, 3).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockObj.ExpectCommit()
+
+		mockObj.ExpectBegin()
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `outbox_messages`")).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mockObj.ExpectCommit()
+
+		err := w.Drain(context.Background())
+		assert.NoError(t, err)
+		assert.Len(t, mailer.sent, 2)
+		assert.NoError(t, mockObj.ExpectationsWereMet())
+	})
+
+	t.Run("batch-leased-per-message", func(t *testing.T) {
+		config := DefaultWorkerConfig()
+		w, mockObj := newTestWorker(t, &slowMailer{}, config)
+
+		rows := sqlmock.NewRows([]string{"id", "recipient", "status", "attempts"})
+		for i := 1; i <= 3; i++ {
+			rows.AddRow(i, "jane.doe@example.com", OutboxStatusPending, 0)
+		}
+
+		mockObj.ExpectBegin()
+		mockObj.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `outbox_messages`")).WillReturnRows(rows)
+		mockObj.ExpectExec(regexp.QuoteMeta("UPDATE `outbox_messages` SET `attempts`=attempts + 1,`next_attempt_at`=?,`status`=?")).
+			WithArgs(leaseUntil(3*config.SendTimeout), OutboxStatusSending, sqlmock.AnyArg(), 1, 2, 3).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mockObj.ExpectCommit()
+
+		messages, err := w.claim(context.Background())
+		assert.NoError(t, err)
+		assert.Len(t, messages, 3)
+		assert.Equal(t, 1, messages[0].Attempts)
+		assert.NoError(t, mockObj.ExpectationsWereMet())
+	})
+}
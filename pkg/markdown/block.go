@@ -0,0 +1,131 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+)
+
+type blockKind int
+
+const (
+	paragraphBlock blockKind = iota
+	quoteBlock
+	listBlock
+	orderedListBlock
+	codeBlock
+)
+
+type block struct {
+	kind blockKind
+	// text is the content of a code block
+	text     string
+	inlines  []inline
+	children []block
+	items    [][]inline
+}
+
+var (
+	bulletItem  = regexp.MustCompile(`^[-*+]\s+(.*)$`)
+	orderedItem = regexp.MustCompile(`^\d{1,9}[.)]\s+(.*)$`)
+)
+
+func isFence(line string) bool {
+	return strings.HasPrefix(strings.TrimSpace(line), "```")
+}
+
+func isQuote(line string) bool {
+	return strings.HasPrefix(strings.TrimSpace(line), ">")
+}
+
+// listItem reports the content of a list item line and whether the list is
+// ordered, ok is false for any other line
+func listItem(line string) (content string, ordered, ok bool) {
+	line = strings.TrimSpace(line)
+	if m := bulletItem.FindStringSubmatch(line); m != nil {
+		return m[1], false, true
+	}
+	if m := orderedItem.FindStringSubmatch(line); m != nil {
+		return m[1], true, true
+	}
+	return "", false, false
+}
+
+// startsBlock reports whether line opens a block other than a paragraph
+func startsBlock(line string, depth int) bool {
+	if isFence(line) || (isQuote(line) && depth < maxQuoteDepth) {
+		return true
+	}
+	_, _, ok := listItem(line)
+	return ok
+}
+
+func parseBlocks(lines []string, depth int) []block {
+	var blocks []block
+
+	for i := 0; i < len(lines); {
+		line := lines[i]
+
+		switch {
+		case strings.TrimSpace(line) == "":
+			i++
+
+		case isFence(line):
+			end := i + 1
+			for end < len(lines) && !isFence(lines[end]) {
+				end++
+			}
+			blocks = append(blocks, block{kind: codeBlock, text: strings.Join(lines[i+1:end], "\n")})
+			// an unclosed fence runs to the end of the body
+			i = end + 1
+
+		case isQuote(line) && depth < maxQuoteDepth:
+			var quoted []string
+			for ; i < len(lines) && isQuote(lines[i]); i++ {
+				content := strings.TrimPrefix(strings.TrimSpace(lines[i]), ">")
+				quoted = append(quoted, strings.TrimPrefix(content, " "))
+			}
+			blocks = append(blocks, block{kind: quoteBlock, children: parseBlocks(quoted, depth+1)})
+
+		default:
+			if _, ordered, ok := listItem(line); ok {
+				list := block{kind: listBlock}
+				if ordered {
+					list.kind = orderedListBlock
+				}
+
+				var items []string
+				for i < len(lines) {
+					content, itemOrdered, isItem := listItem(lines[i])
+					if isItem && itemOrdered == ordered {
+						items = append(items, content)
+						i++
+						continue
+					}
+					// an indented line carries on the previous item
+					if !isItem && strings.TrimSpace(lines[i]) != "" && strings.TrimLeft(lines[i], " \t") != lines[i] {
+						items[len(items)-1] += "\n" + strings.TrimSpace(lines[i])
+						i++
+						continue
+					}
+					break
+				}
+				for _, item := range items {
+					list.items = append(list.items, parseInline(item, 0, false))
+				}
+				blocks = append(blocks, list)
+				continue
+			}
+
+			var paragraph []string
+			for ; i < len(lines) && strings.TrimSpace(lines[i]) != ""; i++ {
+				if len(paragraph) > 0 && startsBlock(lines[i], depth) {
+					break
+				}
+				paragraph = append(paragraph, strings.TrimSpace(lines[i]))
+			}
+			blocks = append(blocks, block{kind: paragraphBlock, inlines: parseInline(strings.Join(paragraph, "\n"), 0, false)})
+		}
+	}
+
+	return blocks
+}
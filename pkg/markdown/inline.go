@@ -0,0 +1,250 @@
+package markdown
+
+import (
+	"net/url"
+	"strings"
+)
+
+type inlineKind int
+
+const (
+	textInline inlineKind = iota
+	breakInline
+	codeInline
+	strongInline
+	emInline
+	spoilerInline
+	linkInline
+	// labelInline is the text of a link whose URL was dropped
+	labelInline
+)
+
+type inline struct {
+	kind     inlineKind
+	text     string
+	href     string
+	children []inline
+}
+
+// pairedKinds maps the two character delimiters onto what they wrap
+var pairedKinds = map[string]inlineKind{
+	"**": strongInline,
+	"__": strongInline,
+	"||": spoilerInline,
+}
+
+func isAlphanumeric(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+func isPunctuation(c byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) >= 0
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n'
+}
+
+// closing finds delim after from and reports where it starts. The wrapped
+// text may not be empty or padded with spaces, a lone * or _ skips doubled
+// markers and _ only counts outside words, so snake_case stays as is.
+func closing(s string, from int, delim string) (int, bool) {
+	if from >= len(s) || isSpace(s[from]) {
+		return 0, false
+	}
+
+	for j := from; j < len(s); j++ {
+		if s[j] == '\\' {
+			j++
+			continue
+		}
+		if !strings.HasPrefix(s[j:], delim) {
+			continue
+		}
+		if len(delim) == 1 && j+1 < len(s) && s[j+1] == delim[0] {
+			j++
+			continue
+		}
+		end := j + len(delim)
+		// in a run like *** the outer pair closes last
+		if len(delim) == 2 && end < len(s) && s[end] == delim[0] {
+			continue
+		}
+		if j == from || isSpace(s[j-1]) {
+			continue
+		}
+		if delim[0] == '_' && end < len(s) && isAlphanumeric(s[end]) {
+			continue
+		}
+		return j, true
+	}
+
+	return 0, false
+}
+
+// safeURL keeps the web and mail links, everything else like javascript:
+// or relative paths is dropped
+func safeURL(raw string) (string, bool) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", false
+	}
+
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https":
+		return u.String(), u.Host != ""
+	case "mailto":
+		return u.String(), u.Opaque != ""
+	}
+	return "", false
+}
+
+// autolink reads the bare web link at the start of s and reports its
+// length, trailing punctuation is left to the sentence
+func autolink(s string) (string, int) {
+	lower := strings.ToLower(s)
+	if !strings.HasPrefix(lower, "http://") && !strings.HasPrefix(lower, "https://") {
+		return "", 0
+	}
+
+	end := strings.IndexAny(s, " \t\n<>\"")
+	if end < 0 {
+		end = len(s)
+	}
+	link := strings.TrimRight(s[:end], ".,;:!?'*_|)")
+
+	href, ok := safeURL(link)
+	if !ok {
+		return "", 0
+	}
+	return href, len(link)
+}
+
+// parseInline parses the spans of a paragraph or list item, inLink keeps
+// link text from holding another link
+func parseInline(s string, depth int, inLink bool) []inline {
+	var out []inline
+	var text strings.Builder
+
+	flush := func() {
+		if text.Len() > 0 {
+			out = append(out, inline{kind: textInline, text: text.String()})
+			text.Reset()
+		}
+	}
+	push := func(node inline) {
+		flush()
+		out = append(out, node)
+	}
+
+	for i := 0; i < len(s); {
+		c := s[i]
+
+		if c == '\\' && i+1 < len(s) && isPunctuation(s[i+1]) {
+			text.WriteByte(s[i+1])
+			i += 2
+			continue
+		}
+
+		if c == '\n' {
+			push(inline{kind: breakInline})
+			i++
+			continue
+		}
+
+		if c == '`' {
+			n := len(s[i:]) - len(strings.TrimLeft(s[i:], "`"))
+			delim := s[i : i+n]
+			if end := strings.Index(s[i+n:], delim); end > 0 {
+				push(inline{kind: codeInline, text: s[i+n : i+n+end]})
+				i += n + end + n
+				continue
+			}
+			text.WriteString(delim)
+			i += n
+			continue
+		}
+
+		if depth < maxInlineDepth && i+1 < len(s) {
+			delim := s[i : i+2]
+			if kind, ok := pairedKinds[delim]; ok && (delim != "__" || i == 0 || !isAlphanumeric(s[i-1])) {
+				if end, ok := closing(s, i+2, delim); ok {
+					push(inline{kind: kind, children: parseInline(s[i+2:end], depth+1, inLink)})
+					i = end + 2
+					continue
+				}
+			}
+		}
+
+		if depth < maxInlineDepth && (c == '*' || c == '_') && (c == '*' || i == 0 || !isAlphanumeric(s[i-1])) {
+			if end, ok := closing(s, i+1, string(c)); ok {
+				push(inline{kind: emInline, children: parseInline(s[i+1:end], depth+1, inLink)})
+				i = end + 1
+				continue
+			}
+		}
+
+		if c == '[' && !inLink && depth < maxInlineDepth {
+			if node, n, ok := parseLink(s[i:], depth); ok {
+				push(node)
+				i += n
+				continue
+			}
+		}
+
+		if (c == 'h' || c == 'H') && !inLink && (i == 0 || !isAlphanumeric(s[i-1])) {
+			if href, n := autolink(s[i:]); n > 0 {
+				push(inline{kind: linkInline, href: href, children: []inline{{kind: textInline, text: s[i : i+n]}}})
+				i += n
+				continue
+			}
+		}
+
+		text.WriteByte(c)
+		i++
+	}
+	flush()
+
+	return out
+}
+
+// parseLink reads a [text](url) link at the start of s. A link to an unsafe
+// URL keeps its text without the link.
+func parseLink(s string, depth int) (inline, int, bool) {
+	label := 1
+	for ; label < len(s) && s[label] != ']'; label++ {
+		if s[label] == '\\' {
+			label++
+		}
+	}
+	if label == 1 || label >= len(s) || !strings.HasPrefix(s[label:], "](") {
+		return inline{}, 0, false
+	}
+	// parentheses inside the URL have to be balanced
+	end, open := -1, 0
+	for j, c := range s[label+2:] {
+		if c == '(' {
+			open++
+		} else if c == ')' {
+			if open == 0 {
+				end = j
+				break
+			}
+			open--
+		}
+	}
+	if end < 0 {
+		return inline{}, 0, false
+	}
+	target := strings.TrimSpace(s[label+2 : label+2+end])
+	if target == "" || strings.ContainsAny(target, " \t\n") {
+		return inline{}, 0, false
+	}
+
+	node := inline{kind: labelInline, children: parseInline(s[1:label], depth+1, true)}
+	if href, ok := safeURL(target); ok {
+		node.kind = linkInline
+		node.href = href
+	}
+	return node, label + 2 + end + 1, true
+}
@@ -0,0 +1,27 @@
+// Package markdown renders the restricted Markdown dialect of thread and
+// comment bodies: bold, italics, links, lists, code, quotes and spoilers.
+//
+// The renderer never passes source HTML through, every piece of text is
+// escaped and only the tags it writes itself end up in the output, so the
+// HTML is safe to embed without a separate sanitizer.
+package markdown
+
+import "strings"
+
+const (
+	// maxQuoteDepth and maxInlineDepth bound the recursion on nested quotes
+	// and emphasis, deeper markers are kept as text
+	maxQuoteDepth  = 8
+	maxInlineDepth = 8
+
+	// SpoilerText stands in for spoilers in the plain text rendering
+	SpoilerText = "[spoiler]"
+)
+
+// Render returns the sanitized HTML and the plain text of a Markdown source
+func Render(source string) (string, string) {
+	source = strings.ReplaceAll(source, "\r\n", "\n")
+	blocks := parseBlocks(strings.Split(source, "\n"), 0)
+
+	return renderHTML(blocks), renderText(blocks)
+}
@@ -0,0 +1,82 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderHTML(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		html   string
+	}{
+		{"paragraphs", "one\ntwo\n\nthree", "<p>one<br>two</p><p>three</p>"},
+		{"emphasis", "**bold** and *italic* and __also__ _too_", "<p><strong>bold</strong> and <em>italic</em> and <strong>also</strong> <em>too</em></p>"},
+		{"nested emphasis", "**bold *and italic***", "<p><strong>bold <em>and italic</em></strong></p>"},
+		{"snake case", "snake_case_name and 2 * 3 * 4", "<p>snake_case_name and 2 * 3 * 4</p>"},
+		{"spoiler", "the end: ||they win||", `<p>the end: <span class="spoiler">they win</span></p>`},
+		{"inline code", "run `go test ./...` **now**", "<p>run <code>go test ./...</code> <strong>now</strong></p>"},
+		{"code keeps markers", "`**not bold**`", "<p><code>**not bold**</code></p>"},
+		{"code block", "```\n<b>x</b>\n**y**\n```", "<pre><code>&lt;b&gt;x&lt;/b&gt;\n**y**</code></pre>"},
+		{"bullet list", "- one\n- **two**\n  more", "<ul><li>one</li><li><strong>two</strong><br>more</li></ul>"},
+		{"ordered list", "intro\n1. one\n2) two", "<p>intro</p><ol><li>one</li><li>two</li></ol>"},
+		{"quote", "> quoted\n> > nested\n\nafter", "<blockquote><p>quoted</p><blockquote><p>nested</p></blockquote></blockquote><p>after</p>"},
+		{"link", "[docs](https://example.com/a?b=1&c=2)", `<p><a href="https://example.com/a?b=1&amp;c=2" rel="nofollow noopener noreferrer ugc">docs</a></p>`},
+		{"autolink", "see https://example.com/post.", `<p>see <a href="https://example.com/post" rel="nofollow noopener noreferrer ugc">https://example.com/post</a>.</p>`},
+		{"mailto", "[mail](mailto:team@example.com)", `<p><a href="mailto:team@example.com" rel="nofollow noopener noreferrer ugc">mail</a></p>`},
+		{"escapes", `\*not italic\*`, "<p>*not italic*</p>"},
+		{"unclosed", "**open and `tick", "<p>**open and `tick</p>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			html, _ := Render(tt.source)
+			assert.Equal(t, tt.html, html)
+		})
+	}
+}
+
+func TestRenderSanitizes(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		html   string
+	}{
+		{"raw html", `<script>alert(1)</script><img src=x onerror="alert(1)">`, "<p>&lt;script&gt;alert(1)&lt;/script&gt;&lt;img src=x onerror=&#34;alert(1)&#34;&gt;</p>"},
+		{"javascript link", "[click](javascript:alert(1))", "<p>click</p>"},
+		{"data link", "[click](data:text/html;base64,PHNjcmlwdD4=)", "<p>click</p>"},
+		{"relative link", "[click](/admin)", "<p>click</p>"},
+		{"attribute break out", `[x](https://example.com/"onmouseover="alert(1))`, `<p><a href="https://example.com/%22onmouseover=%22alert%281%29" rel="nofollow noopener noreferrer ugc">x</a></p>`},
+		{"link in link", "[see https://example.com](https://example.org)", `<p><a href="https://example.org" rel="nofollow noopener noreferrer ugc">see https://example.com</a></p>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			html, _ := Render(tt.source)
+			assert.Equal(t, tt.html, html)
+		})
+	}
+}
+
+func TestRenderText(t *testing.T) {
+	_, text := Render("# **Hello** [world](https://example.com)\r\n\r\n- one\n- `two`\n\n> ||Snape|| did it\n\n```\ncode *as is*\n```")
+
+	assert.Equal(t, "# Hello world\n\n- one\n- two\n\n[spoiler] did it\n\ncode *as is*", text)
+}
+
+func TestRenderDeepNesting(t *testing.T) {
+	source := ""
+	for i := 0; i < 100; i++ {
+		source += "> "
+	}
+	source += "deep"
+
+	html, text := Render(source)
+
+	assert.Equal(t, maxQuoteDepth, strings.Count(html, "<blockquote>"))
+	assert.Contains(t, html, "&gt; deep</p>")
+	assert.Contains(t, text, "> deep")
+}
@@ -0,0 +1,128 @@
+package markdown
+
+import (
+	"html"
+	"strconv"
+	"strings"
+)
+
+// linkRel keeps user links from passing on ranking or the opener
+const linkRel = "nofollow noopener noreferrer ugc"
+
+func renderHTML(blocks []block) string {
+	var b strings.Builder
+	writeBlocksHTML(&b, blocks)
+	return b.String()
+}
+
+func writeBlocksHTML(b *strings.Builder, blocks []block) {
+	for _, blk := range blocks {
+		switch blk.kind {
+		case paragraphBlock:
+			b.WriteString("<p>")
+			writeInlinesHTML(b, blk.inlines)
+			b.WriteString("</p>")
+		case quoteBlock:
+			b.WriteString("<blockquote>")
+			writeBlocksHTML(b, blk.children)
+			b.WriteString("</blockquote>")
+		case listBlock, orderedListBlock:
+			tag := "ul"
+			if blk.kind == orderedListBlock {
+				tag = "ol"
+			}
+			b.WriteString("<" + tag + ">")
+			for _, item := range blk.items {
+				b.WriteString("<li>")
+				writeInlinesHTML(b, item)
+				b.WriteString("</li>")
+			}
+			b.WriteString("</" + tag + ">")
+		case codeBlock:
+			b.WriteString("<pre><code>")
+			b.WriteString(html.EscapeString(blk.text))
+			b.WriteString("</code></pre>")
+		}
+	}
+}
+
+func writeInlinesHTML(b *strings.Builder, inlines []inline) {
+	for _, node := range inlines {
+		switch node.kind {
+		case textInline:
+			b.WriteString(html.EscapeString(node.text))
+		case breakInline:
+			b.WriteString("<br>")
+		case codeInline:
+			b.WriteString("<code>" + html.EscapeString(node.text) + "</code>")
+		case strongInline:
+			b.WriteString("<strong>")
+			writeInlinesHTML(b, node.children)
+			b.WriteString("</strong>")
+		case emInline:
+			b.WriteString("<em>")
+			writeInlinesHTML(b, node.children)
+			b.WriteString("</em>")
+		case spoilerInline:
+			b.WriteString(`<span class="spoiler">`)
+			writeInlinesHTML(b, node.children)
+			b.WriteString("</span>")
+		case linkInline:
+			b.WriteString(`<a href="` + html.EscapeString(node.href) + `" rel="` + linkRel + `">`)
+			writeInlinesHTML(b, node.children)
+			b.WriteString("</a>")
+		case labelInline:
+			writeInlinesHTML(b, node.children)
+		}
+	}
+}
+
+// renderText keeps the words and line breaks without the markup, blocks
+// are separated by a blank line and spoilers are hidden
+func renderText(blocks []block) string {
+	var parts []string
+
+	for _, blk := range blocks {
+		switch blk.kind {
+		case paragraphBlock:
+			parts = append(parts, inlinesText(blk.inlines))
+		case quoteBlock:
+			if text := renderText(blk.children); text != "" {
+				parts = append(parts, text)
+			}
+		case listBlock, orderedListBlock:
+			items := make([]string, 0, len(blk.items))
+			for i, item := range blk.items {
+				marker := "- "
+				if blk.kind == orderedListBlock {
+					marker = strconv.Itoa(i+1) + ". "
+				}
+				items = append(items, marker+inlinesText(item))
+			}
+			parts = append(parts, strings.Join(items, "\n"))
+		case codeBlock:
+			parts = append(parts, blk.text)
+		}
+	}
+
+	return strings.Join(parts, "\n\n")
+}
+
+func inlinesText(inlines []inline) string {
+	var b strings.Builder
+
+	for _, node := range inlines {
+		switch node.kind {
+		case textInline, codeInline:
+			b.WriteString(node.text)
+		case breakInline:
+			b.WriteString("\n")
+		case spoilerInline:
+			b.WriteString(SpoilerText)
+		default:
+			b.WriteString(inlinesText(node.children))
+		}
+	}
+
+	return b.String()
+}
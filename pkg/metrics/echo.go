@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Middleware records HTTP latency labelled with the route template (e.g.
+// /api/v1/users/:userID) rather than the raw path to keep cardinality low
+func Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if c.Path() == "/metrics" {
+				return next(c)
+			}
+
+			start := time.Now()
+			err := next(c)
+
+			status := c.Response().Status
+			if err != nil {
+				// the error has not been written yet, resolve the code the
+				// same way echo's error handler will
+				status = http.StatusInternalServerError
+				var he *echo.HTTPError
+				if errors.As(err, &he) {
+					status = he.Code
+				}
+			}
+
+			route := c.Path()
+			if route == "" {
+				route = "unmatched"
+			}
+
+			HTTPRequestDuration.WithLabelValues(c.Request().Method, route, strconv.Itoa(status)).Observe(time.Since(start).Seconds())
+			return err
+		}
+	}
+}
+
+// Handler exposes the default registry in the Prometheus text format
+func Handler() echo.HandlerFunc {
+	return echo.WrapHandler(promhttp.Handler())
+}
@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "macaiki"
+
+var (
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request latency by route and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	DBQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "query_duration_seconds",
+		Help:      "GORM query latency by operation and table.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+	}, []string{"operation", "table"})
+
+	DBQueryErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "query_errors_total",
+		Help:      "GORM queries that returned an error, not counting record not found.",
+	}, []string{"operation", "table"})
+
+	UserRegistrations = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "user_registrations_total",
+		Help:      "Accounts registered.",
+	})
+
+	Logins = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "logins_total",
+		Help:      "Login attempts by result (success or fail).",
+	}, []string{"result"})
+
+	ThreadsCreated = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "threads_created_total",
+		Help:      "Threads created.",
+	})
+
+	ReportsFiled = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reports_filed_total",
+		Help:      "Reports filed by reported item type (user, community, thread, comment).",
+	}, []string{"type"})
+
+	NotificationsSent = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "notifications_sent_total",
+		Help:      "Notifications stored by notification type.",
+	}, []string{"type"})
+)
+
+const (
+	LoginSuccess = "success"
+	LoginFail    = "fail"
+
+	ReportUser      = "user"
+	ReportCommunity = "community"
+	ReportThread    = "thread"
+	ReportComment   = "comment"
+)
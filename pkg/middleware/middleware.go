@@ -1,8 +1,14 @@
 package middleware
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"fmt"
 	"log"
 	"macaiki/config"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt"
@@ -38,6 +44,60 @@ func JWTCreateToken(userId int, role string) (string, error) {
 	return token.SignedString([]byte(JWTSecret.Secret))
 }
 
+// JWTCreateChallengeToken issues the short-lived token a user trades in
+// for an access token once the second factor is checked. It is signed with
+// a key derived from the JWT secret, so it is never accepted as an access
+// token by the JWT middleware.
+func JWTCreateChallengeToken(userId int, purpose string, ttl time.Duration) (string, error) {
+	JWTSecret, err := config.LoadJWTSecret(".")
+	if err != nil {
+		return "", err
+	}
+
+	claims := jwt.MapClaims{}
+	claims["userId"] = userId
+	claims["purpose"] = purpose
+	claims["exp"] = time.Now().Add(ttl).Unix()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(challengeKey(JWTSecret.Secret))
+}
+
+// JWTParseChallengeToken returns the user and purpose of a challenge token
+func JWTParseChallengeToken(tokenString string) (int, string, error) {
+	JWTSecret, err := config.LoadJWTSecret(".")
+	if err != nil {
+		return 0, "", err
+	}
+
+	token, err := jwt.Parse(tokenString, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method %v", t.Header["alg"])
+		}
+		return challengeKey(JWTSecret.Secret), nil
+	})
+	if err != nil || !token.Valid {
+		return 0, "", errors.New("invalid challenge token")
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return 0, "", errors.New("invalid challenge token")
+	}
+	userID, ok := claims["userId"].(float64)
+	if !ok {
+		return 0, "", errors.New("invalid challenge token")
+	}
+	purpose, _ := claims["purpose"].(string)
+	return int(userID), purpose, nil
+}
+
+func challengeKey(secret string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("challenge"))
+	return mac.Sum(nil)
+}
+
 func ExtractTokenUser(c echo.Context) (int, string) {
 	user := c.Get("user").(*jwt.Token)
 	if user.Valid {
@@ -49,6 +109,39 @@ func ExtractTokenUser(c echo.Context) (int, string) {
 	return 0, ""
 }
 
+// TokenUserID reads the user ID from the bearer token without requiring the
+// route to be behind the JWT middleware, it is used by middleware that runs
+// before authentication such as the rate limiter
+func TokenUserID(secret string) func(c echo.Context) (string, bool) {
+	return func(c echo.Context) (string, bool) {
+		auth := c.Request().Header.Get(echo.HeaderAuthorization)
+		tokenString := strings.TrimPrefix(auth, "Bearer ")
+		if tokenString == "" || tokenString == auth {
+			return "", false
+		}
+
+		token, err := jwt.Parse(tokenString, func(t *jwt.Token) (interface{}, error) {
+			if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, fmt.Errorf("unexpected signing method %v", t.Header["alg"])
+			}
+			return []byte(secret), nil
+		})
+		if err != nil || !token.Valid {
+			return "", false
+		}
+
+		claims, ok := token.Claims.(jwt.MapClaims)
+		if !ok {
+			return "", false
+		}
+		userID, ok := claims["userId"].(float64)
+		if !ok {
+			return "", false
+		}
+		return strconv.Itoa(int(userID)), true
+	}
+}
+
 // InitMiddleware initialize the middleware
 func InitMiddleware() *GoMiddleware {
 	return &GoMiddleware{}
@@ -0,0 +1,140 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"macaiki/pkg/tracing"
+	"sync"
+
+	gooidc "github.com/coreos/go-oidc/v3/oidc"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/oauth2"
+)
+
+// ErrNonceMismatch is returned when the ID token was not issued for the
+// authorization request that started the flow
+var ErrNonceMismatch = errors.New("oidc: nonce mismatch")
+
+type Config struct {
+	// Name identifies the provider in routes and linked identities
+	Name         string
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+// Claims is the subset of the ID token the application cares about
+type Claims struct {
+	Subject           string `json:"sub"`
+	Email             string `json:"email"`
+	EmailVerified     bool   `json:"email_verified"`
+	Name              string `json:"name"`
+	PreferredUsername string `json:"preferred_username"`
+	Picture           string `json:"picture"`
+}
+
+// Provider runs the authorization code flow with PKCE against a single
+// OpenID Connect issuer. Discovery happens on first use so the application
+// can start while the issuer is unreachable.
+type Provider struct {
+	config Config
+
+	mu       sync.Mutex
+	oauth    *oauth2.Config
+	verifier *gooidc.IDTokenVerifier
+}
+
+func NewProvider(config Config) *Provider {
+	if len(config.Scopes) == 0 {
+		config.Scopes = []string{gooidc.ScopeOpenID, "email", "profile"}
+	}
+	return &Provider{config: config}
+}
+
+func (p *Provider) Name() string {
+	return p.config.Name
+}
+
+// GenerateVerifier returns a new PKCE code verifier
+func GenerateVerifier() string {
+	return oauth2.GenerateVerifier()
+}
+
+// AuthCodeURL returns the URL the user is sent to, the S256 challenge of
+// verifier and the nonce are bound to the request
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce, verifier string) (string, error) {
+	oauth, _, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return oauth.AuthCodeURL(state, gooidc.Nonce(nonce), oauth2.S256ChallengeOption(verifier)), nil
+}
+
+// Exchange redeems the authorization code and returns the claims of the
+// verified ID token
+func (p *Provider) Exchange(ctx context.Context, code, verifier, nonce string) (_ Claims, err error) {
+	ctx, span := tracing.Start(ctx, "oidc.exchange", trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("oidc.provider", p.config.Name)))
+	defer func() { tracing.End(span, err) }()
+
+	oauth, verifierOIDC, err := p.discover(ctx)
+	if err != nil {
+		return Claims{}, err
+	}
+
+	token, err := oauth.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return Claims{}, fmt.Errorf("oidc: exchange code: %w", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return Claims{}, errors.New("oidc: token response has no id_token")
+	}
+
+	idToken, err := verifierOIDC.Verify(ctx, rawIDToken)
+	if err != nil {
+		return Claims{}, fmt.Errorf("oidc: verify id_token: %w", err)
+	}
+	if idToken.Nonce != nonce {
+		return Claims{}, ErrNonceMismatch
+	}
+
+	var claims Claims
+	if err := idToken.Claims(&claims); err != nil {
+		return Claims{}, fmt.Errorf("oidc: decode claims: %w", err)
+	}
+	claims.Subject = idToken.Subject
+	return claims, nil
+}
+
+func (p *Provider) discover(ctx context.Context) (*oauth2.Config, *gooidc.IDTokenVerifier, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.oauth != nil {
+		return p.oauth, p.verifier, nil
+	}
+
+	// the provider keeps this context for fetching signing keys later, so
+	// it must not be tied to the request that triggered discovery
+	provider, err := gooidc.NewProvider(context.WithoutCancel(ctx), p.config.IssuerURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("oidc: discover %s: %w", p.config.IssuerURL, err)
+	}
+
+	p.oauth = &oauth2.Config{
+		ClientID:     p.config.ClientID,
+		ClientSecret: p.config.ClientSecret,
+		RedirectURL:  p.config.RedirectURL,
+		Endpoint:     provider.Endpoint(),
+		Scopes:       p.config.Scopes,
+	}
+	p.verifier = provider.Verifier(&gooidc.Config{ClientID: p.config.ClientID})
+	return p.oauth, p.verifier, nil
+}
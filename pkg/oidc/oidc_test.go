@@ -0,0 +1,209 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// mockIssuer is a minimal OpenID Connect provider: discovery, signing keys
+// and a token endpoint that checks the PKCE verifier of the code it issued
+type mockIssuer struct {
+	*httptest.Server
+	key       *rsa.PrivateKey
+	signer    *rsa.PrivateKey
+	challenge string
+	nonce     string
+}
+
+func newMockIssuer(t *testing.T) *mockIssuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	m := &mockIssuer{key: key, signer: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"issuer":                                m.URL,
+			"authorization_endpoint":                m.URL + "/authorize",
+			"token_endpoint":                        m.URL + "/token",
+			"jwks_uri":                              m.URL + "/keys",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"alg": "RS256",
+				"use": "sig",
+				"kid": "test",
+				"n":   b64(key.N.Bytes()),
+				"e":   b64(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		sum := sha256.Sum256([]byte(r.Form.Get("code_verifier")))
+		if r.Form.Get("code") != "good-code" || b64(sum[:]) != m.challenge {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+			"id_token":     m.idToken(t),
+		})
+	})
+	m.Server = httptest.NewServer(mux)
+	t.Cleanup(m.Close)
+	return m
+}
+
+func (m *mockIssuer) idToken(t *testing.T) string {
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": "test"})
+	payload, _ := json.Marshal(map[string]interface{}{
+		"iss":                m.URL,
+		"sub":                "subject-1",
+		"aud":                "client",
+		"exp":                time.Now().Add(time.Hour).Unix(),
+		"iat":                time.Now().Unix(),
+		"nonce":              m.nonce,
+		"email":              "jane.doe@example.com",
+		"email_verified":     true,
+		"name":               "Jane Doe",
+		"preferred_username": "jane",
+	})
+
+	signingInput := b64(header) + "." + b64(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, m.signer, crypto.SHA256, digest[:])
+	assert.NoError(t, err)
+	return signingInput + "." + b64(signature)
+}
+
+func b64(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func newTestProvider(issuerURL string) *Provider {
+	return NewProvider(Config{
+		Name:         "mock",
+		IssuerURL:    issuerURL,
+		ClientID:     "client",
+		ClientSecret: "secret",
+		RedirectURL:  "http://localhost/callback",
+	})
+}
+
+// authorize plays the user agent: it starts the flow and records what the
+// issuer would have stored with the authorization code
+func authorize(t *testing.T, m *mockIssuer, p *Provider, verifier, nonce string) {
+	link, err := p.AuthCodeURL(context.Background(), "state-1", nonce, verifier)
+	assert.NoError(t, err)
+
+	u, err := url.Parse(link)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(link, m.URL+"/authorize"))
+	q := u.Query()
+	assert.Equal(t, "client", q.Get("client_id"))
+	assert.Equal(t, "state-1", q.Get("state"))
+	assert.Equal(t, "S256", q.Get("code_challenge_method"))
+	assert.Equal(t, "openid email profile", q.Get("scope"))
+
+	m.challenge = q.Get("code_challenge")
+	m.nonce = q.Get("nonce")
+}
+
+func TestProviderAuthCodeURL(t *testing.T) {
+	m := newMockIssuer(t)
+	p := newTestProvider(m.URL)
+	verifier := GenerateVerifier()
+
+	authorize(t, m, p, verifier, "nonce-1")
+
+	sum := sha256.Sum256([]byte(verifier))
+	assert.Equal(t, b64(sum[:]), m.challenge)
+	assert.Equal(t, "nonce-1", m.nonce)
+}
+
+func TestProviderExchange(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		m := newMockIssuer(t)
+		p := newTestProvider(m.URL)
+		verifier := GenerateVerifier()
+		authorize(t, m, p, verifier, "nonce-1")
+
+		claims, err := p.Exchange(context.Background(), "good-code", verifier, "nonce-1")
+		assert.NoError(t, err)
+		assert.Equal(t, Claims{
+			Subject:           "subject-1",
+			Email:             "jane.doe@example.com",
+			EmailVerified:     true,
+			Name:              "Jane Doe",
+			PreferredUsername: "jane",
+		}, claims)
+	})
+
+	t.Run("wrong-verifier", func(t *testing.T) {
+		m := newMockIssuer(t)
+		p := newTestProvider(m.URL)
+		authorize(t, m, p, GenerateVerifier(), "nonce-1")
+
+		_, err := p.Exchange(context.Background(), "good-code", GenerateVerifier(), "nonce-1")
+		assert.ErrorContains(t, err, "exchange code")
+	})
+
+	t.Run("nonce-mismatch", func(t *testing.T) {
+		m := newMockIssuer(t)
+		p := newTestProvider(m.URL)
+		verifier := GenerateVerifier()
+		authorize(t, m, p, verifier, "nonce-1")
+
+		_, err := p.Exchange(context.Background(), "good-code", verifier, "nonce-2")
+		assert.ErrorIs(t, err, ErrNonceMismatch)
+	})
+
+	t.Run("bad-signature", func(t *testing.T) {
+		m := newMockIssuer(t)
+		p := newTestProvider(m.URL)
+		verifier := GenerateVerifier()
+		authorize(t, m, p, verifier, "nonce-1")
+
+		other, err := rsa.GenerateKey(rand.Reader, 2048)
+		assert.NoError(t, err)
+		m.signer = other
+
+		_, err = p.Exchange(context.Background(), "good-code", verifier, "nonce-1")
+		assert.ErrorContains(t, err, "verify id_token")
+	})
+
+	t.Run("issuer-unreachable", func(t *testing.T) {
+		m := newMockIssuer(t)
+		issuerURL := m.URL
+		m.Close()
+
+		p := newTestProvider(issuerURL)
+		_, err := p.AuthCodeURL(context.Background(), "state-1", "nonce-1", GenerateVerifier())
+		assert.ErrorContains(t, err, "discover")
+	})
+}
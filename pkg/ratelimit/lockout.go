@@ -0,0 +1,67 @@
+package ratelimit
+
+import (
+	"context"
+	"macaiki/pkg/utils"
+)
+
+// Lockout guards an action such as a login or an OTP check against brute
+// force by locking the key after repeated failures
+type Lockout interface {
+	// Check returns a *utils.RetryAfterError while key is locked
+	Check(ctx context.Context, key string) error
+	// Fail records a failed attempt and returns a *utils.RetryAfterError if
+	// the key just became locked
+	Fail(ctx context.Context, key string) error
+	// Succeed clears the failures recorded for key
+	Succeed(ctx context.Context, key string) error
+}
+
+type lockout struct {
+	store  Store
+	policy LockoutPolicy
+}
+
+func NewLockout(store Store, policy LockoutPolicy) Lockout {
+	return &lockout{store: store, policy: policy}
+}
+
+// OrNoop returns l, or a Lockout that never locks when l is nil
+func OrNoop(l Lockout) Lockout {
+	if l == nil {
+		return noopLockout{}
+	}
+	return l
+}
+
+func (l *lockout) Check(ctx context.Context, key string) error {
+	wait, err := l.store.LockedFor(ctx, key)
+	if err != nil {
+		return err
+	}
+	if wait > 0 {
+		return &utils.RetryAfterError{Err: utils.ErrTooManyRequests, RetryAfter: wait}
+	}
+	return nil
+}
+
+func (l *lockout) Fail(ctx context.Context, key string) error {
+	wait, err := l.store.RecordFailure(ctx, key, l.policy)
+	if err != nil {
+		return err
+	}
+	if wait > 0 {
+		return &utils.RetryAfterError{Err: utils.ErrTooManyRequests, RetryAfter: wait}
+	}
+	return nil
+}
+
+func (l *lockout) Succeed(ctx context.Context, key string) error {
+	return l.store.ResetFailures(ctx, key)
+}
+
+type noopLockout struct{}
+
+func (noopLockout) Check(context.Context, string) error   { return nil }
+func (noopLockout) Fail(context.Context, string) error    { return nil }
+func (noopLockout) Succeed(context.Context, string) error { return nil }
@@ -0,0 +1,140 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+const sweepInterval = time.Minute
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	policy  Policy
+}
+
+type failures struct {
+	count       int
+	windowStart time.Time
+	lockedUntil time.Time
+	window      time.Duration
+}
+
+// MemoryStore is an in-process Store. State is lost on restart and not
+// shared between instances.
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	failures  map[string]*failures
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets:  map[string]*bucket{},
+		failures: map[string]*failures{},
+		now:      time.Now,
+	}
+}
+
+func (m *MemoryStore) Allow(ctx context.Context, key string, policy Policy) (Result, error) {
+	if policy.Disabled() {
+		return Result{Allowed: true}, nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	m.sweep(now)
+
+	b, ok := m.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(policy.Burst), updated: now}
+		m.buckets[key] = b
+	}
+	b.policy = policy
+	b.tokens = math.Min(float64(policy.Burst), b.tokens+now.Sub(b.updated).Seconds()*policy.Rate)
+	b.updated = now
+
+	if b.tokens < 1 {
+		wait := time.Duration((1 - b.tokens) / policy.Rate * float64(time.Second))
+		return Result{Allowed: false, RetryAfter: wait}, nil
+	}
+
+	b.tokens--
+	return Result{Allowed: true, Remaining: int(b.tokens)}, nil
+}
+
+func (m *MemoryStore) LockedFor(ctx context.Context, key string) (time.Duration, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	f, ok := m.failures[key]
+	if !ok {
+		return 0, nil
+	}
+
+	if wait := f.lockedUntil.Sub(m.now()); wait > 0 {
+		return wait, nil
+	}
+	return 0, nil
+}
+
+func (m *MemoryStore) RecordFailure(ctx context.Context, key string, policy LockoutPolicy) (time.Duration, error) {
+	if policy.MaxFailures <= 0 {
+		return 0, nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	f, ok := m.failures[key]
+	if !ok || now.Sub(f.windowStart) > policy.Window {
+		f = &failures{windowStart: now}
+		m.failures[key] = f
+	}
+	f.window = policy.Window
+	f.count++
+
+	if f.count < policy.MaxFailures {
+		return 0, nil
+	}
+
+	f.count = 0
+	f.windowStart = now
+	f.lockedUntil = now.Add(policy.Duration)
+	return policy.Duration, nil
+}
+
+func (m *MemoryStore) ResetFailures(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.failures, key)
+	return nil
+}
+
+// sweep drops buckets that have refilled completely and failure records that
+// have expired, so memory use follows the number of active clients
+func (m *MemoryStore) sweep(now time.Time) {
+	if now.Sub(m.lastSweep) < sweepInterval {
+		return
+	}
+	m.lastSweep = now
+
+	for key, b := range m.buckets {
+		if b.tokens+now.Sub(b.updated).Seconds()*b.policy.Rate >= float64(b.policy.Burst) {
+			delete(m.buckets, key)
+		}
+	}
+	for key, f := range m.failures {
+		if now.After(f.lockedUntil) && now.Sub(f.windowStart) > f.window {
+			delete(m.failures, key)
+		}
+	}
+}
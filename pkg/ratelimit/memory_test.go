@@ -0,0 +1,171 @@
+package ratelimit
+
+import (
+	"context"
+	"macaiki/pkg/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestStore returns a store whose clock only moves through advance
+func newTestStore() (*MemoryStore, func(d time.Duration)) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	m := NewMemoryStore()
+	m.now = func() time.Time { return now }
+	return m, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestMemoryStoreAllow(t *testing.T) {
+	policy := Every(2, time.Minute)
+
+	tests := []struct {
+		name          string
+		advance       time.Duration
+		wantAllowed   bool
+		wantRemaining int
+		wantRetry     time.Duration
+	}{
+		{name: "first", wantAllowed: true, wantRemaining: 1},
+		{name: "burst", wantAllowed: true, wantRemaining: 0},
+		{name: "empty", wantAllowed: false, wantRetry: 30 * time.Second},
+		{name: "partly-refilled", advance: 20 * time.Second, wantAllowed: false, wantRetry: 10 * time.Second},
+		{name: "refilled", advance: 10 * time.Second, wantAllowed: true, wantRemaining: 0},
+		{name: "capped-at-burst", advance: time.Hour, wantAllowed: true, wantRemaining: 1},
+	}
+
+	m, advance := newTestStore()
+	for _, tt := range tests {
+		advance(tt.advance)
+		res, err := m.Allow(context.Background(), "key", policy)
+
+		assert.NoError(t, err, tt.name)
+		assert.Equal(t, tt.wantAllowed, res.Allowed, tt.name)
+		assert.Equal(t, tt.wantRemaining, res.Remaining, tt.name)
+		assert.InDelta(t, tt.wantRetry, res.RetryAfter, float64(time.Millisecond), tt.name)
+	}
+}
+
+func TestMemoryStoreAllowKeysAreSeparate(t *testing.T) {
+	m, _ := newTestStore()
+	policy := Every(1, time.Minute)
+
+	res, _ := m.Allow(context.Background(), "a", policy)
+	assert.True(t, res.Allowed)
+	res, _ = m.Allow(context.Background(), "a", policy)
+	assert.False(t, res.Allowed)
+	res, _ = m.Allow(context.Background(), "b", policy)
+	assert.True(t, res.Allowed)
+}
+
+func TestMemoryStoreAllowDisabled(t *testing.T) {
+	m, _ := newTestStore()
+
+	for i := 0; i < 10; i++ {
+		res, err := m.Allow(context.Background(), "key", Policy{})
+		assert.NoError(t, err)
+		assert.True(t, res.Allowed)
+	}
+}
+
+func TestMemoryStoreLockout(t *testing.T) {
+	policy := LockoutPolicy{MaxFailures: 3, Window: 10 * time.Minute, Duration: 15 * time.Minute}
+
+	t.Run("locks-after-max-failures", func(t *testing.T) {
+		m, advance := newTestStore()
+		ctx := context.Background()
+
+		for i := 0; i < 2; i++ {
+			wait, err := m.RecordFailure(ctx, "key", policy)
+			assert.NoError(t, err)
+			assert.Zero(t, wait)
+		}
+		wait, err := m.RecordFailure(ctx, "key", policy)
+		assert.NoError(t, err)
+		assert.Equal(t, 15*time.Minute, wait)
+
+		advance(5 * time.Minute)
+		wait, err = m.LockedFor(ctx, "key")
+		assert.NoError(t, err)
+		assert.Equal(t, 10*time.Minute, wait)
+
+		advance(10 * time.Minute)
+		wait, err = m.LockedFor(ctx, "key")
+		assert.NoError(t, err)
+		assert.Zero(t, wait)
+	})
+
+	t.Run("failures-outside-window-are-forgotten", func(t *testing.T) {
+		m, advance := newTestStore()
+		ctx := context.Background()
+
+		m.RecordFailure(ctx, "key", policy)
+		m.RecordFailure(ctx, "key", policy)
+		advance(11 * time.Minute)
+
+		wait, _ := m.RecordFailure(ctx, "key", policy)
+		assert.Zero(t, wait)
+		wait, _ = m.LockedFor(ctx, "key")
+		assert.Zero(t, wait)
+	})
+
+	t.Run("reset-clears-lock", func(t *testing.T) {
+		m, _ := newTestStore()
+		ctx := context.Background()
+
+		for i := 0; i < 3; i++ {
+			m.RecordFailure(ctx, "key", policy)
+		}
+		assert.NoError(t, m.ResetFailures(ctx, "key"))
+
+		wait, _ := m.LockedFor(ctx, "key")
+		assert.Zero(t, wait)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		m, _ := newTestStore()
+
+		for i := 0; i < 10; i++ {
+			wait, err := m.RecordFailure(context.Background(), "key", LockoutPolicy{})
+			assert.NoError(t, err)
+			assert.Zero(t, wait)
+		}
+	})
+}
+
+func TestMemoryStoreSweep(t *testing.T) {
+	m, advance := newTestStore()
+	ctx := context.Background()
+	policy := LockoutPolicy{MaxFailures: 1, Window: time.Minute, Duration: time.Minute}
+
+	m.Allow(ctx, "key", Every(1, time.Minute))
+	m.RecordFailure(ctx, "key", policy)
+
+	advance(2 * time.Minute)
+	m.Allow(ctx, "other", Every(1, time.Minute))
+
+	assert.NotContains(t, m.buckets, "key")
+	assert.NotContains(t, m.failures, "key")
+	assert.Contains(t, m.buckets, "other")
+}
+
+func TestLockout(t *testing.T) {
+	m, advance := newTestStore()
+	l := NewLockout(m, LockoutPolicy{MaxFailures: 2, Window: time.Minute, Duration: time.Minute})
+	ctx := context.Background()
+
+	assert.NoError(t, l.Check(ctx, "key"))
+	assert.NoError(t, l.Fail(ctx, "key"))
+
+	err := l.Fail(ctx, "key")
+	var retryErr *utils.RetryAfterError
+	assert.ErrorAs(t, err, &retryErr)
+	assert.Equal(t, time.Minute, retryErr.RetryAfter)
+	assert.ErrorIs(t, l.Check(ctx, "key"), utils.ErrTooManyRequests)
+
+	advance(time.Minute)
+	assert.NoError(t, l.Check(ctx, "key"))
+
+	assert.NoError(t, OrNoop(nil).Fail(ctx, "key"))
+}
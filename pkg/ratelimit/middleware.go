@@ -0,0 +1,91 @@
+package ratelimit
+
+import (
+	"log/slog"
+	"macaiki/pkg/logger"
+	"macaiki/pkg/response"
+	"macaiki/pkg/utils"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// KeyFunc identifies who a request is counted against
+type KeyFunc func(c echo.Context) string
+
+// ByIP counts requests against the client address
+func ByIP(c echo.Context) string {
+	return "ip:" + c.RealIP()
+}
+
+// ByUser counts requests against the user returned by identify, falling
+// back to the client address for anonymous requests
+func ByUser(identify func(c echo.Context) (string, bool)) KeyFunc {
+	return func(c echo.Context) string {
+		if userID, ok := identify(c); ok {
+			return "user:" + userID
+		}
+		return ByIP(c)
+	}
+}
+
+// Rule applies a policy to a route, Path is the route template as
+// registered, e.g. /api/v1/threads/:threadID/comments. Rules naming the
+// same Bucket share one counter, without a Bucket each route counts alone.
+type Rule struct {
+	Method string
+	Path   string
+	Policy Policy
+	Key    KeyFunc
+	Bucket string
+}
+
+// Middleware enforces the rules matching each request's route. Store errors
+// are logged and the request is let through rather than failing closed.
+func Middleware(store Store, rules []Rule, log *slog.Logger) echo.MiddlewareFunc {
+	log = logger.OrDefault(log)
+
+	byRoute := map[string][]Rule{}
+	for _, rule := range rules {
+		if rule.Policy.Disabled() {
+			continue
+		}
+		if rule.Key == nil {
+			rule.Key = ByIP
+		}
+		route := routeKey(rule.Method, rule.Path)
+		byRoute[route] = append(byRoute[route], rule)
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			route := routeKey(c.Request().Method, c.Path())
+			for _, rule := range byRoute[route] {
+				bucket := route
+				if rule.Bucket != "" {
+					bucket = rule.Bucket
+				}
+				res, err := store.Allow(c.Request().Context(), bucket+"|"+rule.Key(c), rule.Policy)
+				if err != nil {
+					log.ErrorContext(c.Request().Context(), "rate limit store failed", "route", route, "err", err)
+					continue
+				}
+
+				c.Response().Header().Set("X-RateLimit-Limit", strconv.Itoa(rule.Policy.Burst))
+				c.Response().Header().Set("X-RateLimit-Remaining", strconv.Itoa(res.Remaining))
+				if !res.Allowed {
+					return response.ErrorResponse(c, &utils.RetryAfterError{Err: utils.ErrTooManyRequests, RetryAfter: res.RetryAfter})
+				}
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// routeKey normalises the leading slash, routes are registered both with
+// and without it
+func routeKey(method, path string) string {
+	return method + " /" + strings.TrimPrefix(path, "/")
+}
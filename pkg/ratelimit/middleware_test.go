@@ -0,0 +1,115 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// failingStore lets every call fail to check the middleware fails open
+type failingStore struct{ Store }
+
+func (failingStore) Allow(ctx context.Context, key string, policy Policy) (Result, error) {
+	return Result{}, errors.New("store unavailable")
+}
+
+func newTestServer(store Store, rules []Rule) *echo.Echo {
+	e := echo.New()
+	e.Use(Middleware(store, rules, nil))
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusOK) }
+	e.POST("/api/v1/threads", ok)
+	e.POST("/api/v1/threads/:threadID/publish", ok)
+	e.GET("/api/v1/threads", ok)
+	return e
+}
+
+func serve(e *echo.Echo, method, path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	req.RemoteAddr = "192.0.2.1:1234"
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestMiddleware(t *testing.T) {
+	policy := Every(1, time.Minute)
+
+	t.Run("limits-with-retry-after", func(t *testing.T) {
+		m, _ := newTestStore()
+		e := newTestServer(m, []Rule{{Method: http.MethodPost, Path: "/api/v1/threads", Policy: policy}})
+
+		rec := serve(e, http.MethodPost, "/api/v1/threads")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "1", rec.Header().Get("X-RateLimit-Limit"))
+		assert.Equal(t, "0", rec.Header().Get("X-RateLimit-Remaining"))
+
+		rec = serve(e, http.MethodPost, "/api/v1/threads")
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.Equal(t, "60", rec.Header().Get("Retry-After"))
+
+		// other routes are not counted
+		rec = serve(e, http.MethodGet, "/api/v1/threads")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get("X-RateLimit-Limit"))
+	})
+
+	t.Run("shared-bucket", func(t *testing.T) {
+		m, _ := newTestStore()
+		e := newTestServer(m, []Rule{
+			{Method: http.MethodPost, Path: "/api/v1/threads", Policy: policy, Bucket: "thread"},
+			{Method: http.MethodPost, Path: "api/v1/threads/:threadID/publish", Policy: policy, Bucket: "thread"},
+		})
+
+		rec := serve(e, http.MethodPost, "/api/v1/threads")
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		rec = serve(e, http.MethodPost, "/api/v1/threads/1/publish")
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	})
+
+	t.Run("disabled-rule", func(t *testing.T) {
+		m, _ := newTestStore()
+		e := newTestServer(m, []Rule{{Method: http.MethodPost, Path: "/api/v1/threads"}})
+
+		for i := 0; i < 3; i++ {
+			rec := serve(e, http.MethodPost, "/api/v1/threads")
+			assert.Equal(t, http.StatusOK, rec.Code)
+		}
+	})
+
+	t.Run("fails-open", func(t *testing.T) {
+		e := newTestServer(failingStore{}, []Rule{{Method: http.MethodPost, Path: "/api/v1/threads", Policy: policy}})
+
+		for i := 0; i < 3; i++ {
+			rec := serve(e, http.MethodPost, "/api/v1/threads")
+			assert.Equal(t, http.StatusOK, rec.Code)
+		}
+	})
+
+	t.Run("by-user", func(t *testing.T) {
+		m, _ := newTestStore()
+		identify := func(c echo.Context) (string, bool) {
+			userID := c.Request().Header.Get("X-User")
+			return userID, userID != ""
+		}
+		e := newTestServer(m, []Rule{{Method: http.MethodPost, Path: "/api/v1/threads", Policy: policy, Key: ByUser(identify)}})
+
+		send := func(userID string) int {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/threads", nil)
+			req.Header.Set("X-User", userID)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			return rec.Code
+		}
+
+		assert.Equal(t, http.StatusOK, send("1"))
+		assert.Equal(t, http.StatusOK, send("2"))
+		assert.Equal(t, http.StatusTooManyRequests, send("1"))
+	})
+}
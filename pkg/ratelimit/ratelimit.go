@@ -0,0 +1,81 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Policy describes a token bucket holding up to Burst tokens that refills
+// at Rate tokens per second
+type Policy struct {
+	Rate  float64
+	Burst int
+}
+
+// Every builds a policy allowing n requests per period, all of which may be
+// spent at once
+func Every(n int, period time.Duration) Policy {
+	return Policy{Rate: float64(n) / period.Seconds(), Burst: n}
+}
+
+// ParsePolicy parses policies written as "<requests>/<period>", e.g. "10/1m".
+// An empty string yields the zero policy, which disables the limit.
+func ParsePolicy(s string) (Policy, error) {
+	if s == "" {
+		return Policy{}, nil
+	}
+
+	count, period, ok := strings.Cut(s, "/")
+	if !ok {
+		return Policy{}, fmt.Errorf("invalid rate limit policy %q, expected <requests>/<period>", s)
+	}
+
+	n, err := strconv.Atoi(strings.TrimSpace(count))
+	if err != nil || n <= 0 {
+		return Policy{}, fmt.Errorf("invalid request count in rate limit policy %q", s)
+	}
+	d, err := time.ParseDuration(strings.TrimSpace(period))
+	if err != nil || d <= 0 {
+		return Policy{}, fmt.Errorf("invalid period in rate limit policy %q", s)
+	}
+
+	return Every(n, d), nil
+}
+
+func (p Policy) Disabled() bool {
+	return p.Rate <= 0 || p.Burst <= 0
+}
+
+type Result struct {
+	Allowed   bool
+	Remaining int
+	// RetryAfter is how long until the next token is available, only set
+	// when the request was not allowed
+	RetryAfter time.Duration
+}
+
+// LockoutPolicy locks a key for Duration once MaxFailures failures have been
+// recorded within Window
+type LockoutPolicy struct {
+	MaxFailures int
+	Window      time.Duration
+	Duration    time.Duration
+}
+
+// Store keeps bucket and failure state. MemoryStore is enough for a single
+// instance, deployments running several replicas should plug in a shared
+// implementation (e.g. backed by Redis) so limits hold across instances.
+type Store interface {
+	// Allow takes a token from the bucket identified by key
+	Allow(ctx context.Context, key string, policy Policy) (Result, error)
+	// LockedFor returns how long key remains locked, zero if it is not
+	LockedFor(ctx context.Context, key string) (time.Duration, error)
+	// RecordFailure counts a failure for key and returns the lock duration
+	// when the failure pushed it over the policy limit
+	RecordFailure(ctx context.Context, key string, policy LockoutPolicy) (time.Duration, error)
+	// ResetFailures clears failures and any lock held on key
+	ResetFailures(ctx context.Context, key string) error
+}
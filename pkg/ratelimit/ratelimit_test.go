@@ -0,0 +1,45 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Policy
+		wantErr bool
+	}{
+		{name: "per-minute", input: "10/1m", want: Policy{Rate: 10.0 / 60, Burst: 10}},
+		{name: "spaces", input: " 3 / 24h ", want: Policy{Rate: 3.0 / (24 * 60 * 60), Burst: 3}},
+		{name: "empty-disables", input: "", want: Policy{}},
+		{name: "no-period", input: "10", wantErr: true},
+		{name: "bad-count", input: "ten/1m", wantErr: true},
+		{name: "zero-count", input: "0/1m", wantErr: true},
+		{name: "bad-period", input: "10/minute", wantErr: true},
+		{name: "negative-period", input: "10/-1m", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePolicy(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want.Burst, got.Burst)
+			assert.InDelta(t, tt.want.Rate, got.Rate, 1e-12)
+		})
+	}
+}
+
+func TestPolicyDisabled(t *testing.T) {
+	assert.True(t, Policy{}.Disabled())
+	assert.True(t, Policy{Rate: 1}.Disabled())
+	assert.False(t, Every(5, time.Minute).Disabled())
+}
@@ -1,8 +1,12 @@
 package response
 
 import (
+	"errors"
+	"macaiki/pkg/tracing"
 	"macaiki/pkg/utils"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/labstack/echo/v4"
 )
@@ -10,6 +14,7 @@ import (
 type meta struct {
 	Message string `json:"message"`
 	Code    int    `json:"code"`
+	TraceID string `json:"traceId,omitempty"`
 }
 type baseResponse struct {
 	Meta meta
@@ -26,10 +31,38 @@ func SuccessResponse(c echo.Context, data interface{}) error {
 }
 
 func ErrorResponse(c echo.Context, err error) error {
+	setRetryAfter(c, err)
+
 	resp := baseResponse{}
 	resp.Meta.Code = utils.GetStatusCode(err)
 	resp.Meta.Message = err.Error()
+	resp.Meta.TraceID = tracing.TraceID(c.Request().Context())
 	resp.Data = nil
 
 	return c.JSON(resp.Meta.Code, resp)
 }
+
+// ErrorResponseWithData is ErrorResponse for errors that still carry a body,
+// e.g. the failing checks of a readiness probe
+func ErrorResponseWithData(c echo.Context, err error, data interface{}) error {
+	resp := baseResponse{}
+	resp.Meta.Code = utils.GetStatusCode(err)
+	resp.Meta.Message = err.Error()
+	resp.Meta.TraceID = tracing.TraceID(c.Request().Context())
+	resp.Data = data
+
+	return c.JSON(resp.Meta.Code, resp)
+}
+
+func setRetryAfter(c echo.Context, err error) {
+	var retryErr *utils.RetryAfterError
+	if !errors.As(err, &retryErr) {
+		return
+	}
+
+	seconds := int(math.Ceil(retryErr.RetryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	c.Response().Header().Set("Retry-After", strconv.Itoa(seconds))
+}
@@ -0,0 +1,78 @@
+package unfurl
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const defaultCacheSize = 1000
+
+type cacheEntry struct {
+	preview Preview
+	err     error
+	expires time.Time
+}
+
+// Cache remembers the previews of a Fetcher for a while. Links without a
+// preview are remembered too, so a broken link is not fetched on every
+// edit, while network errors are not.
+type Cache struct {
+	fetcher Fetcher
+	ttl     time.Duration
+	size    int
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+	now     func() time.Time
+}
+
+// NewCache keeps up to size previews for ttl each
+func NewCache(fetcher Fetcher, ttl time.Duration, size int) *Cache {
+	if size <= 0 {
+		size = defaultCacheSize
+	}
+	return &Cache{fetcher: fetcher, ttl: ttl, size: size, entries: map[string]cacheEntry{}, now: time.Now}
+}
+
+func (c *Cache) Fetch(ctx context.Context, rawURL string) (Preview, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[rawURL]
+	c.mu.Unlock()
+	if ok && c.now().Before(entry.expires) {
+		return entry.preview, entry.err
+	}
+
+	preview, err := c.fetcher.Fetch(ctx, rawURL)
+	if err != nil && !Permanent(err) {
+		return preview, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[rawURL]; !ok && len(c.entries) >= c.size {
+		c.evict()
+	}
+	c.entries[rawURL] = cacheEntry{preview: preview, err: err, expires: c.now().Add(c.ttl)}
+
+	return preview, err
+}
+
+// evict drops the expired entries, or the one closest to expiring when none
+// has expired yet
+func (c *Cache) evict() {
+	now := c.now()
+	oldest := ""
+	for key, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, key)
+			continue
+		}
+		if oldest == "" || entry.expires.Before(c.entries[oldest].expires) {
+			oldest = key
+		}
+	}
+	if len(c.entries) >= c.size && oldest != "" {
+		delete(c.entries, oldest)
+	}
+}
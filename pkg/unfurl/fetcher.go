@@ -0,0 +1,205 @@
+package unfurl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+)
+
+const (
+	maxRedirects       = 3
+	maxTitleLength     = 300
+	maxDescription     = 1000
+	maxSiteNameLength  = 200
+	maxURLLength       = 2048
+	defaultTimeout     = 5 * time.Second
+	defaultMaxBytes    = 512 << 10
+	defaultUserAgent   = "MacaikiBot/1.0 (+link preview)"
+	acceptContentTypes = "text/html,application/xhtml+xml"
+)
+
+type Config struct {
+	// Timeout bounds a whole fetch, redirects included
+	Timeout time.Duration
+	// MaxBytes is how much of a page is read, metadata past it is ignored
+	MaxBytes  int64
+	UserAgent string
+}
+
+// HTTPFetcher reads the preview of a page over HTTP
+type HTTPFetcher struct {
+	config Config
+	client *http.Client
+	// allowed decides which resolved addresses may be dialed
+	allowed func(ip net.IP) bool
+}
+
+func NewFetcher(config Config) *HTTPFetcher {
+	if config.Timeout <= 0 {
+		config.Timeout = defaultTimeout
+	}
+	if config.MaxBytes <= 0 {
+		config.MaxBytes = defaultMaxBytes
+	}
+	if config.UserAgent == "" {
+		config.UserAgent = defaultUserAgent
+	}
+
+	f := &HTTPFetcher{config: config, allowed: publicAddress}
+	dialer := &net.Dialer{Timeout: config.Timeout, Control: f.control}
+	f.client = &http.Client{
+		Timeout: config.Timeout,
+		Transport: &http.Transport{
+			// a proxy would dial on our behalf and skip the address check
+			Proxy:                 nil,
+			DialContext:           dialer.DialContext,
+			TLSHandshakeTimeout:   config.Timeout,
+			ResponseHeaderTimeout: config.Timeout,
+			MaxIdleConns:          10,
+			IdleConnTimeout:       30 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return ErrNoPreview
+			}
+			if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+				return ErrUnsupportedURL
+			}
+			return nil
+		},
+	}
+	return f
+}
+
+func (f *HTTPFetcher) Fetch(ctx context.Context, rawURL string) (Preview, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" || len(rawURL) > maxURLLength {
+		return Preview{}, ErrUnsupportedURL
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, f.config.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return Preview{}, ErrUnsupportedURL
+	}
+	req.Header.Set("User-Agent", f.config.UserAgent)
+	req.Header.Set("Accept", acceptContentTypes)
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		for _, sentinel := range []error{ErrBlockedAddress, ErrUnsupportedURL, ErrNoPreview} {
+			if errors.Is(err, sentinel) {
+				return Preview{}, sentinel
+			}
+		}
+		return Preview{}, fmt.Errorf("unfurl: fetch %s: %w", u.Host, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Preview{}, ErrNoPreview
+	}
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil || (mediaType != "text/html" && mediaType != "application/xhtml+xml") {
+		return Preview{}, ErrNoPreview
+	}
+
+	preview := parse(io.LimitReader(resp.Body, f.config.MaxBytes), resp.Request.URL)
+	if preview.Title == "" {
+		return Preview{}, ErrNoPreview
+	}
+	preview.URL = rawURL
+
+	return preview, nil
+}
+
+// parse reads the metadata in the head of a page, Open Graph first, then
+// Twitter cards and the plain title and description. base resolves a
+// relative image.
+func parse(r io.Reader, base *url.URL) Preview {
+	meta := map[string]string{}
+	title := ""
+
+	z := html.NewTokenizer(r)
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+
+		token := z.Token()
+		if tt == html.EndTagToken && token.Data == "head" || tt == html.StartTagToken && token.Data == "body" {
+			break
+		}
+		if tt != html.StartTagToken && tt != html.SelfClosingTagToken {
+			continue
+		}
+
+		switch token.Data {
+		case "title":
+			if z.Next() == html.TextToken && title == "" {
+				title = string(z.Text())
+			}
+		case "meta":
+			key, content := "", ""
+			for _, attr := range token.Attr {
+				switch attr.Key {
+				case "property", "name":
+					if key == "" {
+						key = strings.ToLower(strings.TrimSpace(attr.Val))
+					}
+				case "content":
+					content = attr.Val
+				}
+			}
+			if _, ok := meta[key]; key != "" && !ok {
+				meta[key] = content
+			}
+		}
+	}
+
+	first := func(values ...string) string {
+		for _, val := range values {
+			if val = strings.TrimSpace(val); val != "" {
+				return val
+			}
+		}
+		return ""
+	}
+
+	preview := Preview{
+		Title:       clip(first(meta["og:title"], meta["twitter:title"], title), maxTitleLength),
+		Description: clip(first(meta["og:description"], meta["twitter:description"], meta["description"]), maxDescription),
+		SiteName:    clip(first(meta["og:site_name"]), maxSiteNameLength),
+	}
+
+	if image := first(meta["og:image"], meta["og:image:url"], meta["twitter:image"], meta["twitter:image:src"]); image != "" {
+		if u, err := base.Parse(image); err == nil && (u.Scheme == "http" || u.Scheme == "https") && len(u.String()) <= maxURLLength {
+			preview.ImageURL = u.String()
+		}
+	}
+
+	return preview
+}
+
+// clip makes s valid UTF-8, collapses its whitespace and cuts it to max
+// runes
+func clip(s string, max int) string {
+	s = strings.Join(strings.Fields(strings.ToValidUTF8(s, "")), " ")
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	return string([]rune(s)[:max-1]) + "…"
+}
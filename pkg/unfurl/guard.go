@@ -0,0 +1,60 @@
+package unfurl
+
+import (
+	"net"
+	"syscall"
+)
+
+// blockedRanges are the non public ranges the net.IP predicates do not
+// cover
+var blockedRanges = func() []*net.IPNet {
+	ranges := []*net.IPNet{}
+	for _, cidr := range []string{
+		"0.0.0.0/8",       // this network
+		"100.64.0.0/10",   // carrier-grade NAT
+		"192.0.0.0/24",    // IETF protocol assignments
+		"192.0.2.0/24",    // documentation
+		"198.18.0.0/15",   // benchmarking
+		"198.51.100.0/24", // documentation
+		"203.0.113.0/24",  // documentation
+		"240.0.0.0/4",     // reserved
+		"64:ff9b::/96",    // NAT64, can reach private IPv4
+		"2001:db8::/32",   // documentation
+	} {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		ranges = append(ranges, ipNet)
+	}
+	return ranges
+}()
+
+// publicAddress reports whether ip is reachable on the public internet
+func publicAddress(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsMulticast() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() {
+		return false
+	}
+	for _, ipNet := range blockedRanges {
+		if ipNet.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// control runs after name resolution for every connection, redirects
+// included, so a host name that resolves to an internal address is
+// refused as well
+func (f *HTTPFetcher) control(network, address string, c syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return ErrBlockedAddress
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !f.allowed(ip) {
+		return ErrBlockedAddress
+	}
+	return nil
+}
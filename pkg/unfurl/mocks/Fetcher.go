@@ -0,0 +1,51 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	unfurl "macaiki/pkg/unfurl"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Fetcher is an autogenerated mock type for the Fetcher type
+type Fetcher struct {
+	mock.Mock
+}
+
+// Fetch provides a mock function with given fields: ctx, rawURL
+func (_m *Fetcher) Fetch(ctx context.Context, rawURL string) (unfurl.Preview, error) {
+	ret := _m.Called(ctx, rawURL)
+
+	var r0 unfurl.Preview
+	if rf, ok := ret.Get(0).(func(context.Context, string) unfurl.Preview); ok {
+		r0 = rf(ctx, rawURL)
+	} else {
+		r0 = ret.Get(0).(unfurl.Preview)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, rawURL)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewFetcher interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewFetcher creates a new instance of Fetcher. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewFetcher(t mockConstructorTestingTNewFetcher) *Fetcher {
+	mock := &Fetcher{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
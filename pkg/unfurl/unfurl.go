@@ -0,0 +1,51 @@
+// Package unfurl builds link previews from the Open Graph and Twitter card
+// metadata of a page. The HTTP fetcher only talks to public addresses, so
+// a link in user content cannot be used to probe the internal network.
+package unfurl
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var (
+	// ErrNoPreview is returned for a page that is not HTML, does not answer
+	// with 200 or has no title to show
+	ErrNoPreview = errors.New("unfurl: no preview")
+	// ErrBlockedAddress is returned when the link resolves to a loopback,
+	// private or otherwise non public address
+	ErrBlockedAddress = errors.New("unfurl: blocked address")
+	// ErrUnsupportedURL is returned for a link that is not http or https
+	ErrUnsupportedURL = errors.New("unfurl: unsupported url")
+)
+
+// Preview is what a client shows in place of a bare link, only Title is
+// always set
+type Preview struct {
+	URL         string
+	Title       string
+	Description string
+	ImageURL    string
+	SiteName    string
+}
+
+type Fetcher interface {
+	Fetch(ctx context.Context, rawURL string) (Preview, error)
+}
+
+// Permanent reports whether fetching the link again would fail the same
+// way, as opposed to a network error or timeout
+func Permanent(err error) bool {
+	return errors.Is(err, ErrNoPreview) || errors.Is(err, ErrBlockedAddress) || errors.Is(err, ErrUnsupportedURL)
+}
+
+var linkPattern = regexp.MustCompile(`https?://[^\s<>"'` + "`" + `]+`)
+
+// FirstURL returns the first http or https link in text, punctuation that
+// ends a sentence right after the link is not part of it
+func FirstURL(text string) string {
+	link := linkPattern.FindString(text)
+	return strings.TrimRight(link, ".,;:!?)]}*_")
+}
@@ -0,0 +1,205 @@
+package unfurl
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// localFetcher may dial the loopback test server
+func localFetcher(config Config) *HTTPFetcher {
+	f := NewFetcher(config)
+	f.allowed = func(ip net.IP) bool { return true }
+	return f
+}
+
+func serve(t *testing.T, contentType, body string) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestFetch(t *testing.T) {
+	t.Run("open-graph", func(t *testing.T) {
+		srv := serve(t, "text/html; charset=utf-8", `<html><head>
+			<title>Page title</title>
+			<meta property="og:title" content="OG title">
+			<meta property="og:description" content="  An   article ">
+			<meta property="og:image" content="/cover.png">
+			<meta property="og:site_name" content="Example">
+			<meta name="twitter:title" content="Twitter title">
+		</head><body><meta property="og:title" content="ignored"></body></html>`)
+
+		preview, err := localFetcher(Config{}).Fetch(context.Background(), srv.URL+"/post")
+
+		assert.NoError(t, err)
+		assert.Equal(t, Preview{
+			URL:         srv.URL + "/post",
+			Title:       "OG title",
+			Description: "An article",
+			ImageURL:    srv.URL + "/cover.png",
+			SiteName:    "Example",
+		}, preview)
+	})
+
+	t.Run("twitter-card-and-title", func(t *testing.T) {
+		srv := serve(t, "text/html", `<head><title>Page title</title>
+			<meta name="twitter:description" content="Card">
+			<meta name="twitter:image" content="javascript:alert(1)"></head>`)
+
+		preview, err := localFetcher(Config{}).Fetch(context.Background(), srv.URL)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Page title", preview.Title)
+		assert.Equal(t, "Card", preview.Description)
+		assert.Empty(t, preview.ImageURL)
+	})
+
+	t.Run("not-html", func(t *testing.T) {
+		srv := serve(t, "application/json", `{"title":"x"}`)
+
+		_, err := localFetcher(Config{}).Fetch(context.Background(), srv.URL)
+		assert.ErrorIs(t, err, ErrNoPreview)
+	})
+
+	t.Run("size-cap", func(t *testing.T) {
+		srv := serve(t, "text/html", "<head>"+strings.Repeat("<!-- padding -->", 100)+`<title>Too late</title></head>`)
+
+		_, err := localFetcher(Config{MaxBytes: 512}).Fetch(context.Background(), srv.URL)
+		assert.ErrorIs(t, err, ErrNoPreview)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-time.After(time.Second):
+			case <-r.Context().Done():
+			}
+		}))
+		defer srv.Close()
+
+		_, err := localFetcher(Config{Timeout: 50 * time.Millisecond}).Fetch(context.Background(), srv.URL)
+		assert.Error(t, err)
+		assert.False(t, Permanent(err))
+	})
+
+	t.Run("unsupported-url", func(t *testing.T) {
+		_, err := NewFetcher(Config{}).Fetch(context.Background(), "file:///etc/passwd")
+		assert.ErrorIs(t, err, ErrUnsupportedURL)
+	})
+}
+
+func TestFetchBlocksPrivateAddresses(t *testing.T) {
+	srv := serve(t, "text/html", `<title>Internal</title>`)
+
+	_, err := NewFetcher(Config{}).Fetch(context.Background(), srv.URL)
+	assert.ErrorIs(t, err, ErrBlockedAddress)
+
+	// a public page redirecting inside is refused on the second hop
+	redirect := httptest.NewServer(http.RedirectHandler(srv.URL, http.StatusFound))
+	defer redirect.Close()
+	f := NewFetcher(Config{})
+	dials := 0
+	f.allowed = func(ip net.IP) bool {
+		dials++
+		return dials == 1
+	}
+	_, err = f.Fetch(context.Background(), redirect.URL)
+	assert.Equal(t, 2, dials)
+	assert.ErrorIs(t, err, ErrBlockedAddress)
+}
+
+func TestPublicAddress(t *testing.T) {
+	for addr, public := range map[string]bool{
+		"93.184.216.34":    true,
+		"2606:4700::1111":  true,
+		"127.0.0.1":        false,
+		"10.1.2.3":         false,
+		"172.16.0.1":       false,
+		"192.168.1.1":      false,
+		"169.254.169.254":  false,
+		"100.64.0.1":       false,
+		"0.0.0.0":          false,
+		"::1":              false,
+		"fc00::1":          false,
+		"fe80::1":          false,
+		"::ffff:127.0.0.1": false,
+		"64:ff9b::a00:1":   false,
+	} {
+		assert.Equal(t, public, publicAddress(net.ParseIP(addr)), addr)
+	}
+}
+
+func TestFirstURL(t *testing.T) {
+	assert.Equal(t, "https://example.com/a?b=c", FirstURL("see https://example.com/a?b=c."))
+	assert.Equal(t, "http://example.com", FirstURL("(http://example.com) and https://other.com"))
+	assert.Empty(t, FirstURL("no links, just ftp://example.com"))
+}
+
+type countingFetcher struct {
+	calls int
+	err   error
+}
+
+func (c *countingFetcher) Fetch(ctx context.Context, rawURL string) (Preview, error) {
+	c.calls++
+	if c.err != nil {
+		return Preview{}, c.err
+	}
+	return Preview{URL: rawURL, Title: "Title"}, nil
+}
+
+func TestCache(t *testing.T) {
+	now := time.Now()
+
+	t.Run("hit-and-expiry", func(t *testing.T) {
+		fetcher := &countingFetcher{}
+		cache := NewCache(fetcher, time.Minute, 10)
+		cache.now = func() time.Time { return now }
+
+		cache.Fetch(context.Background(), "https://example.com")
+		preview, err := cache.Fetch(context.Background(), "https://example.com")
+		assert.NoError(t, err)
+		assert.Equal(t, "Title", preview.Title)
+		assert.Equal(t, 1, fetcher.calls)
+
+		cache.now = func() time.Time { return now.Add(2 * time.Minute) }
+		cache.Fetch(context.Background(), "https://example.com")
+		assert.Equal(t, 2, fetcher.calls)
+	})
+
+	t.Run("permanent-errors-only", func(t *testing.T) {
+		fetcher := &countingFetcher{err: ErrNoPreview}
+		cache := NewCache(fetcher, time.Minute, 10)
+
+		cache.Fetch(context.Background(), "https://example.com")
+		_, err := cache.Fetch(context.Background(), "https://example.com")
+		assert.ErrorIs(t, err, ErrNoPreview)
+		assert.Equal(t, 1, fetcher.calls)
+
+		fetcher.err = errors.New("connection reset")
+		cache.Fetch(context.Background(), "https://other.com")
+		cache.Fetch(context.Background(), "https://other.com")
+		assert.Equal(t, 3, fetcher.calls)
+	})
+
+	t.Run("size", func(t *testing.T) {
+		fetcher := &countingFetcher{}
+		cache := NewCache(fetcher, time.Minute, 2)
+
+		for _, link := range []string{"https://a.com", "https://b.com", "https://c.com"} {
+			cache.Fetch(context.Background(), link)
+		}
+		assert.Len(t, cache.entries, 2)
+	})
+}
@@ -3,6 +3,7 @@ package utils
 import (
 	"errors"
 	"net/http"
+	"time"
 )
 
 var (
@@ -34,10 +35,58 @@ var (
 	ErrUnauthorizedAccess = errors.New("unauthorized access")
 
 	ErrDuplicateEntry = errors.New("Duplicate entry")
+	// ErrServiceUnavailable will throw if a dependency the service relies on is down
+	ErrServiceUnavailable = errors.New("Service Unavailable")
+	// ErrOTPInvalid will throw if the submitted OTP does not match
+	ErrOTPInvalid = errors.New("OTP Not Valid")
+	// ErrOTPExpired will throw if the OTP is past its expiry
+	ErrOTPExpired = errors.New("OTP Is Expired")
+	// ErrOTPAttemptsExceeded will throw if the OTP was guessed wrong too many times
+	ErrOTPAttemptsExceeded = errors.New("Too many wrong attempts, please request a new OTP")
+	// ErrEmailAlreadyVerified will throw if an OTP is requested for a verified email
+	ErrEmailAlreadyVerified = errors.New("Email already verified")
+	// ErrEmailNotVerified will throw if an unverified account tries a restricted action
+	ErrEmailNotVerified = errors.New("Please verify your email first")
+	// ErrInvalidToken will throw if an emailed link token is unknown, used or expired
+	ErrInvalidToken = errors.New("Invalid or expired token")
+	// ErrTooManyRequests will throw if the client hit a rate limit or is locked out
+	ErrTooManyRequests = errors.New("Too many requests, please try again later")
+	// ErrExternalLoginFailed will throw if the identity provider rejected the sign in
+	ErrExternalLoginFailed = errors.New("Sign in with the identity provider failed")
+	// ErrIdentityAlreadyLinked will throw if an external account belongs to another user
+	ErrIdentityAlreadyLinked = errors.New("This account is already linked to another user")
+	// ErrLastLoginMethod will throw if unlinking would leave the user unable to sign in
+	ErrLastLoginMethod = errors.New("Set a password or link another account before unlinking this one")
+	// ErrTwoFactorAlreadyEnabled will throw if 2FA setup is started while it is on
+	ErrTwoFactorAlreadyEnabled = errors.New("Two-factor authentication is already enabled")
+	// ErrTwoFactorNotEnabled will throw if a 2FA action needs it to be on first
+	ErrTwoFactorNotEnabled = errors.New("Two-factor authentication is not enabled")
+	// ErrTwoFactorRequired will throw if a user tries to turn off mandatory 2FA
+	ErrTwoFactorRequired = errors.New("Two-factor authentication is required for this account")
 )
 
+// RetryAfterError wraps an error with how long the client should wait
+// before trying again, it is sent back in the Retry-After header
+type RetryAfterError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e *RetryAfterError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RetryAfterError) Unwrap() error {
+	return e.Err
+}
+
 // unfinished
 func GetStatusCode(err error) int {
+	var retryErr *RetryAfterError
+	if errors.As(err, &retryErr) {
+		return GetStatusCode(retryErr.Err)
+	}
+
 	switch err {
 	case ErrInternalServerError:
 		return http.StatusInternalServerError
@@ -63,6 +112,34 @@ func GetStatusCode(err error) int {
 		return http.StatusUnauthorized
 	case ErrUnauthorizedAccess:
 		return http.StatusUnauthorized
+	case ErrServiceUnavailable:
+		return http.StatusServiceUnavailable
+	case ErrTooManyRequests:
+		return http.StatusTooManyRequests
+	case ErrOTPInvalid:
+		return http.StatusBadRequest
+	case ErrOTPExpired:
+		return http.StatusBadRequest
+	case ErrOTPAttemptsExceeded:
+		return http.StatusTooManyRequests
+	case ErrEmailAlreadyVerified:
+		return http.StatusConflict
+	case ErrEmailNotVerified:
+		return http.StatusForbidden
+	case ErrInvalidToken:
+		return http.StatusBadRequest
+	case ErrExternalLoginFailed:
+		return http.StatusUnauthorized
+	case ErrIdentityAlreadyLinked:
+		return http.StatusConflict
+	case ErrLastLoginMethod:
+		return http.StatusConflict
+	case ErrTwoFactorAlreadyEnabled:
+		return http.StatusConflict
+	case ErrTwoFactorNotEnabled:
+		return http.StatusBadRequest
+	case ErrTwoFactorRequired:
+		return http.StatusForbidden
 	default:
 		return http.StatusOK
 	}